@@ -36,7 +36,10 @@ func runSQLScriptsSequentially(db *sqlx.DB) error {
 		"/app/internal/database/sql/02-constraints.sql",
 		"/app/internal/database/sql/03-inserts.sql",
 		"/app/internal/database/sql/trigger_accept.sql",
-		"/app/internal/database/sql/trigger_order.sql",
+		"/app/internal/database/sql/trigger_price.sql",
+		"/app/internal/database/sql/trigger_rating.sql",
+		"/app/internal/database/sql/trigger_stock.sql",
+		"/app/internal/database/sql/trigger_articule.sql",
 	}
 
 	for _, script := range scripts {
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type ReorderRuleRequest struct {
+	ReorderPoint    int `json:"reorder_point"`
+	ReorderQuantity int `json:"reorder_quantity" binding:"required"`
+}
+
+// SetReorderRuleHandler задает точку заказа товара
+// @Summary Задать точку заказа
+// @Description Задает точку заказа и количество для дозаказа товара (только для администраторов). Когда остаток товара вместе с вариантами опускается ниже точки заказа, создается уведомление
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID товара"
+// @Param request body ReorderRuleRequest true "Точка заказа"
+// @Success 200 {object} object "Точка заказа задана"
+// @Failure 400 {object} object "Неверные параметры"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Товар не найден"
+// @Failure 500 {object} object "Ошибка сервера при сохранении точки заказа"
+// @Router /api/v1/products/{id}/reorder [put]
+func (c *Controller) SetReorderRuleHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to set reorder rule")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	var input ReorderRuleRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = c.AlertService.SetRule(ctx, structs.ReorderRule{
+		IdProduct:       id,
+		ReorderPoint:    input.ReorderPoint,
+		ReorderQuantity: input.ReorderQuantity,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant set reorder rule: %v", err)
+		c.writeAlertError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Reorder rule set"})
+}
+
+// DeleteReorderRuleHandler удаляет точку заказа товара
+// @Summary Удалить точку заказа
+// @Description Отключает уведомления о низком остатке товара (только для администраторов)
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID товара"
+// @Success 200 {object} object "Точка заказа удалена"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Точка заказа не задана"
+// @Failure 500 {object} object "Ошибка сервера при удалении точки заказа"
+// @Router /api/v1/products/{id}/reorder [delete]
+func (c *Controller) DeleteReorderRuleHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to delete reorder rule")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	if err := c.AlertService.DeleteRule(ctx, id); err != nil {
+		log.Printf("[ERROR] Cant delete reorder rule: %v", err)
+		c.writeAlertError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Reorder rule deleted"})
+}
+
+// GetStockAlertsHandler получает уведомления о низком остатке
+// @Summary Получить уведомления о низком остатке
+// @Description Возвращает открытые уведомления о товарах с остатком ниже точки заказа, сначала новые (только для работников)
+// @Tags workers
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} structs.StockAlert "Уведомления"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении уведомлений"
+// @Router /api/v1/workers/me/alerts [get]
+func (c *Controller) GetStockAlertsHandler(ctx *gin.Context) {
+	good := c.VerifyW(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get stock alerts")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	as, err := c.AlertService.GetOpen(ctx)
+	if err != nil {
+		log.Printf("[ERROR] Cant get stock alerts: %v", err)
+		c.writeAlertError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, as)
+}
+
+// GetReorderReportHandler получает отчет для дозаказа
+// @Summary Получить отчет для дозаказа
+// @Description Возвращает товары с остатком ниже точки заказа, сгруппированные по брендам, с количеством для дозаказа (только для администраторов)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} structs.BrandReorder "Отчет"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при построении отчета"
+// @Router /api/v1/admin/reorder-report [get]
+func (c *Controller) GetReorderReportHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get reorder report")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	r, err := c.AlertService.ReorderReport(ctx)
+	if err != nil {
+		log.Printf("[ERROR] Cant get reorder report: %v", err)
+		c.writeAlertError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+func (c *Controller) writeAlertError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrProductNotFound),
+		errors.Is(err, structs.ErrReorderRuleNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidReorderRule):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
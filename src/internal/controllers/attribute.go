@@ -0,0 +1,620 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type IngredientRequest struct {
+	InciName string `json:"inci_name" binding:"required"`
+	Name     string `json:"name"`
+}
+
+type AttributeRequest struct {
+	Kind string `json:"kind" binding:"required"`
+	Code string `json:"code" binding:"required"`
+	Name string `json:"name" binding:"required"`
+}
+
+// ProductAttributesRequest lists ingredient ids in the INCI order of the
+// label and attribute ids of any kind.
+type ProductAttributesRequest struct {
+	Ingredients []string `json:"ingredients"`
+	Attributes  []string `json:"attributes"`
+	Spf         int      `json:"spf"`
+	Vegan       bool     `json:"vegan"`
+	CrueltyFree bool     `json:"cruelty_free"`
+}
+
+type AllergensRequest struct {
+	Ingredients []string `json:"ingredients"`
+}
+
+// ProductSearchItem is a found product with the ingredients from the
+// allergen profile of the current user.
+type ProductSearchItem struct {
+	structs.Product
+	Allergens []structs.Ingredient `json:"allergens,omitempty"`
+}
+
+// productFilterParams are the query parameters handled by SearchProductsHandler.
+var productFilterParams = []string{"ingredient", "exclude_ingredient",
+	structs.AttributeSkinType, structs.AttributeHairType, structs.AttributeFinish,
+	"vegan", "cruelty_free", "min_spf", "min_rating", "sort"}
+
+// CreateIngredientHandler создает ингредиент
+// @Summary Создать ингредиент
+// @Description Добавляет ингредиент в справочник INCI (только для администраторов)
+// @Tags attributes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body IngredientRequest true "Данные ингредиента"
+// @Success 201 {object} object "ID созданного ингредиента"
+// @Failure 400 {object} object "Неверный формат данных"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 409 {object} object "Ингредиент уже существует"
+// @Failure 500 {object} object "Ошибка сервера при создании ингредиента"
+// @Router /api/v1/ingredients [post]
+func (c *Controller) CreateIngredientHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to create ingredient")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var input IngredientRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := c.AttributeService.CreateIngredient(ctx, structs.Ingredient{InciName: input.InciName, Name: input.Name})
+	if err != nil {
+		log.Printf("[ERROR] Cant create ingredient: %v", err)
+		c.writeAttributeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// GetIngredientsHandler получает справочник ингредиентов
+// @Summary Получить ингредиенты
+// @Description Возвращает справочник INCI, отсортированный по названию
+// @Tags attributes
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} structs.Ingredient "Ингредиенты"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении ингредиентов"
+// @Router /api/v1/ingredients [get]
+func (c *Controller) GetIngredientsHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get ingredients")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	is, err := c.AttributeService.GetIngredients(ctx)
+	if err != nil {
+		log.Printf("[ERROR] Cant get ingredients: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, is)
+}
+
+// DeleteIngredientHandler удаляет ингредиент
+// @Summary Удалить ингредиент
+// @Description Удаляет ингредиент, не входящий в состав товаров (только для администраторов)
+// @Tags attributes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID ингредиента"
+// @Success 200 {object} object "Ингредиент удален"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Ингредиент не найден"
+// @Failure 409 {object} object "Ингредиент входит в состав товаров"
+// @Failure 500 {object} object "Ошибка сервера при удалении ингредиента"
+// @Router /api/v1/ingredients/{id} [delete]
+func (c *Controller) DeleteIngredientHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to delete ingredient")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse ingredient id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ingredient ID format"})
+		return
+	}
+
+	if err := c.AttributeService.DeleteIngredient(ctx, id); err != nil {
+		log.Printf("[ERROR] Cant delete ingredient: %v", err)
+		c.writeAttributeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Ingredient deleted"})
+}
+
+// CreateAttributeHandler создает значение справочника свойств
+// @Summary Создать свойство
+// @Description Добавляет значение в справочник типов кожи (skin_type), типов волос (hair_type) или финишей (finish) (только для администраторов)
+// @Tags attributes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body AttributeRequest true "Данные свойства"
+// @Success 201 {object} object "ID созданного свойства"
+// @Failure 400 {object} object "Неверный формат данных"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 409 {object} object "Свойство уже существует"
+// @Failure 500 {object} object "Ошибка сервера при создании свойства"
+// @Router /api/v1/attributes [post]
+func (c *Controller) CreateAttributeHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to create attribute")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var input AttributeRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := c.AttributeService.CreateAttribute(ctx, structs.Attribute{Kind: input.Kind, Code: input.Code, Name: input.Name})
+	if err != nil {
+		log.Printf("[ERROR] Cant create attribute: %v", err)
+		c.writeAttributeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// GetAttributesHandler получает справочник свойств
+// @Summary Получить свойства
+// @Description Возвращает справочник свойств одного вида или всех видов
+// @Tags attributes
+// @Produce json
+// @Security BearerAuth
+// @Param kind query string false "Вид свойства: skin_type, hair_type или finish"
+// @Success 200 {array} structs.Attribute "Свойства"
+// @Failure 400 {object} object "Неизвестный вид свойства"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении свойств"
+// @Router /api/v1/attributes [get]
+func (c *Controller) GetAttributesHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get attributes")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	as, err := c.AttributeService.GetAttributes(ctx, ctx.Query("kind"))
+	if err != nil {
+		log.Printf("[ERROR] Cant get attributes: %v", err)
+		c.writeAttributeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, as)
+}
+
+// DeleteAttributeHandler удаляет значение справочника свойств
+// @Summary Удалить свойство
+// @Description Удаляет свойство, не назначенное товарам (только для администраторов)
+// @Tags attributes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID свойства"
+// @Success 200 {object} object "Свойство удалено"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Свойство не найдено"
+// @Failure 409 {object} object "Свойство назначено товарам"
+// @Failure 500 {object} object "Ошибка сервера при удалении свойства"
+// @Router /api/v1/attributes/{id} [delete]
+func (c *Controller) DeleteAttributeHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to delete attribute")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse attribute id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attribute ID format"})
+		return
+	}
+
+	if err := c.AttributeService.DeleteAttribute(ctx, id); err != nil {
+		log.Printf("[ERROR] Cant delete attribute: %v", err)
+		c.writeAttributeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Attribute deleted"})
+}
+
+// GetProductAttributesHandler получает состав и свойства продукта
+// @Summary Получить состав продукта
+// @Description Возвращает ингредиенты в порядке INCI, свойства, SPF и признаки vegan и cruelty free
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Success 200 {object} structs.ProductAttributes "Состав продукта"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Продукт не найден"
+// @Failure 500 {object} object "Ошибка сервера при получении состава"
+// @Router /api/v1/products/{id}/attributes [get]
+func (c *Controller) GetProductAttributesHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get product attributes")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	a, err := c.AttributeService.GetProductAttributes(ctx, id)
+	if err != nil {
+		log.Printf("[ERROR] Cant get product attributes: %v", err)
+		c.writeAttributeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, a)
+}
+
+// SetProductAttributesHandler задает состав и свойства продукта
+// @Summary Задать состав продукта
+// @Description Заменяет ингредиенты (в порядке INCI), свойства, SPF и признаки vegan и cruelty free продукта (только для администраторов)
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Param request body ProductAttributesRequest true "Состав продукта"
+// @Success 200 {object} object "Состав сохранен"
+// @Failure 400 {object} object "Неверный формат данных, повторы или SPF вне 0..100"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Продукт, ингредиент или свойство не найдены"
+// @Failure 500 {object} object "Ошибка сервера при сохранении состава"
+// @Router /api/v1/products/{id}/attributes [put]
+func (c *Controller) SetProductAttributesHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to set product attributes")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	var input ProductAttributesRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ingredients, err := parseIds(input.Ingredients)
+	if err != nil {
+		log.Printf("[ERROR] Cant parse ingredient ids: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ingredient ID format"})
+		return
+	}
+	attributes, err := parseIds(input.Attributes)
+	if err != nil {
+		log.Printf("[ERROR] Cant parse attribute ids: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attribute ID format"})
+		return
+	}
+
+	a := structs.ProductAttributes{
+		IdProduct:   id,
+		Spf:         input.Spf,
+		Vegan:       input.Vegan,
+		CrueltyFree: input.CrueltyFree,
+	}
+	for _, v := range ingredients {
+		a.Ingredients = append(a.Ingredients, structs.Ingredient{Id: v})
+	}
+	for _, v := range attributes {
+		a.Attributes = append(a.Attributes, structs.Attribute{Id: v})
+	}
+
+	if err := c.AttributeService.SetProductAttributes(ctx, a); err != nil {
+		log.Printf("[ERROR] Cant set product attributes: %v", err)
+		c.writeAttributeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Product attributes saved"})
+}
+
+// GetAllergensHandler получает аллергены пользователя
+// @Summary Получить аллергены
+// @Description Возвращает ингредиенты из профиля аллергенов текущего пользователя
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} structs.Ingredient "Аллергены"
+// @Failure 400 {object} object "Неверный формат ID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении аллергенов"
+// @Router /api/v1/users/me/allergens [get]
+func (c *Controller) GetAllergensHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get allergens")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	is, err := c.AttributeService.GetAllergens(ctx, id)
+	if err != nil {
+		log.Printf("[ERROR] Cant get allergens: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, is)
+}
+
+// SetAllergensHandler задает аллергены пользователя
+// @Summary Задать аллергены
+// @Description Заменяет профиль аллергенов текущего пользователя. Товары с этими ингредиентами помечаются в корзине и поиске
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body AllergensRequest true "UUID ингредиентов"
+// @Success 200 {object} object "Профиль сохранен"
+// @Failure 400 {object} object "Неверный формат данных"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Ингредиент не найден"
+// @Failure 500 {object} object "Ошибка сервера при сохранении аллергенов"
+// @Router /api/v1/users/me/allergens [put]
+func (c *Controller) SetAllergensHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to set allergens")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var input AllergensRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ids, err := parseIds(input.Ingredients)
+	if err != nil {
+		log.Printf("[ERROR] Cant parse ingredient ids: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ingredient ID format"})
+		return
+	}
+
+	if err := c.AttributeService.SetAllergens(ctx, id, ids); err != nil {
+		log.Printf("[ERROR] Cant set allergens: %v", err)
+		c.writeAttributeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Allergens saved"})
+}
+
+// SearchProductsHandler searches the catalog by category, brand, ingredients,
+// attributes and rating. List parameters accept repeated and comma separated
+// values.
+func (c *Controller) SearchProductsHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to search products")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id_user, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	f := structs.ProductFilter{
+		Brand:              ctx.Query("brand"),
+		Attributes:         make(map[string][]string),
+		IncludeIngredients: queryList(ctx, "ingredient"),
+		ExcludeIngredients: queryList(ctx, "exclude_ingredient"),
+		Vegan:              ctx.Query("vegan") == "true",
+		CrueltyFree:        ctx.Query("cruelty_free") == "true",
+	}
+	for _, kind := range structs.AttributeKinds {
+		if codes := queryList(ctx, kind); len(codes) > 0 {
+			f.Attributes[kind] = codes
+		}
+	}
+	if spf := ctx.Query("min_spf"); spf != "" {
+		f.MinSpf, err = strconv.Atoi(spf)
+		if err != nil {
+			log.Printf("[ERROR] Cant parse min_spf: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_spf"})
+			return
+		}
+	}
+	if rating := ctx.Query("min_rating"); rating != "" {
+		f.MinRating, err = strconv.ParseFloat(rating, 64)
+		if err != nil {
+			log.Printf("[ERROR] Cant parse min_rating: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_rating"})
+			return
+		}
+	}
+	f.Sort = ctx.Query("sort")
+	if category := ctx.Query("category"); category != "" {
+		f.IdCategory, err = uuid.Parse(category)
+		if err != nil {
+			cat, err := c.CategoryService.GetBySlug(ctx, category)
+			if err != nil {
+				log.Printf("[ERROR] Cant get product category: %v", err)
+				c.writeCategoryError(ctx, err)
+				return
+			}
+			f.IdCategory = cat.Id
+		}
+	}
+
+	products, err := c.ProductService.Search(ctx, f)
+	if err != nil {
+		log.Printf("[ERROR] Cant search products: %v", err)
+		c.writeAttributeError(ctx, err)
+		return
+	}
+
+	ids := make([]uuid.UUID, len(products))
+	for i, p := range products {
+		ids[i] = p.Id
+	}
+	allergens, err := c.AttributeService.FindAllergens(ctx, id_user, ids)
+	if err != nil {
+		log.Printf("[ERROR] Cant find allergens: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	res := make([]ProductSearchItem, len(products))
+	for i, p := range products {
+		res[i] = ProductSearchItem{Product: p, Allergens: allergens[p.Id]}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func hasProductFilter(ctx *gin.Context) bool {
+	for _, p := range productFilterParams {
+		if _, ok := ctx.GetQuery(p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func queryList(ctx *gin.Context, key string) []string {
+	var res []string
+	for _, v := range ctx.QueryArray(key) {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				res = append(res, s)
+			}
+		}
+	}
+	return res
+}
+
+func parseIds(ss []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(ss))
+	for i, s := range ss {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func (c *Controller) writeAttributeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrProductNotFound),
+		errors.Is(err, structs.ErrIngredientNotFound),
+		errors.Is(err, structs.ErrAttributeNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrDuplicateIngredient),
+		errors.Is(err, structs.ErrDuplicateAttribute),
+		errors.Is(err, structs.ErrDictionaryEntryInUse):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidIngredient),
+		errors.Is(err, structs.ErrInvalidAttribute),
+		errors.Is(err, structs.ErrInvalidProductAttributes),
+		errors.Is(err, structs.ErrInvalidSort):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -219,3 +219,26 @@ func (c *Controller) VerifyW(ctx *gin.Context) bool {
 	good := c.AuthServise.CheckWorker(context.Background(), id)
 	return good
 }
+
+func (c *Controller) VerifyWA(ctx *gin.Context) bool {
+	if good := c.Verify(ctx); !good {
+		return false
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return false
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return false
+	}
+
+	good := c.AuthServise.CheckWorker(context.Background(), id) || c.AuthServise.CheckAdmin(context.Background(), id)
+	return good
+}
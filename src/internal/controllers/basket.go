@@ -15,21 +15,30 @@ import (
 
 type BasketItemRequest struct {
 	ProductID uuid.UUID `json:"product_id" binding:"required"`
+	VariantID uuid.UUID `json:"variant_id"`
 	Amount    int       `json:"amount" binding:"required,min=1"`
 }
 
 type BasketItemDeleteRequest struct {
 	ProductID uuid.UUID `json:"product_id" binding:"required"`
+	VariantID uuid.UUID `json:"variant_id"`
+}
+
+// BasketItemResponse is a basket item with the ingredients of its product
+// from the allergen profile of the user.
+type BasketItemResponse struct {
+	structs.BasketItem
+	Allergens []structs.Ingredient `json:"allergens,omitempty"`
 }
 
 // GetBasketItemsHandler получает все товары в корзине пользователя
 // @Summary Получить товары корзины
-// @Description Возвращает список всех товаров в корзине текущего пользователя
+// @Description Возвращает список всех товаров в корзине текущего пользователя. Товары с ингредиентами из профиля аллергенов содержат поле allergens
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} object "Список товаров в корзине"
+// @Success 200 {array} BasketItemResponse "Список товаров в корзине"
 // @Failure 400 {object} object "Неверный формат ID"
 // @Failure 401 {object} object "Неавторизованный доступ"
 // @Failure 500 {object} object "Ошибка сервера при получении товаров"
@@ -63,7 +72,22 @@ func (c *Controller) GetBasketItemsHandler(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, items)
+	ids := make([]uuid.UUID, len(items))
+	for i, v := range items {
+		ids[i] = v.IdProduct
+	}
+	allergens, err := c.AttributeService.FindAllergens(ctx.Request.Context(), id, ids)
+	if err != nil {
+		log.Printf("[ERROR] Cant find allergens: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get items"})
+		return
+	}
+
+	res := make([]BasketItemResponse, len(items))
+	for i, v := range items {
+		res[i] = BasketItemResponse{BasketItem: v, Allergens: allergens[v.IdProduct]}
+	}
+	ctx.JSON(http.StatusOK, res)
 }
 
 // GetBasketByIdHandler получает корзину по ID пользователя
@@ -113,7 +137,7 @@ func (c *Controller) GetBasketByIdHandler(ctx *gin.Context) {
 
 // AddBasketItemHandler добавляет товар в корзину
 // @Summary Добавить товар в корзину
-// @Description Добавляет товар с указанным количеством в корзину пользователя
+// @Description Добавляет товар с указанным количеством в корзину пользователя. Для товаров с вариантами (оттенок, объем) передается variant_id
 // @Tags users
 // @Accept json
 // @Produce json
@@ -158,6 +182,7 @@ func (c *Controller) AddBasketItemHandler(ctx *gin.Context) {
 
 	item := structs.BasketItem{
 		IdProduct: input.ProductID,
+		IdVariant: input.VariantID,
 		Amount:    input.Amount,
 	}
 
@@ -179,7 +204,7 @@ func (c *Controller) AddBasketItemHandler(ctx *gin.Context) {
 
 // DeleteBasketItemHandler удаляет товар из корзины
 // @Summary Удалить товар из корзины
-// @Description Удаляет указанный товар из корзины пользователя
+// @Description Удаляет указанный товар из корзины пользователя, для товаров с вариантами — конкретный вариант по variant_id
 // @Tags users
 // @Accept json
 // @Produce json
@@ -221,7 +246,7 @@ func (c *Controller) DeleteBasketItemHandler(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.BasketService.DeleteItem(ctx.Request.Context(), id, input.ProductID); err != nil {
+	if err := c.BasketService.DeleteItem(ctx.Request.Context(), id, input.ProductID, input.VariantID); err != nil {
 		log.Printf("[ERROR] Cant delete item from basket: %v", err)
 
 		if errors.Is(err, sql.ErrNoRows) ||
@@ -239,7 +264,7 @@ func (c *Controller) DeleteBasketItemHandler(ctx *gin.Context) {
 
 // UpdateBasketItemAmountHandler обновляет количество товара в корзине
 // @Summary Обновить количество товара
-// @Description Обновляет количество указанного товара в корзине пользователя
+// @Description Обновляет количество указанного товара в корзине пользователя, для товаров с вариантами — конкретного варианта по variant_id
 // @Tags users
 // @Accept json
 // @Produce json
@@ -281,7 +306,7 @@ func (c *Controller) UpdateBasketItemAmountHandler(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.BasketService.UpdateItemAmount(ctx.Request.Context(), id, input.ProductID, input.Amount); err != nil {
+	if err := c.BasketService.UpdateItemAmount(ctx.Request.Context(), id, input.ProductID, input.VariantID, input.Amount); err != nil {
 		log.Printf("[ERROR] Cant update item in basket: %v", err)
 		if errors.Is(err, sql.ErrNoRows) ||
 			strings.Contains(strings.ToLower(err.Error()), "not found") {
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taucuya/ppo/internal/core/service/catalog"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+const maxCatalogImportSize = 32 << 20
+
+// ImportCatalogHandler импортирует каталог из файла
+// @Summary Импорт каталога
+// @Description Загружает товары и бренды из CSV или JSON Lines по артикулу в одной транзакции (только для администраторов). При dry_run=true изменения не применяются, возвращается построчный отчет
+// @Tags admin
+// @Accept text/csv,application/x-ndjson,multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "Формат файла, по умолчанию определяется по Content-Type или расширению" Enums(csv, jsonl)
+// @Param dry_run query bool false "Только проверить файл без записи в базу"
+// @Param file formData file false "Файл каталога (для multipart/form-data)"
+// @Success 200 {object} structs.CatalogImportReport "Отчет об импорте"
+// @Failure 400 {object} object "Неверный формат файла"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 413 {object} object "Файл слишком большой"
+// @Failure 422 {object} structs.CatalogImportReport "Файл содержит некорректные строки, изменения не применены"
+// @Failure 500 {object} object "Ошибка сервера при импорте"
+// @Router /api/v1/admin/catalog/import [post]
+func (c *Controller) ImportCatalogHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to import catalog")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	dryRun := false
+	if v := ctx.Query("dry_run"); v != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(v)
+		if err != nil {
+			log.Printf("[ERROR] Cant parse dry_run: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dry_run value"})
+			return
+		}
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxCatalogImportSize)
+
+	format := ctx.Query("format")
+	var body io.Reader = ctx.Request.Body
+	if strings.HasPrefix(ctx.ContentType(), "multipart/form-data") {
+		fh, err := ctx.FormFile("file")
+		if err != nil {
+			log.Printf("[ERROR] Cant get import file: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "File field is required"})
+			return
+		}
+		f, err := fh.Open()
+		if err != nil {
+			log.Printf("[ERROR] Cant open import file: %v", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		defer f.Close()
+		body = f
+		if format == "" {
+			format = catalogFormatByName(fh.Filename)
+		}
+	}
+	if format == "" {
+		format = catalogFormatByType(ctx.ContentType())
+	}
+
+	rows, err := catalog.ParseImport(format, body)
+	if err != nil {
+		log.Printf("[ERROR] Cant parse catalog import: %v", err)
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Import file is too large"})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.CatalogService.Import(ctx.Request.Context(), rows, dryRun)
+	if err != nil {
+		log.Printf("[ERROR] Cant import catalog: %v", err)
+		if errors.Is(err, structs.ErrCatalogImportInvalid) {
+			ctx.JSON(http.StatusUnprocessableEntity, report)
+			return
+		}
+		if errors.Is(err, structs.ErrEmptyImport) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// ExportCatalogHandler выгружает каталог
+// @Summary Экспорт каталога
+// @Description Выгружает все товары, по предложению на каждый вариант, в CSV, JSON Lines или YML (Яндекс.Маркет) потоком (только для администраторов)
+// @Tags admin
+// @Produce text/csv,application/x-ndjson,application/xml
+// @Security BearerAuth
+// @Param format query string true "Формат выгрузки" Enums(csv, jsonl, yml)
+// @Success 200 {file} file "Файл каталога"
+// @Failure 400 {object} object "Неподдерживаемый формат"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при экспорте"
+// @Router /api/v1/admin/catalog/export [get]
+func (c *Controller) ExportCatalogHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to export catalog")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	format := ctx.Query("format")
+	contentType, ok := catalogExportTypes[format]
+	if !ok {
+		log.Printf("[ERROR] Unsupported export format: %q", format)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": structs.ErrUnsupportedFormat.Error()})
+		return
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="catalog.%s"`, format))
+	ctx.Status(http.StatusOK)
+
+	// Headers are already sent at this point, so a failure in the middle of
+	// the stream can only be logged.
+	err := c.CatalogService.Export(ctx.Request.Context(), format, ctx.Writer)
+	if err != nil {
+		log.Printf("[ERROR] Cant export catalog: %v", err)
+	}
+}
+
+var catalogExportTypes = map[string]string{
+	structs.CatalogFormatCSV:   "text/csv; charset=utf-8",
+	structs.CatalogFormatJSONL: "application/x-ndjson; charset=utf-8",
+	structs.CatalogFormatYML:   "application/xml; charset=utf-8",
+}
+
+func catalogFormatByType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch mt {
+	case "text/csv", "application/csv":
+		return structs.CatalogFormatCSV
+	case "application/x-ndjson", "application/jsonl", "application/json":
+		return structs.CatalogFormatJSONL
+	}
+	return ""
+}
+
+func catalogFormatByName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return structs.CatalogFormatCSV
+	case ".jsonl", ".ndjson":
+		return structs.CatalogFormatJSONL
+	}
+	return ""
+}
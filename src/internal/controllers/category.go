@@ -0,0 +1,244 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type CategoryRequest struct {
+	IdParent  string `json:"id_parent"`
+	Name      string `json:"name" binding:"required"`
+	Slug      string `json:"slug" binding:"required"`
+	SortOrder int    `json:"sort_order"`
+}
+
+// CreateCategoryHandler создает категорию
+// @Summary Создать категорию
+// @Description Создает категорию каталога, корневую или вложенную в id_parent (только для администраторов). Slug состоит из строчных латинских букв, цифр и дефисов
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CategoryRequest true "Данные категории"
+// @Success 201 {object} object "ID созданной категории"
+// @Failure 400 {object} object "Неверный формат данных"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Родительская категория не найдена"
+// @Failure 409 {object} object "Slug уже занят"
+// @Failure 500 {object} object "Ошибка сервера при создании категории"
+// @Router /api/v1/categories [post]
+func (c *Controller) CreateCategoryHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to create category")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	cat, ok := bindCategory(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := c.CategoryService.Create(ctx, cat)
+	if err != nil {
+		log.Printf("[ERROR] Cant create category: %v", err)
+		c.writeCategoryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// GetCategoriesHandler получает дерево категорий
+// @Summary Получить дерево категорий
+// @Description Возвращает корневые категории с вложенными подкатегориями, отсортированные по sort_order и названию
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} structs.Category "Дерево категорий"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении категорий"
+// @Router /api/v1/categories [get]
+func (c *Controller) GetCategoriesHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get categories")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	tree, err := c.CategoryService.GetTree(ctx)
+	if err != nil {
+		log.Printf("[ERROR] Cant get categories: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tree)
+}
+
+// GetCategoryHandler получает категорию
+// @Summary Получить категорию
+// @Description Возвращает категорию по UUID или slug
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID или slug категории"
+// @Success 200 {object} structs.Category "Данные категории"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Категория не найдена"
+// @Failure 500 {object} object "Ошибка сервера при получении категории"
+// @Router /api/v1/categories/{id} [get]
+func (c *Controller) GetCategoryHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get category")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var cat structs.Category
+	var err error
+	if id, perr := uuid.Parse(ctx.Param("id")); perr == nil {
+		cat, err = c.CategoryService.GetById(ctx, id)
+	} else {
+		cat, err = c.CategoryService.GetBySlug(ctx, ctx.Param("id"))
+	}
+	if err != nil {
+		log.Printf("[ERROR] Cant get category: %v", err)
+		c.writeCategoryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cat)
+}
+
+// UpdateCategoryHandler изменяет категорию
+// @Summary Изменить категорию
+// @Description Изменяет название, slug, порядок сортировки и родителя категории (только для администраторов). Категорию нельзя перенести в нее саму или в ее потомка
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID категории"
+// @Param request body CategoryRequest true "Данные категории"
+// @Success 200 {object} object "Категория изменена"
+// @Failure 400 {object} object "Неверный формат данных или цикл в дереве"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Категория не найдена"
+// @Failure 409 {object} object "Slug уже занят"
+// @Failure 500 {object} object "Ошибка сервера при изменении категории"
+// @Router /api/v1/categories/{id} [put]
+func (c *Controller) UpdateCategoryHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to update category")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse category id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID format"})
+		return
+	}
+
+	cat, ok := bindCategory(ctx)
+	if !ok {
+		return
+	}
+	cat.Id = id
+
+	if err := c.CategoryService.Update(ctx, cat); err != nil {
+		log.Printf("[ERROR] Cant update category: %v", err)
+		c.writeCategoryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Category updated"})
+}
+
+// DeleteCategoryHandler удаляет категорию
+// @Summary Удалить категорию
+// @Description Удаляет категорию без подкатегорий и товаров (только для администраторов)
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID категории"
+// @Success 200 {object} object "Категория удалена"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Категория не найдена"
+// @Failure 409 {object} object "У категории есть подкатегории или товары"
+// @Failure 500 {object} object "Ошибка сервера при удалении категории"
+// @Router /api/v1/categories/{id} [delete]
+func (c *Controller) DeleteCategoryHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to delete category")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse category id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID format"})
+		return
+	}
+
+	if err := c.CategoryService.Delete(ctx, id); err != nil {
+		log.Printf("[ERROR] Cant delete category: %v", err)
+		c.writeCategoryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Category deleted"})
+}
+
+func bindCategory(ctx *gin.Context) (structs.Category, bool) {
+	var input CategoryRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return structs.Category{}, false
+	}
+
+	cat := structs.Category{
+		Name:      input.Name,
+		Slug:      input.Slug,
+		SortOrder: input.SortOrder,
+	}
+	if input.IdParent != "" {
+		id, err := uuid.Parse(input.IdParent)
+		if err != nil {
+			log.Printf("[ERROR] Cant parse parent category id: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parent category ID format"})
+			return structs.Category{}, false
+		}
+		cat.IdParent = id
+	}
+	return cat, true
+}
+
+func (c *Controller) writeCategoryError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrCategoryNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+	case errors.Is(err, structs.ErrDuplicateSlug),
+		errors.Is(err, structs.ErrCategoryInUse):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidCategory),
+		errors.Is(err, structs.ErrCategoryCycle):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
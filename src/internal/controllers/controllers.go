@@ -1,27 +1,57 @@
 package controller
 
 import (
+	"github.com/taucuya/ppo/internal/core/service/alert"
+	"github.com/taucuya/ppo/internal/core/service/attribute"
 	"github.com/taucuya/ppo/internal/core/service/auth"
 	"github.com/taucuya/ppo/internal/core/service/basket"
 	"github.com/taucuya/ppo/internal/core/service/brand"
+	"github.com/taucuya/ppo/internal/core/service/catalog"
+	"github.com/taucuya/ppo/internal/core/service/category"
+	"github.com/taucuya/ppo/internal/core/service/delivery"
 	"github.com/taucuya/ppo/internal/core/service/favourites"
+	"github.com/taucuya/ppo/internal/core/service/idempotency"
+	"github.com/taucuya/ppo/internal/core/service/media"
 	"github.com/taucuya/ppo/internal/core/service/order"
+	"github.com/taucuya/ppo/internal/core/service/payment"
+	"github.com/taucuya/ppo/internal/core/service/price"
 	"github.com/taucuya/ppo/internal/core/service/product"
+	"github.com/taucuya/ppo/internal/core/service/promotion"
+	"github.com/taucuya/ppo/internal/core/service/purchase"
+	"github.com/taucuya/ppo/internal/core/service/recommendation"
+	"github.com/taucuya/ppo/internal/core/service/reservation"
+	"github.com/taucuya/ppo/internal/core/service/returns"
 	"github.com/taucuya/ppo/internal/core/service/review"
+	"github.com/taucuya/ppo/internal/core/service/stock"
 	"github.com/taucuya/ppo/internal/core/service/user"
 	"github.com/taucuya/ppo/internal/core/service/worker"
 )
 
 type Controller struct {
-	AuthServise       auth.Service
-	BasketService     basket.Service
-	BrandService      brand.Service
-	FavouritesService favourites.Service
-	OrderService      order.Service
-	ProductService    product.Service
-	ReviewService     review.Service
-	UserService       user.Service
-	WorkerService     worker.Service
+	AlertService          alert.Service
+	AttributeService      attribute.Service
+	AuthServise           auth.Service
+	BasketService         basket.Service
+	BrandService          brand.Service
+	CatalogService        catalog.Service
+	CategoryService       category.Service
+	DeliveryService       delivery.Service
+	FavouritesService     favourites.Service
+	IdempotencyService    idempotency.Service
+	MediaService          media.Service
+	OrderService          order.Service
+	PaymentService        payment.Service
+	PriceService          price.Service
+	ProductService        product.Service
+	PromotionService      promotion.Service
+	PurchaseService       purchase.Service
+	RecommendationService recommendation.Service
+	ReservationService    reservation.Service
+	ReturnService         returns.Service
+	ReviewService         review.Service
+	StockService          stock.Service
+	UserService           user.Service
+	WorkerService         worker.Service
 }
 
 func New(a auth.Service, ba basket.Service,
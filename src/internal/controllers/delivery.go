@@ -0,0 +1,388 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type DeliveryRateRequest struct {
+	Zone      string  `json:"zone" binding:"required"`
+	MaxWeight int     `json:"max_weight"`
+	MinTotal  float64 `json:"min_total"`
+	Cost      float64 `json:"cost"`
+	PerKg     float64 `json:"per_kg"`
+}
+
+type CreateDeliveryRequest struct {
+	Kind     string                `json:"kind" binding:"required"`
+	Name     string                `json:"name" binding:"required"`
+	FreeFrom float64               `json:"free_from"`
+	Rates    []DeliveryRateRequest `json:"rates" binding:"required,dive"`
+}
+
+type SetDeliveryRatesRequest struct {
+	Rates []DeliveryRateRequest `json:"rates" binding:"required,dive"`
+}
+
+type CreateDeliverySlotRequest struct {
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Capacity int       `json:"capacity" binding:"required"`
+}
+
+// GetDeliveryMethodsHandler получает способы доставки
+// @Summary Получить способы доставки
+// @Description Возвращает доступные способы доставки с тарифами. Тариф применяется к заказу в зоне zone весом до max_weight граммов (0 — без ограничения) и суммой от min_total; стоимость — cost плюс per_kg за каждый начатый килограмм. Из подходящих тарифов берется самый дешевый, заказы от free_from доставляются бесплатно
+// @Tags delivery
+// @Produce json
+// @Success 200 {array} structs.DeliveryMethod "Способы доставки"
+// @Failure 500 {object} object "Ошибка сервера при получении способов доставки"
+// @Router /api/v1/delivery [get]
+func (c *Controller) GetDeliveryMethodsHandler(ctx *gin.Context) {
+	ms, err := c.DeliveryService.GetActive(ctx)
+	if err != nil {
+		log.Printf("[ERROR] Cant get delivery methods: %v", err)
+		c.writeDeliveryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ms)
+}
+
+// GetDeliverySlotsHandler получает слоты доставки
+// @Summary Получить слоты доставки
+// @Description Возвращает слоты курьерской доставки, начинающиеся в интервале [from, to), с числом занятых мест. По умолчанию — ближайшие две недели, интервал не больше месяца
+// @Tags delivery
+// @Produce json
+// @Param id path string true "UUID способа доставки"
+// @Param from query string false "Начало интервала (RFC3339)"
+// @Param to query string false "Конец интервала (RFC3339)"
+// @Success 200 {array} structs.DeliverySlot "Слоты доставки"
+// @Failure 400 {object} object "Неверный формат UUID или интервала"
+// @Failure 404 {object} object "Способ доставки не найден"
+// @Failure 500 {object} object "Ошибка сервера при получении слотов"
+// @Router /api/v1/delivery/{id}/slots [get]
+func (c *Controller) GetDeliverySlotsHandler(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse delivery id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID format"})
+		return
+	}
+
+	var from, to time.Time
+	if v := ctx.Query("from"); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			log.Printf("[ERROR] Cant parse from: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from format"})
+			return
+		}
+	}
+	if v := ctx.Query("to"); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			log.Printf("[ERROR] Cant parse to: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to format"})
+			return
+		}
+	}
+
+	ss, err := c.DeliveryService.GetSlots(ctx, id, from, to)
+	if err != nil {
+		log.Printf("[ERROR] Cant get delivery slots: %v", err)
+		c.writeDeliveryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ss)
+}
+
+// CreateDeliveryMethodHandler создает способ доставки
+// @Summary Создать способ доставки
+// @Description Создает способ доставки (только для администраторов). kind: courier — курьер по слотам, pickup — пункт выдачи, post — почта. Нужен хотя бы один тариф, зоны не зависят от регистра
+// @Tags delivery
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateDeliveryRequest true "Параметры способа доставки"
+// @Success 201 {object} object "ID способа доставки"
+// @Failure 400 {object} object "Неверные параметры способа доставки"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при создании способа доставки"
+// @Router /api/v1/admin/delivery [post]
+func (c *Controller) CreateDeliveryMethodHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to create delivery method")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var input CreateDeliveryRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := c.DeliveryService.Create(ctx, structs.DeliveryMethod{
+		Kind:     structs.DeliveryKind(input.Kind),
+		Name:     input.Name,
+		FreeFrom: input.FreeFrom,
+		Rates:    toDeliveryRates(input.Rates),
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant create delivery method: %v", err)
+		c.writeDeliveryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// GetAllDeliveryMethodsHandler получает все способы доставки
+// @Summary Получить все способы доставки
+// @Description Возвращает все способы доставки, сначала действующие (только для администраторов)
+// @Tags delivery
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} structs.DeliveryMethod "Способы доставки"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении способов доставки"
+// @Router /api/v1/admin/delivery [get]
+func (c *Controller) GetAllDeliveryMethodsHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get delivery methods")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ms, err := c.DeliveryService.GetAll(ctx)
+	if err != nil {
+		log.Printf("[ERROR] Cant get delivery methods: %v", err)
+		c.writeDeliveryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ms)
+}
+
+// SetDeliveryRatesHandler заменяет тарифы способа доставки
+// @Summary Заменить тарифы доставки
+// @Description Заменяет все тарифы способа доставки (только для администраторов). Стоимость доставки оформленных заказов не меняется
+// @Tags delivery
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID способа доставки"
+// @Param request body SetDeliveryRatesRequest true "Тарифы"
+// @Success 200 {object} object "Тарифы заменены"
+// @Failure 400 {object} object "Неверный формат UUID или тарифов"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Способ доставки не найден"
+// @Failure 500 {object} object "Ошибка сервера при замене тарифов"
+// @Router /api/v1/admin/delivery/{id}/rates [put]
+func (c *Controller) SetDeliveryRatesHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to set delivery rates")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse delivery id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID format"})
+		return
+	}
+
+	var input SetDeliveryRatesRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.DeliveryService.SetRates(ctx, id, toDeliveryRates(input.Rates)); err != nil {
+		log.Printf("[ERROR] Cant set delivery rates: %v", err)
+		c.writeDeliveryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Delivery rates updated"})
+}
+
+// DeactivateDeliveryMethodHandler отключает способ доставки
+// @Summary Отключить способ доставки
+// @Description Отключает способ доставки (только для администраторов). Способы доставки не удаляются, так как заказы ссылаются на них
+// @Tags delivery
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID способа доставки"
+// @Success 200 {object} object "Способ доставки отключен"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Способ доставки не найден"
+// @Failure 500 {object} object "Ошибка сервера при отключении способа доставки"
+// @Router /api/v1/admin/delivery/{id} [delete]
+func (c *Controller) DeactivateDeliveryMethodHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to deactivate delivery method")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse delivery id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID format"})
+		return
+	}
+
+	if err := c.DeliveryService.Deactivate(ctx, id); err != nil {
+		log.Printf("[ERROR] Cant deactivate delivery method: %v", err)
+		c.writeDeliveryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Delivery method deactivated"})
+}
+
+// CreateDeliverySlotHandler создает слот доставки
+// @Summary Создать слот доставки
+// @Description Добавляет будущий слот курьерской доставки на capacity заказов (только для администраторов)
+// @Tags delivery
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID способа доставки"
+// @Param request body CreateDeliverySlotRequest true "Параметры слота"
+// @Success 201 {object} object "ID слота"
+// @Failure 400 {object} object "Неверные параметры слота или способ доставки без слотов"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Способ доставки не найден"
+// @Failure 500 {object} object "Ошибка сервера при создании слота"
+// @Router /api/v1/admin/delivery/{id}/slots [post]
+func (c *Controller) CreateDeliverySlotHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to create delivery slot")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse delivery id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID format"})
+		return
+	}
+
+	var input CreateDeliverySlotRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id_slot, err := c.DeliveryService.CreateSlot(ctx, structs.DeliverySlot{
+		IdDelivery: id,
+		StartsAt:   input.StartsAt,
+		EndsAt:     input.EndsAt,
+		Capacity:   input.Capacity,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant create delivery slot: %v", err)
+		c.writeDeliveryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": id_slot})
+}
+
+// DeleteDeliverySlotHandler удаляет слот доставки
+// @Summary Удалить слот доставки
+// @Description Удаляет слот, в котором не оформлено ни одного заказа (только для администраторов)
+// @Tags delivery
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID способа доставки"
+// @Param id_slot path string true "UUID слота"
+// @Success 200 {object} object "Слот удален"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Слот не найден"
+// @Failure 409 {object} object "В слоте есть заказы"
+// @Failure 500 {object} object "Ошибка сервера при удалении слота"
+// @Router /api/v1/admin/delivery/{id}/slots/{id_slot} [delete]
+func (c *Controller) DeleteDeliverySlotHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to delete delivery slot")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse delivery id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID format"})
+		return
+	}
+
+	id_slot, err := uuid.Parse(ctx.Param("id_slot"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse slot id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid slot ID format"})
+		return
+	}
+
+	if err := c.DeliveryService.DeleteSlot(ctx, id, id_slot); err != nil {
+		log.Printf("[ERROR] Cant delete delivery slot: %v", err)
+		c.writeDeliveryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Delivery slot deleted"})
+}
+
+func toDeliveryRates(rs []DeliveryRateRequest) []structs.DeliveryRate {
+	res := make([]structs.DeliveryRate, len(rs))
+	for i, r := range rs {
+		res[i] = structs.DeliveryRate{
+			Zone:      r.Zone,
+			MaxWeight: r.MaxWeight,
+			MinTotal:  r.MinTotal,
+			Cost:      r.Cost,
+			PerKg:     r.PerKg,
+		}
+	}
+	return res
+}
+
+func (c *Controller) writeDeliveryError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrDeliveryNotFound),
+		errors.Is(err, structs.ErrSlotNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrSlotFull),
+		errors.Is(err, structs.ErrSlotClosed),
+		errors.Is(err, structs.ErrSlotInUse):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidDelivery),
+		errors.Is(err, structs.ErrDeliveryUnavailable),
+		errors.Is(err, structs.ErrInvalidSlot),
+		errors.Is(err, structs.ErrSlotRequired):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
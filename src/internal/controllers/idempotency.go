@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+const idempotencyHeader = "Idempotency-Key"
+
+// recordingWriter keeps a copy of the response body for the idempotency
+// record.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotent makes a POST safe to retry. A request with an Idempotency-Key
+// header runs once per key and user; retries with the same path and body
+// get the stored response with the Idempotent-Replayed header, retries with
+// another path or body get 422. Server errors are not stored, so the
+// request may be retried with the same key. Requests without the header are
+// passed through.
+func (c *Controller) Idempotent(ctx *gin.Context) {
+	key := ctx.GetHeader(idempotencyHeader)
+	if key == "" {
+		ctx.Next()
+		return
+	}
+
+	if good := c.Verify(ctx); !good {
+		if !ctx.Writer.Written() {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		}
+		ctx.Abort()
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		log.Printf("[ERROR] Cant read request body: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	h := sha256.New()
+	h.Write([]byte(ctx.Request.Method + " " + ctx.Request.URL.Path + "\n"))
+	h.Write(body)
+
+	rec, replay, err := c.IdempotencyService.Begin(ctx, structs.IdempotencyRecord{
+		IdUser:      id,
+		Key:         key,
+		RequestHash: hex.EncodeToString(h.Sum(nil)),
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant begin idempotent request: %v", err)
+		c.writeIdempotencyError(ctx, err)
+		ctx.Abort()
+		return
+	}
+	if replay {
+		ctx.Header("Idempotent-Replayed", "true")
+		ctx.Data(rec.Status, rec.ContentType, rec.Body)
+		ctx.Abort()
+		return
+	}
+
+	w := &recordingWriter{ResponseWriter: ctx.Writer}
+	ctx.Writer = w
+	stored := false
+	// The key is freed if the handler panics or fails with a server error.
+	defer func() {
+		if !stored {
+			if err := c.IdempotencyService.Abandon(ctx, id, key); err != nil {
+				log.Printf("[ERROR] Cant free idempotency key: %v", err)
+			}
+		}
+	}()
+
+	ctx.Next()
+
+	if w.Status() >= http.StatusInternalServerError {
+		return
+	}
+	rec.Status = w.Status()
+	rec.ContentType = w.Header().Get("Content-Type")
+	rec.Body = w.body.Bytes()
+	if err := c.IdempotencyService.Complete(ctx, rec); err != nil {
+		log.Printf("[ERROR] Cant store idempotent response: %v", err)
+		return
+	}
+	stored = true
+}
+
+func (c *Controller) writeIdempotencyError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrInvalidIdempotencyKey):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrIdempotencyKeyReused):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrIdempotencyKeyInProgress):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
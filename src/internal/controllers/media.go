@@ -0,0 +1,309 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/service/media"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+const (
+	maxImagesPerUpload = 10
+	maxImageUploadSize = maxImagesPerUpload*media.MaxImageSize + 1<<20
+)
+
+type ReorderImagesRequest struct {
+	Ids []string `json:"ids" binding:"required"`
+}
+
+// UploadProductImagesHandler загружает изображения продукта
+// @Summary Загрузить изображения продукта
+// @Description Загружает одно или несколько изображений (JPEG, PNG, GIF до 10 МБ) и создает миниатюры (только для администраторов). Первое изображение становится основным, если primary=true или у продукта еще нет изображений
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Param images formData file true "Файлы изображений"
+// @Param primary formData bool false "Сделать первое изображение основным"
+// @Success 201 {array} structs.ProductImage "Загруженные изображения"
+// @Failure 400 {object} object "Неверный формат данных"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Продукт не найден"
+// @Failure 413 {object} object "Файл слишком большой"
+// @Failure 415 {object} object "Неподдерживаемый тип файла"
+// @Failure 500 {object} object "Ошибка сервера при загрузке"
+// @Router /api/v1/products/{id}/images [post]
+func (c *Controller) UploadProductImagesHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to upload product images")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImageUploadSize)
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		log.Printf("[ERROR] Cant parse multipart form: %v", err)
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Upload is too large"})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	headers := form.File["images"]
+	if len(headers) == 0 || len(headers) > maxImagesPerUpload {
+		log.Printf("[ERROR] Wrong number of images: %d", len(headers))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "From 1 to 10 files are expected in the images field"})
+		return
+	}
+
+	primary := false
+	if v := ctx.PostForm("primary"); v != "" {
+		primary, err = strconv.ParseBool(v)
+		if err != nil {
+			log.Printf("[ERROR] Cant parse primary: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid primary value"})
+			return
+		}
+	}
+
+	files := make([]structs.ImageFile, 0, len(headers))
+	for _, fh := range headers {
+		if fh.Size > media.MaxImageSize {
+			log.Printf("[ERROR] Image %s is too large: %d", fh.Filename, fh.Size)
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": structs.ErrImageTooLarge.Error()})
+			return
+		}
+		f, err := fh.Open()
+		if err != nil {
+			log.Printf("[ERROR] Cant open image: %v", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		data, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			log.Printf("[ERROR] Cant read image: %v", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		files = append(files, structs.ImageFile{Name: fh.Filename, Data: data})
+	}
+
+	imgs, err := c.MediaService.Upload(ctx.Request.Context(), id, files, primary)
+	if err != nil {
+		log.Printf("[ERROR] Cant upload product images: %v", err)
+		c.writeImageError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, imgs)
+}
+
+// GetProductImagesHandler возвращает изображения продукта
+// @Summary Получить изображения продукта
+// @Description Возвращает изображения продукта в порядке отображения вместе со ссылками на миниатюры
+// @Tags products
+// @Produce json
+// @Param id path string true "UUID продукта"
+// @Success 200 {array} structs.ProductImage "Изображения продукта"
+// @Failure 400 {object} object "Неверный формат ID"
+// @Failure 500 {object} object "Ошибка сервера при получении изображений"
+// @Router /api/v1/products/{id}/images [get]
+func (c *Controller) GetProductImagesHandler(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	imgs, err := c.MediaService.GetByProduct(ctx.Request.Context(), id)
+	if err != nil {
+		log.Printf("[ERROR] Cant get product images: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, imgs)
+}
+
+// ReorderProductImagesHandler меняет порядок изображений продукта
+// @Summary Изменить порядок изображений
+// @Description Задает порядок отображения изображений продукта, в списке должны быть все изображения продукта (только для администраторов)
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Param request body ReorderImagesRequest true "ID изображений в новом порядке"
+// @Success 200 {object} object "Порядок изменен"
+// @Failure 400 {object} object "Неверный формат данных"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера"
+// @Router /api/v1/products/{id}/images [put]
+func (c *Controller) ReorderProductImagesHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to reorder product images")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	var input ReorderImagesRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ids := make([]uuid.UUID, 0, len(input.Ids))
+	for _, s := range input.Ids {
+		iid, err := uuid.Parse(s)
+		if err != nil {
+			log.Printf("[ERROR] Cant parse image id: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid image ID"})
+			return
+		}
+		ids = append(ids, iid)
+	}
+
+	if err := c.MediaService.Reorder(ctx.Request.Context(), id, ids); err != nil {
+		log.Printf("[ERROR] Cant reorder product images: %v", err)
+		c.writeImageError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Images reordered"})
+}
+
+// SetPrimaryProductImageHandler делает изображение основным
+// @Summary Сделать изображение основным
+// @Description Делает изображение основным и обновляет pic_link продукта (только для администраторов)
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Param id_image path string true "UUID изображения"
+// @Success 200 {object} object "Основное изображение изменено"
+// @Failure 400 {object} object "Неверный формат ID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Изображение не найдено"
+// @Failure 500 {object} object "Ошибка сервера"
+// @Router /api/v1/products/{id}/images/{id_image}/primary [put]
+func (c *Controller) SetPrimaryProductImageHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to set primary image")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, iid, ok := parseImagePath(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.MediaService.SetPrimary(ctx.Request.Context(), id, iid); err != nil {
+		log.Printf("[ERROR] Cant set primary image: %v", err)
+		c.writeImageError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Primary image updated"})
+}
+
+// DeleteProductImageHandler удаляет изображение продукта
+// @Summary Удалить изображение продукта
+// @Description Удаляет изображение и его миниатюры (только для администраторов). Если удалено основное изображение, основным становится следующее по порядку
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Param id_image path string true "UUID изображения"
+// @Success 200 {object} object "Изображение удалено"
+// @Failure 400 {object} object "Неверный формат ID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Изображение не найдено"
+// @Failure 500 {object} object "Ошибка сервера"
+// @Router /api/v1/products/{id}/images/{id_image} [delete]
+func (c *Controller) DeleteProductImageHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to delete product image")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, iid, ok := parseImagePath(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.MediaService.Delete(ctx.Request.Context(), id, iid); err != nil {
+		log.Printf("[ERROR] Cant delete product image: %v", err)
+		c.writeImageError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Image deleted"})
+}
+
+func parseImagePath(ctx *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	iid, err := uuid.Parse(ctx.Param("id_image"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse image id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid image ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, iid, true
+}
+
+func (c *Controller) writeImageError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrProductNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+	case errors.Is(err, structs.ErrImageNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
+	case errors.Is(err, structs.ErrImageTooLarge):
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrUnsupportedImageType):
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidImage),
+		errors.Is(err, structs.ErrInvalidImageOrder),
+		errors.Is(err, structs.ErrNoImages):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
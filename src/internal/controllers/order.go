@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,7 +14,15 @@ import (
 )
 
 type CreateOrderRequest struct {
-	Address string `json:"address" binding:"required"`
+	Address    string    `json:"address" binding:"required"`
+	PromoCode  string    `json:"promo_code"`
+	IdDelivery uuid.UUID `json:"id_delivery" binding:"required"`
+	Zone       string    `json:"zone"`
+	IdSlot     uuid.UUID `json:"id_slot"`
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason" binding:"required"`
 }
 
 type OrderResponse struct {
@@ -36,15 +45,19 @@ type OrderItemResponse struct {
 
 // CreateOrderHandler создает новый заказ
 // @Summary Создать заказ
-// @Description Создает новый заказ для текущего пользователя
+// @Description Создает новый заказ из корзины текущего пользователя. Применяются действующие акции и промокод promo_code, скидки сохраняются по позициям заказа. Остатки всех позиций проверяются и списываются в одной транзакции, сначала из партий с ближайшим сроком годности. Резерв товара, сделанный при начале оформления, переходит в заказ. Заказ создается неоплаченным и виден работникам после оплаты. Если товара не хватает, в items перечисляются все такие позиции с доступным количеством. Стоимость доставки id_delivery в зону zone считается по весу и сумме заказа и входит в price; курьерская доставка требует слот id_slot со свободными местами
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом и телом возвращает первый ответ"
 // @Param request body CreateOrderRequest true "Данные для создания заказа"
-// @Success 201 {object} object "Заказ успешно создан"
-// @Failure 400 {object} object "Неверный формат данных"
+// @Success 201 {object} object "Заказ успешно создан, возвращаются id, итоговая цена и стоимость доставки"
+// @Failure 400 {object} object "Неверный формат данных, корзина пуста, промокод не подходит, доставка в зону недоступна или не указан слот"
 // @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Промокод, товар, способ доставки или слот не найден"
+// @Failure 409 {object} object "Лимит использований промокода исчерпан, товара недостаточно на складе, слот занят или уже начался, или запрос с этим ключом еще выполняется"
+// @Failure 422 {object} object "Ключ идемпотентности использован для другого запроса"
 // @Failure 500 {object} object "Ошибка сервера при создании заказа"
 // @Router /api/v1/orders [post]
 func (c *Controller) CreateOrderHandler(ctx *gin.Context) {
@@ -76,25 +89,29 @@ func (c *Controller) CreateOrderHandler(ctx *gin.Context) {
 	}
 
 	o := structs.Order{
-		Date:    time.Now(),
-		IdUser:  id,
-		Address: input.Address,
-		Status:  "непринятый",
-		Price:   0,
+		Date:       time.Now(),
+		IdUser:     id,
+		Address:    input.Address,
+		Status:     structs.OrderUnpaid,
+		Price:      0,
+		IdDelivery: input.IdDelivery,
+		Zone:       input.Zone,
+		IdSlot:     input.IdSlot,
 	}
 
-	if err = c.OrderService.Create(ctx, o); err != nil {
+	o, err = c.OrderService.Checkout(ctx, o, input.PromoCode)
+	if err != nil {
 		log.Printf("[ERROR] Cant create order: %v", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.writeOrderError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{"message": "Order created"})
+	ctx.JSON(http.StatusCreated, gin.H{"message": "Order created", "id": o.Id, "price": o.Price, "shipping_cost": o.ShippingCost})
 }
 
 // GetOrderItemsHandler получает товары в заказе
 // @Summary Получить товары заказа
-// @Description Возвращает список товаров в указанном заказе
+// @Description Возвращает список товаров в указанном заказе. Price (цена за единицу), Discount (скидка на позицию), Name, Art и Brand сохранены на момент покупки и не меняются вместе с каталогом. Для товаров, хранящихся партиями, Lots содержит списанные партии со сроками годности
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -144,7 +161,7 @@ func (c *Controller) GetOrderItemsHandler(ctx *gin.Context) {
 
 // GetOrdersHandler получает заказы
 // @Summary Получить заказы
-// @Description Возвращает список заказов. Всех, если без параметров только для админа, если status=непринятый - свободные заказы для работников и админов
+// @Description Возвращает список заказов. Всех, если без параметров только для админа, если status=непринятый - свободные оплаченные заказы для работников и админов
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -159,7 +176,7 @@ func (c *Controller) GetOrderItemsHandler(ctx *gin.Context) {
 // @Router /api/v1/orders [get]
 func (c *Controller) GetOrdersHandler(ctx *gin.Context) {
 	status := ctx.Query("status")
-	if status == "непринятый" {
+	if status == string(structs.OrderNew) {
 		c.GetFreeOrdersHandler(ctx)
 	} else {
 		c.GetAllOrdersHandler(ctx)
@@ -176,7 +193,7 @@ func (c *Controller) GetFreeOrdersHandler(ctx *gin.Context) {
 		return
 	}
 	status := ctx.Query("status")
-	if status != "непринятый" {
+	if status != string(structs.OrderNew) {
 		log.Printf("[ERROR] Cant parse status to get free orders")
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order status format"})
 		return
@@ -221,42 +238,45 @@ func (c *Controller) GetAllOrdersHandler(ctx *gin.Context) {
 
 // ChangeOrderStatusHandler изменяет статус заказа
 // @Summary Изменить статус заказа
-// @Description Обновляет статус заказа (для работников и администраторов)
+// @Description Переводит заказ в новый статус (для работников и администраторов). Неоплаченный заказ становится непринятым только после оплаты. Работник ведет заказ по шагам непринятый → принятый → собранный → отданный и может отметить непринятый заказ некорректным, администратор дополнительно может отметить некорректным или отменить любой еще не отданный заказ. Товар некорректного и отмененного заказа возвращается на склад, оплата возвращается покупателю. Отданный, некорректный и отмененный заказы не меняются. Переход записывается в историю статусов с комментарием comment
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "UUID заказа"
-// @Param status query string true "Новый статус заказа" Enums(некорректный, непринятый, принятый, собранный, отданный)
+// @Param status query string true "Новый статус заказа" Enums(некорректный, неоплаченный, непринятый, принятый, собранный, отданный, отмененный)
+// @Param comment query string false "Комментарий к переходу, для отмены - обязательная причина"
 // @Success 200 {object} object "Статус заказа успешно обновлен"
 // @Failure 400 {object} object "Неверный формат данных"
 // @Failure 401 {object} object "Неавторизованный доступ"
-// @Failure 403 {object} object "Недостаточно прав"
 // @Failure 404 {object} object "Заказ не найден"
+// @Failure 409 {object} object "Переход в этот статус недопустим"
 // @Failure 500 {object} object "Ошибка сервера при обновлении статуса"
 // @Router /api/v1/users/me/orders/{id} [patch]
 func (c *Controller) ChangeOrderStatusHandler(ctx *gin.Context) {
-	goodW := c.VerifyW(ctx)
-	goodA := c.VerifyA(ctx)
-
-	if !goodW && !goodA {
+	good := c.VerifyWA(ctx)
+	if !good {
 		log.Printf("[ERROR] Cant autorize to change order status")
 		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
 		return
 	}
 
-	status := ctx.Query("status")
-
-	possible := []string{"некорректный", "непринятый", "принятый", "собранный", "отданный"}
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
 
-	inarr := false
-	for _, i := range possible {
-		if status == i {
-			inarr = true
-			break
-		}
+	id_user, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
 	}
-	if !inarr {
+
+	status := structs.OrderStatus(ctx.Query("status"))
+	if !status.Valid() {
 		log.Printf("[ERROR] Cant parse status to get order status")
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order status format"})
 		return
@@ -269,26 +289,239 @@ func (c *Controller) ChangeOrderStatusHandler(ctx *gin.Context) {
 		return
 	}
 
-	err = c.OrderService.ChangeOrderStatus(ctx, id, status)
+	role := structs.OrderRoleWorker
+	if c.AuthServise.CheckAdmin(ctx, id_user) {
+		role = structs.OrderRoleAdmin
+	}
+
+	err = c.OrderService.ChangeOrderStatus(ctx, structs.OrderStatusChange{
+		IdOrder: id,
+		To:      status,
+		IdActor: id_user,
+		Role:    role,
+		Comment: ctx.Query("comment"),
+	})
 	if err != nil {
 		log.Printf("[ERROR] Cant change order status: %v", err)
+		c.writeOrderStatusError(ctx, err)
+		return
+	}
 
-		if errors.Is(err, structs.ErrOrderNotFound) ||
-			errors.Is(err, structs.ErrNotFound) {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
-			return
-		}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Order status updated"})
+}
 
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+// CancelOrderHandler отменяет заказ покупателем
+// @Summary Отменить заказ
+// @Description Отменяет заказ текущего пользователя, пока он неоплаченный, непринятый или принятый. Причина отмены записывается в историю статусов, товар возвращается на склад, в те же партии, оплата возвращается покупателю
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заказа"
+// @Param request body CancelOrderRequest true "Причина отмены"
+// @Success 200 {object} object "Заказ отменен"
+// @Failure 400 {object} object "Неверный формат данных или не указана причина"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заказ не найден"
+// @Failure 409 {object} object "Заказ уже нельзя отменить"
+// @Failure 500 {object} object "Ошибка сервера при отмене заказа"
+// @Router /api/v1/users/me/orders/{id}/cancel [post]
+func (c *Controller) CancelOrderHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to cancel order")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Order status updated"})
+	c.cancelOrder(ctx, structs.OrderRoleCustomer)
+}
+
+// AdminCancelOrderHandler отменяет заказ администратором
+// @Summary Отменить заказ (администратор)
+// @Description Отменяет любой еще не отданный заказ (только для администраторов). Причина отмены записывается в историю статусов, товар возвращается на склад, в те же партии, оплата возвращается покупателю
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заказа"
+// @Param request body CancelOrderRequest true "Причина отмены"
+// @Success 200 {object} object "Заказ отменен"
+// @Failure 400 {object} object "Неверный формат данных или не указана причина"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заказ не найден"
+// @Failure 409 {object} object "Заказ уже нельзя отменить"
+// @Failure 500 {object} object "Ошибка сервера при отмене заказа"
+// @Router /api/v1/admin/orders/{id}/cancel [post]
+func (c *Controller) AdminCancelOrderHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to cancel order")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	c.cancelOrder(ctx, structs.OrderRoleAdmin)
+}
+
+func (c *Controller) cancelOrder(ctx *gin.Context, role structs.OrderRole) {
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id_user, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse order id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var input CancelOrderRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = c.OrderService.Cancel(ctx, structs.OrderStatusChange{
+		IdOrder: id,
+		IdActor: id_user,
+		Role:    role,
+		Comment: input.Reason,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant cancel order: %v", err)
+		c.writeOrderStatusError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Order cancelled"})
+}
+
+// GetOrderHistoryHandler получает историю статусов заказа
+// @Summary Получить историю статусов заказа
+// @Description Возвращает переходы статусов заказа текущего пользователя от создания заказа: прежний и новый статус, роль автора перехода, комментарий и время
+// @Tags orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заказа"
+// @Success 200 {array} structs.OrderStatusChange "История статусов"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заказ не найден"
+// @Failure 500 {object} object "Ошибка сервера при получении истории"
+// @Router /api/v1/users/me/orders/{id}/history [get]
+func (c *Controller) GetOrderHistoryHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get order history")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id_user, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse order id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	history, err := c.OrderService.GetHistory(ctx, id, id_user)
+	if err != nil {
+		log.Printf("[ERROR] Cant get order history: %v", err)
+		c.writeOrderStatusError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
+
+// RepeatOrderHandler повторяет заказ
+// @Summary Повторить заказ
+// @Description Добавляет товары заказа текущего пользователя в корзину. Товары, которых больше нет в каталоге (archived) или нет в наличии (out_of_stock), пропускаются; количество уменьшается до остатка с учетом того, что уже лежит в корзине. Для каждой позиции возвращается status: added, reduced или skipped с причиной reason
+// @Tags orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заказа"
+// @Success 200 {array} structs.RepeatItem "Результат по позициям заказа"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заказ не найден"
+// @Failure 500 {object} object "Ошибка сервера при повторе заказа"
+// @Router /api/v1/users/me/orders/{id}/reorder [post]
+func (c *Controller) RepeatOrderHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to repeat order")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id_user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse order id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := c.OrderService.Repeat(ctx, id, id_user)
+	if err != nil {
+		log.Printf("[ERROR] Cant repeat order: %v", err)
+		c.writeOrderStatusError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, items)
+}
+
+func (c *Controller) writeOrderStatusError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrOrderNotFound),
+		errors.Is(err, structs.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+	case errors.Is(err, structs.ErrInvalidOrderStatus),
+		errors.Is(err, structs.ErrCancelReasonRequired):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrIllegalOrderTransition),
+		errors.Is(err, structs.ErrOrderStatusChanged):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
 }
 
 // DeleteOrderHandler удаляет заказ
 // @Summary Удалить заказ
-// @Description Удаляет заказ по его идентификатору (только для администраторов)
+// @Description Удаляет заказ по его идентификатору (только для администраторов). Товар еще не отданного и не отмененного заказа возвращается на склад
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -384,3 +617,224 @@ func (c *Controller) GetOrdersByUserHandler(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, orders)
 }
+
+// SearchOrdersHandler ищет заказы для администратора
+// @Summary Поиск заказов (администратор)
+// @Description Возвращает заказы с позициями по фильтрам (только для администраторов). Страницы листаются курсором: next_cursor ответа передается в cursor следующего запроса с той же сортировкой
+// @Tags admins
+// @Produce json
+// @Security BearerAuth
+// @Param status query []string false "Статусы заказа" collectionFormat(multi)
+// @Param from query string false "Дата заказа от (YYYY-MM-DD или RFC3339), включительно"
+// @Param to query string false "Дата заказа до (YYYY-MM-DD включительно или RFC3339 не включая)"
+// @Param customer query string false "Часть e-mail или телефона покупателя"
+// @Param id_worker query string false "UUID работника, принявшего заказ"
+// @Param min_price query number false "Минимальная сумма заказа"
+// @Param max_price query number false "Максимальная сумма заказа"
+// @Param id_product query string false "UUID товара в заказе"
+// @Param sort query string false "Сортировка: -date (по умолчанию, сначала новые), date, price, -price" Enums(-date, date, price, -price)
+// @Param limit query int false "Размер страницы (по умолчанию 50, не больше 200)"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Success 200 {object} structs.OrderPage "Страница заказов"
+// @Failure 400 {object} object "Неверные параметры фильтра или курсор"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при поиске заказов"
+// @Router /api/v1/admin/orders [get]
+func (c *Controller) SearchOrdersHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to search orders")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	f, ok := parseOrderFilter(ctx)
+	if !ok {
+		return
+	}
+	var limit int
+	if l := ctx.Query("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit <= 0 {
+			log.Printf("[ERROR] Cant parse limit: %q", l)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+
+	page, err := c.OrderService.Search(ctx, f, ctx.Query("cursor"), limit)
+	if err != nil {
+		log.Printf("[ERROR] Cant search orders: %v", err)
+		c.writeOrderSearchError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// ExportOrdersHandler выгружает заказы в CSV
+// @Summary Экспорт заказов (администратор)
+// @Description Выгружает заказы по тем же фильтрам и в той же сортировке, что и поиск, потоком в CSV, одна строка на позицию заказа (только для администраторов)
+// @Tags admins
+// @Produce text/csv
+// @Security BearerAuth
+// @Param status query []string false "Статусы заказа" collectionFormat(multi)
+// @Param from query string false "Дата заказа от (YYYY-MM-DD или RFC3339), включительно"
+// @Param to query string false "Дата заказа до (YYYY-MM-DD включительно или RFC3339 не включая)"
+// @Param customer query string false "Часть e-mail или телефона покупателя"
+// @Param id_worker query string false "UUID работника, принявшего заказ"
+// @Param min_price query number false "Минимальная сумма заказа"
+// @Param max_price query number false "Максимальная сумма заказа"
+// @Param id_product query string false "UUID товара в заказе"
+// @Param sort query string false "Сортировка: -date (по умолчанию), date, price, -price" Enums(-date, date, price, -price)
+// @Success 200 {file} file "CSV с заказами"
+// @Failure 400 {object} object "Неверные параметры фильтра"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при экспорте"
+// @Router /api/v1/admin/orders/export [get]
+func (c *Controller) ExportOrdersHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to export orders")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	f, ok := parseOrderFilter(ctx)
+	if !ok {
+		return
+	}
+	// Headers are sent with the first chunk of CSV, so a rejected filter
+	// still gets a JSON error; a failure mid-stream can only be logged.
+	w := &csvAttachment{ctx: ctx, filename: "orders.csv"}
+	if err := c.OrderService.Export(ctx.Request.Context(), f, w); err != nil {
+		log.Printf("[ERROR] Cant export orders: %v", err)
+		if !w.started {
+			c.writeOrderSearchError(ctx, err)
+		}
+	}
+}
+
+// csvAttachment delays the response headers until the first write.
+type csvAttachment struct {
+	ctx      *gin.Context
+	filename string
+	started  bool
+}
+
+func (w *csvAttachment) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.ctx.Header("Content-Type", "text/csv; charset=utf-8")
+		w.ctx.Header("Content-Disposition", `attachment; filename="`+w.filename+`"`)
+		w.ctx.Status(http.StatusOK)
+	}
+	return w.ctx.Writer.Write(p)
+}
+
+// parseOrderFilter reads the search parameters and answers 400 when one of
+// them is malformed. A date-only "to" includes the whole day.
+func parseOrderFilter(ctx *gin.Context) (structs.OrderFilter, bool) {
+	f := structs.OrderFilter{
+		Customer: ctx.Query("customer"),
+		Sort:     ctx.Query("sort"),
+	}
+	for _, st := range queryList(ctx, "status") {
+		f.Statuses = append(f.Statuses, structs.OrderStatus(st))
+	}
+
+	fail := func(param string, err error) (structs.OrderFilter, bool) {
+		log.Printf("[ERROR] Cant parse %s: %v", param, err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+		return structs.OrderFilter{}, false
+	}
+	var err error
+	if v := ctx.Query("from"); v != "" {
+		if f.From, _, err = parseOrderDate(v); err != nil {
+			return fail("from", err)
+		}
+	}
+	if v := ctx.Query("to"); v != "" {
+		var dateOnly bool
+		if f.To, dateOnly, err = parseOrderDate(v); err != nil {
+			return fail("to", err)
+		}
+		if dateOnly {
+			f.To = f.To.AddDate(0, 0, 1)
+		}
+	}
+	if v := ctx.Query("id_worker"); v != "" {
+		if f.IdWorker, err = uuid.Parse(v); err != nil {
+			return fail("id_worker", err)
+		}
+	}
+	if v := ctx.Query("id_product"); v != "" {
+		if f.IdProduct, err = uuid.Parse(v); err != nil {
+			return fail("id_product", err)
+		}
+	}
+	if v := ctx.Query("min_price"); v != "" {
+		p, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fail("min_price", err)
+		}
+		f.MinPrice = &p
+	}
+	if v := ctx.Query("max_price"); v != "" {
+		p, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fail("max_price", err)
+		}
+		f.MaxPrice = &p
+	}
+	return f, true
+}
+
+// parseOrderDate accepts a date or a timestamp. Order dates are stored
+// without a time zone, so the wall time of a timestamp is used.
+func parseOrderDate(s string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC), false, nil
+}
+
+func (c *Controller) writeOrderSearchError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrInvalidOrderFilter),
+		errors.Is(err, structs.ErrInvalidOrderStatus),
+		errors.Is(err, structs.ErrInvalidCursor):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (c *Controller) writeOrderError(ctx *gin.Context, err error) {
+	var se *structs.InsufficientStockError
+	switch {
+	case errors.As(err, &se):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "items": se.Shortages})
+	case errors.Is(err, structs.ErrEmptyBasket):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrProductNotFound),
+		errors.Is(err, structs.ErrVariantNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInsufficientStock):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrDeliveryNotFound),
+		errors.Is(err, structs.ErrDeliveryUnavailable),
+		errors.Is(err, structs.ErrSlotNotFound),
+		errors.Is(err, structs.ErrSlotRequired),
+		errors.Is(err, structs.ErrSlotFull),
+		errors.Is(err, structs.ErrSlotClosed):
+		c.writeDeliveryError(ctx, err)
+	default:
+		c.writePromotionError(ctx, err)
+	}
+}
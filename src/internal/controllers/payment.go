@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+const maxWebhookSize = 1 << 20
+
+type StartPaymentRequest struct {
+	Method string `json:"method"`
+}
+
+// StartPaymentHandler начинает оплату заказа
+// @Summary Оплатить заказ
+// @Description Создает платеж на всю сумму неоплаченного заказа текущего пользователя и возвращает client_secret для оплаты у платежного провайдера. Результат оплаты приходит от провайдера, после успешной оплаты заказ становится непринятым и виден работникам. Заказ без суммы к оплате оплачивается сразу. Поддерживает заголовок Idempotency-Key
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заказа"
+// @Param request body StartPaymentRequest false "Способ оплаты, для тестового провайдера fake-declined или fake-delayed"
+// @Success 201 {object} structs.Payment "Созданный платеж"
+// @Failure 400 {object} object "Неверный формат данных"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заказ не найден"
+// @Failure 409 {object} object "Заказ уже оплачен или отменен"
+// @Failure 500 {object} object "Ошибка сервера при создании платежа"
+// @Router /api/v1/users/me/orders/{id}/payments [post]
+func (c *Controller) StartPaymentHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to start payment")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id_user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id_order, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse order id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID format"})
+		return
+	}
+
+	var input StartPaymentRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p, err := c.PaymentService.Start(ctx, id_order, id_user, input.Method)
+	if err != nil {
+		log.Printf("[ERROR] Cant start payment: %v", err)
+		c.writePaymentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, p)
+}
+
+// GetOrderPaymentsHandler получает платежи заказа
+// @Summary Получить платежи заказа
+// @Description Возвращает платежи заказа текущего пользователя, сначала новые, с их статусом и причиной отказа
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заказа"
+// @Success 200 {array} structs.Payment "Платежи заказа"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заказ не найден"
+// @Failure 500 {object} object "Ошибка сервера при получении платежей"
+// @Router /api/v1/users/me/orders/{id}/payments [get]
+func (c *Controller) GetOrderPaymentsHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get payments")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id_user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id_order, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse order id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID format"})
+		return
+	}
+
+	ps, err := c.PaymentService.GetByOrder(ctx, id_order, id_user)
+	if err != nil {
+		log.Printf("[ERROR] Cant get payments: %v", err)
+		c.writePaymentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ps)
+}
+
+// PaymentWebhookHandler принимает уведомления платежного провайдера
+// @Summary Уведомление о платеже
+// @Description Принимает от платежного провайдера результат платежа. Запрос подписывается провайдером в заголовке X-Payment-Signature. Повторные уведомления ничего не меняют, при ответе с ошибкой провайдер повторяет уведомление
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param X-Payment-Signature header string true "Подпись тела запроса"
+// @Success 200 {object} object "Уведомление принято"
+// @Failure 400 {object} object "Неверное уведомление"
+// @Failure 401 {object} object "Неверная подпись"
+// @Failure 404 {object} object "Платеж не найден"
+// @Failure 413 {object} object "Слишком большое уведомление"
+// @Failure 500 {object} object "Ошибка сервера при обработке уведомления"
+// @Router /api/v1/payments/webhook [post]
+func (c *Controller) PaymentWebhookHandler(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxWebhookSize)
+	payload, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		log.Printf("[ERROR] Cant read payment webhook: %v", err)
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Webhook is too large"})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = c.PaymentService.HandleWebhook(ctx, payload, ctx.GetHeader(structs.PaymentSignatureHeader))
+	if err != nil {
+		log.Printf("[ERROR] Cant handle payment webhook: %v", err)
+		c.writePaymentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Webhook accepted"})
+}
+
+func (c *Controller) writePaymentError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrOrderNotFound),
+		errors.Is(err, structs.ErrPaymentNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrPaymentNotAllowed):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidPaymentMethod),
+		errors.Is(err, structs.ErrInvalidPaymentEvent):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidSignature):
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type SchedulePriceRequest struct {
+	Price     float64   `json:"price" binding:"min=0"`
+	ValidFrom time.Time `json:"valid_from" binding:"required"`
+}
+
+// SchedulePriceHandler планирует цену продукта
+// @Summary Запланировать цену
+// @Description Добавляет цену, которая вступит в силу в момент valid_from (RFC 3339, только для администраторов). Цена становится текущей при следующем запуске фоновой активации
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Param request body SchedulePriceRequest true "Цена и начало действия"
+// @Success 201 {object} object "ID запланированной цены"
+// @Failure 400 {object} object "Неверный формат данных или начало действия в прошлом"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Продукт не найден"
+// @Failure 409 {object} object "На это время уже запланирована цена"
+// @Failure 500 {object} object "Ошибка сервера при планировании цены"
+// @Router /api/v1/products/{id}/prices [post]
+func (c *Controller) SchedulePriceHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to schedule price")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	var input SchedulePriceRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pid, err := c.PriceService.Schedule(ctx, structs.ProductPrice{
+		IdProduct: id,
+		Price:     input.Price,
+		ValidFrom: input.ValidFrom,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant schedule price: %v", err)
+		c.writePriceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": pid})
+}
+
+// CancelScheduledPriceHandler отменяет запланированную цену
+// @Summary Отменить запланированную цену
+// @Description Удаляет цену, которая еще не вступила в силу (только для администраторов)
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Param id_price path string true "UUID цены"
+// @Success 200 {object} object "Цена отменена"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Продукт или запланированная цена не найдены"
+// @Failure 500 {object} object "Ошибка сервера при отмене цены"
+// @Router /api/v1/products/{id}/prices/{id_price} [delete]
+func (c *Controller) CancelScheduledPriceHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to cancel price")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+	pid, err := uuid.Parse(ctx.Param("id_price"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse price id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid price ID format"})
+		return
+	}
+
+	if err := c.PriceService.CancelScheduled(ctx, id, pid); err != nil {
+		log.Printf("[ERROR] Cant cancel price: %v", err)
+		c.writePriceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Price cancelled"})
+}
+
+// GetPriceHistoryHandler получает историю цен продукта
+// @Summary Получить историю цен
+// @Description Возвращает все цены продукта с интервалами действия, включая запланированные (только для администраторов)
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Success 200 {array} structs.ProductPrice "История цен"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Продукт не найден"
+// @Failure 500 {object} object "Ошибка сервера при получении истории"
+// @Router /api/v1/products/{id}/price-history [get]
+func (c *Controller) GetPriceHistoryHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get price history")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	ps, err := c.PriceService.GetHistory(ctx, id)
+	if err != nil {
+		log.Printf("[ERROR] Cant get price history: %v", err)
+		c.writePriceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ps)
+}
+
+func (c *Controller) writePriceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrProductNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+	case errors.Is(err, structs.ErrPriceNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrDuplicatePrice):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidPrice),
+		errors.Is(err, structs.ErrPriceNotInFuture):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -15,23 +15,39 @@ type CreateProductRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
 	Price       string `json:"price" binding:"required"`
-	Category    string `json:"category" binding:"required"`
+	IdCategory  string `json:"id_category" binding:"required"`
 	Amount      int    `json:"amount" binding:"required,min=0"`
 	IdBrand     string `json:"id_brand" binding:"required"`
 	PicLink     string `json:"pic_link"`
 	Articule    string `json:"articule"`
+	Weight      int    `json:"weight" binding:"min=0"`
+}
+
+type CreateVariantRequest struct {
+	Articule string  `json:"articule" binding:"required"`
+	Shade    string  `json:"shade"`
+	VolumeMl int     `json:"volume_ml" binding:"min=0"`
+	Price    float64 `json:"price" binding:"min=0"`
+	Amount   int     `json:"amount" binding:"min=0"`
+	Weight   int     `json:"weight" binding:"min=0"`
+}
+
+// ProductResponse is a product together with its variant matrix.
+type ProductResponse struct {
+	structs.Product
+	Variants structs.VariantMatrix `json:"variants"`
 }
 
 // CreateProductHandler создает новый продукт
 // @Summary Создать продукт
-// @Description Создает новый продукт в системе (только для администраторов)
+// @Description Создает новый продукт в системе (только для администраторов). weight — вес единицы товара в граммах для расчета доставки
 // @Tags products
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body CreateProductRequest true "Данные для создания продукта"
 // @Success 201 {object} object "Продукт успешно создан"
-// @Failure 400 {object} object "Неверный формат данных"
+// @Failure 400 {object} object "Неверный формат данных или категория не найдена"
 // @Failure 401 {object} object "Неавторизованный доступ"
 // @Failure 403 {object} object "Недостаточно прав"
 // @Failure 500 {object} object "Ошибка сервера при создании продукта"
@@ -48,11 +64,12 @@ func (c *Controller) CreateProductHandler(ctx *gin.Context) {
 		Name        string `json:"name"`
 		Description string `json:"description"`
 		Price       string `json:"price"`
-		Category    string `json:"category"`
+		IdCategory  string `json:"id_category"`
 		Amount      int    `json:"amount"`
 		IdBrand     string `json:"id_brand"`
 		PicLink     string `json:"pic_link"`
 		Articule    string `json:"articule"`
+		Weight      int    `json:"weight" binding:"min=0"`
 	}
 	if err := ctx.ShouldBindJSON(&input); err != nil {
 		log.Printf("[ERROR] Cant bind JSON: %v", err)
@@ -74,15 +91,23 @@ func (c *Controller) CreateProductHandler(ctx *gin.Context) {
 		return
 	}
 
+	id_cat, err := uuid.Parse(input.IdCategory)
+	if err != nil {
+		log.Printf("[ERROR] Cant parse category id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID format"})
+		return
+	}
+
 	p := structs.Product{
 		Name:        input.Name,
 		Description: input.Description,
 		Price:       pr,
-		Category:    input.Category,
+		IdCategory:  id_cat,
 		Amount:      input.Amount,
 		IdBrand:     id_brnd,
 		PicLink:     input.PicLink,
 		Articule:    input.Articule,
+		Weight:      input.Weight,
 	}
 
 	if err := c.ProductService.Create(ctx, p); err != nil {
@@ -91,6 +116,10 @@ func (c *Controller) CreateProductHandler(ctx *gin.Context) {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Product with this articule already exists"})
 			return
 		}
+		if errors.Is(err, structs.ErrCategoryNotFound) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Category not found"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -100,16 +129,26 @@ func (c *Controller) CreateProductHandler(ctx *gin.Context) {
 
 // GetProductsHandler получает продукты
 // @Summary Получить продукты
-// @Description Возвращает список продуктов с различными фильтрами: по категории или бренду
+// @Description Возвращает список продуктов с различными фильтрами: по категории или бренду. Каждый продукт содержит сводку отзывов Rating: средняя оценка, число отзывов и гистограмма histogram, где i-й элемент — число отзывов с i+1 звездами. При фильтрах по составу, свойствам и оценке или сортировке выполняется поиск, найденные товары с ингредиентами из профиля аллергенов пользователя содержат поле allergens
 // @Tags products
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id query string false "UUID продукта"
 // @Param art query string false "Артикул продукта"
-// @Param category query string false "Категория продукта" Enums(уход, декоративная, парфюмерия, для волос, мужская)
+// @Param category query string false "UUID или slug категории, товары подкатегорий включаются"
 // @Param brand query string false "Название бренда"
-// @Success 200 {object} object "Данные продукта или список продуктов"
+// @Param ingredient query []string false "INCI ингредиенты, которые должны входить в состав" collectionFormat(multi)
+// @Param exclude_ingredient query []string false "INCI ингредиенты, которых не должно быть в составе" collectionFormat(multi)
+// @Param skin_type query []string false "Коды типов кожи" collectionFormat(multi)
+// @Param hair_type query []string false "Коды типов волос" collectionFormat(multi)
+// @Param finish query []string false "Коды финишей" collectionFormat(multi)
+// @Param vegan query bool false "Только веганские"
+// @Param cruelty_free query bool false "Только cruelty free"
+// @Param min_spf query int false "Минимальный SPF"
+// @Param min_rating query number false "Минимальная средняя оценка от 0 до 5"
+// @Param sort query string false "Сортировка: name (по умолчанию), rating — по средней оценке и числу отзывов, reviews — по числу отзывов" Enums(name, rating, reviews)
+// @Success 200 {object} object "Данные продукта с матрицей вариантов или список продуктов"
 // @Failure 400 {object} object "Неверные параметры запроса"
 // @Failure 401 {object} object "Неавторизованный доступ"
 // @Failure 404 {object} object "Продукт не найден"
@@ -119,7 +158,9 @@ func (c *Controller) GetProductsHandler(ctx *gin.Context) {
 	category := ctx.Query("category")
 	brand := ctx.Query("brand")
 
-	if category == "" && brand == "" {
+	if hasProductFilter(ctx) {
+		c.SearchProductsHandler(ctx)
+	} else if category == "" && brand == "" {
 		c.GetProductHandler(ctx)
 	} else if category != "" {
 		c.GetProductsByCategoryHandler(ctx)
@@ -157,7 +198,14 @@ func (c *Controller) GetProductHandler(ctx *gin.Context) {
 			return
 		}
 
-		ctx.JSON(http.StatusOK, product)
+		variants, err := c.ProductService.GetVariants(ctx, pid)
+		if err != nil {
+			log.Printf("[ERROR] Cant get product variants: %v", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, ProductResponse{Product: product, Variants: variants})
 	}
 	// if name := ctx.Query("name"); name != "" {
 	// 	product, err := c.ProductService.GetByName(ctx, name)
@@ -181,7 +229,14 @@ func (c *Controller) GetProductHandler(ctx *gin.Context) {
 			return
 		}
 
-		ctx.JSON(http.StatusOK, product)
+		variants, err := c.ProductService.GetVariants(ctx, product.Id)
+		if err != nil {
+			log.Printf("[ERROR] Cant get product variants: %v", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, ProductResponse{Product: product, Variants: variants})
 	}
 
 	ctx.JSON(http.StatusBadRequest, gin.H{"error": "No valid query parameter provided"})
@@ -233,20 +288,22 @@ func (c *Controller) DeleteProductHandler(ctx *gin.Context) {
 
 func (c *Controller) GetProductsByCategoryHandler(ctx *gin.Context) {
 	category := ctx.Query("category")
-	possible := []string{"уход", "декоративная", "парфюмерия", "для волос", "мужская"}
-	inarr := false
-	for _, i := range possible {
-		if category == i {
-			inarr = true
-			break
+	id, err := uuid.Parse(category)
+	if err != nil {
+		cat, err := c.CategoryService.GetBySlug(ctx, category)
+		if err != nil {
+			log.Printf("[ERROR] Cant get product category: %v", err)
+			if errors.Is(err, structs.ErrCategoryNotFound) {
+				ctx.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
 		}
+		id = cat.Id
 	}
-	if !inarr {
-		log.Printf("[ERROR] Cant parse status to get product category")
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product category format"})
-		return
-	}
-	products, err := c.ProductService.GetByCategory(ctx, category)
+
+	products, err := c.ProductService.GetByCategory(ctx, id)
 	if err != nil {
 		log.Printf("[ERROR] Cant get products by category: %v", err)
 		if errors.Is(err, structs.ErrProductNotFound) ||
@@ -336,3 +393,148 @@ func (c *Controller) GetReviewsForProductHandler(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, reviews)
 }
+
+// CreateProductVariantHandler создает вариант продукта
+// @Summary Создать вариант продукта
+// @Description Добавляет к продукту вариант (оттенок и/или объем) с собственным артикулом, ценой и остатком (только для администраторов). Вариант без веса weight весит столько же, сколько продукт
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Param request body CreateVariantRequest true "Данные варианта"
+// @Success 201 {object} object "Вариант успешно создан"
+// @Failure 400 {object} object "Неверный формат данных"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Продукт не найден"
+// @Failure 409 {object} object "Артикул или сочетание оттенка и объема уже существует"
+// @Failure 500 {object} object "Ошибка сервера при создании варианта"
+// @Router /api/v1/products/{id}/variants [post]
+func (c *Controller) CreateProductVariantHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to create product variant")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	pid, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	var input CreateVariantRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	v := structs.ProductVariant{
+		IdProduct: pid,
+		Articule:  input.Articule,
+		Shade:     input.Shade,
+		VolumeMl:  input.VolumeMl,
+		Price:     input.Price,
+		Amount:    input.Amount,
+		Weight:    input.Weight,
+	}
+	if err := c.ProductService.CreateVariant(ctx, v); err != nil {
+		log.Printf("[ERROR] Cant create product variant: %v", err)
+		switch {
+		case errors.Is(err, structs.ErrInvalidVariant):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, structs.ErrProductNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		case errors.Is(err, structs.ErrDuplicateArticule):
+			ctx.JSON(http.StatusConflict, gin.H{"error": "Variant with this articule, shade or volume already exists"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"message": "Variant created"})
+}
+
+// GetProductVariantsHandler получает варианты продукта
+// @Summary Получить варианты продукта
+// @Description Возвращает матрицу вариантов продукта: доступные оттенки, объемы и сами варианты
+// @Tags products
+// @Produce json
+// @Param id path string true "UUID продукта"
+// @Success 200 {object} structs.VariantMatrix "Матрица вариантов"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 500 {object} object "Ошибка сервера при получении вариантов"
+// @Router /api/v1/products/{id}/variants [get]
+func (c *Controller) GetProductVariantsHandler(ctx *gin.Context) {
+	pid, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	variants, err := c.ProductService.GetVariants(ctx, pid)
+	if err != nil {
+		log.Printf("[ERROR] Cant get product variants: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, variants)
+}
+
+// DeleteProductVariantHandler удаляет вариант продукта
+// @Summary Удалить вариант продукта
+// @Description Удаляет вариант продукта (только для администраторов)
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Param id_variant path string true "UUID варианта"
+// @Success 200 {object} object "Вариант удален"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Вариант не найден"
+// @Failure 409 {object} object "Вариант уже заказывали"
+// @Failure 500 {object} object "Ошибка сервера при удалении варианта"
+// @Router /api/v1/products/{id}/variants/{id_variant} [delete]
+func (c *Controller) DeleteProductVariantHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to delete product variant")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	pid, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+	vid, err := uuid.Parse(ctx.Param("id_variant"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse variant id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid variant ID format"})
+		return
+	}
+
+	if err := c.ProductService.DeleteVariant(ctx, pid, vid); err != nil {
+		log.Printf("[ERROR] Cant delete product variant: %v", err)
+		switch {
+		case errors.Is(err, structs.ErrVariantNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Variant not found"})
+		case errors.Is(err, structs.ErrVariantOrdered):
+			ctx.JSON(http.StatusConflict, gin.H{"error": "Variant has been ordered and cannot be deleted"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Variant deleted"})
+}
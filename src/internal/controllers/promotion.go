@@ -0,0 +1,250 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type CreatePromotionRequest struct {
+	Name         string     `json:"name" binding:"required"`
+	Kind         string     `json:"kind" binding:"required"`
+	Code         string     `json:"code"`
+	Value        float64    `json:"value"`
+	BuyQuantity  int        `json:"buy_quantity"`
+	FreeQuantity int        `json:"free_quantity"`
+	IdBrand      uuid.UUID  `json:"id_brand"`
+	IdCategory   uuid.UUID  `json:"id_category"`
+	MinBasket    float64    `json:"min_basket"`
+	UsageLimit   int        `json:"usage_limit"`
+	PerUserLimit int        `json:"per_user_limit"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+}
+
+// CreatePromotionHandler создает акцию или промокод
+// @Summary Создать акцию
+// @Description Создает акцию (только для администраторов). kind: percent — скидка value процентов, fixed — скидка value рублей (только промокод), bundle — из каждых buy_quantity+free_quantity единиц free_quantity бесплатно. Без code акция применяется автоматически. id_brand и id_category ограничивают товары, категория включает подкатегории. Нулевые лимиты и пустые даты — без ограничений
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreatePromotionRequest true "Параметры акции"
+// @Success 201 {object} object "ID акции"
+// @Failure 400 {object} object "Неверные параметры акции"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Бренд или категория не найдены"
+// @Failure 409 {object} object "Промокод уже существует"
+// @Failure 500 {object} object "Ошибка сервера при создании акции"
+// @Router /api/v1/admin/promotions [post]
+func (c *Controller) CreatePromotionHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to create promotion")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var input CreatePromotionRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := c.PromotionService.Create(ctx, structs.Promotion{
+		Name:         input.Name,
+		Kind:         input.Kind,
+		Code:         input.Code,
+		Value:        input.Value,
+		BuyQuantity:  input.BuyQuantity,
+		FreeQuantity: input.FreeQuantity,
+		IdBrand:      input.IdBrand,
+		IdCategory:   input.IdCategory,
+		MinBasket:    input.MinBasket,
+		UsageLimit:   input.UsageLimit,
+		PerUserLimit: input.PerUserLimit,
+		StartsAt:     input.StartsAt,
+		EndsAt:       input.EndsAt,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant create promotion: %v", err)
+		c.writePromotionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// GetPromotionsHandler получает все акции
+// @Summary Получить акции
+// @Description Возвращает все акции и промокоды, сначала действующие (только для администраторов)
+// @Tags promotions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} structs.Promotion "Акции"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении акций"
+// @Router /api/v1/admin/promotions [get]
+func (c *Controller) GetPromotionsHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get promotions")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ps, err := c.PromotionService.GetAll(ctx)
+	if err != nil {
+		log.Printf("[ERROR] Cant get promotions: %v", err)
+		c.writePromotionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ps)
+}
+
+// GetPromotionHandler получает акцию
+// @Summary Получить акцию
+// @Description Возвращает акцию по ID (только для администраторов)
+// @Tags promotions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID акции"
+// @Success 200 {object} structs.Promotion "Акция"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Акция не найдена"
+// @Failure 500 {object} object "Ошибка сервера при получении акции"
+// @Router /api/v1/admin/promotions/{id} [get]
+func (c *Controller) GetPromotionHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get promotion")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse promotion id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid promotion ID format"})
+		return
+	}
+
+	p, err := c.PromotionService.GetById(ctx, id)
+	if err != nil {
+		log.Printf("[ERROR] Cant get promotion: %v", err)
+		c.writePromotionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, p)
+}
+
+// DeactivatePromotionHandler останавливает акцию
+// @Summary Остановить акцию
+// @Description Отключает акцию (только для администраторов). Акции не удаляются, так как скидки в заказах ссылаются на них
+// @Tags promotions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID акции"
+// @Success 200 {object} object "Акция остановлена"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Акция не найдена"
+// @Failure 500 {object} object "Ошибка сервера при остановке акции"
+// @Router /api/v1/admin/promotions/{id} [delete]
+func (c *Controller) DeactivatePromotionHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to deactivate promotion")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse promotion id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid promotion ID format"})
+		return
+	}
+
+	if err := c.PromotionService.Deactivate(ctx, id); err != nil {
+		log.Printf("[ERROR] Cant deactivate promotion: %v", err)
+		c.writePromotionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Promotion deactivated"})
+}
+
+// GetBasketDiscountsHandler рассчитывает скидки корзины
+// @Summary Рассчитать скидки
+// @Description Возвращает сумму корзины текущего пользователя со скидками действующих акций и промокода. Та же скидка будет применена при оформлении заказа
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param code query string false "Промокод"
+// @Success 200 {object} structs.DiscountQuote "Расчет скидок"
+// @Failure 400 {object} object "Промокод неактивен или не подходит к корзине"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Промокод не найден"
+// @Failure 409 {object} object "Лимит использований промокода исчерпан"
+// @Failure 500 {object} object "Ошибка сервера при расчете скидок"
+// @Router /api/v1/users/me/basket/discounts [get]
+func (c *Controller) GetBasketDiscountsHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get basket discounts")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	q, err := c.PromotionService.Quote(ctx, id, ctx.Query("code"))
+	if err != nil {
+		log.Printf("[ERROR] Cant quote basket: %v", err)
+		c.writePromotionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, q)
+}
+
+func (c *Controller) writePromotionError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrPromotionNotFound),
+		errors.Is(err, structs.ErrBrandNotFound),
+		errors.Is(err, structs.ErrCategoryNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrDuplicatePromoCode),
+		errors.Is(err, structs.ErrPromotionLimitReached):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidPromotion),
+		errors.Is(err, structs.ErrPromotionExpired),
+		errors.Is(err, structs.ErrPromotionMinBasket),
+		errors.Is(err, structs.ErrPromotionNotApplicable):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,409 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type CreateSupplierRequest struct {
+	Name   string      `json:"name" binding:"required"`
+	Email  string      `json:"email"`
+	Phone  string      `json:"phone"`
+	Brands []uuid.UUID `json:"brands" binding:"required"`
+}
+
+type PurchaseLineRequest struct {
+	IdProduct uuid.UUID `json:"id_product" binding:"required"`
+	IdVariant uuid.UUID `json:"id_variant"`
+	Quantity  int       `json:"quantity" binding:"required"`
+}
+
+type CreatePurchaseOrderRequest struct {
+	IdSupplier uuid.UUID             `json:"id_supplier" binding:"required"`
+	ExpectedAt *time.Time            `json:"expected_at"`
+	Note       string                `json:"note"`
+	Lines      []PurchaseLineRequest `json:"lines" binding:"required"`
+}
+
+type ReceivedLineRequest struct {
+	IdLine   uuid.UUID `json:"id_line" binding:"required"`
+	Quantity int       `json:"quantity"`
+}
+
+type PurchaseReceiptRequest struct {
+	Lines []ReceivedLineRequest `json:"lines"`
+	Final bool                  `json:"final"`
+}
+
+// CreateSupplierHandler создает поставщика
+// @Summary Создать поставщика
+// @Description Создает поставщика брендов (только для администраторов). Заказы поставщику могут содержать только товары его брендов
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateSupplierRequest true "Поставщик"
+// @Success 201 {object} object "ID поставщика"
+// @Failure 400 {object} object "Неверные данные поставщика"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Бренд не найден"
+// @Failure 409 {object} object "Поставщик уже существует"
+// @Failure 500 {object} object "Ошибка сервера при создании поставщика"
+// @Router /api/v1/admin/suppliers [post]
+func (c *Controller) CreateSupplierHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to create supplier")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var input CreateSupplierRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := c.PurchaseService.CreateSupplier(ctx, structs.Supplier{
+		Name:   input.Name,
+		Email:  input.Email,
+		Phone:  input.Phone,
+		Brands: input.Brands,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant create supplier: %v", err)
+		c.writePurchaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// GetSuppliersHandler получает поставщиков
+// @Summary Получить поставщиков
+// @Description Возвращает всех поставщиков с их брендами (только для администраторов)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} structs.Supplier "Поставщики"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении поставщиков"
+// @Router /api/v1/admin/suppliers [get]
+func (c *Controller) GetSuppliersHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get suppliers")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ss, err := c.PurchaseService.GetSuppliers(ctx)
+	if err != nil {
+		log.Printf("[ERROR] Cant get suppliers: %v", err)
+		c.writePurchaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ss)
+}
+
+// DeleteSupplierHandler удаляет поставщика
+// @Summary Удалить поставщика
+// @Description Удаляет поставщика без заказов (только для администраторов)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID поставщика"
+// @Success 200 {object} object "Поставщик удален"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Поставщик не найден"
+// @Failure 409 {object} object "У поставщика есть заказы"
+// @Failure 500 {object} object "Ошибка сервера при удалении поставщика"
+// @Router /api/v1/admin/suppliers/{id} [delete]
+func (c *Controller) DeleteSupplierHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to delete supplier")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse supplier id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier ID format"})
+		return
+	}
+
+	if err := c.PurchaseService.DeleteSupplier(ctx, id); err != nil {
+		log.Printf("[ERROR] Cant delete supplier: %v", err)
+		c.writePurchaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Supplier deleted"})
+}
+
+// CreatePurchaseOrderHandler создает заказ поставщику
+// @Summary Создать заказ поставщику
+// @Description Создает черновик заказа поставщику (только для администраторов). Каждый товар или вариант указывается в одной строке
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreatePurchaseOrderRequest true "Заказ поставщику"
+// @Success 201 {object} object "ID заказа"
+// @Failure 400 {object} object "Неверные данные заказа или товар не из брендов поставщика"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Поставщик, товар или вариант не найден"
+// @Failure 500 {object} object "Ошибка сервера при создании заказа"
+// @Router /api/v1/admin/purchase-orders [post]
+func (c *Controller) CreatePurchaseOrderHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to create purchase order")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var input CreatePurchaseOrderRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	lines := make([]structs.PurchaseLine, len(input.Lines))
+	for i, l := range input.Lines {
+		lines[i] = structs.PurchaseLine{
+			IdProduct: l.IdProduct,
+			IdVariant: l.IdVariant,
+			Ordered:   l.Quantity,
+		}
+	}
+
+	id, err := c.PurchaseService.CreateOrder(ctx, structs.PurchaseOrder{
+		IdSupplier: input.IdSupplier,
+		ExpectedAt: input.ExpectedAt,
+		Note:       input.Note,
+		Lines:      lines,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant create purchase order: %v", err)
+		c.writePurchaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// GetPurchaseOrdersHandler получает заказы поставщикам
+// @Summary Получить заказы поставщикам
+// @Description Возвращает заказы поставщикам без строк, сначала новые (для администраторов и работников). status: draft, sent, partially_received, received
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Статус заказа"
+// @Success 200 {array} structs.PurchaseOrder "Заказы"
+// @Failure 400 {object} object "Неверный статус"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении заказов"
+// @Router /api/v1/admin/purchase-orders [get]
+// @Router /api/v1/workers/me/purchase-orders [get]
+func (c *Controller) GetPurchaseOrdersHandler(ctx *gin.Context) {
+	good := c.VerifyWA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get purchase orders")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	pos, err := c.PurchaseService.GetOrders(ctx, ctx.Query("status"))
+	if err != nil {
+		log.Printf("[ERROR] Cant get purchase orders: %v", err)
+		c.writePurchaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pos)
+}
+
+// GetPurchaseOrderHandler получает заказ поставщику
+// @Summary Получить заказ поставщику
+// @Description Возвращает заказ поставщику со строками и расхождениями (для администраторов и работников)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заказа"
+// @Success 200 {object} structs.PurchaseOrder "Заказ"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заказ не найден"
+// @Failure 500 {object} object "Ошибка сервера при получении заказа"
+// @Router /api/v1/admin/purchase-orders/{id} [get]
+// @Router /api/v1/workers/me/purchase-orders/{id} [get]
+func (c *Controller) GetPurchaseOrderHandler(ctx *gin.Context) {
+	good := c.VerifyWA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get purchase order")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse purchase order id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase order ID format"})
+		return
+	}
+
+	po, err := c.PurchaseService.GetOrder(ctx, id)
+	if err != nil {
+		log.Printf("[ERROR] Cant get purchase order: %v", err)
+		c.writePurchaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, po)
+}
+
+// SendPurchaseOrderHandler отправляет заказ поставщику
+// @Summary Отправить заказ поставщику
+// @Description Переводит черновик заказа в статус sent, после чего поставку можно принимать (только для администраторов)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заказа"
+// @Success 200 {object} object "Заказ отправлен"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заказ не найден"
+// @Failure 409 {object} object "Заказ уже отправлен"
+// @Failure 500 {object} object "Ошибка сервера при отправке заказа"
+// @Router /api/v1/admin/purchase-orders/{id}/send [post]
+func (c *Controller) SendPurchaseOrderHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to send purchase order")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse purchase order id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase order ID format"})
+		return
+	}
+
+	if err := c.PurchaseService.Send(ctx, id); err != nil {
+		log.Printf("[ERROR] Cant send purchase order: %v", err)
+		c.writePurchaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Purchase order sent"})
+}
+
+// ReceivePurchaseOrderHandler принимает поставку
+// @Summary Принять поставку
+// @Description Записывает фактически полученное количество по строкам заказа и увеличивает остатки на это количество (только для работников). Заказ закрывается, когда все строки получены полностью или передан final, тогда строки с недопоставкой или перепоставкой записываются как расхождения
+// @Tags workers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заказа"
+// @Param request body PurchaseReceiptRequest true "Поставка"
+// @Success 200 {object} structs.PurchaseOrder "Заказ после приемки"
+// @Failure 400 {object} object "Неверные данные поставки"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заказ или строка не найдены"
+// @Failure 409 {object} object "Заказ не отправлен, уже получен или изменен другой приемкой"
+// @Failure 500 {object} object "Ошибка сервера при приемке"
+// @Router /api/v1/workers/me/purchase-orders/{id}/receipts [post]
+func (c *Controller) ReceivePurchaseOrderHandler(ctx *gin.Context) {
+	good := c.VerifyW(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to receive purchase order")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id_worker, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse purchase order id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase order ID format"})
+		return
+	}
+
+	var input PurchaseReceiptRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	lines := make([]structs.ReceivedLine, len(input.Lines))
+	for i, l := range input.Lines {
+		lines[i] = structs.ReceivedLine{IdLine: l.IdLine, Quantity: l.Quantity}
+	}
+
+	po, err := c.PurchaseService.Receive(ctx, structs.PurchaseReceipt{
+		IdOrder:  id,
+		IdWorker: id_worker,
+		Lines:    lines,
+		Final:    input.Final,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant receive purchase order: %v", err)
+		c.writePurchaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, po)
+}
+
+func (c *Controller) writePurchaseError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrSupplierNotFound),
+		errors.Is(err, structs.ErrPurchaseOrderNotFound),
+		errors.Is(err, structs.ErrPurchaseLineNotFound),
+		errors.Is(err, structs.ErrBrandNotFound),
+		errors.Is(err, structs.ErrProductNotFound),
+		errors.Is(err, structs.ErrVariantNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrDuplicateSupplier),
+		errors.Is(err, structs.ErrSupplierHasOrders),
+		errors.Is(err, structs.ErrPurchaseOrderStatus),
+		errors.Is(err, structs.ErrPurchaseOrderChanged):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidSupplier),
+		errors.Is(err, structs.ErrInvalidPurchaseOrder),
+		errors.Is(err, structs.ErrProductNotSupplied):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
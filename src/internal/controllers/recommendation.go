@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+// GetProductRecommendationsHandler получает товары, которые покупают вместе с товаром
+// @Summary С этим товаром покупают
+// @Description Возвращает товары, которые часто заказывают вместе с указанным, по убыванию достоверности. Товары без остатка и уже лежащие в корзине пользователя не возвращаются. Пары пересчитываются фоновой задачей
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID товара"
+// @Param limit query int false "Количество товаров (по умолчанию 10, не больше 50)"
+// @Success 200 {array} structs.Recommendation "Рекомендации"
+// @Failure 400 {object} object "Неверный формат UUID или лимита"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Товар не найден"
+// @Failure 500 {object} object "Ошибка сервера при получении рекомендаций"
+// @Router /api/v1/products/{id}/recommendations [get]
+func (c *Controller) GetProductRecommendationsHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get product recommendations")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id_user, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	limit, ok := recommendationLimit(ctx)
+	if !ok {
+		return
+	}
+
+	rs, err := c.RecommendationService.ForProduct(ctx, id, id_user, limit)
+	if err != nil {
+		log.Printf("[ERROR] Cant get product recommendations: %v", err)
+		c.writeRecommendationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rs)
+}
+
+// GetBasketRecommendationsHandler получает рекомендации к корзине
+// @Summary Рекомендации к корзине
+// @Description Возвращает товары, которые часто заказывают вместе с товарами корзины текущего пользователя. Товары без остатка и уже лежащие в корзине не возвращаются
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Количество товаров (по умолчанию 10, не больше 50)"
+// @Success 200 {array} structs.Recommendation "Рекомендации"
+// @Failure 400 {object} object "Неверный лимит"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении рекомендаций"
+// @Router /api/v1/users/me/basket/recommendations [get]
+func (c *Controller) GetBasketRecommendationsHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get basket recommendations")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	limit, ok := recommendationLimit(ctx)
+	if !ok {
+		return
+	}
+
+	rs, err := c.RecommendationService.ForBasket(ctx, id, limit)
+	if err != nil {
+		log.Printf("[ERROR] Cant get basket recommendations: %v", err)
+		c.writeRecommendationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rs)
+}
+
+// recommendationLimit parses the optional limit parameter, zero lets the
+// service pick the default.
+func recommendationLimit(ctx *gin.Context) (int, bool) {
+	s := ctx.Query("limit")
+	if s == "" {
+		return 0, true
+	}
+	limit, err := strconv.Atoi(s)
+	if err != nil || limit < 0 {
+		log.Printf("[ERROR] Cant parse limit: %q", s)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return 0, false
+	}
+	return limit, true
+}
+
+func (c *Controller) writeRecommendationError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrProductNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
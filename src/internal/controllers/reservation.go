@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+// StartCheckoutHandler начинает оформление заказа
+// @Summary Начать оформление
+// @Description Резервирует товары корзины текущего пользователя на время оформления. Повторный вызов заменяет прежний резерв и продлевает его. Зарезервированный товар недоступен другим покупателям, резерв снимается при создании заказа, отмене оформления или по истечении срока
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {array} structs.Reservation "Резерв товаров"
+// @Failure 400 {object} object "Корзина пуста"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Товар или вариант не найден"
+// @Failure 409 {object} object "Товара недостаточно на складе"
+// @Failure 500 {object} object "Ошибка сервера при резервировании"
+// @Router /api/v1/users/me/basket/checkout [post]
+func (c *Controller) StartCheckoutHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to start checkout")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	rs, err := c.ReservationService.Reserve(ctx, id)
+	if err != nil {
+		log.Printf("[ERROR] Cant reserve basket: %v", err)
+		c.writeReservationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rs)
+}
+
+// CancelCheckoutHandler отменяет оформление заказа
+// @Summary Отменить оформление
+// @Description Снимает резерв товаров корзины текущего пользователя
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object "Резерв снят"
+// @Failure 400 {object} object "Неверный формат ID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при снятии резерва"
+// @Router /api/v1/users/me/basket/checkout [delete]
+func (c *Controller) CancelCheckoutHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to cancel checkout")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := c.ReservationService.Release(ctx, id); err != nil {
+		log.Printf("[ERROR] Cant release reservations: %v", err)
+		c.writeReservationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Reservations released"})
+}
+
+func (c *Controller) writeReservationError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrProductNotFound),
+		errors.Is(err, structs.ErrVariantNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInsufficientStock):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrEmptyBasket):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,529 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/service/returns"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+const maxReturnUploadSize = returns.MaxPhotos*returns.MaxPhotoSize + 1<<20
+
+type ReturnLineRequest struct {
+	IdOrderItem uuid.UUID `json:"id_order_item" binding:"required"`
+	Amount      int       `json:"amount" binding:"required"`
+}
+
+type CreateReturnRequest struct {
+	Reason string              `json:"reason" binding:"required"`
+	Lines  []ReturnLineRequest `json:"lines" binding:"required"`
+}
+
+type RejectReturnRequest struct {
+	Comment string `json:"comment" binding:"required"`
+}
+
+type ReceivedReturnLineRequest struct {
+	IdLine    uuid.UUID `json:"id_line" binding:"required"`
+	Received  int       `json:"received"`
+	Condition string    `json:"condition" binding:"required"`
+}
+
+type ReturnReceiptRequest struct {
+	Lines []ReceivedReturnLineRequest `json:"lines" binding:"required"`
+}
+
+// CreateReturnHandler создает заявку на возврат
+// @Summary Оформить возврат
+// @Description Создает заявку на возврат части позиций отданного заказа текущего пользователя с указанием количества и причины. Возврат возможен в течение срока возврата с момента выдачи заказа, по каждой позиции нельзя вернуть больше купленного с учетом прежних возвратов
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заказа"
+// @Param request body CreateReturnRequest true "Позиции и причина возврата"
+// @Success 201 {object} object "ID заявки на возврат"
+// @Failure 400 {object} object "Неверные данные возврата"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заказ не найден"
+// @Failure 409 {object} object "Заказ не отдан, срок возврата истек или товар уже возвращен"
+// @Failure 500 {object} object "Ошибка сервера при создании возврата"
+// @Router /api/v1/users/me/orders/{id}/returns [post]
+func (c *Controller) CreateReturnHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to create return")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id_user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id_order, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse order id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID format"})
+		return
+	}
+
+	var input CreateReturnRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	lines := make([]structs.ReturnLine, len(input.Lines))
+	for i, l := range input.Lines {
+		lines[i] = structs.ReturnLine{IdOrderItem: l.IdOrderItem, Amount: l.Amount}
+	}
+
+	id, err := c.ReturnService.Create(ctx, structs.ReturnRequest{
+		IdOrder: id_order,
+		IdUser:  id_user,
+		Reason:  input.Reason,
+		Lines:   lines,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant create return: %v", err)
+		c.writeReturnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// UploadReturnPhotosHandler загружает фотографии к возврату
+// @Summary Загрузить фотографии к возврату
+// @Description Прикладывает фотографии товара (JPEG или PNG до 10 МБ, не больше 5 на заявку) к заявке на возврат текущего пользователя, пока она не рассмотрена
+// @Tags users
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заявки на возврат"
+// @Param photos formData file true "Фотографии"
+// @Success 201 {array} structs.ReturnPhoto "Загруженные фотографии"
+// @Failure 400 {object} object "Неверный формат данных или слишком много фотографий"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заявка не найдена"
+// @Failure 409 {object} object "Заявка уже рассмотрена"
+// @Failure 413 {object} object "Файл слишком большой"
+// @Failure 415 {object} object "Неподдерживаемый тип файла"
+// @Failure 500 {object} object "Ошибка сервера при загрузке"
+// @Router /api/v1/users/me/returns/{id}/photos [post]
+func (c *Controller) UploadReturnPhotosHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to upload return photos")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id_user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse return id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid return ID format"})
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxReturnUploadSize)
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		log.Printf("[ERROR] Cant parse multipart form: %v", err)
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Upload is too large"})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	headers := form.File["photos"]
+	files := make([]structs.ImageFile, 0, len(headers))
+	for _, fh := range headers {
+		if fh.Size > returns.MaxPhotoSize {
+			log.Printf("[ERROR] Photo %s is too large: %d", fh.Filename, fh.Size)
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": structs.ErrImageTooLarge.Error()})
+			return
+		}
+		f, err := fh.Open()
+		if err != nil {
+			log.Printf("[ERROR] Cant open photo: %v", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		data, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			log.Printf("[ERROR] Cant read photo: %v", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		files = append(files, structs.ImageFile{Name: fh.Filename, Data: data})
+	}
+
+	photos, err := c.ReturnService.AddPhotos(ctx.Request.Context(), id, id_user, files)
+	if err != nil {
+		log.Printf("[ERROR] Cant upload return photos: %v", err)
+		c.writeReturnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, photos)
+}
+
+// GetMyReturnsHandler получает возвраты пользователя
+// @Summary Получить свои возвраты
+// @Description Возвращает заявки на возврат текущего пользователя, сначала новые
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} structs.ReturnRequest "Заявки на возврат"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении возвратов"
+// @Router /api/v1/users/me/returns [get]
+func (c *Controller) GetMyReturnsHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get returns")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id_user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	rs, err := c.ReturnService.GetByUser(ctx, id_user)
+	if err != nil {
+		log.Printf("[ERROR] Cant get returns: %v", err)
+		c.writeReturnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rs)
+}
+
+// GetMyReturnHandler получает возврат пользователя
+// @Summary Получить свой возврат
+// @Description Возвращает заявку на возврат текущего пользователя с позициями, фотографиями и суммой возврата денег
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заявки на возврат"
+// @Success 200 {object} structs.ReturnRequest "Заявка на возврат"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заявка не найдена"
+// @Failure 500 {object} object "Ошибка сервера при получении возврата"
+// @Router /api/v1/users/me/returns/{id} [get]
+func (c *Controller) GetMyReturnHandler(ctx *gin.Context) {
+	good := c.Verify(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get return")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id_user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse return id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid return ID format"})
+		return
+	}
+
+	r, err := c.ReturnService.GetOwn(ctx, id, id_user)
+	if err != nil {
+		log.Printf("[ERROR] Cant get return: %v", err)
+		c.writeReturnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// GetReturnsHandler получает заявки на возврат
+// @Summary Получить заявки на возврат
+// @Description Возвращает заявки на возврат, сначала новые, с фильтром по статусу (для администраторов и работников)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Статус заявки" Enums(requested, approved, rejected, received)
+// @Success 200 {array} structs.ReturnRequest "Заявки на возврат"
+// @Failure 400 {object} object "Неверный статус"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении возвратов"
+// @Router /api/v1/admin/returns [get]
+// @Router /api/v1/workers/me/returns [get]
+func (c *Controller) GetReturnsHandler(ctx *gin.Context) {
+	good := c.VerifyWA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get returns")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	rs, err := c.ReturnService.GetAll(ctx, ctx.Query("status"))
+	if err != nil {
+		log.Printf("[ERROR] Cant get returns: %v", err)
+		c.writeReturnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rs)
+}
+
+// GetReturnHandler получает заявку на возврат
+// @Summary Получить заявку на возврат
+// @Description Возвращает заявку на возврат с позициями, фотографиями и суммой возврата денег (для администраторов и работников)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заявки на возврат"
+// @Success 200 {object} structs.ReturnRequest "Заявка на возврат"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заявка не найдена"
+// @Failure 500 {object} object "Ошибка сервера при получении возврата"
+// @Router /api/v1/admin/returns/{id} [get]
+// @Router /api/v1/workers/me/returns/{id} [get]
+func (c *Controller) GetReturnHandler(ctx *gin.Context) {
+	good := c.VerifyWA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get return")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse return id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid return ID format"})
+		return
+	}
+
+	r, err := c.ReturnService.GetById(ctx, id)
+	if err != nil {
+		log.Printf("[ERROR] Cant get return: %v", err)
+		c.writeReturnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// ApproveReturnHandler одобряет возврат
+// @Summary Одобрить возврат
+// @Description Одобряет заявку на возврат, после чего покупатель может передать товар (только для администраторов)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заявки на возврат"
+// @Success 200 {object} object "Возврат одобрен"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заявка не найдена"
+// @Failure 409 {object} object "Заявка уже рассмотрена"
+// @Failure 500 {object} object "Ошибка сервера при одобрении возврата"
+// @Router /api/v1/admin/returns/{id}/approve [post]
+func (c *Controller) ApproveReturnHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to approve return")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id_admin, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse return id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid return ID format"})
+		return
+	}
+
+	if err := c.ReturnService.Approve(ctx, id, id_admin); err != nil {
+		log.Printf("[ERROR] Cant approve return: %v", err)
+		c.writeReturnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Return approved"})
+}
+
+// RejectReturnHandler отклоняет возврат
+// @Summary Отклонить возврат
+// @Description Отклоняет заявку на возврат с указанием причины, которую видит покупатель (только для администраторов). Товар отклоненной заявки можно вернуть новой заявкой
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заявки на возврат"
+// @Param request body RejectReturnRequest true "Причина отказа"
+// @Success 200 {object} object "Возврат отклонен"
+// @Failure 400 {object} object "Неверный формат данных или не указана причина"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заявка не найдена"
+// @Failure 409 {object} object "Заявка уже рассмотрена"
+// @Failure 500 {object} object "Ошибка сервера при отклонении возврата"
+// @Router /api/v1/admin/returns/{id}/reject [post]
+func (c *Controller) RejectReturnHandler(ctx *gin.Context) {
+	good := c.VerifyA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to reject return")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id_admin, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse return id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid return ID format"})
+		return
+	}
+
+	var input RejectReturnRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.ReturnService.Reject(ctx, id, id_admin, input.Comment); err != nil {
+		log.Printf("[ERROR] Cant reject return: %v", err)
+		c.writeReturnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Return rejected"})
+}
+
+// ReceiveReturnHandler принимает возвращенный товар
+// @Summary Принять возврат
+// @Description Записывает по каждой позиции одобренного возврата полученное количество и состояние товара: sellable или damaged (только для работников). Годный товар возвращается на склад в партии, из которых был продан, начиная с самой поздней, на сумму, оплаченную за весь полученный товар, создается возврат денег
+// @Tags workers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID заявки на возврат"
+// @Param request body ReturnReceiptRequest true "Полученный товар"
+// @Success 200 {object} structs.ReturnRequest "Заявка после приемки"
+// @Failure 400 {object} object "Неверные данные приемки"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Заявка не найдена"
+// @Failure 409 {object} object "Возврат не одобрен или уже принят"
+// @Failure 500 {object} object "Ошибка сервера при приемке"
+// @Router /api/v1/workers/me/returns/{id}/receipts [post]
+func (c *Controller) ReceiveReturnHandler(ctx *gin.Context) {
+	good := c.VerifyW(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to receive return")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id_worker, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse return id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid return ID format"})
+		return
+	}
+
+	var input ReturnReceiptRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	lines := make([]structs.ReceivedReturnLine, len(input.Lines))
+	for i, l := range input.Lines {
+		lines[i] = structs.ReceivedReturnLine{IdLine: l.IdLine, Received: l.Received, Condition: l.Condition}
+	}
+
+	r, err := c.ReturnService.Receive(ctx, structs.ReturnReceipt{IdReturn: id, IdWorker: id_worker, Lines: lines})
+	if err != nil {
+		log.Printf("[ERROR] Cant receive return: %v", err)
+		c.writeReturnError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, r)
+}
+
+// currentUser returns the id of the signed in user and answers the request
+// itself when there is none.
+func (c *Controller) currentUser(ctx *gin.Context) (uuid.UUID, bool) {
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return uuid.Nil, false
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) writeReturnError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrReturnNotFound),
+		errors.Is(err, structs.ErrOrderNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrReturnNotAllowed),
+		errors.Is(err, structs.ErrReturnWindowClosed),
+		errors.Is(err, structs.ErrReturnAmountExceeded),
+		errors.Is(err, structs.ErrReturnStatus),
+		errors.Is(err, structs.ErrReturnChanged):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidReturn),
+		errors.Is(err, structs.ErrTooManyReturnPhotos),
+		errors.Is(err, structs.ErrNoImages):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrImageTooLarge):
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrUnsupportedImageType):
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
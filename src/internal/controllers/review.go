@@ -24,10 +24,13 @@ type CreateReviewRequest struct {
 // @Produce json
 // @Security BearerAuth
 // @Param id_product path string true "UUID продукта"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом и телом возвращает первый ответ"
 // @Param request body CreateReviewRequest true "Данные для создания отзыва"
 // @Success 201 {object} object "Отзыв успешно создан"
 // @Failure 400 {object} object "Неверный формат данных"
 // @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 409 {object} object "Запрос с этим ключом идемпотентности еще выполняется"
+// @Failure 422 {object} object "Ключ идемпотентности использован для другого запроса"
 // @Failure 500 {object} object "Ошибка сервера при создании отзыва"
 // @Router /api/v1/users/me/products/{id_product}/reviews [post]
 func (c *Controller) CreateReviewHandler(ctx *gin.Context) {
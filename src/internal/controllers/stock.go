@@ -0,0 +1,302 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type StockReceiptRequest struct {
+	IdProduct uuid.UUID  `json:"id_product" binding:"required"`
+	IdVariant uuid.UUID  `json:"id_variant"`
+	Quantity  int        `json:"quantity" binding:"required"`
+	Reason    string     `json:"reason"`
+	Reference string     `json:"reference"`
+	Batch     string     `json:"batch"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type StockAdjustmentRequest struct {
+	IdProduct uuid.UUID `json:"id_product" binding:"required"`
+	IdVariant uuid.UUID `json:"id_variant"`
+	Kind      string    `json:"kind"`
+	Quantity  int       `json:"quantity" binding:"required"`
+	Reason    string    `json:"reason" binding:"required"`
+	Reference string    `json:"reference"`
+	IdLot     uuid.UUID `json:"id_lot"`
+}
+
+// PostStockReceiptHandler оприходует поступление товара
+// @Summary Оприходовать поступление
+// @Description Записывает поступление товара или варианта в журнал движения и увеличивает остаток (только для работников). reference — номер документа поставки. Если указан batch, товар приходуется в партию с этим номером и сроком годности expires_at; партия создается при первом поступлении
+// @Tags workers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body StockReceiptRequest true "Поступление"
+// @Success 201 {object} structs.StockMovement "Запись журнала"
+// @Failure 400 {object} object "Неверные данные поступления"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Товар или вариант не найден"
+// @Failure 409 {object} object "Срок годности не совпадает с уже принятой партией"
+// @Failure 500 {object} object "Ошибка сервера при оприходовании"
+// @Router /api/v1/workers/me/stock/receipts [post]
+func (c *Controller) PostStockReceiptHandler(ctx *gin.Context) {
+	good := c.VerifyW(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to post stock receipt")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	var input StockReceiptRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	movement := structs.StockMovement{
+		IdProduct: input.IdProduct,
+		IdVariant: input.IdVariant,
+		Quantity:  input.Quantity,
+		Reason:    input.Reason,
+		IdActor:   id,
+		Reference: input.Reference,
+	}
+	var m structs.StockMovement
+	if input.Batch != "" || input.ExpiresAt != nil {
+		lot := structs.StockLot{Batch: input.Batch}
+		if input.ExpiresAt != nil {
+			lot.ExpiresAt = *input.ExpiresAt
+		}
+		m, err = c.StockService.ReceiveLot(ctx, movement, lot)
+	} else {
+		m, err = c.StockService.Receive(ctx, movement)
+	}
+	if err != nil {
+		log.Printf("[ERROR] Cant post stock receipt: %v", err)
+		c.writeStockError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, m)
+}
+
+// PostStockAdjustmentHandler корректирует остаток товара
+// @Summary Скорректировать остаток
+// @Description Записывает в журнал движения корректировку (kind=adjustment, по умолчанию, quantity со знаком), списание (write_off, quantity < 0) или возврат на склад (return, quantity > 0) с обязательной причиной (только для работников). Остаток, хранящийся партиями, списывается с указанием партии id_lot
+// @Tags workers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body StockAdjustmentRequest true "Корректировка"
+// @Success 201 {object} structs.StockMovement "Запись журнала"
+// @Failure 400 {object} object "Неверные данные корректировки"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Товар, вариант или партия не найдены"
+// @Failure 409 {object} object "Недостаточно товара на складе или не указана партия"
+// @Failure 500 {object} object "Ошибка сервера при корректировке"
+// @Router /api/v1/workers/me/stock/adjustments [post]
+func (c *Controller) PostStockAdjustmentHandler(ctx *gin.Context) {
+	good := c.VerifyW(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to post stock adjustment")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	atoken, err := ctx.Cookie("access_token")
+	if err != nil {
+		log.Printf("[ERROR] Cant get access token: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "access token missing"})
+		return
+	}
+
+	id, err := c.AuthServise.GetId(atoken)
+	if err != nil {
+		log.Printf("[ERROR] Cant get user id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	var input StockAdjustmentRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("[ERROR] Cant bind JSON: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	m, err := c.StockService.Adjust(ctx, structs.StockMovement{
+		IdProduct: input.IdProduct,
+		IdVariant: input.IdVariant,
+		Kind:      input.Kind,
+		Quantity:  input.Quantity,
+		Reason:    input.Reason,
+		IdActor:   id,
+		Reference: input.Reference,
+		IdLot:     input.IdLot,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Cant post stock adjustment: %v", err)
+		c.writeStockError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, m)
+}
+
+// GetStockMovementsHandler получает журнал движения товара
+// @Summary Получить движение товара
+// @Description Возвращает записи журнала движения товара и его вариантов, новые первыми (только для работников)
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Success 200 {array} structs.StockMovement "Журнал движения"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Продукт не найден"
+// @Failure 500 {object} object "Ошибка сервера при получении журнала"
+// @Router /api/v1/products/{id}/stock-movements [get]
+func (c *Controller) GetStockMovementsHandler(ctx *gin.Context) {
+	good := c.VerifyW(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get stock movements")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	ms, err := c.StockService.GetHistory(ctx, id)
+	if err != nil {
+		log.Printf("[ERROR] Cant get stock movements: %v", err)
+		c.writeStockError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ms)
+}
+
+// GetProductLotsHandler получает партии товара
+// @Summary Получить партии товара
+// @Description Возвращает партии товара и его вариантов с ненулевым остатком, раньше истекающие первыми (для работников и администраторов)
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "UUID продукта"
+// @Success 200 {array} structs.StockLot "Партии"
+// @Failure 400 {object} object "Неверный формат UUID"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 404 {object} object "Продукт не найден"
+// @Failure 500 {object} object "Ошибка сервера при получении партий"
+// @Router /api/v1/products/{id}/lots [get]
+func (c *Controller) GetProductLotsHandler(ctx *gin.Context) {
+	good := c.VerifyWA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get product lots")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Printf("[ERROR] Cant parse product id: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID format"})
+		return
+	}
+
+	lots, err := c.StockService.GetLots(ctx, id)
+	if err != nil {
+		log.Printf("[ERROR] Cant get product lots: %v", err)
+		c.writeStockError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lots)
+}
+
+// GetExpiringLotsHandler получает отчет по истекающим партиям
+// @Summary Получить истекающие партии
+// @Description Возвращает партии с остатком, срок годности которых истекает в ближайшие days дней (по умолчанию 30), включая уже просроченные. Просроченные партии не продаются (для работников и администраторов)
+// @Tags admins
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Горизонт отчета в днях"
+// @Success 200 {array} structs.StockLot "Истекающие партии"
+// @Failure 400 {object} object "Неверное количество дней"
+// @Failure 401 {object} object "Неавторизованный доступ"
+// @Failure 500 {object} object "Ошибка сервера при получении отчета"
+// @Router /api/v1/admin/lots/expiring [get]
+func (c *Controller) GetExpiringLotsHandler(ctx *gin.Context) {
+	good := c.VerifyWA(ctx)
+	if !good {
+		log.Printf("[ERROR] Cant autorize to get expiring lots")
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	days := 30
+	if d := ctx.Query("days"); d != "" {
+		var err error
+		days, err = strconv.Atoi(d)
+		if err != nil {
+			log.Printf("[ERROR] Cant parse days: %v", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid days"})
+			return
+		}
+	}
+
+	lots, err := c.StockService.GetExpiring(ctx, days)
+	if err != nil {
+		log.Printf("[ERROR] Cant get expiring lots: %v", err)
+		c.writeStockError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lots)
+}
+
+func (c *Controller) writeStockError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, structs.ErrProductNotFound),
+		errors.Is(err, structs.ErrVariantNotFound),
+		errors.Is(err, structs.ErrLotNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInsufficientStock),
+		errors.Is(err, structs.ErrLotExpiryMismatch),
+		errors.Is(err, structs.ErrLotRequired):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, structs.ErrInvalidStockMovement),
+		errors.Is(err, structs.ErrInvalidLot):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
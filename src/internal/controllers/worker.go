@@ -266,6 +266,7 @@ func (c *Controller) GetAllWorkersHandler(ctx *gin.Context) {
 // @Failure 401 {object} object "Неавторизованный доступ"
 // @Failure 403 {object} object "Недостаточно прав"
 // @Failure 404 {object} object "Заказ не найдены"
+// @Failure 409 {object} object "Заказ уже не в статусе непринятый"
 // @Failure 500 {object} object "Ошибка сервера при принятии заказа"
 // @Router /api/v1/workers/me/orders [post]
 func (c *Controller) AcceptOrderHandler(ctx *gin.Context) {
@@ -315,6 +316,11 @@ func (c *Controller) AcceptOrderHandler(ctx *gin.Context) {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Order already accepted"})
 			return
 		}
+
+		if errors.Is(err, structs.ErrIllegalOrderTransition) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": "Only new orders can be accepted"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -0,0 +1,243 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/alert/alert.go
+
+// Package mock_structs is a generated GoMock package.
+package mock_structs
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockAlertService is a mock of AlertService interface.
+type MockAlertService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertServiceMockRecorder
+}
+
+// MockAlertServiceMockRecorder is the mock recorder for MockAlertService.
+type MockAlertServiceMockRecorder struct {
+	mock *MockAlertService
+}
+
+// NewMockAlertService creates a new mock instance.
+func NewMockAlertService(ctrl *gomock.Controller) *MockAlertService {
+	mock := &MockAlertService{ctrl: ctrl}
+	mock.recorder = &MockAlertServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertService) EXPECT() *MockAlertServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockAlertService) Check(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockAlertServiceMockRecorder) Check(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockAlertService)(nil).Check), ctx)
+}
+
+// DeleteRule mocks base method.
+func (m *MockAlertService) DeleteRule(ctx context.Context, id_product uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, id_product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockAlertServiceMockRecorder) DeleteRule(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockAlertService)(nil).DeleteRule), ctx, id_product)
+}
+
+// GetOpen mocks base method.
+func (m *MockAlertService) GetOpen(ctx context.Context) ([]structs.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpen", ctx)
+	ret0, _ := ret[0].([]structs.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpen indicates an expected call of GetOpen.
+func (mr *MockAlertServiceMockRecorder) GetOpen(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpen", reflect.TypeOf((*MockAlertService)(nil).GetOpen), ctx)
+}
+
+// ReorderReport mocks base method.
+func (m *MockAlertService) ReorderReport(ctx context.Context) ([]structs.BrandReorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderReport", ctx)
+	ret0, _ := ret[0].([]structs.BrandReorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderReport indicates an expected call of ReorderReport.
+func (mr *MockAlertServiceMockRecorder) ReorderReport(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderReport", reflect.TypeOf((*MockAlertService)(nil).ReorderReport), ctx)
+}
+
+// SetRule mocks base method.
+func (m *MockAlertService) SetRule(ctx context.Context, r structs.ReorderRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRule", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRule indicates an expected call of SetRule.
+func (mr *MockAlertServiceMockRecorder) SetRule(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRule", reflect.TypeOf((*MockAlertService)(nil).SetRule), ctx, r)
+}
+
+// MockAlertRepository is a mock of AlertRepository interface.
+type MockAlertRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertRepositoryMockRecorder
+}
+
+// MockAlertRepositoryMockRecorder is the mock recorder for MockAlertRepository.
+type MockAlertRepositoryMockRecorder struct {
+	mock *MockAlertRepository
+}
+
+// NewMockAlertRepository creates a new mock instance.
+func NewMockAlertRepository(ctrl *gomock.Controller) *MockAlertRepository {
+	mock := &MockAlertRepository{ctrl: ctrl}
+	mock.recorder = &MockAlertRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertRepository) EXPECT() *MockAlertRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteRule mocks base method.
+func (m *MockAlertRepository) DeleteRule(ctx context.Context, id_product uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, id_product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockAlertRepositoryMockRecorder) DeleteRule(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockAlertRepository)(nil).DeleteRule), ctx, id_product)
+}
+
+// GetOpen mocks base method.
+func (m *MockAlertRepository) GetOpen(ctx context.Context) ([]structs.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpen", ctx)
+	ret0, _ := ret[0].([]structs.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpen indicates an expected call of GetOpen.
+func (mr *MockAlertRepositoryMockRecorder) GetOpen(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpen", reflect.TypeOf((*MockAlertRepository)(nil).GetOpen), ctx)
+}
+
+// GetReorderItems mocks base method.
+func (m *MockAlertRepository) GetReorderItems(ctx context.Context) ([]structs.ReorderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReorderItems", ctx)
+	ret0, _ := ret[0].([]structs.ReorderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReorderItems indicates an expected call of GetReorderItems.
+func (mr *MockAlertRepositoryMockRecorder) GetReorderItems(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReorderItems", reflect.TypeOf((*MockAlertRepository)(nil).GetReorderItems), ctx)
+}
+
+// RaiseAlerts mocks base method.
+func (m *MockAlertRepository) RaiseAlerts(ctx context.Context) ([]structs.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RaiseAlerts", ctx)
+	ret0, _ := ret[0].([]structs.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RaiseAlerts indicates an expected call of RaiseAlerts.
+func (mr *MockAlertRepositoryMockRecorder) RaiseAlerts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RaiseAlerts", reflect.TypeOf((*MockAlertRepository)(nil).RaiseAlerts), ctx)
+}
+
+// SetRule mocks base method.
+func (m *MockAlertRepository) SetRule(ctx context.Context, r structs.ReorderRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRule", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRule indicates an expected call of SetRule.
+func (mr *MockAlertRepositoryMockRecorder) SetRule(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRule", reflect.TypeOf((*MockAlertRepository)(nil).SetRule), ctx, r)
+}
+
+// MockAlertNotifier is a mock of AlertNotifier interface.
+type MockAlertNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertNotifierMockRecorder
+}
+
+// MockAlertNotifierMockRecorder is the mock recorder for MockAlertNotifier.
+type MockAlertNotifierMockRecorder struct {
+	mock *MockAlertNotifier
+}
+
+// NewMockAlertNotifier creates a new mock instance.
+func NewMockAlertNotifier(ctrl *gomock.Controller) *MockAlertNotifier {
+	mock := &MockAlertNotifier{ctrl: ctrl}
+	mock.recorder = &MockAlertNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertNotifier) EXPECT() *MockAlertNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockAlertNotifier) Notify(ctx context.Context, a structs.StockAlert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockAlertNotifierMockRecorder) Notify(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockAlertNotifier)(nil).Notify), ctx, a)
+}
@@ -0,0 +1,382 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/attribute/attribute.go
+
+// Package mock_structs is a generated GoMock package.
+package mock_structs
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockAttributeService is a mock of AttributeService interface.
+type MockAttributeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttributeServiceMockRecorder
+}
+
+// MockAttributeServiceMockRecorder is the mock recorder for MockAttributeService.
+type MockAttributeServiceMockRecorder struct {
+	mock *MockAttributeService
+}
+
+// NewMockAttributeService creates a new mock instance.
+func NewMockAttributeService(ctrl *gomock.Controller) *MockAttributeService {
+	mock := &MockAttributeService{ctrl: ctrl}
+	mock.recorder = &MockAttributeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttributeService) EXPECT() *MockAttributeServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateAttribute mocks base method.
+func (m *MockAttributeService) CreateAttribute(ctx context.Context, a structs.Attribute) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttribute", ctx, a)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttribute indicates an expected call of CreateAttribute.
+func (mr *MockAttributeServiceMockRecorder) CreateAttribute(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttribute", reflect.TypeOf((*MockAttributeService)(nil).CreateAttribute), ctx, a)
+}
+
+// CreateIngredient mocks base method.
+func (m *MockAttributeService) CreateIngredient(ctx context.Context, i structs.Ingredient) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredient", ctx, i)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredient indicates an expected call of CreateIngredient.
+func (mr *MockAttributeServiceMockRecorder) CreateIngredient(ctx, i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredient", reflect.TypeOf((*MockAttributeService)(nil).CreateIngredient), ctx, i)
+}
+
+// DeleteAttribute mocks base method.
+func (m *MockAttributeService) DeleteAttribute(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttribute", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttribute indicates an expected call of DeleteAttribute.
+func (mr *MockAttributeServiceMockRecorder) DeleteAttribute(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttribute", reflect.TypeOf((*MockAttributeService)(nil).DeleteAttribute), ctx, id)
+}
+
+// DeleteIngredient mocks base method.
+func (m *MockAttributeService) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIngredient", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIngredient indicates an expected call of DeleteIngredient.
+func (mr *MockAttributeServiceMockRecorder) DeleteIngredient(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredient", reflect.TypeOf((*MockAttributeService)(nil).DeleteIngredient), ctx, id)
+}
+
+// FindAllergens mocks base method.
+func (m *MockAttributeService) FindAllergens(ctx context.Context, id_user uuid.UUID, id_products []uuid.UUID) (map[uuid.UUID][]structs.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllergens", ctx, id_user, id_products)
+	ret0, _ := ret[0].(map[uuid.UUID][]structs.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllergens indicates an expected call of FindAllergens.
+func (mr *MockAttributeServiceMockRecorder) FindAllergens(ctx, id_user, id_products interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllergens", reflect.TypeOf((*MockAttributeService)(nil).FindAllergens), ctx, id_user, id_products)
+}
+
+// GetAllergens mocks base method.
+func (m *MockAttributeService) GetAllergens(ctx context.Context, id_user uuid.UUID) ([]structs.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllergens", ctx, id_user)
+	ret0, _ := ret[0].([]structs.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllergens indicates an expected call of GetAllergens.
+func (mr *MockAttributeServiceMockRecorder) GetAllergens(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllergens", reflect.TypeOf((*MockAttributeService)(nil).GetAllergens), ctx, id_user)
+}
+
+// GetAttributes mocks base method.
+func (m *MockAttributeService) GetAttributes(ctx context.Context, kind string) ([]structs.Attribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttributes", ctx, kind)
+	ret0, _ := ret[0].([]structs.Attribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttributes indicates an expected call of GetAttributes.
+func (mr *MockAttributeServiceMockRecorder) GetAttributes(ctx, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributes", reflect.TypeOf((*MockAttributeService)(nil).GetAttributes), ctx, kind)
+}
+
+// GetIngredients mocks base method.
+func (m *MockAttributeService) GetIngredients(ctx context.Context) ([]structs.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredients", ctx)
+	ret0, _ := ret[0].([]structs.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredients indicates an expected call of GetIngredients.
+func (mr *MockAttributeServiceMockRecorder) GetIngredients(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredients", reflect.TypeOf((*MockAttributeService)(nil).GetIngredients), ctx)
+}
+
+// GetProductAttributes mocks base method.
+func (m *MockAttributeService) GetProductAttributes(ctx context.Context, id_product uuid.UUID) (structs.ProductAttributes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductAttributes", ctx, id_product)
+	ret0, _ := ret[0].(structs.ProductAttributes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductAttributes indicates an expected call of GetProductAttributes.
+func (mr *MockAttributeServiceMockRecorder) GetProductAttributes(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductAttributes", reflect.TypeOf((*MockAttributeService)(nil).GetProductAttributes), ctx, id_product)
+}
+
+// SetAllergens mocks base method.
+func (m *MockAttributeService) SetAllergens(ctx context.Context, id_user uuid.UUID, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAllergens", ctx, id_user, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAllergens indicates an expected call of SetAllergens.
+func (mr *MockAttributeServiceMockRecorder) SetAllergens(ctx, id_user, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllergens", reflect.TypeOf((*MockAttributeService)(nil).SetAllergens), ctx, id_user, ids)
+}
+
+// SetProductAttributes mocks base method.
+func (m *MockAttributeService) SetProductAttributes(ctx context.Context, a structs.ProductAttributes) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductAttributes", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProductAttributes indicates an expected call of SetProductAttributes.
+func (mr *MockAttributeServiceMockRecorder) SetProductAttributes(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductAttributes", reflect.TypeOf((*MockAttributeService)(nil).SetProductAttributes), ctx, a)
+}
+
+// MockAttributeRepository is a mock of AttributeRepository interface.
+type MockAttributeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttributeRepositoryMockRecorder
+}
+
+// MockAttributeRepositoryMockRecorder is the mock recorder for MockAttributeRepository.
+type MockAttributeRepositoryMockRecorder struct {
+	mock *MockAttributeRepository
+}
+
+// NewMockAttributeRepository creates a new mock instance.
+func NewMockAttributeRepository(ctrl *gomock.Controller) *MockAttributeRepository {
+	mock := &MockAttributeRepository{ctrl: ctrl}
+	mock.recorder = &MockAttributeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttributeRepository) EXPECT() *MockAttributeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAttribute mocks base method.
+func (m *MockAttributeRepository) CreateAttribute(ctx context.Context, a structs.Attribute) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttribute", ctx, a)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttribute indicates an expected call of CreateAttribute.
+func (mr *MockAttributeRepositoryMockRecorder) CreateAttribute(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttribute", reflect.TypeOf((*MockAttributeRepository)(nil).CreateAttribute), ctx, a)
+}
+
+// CreateIngredient mocks base method.
+func (m *MockAttributeRepository) CreateIngredient(ctx context.Context, i structs.Ingredient) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredient", ctx, i)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredient indicates an expected call of CreateIngredient.
+func (mr *MockAttributeRepositoryMockRecorder) CreateIngredient(ctx, i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredient", reflect.TypeOf((*MockAttributeRepository)(nil).CreateIngredient), ctx, i)
+}
+
+// DeleteAttribute mocks base method.
+func (m *MockAttributeRepository) DeleteAttribute(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttribute", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttribute indicates an expected call of DeleteAttribute.
+func (mr *MockAttributeRepositoryMockRecorder) DeleteAttribute(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttribute", reflect.TypeOf((*MockAttributeRepository)(nil).DeleteAttribute), ctx, id)
+}
+
+// DeleteIngredient mocks base method.
+func (m *MockAttributeRepository) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIngredient", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIngredient indicates an expected call of DeleteIngredient.
+func (mr *MockAttributeRepositoryMockRecorder) DeleteIngredient(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredient", reflect.TypeOf((*MockAttributeRepository)(nil).DeleteIngredient), ctx, id)
+}
+
+// FindAllergens mocks base method.
+func (m *MockAttributeRepository) FindAllergens(ctx context.Context, id_user uuid.UUID, id_products []uuid.UUID) (map[uuid.UUID][]structs.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllergens", ctx, id_user, id_products)
+	ret0, _ := ret[0].(map[uuid.UUID][]structs.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllergens indicates an expected call of FindAllergens.
+func (mr *MockAttributeRepositoryMockRecorder) FindAllergens(ctx, id_user, id_products interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllergens", reflect.TypeOf((*MockAttributeRepository)(nil).FindAllergens), ctx, id_user, id_products)
+}
+
+// GetAllergens mocks base method.
+func (m *MockAttributeRepository) GetAllergens(ctx context.Context, id_user uuid.UUID) ([]structs.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllergens", ctx, id_user)
+	ret0, _ := ret[0].([]structs.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllergens indicates an expected call of GetAllergens.
+func (mr *MockAttributeRepositoryMockRecorder) GetAllergens(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllergens", reflect.TypeOf((*MockAttributeRepository)(nil).GetAllergens), ctx, id_user)
+}
+
+// GetAttributes mocks base method.
+func (m *MockAttributeRepository) GetAttributes(ctx context.Context, kind string) ([]structs.Attribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttributes", ctx, kind)
+	ret0, _ := ret[0].([]structs.Attribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttributes indicates an expected call of GetAttributes.
+func (mr *MockAttributeRepositoryMockRecorder) GetAttributes(ctx, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributes", reflect.TypeOf((*MockAttributeRepository)(nil).GetAttributes), ctx, kind)
+}
+
+// GetIngredients mocks base method.
+func (m *MockAttributeRepository) GetIngredients(ctx context.Context) ([]structs.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredients", ctx)
+	ret0, _ := ret[0].([]structs.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredients indicates an expected call of GetIngredients.
+func (mr *MockAttributeRepositoryMockRecorder) GetIngredients(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredients", reflect.TypeOf((*MockAttributeRepository)(nil).GetIngredients), ctx)
+}
+
+// GetProductAttributes mocks base method.
+func (m *MockAttributeRepository) GetProductAttributes(ctx context.Context, id_product uuid.UUID) (structs.ProductAttributes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductAttributes", ctx, id_product)
+	ret0, _ := ret[0].(structs.ProductAttributes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductAttributes indicates an expected call of GetProductAttributes.
+func (mr *MockAttributeRepositoryMockRecorder) GetProductAttributes(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductAttributes", reflect.TypeOf((*MockAttributeRepository)(nil).GetProductAttributes), ctx, id_product)
+}
+
+// SetAllergens mocks base method.
+func (m *MockAttributeRepository) SetAllergens(ctx context.Context, id_user uuid.UUID, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAllergens", ctx, id_user, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAllergens indicates an expected call of SetAllergens.
+func (mr *MockAttributeRepositoryMockRecorder) SetAllergens(ctx, id_user, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllergens", reflect.TypeOf((*MockAttributeRepository)(nil).SetAllergens), ctx, id_user, ids)
+}
+
+// SetProductAttributes mocks base method.
+func (m *MockAttributeRepository) SetProductAttributes(ctx context.Context, a structs.ProductAttributes) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductAttributes", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProductAttributes indicates an expected call of SetProductAttributes.
+func (mr *MockAttributeRepositoryMockRecorder) SetProductAttributes(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductAttributes", reflect.TypeOf((*MockAttributeRepository)(nil).SetProductAttributes), ctx, a)
+}
@@ -65,17 +65,17 @@ func (mr *MockBasketServiceMockRecorder) Create(ctx, b interface{}) *gomock.Call
 }
 
 // DeleteItem mocks base method.
-func (m *MockBasketService) DeleteItem(ctx context.Context, id, product_id uuid.UUID) error {
+func (m *MockBasketService) DeleteItem(ctx context.Context, id, product_id, variant_id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteItem", ctx, id, product_id)
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, id, product_id, variant_id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteItem indicates an expected call of DeleteItem.
-func (mr *MockBasketServiceMockRecorder) DeleteItem(ctx, id, product_id interface{}) *gomock.Call {
+func (mr *MockBasketServiceMockRecorder) DeleteItem(ctx, id, product_id, variant_id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockBasketService)(nil).DeleteItem), ctx, id, product_id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockBasketService)(nil).DeleteItem), ctx, id, product_id, variant_id)
 }
 
 // GetById mocks base method.
@@ -109,17 +109,17 @@ func (mr *MockBasketServiceMockRecorder) GetItems(ctx, id_basket interface{}) *g
 }
 
 // UpdateItemAmount mocks base method.
-func (m *MockBasketService) UpdateItemAmount(ctx context.Context, user_id, product_id uuid.UUID, amount int) error {
+func (m *MockBasketService) UpdateItemAmount(ctx context.Context, user_id, product_id, variant_id uuid.UUID, amount int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItemAmount", ctx, user_id, product_id, amount)
+	ret := m.ctrl.Call(m, "UpdateItemAmount", ctx, user_id, product_id, variant_id, amount)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateItemAmount indicates an expected call of UpdateItemAmount.
-func (mr *MockBasketServiceMockRecorder) UpdateItemAmount(ctx, user_id, product_id, amount interface{}) *gomock.Call {
+func (mr *MockBasketServiceMockRecorder) UpdateItemAmount(ctx, user_id, product_id, variant_id, amount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemAmount", reflect.TypeOf((*MockBasketService)(nil).UpdateItemAmount), ctx, user_id, product_id, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemAmount", reflect.TypeOf((*MockBasketService)(nil).UpdateItemAmount), ctx, user_id, product_id, variant_id, amount)
 }
 
 // MockBasketRepository is a mock of BasketRepository interface.
@@ -174,17 +174,17 @@ func (mr *MockBasketRepositoryMockRecorder) Create(ctx, b interface{}) *gomock.C
 }
 
 // DeleteItem mocks base method.
-func (m *MockBasketRepository) DeleteItem(ctx context.Context, id, product_id uuid.UUID) error {
+func (m *MockBasketRepository) DeleteItem(ctx context.Context, id, product_id, variant_id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteItem", ctx, id, product_id)
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, id, product_id, variant_id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteItem indicates an expected call of DeleteItem.
-func (mr *MockBasketRepositoryMockRecorder) DeleteItem(ctx, id, product_id interface{}) *gomock.Call {
+func (mr *MockBasketRepositoryMockRecorder) DeleteItem(ctx, id, product_id, variant_id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockBasketRepository)(nil).DeleteItem), ctx, id, product_id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockBasketRepository)(nil).DeleteItem), ctx, id, product_id, variant_id)
 }
 
 // GetBIdByUId mocks base method.
@@ -233,15 +233,15 @@ func (mr *MockBasketRepositoryMockRecorder) GetItems(ctx, id_basket interface{})
 }
 
 // UpdateItemAmount mocks base method.
-func (m *MockBasketRepository) UpdateItemAmount(ctx context.Context, basket_id, product_id uuid.UUID, amount int) error {
+func (m *MockBasketRepository) UpdateItemAmount(ctx context.Context, basket_id, product_id, variant_id uuid.UUID, amount int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItemAmount", ctx, basket_id, product_id, amount)
+	ret := m.ctrl.Call(m, "UpdateItemAmount", ctx, basket_id, product_id, variant_id, amount)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateItemAmount indicates an expected call of UpdateItemAmount.
-func (mr *MockBasketRepositoryMockRecorder) UpdateItemAmount(ctx, basket_id, product_id, amount interface{}) *gomock.Call {
+func (mr *MockBasketRepositoryMockRecorder) UpdateItemAmount(ctx, basket_id, product_id, variant_id, amount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemAmount", reflect.TypeOf((*MockBasketRepository)(nil).UpdateItemAmount), ctx, basket_id, product_id, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemAmount", reflect.TypeOf((*MockBasketRepository)(nil).UpdateItemAmount), ctx, basket_id, product_id, variant_id, amount)
 }
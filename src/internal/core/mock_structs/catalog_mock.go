@@ -0,0 +1,229 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/catalog/catalog.go
+
+// Package mock_structs is a generated GoMock package.
+package mock_structs
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockCatalogService is a mock of CatalogService interface.
+type MockCatalogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogServiceMockRecorder
+}
+
+// MockCatalogServiceMockRecorder is the mock recorder for MockCatalogService.
+type MockCatalogServiceMockRecorder struct {
+	mock *MockCatalogService
+}
+
+// NewMockCatalogService creates a new mock instance.
+func NewMockCatalogService(ctrl *gomock.Controller) *MockCatalogService {
+	mock := &MockCatalogService{ctrl: ctrl}
+	mock.recorder = &MockCatalogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogService) EXPECT() *MockCatalogServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockCatalogService) Export(ctx context.Context, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockCatalogServiceMockRecorder) Export(ctx, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockCatalogService)(nil).Export), ctx, format, w)
+}
+
+// Import mocks base method.
+func (m *MockCatalogService) Import(ctx context.Context, rows []structs.CatalogImportRow, dryRun bool) (structs.CatalogImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, rows, dryRun)
+	ret0, _ := ret[0].(structs.CatalogImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockCatalogServiceMockRecorder) Import(ctx, rows, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockCatalogService)(nil).Import), ctx, rows, dryRun)
+}
+
+// MockCatalogRepository is a mock of CatalogRepository interface.
+type MockCatalogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogRepositoryMockRecorder
+}
+
+// MockCatalogRepositoryMockRecorder is the mock recorder for MockCatalogRepository.
+type MockCatalogRepositoryMockRecorder struct {
+	mock *MockCatalogRepository
+}
+
+// NewMockCatalogRepository creates a new mock instance.
+func NewMockCatalogRepository(ctrl *gomock.Controller) *MockCatalogRepository {
+	mock := &MockCatalogRepository{ctrl: ctrl}
+	mock.recorder = &MockCatalogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogRepository) EXPECT() *MockCatalogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExistingArticules mocks base method.
+func (m *MockCatalogRepository) ExistingArticules(ctx context.Context, arts []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingArticules", ctx, arts)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingArticules indicates an expected call of ExistingArticules.
+func (mr *MockCatalogRepositoryMockRecorder) ExistingArticules(ctx, arts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingArticules", reflect.TypeOf((*MockCatalogRepository)(nil).ExistingArticules), ctx, arts)
+}
+
+// ExistingBrands mocks base method.
+func (m *MockCatalogRepository) ExistingBrands(ctx context.Context, names []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingBrands", ctx, names)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingBrands indicates an expected call of ExistingBrands.
+func (mr *MockCatalogRepositoryMockRecorder) ExistingBrands(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingBrands", reflect.TypeOf((*MockCatalogRepository)(nil).ExistingBrands), ctx, names)
+}
+
+// ExportCategories mocks base method.
+func (m *MockCatalogRepository) ExportCategories(ctx context.Context) ([]structs.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCategories", ctx)
+	ret0, _ := ret[0].([]structs.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCategories indicates an expected call of ExportCategories.
+func (mr *MockCatalogRepositoryMockRecorder) ExportCategories(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCategories", reflect.TypeOf((*MockCatalogRepository)(nil).ExportCategories), ctx)
+}
+
+// ExportProducts mocks base method.
+func (m *MockCatalogRepository) ExportProducts(ctx context.Context, fn func(structs.CatalogExportItem) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProducts", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockCatalogRepositoryMockRecorder) ExportProducts(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockCatalogRepository)(nil).ExportProducts), ctx, fn)
+}
+
+// FindCategories mocks base method.
+func (m *MockCatalogRepository) FindCategories(ctx context.Context, keys []string) ([]structs.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCategories", ctx, keys)
+	ret0, _ := ret[0].([]structs.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCategories indicates an expected call of FindCategories.
+func (mr *MockCatalogRepositoryMockRecorder) FindCategories(ctx, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCategories", reflect.TypeOf((*MockCatalogRepository)(nil).FindCategories), ctx, keys)
+}
+
+// Import mocks base method.
+func (m *MockCatalogRepository) Import(ctx context.Context, rows []structs.CatalogImportRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockCatalogRepositoryMockRecorder) Import(ctx, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockCatalogRepository)(nil).Import), ctx, rows)
+}
+
+// VariantArticules mocks base method.
+func (m *MockCatalogRepository) VariantArticules(ctx context.Context, arts []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VariantArticules", ctx, arts)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VariantArticules indicates an expected call of VariantArticules.
+func (mr *MockCatalogRepositoryMockRecorder) VariantArticules(ctx, arts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariantArticules", reflect.TypeOf((*MockCatalogRepository)(nil).VariantArticules), ctx, arts)
+}
+
+// MockImageStorage is a mock of ImageStorage interface.
+type MockImageStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageStorageMockRecorder
+}
+
+// MockImageStorageMockRecorder is the mock recorder for MockImageStorage.
+type MockImageStorageMockRecorder struct {
+	mock *MockImageStorage
+}
+
+// NewMockImageStorage creates a new mock instance.
+func NewMockImageStorage(ctrl *gomock.Controller) *MockImageStorage {
+	mock := &MockImageStorage{ctrl: ctrl}
+	mock.recorder = &MockImageStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageStorage) EXPECT() *MockImageStorageMockRecorder {
+	return m.recorder
+}
+
+// URL mocks base method.
+func (m *MockImageStorage) URL(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URL", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// URL indicates an expected call of URL.
+func (mr *MockImageStorageMockRecorder) URL(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockImageStorage)(nil).URL), key)
+}
@@ -0,0 +1,325 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/delivery/delivery.go
+
+// Package mock_structs is a generated GoMock package.
+package mock_structs
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockDeliveryService is a mock of DeliveryService interface.
+type MockDeliveryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryServiceMockRecorder
+}
+
+// MockDeliveryServiceMockRecorder is the mock recorder for MockDeliveryService.
+type MockDeliveryServiceMockRecorder struct {
+	mock *MockDeliveryService
+}
+
+// NewMockDeliveryService creates a new mock instance.
+func NewMockDeliveryService(ctrl *gomock.Controller) *MockDeliveryService {
+	mock := &MockDeliveryService{ctrl: ctrl}
+	mock.recorder = &MockDeliveryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryService) EXPECT() *MockDeliveryServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m_2 *MockDeliveryService) Create(ctx context.Context, m structs.DeliveryMethod) (uuid.UUID, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDeliveryServiceMockRecorder) Create(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeliveryService)(nil).Create), ctx, m)
+}
+
+// CreateSlot mocks base method.
+func (m *MockDeliveryService) CreateSlot(ctx context.Context, slot structs.DeliverySlot) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSlot", ctx, slot)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSlot indicates an expected call of CreateSlot.
+func (mr *MockDeliveryServiceMockRecorder) CreateSlot(ctx, slot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSlot", reflect.TypeOf((*MockDeliveryService)(nil).CreateSlot), ctx, slot)
+}
+
+// Deactivate mocks base method.
+func (m *MockDeliveryService) Deactivate(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockDeliveryServiceMockRecorder) Deactivate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockDeliveryService)(nil).Deactivate), ctx, id)
+}
+
+// DeleteSlot mocks base method.
+func (m *MockDeliveryService) DeleteSlot(ctx context.Context, id_delivery, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSlot", ctx, id_delivery, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSlot indicates an expected call of DeleteSlot.
+func (mr *MockDeliveryServiceMockRecorder) DeleteSlot(ctx, id_delivery, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSlot", reflect.TypeOf((*MockDeliveryService)(nil).DeleteSlot), ctx, id_delivery, id)
+}
+
+// GetActive mocks base method.
+func (m *MockDeliveryService) GetActive(ctx context.Context) ([]structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx)
+	ret0, _ := ret[0].([]structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockDeliveryServiceMockRecorder) GetActive(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockDeliveryService)(nil).GetActive), ctx)
+}
+
+// GetAll mocks base method.
+func (m *MockDeliveryService) GetAll(ctx context.Context) ([]structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDeliveryServiceMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDeliveryService)(nil).GetAll), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockDeliveryService) GetById(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockDeliveryServiceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockDeliveryService)(nil).GetById), ctx, id)
+}
+
+// GetSlots mocks base method.
+func (m *MockDeliveryService) GetSlots(ctx context.Context, id_delivery uuid.UUID, from, to time.Time) ([]structs.DeliverySlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlots", ctx, id_delivery, from, to)
+	ret0, _ := ret[0].([]structs.DeliverySlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlots indicates an expected call of GetSlots.
+func (mr *MockDeliveryServiceMockRecorder) GetSlots(ctx, id_delivery, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlots", reflect.TypeOf((*MockDeliveryService)(nil).GetSlots), ctx, id_delivery, from, to)
+}
+
+// SetRates mocks base method.
+func (m *MockDeliveryService) SetRates(ctx context.Context, id uuid.UUID, rates []structs.DeliveryRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRates", ctx, id, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRates indicates an expected call of SetRates.
+func (mr *MockDeliveryServiceMockRecorder) SetRates(ctx, id, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRates", reflect.TypeOf((*MockDeliveryService)(nil).SetRates), ctx, id, rates)
+}
+
+// MockDeliveryRepository is a mock of DeliveryRepository interface.
+type MockDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryRepositoryMockRecorder
+}
+
+// MockDeliveryRepositoryMockRecorder is the mock recorder for MockDeliveryRepository.
+type MockDeliveryRepositoryMockRecorder struct {
+	mock *MockDeliveryRepository
+}
+
+// NewMockDeliveryRepository creates a new mock instance.
+func NewMockDeliveryRepository(ctrl *gomock.Controller) *MockDeliveryRepository {
+	mock := &MockDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryRepository) EXPECT() *MockDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m_2 *MockDeliveryRepository) Create(ctx context.Context, m structs.DeliveryMethod) (uuid.UUID, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDeliveryRepositoryMockRecorder) Create(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeliveryRepository)(nil).Create), ctx, m)
+}
+
+// CreateSlot mocks base method.
+func (m *MockDeliveryRepository) CreateSlot(ctx context.Context, slot structs.DeliverySlot) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSlot", ctx, slot)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSlot indicates an expected call of CreateSlot.
+func (mr *MockDeliveryRepositoryMockRecorder) CreateSlot(ctx, slot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSlot", reflect.TypeOf((*MockDeliveryRepository)(nil).CreateSlot), ctx, slot)
+}
+
+// Deactivate mocks base method.
+func (m *MockDeliveryRepository) Deactivate(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockDeliveryRepositoryMockRecorder) Deactivate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockDeliveryRepository)(nil).Deactivate), ctx, id)
+}
+
+// DeleteSlot mocks base method.
+func (m *MockDeliveryRepository) DeleteSlot(ctx context.Context, id_delivery, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSlot", ctx, id_delivery, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSlot indicates an expected call of DeleteSlot.
+func (mr *MockDeliveryRepositoryMockRecorder) DeleteSlot(ctx, id_delivery, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSlot", reflect.TypeOf((*MockDeliveryRepository)(nil).DeleteSlot), ctx, id_delivery, id)
+}
+
+// GetActive mocks base method.
+func (m *MockDeliveryRepository) GetActive(ctx context.Context) ([]structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx)
+	ret0, _ := ret[0].([]structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockDeliveryRepositoryMockRecorder) GetActive(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockDeliveryRepository)(nil).GetActive), ctx)
+}
+
+// GetAll mocks base method.
+func (m *MockDeliveryRepository) GetAll(ctx context.Context) ([]structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDeliveryRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDeliveryRepository)(nil).GetAll), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockDeliveryRepository) GetById(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockDeliveryRepositoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockDeliveryRepository)(nil).GetById), ctx, id)
+}
+
+// GetSlots mocks base method.
+func (m *MockDeliveryRepository) GetSlots(ctx context.Context, id_delivery uuid.UUID, from, to time.Time) ([]structs.DeliverySlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlots", ctx, id_delivery, from, to)
+	ret0, _ := ret[0].([]structs.DeliverySlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlots indicates an expected call of GetSlots.
+func (mr *MockDeliveryRepositoryMockRecorder) GetSlots(ctx, id_delivery, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlots", reflect.TypeOf((*MockDeliveryRepository)(nil).GetSlots), ctx, id_delivery, from, to)
+}
+
+// SetRates mocks base method.
+func (m *MockDeliveryRepository) SetRates(ctx context.Context, id uuid.UUID, rates []structs.DeliveryRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRates", ctx, id, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRates indicates an expected call of SetRates.
+func (mr *MockDeliveryRepositoryMockRecorder) SetRates(ctx, id, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRates", reflect.TypeOf((*MockDeliveryRepository)(nil).SetRates), ctx, id, rates)
+}
@@ -6,6 +6,7 @@ package mock_structs
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -36,32 +37,47 @@ func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
 	return m.recorder
 }
 
-// ChangeOrderStatus mocks base method.
-func (m *MockOrderService) ChangeOrderStatus(ctx context.Context, id uuid.UUID) error {
+// Cancel mocks base method.
+func (m *MockOrderService) Cancel(ctx context.Context, ch structs.OrderStatusChange) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangeOrderStatus", ctx, id)
+	ret := m.ctrl.Call(m, "Cancel", ctx, ch)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ChangeOrderStatus indicates an expected call of ChangeOrderStatus.
-func (mr *MockOrderServiceMockRecorder) ChangeOrderStatus(ctx, id interface{}) *gomock.Call {
+// Cancel indicates an expected call of Cancel.
+func (mr *MockOrderServiceMockRecorder) Cancel(ctx, ch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeOrderStatus", reflect.TypeOf((*MockOrderService)(nil).ChangeOrderStatus), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockOrderService)(nil).Cancel), ctx, ch)
 }
 
-// Create mocks base method.
-func (m *MockOrderService) Create(ctx context.Context, o structs.Order) error {
+// ChangeOrderStatus mocks base method.
+func (m *MockOrderService) ChangeOrderStatus(ctx context.Context, ch structs.OrderStatusChange) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, o)
+	ret := m.ctrl.Call(m, "ChangeOrderStatus", ctx, ch)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockOrderServiceMockRecorder) Create(ctx, o interface{}) *gomock.Call {
+// ChangeOrderStatus indicates an expected call of ChangeOrderStatus.
+func (mr *MockOrderServiceMockRecorder) ChangeOrderStatus(ctx, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeOrderStatus", reflect.TypeOf((*MockOrderService)(nil).ChangeOrderStatus), ctx, ch)
+}
+
+// Checkout mocks base method.
+func (m *MockOrderService) Checkout(ctx context.Context, o structs.Order, code string) (structs.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, o, code)
+	ret0, _ := ret[0].(structs.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockOrderServiceMockRecorder) Checkout(ctx, o, code interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderService)(nil).Create), ctx, o)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockOrderService)(nil).Checkout), ctx, o, code)
 }
 
 // Delete mocks base method.
@@ -78,6 +94,35 @@ func (mr *MockOrderServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderService)(nil).Delete), ctx, id)
 }
 
+// Export mocks base method.
+func (m *MockOrderService) Export(ctx context.Context, f structs.OrderFilter, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, f, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockOrderServiceMockRecorder) Export(ctx, f, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockOrderService)(nil).Export), ctx, f, w)
+}
+
+// GetAllOrders mocks base method.
+func (m *MockOrderService) GetAllOrders(ctx context.Context) ([]structs.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOrders", ctx)
+	ret0, _ := ret[0].([]structs.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOrders indicates an expected call of GetAllOrders.
+func (mr *MockOrderServiceMockRecorder) GetAllOrders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderService)(nil).GetAllOrders), ctx)
+}
+
 // GetById mocks base method.
 func (m *MockOrderService) GetById(ctx context.Context, id uuid.UUID) (structs.Order, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +153,21 @@ func (mr *MockOrderServiceMockRecorder) GetFreeOrders(ctx interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFreeOrders", reflect.TypeOf((*MockOrderService)(nil).GetFreeOrders), ctx)
 }
 
+// GetHistory mocks base method.
+func (m *MockOrderService) GetHistory(ctx context.Context, id_order, id_user uuid.UUID) ([]structs.OrderStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id_order, id_user)
+	ret0, _ := ret[0].([]structs.OrderStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockOrderServiceMockRecorder) GetHistory(ctx, id_order, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockOrderService)(nil).GetHistory), ctx, id_order, id_user)
+}
+
 // GetItems mocks base method.
 func (m *MockOrderService) GetItems(ctx context.Context, id uuid.UUID) ([]structs.OrderItem, error) {
 	m.ctrl.T.Helper()
@@ -139,10 +199,10 @@ func (mr *MockOrderServiceMockRecorder) GetOrdersByUser(ctx, id interface{}) *go
 }
 
 // GetStatus mocks base method.
-func (m *MockOrderService) GetStatus(ctx context.Context, id uuid.UUID) (string, error) {
+func (m *MockOrderService) GetStatus(ctx context.Context, id uuid.UUID) (structs.OrderStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStatus", ctx, id)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(structs.OrderStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -153,6 +213,36 @@ func (mr *MockOrderServiceMockRecorder) GetStatus(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockOrderService)(nil).GetStatus), ctx, id)
 }
 
+// Repeat mocks base method.
+func (m *MockOrderService) Repeat(ctx context.Context, id_order, id_user uuid.UUID) ([]structs.RepeatItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repeat", ctx, id_order, id_user)
+	ret0, _ := ret[0].([]structs.RepeatItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Repeat indicates an expected call of Repeat.
+func (mr *MockOrderServiceMockRecorder) Repeat(ctx, id_order, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repeat", reflect.TypeOf((*MockOrderService)(nil).Repeat), ctx, id_order, id_user)
+}
+
+// Search mocks base method.
+func (m *MockOrderService) Search(ctx context.Context, f structs.OrderFilter, cursor string, limit int) (structs.OrderPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, f, cursor, limit)
+	ret0, _ := ret[0].(structs.OrderPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockOrderServiceMockRecorder) Search(ctx, f, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockOrderService)(nil).Search), ctx, f, cursor, limit)
+}
+
 // MockOrderRepository is a mock of OrderRepository interface.
 type MockOrderRepository struct {
 	ctrl     *gomock.Controller
@@ -176,32 +266,47 @@ func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockOrderRepository) Create(ctx context.Context, o structs.Order) error {
+// Delete mocks base method.
+func (m *MockOrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, o)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockOrderRepositoryMockRecorder) Create(ctx, o interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockOrderRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderRepository)(nil).Create), ctx, o)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepository)(nil).Delete), ctx, id)
 }
 
-// Delete mocks base method.
-func (m *MockOrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// Export mocks base method.
+func (m *MockOrderRepository) Export(ctx context.Context, f structs.OrderFilter, fn func(structs.OrderExportRow) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Export", ctx, f, fn)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockOrderRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+// Export indicates an expected call of Export.
+func (mr *MockOrderRepositoryMockRecorder) Export(ctx, f, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockOrderRepository)(nil).Export), ctx, f, fn)
+}
+
+// GetAllOrders mocks base method.
+func (m *MockOrderRepository) GetAllOrders(ctx context.Context) ([]structs.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOrders", ctx)
+	ret0, _ := ret[0].([]structs.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOrders indicates an expected call of GetAllOrders.
+func (mr *MockOrderRepositoryMockRecorder) GetAllOrders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderRepository)(nil).GetAllOrders), ctx)
 }
 
 // GetById mocks base method.
@@ -234,6 +339,21 @@ func (mr *MockOrderRepositoryMockRecorder) GetFreeOrders(ctx interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFreeOrders", reflect.TypeOf((*MockOrderRepository)(nil).GetFreeOrders), ctx)
 }
 
+// GetHistory mocks base method.
+func (m *MockOrderRepository) GetHistory(ctx context.Context, id uuid.UUID) ([]structs.OrderStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id)
+	ret0, _ := ret[0].([]structs.OrderStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockOrderRepositoryMockRecorder) GetHistory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockOrderRepository)(nil).GetHistory), ctx, id)
+}
+
 // GetItems mocks base method.
 func (m *MockOrderRepository) GetItems(ctx context.Context, id uuid.UUID) ([]structs.OrderItem, error) {
 	m.ctrl.T.Helper()
@@ -249,6 +369,21 @@ func (mr *MockOrderRepositoryMockRecorder) GetItems(ctx, id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockOrderRepository)(nil).GetItems), ctx, id)
 }
 
+// GetItemsByOrders mocks base method.
+func (m *MockOrderRepository) GetItemsByOrders(ctx context.Context, ids []uuid.UUID) ([]structs.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsByOrders", ctx, ids)
+	ret0, _ := ret[0].([]structs.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsByOrders indicates an expected call of GetItemsByOrders.
+func (mr *MockOrderRepositoryMockRecorder) GetItemsByOrders(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsByOrders", reflect.TypeOf((*MockOrderRepository)(nil).GetItemsByOrders), ctx, ids)
+}
+
 // GetOrdersByUser mocks base method.
 func (m *MockOrderRepository) GetOrdersByUser(ctx context.Context, id uuid.UUID) ([]structs.Order, error) {
 	m.ctrl.T.Helper()
@@ -264,11 +399,26 @@ func (mr *MockOrderRepositoryMockRecorder) GetOrdersByUser(ctx, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUser", reflect.TypeOf((*MockOrderRepository)(nil).GetOrdersByUser), ctx, id)
 }
 
+// GetRepeatLines mocks base method.
+func (m *MockOrderRepository) GetRepeatLines(ctx context.Context, id_order, id_user uuid.UUID) ([]structs.RepeatLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepeatLines", ctx, id_order, id_user)
+	ret0, _ := ret[0].([]structs.RepeatLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepeatLines indicates an expected call of GetRepeatLines.
+func (mr *MockOrderRepositoryMockRecorder) GetRepeatLines(ctx, id_order, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepeatLines", reflect.TypeOf((*MockOrderRepository)(nil).GetRepeatLines), ctx, id_order, id_user)
+}
+
 // GetStatus mocks base method.
-func (m *MockOrderRepository) GetStatus(ctx context.Context, id uuid.UUID) (string, error) {
+func (m *MockOrderRepository) GetStatus(ctx context.Context, id uuid.UUID) (structs.OrderStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStatus", ctx, id)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(structs.OrderStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -279,16 +429,346 @@ func (mr *MockOrderRepositoryMockRecorder) GetStatus(ctx, id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockOrderRepository)(nil).GetStatus), ctx, id)
 }
 
+// Search mocks base method.
+func (m *MockOrderRepository) Search(ctx context.Context, f structs.OrderFilter, after *structs.OrderCursor, limit int) ([]structs.OrderSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, f, after, limit)
+	ret0, _ := ret[0].([]structs.OrderSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockOrderRepositoryMockRecorder) Search(ctx, f, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockOrderRepository)(nil).Search), ctx, f, after, limit)
+}
+
 // UpdateStatus mocks base method.
-func (m *MockOrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+func (m *MockOrderRepository) UpdateStatus(ctx context.Context, ch structs.OrderStatusChange) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, ch)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockOrderRepositoryMockRecorder) UpdateStatus(ctx, id, status interface{}) *gomock.Call {
+func (mr *MockOrderRepositoryMockRecorder) UpdateStatus(ctx, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepository)(nil).UpdateStatus), ctx, ch)
+}
+
+// MockCheckoutRepository is a mock of CheckoutRepository interface.
+type MockCheckoutRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckoutRepositoryMockRecorder
+}
+
+// MockCheckoutRepositoryMockRecorder is the mock recorder for MockCheckoutRepository.
+type MockCheckoutRepositoryMockRecorder struct {
+	mock *MockCheckoutRepository
+}
+
+// NewMockCheckoutRepository creates a new mock instance.
+func NewMockCheckoutRepository(ctrl *gomock.Controller) *MockCheckoutRepository {
+	mock := &MockCheckoutRepository{ctrl: ctrl}
+	mock.recorder = &MockCheckoutRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCheckoutRepository) EXPECT() *MockCheckoutRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddDiscount mocks base method.
+func (m *MockCheckoutRepository) AddDiscount(ctx context.Context, id_item, id_promotion uuid.UUID, amount float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDiscount", ctx, id_item, id_promotion, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDiscount indicates an expected call of AddDiscount.
+func (mr *MockCheckoutRepositoryMockRecorder) AddDiscount(ctx, id_item, id_promotion, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDiscount", reflect.TypeOf((*MockCheckoutRepository)(nil).AddDiscount), ctx, id_item, id_promotion, amount)
+}
+
+// AddItem mocks base method.
+func (m *MockCheckoutRepository) AddItem(ctx context.Context, item structs.OrderItem) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", ctx, item)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockCheckoutRepositoryMockRecorder) AddItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockCheckoutRepository)(nil).AddItem), ctx, item)
+}
+
+// AddItemLot mocks base method.
+func (m *MockCheckoutRepository) AddItemLot(ctx context.Context, id_item uuid.UUID, lot structs.OrderItemLot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItemLot", ctx, id_item, lot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItemLot indicates an expected call of AddItemLot.
+func (mr *MockCheckoutRepositoryMockRecorder) AddItemLot(ctx, id_item, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemLot", reflect.TypeOf((*MockCheckoutRepository)(nil).AddItemLot), ctx, id_item, lot)
+}
+
+// AddStatusChange mocks base method.
+func (m *MockCheckoutRepository) AddStatusChange(ctx context.Context, ch structs.OrderStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStatusChange", ctx, ch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStatusChange indicates an expected call of AddStatusChange.
+func (mr *MockCheckoutRepositoryMockRecorder) AddStatusChange(ctx, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStatusChange", reflect.TypeOf((*MockCheckoutRepository)(nil).AddStatusChange), ctx, ch)
+}
+
+// AddStockMovement mocks base method.
+func (m_2 *MockCheckoutRepository) AddStockMovement(ctx context.Context, m structs.StockMovement) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "AddStockMovement", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStockMovement indicates an expected call of AddStockMovement.
+func (mr *MockCheckoutRepositoryMockRecorder) AddStockMovement(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStockMovement", reflect.TypeOf((*MockCheckoutRepository)(nil).AddStockMovement), ctx, m)
+}
+
+// ClearBasket mocks base method.
+func (m *MockCheckoutRepository) ClearBasket(ctx context.Context, id_user uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearBasket", ctx, id_user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearBasket indicates an expected call of ClearBasket.
+func (mr *MockCheckoutRepositoryMockRecorder) ClearBasket(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearBasket", reflect.TypeOf((*MockCheckoutRepository)(nil).ClearBasket), ctx, id_user)
+}
+
+// CreateOrder mocks base method.
+func (m *MockCheckoutRepository) CreateOrder(ctx context.Context, o structs.Order) (structs.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, o)
+	ret0, _ := ret[0].(structs.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockCheckoutRepositoryMockRecorder) CreateOrder(ctx, o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockCheckoutRepository)(nil).CreateOrder), ctx, o)
+}
+
+// GetBasket mocks base method.
+func (m *MockCheckoutRepository) GetBasket(ctx context.Context, id_user uuid.UUID) ([]structs.BasketItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBasket", ctx, id_user)
+	ret0, _ := ret[0].([]structs.BasketItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBasket indicates an expected call of GetBasket.
+func (mr *MockCheckoutRepositoryMockRecorder) GetBasket(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBasket", reflect.TypeOf((*MockCheckoutRepository)(nil).GetBasket), ctx, id_user)
+}
+
+// GetDelivery mocks base method.
+func (m *MockCheckoutRepository) GetDelivery(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, id)
+	ret0, _ := ret[0].(structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockCheckoutRepositoryMockRecorder) GetDelivery(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockCheckoutRepository)(nil).GetDelivery), ctx, id)
+}
+
+// InTx mocks base method.
+func (m *MockCheckoutRepository) InTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockCheckoutRepositoryMockRecorder) InTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockCheckoutRepository)(nil).InTx), ctx, fn)
+}
+
+// LockLots mocks base method.
+func (m *MockCheckoutRepository) LockLots(ctx context.Context, id_product, id_variant uuid.UUID) ([]structs.StockLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLots", ctx, id_product, id_variant)
+	ret0, _ := ret[0].([]structs.StockLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockLots indicates an expected call of LockLots.
+func (mr *MockCheckoutRepositoryMockRecorder) LockLots(ctx, id_product, id_variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLots", reflect.TypeOf((*MockCheckoutRepository)(nil).LockLots), ctx, id_product, id_variant)
+}
+
+// LockSlot mocks base method.
+func (m *MockCheckoutRepository) LockSlot(ctx context.Context, id uuid.UUID) (structs.DeliverySlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockSlot", ctx, id)
+	ret0, _ := ret[0].(structs.DeliverySlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockSlot indicates an expected call of LockSlot.
+func (mr *MockCheckoutRepositoryMockRecorder) LockSlot(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSlot", reflect.TypeOf((*MockCheckoutRepository)(nil).LockSlot), ctx, id)
+}
+
+// LockStock mocks base method.
+func (m *MockCheckoutRepository) LockStock(ctx context.Context, id_user, id_product, id_variant uuid.UUID) (structs.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockStock", ctx, id_user, id_product, id_variant)
+	ret0, _ := ret[0].(structs.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockStock indicates an expected call of LockStock.
+func (mr *MockCheckoutRepositoryMockRecorder) LockStock(ctx, id_user, id_product, id_variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockStock", reflect.TypeOf((*MockCheckoutRepository)(nil).LockStock), ctx, id_user, id_product, id_variant)
+}
+
+// Redeem mocks base method.
+func (m *MockCheckoutRepository) Redeem(ctx context.Context, id_promotion, id_user, id_order uuid.UUID, amount float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, id_promotion, id_user, id_order, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockCheckoutRepositoryMockRecorder) Redeem(ctx, id_promotion, id_user, id_order, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockCheckoutRepository)(nil).Redeem), ctx, id_promotion, id_user, id_order, amount)
+}
+
+// SetPrice mocks base method.
+func (m *MockCheckoutRepository) SetPrice(ctx context.Context, id_order uuid.UUID, price, shipping float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrice", ctx, id_order, price, shipping)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrice indicates an expected call of SetPrice.
+func (mr *MockCheckoutRepositoryMockRecorder) SetPrice(ctx, id_order, price, shipping interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrice", reflect.TypeOf((*MockCheckoutRepository)(nil).SetPrice), ctx, id_order, price, shipping)
+}
+
+// MockDiscountCalculator is a mock of DiscountCalculator interface.
+type MockDiscountCalculator struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiscountCalculatorMockRecorder
+}
+
+// MockDiscountCalculatorMockRecorder is the mock recorder for MockDiscountCalculator.
+type MockDiscountCalculatorMockRecorder struct {
+	mock *MockDiscountCalculator
+}
+
+// NewMockDiscountCalculator creates a new mock instance.
+func NewMockDiscountCalculator(ctrl *gomock.Controller) *MockDiscountCalculator {
+	mock := &MockDiscountCalculator{ctrl: ctrl}
+	mock.recorder = &MockDiscountCalculatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiscountCalculator) EXPECT() *MockDiscountCalculatorMockRecorder {
+	return m.recorder
+}
+
+// QuoteLines mocks base method.
+func (m *MockDiscountCalculator) QuoteLines(ctx context.Context, id_user uuid.UUID, code string, lines []structs.CheckoutLine) (structs.DiscountQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteLines", ctx, id_user, code, lines)
+	ret0, _ := ret[0].(structs.DiscountQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteLines indicates an expected call of QuoteLines.
+func (mr *MockDiscountCalculatorMockRecorder) QuoteLines(ctx, id_user, code, lines interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteLines", reflect.TypeOf((*MockDiscountCalculator)(nil).QuoteLines), ctx, id_user, code, lines)
+}
+
+// MockBasketFiller is a mock of BasketFiller interface.
+type MockBasketFiller struct {
+	ctrl     *gomock.Controller
+	recorder *MockBasketFillerMockRecorder
+}
+
+// MockBasketFillerMockRecorder is the mock recorder for MockBasketFiller.
+type MockBasketFillerMockRecorder struct {
+	mock *MockBasketFiller
+}
+
+// NewMockBasketFiller creates a new mock instance.
+func NewMockBasketFiller(ctrl *gomock.Controller) *MockBasketFiller {
+	mock := &MockBasketFiller{ctrl: ctrl}
+	mock.recorder = &MockBasketFillerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBasketFiller) EXPECT() *MockBasketFillerMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *MockBasketFiller) AddItem(ctx context.Context, i structs.BasketItem, id_user uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", ctx, i, id_user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockBasketFillerMockRecorder) AddItem(ctx, i, id_user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepository)(nil).UpdateStatus), ctx, id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockBasketFiller)(nil).AddItem), ctx, i, id_user)
 }
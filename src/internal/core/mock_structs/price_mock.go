@@ -0,0 +1,178 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/price/price.go
+
+// Package mock_structs is a generated GoMock package.
+package mock_structs
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockPriceService is a mock of PriceService interface.
+type MockPriceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceServiceMockRecorder
+}
+
+// MockPriceServiceMockRecorder is the mock recorder for MockPriceService.
+type MockPriceServiceMockRecorder struct {
+	mock *MockPriceService
+}
+
+// NewMockPriceService creates a new mock instance.
+func NewMockPriceService(ctrl *gomock.Controller) *MockPriceService {
+	mock := &MockPriceService{ctrl: ctrl}
+	mock.recorder = &MockPriceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceService) EXPECT() *MockPriceServiceMockRecorder {
+	return m.recorder
+}
+
+// ActivateDue mocks base method.
+func (m *MockPriceService) ActivateDue(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateDue", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateDue indicates an expected call of ActivateDue.
+func (mr *MockPriceServiceMockRecorder) ActivateDue(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateDue", reflect.TypeOf((*MockPriceService)(nil).ActivateDue), ctx)
+}
+
+// CancelScheduled mocks base method.
+func (m *MockPriceService) CancelScheduled(ctx context.Context, id_product, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduled", ctx, id_product, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelScheduled indicates an expected call of CancelScheduled.
+func (mr *MockPriceServiceMockRecorder) CancelScheduled(ctx, id_product, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduled", reflect.TypeOf((*MockPriceService)(nil).CancelScheduled), ctx, id_product, id)
+}
+
+// GetHistory mocks base method.
+func (m *MockPriceService) GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id_product)
+	ret0, _ := ret[0].([]structs.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockPriceServiceMockRecorder) GetHistory(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockPriceService)(nil).GetHistory), ctx, id_product)
+}
+
+// Schedule mocks base method.
+func (m *MockPriceService) Schedule(ctx context.Context, p structs.ProductPrice) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, p)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockPriceServiceMockRecorder) Schedule(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockPriceService)(nil).Schedule), ctx, p)
+}
+
+// MockPriceRepository is a mock of PriceRepository interface.
+type MockPriceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceRepositoryMockRecorder
+}
+
+// MockPriceRepositoryMockRecorder is the mock recorder for MockPriceRepository.
+type MockPriceRepositoryMockRecorder struct {
+	mock *MockPriceRepository
+}
+
+// NewMockPriceRepository creates a new mock instance.
+func NewMockPriceRepository(ctrl *gomock.Controller) *MockPriceRepository {
+	mock := &MockPriceRepository{ctrl: ctrl}
+	mock.recorder = &MockPriceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceRepository) EXPECT() *MockPriceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ActivateDue mocks base method.
+func (m *MockPriceRepository) ActivateDue(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateDue", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateDue indicates an expected call of ActivateDue.
+func (mr *MockPriceRepositoryMockRecorder) ActivateDue(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateDue", reflect.TypeOf((*MockPriceRepository)(nil).ActivateDue), ctx)
+}
+
+// DeleteScheduled mocks base method.
+func (m *MockPriceRepository) DeleteScheduled(ctx context.Context, id_product, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduled", ctx, id_product, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduled indicates an expected call of DeleteScheduled.
+func (mr *MockPriceRepositoryMockRecorder) DeleteScheduled(ctx, id_product, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduled", reflect.TypeOf((*MockPriceRepository)(nil).DeleteScheduled), ctx, id_product, id)
+}
+
+// GetHistory mocks base method.
+func (m *MockPriceRepository) GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id_product)
+	ret0, _ := ret[0].([]structs.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockPriceRepositoryMockRecorder) GetHistory(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockPriceRepository)(nil).GetHistory), ctx, id_product)
+}
+
+// Schedule mocks base method.
+func (m *MockPriceRepository) Schedule(ctx context.Context, p structs.ProductPrice) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, p)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockPriceRepositoryMockRecorder) Schedule(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockPriceRepository)(nil).Schedule), ctx, p)
+}
@@ -50,6 +50,20 @@ func (mr *MockProductServiceMockRecorder) Create(ctx, p interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductService)(nil).Create), ctx, p)
 }
 
+// CreateVariant mocks base method.
+func (m *MockProductService) CreateVariant(ctx context.Context, v structs.ProductVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", ctx, v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVariant indicates an expected call of CreateVariant.
+func (mr *MockProductServiceMockRecorder) CreateVariant(ctx, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockProductService)(nil).CreateVariant), ctx, v)
+}
+
 // Delete mocks base method.
 func (m *MockProductService) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -64,6 +78,20 @@ func (mr *MockProductServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductService)(nil).Delete), ctx, id)
 }
 
+// DeleteVariant mocks base method.
+func (m *MockProductService) DeleteVariant(ctx context.Context, id_product, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVariant", ctx, id_product, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVariant indicates an expected call of DeleteVariant.
+func (mr *MockProductServiceMockRecorder) DeleteVariant(ctx, id_product, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVariant", reflect.TypeOf((*MockProductService)(nil).DeleteVariant), ctx, id_product, id)
+}
+
 // GetByArticule mocks base method.
 func (m *MockProductService) GetByArticule(ctx context.Context, art string) (structs.Product, error) {
 	m.ctrl.T.Helper()
@@ -95,18 +123,18 @@ func (mr *MockProductServiceMockRecorder) GetByBrand(ctx, brand interface{}) *go
 }
 
 // GetByCategory mocks base method.
-func (m *MockProductService) GetByCategory(ctx context.Context, category string) ([]structs.Product, error) {
+func (m *MockProductService) GetByCategory(ctx context.Context, id_category uuid.UUID) ([]structs.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCategory", ctx, category)
+	ret := m.ctrl.Call(m, "GetByCategory", ctx, id_category)
 	ret0, _ := ret[0].([]structs.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByCategory indicates an expected call of GetByCategory.
-func (mr *MockProductServiceMockRecorder) GetByCategory(ctx, category interface{}) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) GetByCategory(ctx, id_category interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategory", reflect.TypeOf((*MockProductService)(nil).GetByCategory), ctx, category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategory", reflect.TypeOf((*MockProductService)(nil).GetByCategory), ctx, id_category)
 }
 
 // GetById mocks base method.
@@ -139,6 +167,36 @@ func (mr *MockProductServiceMockRecorder) GetByName(ctx, name interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockProductService)(nil).GetByName), ctx, name)
 }
 
+// GetVariants mocks base method.
+func (m *MockProductService) GetVariants(ctx context.Context, id_product uuid.UUID) (structs.VariantMatrix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariants", ctx, id_product)
+	ret0, _ := ret[0].(structs.VariantMatrix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariants indicates an expected call of GetVariants.
+func (mr *MockProductServiceMockRecorder) GetVariants(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariants", reflect.TypeOf((*MockProductService)(nil).GetVariants), ctx, id_product)
+}
+
+// Search mocks base method.
+func (m *MockProductService) Search(ctx context.Context, f structs.ProductFilter) ([]structs.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, f)
+	ret0, _ := ret[0].([]structs.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockProductServiceMockRecorder) Search(ctx, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockProductService)(nil).Search), ctx, f)
+}
+
 // MockProductRepository is a mock of ProductRepository interface.
 type MockProductRepository struct {
 	ctrl     *gomock.Controller
@@ -176,6 +234,20 @@ func (mr *MockProductRepositoryMockRecorder) Create(ctx, p interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductRepository)(nil).Create), ctx, p)
 }
 
+// CreateVariant mocks base method.
+func (m *MockProductRepository) CreateVariant(ctx context.Context, v structs.ProductVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", ctx, v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVariant indicates an expected call of CreateVariant.
+func (mr *MockProductRepositoryMockRecorder) CreateVariant(ctx, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockProductRepository)(nil).CreateVariant), ctx, v)
+}
+
 // Delete mocks base method.
 func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -190,6 +262,20 @@ func (mr *MockProductRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductRepository)(nil).Delete), ctx, id)
 }
 
+// DeleteVariant mocks base method.
+func (m *MockProductRepository) DeleteVariant(ctx context.Context, id_product, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVariant", ctx, id_product, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVariant indicates an expected call of DeleteVariant.
+func (mr *MockProductRepositoryMockRecorder) DeleteVariant(ctx, id_product, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVariant", reflect.TypeOf((*MockProductRepository)(nil).DeleteVariant), ctx, id_product, id)
+}
+
 // GetByArticule mocks base method.
 func (m *MockProductRepository) GetByArticule(ctx context.Context, art string) (structs.Product, error) {
 	m.ctrl.T.Helper()
@@ -221,18 +307,18 @@ func (mr *MockProductRepositoryMockRecorder) GetByBrand(ctx, brand interface{})
 }
 
 // GetByCategory mocks base method.
-func (m *MockProductRepository) GetByCategory(ctx context.Context, category string) ([]structs.Product, error) {
+func (m *MockProductRepository) GetByCategory(ctx context.Context, id_category uuid.UUID) ([]structs.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCategory", ctx, category)
+	ret := m.ctrl.Call(m, "GetByCategory", ctx, id_category)
 	ret0, _ := ret[0].([]structs.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByCategory indicates an expected call of GetByCategory.
-func (mr *MockProductRepositoryMockRecorder) GetByCategory(ctx, category interface{}) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) GetByCategory(ctx, id_category interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategory", reflect.TypeOf((*MockProductRepository)(nil).GetByCategory), ctx, category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategory", reflect.TypeOf((*MockProductRepository)(nil).GetByCategory), ctx, id_category)
 }
 
 // GetById mocks base method.
@@ -264,3 +350,33 @@ func (mr *MockProductRepositoryMockRecorder) GetByName(ctx, name interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockProductRepository)(nil).GetByName), ctx, name)
 }
+
+// GetVariants mocks base method.
+func (m *MockProductRepository) GetVariants(ctx context.Context, id_product uuid.UUID) ([]structs.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariants", ctx, id_product)
+	ret0, _ := ret[0].([]structs.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariants indicates an expected call of GetVariants.
+func (mr *MockProductRepositoryMockRecorder) GetVariants(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariants", reflect.TypeOf((*MockProductRepository)(nil).GetVariants), ctx, id_product)
+}
+
+// Search mocks base method.
+func (m *MockProductRepository) Search(ctx context.Context, f structs.ProductFilter) ([]structs.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, f)
+	ret0, _ := ret[0].([]structs.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockProductRepositoryMockRecorder) Search(ctx, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockProductRepository)(nil).Search), ctx, f)
+}
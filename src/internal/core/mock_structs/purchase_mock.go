@@ -0,0 +1,295 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/purchase/purchase.go
+
+// Package mock_structs is a generated GoMock package.
+package mock_structs
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockPurchaseService is a mock of PurchaseService interface.
+type MockPurchaseService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseServiceMockRecorder
+}
+
+// MockPurchaseServiceMockRecorder is the mock recorder for MockPurchaseService.
+type MockPurchaseServiceMockRecorder struct {
+	mock *MockPurchaseService
+}
+
+// NewMockPurchaseService creates a new mock instance.
+func NewMockPurchaseService(ctrl *gomock.Controller) *MockPurchaseService {
+	mock := &MockPurchaseService{ctrl: ctrl}
+	mock.recorder = &MockPurchaseServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseService) EXPECT() *MockPurchaseServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateOrder mocks base method.
+func (m *MockPurchaseService) CreateOrder(ctx context.Context, po structs.PurchaseOrder) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, po)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockPurchaseServiceMockRecorder) CreateOrder(ctx, po interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockPurchaseService)(nil).CreateOrder), ctx, po)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockPurchaseService) CreateSupplier(ctx context.Context, s structs.Supplier) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", ctx, s)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockPurchaseServiceMockRecorder) CreateSupplier(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockPurchaseService)(nil).CreateSupplier), ctx, s)
+}
+
+// DeleteSupplier mocks base method.
+func (m *MockPurchaseService) DeleteSupplier(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSupplier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSupplier indicates an expected call of DeleteSupplier.
+func (mr *MockPurchaseServiceMockRecorder) DeleteSupplier(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupplier", reflect.TypeOf((*MockPurchaseService)(nil).DeleteSupplier), ctx, id)
+}
+
+// GetOrder mocks base method.
+func (m *MockPurchaseService) GetOrder(ctx context.Context, id uuid.UUID) (structs.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
+	ret0, _ := ret[0].(structs.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockPurchaseServiceMockRecorder) GetOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockPurchaseService)(nil).GetOrder), ctx, id)
+}
+
+// GetOrders mocks base method.
+func (m *MockPurchaseService) GetOrders(ctx context.Context, status string) ([]structs.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx, status)
+	ret0, _ := ret[0].([]structs.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockPurchaseServiceMockRecorder) GetOrders(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockPurchaseService)(nil).GetOrders), ctx, status)
+}
+
+// GetSuppliers mocks base method.
+func (m *MockPurchaseService) GetSuppliers(ctx context.Context) ([]structs.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuppliers", ctx)
+	ret0, _ := ret[0].([]structs.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuppliers indicates an expected call of GetSuppliers.
+func (mr *MockPurchaseServiceMockRecorder) GetSuppliers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuppliers", reflect.TypeOf((*MockPurchaseService)(nil).GetSuppliers), ctx)
+}
+
+// Receive mocks base method.
+func (m *MockPurchaseService) Receive(ctx context.Context, r structs.PurchaseReceipt) (structs.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, r)
+	ret0, _ := ret[0].(structs.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockPurchaseServiceMockRecorder) Receive(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockPurchaseService)(nil).Receive), ctx, r)
+}
+
+// Send mocks base method.
+func (m *MockPurchaseService) Send(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockPurchaseServiceMockRecorder) Send(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockPurchaseService)(nil).Send), ctx, id)
+}
+
+// MockPurchaseRepository is a mock of PurchaseRepository interface.
+type MockPurchaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseRepositoryMockRecorder
+}
+
+// MockPurchaseRepositoryMockRecorder is the mock recorder for MockPurchaseRepository.
+type MockPurchaseRepositoryMockRecorder struct {
+	mock *MockPurchaseRepository
+}
+
+// NewMockPurchaseRepository creates a new mock instance.
+func NewMockPurchaseRepository(ctrl *gomock.Controller) *MockPurchaseRepository {
+	mock := &MockPurchaseRepository{ctrl: ctrl}
+	mock.recorder = &MockPurchaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseRepository) EXPECT() *MockPurchaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateOrder mocks base method.
+func (m *MockPurchaseRepository) CreateOrder(ctx context.Context, po structs.PurchaseOrder) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, po)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockPurchaseRepositoryMockRecorder) CreateOrder(ctx, po interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockPurchaseRepository)(nil).CreateOrder), ctx, po)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockPurchaseRepository) CreateSupplier(ctx context.Context, s structs.Supplier) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", ctx, s)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockPurchaseRepositoryMockRecorder) CreateSupplier(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockPurchaseRepository)(nil).CreateSupplier), ctx, s)
+}
+
+// DeleteSupplier mocks base method.
+func (m *MockPurchaseRepository) DeleteSupplier(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSupplier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSupplier indicates an expected call of DeleteSupplier.
+func (mr *MockPurchaseRepositoryMockRecorder) DeleteSupplier(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupplier", reflect.TypeOf((*MockPurchaseRepository)(nil).DeleteSupplier), ctx, id)
+}
+
+// GetOrder mocks base method.
+func (m *MockPurchaseRepository) GetOrder(ctx context.Context, id uuid.UUID) (structs.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
+	ret0, _ := ret[0].(structs.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockPurchaseRepositoryMockRecorder) GetOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockPurchaseRepository)(nil).GetOrder), ctx, id)
+}
+
+// GetOrders mocks base method.
+func (m *MockPurchaseRepository) GetOrders(ctx context.Context, status string) ([]structs.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx, status)
+	ret0, _ := ret[0].([]structs.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockPurchaseRepositoryMockRecorder) GetOrders(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockPurchaseRepository)(nil).GetOrders), ctx, status)
+}
+
+// GetSuppliers mocks base method.
+func (m *MockPurchaseRepository) GetSuppliers(ctx context.Context) ([]structs.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuppliers", ctx)
+	ret0, _ := ret[0].([]structs.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuppliers indicates an expected call of GetSuppliers.
+func (mr *MockPurchaseRepositoryMockRecorder) GetSuppliers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuppliers", reflect.TypeOf((*MockPurchaseRepository)(nil).GetSuppliers), ctx)
+}
+
+// Receive mocks base method.
+func (m *MockPurchaseRepository) Receive(ctx context.Context, seen structs.PurchaseOrder, r structs.PurchaseReceipt, status string, ds []structs.PurchaseDiscrepancy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, seen, r, status, ds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockPurchaseRepositoryMockRecorder) Receive(ctx, seen, r, status, ds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockPurchaseRepository)(nil).Receive), ctx, seen, r, status, ds)
+}
+
+// SetStatus mocks base method.
+func (m *MockPurchaseRepository) SetStatus(ctx context.Context, id uuid.UUID, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockPurchaseRepositoryMockRecorder) SetStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockPurchaseRepository)(nil).SetStatus), ctx, id, from, to)
+}
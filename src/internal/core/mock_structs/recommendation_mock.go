@@ -0,0 +1,150 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/recommendation/recommendation.go
+
+// Package mock_structs is a generated GoMock package.
+package mock_structs
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockRecommendationService is a mock of RecommendationService interface.
+type MockRecommendationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationServiceMockRecorder
+}
+
+// MockRecommendationServiceMockRecorder is the mock recorder for MockRecommendationService.
+type MockRecommendationServiceMockRecorder struct {
+	mock *MockRecommendationService
+}
+
+// NewMockRecommendationService creates a new mock instance.
+func NewMockRecommendationService(ctrl *gomock.Controller) *MockRecommendationService {
+	mock := &MockRecommendationService{ctrl: ctrl}
+	mock.recorder = &MockRecommendationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationService) EXPECT() *MockRecommendationServiceMockRecorder {
+	return m.recorder
+}
+
+// ForBasket mocks base method.
+func (m *MockRecommendationService) ForBasket(ctx context.Context, id_user uuid.UUID, limit int) ([]structs.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForBasket", ctx, id_user, limit)
+	ret0, _ := ret[0].([]structs.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForBasket indicates an expected call of ForBasket.
+func (mr *MockRecommendationServiceMockRecorder) ForBasket(ctx, id_user, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForBasket", reflect.TypeOf((*MockRecommendationService)(nil).ForBasket), ctx, id_user, limit)
+}
+
+// ForProduct mocks base method.
+func (m *MockRecommendationService) ForProduct(ctx context.Context, id_product, id_user uuid.UUID, limit int) ([]structs.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForProduct", ctx, id_product, id_user, limit)
+	ret0, _ := ret[0].([]structs.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForProduct indicates an expected call of ForProduct.
+func (mr *MockRecommendationServiceMockRecorder) ForProduct(ctx, id_product, id_user, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForProduct", reflect.TypeOf((*MockRecommendationService)(nil).ForProduct), ctx, id_product, id_user, limit)
+}
+
+// Rebuild mocks base method.
+func (m *MockRecommendationService) Rebuild(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebuild", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rebuild indicates an expected call of Rebuild.
+func (mr *MockRecommendationServiceMockRecorder) Rebuild(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockRecommendationService)(nil).Rebuild), ctx)
+}
+
+// MockRecommendationRepository is a mock of RecommendationRepository interface.
+type MockRecommendationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationRepositoryMockRecorder
+}
+
+// MockRecommendationRepositoryMockRecorder is the mock recorder for MockRecommendationRepository.
+type MockRecommendationRepositoryMockRecorder struct {
+	mock *MockRecommendationRepository
+}
+
+// NewMockRecommendationRepository creates a new mock instance.
+func NewMockRecommendationRepository(ctrl *gomock.Controller) *MockRecommendationRepository {
+	mock := &MockRecommendationRepository{ctrl: ctrl}
+	mock.recorder = &MockRecommendationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationRepository) EXPECT() *MockRecommendationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ForBasket mocks base method.
+func (m *MockRecommendationRepository) ForBasket(ctx context.Context, id_user uuid.UUID, limit int) ([]structs.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForBasket", ctx, id_user, limit)
+	ret0, _ := ret[0].([]structs.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForBasket indicates an expected call of ForBasket.
+func (mr *MockRecommendationRepositoryMockRecorder) ForBasket(ctx, id_user, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForBasket", reflect.TypeOf((*MockRecommendationRepository)(nil).ForBasket), ctx, id_user, limit)
+}
+
+// ForProduct mocks base method.
+func (m *MockRecommendationRepository) ForProduct(ctx context.Context, id_product, id_user uuid.UUID, limit int) ([]structs.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForProduct", ctx, id_product, id_user, limit)
+	ret0, _ := ret[0].([]structs.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForProduct indicates an expected call of ForProduct.
+func (mr *MockRecommendationRepositoryMockRecorder) ForProduct(ctx, id_product, id_user, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForProduct", reflect.TypeOf((*MockRecommendationRepository)(nil).ForProduct), ctx, id_product, id_user, limit)
+}
+
+// Rebuild mocks base method.
+func (m *MockRecommendationRepository) Rebuild(ctx context.Context, t structs.RecommendationThresholds) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebuild", ctx, t)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rebuild indicates an expected call of Rebuild.
+func (mr *MockRecommendationRepositoryMockRecorder) Rebuild(ctx, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockRecommendationRepository)(nil).Rebuild), ctx, t)
+}
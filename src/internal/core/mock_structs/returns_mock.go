@@ -0,0 +1,444 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/returns/returns.go
+
+// Package mock_structs is a generated GoMock package.
+package mock_structs
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockReturnService is a mock of ReturnService interface.
+type MockReturnService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnServiceMockRecorder
+}
+
+// MockReturnServiceMockRecorder is the mock recorder for MockReturnService.
+type MockReturnServiceMockRecorder struct {
+	mock *MockReturnService
+}
+
+// NewMockReturnService creates a new mock instance.
+func NewMockReturnService(ctrl *gomock.Controller) *MockReturnService {
+	mock := &MockReturnService{ctrl: ctrl}
+	mock.recorder = &MockReturnServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnService) EXPECT() *MockReturnServiceMockRecorder {
+	return m.recorder
+}
+
+// AddPhotos mocks base method.
+func (m *MockReturnService) AddPhotos(ctx context.Context, id, id_user uuid.UUID, files []structs.ImageFile) ([]structs.ReturnPhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPhotos", ctx, id, id_user, files)
+	ret0, _ := ret[0].([]structs.ReturnPhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPhotos indicates an expected call of AddPhotos.
+func (mr *MockReturnServiceMockRecorder) AddPhotos(ctx, id, id_user, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPhotos", reflect.TypeOf((*MockReturnService)(nil).AddPhotos), ctx, id, id_user, files)
+}
+
+// Approve mocks base method.
+func (m *MockReturnService) Approve(ctx context.Context, id, id_admin uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, id, id_admin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockReturnServiceMockRecorder) Approve(ctx, id, id_admin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockReturnService)(nil).Approve), ctx, id, id_admin)
+}
+
+// Create mocks base method.
+func (m *MockReturnService) Create(ctx context.Context, r structs.ReturnRequest) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, r)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnServiceMockRecorder) Create(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturnService)(nil).Create), ctx, r)
+}
+
+// GetAll mocks base method.
+func (m *MockReturnService) GetAll(ctx context.Context, status string) ([]structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, status)
+	ret0, _ := ret[0].([]structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockReturnServiceMockRecorder) GetAll(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockReturnService)(nil).GetAll), ctx, status)
+}
+
+// GetById mocks base method.
+func (m *MockReturnService) GetById(ctx context.Context, id uuid.UUID) (structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockReturnServiceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockReturnService)(nil).GetById), ctx, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockReturnService) GetByUser(ctx context.Context, id_user uuid.UUID) ([]structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, id_user)
+	ret0, _ := ret[0].([]structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockReturnServiceMockRecorder) GetByUser(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockReturnService)(nil).GetByUser), ctx, id_user)
+}
+
+// GetOwn mocks base method.
+func (m *MockReturnService) GetOwn(ctx context.Context, id, id_user uuid.UUID) (structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwn", ctx, id, id_user)
+	ret0, _ := ret[0].(structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwn indicates an expected call of GetOwn.
+func (mr *MockReturnServiceMockRecorder) GetOwn(ctx, id, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwn", reflect.TypeOf((*MockReturnService)(nil).GetOwn), ctx, id, id_user)
+}
+
+// Receive mocks base method.
+func (m *MockReturnService) Receive(ctx context.Context, rc structs.ReturnReceipt) (structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, rc)
+	ret0, _ := ret[0].(structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockReturnServiceMockRecorder) Receive(ctx, rc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockReturnService)(nil).Receive), ctx, rc)
+}
+
+// Reject mocks base method.
+func (m *MockReturnService) Reject(ctx context.Context, id, id_admin uuid.UUID, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, id, id_admin, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockReturnServiceMockRecorder) Reject(ctx, id, id_admin, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockReturnService)(nil).Reject), ctx, id, id_admin, comment)
+}
+
+// MockReturnRepository is a mock of ReturnRepository interface.
+type MockReturnRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnRepositoryMockRecorder
+}
+
+// MockReturnRepositoryMockRecorder is the mock recorder for MockReturnRepository.
+type MockReturnRepositoryMockRecorder struct {
+	mock *MockReturnRepository
+}
+
+// NewMockReturnRepository creates a new mock instance.
+func NewMockReturnRepository(ctrl *gomock.Controller) *MockReturnRepository {
+	mock := &MockReturnRepository{ctrl: ctrl}
+	mock.recorder = &MockReturnRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnRepository) EXPECT() *MockReturnRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddPhotos mocks base method.
+func (m *MockReturnRepository) AddPhotos(ctx context.Context, id uuid.UUID, photos []structs.ReturnPhoto, max int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPhotos", ctx, id, photos, max)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPhotos indicates an expected call of AddPhotos.
+func (mr *MockReturnRepositoryMockRecorder) AddPhotos(ctx, id, photos, max interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPhotos", reflect.TypeOf((*MockReturnRepository)(nil).AddPhotos), ctx, id, photos, max)
+}
+
+// Create mocks base method.
+func (m *MockReturnRepository) Create(ctx context.Context, r structs.ReturnRequest) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, r)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnRepositoryMockRecorder) Create(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturnRepository)(nil).Create), ctx, r)
+}
+
+// Decide mocks base method.
+func (m *MockReturnRepository) Decide(ctx context.Context, id uuid.UUID, status string, id_admin uuid.UUID, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, id, status, id_admin, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockReturnRepositoryMockRecorder) Decide(ctx, id, status, id_admin, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockReturnRepository)(nil).Decide), ctx, id, status, id_admin, comment)
+}
+
+// GetAll mocks base method.
+func (m *MockReturnRepository) GetAll(ctx context.Context, status string) ([]structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, status)
+	ret0, _ := ret[0].([]structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockReturnRepositoryMockRecorder) GetAll(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockReturnRepository)(nil).GetAll), ctx, status)
+}
+
+// GetById mocks base method.
+func (m *MockReturnRepository) GetById(ctx context.Context, id uuid.UUID) (structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockReturnRepositoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockReturnRepository)(nil).GetById), ctx, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockReturnRepository) GetByUser(ctx context.Context, id_user uuid.UUID) ([]structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, id_user)
+	ret0, _ := ret[0].([]structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockReturnRepositoryMockRecorder) GetByUser(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockReturnRepository)(nil).GetByUser), ctx, id_user)
+}
+
+// GetRestocked mocks base method.
+func (m *MockReturnRepository) GetRestocked(ctx context.Context, id_order uuid.UUID) (map[uuid.UUID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRestocked", ctx, id_order)
+	ret0, _ := ret[0].(map[uuid.UUID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestocked indicates an expected call of GetRestocked.
+func (mr *MockReturnRepositoryMockRecorder) GetRestocked(ctx, id_order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestocked", reflect.TypeOf((*MockReturnRepository)(nil).GetRestocked), ctx, id_order)
+}
+
+// Receive mocks base method.
+func (m *MockReturnRepository) Receive(ctx context.Context, seen structs.ReturnRequest, rc structs.ReturnReceipt, ms []structs.StockMovement, refund structs.Refund) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, seen, rc, ms, refund)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockReturnRepositoryMockRecorder) Receive(ctx, seen, rc, ms, refund interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockReturnRepository)(nil).Receive), ctx, seen, rc, ms, refund)
+}
+
+// MockOrderReader is a mock of OrderReader interface.
+type MockOrderReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderReaderMockRecorder
+}
+
+// MockOrderReaderMockRecorder is the mock recorder for MockOrderReader.
+type MockOrderReaderMockRecorder struct {
+	mock *MockOrderReader
+}
+
+// NewMockOrderReader creates a new mock instance.
+func NewMockOrderReader(ctrl *gomock.Controller) *MockOrderReader {
+	mock := &MockOrderReader{ctrl: ctrl}
+	mock.recorder = &MockOrderReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderReader) EXPECT() *MockOrderReaderMockRecorder {
+	return m.recorder
+}
+
+// GetById mocks base method.
+func (m *MockOrderReader) GetById(ctx context.Context, id uuid.UUID) (structs.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(structs.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockOrderReaderMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockOrderReader)(nil).GetById), ctx, id)
+}
+
+// GetHistory mocks base method.
+func (m *MockOrderReader) GetHistory(ctx context.Context, id_order, id_user uuid.UUID) ([]structs.OrderStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id_order, id_user)
+	ret0, _ := ret[0].([]structs.OrderStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockOrderReaderMockRecorder) GetHistory(ctx, id_order, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockOrderReader)(nil).GetHistory), ctx, id_order, id_user)
+}
+
+// GetItems mocks base method.
+func (m *MockOrderReader) GetItems(ctx context.Context, id uuid.UUID) ([]structs.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", ctx, id)
+	ret0, _ := ret[0].([]structs.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockOrderReaderMockRecorder) GetItems(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockOrderReader)(nil).GetItems), ctx, id)
+}
+
+// MockReturnStorage is a mock of ReturnStorage interface.
+type MockReturnStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnStorageMockRecorder
+}
+
+// MockReturnStorageMockRecorder is the mock recorder for MockReturnStorage.
+type MockReturnStorageMockRecorder struct {
+	mock *MockReturnStorage
+}
+
+// NewMockReturnStorage creates a new mock instance.
+func NewMockReturnStorage(ctrl *gomock.Controller) *MockReturnStorage {
+	mock := &MockReturnStorage{ctrl: ctrl}
+	mock.recorder = &MockReturnStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnStorage) EXPECT() *MockReturnStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockReturnStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReturnStorageMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReturnStorage)(nil).Delete), ctx, key)
+}
+
+// Save mocks base method.
+func (m *MockReturnStorage) Save(ctx context.Context, key string, r io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, key, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockReturnStorageMockRecorder) Save(ctx, key, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockReturnStorage)(nil).Save), ctx, key, r)
+}
+
+// URL mocks base method.
+func (m *MockReturnStorage) URL(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URL", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// URL indicates an expected call of URL.
+func (mr *MockReturnStorageMockRecorder) URL(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockReturnStorage)(nil).URL), key)
+}
@@ -0,0 +1,225 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/stock/stock.go
+
+// Package mock_structs is a generated GoMock package.
+package mock_structs
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockStockService is a mock of StockService interface.
+type MockStockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockServiceMockRecorder
+}
+
+// MockStockServiceMockRecorder is the mock recorder for MockStockService.
+type MockStockServiceMockRecorder struct {
+	mock *MockStockService
+}
+
+// NewMockStockService creates a new mock instance.
+func NewMockStockService(ctrl *gomock.Controller) *MockStockService {
+	mock := &MockStockService{ctrl: ctrl}
+	mock.recorder = &MockStockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockService) EXPECT() *MockStockServiceMockRecorder {
+	return m.recorder
+}
+
+// Adjust mocks base method.
+func (m_2 *MockStockService) Adjust(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Adjust", ctx, m)
+	ret0, _ := ret[0].(structs.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Adjust indicates an expected call of Adjust.
+func (mr *MockStockServiceMockRecorder) Adjust(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adjust", reflect.TypeOf((*MockStockService)(nil).Adjust), ctx, m)
+}
+
+// GetExpiring mocks base method.
+func (m *MockStockService) GetExpiring(ctx context.Context, days int) ([]structs.StockLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiring", ctx, days)
+	ret0, _ := ret[0].([]structs.StockLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiring indicates an expected call of GetExpiring.
+func (mr *MockStockServiceMockRecorder) GetExpiring(ctx, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiring", reflect.TypeOf((*MockStockService)(nil).GetExpiring), ctx, days)
+}
+
+// GetHistory mocks base method.
+func (m *MockStockService) GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id_product)
+	ret0, _ := ret[0].([]structs.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockStockServiceMockRecorder) GetHistory(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockStockService)(nil).GetHistory), ctx, id_product)
+}
+
+// GetLots mocks base method.
+func (m *MockStockService) GetLots(ctx context.Context, id_product uuid.UUID) ([]structs.StockLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLots", ctx, id_product)
+	ret0, _ := ret[0].([]structs.StockLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLots indicates an expected call of GetLots.
+func (mr *MockStockServiceMockRecorder) GetLots(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLots", reflect.TypeOf((*MockStockService)(nil).GetLots), ctx, id_product)
+}
+
+// Receive mocks base method.
+func (m_2 *MockStockService) Receive(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Receive", ctx, m)
+	ret0, _ := ret[0].(structs.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockStockServiceMockRecorder) Receive(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockStockService)(nil).Receive), ctx, m)
+}
+
+// ReceiveLot mocks base method.
+func (m_2 *MockStockService) ReceiveLot(ctx context.Context, m structs.StockMovement, lot structs.StockLot) (structs.StockMovement, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "ReceiveLot", ctx, m, lot)
+	ret0, _ := ret[0].(structs.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveLot indicates an expected call of ReceiveLot.
+func (mr *MockStockServiceMockRecorder) ReceiveLot(ctx, m, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveLot", reflect.TypeOf((*MockStockService)(nil).ReceiveLot), ctx, m, lot)
+}
+
+// MockStockRepository is a mock of StockRepository interface.
+type MockStockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockRepositoryMockRecorder
+}
+
+// MockStockRepositoryMockRecorder is the mock recorder for MockStockRepository.
+type MockStockRepositoryMockRecorder struct {
+	mock *MockStockRepository
+}
+
+// NewMockStockRepository creates a new mock instance.
+func NewMockStockRepository(ctrl *gomock.Controller) *MockStockRepository {
+	mock := &MockStockRepository{ctrl: ctrl}
+	mock.recorder = &MockStockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockRepository) EXPECT() *MockStockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m_2 *MockStockRepository) Create(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(structs.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStockRepositoryMockRecorder) Create(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStockRepository)(nil).Create), ctx, m)
+}
+
+// CreateLot mocks base method.
+func (m *MockStockRepository) CreateLot(ctx context.Context, lot structs.StockLot) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLot", ctx, lot)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLot indicates an expected call of CreateLot.
+func (mr *MockStockRepositoryMockRecorder) CreateLot(ctx, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLot", reflect.TypeOf((*MockStockRepository)(nil).CreateLot), ctx, lot)
+}
+
+// GetByProduct mocks base method.
+func (m *MockStockRepository) GetByProduct(ctx context.Context, id_product uuid.UUID) ([]structs.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProduct", ctx, id_product)
+	ret0, _ := ret[0].([]structs.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProduct indicates an expected call of GetByProduct.
+func (mr *MockStockRepositoryMockRecorder) GetByProduct(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProduct", reflect.TypeOf((*MockStockRepository)(nil).GetByProduct), ctx, id_product)
+}
+
+// GetExpiring mocks base method.
+func (m *MockStockRepository) GetExpiring(ctx context.Context, days int) ([]structs.StockLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiring", ctx, days)
+	ret0, _ := ret[0].([]structs.StockLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiring indicates an expected call of GetExpiring.
+func (mr *MockStockRepositoryMockRecorder) GetExpiring(ctx, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiring", reflect.TypeOf((*MockStockRepository)(nil).GetExpiring), ctx, days)
+}
+
+// GetLots mocks base method.
+func (m *MockStockRepository) GetLots(ctx context.Context, id_product uuid.UUID) ([]structs.StockLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLots", ctx, id_product)
+	ret0, _ := ret[0].([]structs.StockLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLots indicates an expected call of GetLots.
+func (mr *MockStockRepositoryMockRecorder) GetLots(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLots", reflect.TypeOf((*MockStockRepository)(nil).GetLots), ctx, id_product)
+}
@@ -8,4 +8,19 @@ mockgen -source=service/favourites/favourites.go -destination=mock_structs/favou
 mockgen -source=service/order/order.go -destination=mock_structs/order_mock.go -package=mock_structs
 mockgen -source=service/product/product.go -destination=mock_structs/product_mock.go -package=mock_structs
 mockgen -source=service/review/review.go -destination=mock_structs/review_mock.go -package=mock_structs
-mockgen -source=service/worker/worker.go -destination=mock_structs/worker_mock.go -package=mock_structs
\ No newline at end of file
+mockgen -source=service/worker/worker.go -destination=mock_structs/worker_mock.go -package=mock_structs
+mockgen -source=service/catalog/catalog.go -destination=mock_structs/catalog_mock.go -package=mock_structs
+mockgen -source=service/media/media.go -destination=mock_structs/media_mock.go -package=mock_structs
+mockgen -source=service/category/category.go -destination=mock_structs/category_mock.go -package=mock_structs
+mockgen -source=service/attribute/attribute.go -destination=mock_structs/attribute_mock.go -package=mock_structs
+mockgen -source=service/price/price.go -destination=mock_structs/price_mock.go -package=mock_structs
+mockgen -source=service/promotion/promotion.go -destination=mock_structs/promotion_mock.go -package=mock_structs
+mockgen -source=service/recommendation/recommendation.go -destination=mock_structs/recommendation_mock.go -package=mock_structs
+mockgen -source=service/stock/stock.go -destination=mock_structs/stock_mock.go -package=mock_structs
+mockgen -source=service/reservation/reservation.go -destination=mock_structs/reservation_mock.go -package=mock_structs
+mockgen -source=service/alert/alert.go -destination=mock_structs/alert_mock.go -package=mock_structs
+mockgen -source=service/purchase/purchase.go -destination=mock_structs/purchase_mock.go -package=mock_structs
+mockgen -source=service/idempotency/idempotency.go -destination=mock_structs/idempotency_mock.go -package=mock_structs
+mockgen -source=service/returns/returns.go -destination=mock_structs/returns_mock.go -package=mock_structs
+mockgen -source=service/payment/payment.go -destination=mock_structs/payment_mock.go -package=mock_structs
+mockgen -source=service/delivery/delivery.go -destination=mock_structs/delivery_mock.go -package=mock_structs
\ No newline at end of file
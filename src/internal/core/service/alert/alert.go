@@ -0,0 +1,89 @@
+package alert
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type AlertService interface {
+	SetRule(ctx context.Context, r structs.ReorderRule) error
+	DeleteRule(ctx context.Context, id_product uuid.UUID) error
+	Check(ctx context.Context) (int, error)
+	GetOpen(ctx context.Context) ([]structs.StockAlert, error)
+	ReorderReport(ctx context.Context) ([]structs.BrandReorder, error)
+}
+
+type AlertRepository interface {
+	SetRule(ctx context.Context, r structs.ReorderRule) error
+	DeleteRule(ctx context.Context, id_product uuid.UUID) error
+	RaiseAlerts(ctx context.Context) ([]structs.StockAlert, error)
+	GetOpen(ctx context.Context) ([]structs.StockAlert, error)
+	GetReorderItems(ctx context.Context) ([]structs.ReorderItem, error)
+}
+
+type AlertNotifier interface {
+	Notify(ctx context.Context, a structs.StockAlert) error
+}
+
+type Service struct {
+	rep      AlertRepository
+	notifier AlertNotifier
+}
+
+func New(rep AlertRepository, notifier AlertNotifier) *Service {
+	return &Service{rep: rep, notifier: notifier}
+}
+
+func (s *Service) SetRule(ctx context.Context, r structs.ReorderRule) error {
+	if r.ReorderPoint < 0 || r.ReorderQuantity <= 0 {
+		return structs.ErrInvalidReorderRule
+	}
+	return s.rep.SetRule(ctx, r)
+}
+
+func (s *Service) DeleteRule(ctx context.Context, id_product uuid.UUID) error {
+	return s.rep.DeleteRule(ctx, id_product)
+}
+
+// Check opens alerts for the products that fell below their reorder point,
+// closes the alerts of replenished ones and sends the new alerts through the
+// notifier. An alert that failed to be delivered is still listed by GetOpen.
+func (s *Service) Check(ctx context.Context) (int, error) {
+	alerts, err := s.rep.RaiseAlerts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, a := range alerts {
+		if err := s.notifier.Notify(ctx, a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(alerts), errors.Join(errs...)
+}
+
+func (s *Service) GetOpen(ctx context.Context) ([]structs.StockAlert, error) {
+	return s.rep.GetOpen(ctx)
+}
+
+// ReorderReport groups the products below their reorder point by brand. The
+// repository returns them ordered by brand.
+func (s *Service) ReorderReport(ctx context.Context) ([]structs.BrandReorder, error) {
+	items, err := s.rep.GetReorderItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := []structs.BrandReorder{}
+	for _, it := range items {
+		if n := len(report); n == 0 || report[n-1].IdBrand != it.IdBrand {
+			report = append(report, structs.BrandReorder{IdBrand: it.IdBrand, Brand: it.Brand})
+		}
+		report[len(report)-1].Items = append(report[len(report)-1].Items, it)
+	}
+	return report, nil
+}
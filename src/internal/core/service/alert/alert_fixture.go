@@ -0,0 +1,94 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t      *testing.T
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	rule   structs.ReorderRule
+	alerts []structs.StockAlert
+	items  []structs.ReorderItem
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	id_brand := structs.GenId()
+
+	return &TestFixture{
+		t:    t,
+		ctrl: ctrl,
+		ctx:  context.Background(),
+		rule: structs.ReorderRule{
+			IdProduct:       structs.GenId(),
+			ReorderPoint:    10,
+			ReorderQuantity: 50,
+		},
+		alerts: []structs.StockAlert{
+			{
+				Id:              structs.GenId(),
+				IdProduct:       structs.GenId(),
+				Name:            "Hydrating Serum",
+				Articule:        "HS-001",
+				Amount:          3,
+				ReorderPoint:    10,
+				ReorderQuantity: 50,
+				CreatedAt:       created,
+			},
+			{
+				Id:              structs.GenId(),
+				IdProduct:       structs.GenId(),
+				Name:            "Night Cream",
+				Articule:        "NC-002",
+				Amount:          0,
+				ReorderPoint:    5,
+				ReorderQuantity: 20,
+				CreatedAt:       created,
+			},
+		},
+		items: []structs.ReorderItem{
+			{IdProduct: structs.GenId(), Name: "Lip Balm", IdBrand: id_brand, Brand: "Aurora", Amount: 1, ReorderPoint: 5, ReorderQuantity: 30},
+			{IdProduct: structs.GenId(), Name: "Lip Gloss", IdBrand: id_brand, Brand: "Aurora", Amount: 0, ReorderPoint: 5, ReorderQuantity: 30},
+			{IdProduct: structs.GenId(), Name: "Toner", IdBrand: structs.GenId(), Brand: "Botanica", Amount: 2, ReorderPoint: 8, ReorderQuantity: 40},
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockAlertRepository, *mock_structs.MockAlertNotifier) {
+	mockRepo := mock_structs.NewMockAlertRepository(f.ctrl)
+	mockNotifier := mock_structs.NewMockAlertNotifier(f.ctrl)
+
+	service := New(mockRepo, mockNotifier)
+	return service, mockRepo, mockNotifier
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
@@ -0,0 +1,179 @@
+package alert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestSetRule_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	noQuantity := fixture.rule
+	noQuantity.ReorderQuantity = 0
+	negative := fixture.rule
+	negative.ReorderPoint = -1
+
+	tests := []struct {
+		name        string
+		rule        structs.ReorderRule
+		setupMocks  func(*mock_structs.MockAlertRepository)
+		expectedErr error
+	}{
+		{
+			name: "successful set",
+			rule: fixture.rule,
+			setupMocks: func(mockRepo *mock_structs.MockAlertRepository) {
+				mockRepo.EXPECT().SetRule(fixture.ctx, fixture.rule).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "zero reorder quantity",
+			rule:        noQuantity,
+			setupMocks:  func(mockRepo *mock_structs.MockAlertRepository) {},
+			expectedErr: structs.ErrInvalidReorderRule,
+		},
+		{
+			name:        "negative reorder point",
+			rule:        negative,
+			setupMocks:  func(mockRepo *mock_structs.MockAlertRepository) {},
+			expectedErr: structs.ErrInvalidReorderRule,
+		},
+		{
+			name: "product not found",
+			rule: fixture.rule,
+			setupMocks: func(mockRepo *mock_structs.MockAlertRepository) {
+				mockRepo.EXPECT().SetRule(fixture.ctx, fixture.rule).Return(structs.ErrProductNotFound)
+			},
+			expectedErr: structs.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _ := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.SetRule(fixture.ctx, tt.rule)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCheck_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockAlertRepository, *mock_structs.MockAlertNotifier)
+		expectedRet int
+		expectedErr error
+	}{
+		{
+			name: "new alerts are delivered",
+			setupMocks: func(mockRepo *mock_structs.MockAlertRepository, mockNotifier *mock_structs.MockAlertNotifier) {
+				mockRepo.EXPECT().RaiseAlerts(fixture.ctx).Return(fixture.alerts, nil)
+				mockNotifier.EXPECT().Notify(fixture.ctx, fixture.alerts[0]).Return(nil)
+				mockNotifier.EXPECT().Notify(fixture.ctx, fixture.alerts[1]).Return(nil)
+			},
+			expectedRet: 2,
+			expectedErr: nil,
+		},
+		{
+			name: "nothing below reorder point",
+			setupMocks: func(mockRepo *mock_structs.MockAlertRepository, mockNotifier *mock_structs.MockAlertNotifier) {
+				mockRepo.EXPECT().RaiseAlerts(fixture.ctx).Return(nil, nil)
+			},
+			expectedRet: 0,
+			expectedErr: nil,
+		},
+		{
+			name: "failed delivery does not stop the others",
+			setupMocks: func(mockRepo *mock_structs.MockAlertRepository, mockNotifier *mock_structs.MockAlertNotifier) {
+				mockRepo.EXPECT().RaiseAlerts(fixture.ctx).Return(fixture.alerts, nil)
+				mockNotifier.EXPECT().Notify(fixture.ctx, fixture.alerts[0]).Return(errTest)
+				mockNotifier.EXPECT().Notify(fixture.ctx, fixture.alerts[1]).Return(nil)
+			},
+			expectedRet: 2,
+			expectedErr: errTest,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockAlertRepository, mockNotifier *mock_structs.MockAlertNotifier) {
+				mockRepo.EXPECT().RaiseAlerts(fixture.ctx).Return(nil, errTest)
+			},
+			expectedRet: 0,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, mockNotifier := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo, mockNotifier)
+
+			ret, err := service.Check(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestReorderReport_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	items := fixture.items
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockAlertRepository)
+		expectedRet []structs.BrandReorder
+		expectedErr error
+	}{
+		{
+			name: "grouped by brand",
+			setupMocks: func(mockRepo *mock_structs.MockAlertRepository) {
+				mockRepo.EXPECT().GetReorderItems(fixture.ctx).Return(items, nil)
+			},
+			expectedRet: []structs.BrandReorder{
+				{IdBrand: items[0].IdBrand, Brand: "Aurora", Items: items[:2]},
+				{IdBrand: items[2].IdBrand, Brand: "Botanica", Items: items[2:]},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "nothing to reorder",
+			setupMocks: func(mockRepo *mock_structs.MockAlertRepository) {
+				mockRepo.EXPECT().GetReorderItems(fixture.ctx).Return(nil, nil)
+			},
+			expectedRet: []structs.BrandReorder{},
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockAlertRepository) {
+				mockRepo.EXPECT().GetReorderItems(fixture.ctx).Return(nil, errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _ := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.ReorderReport(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,140 @@
+package attribute
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type AttributeService interface {
+	CreateIngredient(ctx context.Context, i structs.Ingredient) (uuid.UUID, error)
+	GetIngredients(ctx context.Context) ([]structs.Ingredient, error)
+	DeleteIngredient(ctx context.Context, id uuid.UUID) error
+	CreateAttribute(ctx context.Context, a structs.Attribute) (uuid.UUID, error)
+	GetAttributes(ctx context.Context, kind string) ([]structs.Attribute, error)
+	DeleteAttribute(ctx context.Context, id uuid.UUID) error
+	GetProductAttributes(ctx context.Context, id_product uuid.UUID) (structs.ProductAttributes, error)
+	SetProductAttributes(ctx context.Context, a structs.ProductAttributes) error
+	GetAllergens(ctx context.Context, id_user uuid.UUID) ([]structs.Ingredient, error)
+	SetAllergens(ctx context.Context, id_user uuid.UUID, ids []uuid.UUID) error
+	FindAllergens(ctx context.Context, id_user uuid.UUID, id_products []uuid.UUID) (map[uuid.UUID][]structs.Ingredient, error)
+}
+
+type AttributeRepository interface {
+	CreateIngredient(ctx context.Context, i structs.Ingredient) (uuid.UUID, error)
+	GetIngredients(ctx context.Context) ([]structs.Ingredient, error)
+	DeleteIngredient(ctx context.Context, id uuid.UUID) error
+	CreateAttribute(ctx context.Context, a structs.Attribute) (uuid.UUID, error)
+	GetAttributes(ctx context.Context, kind string) ([]structs.Attribute, error)
+	DeleteAttribute(ctx context.Context, id uuid.UUID) error
+	GetProductAttributes(ctx context.Context, id_product uuid.UUID) (structs.ProductAttributes, error)
+	SetProductAttributes(ctx context.Context, a structs.ProductAttributes) error
+	GetAllergens(ctx context.Context, id_user uuid.UUID) ([]structs.Ingredient, error)
+	SetAllergens(ctx context.Context, id_user uuid.UUID, ids []uuid.UUID) error
+	FindAllergens(ctx context.Context, id_user uuid.UUID, id_products []uuid.UUID) (map[uuid.UUID][]structs.Ingredient, error)
+}
+
+const maxSpf = 100
+
+type Service struct {
+	rep AttributeRepository
+}
+
+func New(rep AttributeRepository) *Service {
+	return &Service{rep: rep}
+}
+
+func (s *Service) CreateIngredient(ctx context.Context, i structs.Ingredient) (uuid.UUID, error) {
+	i.InciName = strings.TrimSpace(i.InciName)
+	i.Name = strings.TrimSpace(i.Name)
+	if i.InciName == "" {
+		return uuid.Nil, structs.ErrInvalidIngredient
+	}
+	return s.rep.CreateIngredient(ctx, i)
+}
+
+func (s *Service) GetIngredients(ctx context.Context) ([]structs.Ingredient, error) {
+	return s.rep.GetIngredients(ctx)
+}
+
+func (s *Service) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	return s.rep.DeleteIngredient(ctx, id)
+}
+
+func (s *Service) CreateAttribute(ctx context.Context, a structs.Attribute) (uuid.UUID, error) {
+	a.Code = strings.TrimSpace(a.Code)
+	a.Name = strings.TrimSpace(a.Name)
+	if !slices.Contains(structs.AttributeKinds, a.Kind) || a.Code == "" || a.Name == "" {
+		return uuid.Nil, structs.ErrInvalidAttribute
+	}
+	return s.rep.CreateAttribute(ctx, a)
+}
+
+// GetAttributes returns the dictionary of one kind, or all kinds when kind
+// is empty.
+func (s *Service) GetAttributes(ctx context.Context, kind string) ([]structs.Attribute, error) {
+	if kind != "" && !slices.Contains(structs.AttributeKinds, kind) {
+		return nil, structs.ErrInvalidAttribute
+	}
+	return s.rep.GetAttributes(ctx, kind)
+}
+
+func (s *Service) DeleteAttribute(ctx context.Context, id uuid.UUID) error {
+	return s.rep.DeleteAttribute(ctx, id)
+}
+
+func (s *Service) GetProductAttributes(ctx context.Context, id_product uuid.UUID) (structs.ProductAttributes, error) {
+	return s.rep.GetProductAttributes(ctx, id_product)
+}
+
+// SetProductAttributes replaces the composition of a product. Only the ids
+// of ingredients and attributes are used, the ingredient order is kept.
+func (s *Service) SetProductAttributes(ctx context.Context, a structs.ProductAttributes) error {
+	if a.Spf < 0 || a.Spf > maxSpf {
+		return structs.ErrInvalidProductAttributes
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, i := range a.Ingredients {
+		if seen[i.Id] {
+			return structs.ErrInvalidProductAttributes
+		}
+		seen[i.Id] = true
+	}
+	for _, v := range a.Attributes {
+		if seen[v.Id] {
+			return structs.ErrInvalidProductAttributes
+		}
+		seen[v.Id] = true
+	}
+
+	return s.rep.SetProductAttributes(ctx, a)
+}
+
+func (s *Service) GetAllergens(ctx context.Context, id_user uuid.UUID) ([]structs.Ingredient, error) {
+	return s.rep.GetAllergens(ctx, id_user)
+}
+
+// SetAllergens replaces the allergen profile of the user.
+func (s *Service) SetAllergens(ctx context.Context, id_user uuid.UUID, ids []uuid.UUID) error {
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return s.rep.SetAllergens(ctx, id_user, unique)
+}
+
+// FindAllergens returns, for each of the products containing ingredients from
+// the allergen profile of the user, the matching ingredients. Products
+// without matches are absent from the map.
+func (s *Service) FindAllergens(ctx context.Context, id_user uuid.UUID, id_products []uuid.UUID) (map[uuid.UUID][]structs.Ingredient, error) {
+	if len(id_products) == 0 {
+		return map[uuid.UUID][]structs.Ingredient{}, nil
+	}
+	return s.rep.FindAllergens(ctx, id_user, id_products)
+}
@@ -0,0 +1,75 @@
+package attribute
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t          *testing.T
+	ctrl       *gomock.Controller
+	ctx        context.Context
+	aqua       structs.Ingredient
+	parfum     structs.Ingredient
+	oily       structs.Attribute
+	matte      structs.Attribute
+	attributes structs.ProductAttributes
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	aqua := structs.Ingredient{Id: structs.GenId(), InciName: "Aqua", Name: "Вода"}
+	parfum := structs.Ingredient{Id: structs.GenId(), InciName: "Parfum", Name: "Отдушка"}
+	oily := structs.Attribute{Id: structs.GenId(), Kind: structs.AttributeSkinType, Code: "oily", Name: "жирная"}
+	matte := structs.Attribute{Id: structs.GenId(), Kind: structs.AttributeFinish, Code: "matte", Name: "матовый"}
+
+	return &TestFixture{
+		t:      t,
+		ctrl:   ctrl,
+		ctx:    context.Background(),
+		aqua:   aqua,
+		parfum: parfum,
+		oily:   oily,
+		matte:  matte,
+		attributes: structs.ProductAttributes{
+			IdProduct:   structs.GenId(),
+			Ingredients: []structs.Ingredient{aqua, parfum},
+			Attributes:  []structs.Attribute{oily, matte},
+			Spf:         30,
+			Vegan:       true,
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockAttributeRepository) {
+	mockRepo := mock_structs.NewMockAttributeRepository(f.ctrl)
+
+	service := New(mockRepo)
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
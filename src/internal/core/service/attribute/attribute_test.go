@@ -0,0 +1,329 @@
+package attribute
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreateIngredient_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	padded := fixture.aqua
+	padded.InciName = "  Aqua "
+	noInci := fixture.aqua
+	noInci.InciName = " "
+
+	tests := []struct {
+		name        string
+		ingredient  structs.Ingredient
+		setupMocks  func(*mock_structs.MockAttributeRepository)
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:       "successful create with trimmed INCI name",
+			ingredient: padded,
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().CreateIngredient(fixture.ctx, fixture.aqua).Return(fixture.aqua.Id, nil)
+			},
+			expectedRet: fixture.aqua.Id,
+			expectedErr: nil,
+		},
+		{
+			name:        "missing INCI name",
+			ingredient:  noInci,
+			setupMocks:  func(mockRepo *mock_structs.MockAttributeRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidIngredient,
+		},
+		{
+			name:       "duplicate INCI name",
+			ingredient: fixture.aqua,
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().CreateIngredient(fixture.ctx, fixture.aqua).Return(uuid.Nil, structs.ErrDuplicateIngredient)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDuplicateIngredient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.CreateIngredient(fixture.ctx, tt.ingredient)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCreateAttribute_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	unknownKind := fixture.oily
+	unknownKind.Kind = "color"
+	noCode := fixture.oily
+	noCode.Code = ""
+
+	tests := []struct {
+		name        string
+		attribute   structs.Attribute
+		setupMocks  func(*mock_structs.MockAttributeRepository)
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:      "successful create",
+			attribute: fixture.oily,
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().CreateAttribute(fixture.ctx, fixture.oily).Return(fixture.oily.Id, nil)
+			},
+			expectedRet: fixture.oily.Id,
+			expectedErr: nil,
+		},
+		{
+			name:        "unknown kind",
+			attribute:   unknownKind,
+			setupMocks:  func(mockRepo *mock_structs.MockAttributeRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidAttribute,
+		},
+		{
+			name:        "missing code",
+			attribute:   noCode,
+			setupMocks:  func(mockRepo *mock_structs.MockAttributeRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidAttribute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.CreateAttribute(fixture.ctx, tt.attribute)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetAttributes_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		kind        string
+		setupMocks  func(*mock_structs.MockAttributeRepository)
+		expectedRet []structs.Attribute
+		expectedErr error
+	}{
+		{
+			name: "all kinds",
+			kind: "",
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().GetAttributes(fixture.ctx, "").Return([]structs.Attribute{fixture.matte, fixture.oily}, nil)
+			},
+			expectedRet: []structs.Attribute{fixture.matte, fixture.oily},
+			expectedErr: nil,
+		},
+		{
+			name: "one kind",
+			kind: structs.AttributeSkinType,
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().GetAttributes(fixture.ctx, structs.AttributeSkinType).Return([]structs.Attribute{fixture.oily}, nil)
+			},
+			expectedRet: []structs.Attribute{fixture.oily},
+			expectedErr: nil,
+		},
+		{
+			name:        "unknown kind",
+			kind:        "color",
+			setupMocks:  func(mockRepo *mock_structs.MockAttributeRepository) {},
+			expectedRet: nil,
+			expectedErr: structs.ErrInvalidAttribute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.GetAttributes(fixture.ctx, tt.kind)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSetProductAttributes_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	repeated := fixture.attributes
+	repeated.Ingredients = []structs.Ingredient{fixture.aqua, fixture.aqua}
+	highSpf := fixture.attributes
+	highSpf.Spf = 101
+
+	tests := []struct {
+		name        string
+		attributes  structs.ProductAttributes
+		setupMocks  func(*mock_structs.MockAttributeRepository)
+		expectedErr error
+	}{
+		{
+			name:       "successful set",
+			attributes: fixture.attributes,
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().SetProductAttributes(fixture.ctx, fixture.attributes).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "repeated ingredient",
+			attributes:  repeated,
+			setupMocks:  func(mockRepo *mock_structs.MockAttributeRepository) {},
+			expectedErr: structs.ErrInvalidProductAttributes,
+		},
+		{
+			name:        "SPF above 100",
+			attributes:  highSpf,
+			setupMocks:  func(mockRepo *mock_structs.MockAttributeRepository) {},
+			expectedErr: structs.ErrInvalidProductAttributes,
+		},
+		{
+			name:       "unknown ingredient",
+			attributes: fixture.attributes,
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().SetProductAttributes(fixture.ctx, fixture.attributes).Return(structs.ErrIngredientNotFound)
+			},
+			expectedErr: structs.ErrIngredientNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.SetProductAttributes(fixture.ctx, tt.attributes)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSetAllergens_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	id_user := structs.GenId()
+
+	tests := []struct {
+		name        string
+		ids         []uuid.UUID
+		setupMocks  func(*mock_structs.MockAttributeRepository)
+		expectedErr error
+	}{
+		{
+			name: "repeated ids are dropped",
+			ids:  []uuid.UUID{fixture.parfum.Id, fixture.aqua.Id, fixture.parfum.Id},
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().SetAllergens(fixture.ctx, id_user, []uuid.UUID{fixture.parfum.Id, fixture.aqua.Id}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "empty profile",
+			ids:  nil,
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().SetAllergens(fixture.ctx, id_user, []uuid.UUID{}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			ids:  []uuid.UUID{fixture.parfum.Id},
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().SetAllergens(fixture.ctx, id_user, gomock.Any()).Return(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.SetAllergens(fixture.ctx, id_user, tt.ids)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestFindAllergens_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	id_user := structs.GenId()
+	id_product := structs.GenId()
+
+	tests := []struct {
+		name        string
+		ids         []uuid.UUID
+		setupMocks  func(*mock_structs.MockAttributeRepository)
+		expectedRet map[uuid.UUID][]structs.Ingredient
+		expectedErr error
+	}{
+		{
+			name: "product with allergen",
+			ids:  []uuid.UUID{id_product},
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().FindAllergens(fixture.ctx, id_user, []uuid.UUID{id_product}).
+					Return(map[uuid.UUID][]structs.Ingredient{id_product: {fixture.parfum}}, nil)
+			},
+			expectedRet: map[uuid.UUID][]structs.Ingredient{id_product: {fixture.parfum}},
+			expectedErr: nil,
+		},
+		{
+			name:        "no products",
+			ids:         nil,
+			setupMocks:  func(mockRepo *mock_structs.MockAttributeRepository) {},
+			expectedRet: map[uuid.UUID][]structs.Ingredient{},
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			ids:  []uuid.UUID{id_product},
+			setupMocks: func(mockRepo *mock_structs.MockAttributeRepository) {
+				mockRepo.EXPECT().FindAllergens(fixture.ctx, id_user, []uuid.UUID{id_product}).Return(nil, errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.FindAllergens(fixture.ctx, id_user, tt.ids)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
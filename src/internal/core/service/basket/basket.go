@@ -12,8 +12,8 @@ type BasketService interface {
 	GetById(ctx context.Context, id uuid.UUID) (structs.Basket, error)
 	GetItems(ctx context.Context, id_basket uuid.UUID) ([]structs.BasketItem, error)
 	AddItem(ctx context.Context, i structs.BasketItem) error
-	DeleteItem(ctx context.Context, id uuid.UUID, product_id uuid.UUID) error
-	UpdateItemAmount(ctx context.Context, user_id uuid.UUID, product_id uuid.UUID, amount int) error
+	DeleteItem(ctx context.Context, id uuid.UUID, product_id uuid.UUID, variant_id uuid.UUID) error
+	UpdateItemAmount(ctx context.Context, user_id uuid.UUID, product_id uuid.UUID, variant_id uuid.UUID, amount int) error
 }
 
 type BasketRepository interface {
@@ -22,8 +22,8 @@ type BasketRepository interface {
 	GetById(ctx context.Context, id uuid.UUID) (structs.Basket, error)
 	GetItems(ctx context.Context, id_basket uuid.UUID) ([]structs.BasketItem, error)
 	AddItem(ctx context.Context, i structs.BasketItem) error
-	DeleteItem(ctx context.Context, id uuid.UUID, product_id uuid.UUID) error
-	UpdateItemAmount(ctx context.Context, basket_id uuid.UUID, product_id uuid.UUID, amount int) error
+	DeleteItem(ctx context.Context, id uuid.UUID, product_id uuid.UUID, variant_id uuid.UUID) error
+	UpdateItemAmount(ctx context.Context, basket_id uuid.UUID, product_id uuid.UUID, variant_id uuid.UUID, amount int) error
 }
 
 type Service struct {
@@ -69,20 +69,20 @@ func (s *Service) AddItem(ctx context.Context, i structs.BasketItem, id uuid.UUI
 	return err
 }
 
-func (s *Service) DeleteItem(ctx context.Context, id uuid.UUID, product_id uuid.UUID) error {
+func (s *Service) DeleteItem(ctx context.Context, id uuid.UUID, product_id uuid.UUID, variant_id uuid.UUID) error {
 	bid, err := s.rep.GetBIdByUId(ctx, id)
 	if err != nil {
 		return err
 	}
-	err = s.rep.DeleteItem(ctx, bid, product_id)
+	err = s.rep.DeleteItem(ctx, bid, product_id, variant_id)
 	return err
 }
 
-func (s *Service) UpdateItemAmount(ctx context.Context, id uuid.UUID, product_id uuid.UUID, amount int) error {
+func (s *Service) UpdateItemAmount(ctx context.Context, id uuid.UUID, product_id uuid.UUID, variant_id uuid.UUID, amount int) error {
 	bid, err := s.rep.GetBIdByUId(ctx, id)
 	if err != nil {
 		return err
 	}
-	err = s.rep.UpdateItemAmount(ctx, bid, product_id, amount)
+	err = s.rep.UpdateItemAmount(ctx, bid, product_id, variant_id, amount)
 	return err
 }
@@ -150,6 +150,7 @@ func TestAddItem_AAA(t *testing.T) {
 
 	itemId := structs.GenId()
 	productId := structs.GenId()
+	variantId := structs.GenId()
 
 	tests := []struct {
 		name        string
@@ -176,6 +177,26 @@ func TestAddItem_AAA(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "successful add variant",
+			item: structs.BasketItem{
+				Id:        itemId,
+				IdProduct: productId,
+				IdVariant: variantId,
+				Amount:    2,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockBasketRepository) {
+				mockRepo.EXPECT().GetBIdByUId(fixture.ctx, fixture.basket.IdUser).Return(fixture.basket.Id, nil)
+				mockRepo.EXPECT().AddItem(fixture.ctx, structs.BasketItem{
+					Id:        itemId,
+					IdProduct: productId,
+					IdVariant: variantId,
+					IdBasket:  fixture.basket.Id,
+					Amount:    2,
+				}).Return(nil)
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "repository error",
 			item: structs.BasketItem{
@@ -210,6 +231,7 @@ func TestDeleteItem(t *testing.T) {
 	fixture := NewTestFixture(t)
 
 	productId := structs.GenId()
+	variantId := structs.GenId()
 
 	tests := []struct {
 		name        string
@@ -220,7 +242,7 @@ func TestDeleteItem(t *testing.T) {
 			name: "successful delete",
 			setupMocks: func(mockRepo *mock_structs.MockBasketRepository) {
 				mockRepo.EXPECT().GetBIdByUId(fixture.ctx, fixture.basket.IdUser).Return(fixture.basket.Id, nil)
-				mockRepo.EXPECT().DeleteItem(fixture.ctx, fixture.basket.Id, productId).Return(nil)
+				mockRepo.EXPECT().DeleteItem(fixture.ctx, fixture.basket.Id, productId, variantId).Return(nil)
 			},
 			expectedErr: nil,
 		},
@@ -228,7 +250,7 @@ func TestDeleteItem(t *testing.T) {
 			name: "repository error",
 			setupMocks: func(mockRepo *mock_structs.MockBasketRepository) {
 				mockRepo.EXPECT().GetBIdByUId(fixture.ctx, fixture.basket.IdUser).Return(fixture.basket.Id, nil)
-				mockRepo.EXPECT().DeleteItem(fixture.ctx, fixture.basket.Id, productId).Return(errTest)
+				mockRepo.EXPECT().DeleteItem(fixture.ctx, fixture.basket.Id, productId, variantId).Return(errTest)
 			},
 			expectedErr: errTest,
 		},
@@ -239,7 +261,7 @@ func TestDeleteItem(t *testing.T) {
 			service, mockRepo := fixture.CreateServiceWithMocks()
 			tt.setupMocks(mockRepo)
 
-			err := service.DeleteItem(fixture.ctx, fixture.basket.IdUser, productId)
+			err := service.DeleteItem(fixture.ctx, fixture.basket.IdUser, productId, variantId)
 
 			fixture.AssertError(err, tt.expectedErr)
 
@@ -251,6 +273,7 @@ func TestUpdateItemAmount(t *testing.T) {
 	fixture := NewTestFixture(t)
 
 	productId := structs.GenId()
+	variantId := structs.GenId()
 	amount := 5
 
 	tests := []struct {
@@ -264,7 +287,7 @@ func TestUpdateItemAmount(t *testing.T) {
 			amount: amount,
 			setupMocks: func(mockRepo *mock_structs.MockBasketRepository) {
 				mockRepo.EXPECT().GetBIdByUId(fixture.ctx, fixture.basket.IdUser).Return(fixture.basket.Id, nil)
-				mockRepo.EXPECT().UpdateItemAmount(fixture.ctx, fixture.basket.Id, productId, amount).Return(nil)
+				mockRepo.EXPECT().UpdateItemAmount(fixture.ctx, fixture.basket.Id, productId, variantId, amount).Return(nil)
 			},
 			expectedErr: nil,
 		},
@@ -273,7 +296,7 @@ func TestUpdateItemAmount(t *testing.T) {
 			amount: amount,
 			setupMocks: func(mockRepo *mock_structs.MockBasketRepository) {
 				mockRepo.EXPECT().GetBIdByUId(fixture.ctx, fixture.basket.IdUser).Return(fixture.basket.Id, nil)
-				mockRepo.EXPECT().UpdateItemAmount(fixture.ctx, fixture.basket.Id, productId, 5).Return(errTest)
+				mockRepo.EXPECT().UpdateItemAmount(fixture.ctx, fixture.basket.Id, productId, variantId, 5).Return(errTest)
 			},
 			expectedErr: errTest,
 		},
@@ -284,7 +307,7 @@ func TestUpdateItemAmount(t *testing.T) {
 			service, mockRepo := fixture.CreateServiceWithMocks()
 			tt.setupMocks(mockRepo)
 
-			err := service.UpdateItemAmount(fixture.ctx, fixture.basket.IdUser, productId, tt.amount)
+			err := service.UpdateItemAmount(fixture.ctx, fixture.basket.IdUser, productId, variantId, tt.amount)
 
 			fixture.AssertError(err, tt.expectedErr)
 		})
@@ -0,0 +1,190 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type CatalogService interface {
+	Import(ctx context.Context, rows []structs.CatalogImportRow, dryRun bool) (structs.CatalogImportReport, error)
+	Export(ctx context.Context, format string, w io.Writer) error
+}
+
+type CatalogRepository interface {
+	ExistingArticules(ctx context.Context, arts []string) ([]string, error)
+	VariantArticules(ctx context.Context, arts []string) ([]string, error)
+	ExistingBrands(ctx context.Context, names []string) ([]string, error)
+	FindCategories(ctx context.Context, keys []string) ([]structs.Category, error)
+	Import(ctx context.Context, rows []structs.CatalogImportRow) error
+	ExportCategories(ctx context.Context) ([]structs.Category, error)
+	ExportProducts(ctx context.Context, fn func(structs.CatalogExportItem) error) error
+}
+
+// ImageStorage links the product images kept in the media storage.
+type ImageStorage interface {
+	URL(key string) string
+}
+
+type Service struct {
+	rep     CatalogRepository
+	storage ImageStorage
+	shop    structs.ShopInfo
+}
+
+func New(rep CatalogRepository, storage ImageStorage, shop structs.ShopInfo) *Service {
+	return &Service{rep: rep, storage: storage, shop: shop}
+}
+
+func (s *Service) Import(ctx context.Context, rows []structs.CatalogImportRow, dryRun bool) (structs.CatalogImportReport, error) {
+	if len(rows) == 0 {
+		return structs.CatalogImportReport{}, structs.ErrEmptyImport
+	}
+
+	var arts, brands, categories []string
+	for _, r := range rows {
+		if r.Articule != "" {
+			arts = append(arts, r.Articule)
+		}
+		if r.Brand != "" {
+			brands = append(brands, r.Brand)
+		}
+		if r.Category != "" {
+			categories = append(categories, r.Category)
+		}
+	}
+
+	existingArts, err := s.rep.ExistingArticules(ctx, arts)
+	if err != nil {
+		return structs.CatalogImportReport{}, err
+	}
+	variantArts, err := s.rep.VariantArticules(ctx, arts)
+	if err != nil {
+		return structs.CatalogImportReport{}, err
+	}
+	existingBrands, err := s.rep.ExistingBrands(ctx, brands)
+	if err != nil {
+		return structs.CatalogImportReport{}, err
+	}
+
+	foundCategories, err := s.rep.FindCategories(ctx, categories)
+	if err != nil {
+		return structs.CatalogImportReport{}, err
+	}
+
+	known := make(map[string]bool)
+	for _, b := range existingBrands {
+		known[b] = true
+	}
+	resolve := categoryResolver(foundCategories)
+
+	report := structs.CatalogImportReport{DryRun: dryRun}
+	seen := make(map[string]int)
+	var valid []structs.CatalogImportRow
+	for _, r := range rows {
+		res := structs.CatalogImportRowResult{Line: r.Line, Articule: r.Articule}
+
+		reason := validateRow(r)
+		if reason == "" {
+			r.IdCategory, reason = resolve(r.Category)
+		}
+		if reason == "" {
+			if line, ok := seen[r.Articule]; ok {
+				reason = fmt.Sprintf("duplicate articule (first seen on line %d)", line)
+			} else if slices.Contains(variantArts, r.Articule) {
+				reason = "duplicate articule (taken by a product variant)"
+			} else if !known[r.Brand] && r.BrandPriceCategory == "" {
+				reason = fmt.Sprintf("unknown brand %q", r.Brand)
+			}
+		}
+		if reason == "" && r.BrandPriceCategory != "" {
+			known[r.Brand] = true
+		}
+		if r.Articule != "" {
+			if _, ok := seen[r.Articule]; !ok {
+				seen[r.Articule] = r.Line
+			}
+		}
+
+		switch {
+		case reason != "":
+			res.Status = structs.ImportRowInvalid
+			res.Reason = reason
+			report.Invalid++
+		case slices.Contains(existingArts, r.Articule):
+			res.Status = structs.ImportRowUpdated
+			report.Updated++
+			valid = append(valid, r)
+		default:
+			res.Status = structs.ImportRowCreated
+			report.Created++
+			valid = append(valid, r)
+		}
+		report.Rows = append(report.Rows, res)
+	}
+
+	if dryRun {
+		return report, nil
+	}
+	if report.Invalid > 0 {
+		return report, structs.ErrCatalogImportInvalid
+	}
+
+	if err := s.rep.Import(ctx, valid); err != nil {
+		return structs.CatalogImportReport{}, err
+	}
+	report.Applied = true
+	return report, nil
+}
+
+func validateRow(r structs.CatalogImportRow) string {
+	switch {
+	case r.Reason != "":
+		return r.Reason
+	case r.Articule == "":
+		return "missing articule"
+	case r.Name == "":
+		return "missing name"
+	case r.Price < 0:
+		return "price must not be negative"
+	case r.Amount < 0:
+		return "amount must not be negative"
+	case r.Brand == "":
+		return "missing brand"
+	case r.Category == "":
+		return "missing category"
+	}
+	return ""
+}
+
+// categoryResolver maps the category column of an import row to a category.
+// The column holds either a slug or a name; names are compared case
+// insensitively and have to be unique in the tree.
+func categoryResolver(categories []structs.Category) func(key string) (uuid.UUID, string) {
+	bySlug := make(map[string]uuid.UUID)
+	byName := make(map[string][]uuid.UUID)
+	for _, c := range categories {
+		bySlug[c.Slug] = c.Id
+		name := strings.ToLower(c.Name)
+		byName[name] = append(byName[name], c.Id)
+	}
+
+	return func(key string) (uuid.UUID, string) {
+		if id, ok := bySlug[key]; ok {
+			return id, ""
+		}
+		switch ids := byName[strings.ToLower(key)]; len(ids) {
+		case 0:
+			return uuid.Nil, fmt.Sprintf("unknown category %q", key)
+		case 1:
+			return ids[0], ""
+		default:
+			return uuid.Nil, fmt.Sprintf("ambiguous category %q, use its slug", key)
+		}
+	}
+}
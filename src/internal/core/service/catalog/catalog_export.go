@@ -0,0 +1,219 @@
+package catalog
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var exportColumns = []string{
+	"articule", "product_articule", "name", "description", "price", "category",
+	"shade", "volume_ml", "available", "brand", "pic_link", "images",
+}
+
+type ymlOffer struct {
+	XMLName     xml.Name   `xml:"offer"`
+	Id          string     `xml:"id,attr"`
+	Available   bool       `xml:"available,attr"`
+	Name        string     `xml:"name"`
+	Vendor      string     `xml:"vendor,omitempty"`
+	VendorCode  string     `xml:"vendorCode"`
+	Price       string     `xml:"price"`
+	CurrencyId  string     `xml:"currencyId"`
+	CategoryId  int        `xml:"categoryId,omitempty"`
+	Pictures    []string   `xml:"picture"`
+	Description string     `xml:"description,omitempty"`
+	Params      []ymlParam `xml:"param"`
+	Count       int        `xml:"count"`
+}
+
+type ymlParam struct {
+	Name  string `xml:"name,attr"`
+	Unit  string `xml:"unit,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type ymlCategory struct {
+	XMLName  xml.Name `xml:"category"`
+	Id       int      `xml:"id,attr"`
+	ParentId int      `xml:"parentId,attr,omitempty"`
+	Name     string   `xml:",chardata"`
+}
+
+func (s *Service) Export(ctx context.Context, format string, w io.Writer) error {
+	switch format {
+	case structs.CatalogFormatCSV:
+		return s.exportCSV(ctx, w)
+	case structs.CatalogFormatJSONL:
+		return s.exportJSONL(ctx, w)
+	case structs.CatalogFormatYML:
+		return s.exportYML(ctx, w)
+	}
+	return structs.ErrUnsupportedFormat
+}
+
+func (s *Service) exportCSV(ctx context.Context, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportColumns); err != nil {
+		return err
+	}
+
+	err := s.rep.ExportProducts(ctx, func(p structs.CatalogExportItem) error {
+		var volume string
+		if p.VolumeMl > 0 {
+			volume = strconv.Itoa(p.VolumeMl)
+		}
+		return cw.Write([]string{
+			p.Articule, p.ProductArticule, p.Name, p.Description, formatPrice(p.Price), p.Category,
+			p.Shade, volume, strconv.Itoa(p.Available), p.Brand, p.PicLink, strings.Join(s.imageURLs(p.Images), " "),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (s *Service) exportJSONL(ctx context.Context, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return s.rep.ExportProducts(ctx, func(p structs.CatalogExportItem) error {
+		p.Images = s.imageURLs(p.Images)
+		return enc.Encode(p)
+	})
+}
+
+// exportYML writes a Yandex Market Language feed. Categories have to precede
+// offers in the document, so they are loaded first and offers are streamed
+// afterwards. The feed numbers categories itself, parentId keeps the tree.
+// Every variant is an offer of its own with the shade and volume as params;
+// products without a gallery fall back to their picture link.
+func (s *Service) exportYML(ctx context.Context, w io.Writer) error {
+	categories, err := s.rep.ExportCategories(ctx)
+	if err != nil {
+		return err
+	}
+	catIds := make(map[uuid.UUID]int, len(categories))
+	for i, c := range categories {
+		catIds[c.Id] = i + 1
+	}
+
+	ew := &errWriter{w: w}
+	ew.printf("%s<yml_catalog date=\"%s\">\n<shop>\n", xml.Header, time.Now().Format("2006-01-02T15:04-07:00"))
+	ew.element("name", s.shop.Name)
+	ew.element("company", s.shop.Company)
+	ew.element("url", s.shop.URL)
+	ew.printf("<currencies><currency id=\"RUR\" rate=\"1\"/></currencies>\n<categories>\n")
+
+	enc := xml.NewEncoder(ew)
+	for _, c := range categories {
+		cat := ymlCategory{Id: catIds[c.Id], ParentId: catIds[c.IdParent], Name: c.Name}
+		if err := enc.Encode(cat); err != nil {
+			return err
+		}
+		ew.printf("\n")
+	}
+	ew.printf("</categories>\n<offers>\n")
+	if ew.err != nil {
+		return ew.err
+	}
+
+	err = s.rep.ExportProducts(ctx, func(p structs.CatalogExportItem) error {
+		pictures := s.imageURLs(p.Images)
+		if len(pictures) == 0 && p.PicLink != "" {
+			pictures = []string{p.PicLink}
+		}
+		for i := range pictures {
+			pictures[i] = s.pictureURL(pictures[i])
+		}
+		var params []ymlParam
+		if p.Shade != "" {
+			params = append(params, ymlParam{Name: "Оттенок", Value: p.Shade})
+		}
+		if p.VolumeMl > 0 {
+			params = append(params, ymlParam{Name: "Объем", Unit: "мл", Value: strconv.Itoa(p.VolumeMl)})
+		}
+		offer := ymlOffer{
+			Id:          p.Articule,
+			Available:   p.Available > 0,
+			Name:        p.Name,
+			Vendor:      p.Brand,
+			VendorCode:  p.Articule,
+			Price:       formatPrice(p.Price),
+			CurrencyId:  "RUR",
+			CategoryId:  catIds[p.IdCategory],
+			Pictures:    pictures,
+			Description: p.Description,
+			Params:      params,
+			Count:       p.Available,
+		}
+		if err := enc.Encode(offer); err != nil {
+			return err
+		}
+		ew.printf("\n")
+		return ew.err
+	})
+	if err != nil {
+		return err
+	}
+
+	ew.printf("</offers>\n</shop>\n</yml_catalog>\n")
+	return ew.err
+}
+
+func (s *Service) imageURLs(keys []string) []string {
+	urls := make([]string, len(keys))
+	for i, k := range keys {
+		urls[i] = s.storage.URL(k)
+	}
+	return urls
+}
+
+func (s *Service) pictureURL(link string) string {
+	if strings.HasPrefix(link, "/") && s.shop.URL != "" {
+		return strings.TrimRight(s.shop.URL, "/") + link
+	}
+	return link
+}
+
+func formatPrice(p float64) string {
+	return strconv.FormatFloat(p, 'f', 2, 64)
+}
+
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	fmt.Fprintf(ew, format, args...)
+}
+
+func (ew *errWriter) element(name string, value string) {
+	if value == "" {
+		return
+	}
+	ew.printf("<%s>", name)
+	xml.EscapeText(ew, []byte(value))
+	ew.printf("</%s>\n", name)
+}
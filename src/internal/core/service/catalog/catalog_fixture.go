@@ -0,0 +1,109 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t          *testing.T
+	ctrl       *gomock.Controller
+	ctx        context.Context
+	row        structs.CatalogImportRow
+	item       structs.CatalogExportItem
+	variant    structs.CatalogExportItem
+	shop       structs.ShopInfo
+	categories []structs.Category
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	makeup := structs.Category{Id: structs.GenId(), Name: "Декоративная", Slug: "makeup", SortOrder: 2}
+	eyes := structs.Category{Id: structs.GenId(), IdParent: makeup.Id, Name: "Глаза", Slug: "makeup-eyes", SortOrder: 2}
+
+	return &TestFixture{
+		t:    t,
+		ctrl: ctrl,
+		ctx:  context.Background(),
+		row: structs.CatalogImportRow{
+			Line:     2,
+			Articule: "MAY-LS-002",
+			Name:     "Тушь для ресниц Lash Sensational",
+			Price:    1200,
+			Category: "декоративная",
+			Amount:   50,
+			Brand:    "Maybelline",
+		},
+		item: structs.CatalogExportItem{
+			Id:              structs.GenId(),
+			Articule:        "MAY-LS-002",
+			ProductArticule: "MAY-LS-002",
+			Name:            "Тушь для ресниц Lash Sensational",
+			Description:     "Объемная тушь & подкрутка",
+			Price:           1200,
+			Category:        eyes.Slug,
+			IdCategory:      eyes.Id,
+			Available:       47,
+			Brand:           "Maybelline",
+			PicLink:         "/images/mascara.jpg",
+		},
+		variant: structs.CatalogExportItem{
+			Id:              structs.GenId(),
+			IdVariant:       structs.GenId(),
+			Articule:        "MAY-SS-220",
+			ProductArticule: "MAY-SS",
+			Name:            "Помада SuperStay Matte Ink",
+			Price:           950,
+			Category:        "makeup-lips",
+			Shade:           "220 Ambitious",
+			VolumeMl:        5,
+			Available:       0,
+			Brand:           "Maybelline",
+			PicLink:         "/media/products/ss/front.jpg",
+			Images:          []string{"products/ss/front.jpg", "products/ss/swatch.jpg"},
+		},
+		categories: []structs.Category{makeup, eyes},
+		shop: structs.ShopInfo{
+			Name:    "Виртуаль",
+			Company: "Виртуаль",
+			URL:     "https://virtual.example.com",
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockCatalogRepository) {
+	mockRepo := mock_structs.NewMockCatalogRepository(f.ctrl)
+	mockStorage := mock_structs.NewMockImageStorage(f.ctrl)
+	mockStorage.EXPECT().URL(gomock.Any()).DoAndReturn(func(key string) string {
+		return "/media/" + key
+	}).AnyTimes()
+
+	service := New(mockRepo, mockStorage, f.shop)
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
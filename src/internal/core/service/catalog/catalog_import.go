@@ -0,0 +1,184 @@
+package catalog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var importColumns = []string{
+	"articule", "name", "description", "price", "category", "amount",
+	"brand", "brand_description", "brand_price_category", "pic_link",
+}
+
+type importLine struct {
+	Articule           string          `json:"articule"`
+	Name               string          `json:"name"`
+	Description        string          `json:"description"`
+	Price              json.RawMessage `json:"price"`
+	Category           string          `json:"category"`
+	Amount             json.RawMessage `json:"amount"`
+	Brand              string          `json:"brand"`
+	BrandDescription   string          `json:"brand_description"`
+	BrandPriceCategory string          `json:"brand_price_category"`
+	PicLink            string          `json:"pic_link"`
+}
+
+// ParseImport reads a catalog file in the given format. Row level problems
+// (bad price, malformed line) are kept in CatalogImportRow.Reason so they end
+// up in the import report, only an unreadable file is returned as an error.
+func ParseImport(format string, r io.Reader) ([]structs.CatalogImportRow, error) {
+	switch format {
+	case structs.CatalogFormatCSV:
+		return parseCSV(r)
+	case structs.CatalogFormatJSONL:
+		return parseJSONL(r)
+	}
+	return nil, structs.ErrUnsupportedFormat
+}
+
+func parseCSV(r io.Reader) ([]structs.CatalogImportRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, structs.ErrEmptyImport
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	cols := make(map[string]int)
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	if _, ok := cols["articule"]; !ok {
+		return nil, fmt.Errorf("csv header must contain articule column, known columns: %s",
+			strings.Join(importColumns, ", "))
+	}
+
+	var rows []structs.CatalogImportRow
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				rows = append(rows, structs.CatalogImportRow{Line: perr.StartLine, Reason: perr.Err.Error()})
+				continue
+			}
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		get := func(name string) string {
+			i, ok := cols[name]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+
+		row := structs.CatalogImportRow{
+			Line:               line,
+			Articule:           get("articule"),
+			Name:               get("name"),
+			Description:        get("description"),
+			Category:           get("category"),
+			Brand:              get("brand"),
+			BrandDescription:   get("brand_description"),
+			BrandPriceCategory: get("brand_price_category"),
+			PicLink:            get("pic_link"),
+		}
+		row.Price, row.Amount, row.Reason = parseNumbers(get("price"), get("amount"))
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, structs.ErrEmptyImport
+	}
+	return rows, nil
+}
+
+func parseJSONL(r io.Reader) ([]structs.CatalogImportRow, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []structs.CatalogImportRow
+	line := 0
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" {
+			continue
+		}
+
+		var in importLine
+		if err := json.Unmarshal([]byte(text), &in); err != nil {
+			rows = append(rows, structs.CatalogImportRow{Line: line, Reason: "malformed json: " + err.Error()})
+			continue
+		}
+
+		row := structs.CatalogImportRow{
+			Line:               line,
+			Articule:           strings.TrimSpace(in.Articule),
+			Name:               strings.TrimSpace(in.Name),
+			Description:        in.Description,
+			Category:           strings.TrimSpace(in.Category),
+			Brand:              strings.TrimSpace(in.Brand),
+			BrandDescription:   in.BrandDescription,
+			BrandPriceCategory: strings.TrimSpace(in.BrandPriceCategory),
+			PicLink:            strings.TrimSpace(in.PicLink),
+		}
+		row.Price, row.Amount, row.Reason = parseNumbers(rawNumber(in.Price), rawNumber(in.Amount))
+		rows = append(rows, row)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read jsonl: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, structs.ErrEmptyImport
+	}
+	return rows, nil
+}
+
+// rawNumber accepts both 1200.5 and "1200.5" so that feeds exported from
+// spreadsheets with quoted numbers still go through.
+func rawNumber(raw json.RawMessage) string {
+	s := strings.TrimSpace(string(raw))
+	if s == "null" {
+		return ""
+	}
+	return strings.Trim(s, `"`)
+}
+
+func parseNumbers(price string, amount string) (float64, int, string) {
+	price = strings.ReplaceAll(strings.ReplaceAll(price, " ", ""), ",", ".")
+	if price == "" {
+		return 0, 0, "missing price"
+	}
+	p, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return 0, 0, fmt.Sprintf("invalid price %q", price)
+	}
+
+	if amount == "" {
+		return p, 0, ""
+	}
+	a, err := strconv.Atoi(amount)
+	if err != nil {
+		return p, 0, fmt.Sprintf("invalid amount %q", amount)
+	}
+	return p, a, ""
+}
@@ -0,0 +1,359 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestImport_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	existing := fixture.row
+	fresh := fixture.row
+	fresh.Line = 3
+	fresh.Articule = "NEW-001"
+	duplicate := fresh
+	duplicate.Line = 4
+	unknownBrand := fixture.row
+	unknownBrand.Line = 5
+	unknownBrand.Articule = "NEW-002"
+	unknownBrand.Brand = "Unknown"
+	newBrand := unknownBrand
+	newBrand.BrandPriceCategory = "люкс"
+	bySlug := fixture.row
+	bySlug.Line = 6
+	bySlug.Articule = "NEW-003"
+	bySlug.Category = "makeup-eyes"
+	unknownCategory := bySlug
+	unknownCategory.Line = 7
+	unknownCategory.Articule = "NEW-004"
+	unknownCategory.Category = "уход"
+	variantArt := fixture.row
+	variantArt.Line = 8
+	variantArt.Articule = "MAY-SS-220"
+	resolved := func(r structs.CatalogImportRow, c structs.Category) structs.CatalogImportRow {
+		r.IdCategory = c.Id
+		return r
+	}
+
+	tests := []struct {
+		name        string
+		rows        []structs.CatalogImportRow
+		dryRun      bool
+		setupMocks  func(*mock_structs.MockCatalogRepository)
+		expectedRet structs.CatalogImportReport
+		expectedErr error
+	}{
+		{
+			name:   "dry run reports created, updated and invalid rows",
+			rows:   []structs.CatalogImportRow{existing, fresh, duplicate, unknownBrand},
+			dryRun: true,
+			setupMocks: func(mockRepo *mock_structs.MockCatalogRepository) {
+				mockRepo.EXPECT().ExistingArticules(fixture.ctx, gomock.Any()).Return([]string{existing.Articule}, nil)
+				mockRepo.EXPECT().VariantArticules(fixture.ctx, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().ExistingBrands(fixture.ctx, gomock.Any()).Return([]string{existing.Brand}, nil)
+				mockRepo.EXPECT().FindCategories(fixture.ctx, gomock.Any()).Return(fixture.categories, nil)
+			},
+			expectedRet: structs.CatalogImportReport{
+				DryRun:  true,
+				Created: 1,
+				Updated: 1,
+				Invalid: 2,
+				Rows: []structs.CatalogImportRowResult{
+					{Line: 2, Articule: existing.Articule, Status: structs.ImportRowUpdated},
+					{Line: 3, Articule: fresh.Articule, Status: structs.ImportRowCreated},
+					{Line: 4, Articule: fresh.Articule, Status: structs.ImportRowInvalid, Reason: "duplicate articule (first seen on line 3)"},
+					{Line: 5, Articule: unknownBrand.Articule, Status: structs.ImportRowInvalid, Reason: `unknown brand "Unknown"`},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid rows abort the import",
+			rows: []structs.CatalogImportRow{existing, unknownBrand},
+			setupMocks: func(mockRepo *mock_structs.MockCatalogRepository) {
+				mockRepo.EXPECT().ExistingArticules(fixture.ctx, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().VariantArticules(fixture.ctx, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().ExistingBrands(fixture.ctx, gomock.Any()).Return([]string{existing.Brand}, nil)
+				mockRepo.EXPECT().FindCategories(fixture.ctx, gomock.Any()).Return(fixture.categories, nil)
+			},
+			expectedRet: structs.CatalogImportReport{
+				Created: 1,
+				Invalid: 1,
+				Rows: []structs.CatalogImportRowResult{
+					{Line: 2, Articule: existing.Articule, Status: structs.ImportRowCreated},
+					{Line: 5, Articule: unknownBrand.Articule, Status: structs.ImportRowInvalid, Reason: `unknown brand "Unknown"`},
+				},
+			},
+			expectedErr: structs.ErrCatalogImportInvalid,
+		},
+		{
+			name: "brand with price category is created",
+			rows: []structs.CatalogImportRow{newBrand},
+			setupMocks: func(mockRepo *mock_structs.MockCatalogRepository) {
+				mockRepo.EXPECT().ExistingArticules(fixture.ctx, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().VariantArticules(fixture.ctx, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().ExistingBrands(fixture.ctx, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().FindCategories(fixture.ctx, gomock.Any()).Return(fixture.categories, nil)
+				mockRepo.EXPECT().Import(fixture.ctx, []structs.CatalogImportRow{resolved(newBrand, fixture.categories[0])}).Return(nil)
+			},
+			expectedRet: structs.CatalogImportReport{
+				Applied: true,
+				Created: 1,
+				Rows: []structs.CatalogImportRowResult{
+					{Line: 5, Articule: newBrand.Articule, Status: structs.ImportRowCreated},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:   "category is resolved by slug or name",
+			rows:   []structs.CatalogImportRow{bySlug, unknownCategory},
+			dryRun: true,
+			setupMocks: func(mockRepo *mock_structs.MockCatalogRepository) {
+				mockRepo.EXPECT().ExistingArticules(fixture.ctx, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().VariantArticules(fixture.ctx, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().ExistingBrands(fixture.ctx, gomock.Any()).Return([]string{existing.Brand}, nil)
+				mockRepo.EXPECT().FindCategories(fixture.ctx, []string{"makeup-eyes", "уход"}).Return(fixture.categories, nil)
+			},
+			expectedRet: structs.CatalogImportReport{
+				DryRun:  true,
+				Created: 1,
+				Invalid: 1,
+				Rows: []structs.CatalogImportRowResult{
+					{Line: 6, Articule: bySlug.Articule, Status: structs.ImportRowCreated},
+					{Line: 7, Articule: unknownCategory.Articule, Status: structs.ImportRowInvalid, Reason: `unknown category "уход"`},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:   "articule of a variant is invalid",
+			rows:   []structs.CatalogImportRow{variantArt},
+			dryRun: true,
+			setupMocks: func(mockRepo *mock_structs.MockCatalogRepository) {
+				mockRepo.EXPECT().ExistingArticules(fixture.ctx, []string{variantArt.Articule}).Return(nil, nil)
+				mockRepo.EXPECT().VariantArticules(fixture.ctx, []string{variantArt.Articule}).Return([]string{variantArt.Articule}, nil)
+				mockRepo.EXPECT().ExistingBrands(fixture.ctx, gomock.Any()).Return([]string{existing.Brand}, nil)
+				mockRepo.EXPECT().FindCategories(fixture.ctx, gomock.Any()).Return(fixture.categories, nil)
+			},
+			expectedRet: structs.CatalogImportReport{
+				DryRun:  true,
+				Invalid: 1,
+				Rows: []structs.CatalogImportRowResult{
+					{Line: 8, Articule: variantArt.Articule, Status: structs.ImportRowInvalid, Reason: "duplicate articule (taken by a product variant)"},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			rows: []structs.CatalogImportRow{existing},
+			setupMocks: func(mockRepo *mock_structs.MockCatalogRepository) {
+				mockRepo.EXPECT().ExistingArticules(fixture.ctx, gomock.Any()).Return([]string{existing.Articule}, nil)
+				mockRepo.EXPECT().VariantArticules(fixture.ctx, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().ExistingBrands(fixture.ctx, gomock.Any()).Return([]string{existing.Brand}, nil)
+				mockRepo.EXPECT().FindCategories(fixture.ctx, gomock.Any()).Return(fixture.categories, nil)
+				mockRepo.EXPECT().Import(fixture.ctx, []structs.CatalogImportRow{resolved(existing, fixture.categories[0])}).Return(errTest)
+			},
+			expectedRet: structs.CatalogImportReport{},
+			expectedErr: errTest,
+		},
+		{
+			name:        "empty import",
+			rows:        nil,
+			setupMocks:  func(mockRepo *mock_structs.MockCatalogRepository) {},
+			expectedRet: structs.CatalogImportReport{},
+			expectedErr: structs.ErrEmptyImport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Import(fixture.ctx, tt.rows, tt.dryRun)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestParseImport(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		input       string
+		expectedRet []structs.CatalogImportRow
+		expectedErr error
+	}{
+		{
+			name:   "csv",
+			format: structs.CatalogFormatCSV,
+			input: "articule,name,price,category,amount,brand\n" +
+				"MAY-LS-002,Тушь,\"1 200,50\",декоративная,50,Maybelline\n" +
+				"NIV-SF-003,Крем,abc,уход,1,Nivea\n",
+			expectedRet: []structs.CatalogImportRow{
+				{Line: 2, Articule: "MAY-LS-002", Name: "Тушь", Price: 1200.5, Category: "декоративная", Amount: 50, Brand: "Maybelline"},
+				{Line: 3, Articule: "NIV-SF-003", Name: "Крем", Category: "уход", Brand: "Nivea", Reason: `invalid price "abc"`},
+			},
+		},
+		{
+			name:   "jsonl",
+			format: structs.CatalogFormatJSONL,
+			input: `{"articule":"MAY-LS-002","name":"Тушь","price":"1200.5","category":"декоративная","amount":50,"brand":"Maybelline"}` + "\n\n" +
+				`{"articule":` + "\n",
+			expectedRet: []structs.CatalogImportRow{
+				{Line: 1, Articule: "MAY-LS-002", Name: "Тушь", Price: 1200.5, Category: "декоративная", Amount: 50, Brand: "Maybelline"},
+				{Line: 3, Reason: "malformed json: unexpected end of JSON input"},
+			},
+		},
+		{
+			name:        "csv without articule column",
+			format:      structs.CatalogFormatCSV,
+			input:       "name,price\nТушь,1200\n",
+			expectedErr: nil,
+		},
+		{
+			name:        "unsupported format",
+			format:      "xml",
+			input:       "<offers/>",
+			expectedErr: structs.ErrUnsupportedFormat,
+		},
+		{
+			name:        "empty file",
+			format:      structs.CatalogFormatJSONL,
+			input:       "",
+			expectedErr: structs.ErrEmptyImport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ParseImport(tt.format, strings.NewReader(tt.input))
+
+			if tt.expectedRet == nil {
+				require.Error(t, err)
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRet, rows)
+		})
+	}
+}
+
+func TestExport_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	streamItems := func(ctx context.Context, fn func(structs.CatalogExportItem) error) error {
+		if err := fn(fixture.item); err != nil {
+			return err
+		}
+		return fn(fixture.variant)
+	}
+
+	tests := []struct {
+		name        string
+		format      string
+		setupMocks  func(*mock_structs.MockCatalogRepository)
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:   "csv",
+			format: structs.CatalogFormatCSV,
+			setupMocks: func(mockRepo *mock_structs.MockCatalogRepository) {
+				mockRepo.EXPECT().ExportProducts(fixture.ctx, gomock.Any()).DoAndReturn(streamItems)
+			},
+			expected: []string{
+				"articule,product_articule,name,description,price,category,shade,volume_ml,available,brand,pic_link,images\n",
+				"MAY-LS-002,MAY-LS-002,Тушь для ресниц Lash Sensational,Объемная тушь & подкрутка,1200.00,makeup-eyes,,,47,Maybelline,/images/mascara.jpg,\n",
+				"MAY-SS-220,MAY-SS,Помада SuperStay Matte Ink,,950.00,makeup-lips,220 Ambitious,5,0,Maybelline," +
+					"/media/products/ss/front.jpg,/media/products/ss/front.jpg /media/products/ss/swatch.jpg\n",
+			},
+		},
+		{
+			name:   "jsonl",
+			format: structs.CatalogFormatJSONL,
+			setupMocks: func(mockRepo *mock_structs.MockCatalogRepository) {
+				mockRepo.EXPECT().ExportProducts(fixture.ctx, gomock.Any()).DoAndReturn(streamItems)
+			},
+			expected: []string{
+				`"articule":"MAY-LS-002"`,
+				`"description":"Объемная тушь & подкрутка"`,
+				`"brand":"Maybelline"`,
+				`"available":47`,
+				`"product_articule":"MAY-SS","name":"Помада SuperStay Matte Ink"`,
+				`"images":["/media/products/ss/front.jpg","/media/products/ss/swatch.jpg"]`,
+			},
+		},
+		{
+			name:   "yml",
+			format: structs.CatalogFormatYML,
+			setupMocks: func(mockRepo *mock_structs.MockCatalogRepository) {
+				mockRepo.EXPECT().ExportCategories(fixture.ctx).Return(fixture.categories, nil)
+				mockRepo.EXPECT().ExportProducts(fixture.ctx, gomock.Any()).DoAndReturn(streamItems)
+			},
+			expected: []string{
+				"<shop>\n<name>Виртуаль</name>",
+				`<category id="1">Декоративная</category>`,
+				`<category id="2" parentId="1">Глаза</category>`,
+				`<offer id="MAY-LS-002" available="true">`,
+				"<vendor>Maybelline</vendor>",
+				"<price>1200.00</price><currencyId>RUR</currencyId><categoryId>2</categoryId>",
+				"<picture>https://virtual.example.com/images/mascara.jpg</picture>",
+				"<description>Объемная тушь &amp; подкрутка</description><count>47</count></offer>",
+				`<offer id="MAY-SS-220" available="false">`,
+				"<vendorCode>MAY-SS-220</vendorCode><price>950.00</price>",
+				"<picture>https://virtual.example.com/media/products/ss/front.jpg</picture>" +
+					"<picture>https://virtual.example.com/media/products/ss/swatch.jpg</picture>",
+				`<param name="Оттенок">220 Ambitious</param><param name="Объем" unit="мл">5</param><count>0</count></offer>`,
+				"</offers>\n</shop>\n</yml_catalog>\n",
+			},
+		},
+		{
+			name:   "repository error",
+			format: structs.CatalogFormatYML,
+			setupMocks: func(mockRepo *mock_structs.MockCatalogRepository) {
+				mockRepo.EXPECT().ExportCategories(fixture.ctx).Return(nil, errTest)
+			},
+			expectedErr: errTest,
+		},
+		{
+			name:        "unsupported format",
+			format:      "xls",
+			setupMocks:  func(mockRepo *mock_structs.MockCatalogRepository) {},
+			expectedErr: structs.ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			var buf bytes.Buffer
+			err := service.Export(fixture.ctx, tt.format, &buf)
+
+			fixture.AssertError(err, tt.expectedErr)
+			for _, e := range tt.expected {
+				assert.Contains(t, buf.String(), e)
+			}
+		})
+	}
+	fixture.Cleanup()
+}
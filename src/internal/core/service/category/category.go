@@ -0,0 +1,121 @@
+package category
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type CategoryService interface {
+	Create(ctx context.Context, c structs.Category) (uuid.UUID, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.Category, error)
+	GetBySlug(ctx context.Context, slug string) (structs.Category, error)
+	GetTree(ctx context.Context) ([]structs.Category, error)
+	Update(ctx context.Context, c structs.Category) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type CategoryRepository interface {
+	Create(ctx context.Context, c structs.Category) (uuid.UUID, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.Category, error)
+	GetBySlug(ctx context.Context, slug string) (structs.Category, error)
+	GetAll(ctx context.Context) ([]structs.Category, error)
+	Update(ctx context.Context, c structs.Category) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type Service struct {
+	rep CategoryRepository
+}
+
+func New(rep CategoryRepository) *Service {
+	return &Service{rep: rep}
+}
+
+func (s *Service) Create(ctx context.Context, c structs.Category) (uuid.UUID, error) {
+	if err := validate(c); err != nil {
+		return uuid.Nil, err
+	}
+	return s.rep.Create(ctx, c)
+}
+
+func (s *Service) GetById(ctx context.Context, id uuid.UUID) (structs.Category, error) {
+	return s.rep.GetById(ctx, id)
+}
+
+func (s *Service) GetBySlug(ctx context.Context, slug string) (structs.Category, error) {
+	return s.rep.GetBySlug(ctx, slug)
+}
+
+// GetTree returns the root categories with their descendants nested in
+// Children. Siblings keep the order of the repository (sort order, name).
+func (s *Service) GetTree(ctx context.Context) ([]structs.Category, error) {
+	all, err := s.rep.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[uuid.UUID][]structs.Category)
+	for _, c := range all {
+		children[c.IdParent] = append(children[c.IdParent], c)
+	}
+
+	var attach func(id uuid.UUID) []structs.Category
+	attach = func(id uuid.UUID) []structs.Category {
+		nodes := children[id]
+		for i := range nodes {
+			nodes[i].Children = attach(nodes[i].Id)
+		}
+		return nodes
+	}
+
+	tree := attach(uuid.Nil)
+	if tree == nil {
+		tree = []structs.Category{}
+	}
+	return tree, nil
+}
+
+// Update rejects moving a category under itself or one of its descendants,
+// which would detach the subtree from the roots.
+func (s *Service) Update(ctx context.Context, c structs.Category) error {
+	if err := validate(c); err != nil {
+		return err
+	}
+
+	if c.IdParent != uuid.Nil {
+		all, err := s.rep.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		parents := make(map[uuid.UUID]uuid.UUID, len(all))
+		for _, v := range all {
+			parents[v.Id] = v.IdParent
+		}
+		if _, ok := parents[c.IdParent]; !ok {
+			return structs.ErrCategoryNotFound
+		}
+		for id := c.IdParent; id != uuid.Nil; id = parents[id] {
+			if id == c.Id {
+				return structs.ErrCategoryCycle
+			}
+		}
+	}
+
+	return s.rep.Update(ctx, c)
+}
+
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.rep.Delete(ctx, id)
+}
+
+func validate(c structs.Category) error {
+	if c.Name == "" || !slugPattern.MatchString(c.Slug) {
+		return structs.ErrInvalidCategory
+	}
+	return nil
+}
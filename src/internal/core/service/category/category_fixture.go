@@ -0,0 +1,67 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t      *testing.T
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	root   structs.Category
+	child  structs.Category
+	leaf   structs.Category
+	others structs.Category
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	root := structs.Category{Id: structs.GenId(), Name: "Уход", Slug: "skincare", SortOrder: 1}
+	child := structs.Category{Id: structs.GenId(), IdParent: root.Id, Name: "Лицо", Slug: "skincare-face", SortOrder: 1}
+	leaf := structs.Category{Id: structs.GenId(), IdParent: child.Id, Name: "Сыворотки", Slug: "skincare-face-serums"}
+	others := structs.Category{Id: structs.GenId(), Name: "Парфюмерия", Slug: "perfume", SortOrder: 3}
+
+	return &TestFixture{
+		t:      t,
+		ctrl:   ctrl,
+		ctx:    context.Background(),
+		root:   root,
+		child:  child,
+		leaf:   leaf,
+		others: others,
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockCategoryRepository) {
+	mockRepo := mock_structs.NewMockCategoryRepository(f.ctrl)
+
+	service := New(mockRepo)
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
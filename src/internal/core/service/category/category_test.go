@@ -0,0 +1,250 @@
+package category
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	invalidSlug := fixture.child
+	invalidSlug.Slug = "Лицо"
+	noName := fixture.child
+	noName.Name = ""
+
+	tests := []struct {
+		name        string
+		category    structs.Category
+		setupMocks  func(*mock_structs.MockCategoryRepository)
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:     "successful create",
+			category: fixture.child,
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, fixture.child).Return(fixture.child.Id, nil)
+			},
+			expectedRet: fixture.child.Id,
+			expectedErr: nil,
+		},
+		{
+			name:        "slug with uppercase or cyrillic letters",
+			category:    invalidSlug,
+			setupMocks:  func(mockRepo *mock_structs.MockCategoryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidCategory,
+		},
+		{
+			name:        "missing name",
+			category:    noName,
+			setupMocks:  func(mockRepo *mock_structs.MockCategoryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidCategory,
+		},
+		{
+			name:     "duplicate slug",
+			category: fixture.child,
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, fixture.child).Return(uuid.Nil, structs.ErrDuplicateSlug)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDuplicateSlug,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Create(fixture.ctx, tt.category)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetTree_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	child := fixture.child
+	child.Children = []structs.Category{fixture.leaf}
+	root := fixture.root
+	root.Children = []structs.Category{child}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockCategoryRepository)
+		expectedRet []structs.Category
+		expectedErr error
+	}{
+		{
+			name: "nested tree keeps sibling order",
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().GetAll(fixture.ctx).
+					Return([]structs.Category{fixture.leaf, fixture.root, fixture.child, fixture.others}, nil)
+			},
+			expectedRet: []structs.Category{root, fixture.others},
+			expectedErr: nil,
+		},
+		{
+			name: "empty tree",
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().GetAll(fixture.ctx).Return(nil, nil)
+			},
+			expectedRet: []structs.Category{},
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().GetAll(fixture.ctx).Return(nil, errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.GetTree(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestUpdate_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	all := []structs.Category{fixture.root, fixture.child, fixture.leaf, fixture.others}
+
+	moved := fixture.child
+	moved.IdParent = fixture.others.Id
+	underItself := fixture.child
+	underItself.IdParent = fixture.child.Id
+	underDescendant := fixture.root
+	underDescendant.IdParent = fixture.leaf.Id
+	unknownParent := fixture.child
+	unknownParent.IdParent = structs.GenId()
+	toRoot := fixture.child
+	toRoot.IdParent = uuid.Nil
+
+	tests := []struct {
+		name        string
+		category    structs.Category
+		setupMocks  func(*mock_structs.MockCategoryRepository)
+		expectedErr error
+	}{
+		{
+			name:     "move under another branch",
+			category: moved,
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().GetAll(fixture.ctx).Return(all, nil)
+				mockRepo.EXPECT().Update(fixture.ctx, moved).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:     "move to the root",
+			category: toRoot,
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().Update(fixture.ctx, toRoot).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:     "parent is the category itself",
+			category: underItself,
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().GetAll(fixture.ctx).Return(all, nil)
+			},
+			expectedErr: structs.ErrCategoryCycle,
+		},
+		{
+			name:     "parent is a descendant",
+			category: underDescendant,
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().GetAll(fixture.ctx).Return(all, nil)
+			},
+			expectedErr: structs.ErrCategoryCycle,
+		},
+		{
+			name:     "unknown parent",
+			category: unknownParent,
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().GetAll(fixture.ctx).Return(all, nil)
+			},
+			expectedErr: structs.ErrCategoryNotFound,
+		},
+		{
+			name:     "repository error",
+			category: moved,
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().GetAll(fixture.ctx).Return(nil, errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.Update(fixture.ctx, tt.category)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDelete_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockCategoryRepository)
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().Delete(fixture.ctx, fixture.leaf.Id).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "category in use",
+			setupMocks: func(mockRepo *mock_structs.MockCategoryRepository) {
+				mockRepo.EXPECT().Delete(fixture.ctx, gomock.Any()).Return(structs.ErrCategoryInUse)
+			},
+			expectedErr: structs.ErrCategoryInUse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.Delete(fixture.ctx, fixture.leaf.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,162 @@
+package delivery
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type DeliveryService interface {
+	Create(ctx context.Context, m structs.DeliveryMethod) (uuid.UUID, error)
+	GetAll(ctx context.Context) ([]structs.DeliveryMethod, error)
+	GetActive(ctx context.Context) ([]structs.DeliveryMethod, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error)
+	SetRates(ctx context.Context, id uuid.UUID, rates []structs.DeliveryRate) error
+	Deactivate(ctx context.Context, id uuid.UUID) error
+	CreateSlot(ctx context.Context, slot structs.DeliverySlot) (uuid.UUID, error)
+	GetSlots(ctx context.Context, id_delivery uuid.UUID, from time.Time, to time.Time) ([]structs.DeliverySlot, error)
+	DeleteSlot(ctx context.Context, id_delivery uuid.UUID, id uuid.UUID) error
+}
+
+type DeliveryRepository interface {
+	Create(ctx context.Context, m structs.DeliveryMethod) (uuid.UUID, error)
+	GetAll(ctx context.Context) ([]structs.DeliveryMethod, error)
+	GetActive(ctx context.Context) ([]structs.DeliveryMethod, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error)
+	SetRates(ctx context.Context, id uuid.UUID, rates []structs.DeliveryRate) error
+	Deactivate(ctx context.Context, id uuid.UUID) error
+	CreateSlot(ctx context.Context, slot structs.DeliverySlot) (uuid.UUID, error)
+	GetSlots(ctx context.Context, id_delivery uuid.UUID, from time.Time, to time.Time) ([]structs.DeliverySlot, error)
+	DeleteSlot(ctx context.Context, id_delivery uuid.UUID, id uuid.UUID) error
+}
+
+const (
+	maxName      = 100
+	maxZone      = 100
+	maxRates     = 100
+	slotHorizon  = 14 * 24 * time.Hour
+	maxSlotRange = 31 * 24 * time.Hour
+)
+
+type Service struct {
+	rep DeliveryRepository
+	now func() time.Time
+}
+
+func New(rep DeliveryRepository) *Service {
+	return &Service{rep: rep, now: time.Now}
+}
+
+// Create stores an active delivery method. Zones are case insensitive and
+// kept in lower case.
+func (s *Service) Create(ctx context.Context, m structs.DeliveryMethod) (uuid.UUID, error) {
+	m.Name = strings.TrimSpace(m.Name)
+	if m.Name == "" || utf8.RuneCountInString(m.Name) > maxName ||
+		!slices.Contains(structs.DeliveryKinds, m.Kind) || m.FreeFrom < 0 {
+		return uuid.Nil, structs.ErrInvalidDelivery
+	}
+	rates, err := normalizeRates(m.Rates)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	m.Rates = rates
+	m.Active = true
+	return s.rep.Create(ctx, m)
+}
+
+// normalizeRates checks the rates of a method, a method needs at least
+// one.
+func normalizeRates(rates []structs.DeliveryRate) ([]structs.DeliveryRate, error) {
+	if len(rates) == 0 || len(rates) > maxRates {
+		return nil, structs.ErrInvalidDelivery
+	}
+	res := make([]structs.DeliveryRate, len(rates))
+	for i, r := range rates {
+		r.Zone = strings.ToLower(strings.TrimSpace(r.Zone))
+		if r.Zone == "" || utf8.RuneCountInString(r.Zone) > maxZone {
+			return nil, structs.ErrInvalidDelivery
+		}
+		if r.MaxWeight < 0 || r.MinTotal < 0 || r.Cost < 0 || r.PerKg < 0 {
+			return nil, structs.ErrInvalidDelivery
+		}
+		res[i] = r
+	}
+	return res, nil
+}
+
+func (s *Service) GetAll(ctx context.Context) ([]structs.DeliveryMethod, error) {
+	return s.rep.GetAll(ctx)
+}
+
+func (s *Service) GetActive(ctx context.Context) ([]structs.DeliveryMethod, error) {
+	return s.rep.GetActive(ctx)
+}
+
+func (s *Service) GetById(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error) {
+	return s.rep.GetById(ctx, id)
+}
+
+// SetRates replaces the rates of the method. Placed orders keep the cost
+// they were given.
+func (s *Service) SetRates(ctx context.Context, id uuid.UUID, rates []structs.DeliveryRate) error {
+	rates, err := normalizeRates(rates)
+	if err != nil {
+		return err
+	}
+	return s.rep.SetRates(ctx, id, rates)
+}
+
+// Deactivate stops offering the method. Methods are never deleted because
+// orders keep references to them.
+func (s *Service) Deactivate(ctx context.Context, id uuid.UUID) error {
+	return s.rep.Deactivate(ctx, id)
+}
+
+// CreateSlot adds a future delivery slot to a courier method.
+func (s *Service) CreateSlot(ctx context.Context, slot structs.DeliverySlot) (uuid.UUID, error) {
+	if slot.Capacity < 1 || !slot.EndsAt.After(slot.StartsAt) || !slot.StartsAt.After(s.now()) {
+		return uuid.Nil, structs.ErrInvalidSlot
+	}
+	m, err := s.rep.GetById(ctx, slot.IdDelivery)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if m.Kind != structs.DeliveryCourier {
+		return uuid.Nil, structs.ErrInvalidSlot
+	}
+	return s.rep.CreateSlot(ctx, slot)
+}
+
+// GetSlots returns the slots of an active method starting between from and
+// to, with the places taken. Without from the slots start now, without to
+// they are looked up for two weeks; at most a month is returned at once.
+func (s *Service) GetSlots(ctx context.Context, id_delivery uuid.UUID, from time.Time, to time.Time) ([]structs.DeliverySlot, error) {
+	if from.IsZero() {
+		from = s.now()
+	}
+	if to.IsZero() {
+		to = from.Add(slotHorizon)
+	}
+	if !to.After(from) || to.Sub(from) > maxSlotRange {
+		return nil, structs.ErrInvalidSlot
+	}
+
+	m, err := s.rep.GetById(ctx, id_delivery)
+	if err != nil {
+		return nil, err
+	}
+	if !m.Active {
+		return nil, structs.ErrDeliveryNotFound
+	}
+	return s.rep.GetSlots(ctx, id_delivery, from, to)
+}
+
+// DeleteSlot removes a slot no order was placed in.
+func (s *Service) DeleteSlot(ctx context.Context, id_delivery uuid.UUID, id uuid.UUID) error {
+	return s.rep.DeleteSlot(ctx, id_delivery, id)
+}
@@ -0,0 +1,88 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t       *testing.T
+	ctrl    *gomock.Controller
+	ctx     context.Context
+	now     time.Time
+	courier structs.DeliveryMethod
+	post    structs.DeliveryMethod
+	slot    structs.DeliverySlot
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	id_courier := structs.GenId()
+
+	return &TestFixture{
+		t:    t,
+		ctrl: ctrl,
+		ctx:  context.Background(),
+		now:  now,
+		courier: structs.DeliveryMethod{
+			Id:       id_courier,
+			Kind:     structs.DeliveryCourier,
+			Name:     "Курьер",
+			FreeFrom: 5000,
+			Active:   true,
+			Rates: []structs.DeliveryRate{
+				{Zone: "moscow", MaxWeight: 10000, Cost: 350},
+				{Zone: "moscow oblast", MaxWeight: 10000, Cost: 500, PerKg: 30},
+			},
+		},
+		post: structs.DeliveryMethod{
+			Id:     structs.GenId(),
+			Kind:   structs.DeliveryPost,
+			Name:   "Почта",
+			Active: true,
+			Rates:  []structs.DeliveryRate{{Zone: "russia", MaxWeight: 20000, Cost: 250, PerKg: 40}},
+		},
+		slot: structs.DeliverySlot{
+			IdDelivery: id_courier,
+			StartsAt:   now.Add(24 * time.Hour),
+			EndsAt:     now.Add(27 * time.Hour),
+			Capacity:   10,
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockDeliveryRepository) {
+	mockRepo := mock_structs.NewMockDeliveryRepository(f.ctrl)
+
+	service := New(mockRepo)
+	service.now = func() time.Time { return f.now }
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
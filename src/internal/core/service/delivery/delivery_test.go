@@ -0,0 +1,326 @@
+package delivery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	stored := fixture.courier
+	stored.Id = uuid.Nil
+	input := stored
+	input.Name = "  Курьер "
+	input.Active = false
+	input.Rates = []structs.DeliveryRate{
+		{Zone: " Moscow ", MaxWeight: 10000, Cost: 350},
+		{Zone: "MOSCOW OBLAST", MaxWeight: 10000, Cost: 500, PerKg: 30},
+	}
+
+	with := func(fn func(*structs.DeliveryMethod)) structs.DeliveryMethod {
+		m := stored
+		m.Rates = append([]structs.DeliveryRate(nil), stored.Rates...)
+		fn(&m)
+		return m
+	}
+
+	tests := []struct {
+		name        string
+		method      structs.DeliveryMethod
+		setupMocks  func(*mock_structs.MockDeliveryRepository)
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:   "successful creation",
+			method: input,
+			setupMocks: func(mockRepo *mock_structs.MockDeliveryRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, stored).Return(fixture.courier.Id, nil)
+			},
+			expectedRet: fixture.courier.Id,
+			expectedErr: nil,
+		},
+		{
+			name:        "unknown kind",
+			method:      with(func(m *structs.DeliveryMethod) { m.Kind = "drone" }),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidDelivery,
+		},
+		{
+			name:        "empty name",
+			method:      with(func(m *structs.DeliveryMethod) { m.Name = " " }),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidDelivery,
+		},
+		{
+			name:        "negative free-shipping threshold",
+			method:      with(func(m *structs.DeliveryMethod) { m.FreeFrom = -1 }),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidDelivery,
+		},
+		{
+			name:        "no rates",
+			method:      with(func(m *structs.DeliveryMethod) { m.Rates = nil }),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidDelivery,
+		},
+		{
+			name:        "rate without a zone",
+			method:      with(func(m *structs.DeliveryMethod) { m.Rates[1].Zone = "" }),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidDelivery,
+		},
+		{
+			name:        "negative rate cost",
+			method:      with(func(m *structs.DeliveryMethod) { m.Rates[0].PerKg = -10 }),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidDelivery,
+		},
+		{
+			name:   "repository error",
+			method: input,
+			setupMocks: func(mockRepo *mock_structs.MockDeliveryRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, stored).Return(uuid.Nil, errTest)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Create(fixture.ctx, tt.method)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSetRates_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	id := fixture.post.Id
+
+	tests := []struct {
+		name        string
+		rates       []structs.DeliveryRate
+		setupMocks  func(*mock_structs.MockDeliveryRepository)
+		expectedErr error
+	}{
+		{
+			name:  "rates replaced",
+			rates: []structs.DeliveryRate{{Zone: "Russia ", Cost: 300}, {Zone: "russia", MinTotal: 3000, Cost: 100}},
+			setupMocks: func(mockRepo *mock_structs.MockDeliveryRepository) {
+				mockRepo.EXPECT().SetRates(fixture.ctx, id, []structs.DeliveryRate{
+					{Zone: "russia", Cost: 300},
+					{Zone: "russia", MinTotal: 3000, Cost: 100},
+				}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "no rates",
+			rates:       []structs.DeliveryRate{},
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expectedErr: structs.ErrInvalidDelivery,
+		},
+		{
+			name:  "method not found",
+			rates: []structs.DeliveryRate{{Zone: "russia", Cost: 300}},
+			setupMocks: func(mockRepo *mock_structs.MockDeliveryRepository) {
+				mockRepo.EXPECT().SetRates(fixture.ctx, id, []structs.DeliveryRate{{Zone: "russia", Cost: 300}}).
+					Return(structs.ErrDeliveryNotFound)
+			},
+			expectedErr: structs.ErrDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.SetRates(fixture.ctx, id, tt.rates)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCreateSlot_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	slot := fixture.slot
+	id := structs.GenId()
+
+	with := func(fn func(*structs.DeliverySlot)) structs.DeliverySlot {
+		s := slot
+		fn(&s)
+		return s
+	}
+
+	tests := []struct {
+		name        string
+		slot        structs.DeliverySlot
+		setupMocks  func(*mock_structs.MockDeliveryRepository)
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "successful creation",
+			slot: slot,
+			setupMocks: func(mockRepo *mock_structs.MockDeliveryRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.courier.Id).Return(fixture.courier, nil)
+				mockRepo.EXPECT().CreateSlot(fixture.ctx, slot).Return(id, nil)
+			},
+			expectedRet: id,
+			expectedErr: nil,
+		},
+		{
+			name:        "no capacity",
+			slot:        with(func(s *structs.DeliverySlot) { s.Capacity = 0 }),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidSlot,
+		},
+		{
+			name:        "ends before it starts",
+			slot:        with(func(s *structs.DeliverySlot) { s.EndsAt = s.StartsAt.Add(-time.Hour) }),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidSlot,
+		},
+		{
+			name: "slot in the past",
+			slot: with(func(s *structs.DeliverySlot) {
+				s.StartsAt = fixture.now.Add(-2 * time.Hour)
+				s.EndsAt = fixture.now.Add(time.Hour)
+			}),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidSlot,
+		},
+		{
+			name: "method without slots",
+			slot: with(func(s *structs.DeliverySlot) { s.IdDelivery = fixture.post.Id }),
+			setupMocks: func(mockRepo *mock_structs.MockDeliveryRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.post.Id).Return(fixture.post, nil)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidSlot,
+		},
+		{
+			name: "method not found",
+			slot: slot,
+			setupMocks: func(mockRepo *mock_structs.MockDeliveryRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.courier.Id).Return(structs.DeliveryMethod{}, structs.ErrDeliveryNotFound)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.CreateSlot(fixture.ctx, tt.slot)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetSlots_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	id := fixture.courier.Id
+	slots := []structs.DeliverySlot{fixture.slot}
+	from := fixture.now.Add(48 * time.Hour)
+	inactive := fixture.courier
+	inactive.Active = false
+
+	tests := []struct {
+		name        string
+		from        time.Time
+		to          time.Time
+		setupMocks  func(*mock_structs.MockDeliveryRepository)
+		expected    []structs.DeliverySlot
+		expectedErr error
+	}{
+		{
+			name: "next two weeks by default",
+			setupMocks: func(mockRepo *mock_structs.MockDeliveryRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, id).Return(fixture.courier, nil)
+				mockRepo.EXPECT().GetSlots(fixture.ctx, id, fixture.now, fixture.now.Add(14*24*time.Hour)).Return(slots, nil)
+			},
+			expected:    slots,
+			expectedErr: nil,
+		},
+		{
+			name: "given range",
+			from: from,
+			to:   from.Add(72 * time.Hour),
+			setupMocks: func(mockRepo *mock_structs.MockDeliveryRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, id).Return(fixture.courier, nil)
+				mockRepo.EXPECT().GetSlots(fixture.ctx, id, from, from.Add(72*time.Hour)).Return(nil, nil)
+			},
+			expected:    nil,
+			expectedErr: nil,
+		},
+		{
+			name:        "range ends before it starts",
+			from:        from,
+			to:          from.Add(-time.Hour),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expected:    nil,
+			expectedErr: structs.ErrInvalidSlot,
+		},
+		{
+			name:        "range too long",
+			from:        from,
+			to:          from.Add(40 * 24 * time.Hour),
+			setupMocks:  func(mockRepo *mock_structs.MockDeliveryRepository) {},
+			expected:    nil,
+			expectedErr: structs.ErrInvalidSlot,
+		},
+		{
+			name: "inactive method",
+			setupMocks: func(mockRepo *mock_structs.MockDeliveryRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, id).Return(inactive, nil)
+			},
+			expected:    nil,
+			expectedErr: structs.ErrDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.GetSlots(fixture.ctx, id, tt.from, tt.to)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, ret)
+		})
+	}
+	fixture.Cleanup()
+}
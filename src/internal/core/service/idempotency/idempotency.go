@@ -0,0 +1,84 @@
+package idempotency
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, rec structs.IdempotencyRecord) (structs.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, rec structs.IdempotencyRecord) error
+	Abandon(ctx context.Context, id_user uuid.UUID, key string) error
+	Sweep(ctx context.Context) (int64, error)
+}
+
+type IdempotencyRepository interface {
+	Claim(ctx context.Context, rec structs.IdempotencyRecord, ttl time.Duration) (structs.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, rec structs.IdempotencyRecord) error
+	Delete(ctx context.Context, id_user uuid.UUID, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+const maxKey = 255
+
+type Service struct {
+	rep IdempotencyRepository
+	ttl time.Duration
+}
+
+func New(rep IdempotencyRepository, ttl time.Duration) *Service {
+	return &Service{rep: rep, ttl: ttl}
+}
+
+// Begin claims the key of the user for the request. When the key is new or
+// its record has expired, the request should run and the returned flag is
+// false. When the key was already used for the same request, the stored
+// response is returned with the flag set. A key used for another request
+// fails with ErrIdempotencyKeyReused, and a key whose first request has
+// not finished yet fails with ErrIdempotencyKeyInProgress.
+func (s *Service) Begin(ctx context.Context, rec structs.IdempotencyRecord) (structs.IdempotencyRecord, bool, error) {
+	rec.Key = strings.TrimSpace(rec.Key)
+	if rec.Key == "" || len(rec.Key) > maxKey || rec.IdUser == uuid.Nil || rec.RequestHash == "" {
+		return structs.IdempotencyRecord{}, false, structs.ErrInvalidIdempotencyKey
+	}
+
+	stored, claimed, err := s.rep.Claim(ctx, rec, s.ttl)
+	if err != nil {
+		return structs.IdempotencyRecord{}, false, err
+	}
+	if claimed {
+		return stored, false, nil
+	}
+	if stored.RequestHash != rec.RequestHash {
+		return structs.IdempotencyRecord{}, false, structs.ErrIdempotencyKeyReused
+	}
+	if !stored.Done() {
+		return structs.IdempotencyRecord{}, false, structs.ErrIdempotencyKeyInProgress
+	}
+	return stored, true, nil
+}
+
+// Complete stores the response of the request that claimed the key.
+func (s *Service) Complete(ctx context.Context, rec structs.IdempotencyRecord) error {
+	rec.Key = strings.TrimSpace(rec.Key)
+	if rec.Status == 0 {
+		return structs.ErrInvalidIdempotencyKey
+	}
+	return s.rep.Complete(ctx, rec)
+}
+
+// Abandon frees the key when the request failed without a response worth
+// replaying, so that a retry runs it again.
+func (s *Service) Abandon(ctx context.Context, id_user uuid.UUID, key string) error {
+	return s.rep.Delete(ctx, id_user, strings.TrimSpace(key))
+}
+
+// Sweep deletes expired records. Expired keys may already be claimed again,
+// so sweeping only keeps the table small.
+func (s *Service) Sweep(ctx context.Context) (int64, error) {
+	return s.rep.DeleteExpired(ctx)
+}
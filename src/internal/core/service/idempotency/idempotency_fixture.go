@@ -0,0 +1,64 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t      *testing.T
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	ttl    time.Duration
+	record structs.IdempotencyRecord
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+
+	return &TestFixture{
+		t:    t,
+		ctrl: ctrl,
+		ctx:  context.Background(),
+		ttl:  24 * time.Hour,
+		record: structs.IdempotencyRecord{
+			IdUser:      structs.GenId(),
+			Key:         "3f1c9a52-order",
+			RequestHash: "9b74c9897bac770ffc029102a200c5de",
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockIdempotencyRepository) {
+	mockRepo := mock_structs.NewMockIdempotencyRepository(f.ctrl)
+
+	service := New(mockRepo, f.ttl)
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
@@ -0,0 +1,241 @@
+package idempotency
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestBegin_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	rec := fixture.record
+	done := rec
+	done.Status = 201
+	done.ContentType = "application/json; charset=utf-8"
+	done.Body = []byte(`{"message":"Order created"}`)
+	other := done
+	other.RequestHash = "0cc175b9c0f1b6a831c399e269772661"
+
+	tests := []struct {
+		name           string
+		rec            structs.IdempotencyRecord
+		setupMocks     func(*mock_structs.MockIdempotencyRepository)
+		expectedRet    structs.IdempotencyRecord
+		expectedReplay bool
+		expectedErr    error
+	}{
+		{
+			name: "new key is claimed",
+			rec:  rec,
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().Claim(fixture.ctx, rec, fixture.ttl).Return(rec, true, nil)
+			},
+			expectedRet:    rec,
+			expectedReplay: false,
+			expectedErr:    nil,
+		},
+		{
+			name: "key is trimmed",
+			rec: structs.IdempotencyRecord{
+				IdUser: rec.IdUser, Key: "  " + rec.Key + " ", RequestHash: rec.RequestHash,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().Claim(fixture.ctx, rec, fixture.ttl).Return(rec, true, nil)
+			},
+			expectedRet:    rec,
+			expectedReplay: false,
+			expectedErr:    nil,
+		},
+		{
+			name: "same request replays stored response",
+			rec:  rec,
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().Claim(fixture.ctx, rec, fixture.ttl).Return(done, false, nil)
+			},
+			expectedRet:    done,
+			expectedReplay: true,
+			expectedErr:    nil,
+		},
+		{
+			name: "key used for another request",
+			rec:  rec,
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().Claim(fixture.ctx, rec, fixture.ttl).Return(other, false, nil)
+			},
+			expectedErr: structs.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "first request still running",
+			rec:  rec,
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().Claim(fixture.ctx, rec, fixture.ttl).Return(rec, false, nil)
+			},
+			expectedErr: structs.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name:        "empty key",
+			rec:         structs.IdempotencyRecord{IdUser: rec.IdUser, Key: "  ", RequestHash: rec.RequestHash},
+			setupMocks:  func(mockRepo *mock_structs.MockIdempotencyRepository) {},
+			expectedErr: structs.ErrInvalidIdempotencyKey,
+		},
+		{
+			name:        "key too long",
+			rec:         structs.IdempotencyRecord{IdUser: rec.IdUser, Key: strings.Repeat("k", 256), RequestHash: rec.RequestHash},
+			setupMocks:  func(mockRepo *mock_structs.MockIdempotencyRepository) {},
+			expectedErr: structs.ErrInvalidIdempotencyKey,
+		},
+		{
+			name: "repository error",
+			rec:  rec,
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().Claim(fixture.ctx, rec, fixture.ttl).Return(structs.IdempotencyRecord{}, false, errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, replay, err := service.Begin(fixture.ctx, tt.rec)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			assert.Equal(t, tt.expectedReplay, replay)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestComplete_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	done := fixture.record
+	done.Status = 201
+	done.Body = []byte(`{}`)
+
+	tests := []struct {
+		name        string
+		rec         structs.IdempotencyRecord
+		setupMocks  func(*mock_structs.MockIdempotencyRepository)
+		expectedErr error
+	}{
+		{
+			name: "response stored",
+			rec:  done,
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().Complete(fixture.ctx, done).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "no status",
+			rec:         fixture.record,
+			setupMocks:  func(mockRepo *mock_structs.MockIdempotencyRepository) {},
+			expectedErr: structs.ErrInvalidIdempotencyKey,
+		},
+		{
+			name: "repository error",
+			rec:  done,
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().Complete(fixture.ctx, done).Return(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.Complete(fixture.ctx, tt.rec)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestAbandon_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	rec := fixture.record
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockIdempotencyRepository)
+		expectedErr error
+	}{
+		{
+			name: "key freed",
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().Delete(fixture.ctx, rec.IdUser, rec.Key).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().Delete(fixture.ctx, rec.IdUser, rec.Key).Return(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.Abandon(fixture.ctx, rec.IdUser, " "+rec.Key)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSweep_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockIdempotencyRepository)
+		expectedRet int64
+		expectedErr error
+	}{
+		{
+			name: "expired keys deleted",
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().DeleteExpired(fixture.ctx).Return(int64(4), nil)
+			},
+			expectedRet: 4,
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockIdempotencyRepository) {
+				mockRepo.EXPECT().DeleteExpired(fixture.ctx).Return(int64(0), errTest)
+			},
+			expectedRet: 0,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Sweep(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,296 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+const (
+	MaxImageSize   = 10 << 20
+	MaxImagePixels = 40_000_000
+)
+
+// ThumbnailSizes maps a thumbnail name to the longest side of the box the
+// image is fitted into.
+var ThumbnailSizes = map[string]int{
+	"small":  150,
+	"medium": 400,
+	"large":  800,
+}
+
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+type MediaService interface {
+	Upload(ctx context.Context, idProduct uuid.UUID, files []structs.ImageFile, primary bool) ([]structs.ProductImage, error)
+	GetByProduct(ctx context.Context, idProduct uuid.UUID) ([]structs.ProductImage, error)
+	SetPrimary(ctx context.Context, idProduct uuid.UUID, id uuid.UUID) error
+	Reorder(ctx context.Context, idProduct uuid.UUID, ids []uuid.UUID) error
+	Delete(ctx context.Context, idProduct uuid.UUID, id uuid.UUID) error
+}
+
+type MediaRepository interface {
+	Create(ctx context.Context, imgs []structs.ProductImage) ([]structs.ProductImage, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.ProductImage, error)
+	GetByProduct(ctx context.Context, idProduct uuid.UUID) ([]structs.ProductImage, error)
+	SetPrimary(ctx context.Context, idProduct uuid.UUID, id uuid.UUID, picLink string) error
+	Reorder(ctx context.Context, idProduct uuid.UUID, ids []uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type MediaStorage interface {
+	Save(ctx context.Context, key string, r io.Reader) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+type Service struct {
+	rep     MediaRepository
+	storage MediaStorage
+}
+
+func New(rep MediaRepository, storage MediaStorage) *Service {
+	return &Service{rep: rep, storage: storage}
+}
+
+type decodedImage struct {
+	img         image.Image
+	contentType string
+	data        []byte
+}
+
+// Upload validates all files before anything is written, so a single bad
+// file rejects the whole batch. Files already written to the storage are
+// removed again if the database insert fails.
+func (s *Service) Upload(ctx context.Context, idProduct uuid.UUID, files []structs.ImageFile, primary bool) ([]structs.ProductImage, error) {
+	if len(files) == 0 {
+		return nil, structs.ErrNoImages
+	}
+
+	decoded := make([]decodedImage, 0, len(files))
+	for _, f := range files {
+		d, err := decode(f)
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, d)
+	}
+
+	existing, err := s.rep.GetByProduct(ctx, idProduct)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved []string
+	imgs := make([]structs.ProductImage, 0, len(decoded))
+	for i, d := range decoded {
+		img := structs.ProductImage{
+			Id:          structs.GenId(),
+			IdProduct:   idProduct,
+			IsPrimary:   i == 0 && (primary || len(existing) == 0),
+			ContentType: d.contentType,
+			Size:        int64(len(d.data)),
+			CreatedAt:   time.Now(),
+		}
+		img.Key = path.Join("products", idProduct.String(), img.Id.String()+imageExtensions[d.contentType])
+
+		keys, err := s.store(ctx, img.Key, d)
+		saved = append(saved, keys...)
+		if err != nil {
+			s.cleanup(ctx, saved)
+			return nil, err
+		}
+		imgs = append(imgs, s.withURLs(img))
+	}
+
+	imgs, err = s.rep.Create(ctx, imgs)
+	if err != nil {
+		s.cleanup(ctx, saved)
+		return nil, err
+	}
+	return imgs, nil
+}
+
+func (s *Service) GetByProduct(ctx context.Context, idProduct uuid.UUID) ([]structs.ProductImage, error) {
+	imgs, err := s.rep.GetByProduct(ctx, idProduct)
+	if err != nil {
+		return nil, err
+	}
+	for i := range imgs {
+		imgs[i] = s.withURLs(imgs[i])
+	}
+	return imgs, nil
+}
+
+func (s *Service) SetPrimary(ctx context.Context, idProduct uuid.UUID, id uuid.UUID) error {
+	img, err := s.get(ctx, idProduct, id)
+	if err != nil {
+		return err
+	}
+	return s.rep.SetPrimary(ctx, idProduct, id, s.storage.URL(img.Key))
+}
+
+func (s *Service) Reorder(ctx context.Context, idProduct uuid.UUID, ids []uuid.UUID) error {
+	existing, err := s.rep.GetByProduct(ctx, idProduct)
+	if err != nil {
+		return err
+	}
+	if len(ids) != len(existing) {
+		return structs.ErrInvalidImageOrder
+	}
+	known := make(map[uuid.UUID]bool, len(existing))
+	for _, img := range existing {
+		known[img.Id] = true
+	}
+	for _, id := range ids {
+		if !known[id] {
+			return structs.ErrInvalidImageOrder
+		}
+		delete(known, id)
+	}
+	return s.rep.Reorder(ctx, idProduct, ids)
+}
+
+// Delete removes the image and its thumbnails. When the primary image is
+// deleted the next one in order takes its place, so pic_link keeps pointing
+// at an existing file.
+func (s *Service) Delete(ctx context.Context, idProduct uuid.UUID, id uuid.UUID) error {
+	img, err := s.get(ctx, idProduct, id)
+	if err != nil {
+		return err
+	}
+	if err := s.rep.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if img.IsPrimary {
+		rest, err := s.rep.GetByProduct(ctx, idProduct)
+		if err != nil {
+			return err
+		}
+		next, link := uuid.Nil, ""
+		if len(rest) > 0 {
+			next, link = rest[0].Id, s.storage.URL(rest[0].Key)
+		}
+		if err := s.rep.SetPrimary(ctx, idProduct, next, link); err != nil {
+			return err
+		}
+	}
+
+	// The row is gone at this point; files left behind by a failed delete
+	// are unreachable and do not affect the catalog.
+	keys := []string{img.Key}
+	for name := range ThumbnailSizes {
+		keys = append(keys, thumbnailKey(img.Key, name, img.ContentType))
+	}
+	s.cleanup(ctx, keys)
+	return nil
+}
+
+func (s *Service) get(ctx context.Context, idProduct uuid.UUID, id uuid.UUID) (structs.ProductImage, error) {
+	img, err := s.rep.GetById(ctx, id)
+	if err != nil {
+		return structs.ProductImage{}, err
+	}
+	if img.IdProduct != idProduct {
+		return structs.ProductImage{}, structs.ErrImageNotFound
+	}
+	return img, nil
+}
+
+func (s *Service) store(ctx context.Context, key string, d decodedImage) ([]string, error) {
+	var saved []string
+	if err := s.storage.Save(ctx, key, bytes.NewReader(d.data)); err != nil {
+		return saved, fmt.Errorf("failed to save image: %w", err)
+	}
+	saved = append(saved, key)
+
+	for name, size := range ThumbnailSizes {
+		var buf bytes.Buffer
+		if err := encodeThumbnail(&buf, Thumbnail(d.img, size), d.contentType); err != nil {
+			return saved, fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		tkey := thumbnailKey(key, name, d.contentType)
+		if err := s.storage.Save(ctx, tkey, &buf); err != nil {
+			return saved, fmt.Errorf("failed to save thumbnail: %w", err)
+		}
+		saved = append(saved, tkey)
+	}
+	return saved, nil
+}
+
+func (s *Service) cleanup(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		_ = s.storage.Delete(ctx, key)
+	}
+}
+
+func (s *Service) withURLs(img structs.ProductImage) structs.ProductImage {
+	img.URL = s.storage.URL(img.Key)
+	img.Thumbnails = make(map[string]string, len(ThumbnailSizes))
+	for name := range ThumbnailSizes {
+		img.Thumbnails[name] = s.storage.URL(thumbnailKey(img.Key, name, img.ContentType))
+	}
+	return img
+}
+
+func decode(f structs.ImageFile) (decodedImage, error) {
+	if len(f.Data) > MaxImageSize {
+		return decodedImage{}, fmt.Errorf("%s: %w", f.Name, structs.ErrImageTooLarge)
+	}
+
+	// The declared content type of a multipart part is not trusted, the type
+	// is sniffed from the data instead.
+	contentType := http.DetectContentType(f.Data)
+	if _, ok := imageExtensions[contentType]; !ok {
+		return decodedImage{}, fmt.Errorf("%s: %w: %s", f.Name, structs.ErrUnsupportedImageType, contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(f.Data))
+	if err != nil {
+		return decodedImage{}, fmt.Errorf("%s: %w: %v", f.Name, structs.ErrInvalidImage, err)
+	}
+	if cfg.Width*cfg.Height > MaxImagePixels {
+		return decodedImage{}, fmt.Errorf("%s: %w: %dx%d", f.Name, structs.ErrImageTooLarge, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(f.Data))
+	if err != nil {
+		return decodedImage{}, fmt.Errorf("%s: %w: %v", f.Name, structs.ErrInvalidImage, err)
+	}
+	return decodedImage{img: img, contentType: contentType, data: f.Data}, nil
+}
+
+// thumbnailKey derives the storage key of a thumbnail from the key of the
+// original. JPEG thumbnails stay JPEG, everything else is stored as PNG to
+// keep transparency.
+func thumbnailKey(key string, name string, contentType string) string {
+	ext := ".png"
+	if contentType == "image/jpeg" {
+		ext = ".jpg"
+	}
+	return strings.TrimSuffix(key, path.Ext(key)) + "_" + name + ext
+}
+
+func encodeThumbnail(w io.Writer, img image.Image, contentType string) error {
+	if contentType == "image/jpeg" {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+	}
+	return png.Encode(w, img)
+}
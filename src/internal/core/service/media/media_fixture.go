@@ -0,0 +1,92 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t         *testing.T
+	ctrl      *gomock.Controller
+	ctx       context.Context
+	idProduct uuid.UUID
+	png       structs.ImageFile
+	image     structs.ProductImage
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+
+	img := image.NewRGBA(image.Rect(0, 0, 1000, 500))
+	for x := 0; x < 1000; x++ {
+		for y := 0; y < 500; y++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	idProduct := structs.GenId()
+	id := structs.GenId()
+
+	return &TestFixture{
+		t:         t,
+		ctrl:      ctrl,
+		ctx:       context.Background(),
+		idProduct: idProduct,
+		png:       structs.ImageFile{Name: "lipstick.png", Data: buf.Bytes()},
+		image: structs.ProductImage{
+			Id:          id,
+			IdProduct:   idProduct,
+			Position:    0,
+			IsPrimary:   true,
+			ContentType: "image/png",
+			Size:        int64(buf.Len()),
+			Key:         "products/" + idProduct.String() + "/" + id.String() + ".png",
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockMediaRepository, *mock_structs.MockMediaStorage) {
+	mockRepo := mock_structs.NewMockMediaRepository(f.ctrl)
+	mockStorage := mock_structs.NewMockMediaStorage(f.ctrl)
+	mockStorage.EXPECT().URL(gomock.Any()).DoAndReturn(func(key string) string {
+		return "/media/" + key
+	}).AnyTimes()
+
+	service := New(mockRepo, mockStorage)
+	return service, mockRepo, mockStorage
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
@@ -0,0 +1,337 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestUpload_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	broken := structs.ImageFile{Name: "broken.png", Data: append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)}
+	huge := structs.ImageFile{Name: "huge.png", Data: append(bytes.Clone(fixture.png.Data), make([]byte, MaxImageSize)...)}
+	text := structs.ImageFile{Name: "notes.png", Data: []byte("definitely not an image")}
+
+	tests := []struct {
+		name        string
+		files       []structs.ImageFile
+		primary     bool
+		setupMocks  func(*mock_structs.MockMediaRepository, *mock_structs.MockMediaStorage)
+		check       func(*testing.T, []structs.ProductImage)
+		expectedErr error
+	}{
+		{
+			name:  "first image becomes primary",
+			files: []structs.ImageFile{fixture.png},
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository, mockStorage *mock_structs.MockMediaStorage) {
+				mockRepo.EXPECT().GetByProduct(fixture.ctx, fixture.idProduct).Return(nil, nil)
+				mockStorage.EXPECT().Save(fixture.ctx, gomock.Any(), gomock.Any()).Return(nil).Times(1 + len(ThumbnailSizes))
+				mockRepo.EXPECT().Create(fixture.ctx, gomock.Any()).DoAndReturn(
+					func(_ any, imgs []structs.ProductImage) ([]structs.ProductImage, error) {
+						return imgs, nil
+					})
+			},
+			check: func(t *testing.T, imgs []structs.ProductImage) {
+				require.Len(t, imgs, 1)
+				img := imgs[0]
+				assert.True(t, img.IsPrimary)
+				assert.Equal(t, "image/png", img.ContentType)
+				assert.Equal(t, int64(len(fixture.png.Data)), img.Size)
+				assert.Equal(t, "/media/products/"+fixture.idProduct.String()+"/"+img.Id.String()+".png", img.URL)
+				assert.Equal(t, strings.TrimSuffix(img.URL, ".png")+"_small.png", img.Thumbnails["small"])
+				assert.Len(t, img.Thumbnails, len(ThumbnailSizes))
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "existing images keep primary",
+			files: []structs.ImageFile{fixture.png},
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository, mockStorage *mock_structs.MockMediaStorage) {
+				mockRepo.EXPECT().GetByProduct(fixture.ctx, fixture.idProduct).Return([]structs.ProductImage{fixture.image}, nil)
+				mockStorage.EXPECT().Save(fixture.ctx, gomock.Any(), gomock.Any()).Return(nil).Times(1 + len(ThumbnailSizes))
+				mockRepo.EXPECT().Create(fixture.ctx, gomock.Any()).DoAndReturn(
+					func(_ any, imgs []structs.ProductImage) ([]structs.ProductImage, error) {
+						return imgs, nil
+					})
+			},
+			check: func(t *testing.T, imgs []structs.ProductImage) {
+				require.Len(t, imgs, 1)
+				assert.False(t, imgs[0].IsPrimary)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "repository error removes stored files",
+			files: []structs.ImageFile{fixture.png},
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository, mockStorage *mock_structs.MockMediaStorage) {
+				mockRepo.EXPECT().GetByProduct(fixture.ctx, fixture.idProduct).Return(nil, nil)
+				mockStorage.EXPECT().Save(fixture.ctx, gomock.Any(), gomock.Any()).Return(nil).Times(1 + len(ThumbnailSizes))
+				mockRepo.EXPECT().Create(fixture.ctx, gomock.Any()).Return(nil, structs.ErrProductNotFound)
+				mockStorage.EXPECT().Delete(fixture.ctx, gomock.Any()).Return(nil).Times(1 + len(ThumbnailSizes))
+			},
+			expectedErr: structs.ErrProductNotFound,
+		},
+		{
+			name:        "unsupported type",
+			files:       []structs.ImageFile{fixture.png, text},
+			setupMocks:  func(*mock_structs.MockMediaRepository, *mock_structs.MockMediaStorage) {},
+			expectedErr: structs.ErrUnsupportedImageType,
+		},
+		{
+			name:        "broken image",
+			files:       []structs.ImageFile{broken},
+			setupMocks:  func(*mock_structs.MockMediaRepository, *mock_structs.MockMediaStorage) {},
+			expectedErr: structs.ErrInvalidImage,
+		},
+		{
+			name:        "too large",
+			files:       []structs.ImageFile{huge},
+			setupMocks:  func(*mock_structs.MockMediaRepository, *mock_structs.MockMediaStorage) {},
+			expectedErr: structs.ErrImageTooLarge,
+		},
+		{
+			name:        "no files",
+			files:       nil,
+			setupMocks:  func(*mock_structs.MockMediaRepository, *mock_structs.MockMediaStorage) {},
+			expectedErr: structs.ErrNoImages,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, mockStorage := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo, mockStorage)
+
+			ret, err := service.Upload(fixture.ctx, fixture.idProduct, tt.files, tt.primary)
+
+			fixture.AssertError(err, tt.expectedErr)
+			if tt.check != nil {
+				tt.check(t, ret)
+			}
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSetPrimary_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	foreign := fixture.image
+	foreign.IdProduct = structs.GenId()
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockMediaRepository)
+		expectedErr error
+	}{
+		{
+			name: "successful update",
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.image.Id).Return(fixture.image, nil)
+				mockRepo.EXPECT().SetPrimary(fixture.ctx, fixture.idProduct, fixture.image.Id, "/media/"+fixture.image.Key).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "image of another product",
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.image.Id).Return(foreign, nil)
+			},
+			expectedErr: structs.ErrImageNotFound,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.image.Id).Return(structs.ProductImage{}, errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _ := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.SetPrimary(fixture.ctx, fixture.idProduct, fixture.image.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestReorder_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	second := fixture.image
+	second.Id = structs.GenId()
+	second.Position = 1
+	second.IsPrimary = false
+	existing := []structs.ProductImage{fixture.image, second}
+
+	tests := []struct {
+		name        string
+		ids         []uuid.UUID
+		setupMocks  func(*mock_structs.MockMediaRepository)
+		expectedErr error
+	}{
+		{
+			name: "successful reorder",
+			ids:  []uuid.UUID{second.Id, fixture.image.Id},
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository) {
+				mockRepo.EXPECT().GetByProduct(fixture.ctx, fixture.idProduct).Return(existing, nil)
+				mockRepo.EXPECT().Reorder(fixture.ctx, fixture.idProduct, []uuid.UUID{second.Id, fixture.image.Id}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "missing image",
+			ids:  []uuid.UUID{second.Id},
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository) {
+				mockRepo.EXPECT().GetByProduct(fixture.ctx, fixture.idProduct).Return(existing, nil)
+			},
+			expectedErr: structs.ErrInvalidImageOrder,
+		},
+		{
+			name: "duplicate image",
+			ids:  []uuid.UUID{second.Id, second.Id},
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository) {
+				mockRepo.EXPECT().GetByProduct(fixture.ctx, fixture.idProduct).Return(existing, nil)
+			},
+			expectedErr: structs.ErrInvalidImageOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _ := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.Reorder(fixture.ctx, fixture.idProduct, tt.ids)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDelete_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	second := fixture.image
+	second.Id = structs.GenId()
+	second.Key = "products/" + fixture.idProduct.String() + "/" + second.Id.String() + ".png"
+	second.IsPrimary = false
+
+	tests := []struct {
+		name        string
+		image       structs.ProductImage
+		setupMocks  func(*mock_structs.MockMediaRepository, *mock_structs.MockMediaStorage)
+		expectedErr error
+	}{
+		{
+			name:  "primary image is replaced by the next one",
+			image: fixture.image,
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository, mockStorage *mock_structs.MockMediaStorage) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.image.Id).Return(fixture.image, nil)
+				mockRepo.EXPECT().Delete(fixture.ctx, fixture.image.Id).Return(nil)
+				mockRepo.EXPECT().GetByProduct(fixture.ctx, fixture.idProduct).Return([]structs.ProductImage{second}, nil)
+				mockRepo.EXPECT().SetPrimary(fixture.ctx, fixture.idProduct, second.Id, "/media/"+second.Key).Return(nil)
+				mockStorage.EXPECT().Delete(fixture.ctx, gomock.Any()).Return(nil).Times(1 + len(ThumbnailSizes))
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "last primary image clears pic_link",
+			image: fixture.image,
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository, mockStorage *mock_structs.MockMediaStorage) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.image.Id).Return(fixture.image, nil)
+				mockRepo.EXPECT().Delete(fixture.ctx, fixture.image.Id).Return(nil)
+				mockRepo.EXPECT().GetByProduct(fixture.ctx, fixture.idProduct).Return(nil, nil)
+				mockRepo.EXPECT().SetPrimary(fixture.ctx, fixture.idProduct, uuid.Nil, "").Return(nil)
+				mockStorage.EXPECT().Delete(fixture.ctx, gomock.Any()).Return(nil).Times(1 + len(ThumbnailSizes))
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "secondary image",
+			image: second,
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository, mockStorage *mock_structs.MockMediaStorage) {
+				mockRepo.EXPECT().GetById(fixture.ctx, second.Id).Return(second, nil)
+				mockRepo.EXPECT().Delete(fixture.ctx, second.Id).Return(nil)
+				mockStorage.EXPECT().Delete(fixture.ctx, gomock.Any()).Return(nil).Times(1 + len(ThumbnailSizes))
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "repository error",
+			image: second,
+			setupMocks: func(mockRepo *mock_structs.MockMediaRepository, mockStorage *mock_structs.MockMediaStorage) {
+				mockRepo.EXPECT().GetById(fixture.ctx, second.Id).Return(second, nil)
+				mockRepo.EXPECT().Delete(fixture.ctx, second.Id).Return(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, mockStorage := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo, mockStorage)
+
+			err := service.Delete(fixture.ctx, fixture.idProduct, tt.image.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestThumbnail(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for x := 0; x < 400; x++ {
+		for y := 0; y < 200; y++ {
+			c := color.RGBA{A: 255}
+			if x%2 == 0 {
+				c.R = 200
+			}
+			src.Set(x, y, c)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		size   int
+		width  int
+		height int
+	}{
+		{name: "landscape is fitted by width", size: 100, width: 100, height: 50},
+		{name: "small images are not upscaled", size: 800, width: 400, height: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret := Thumbnail(src, tt.size)
+
+			assert.Equal(t, tt.width, ret.Bounds().Dx())
+			assert.Equal(t, tt.height, ret.Bounds().Dy())
+		})
+	}
+
+	r, _, _, _ := Thumbnail(src, 100).At(10, 10).RGBA()
+	assert.InDelta(t, 100, r>>8, 1, "pixels are averaged")
+
+	portrait := Thumbnail(image.NewRGBA(image.Rect(0, 0, 300, 900)), 150)
+	assert.Equal(t, image.Rect(0, 0, 50, 150), portrait.Bounds())
+}
@@ -0,0 +1,299 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type stockKey [2]uuid.UUID
+
+type memoryDiscount struct {
+	IdItem      uuid.UUID
+	IdPromotion uuid.UUID
+	Amount      float64
+}
+
+type memoryRedemption struct {
+	IdPromotion uuid.UUID
+	IdUser      uuid.UUID
+	IdOrder     uuid.UUID
+	Amount      float64
+}
+
+// memoryState is the data a checkout reads and writes. Users own a basket
+// in basketIds from signup; baskets holds its lines.
+type memoryState struct {
+	basketIds   map[uuid.UUID]uuid.UUID
+	baskets     map[uuid.UUID][]structs.BasketItem
+	stock       map[stockKey]int
+	prices      map[stockKey]float64
+	goods       map[stockKey]structs.StockLevel
+	held        map[stockKey]map[uuid.UUID]int
+	methods     map[uuid.UUID]structs.DeliveryMethod
+	slots       map[uuid.UUID]structs.DeliverySlot
+	lots        []structs.StockLot
+	orders      []structs.Order
+	items       []structs.OrderItem
+	itemLots    map[uuid.UUID][]structs.OrderItemLot
+	movements   []structs.StockMovement
+	discounts   []memoryDiscount
+	redemptions []memoryRedemption
+	exhausted   map[uuid.UUID]bool
+	history     []structs.OrderStatusChange
+}
+
+func (s memoryState) clone() memoryState {
+	c := s
+	c.basketIds = maps.Clone(s.basketIds)
+	c.baskets = maps.Clone(s.baskets)
+	for k, v := range c.baskets {
+		c.baskets[k] = slices.Clone(v)
+	}
+	c.stock = maps.Clone(s.stock)
+	c.held = maps.Clone(s.held)
+	for k, v := range c.held {
+		c.held[k] = maps.Clone(v)
+	}
+	c.lots = slices.Clone(s.lots)
+	c.orders = slices.Clone(s.orders)
+	c.items = slices.Clone(s.items)
+	c.itemLots = maps.Clone(s.itemLots)
+	c.movements = slices.Clone(s.movements)
+	c.discounts = slices.Clone(s.discounts)
+	c.redemptions = slices.Clone(s.redemptions)
+	c.history = slices.Clone(s.history)
+	return c
+}
+
+// memoryCheckout is an in-memory CheckoutRepository. A failed transaction
+// leaves the state as it was before.
+type memoryCheckout struct {
+	memoryState
+	today time.Time
+}
+
+type memoryTxKey struct{}
+
+func newMemoryCheckout() *memoryCheckout {
+	y, m, d := time.Now().Date()
+	return &memoryCheckout{
+		memoryState: memoryState{
+			basketIds: make(map[uuid.UUID]uuid.UUID),
+			baskets:   make(map[uuid.UUID][]structs.BasketItem),
+			stock:     make(map[stockKey]int),
+			prices:    make(map[stockKey]float64),
+			goods:     make(map[stockKey]structs.StockLevel),
+			held:      make(map[stockKey]map[uuid.UUID]int),
+			methods:   make(map[uuid.UUID]structs.DeliveryMethod),
+			slots:     make(map[uuid.UUID]structs.DeliverySlot),
+			itemLots:  make(map[uuid.UUID][]structs.OrderItemLot),
+			exhausted: make(map[uuid.UUID]bool),
+		},
+		today: time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (r *memoryCheckout) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := r.memoryState.clone()
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, true)); err != nil {
+		r.memoryState = saved
+		return err
+	}
+	return nil
+}
+
+func (r *memoryCheckout) inTx(ctx context.Context) {
+	if ctx.Value(memoryTxKey{}) == nil {
+		panic("checkout step called outside of a transaction")
+	}
+}
+
+func (r *memoryCheckout) GetBasket(ctx context.Context, id_user uuid.UUID) ([]structs.BasketItem, error) {
+	r.inTx(ctx)
+	return slices.Clone(r.baskets[id_user]), nil
+}
+
+func (r *memoryCheckout) LockStock(ctx context.Context, id_user uuid.UUID, id_product uuid.UUID, id_variant uuid.UUID) (structs.StockLevel, error) {
+	r.inTx(ctx)
+	key := stockKey{id_product, id_variant}
+	amount, ok := r.stock[key]
+	if !ok {
+		return structs.StockLevel{}, structs.ErrProductNotFound
+	}
+	available := amount
+	for u, n := range r.held[key] {
+		if u != id_user {
+			available -= n
+		}
+	}
+	for _, l := range r.lots {
+		if (stockKey{l.IdProduct, l.IdVariant}) == key && l.ExpiresAt.Before(r.today) {
+			available -= l.Amount
+		}
+	}
+	g := r.goods[key]
+	return structs.StockLevel{
+		IdProduct:  id_product,
+		IdVariant:  id_variant,
+		IdBrand:    g.IdBrand,
+		Categories: g.Categories,
+		Available:  available,
+		Price:      r.prices[key],
+		Name:       g.Name,
+		Art:        g.Art,
+		Brand:      g.Brand,
+		Weight:     g.Weight,
+	}, nil
+}
+
+func (r *memoryCheckout) LockLots(ctx context.Context, id_product uuid.UUID, id_variant uuid.UUID) ([]structs.StockLot, error) {
+	r.inTx(ctx)
+	var res []structs.StockLot
+	for _, l := range r.lots {
+		if l.IdProduct == id_product && l.IdVariant == id_variant && l.Amount > 0 && !l.ExpiresAt.Before(r.today) {
+			res = append(res, l)
+		}
+	}
+	slices.SortFunc(res, func(a, b structs.StockLot) int { return a.ExpiresAt.Compare(b.ExpiresAt) })
+	return res, nil
+}
+
+func (r *memoryCheckout) GetDelivery(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error) {
+	r.inTx(ctx)
+	m, ok := r.methods[id]
+	if !ok {
+		return structs.DeliveryMethod{}, structs.ErrDeliveryNotFound
+	}
+	return m, nil
+}
+
+func (r *memoryCheckout) LockSlot(ctx context.Context, id uuid.UUID) (structs.DeliverySlot, error) {
+	r.inTx(ctx)
+	slot, ok := r.slots[id]
+	if !ok {
+		return structs.DeliverySlot{}, structs.ErrSlotNotFound
+	}
+	for _, o := range r.orders {
+		if o.IdSlot == id && !o.Status.Released() {
+			slot.Taken++
+		}
+	}
+	return slot, nil
+}
+
+func (r *memoryCheckout) CreateOrder(ctx context.Context, o structs.Order) (structs.Order, error) {
+	r.inTx(ctx)
+	o.Id = uuid.New()
+	o.Date = time.Now()
+	r.orders = append(r.orders, o)
+	return o, nil
+}
+
+func (r *memoryCheckout) AddItem(ctx context.Context, item structs.OrderItem) (uuid.UUID, error) {
+	r.inTx(ctx)
+	item.Id = uuid.New()
+	r.items = append(r.items, item)
+	return item.Id, nil
+}
+
+func (r *memoryCheckout) AddItemLot(ctx context.Context, id_item uuid.UUID, lot structs.OrderItemLot) error {
+	r.inTx(ctx)
+	r.itemLots[id_item] = append(slices.Clone(r.itemLots[id_item]), lot)
+	return nil
+}
+
+func (r *memoryCheckout) AddStockMovement(ctx context.Context, m structs.StockMovement) error {
+	r.inTx(ctx)
+	key := stockKey{m.IdProduct, m.IdVariant}
+	if r.stock[key]+m.Quantity < 0 {
+		return structs.ErrInsufficientStock
+	}
+	if m.IdLot != uuid.Nil {
+		i := slices.IndexFunc(r.lots, func(l structs.StockLot) bool { return l.Id == m.IdLot })
+		if i < 0 {
+			return structs.ErrLotNotFound
+		}
+		if r.lots[i].Amount+m.Quantity < 0 {
+			return structs.ErrInsufficientStock
+		}
+		r.lots[i].Amount += m.Quantity
+	}
+	r.stock[key] += m.Quantity
+	m.Balance = r.stock[key]
+	r.movements = append(r.movements, m)
+	return nil
+}
+
+func (r *memoryCheckout) AddDiscount(ctx context.Context, id_item uuid.UUID, id_promotion uuid.UUID, amount float64) error {
+	r.inTx(ctx)
+	r.discounts = append(r.discounts, memoryDiscount{IdItem: id_item, IdPromotion: id_promotion, Amount: amount})
+	return nil
+}
+
+func (r *memoryCheckout) Redeem(ctx context.Context, id_promotion uuid.UUID, id_user uuid.UUID, id_order uuid.UUID, amount float64) error {
+	r.inTx(ctx)
+	if r.exhausted[id_promotion] {
+		return structs.ErrPromotionLimitReached
+	}
+	r.redemptions = append(r.redemptions, memoryRedemption{IdPromotion: id_promotion, IdUser: id_user, IdOrder: id_order, Amount: amount})
+	return nil
+}
+
+func (r *memoryCheckout) SetPrice(ctx context.Context, id_order uuid.UUID, price float64, shipping float64) error {
+	r.inTx(ctx)
+	for i := range r.orders {
+		if r.orders[i].Id == id_order {
+			r.orders[i].Price = price
+			r.orders[i].ShippingCost = shipping
+		}
+	}
+	return nil
+}
+
+func (r *memoryCheckout) AddStatusChange(ctx context.Context, ch structs.OrderStatusChange) error {
+	r.inTx(ctx)
+	r.history = append(r.history, ch)
+	return nil
+}
+
+func (r *memoryCheckout) ClearBasket(ctx context.Context, id_user uuid.UUID) error {
+	r.inTx(ctx)
+	delete(r.baskets, id_user)
+	for key, holds := range r.held {
+		if _, ok := holds[id_user]; ok {
+			holds = maps.Clone(holds)
+			delete(holds, id_user)
+			r.held[key] = holds
+		}
+	}
+	return nil
+}
+
+// memoryBasket is a BasketFiller over the baskets of a memoryCheckout. Like
+// the basket service it fails when the user has no basket.
+type memoryBasket struct {
+	*memoryCheckout
+}
+
+func (r memoryBasket) AddItem(ctx context.Context, i structs.BasketItem, id_user uuid.UUID) error {
+	id_basket, ok := r.basketIds[id_user]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	for j, it := range r.baskets[id_user] {
+		if it.IdProduct == i.IdProduct && it.IdVariant == i.IdVariant {
+			r.baskets[id_user][j].Amount += i.Amount
+			return nil
+		}
+	}
+	i.Id = structs.GenId()
+	i.IdBasket = id_basket
+	r.baskets[id_user] = append(r.baskets[id_user], i)
+	return nil
+}
@@ -1,47 +1,343 @@
 package order
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/taucuya/ppo/internal/core/structs"
 )
 
 type OrderService interface {
-	Create(ctx context.Context, o structs.Order) error
+	Checkout(ctx context.Context, o structs.Order, code string) (structs.Order, error)
 	GetById(ctx context.Context, id uuid.UUID) (structs.Order, error)
 	GetItems(ctx context.Context, id uuid.UUID) ([]structs.OrderItem, error)
 	GetFreeOrders(ctx context.Context) ([]structs.Order, error)
 	GetAllOrders(ctx context.Context) ([]structs.Order, error)
 	GetOrdersByUser(ctx context.Context, id uuid.UUID) ([]structs.Order, error)
-	GetStatus(ctx context.Context, id uuid.UUID) (string, error)
-	ChangeOrderStatus(ctx context.Context, id uuid.UUID) error
+	GetStatus(ctx context.Context, id uuid.UUID) (structs.OrderStatus, error)
+	ChangeOrderStatus(ctx context.Context, ch structs.OrderStatusChange) error
+	Cancel(ctx context.Context, ch structs.OrderStatusChange) error
+	GetHistory(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID) ([]structs.OrderStatusChange, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	Search(ctx context.Context, f structs.OrderFilter, cursor string, limit int) (structs.OrderPage, error)
+	Export(ctx context.Context, f structs.OrderFilter, w io.Writer) error
+	Repeat(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID) ([]structs.RepeatItem, error)
 }
 
 type OrderRepository interface {
-	Create(ctx context.Context, o structs.Order) error
 	GetById(ctx context.Context, id uuid.UUID) (structs.Order, error)
 	GetItems(ctx context.Context, id uuid.UUID) ([]structs.OrderItem, error)
 	GetFreeOrders(ctx context.Context) ([]structs.Order, error)
 	GetAllOrders(ctx context.Context) ([]structs.Order, error)
 	GetOrdersByUser(ctx context.Context, id uuid.UUID) ([]structs.Order, error)
-	GetStatus(ctx context.Context, id uuid.UUID) (string, error)
+	GetStatus(ctx context.Context, id uuid.UUID) (structs.OrderStatus, error)
 	Delete(ctx context.Context, id uuid.UUID) error
-	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+	UpdateStatus(ctx context.Context, ch structs.OrderStatusChange) error
+	GetHistory(ctx context.Context, id uuid.UUID) ([]structs.OrderStatusChange, error)
+	Search(ctx context.Context, f structs.OrderFilter, after *structs.OrderCursor, limit int) ([]structs.OrderSummary, error)
+	GetItemsByOrders(ctx context.Context, ids []uuid.UUID) ([]structs.OrderItem, error)
+	Export(ctx context.Context, f structs.OrderFilter, fn func(structs.OrderExportRow) error) error
+	GetRepeatLines(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID) ([]structs.RepeatLine, error)
 }
 
+// CheckoutRepository holds the steps of a checkout. InTx runs fn in one
+// transaction and commits it when fn returns nil; the steps must be called
+// with the context passed to fn. Locked rows stay locked until the
+// transaction ends.
+type CheckoutRepository interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	GetBasket(ctx context.Context, id_user uuid.UUID) ([]structs.BasketItem, error)
+	LockStock(ctx context.Context, id_user uuid.UUID, id_product uuid.UUID, id_variant uuid.UUID) (structs.StockLevel, error)
+	LockLots(ctx context.Context, id_product uuid.UUID, id_variant uuid.UUID) ([]structs.StockLot, error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error)
+	LockSlot(ctx context.Context, id uuid.UUID) (structs.DeliverySlot, error)
+	CreateOrder(ctx context.Context, o structs.Order) (structs.Order, error)
+	AddItem(ctx context.Context, item structs.OrderItem) (uuid.UUID, error)
+	AddItemLot(ctx context.Context, id_item uuid.UUID, lot structs.OrderItemLot) error
+	AddStockMovement(ctx context.Context, m structs.StockMovement) error
+	AddDiscount(ctx context.Context, id_item uuid.UUID, id_promotion uuid.UUID, amount float64) error
+	Redeem(ctx context.Context, id_promotion uuid.UUID, id_user uuid.UUID, id_order uuid.UUID, amount float64) error
+	SetPrice(ctx context.Context, id_order uuid.UUID, price float64, shipping float64) error
+	AddStatusChange(ctx context.Context, ch structs.OrderStatusChange) error
+	ClearBasket(ctx context.Context, id_user uuid.UUID) error
+}
+
+// DiscountCalculator prices the lines of a user with the running
+// promotions and the promo code.
+type DiscountCalculator interface {
+	QuoteLines(ctx context.Context, id_user uuid.UUID, code string, lines []structs.CheckoutLine) (structs.DiscountQuote, error)
+}
+
+// BasketFiller adds goods to the basket of a user, adding to the amount of
+// a line that is already there.
+type BasketFiller interface {
+	AddItem(ctx context.Context, i structs.BasketItem, id_user uuid.UUID) error
+}
+
+const maxComment = 500
+
 type Service struct {
-	rep OrderRepository
+	rep      OrderRepository
+	checkout CheckoutRepository
+	promo    DiscountCalculator
+	basket   BasketFiller
+	now      func() time.Time
 }
 
-func New(rep OrderRepository) *Service {
-	return &Service{rep: rep}
+func New(rep OrderRepository, checkout CheckoutRepository, promo DiscountCalculator, basket BasketFiller) *Service {
+	return &Service{rep: rep, checkout: checkout, promo: promo, basket: basket, now: time.Now}
 }
 
-func (s *Service) Create(ctx context.Context, o structs.Order) error {
-	err := s.rep.Create(ctx, o)
-	return err
+// Checkout places an order from the basket of the user in one
+// transaction. The stock of every basket line is locked in the order of
+// product and variant ids, the same order reservations use, and checked
+// against the stock held by other buyers and expired lots; all short lines
+// are reported in an InsufficientStockError. The promotions are applied to
+// the locked lines and prices. The goods are taken from the lots expiring
+// first, the rest from the stock without a lot. The order lines keep the
+// unit price, the discount and the description of the goods at purchase
+// time, every promotion used is redeemed, and the basket and the holds of
+// the user are cleared. The delivery cost of the chosen method for the
+// zone, the weight and the goods total is added to the price; a courier
+// order takes a place in its delivery slot.
+func (s *Service) Checkout(ctx context.Context, o structs.Order, code string) (structs.Order, error) {
+	o.Zone = strings.ToLower(strings.TrimSpace(o.Zone))
+
+	var created structs.Order
+	err := s.checkout.InTx(ctx, func(ctx context.Context) error {
+		items, err := s.checkout.GetBasket(ctx, o.IdUser)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return structs.ErrEmptyBasket
+		}
+		slices.SortFunc(items, func(a, b structs.BasketItem) int {
+			if c := bytes.Compare(a.IdProduct[:], b.IdProduct[:]); c != 0 {
+				return c
+			}
+			return bytes.Compare(a.IdVariant[:], b.IdVariant[:])
+		})
+
+		levels := make([]structs.StockLevel, len(items))
+		var short []structs.StockShortage
+		for i, it := range items {
+			levels[i], err = s.checkout.LockStock(ctx, o.IdUser, it.IdProduct, it.IdVariant)
+			if err != nil {
+				return err
+			}
+			if levels[i].Available < it.Amount {
+				short = append(short, structs.StockShortage{
+					IdProduct: it.IdProduct,
+					IdVariant: it.IdVariant,
+					Requested: it.Amount,
+					Available: max(levels[i].Available, 0),
+				})
+			}
+		}
+		if len(short) > 0 {
+			return &structs.InsufficientStockError{Shortages: short}
+		}
+
+		priced := make([]structs.CheckoutLine, len(items))
+		for i, it := range items {
+			priced[i] = structs.CheckoutLine{
+				IdProduct:  it.IdProduct,
+				IdVariant:  it.IdVariant,
+				IdBrand:    levels[i].IdBrand,
+				Categories: levels[i].Categories,
+				UnitPrice:  levels[i].Price,
+				Amount:     it.Amount,
+			}
+		}
+		q, err := s.promo.QuoteLines(ctx, o.IdUser, code, priced)
+		if err != nil {
+			return err
+		}
+
+		m, err := s.checkout.GetDelivery(ctx, o.IdDelivery)
+		if err != nil {
+			return err
+		}
+		if !m.Active {
+			return structs.ErrDeliveryNotFound
+		}
+		if err := s.takeSlot(ctx, m, o.IdSlot); err != nil {
+			return err
+		}
+
+		o.Status = structs.OrderUnpaid
+		created, err = s.checkout.CreateOrder(ctx, o)
+		if err != nil {
+			return err
+		}
+
+		discounts := make(map[[2]uuid.UUID]float64)
+		for _, d := range q.Discounts {
+			discounts[[2]uuid.UUID{d.IdProduct, d.IdVariant}] += d.Amount
+		}
+
+		var price float64
+		var weight int
+		lines := make(map[[2]uuid.UUID]uuid.UUID, len(items))
+		for i, it := range items {
+			line := [2]uuid.UUID{it.IdProduct, it.IdVariant}
+			id_item, err := s.checkout.AddItem(ctx, structs.OrderItem{
+				IdProduct: it.IdProduct,
+				IdVariant: it.IdVariant,
+				IdOrder:   created.Id,
+				Amount:    it.Amount,
+				Price:     levels[i].Price,
+				Discount:  math.Round(discounts[line]*100) / 100,
+				Name:      levels[i].Name,
+				Art:       levels[i].Art,
+				Brand:     levels[i].Brand,
+			})
+			if err != nil {
+				return err
+			}
+			lines[line] = id_item
+			price += levels[i].Price * float64(it.Amount)
+			weight += levels[i].Weight * it.Amount
+
+			if err := s.take(ctx, created, id_item, it); err != nil {
+				return err
+			}
+		}
+
+		used := make(map[uuid.UUID]float64)
+		for _, d := range q.Discounts {
+			id_item, ok := lines[[2]uuid.UUID{d.IdProduct, d.IdVariant}]
+			if !ok {
+				continue
+			}
+			if err := s.checkout.AddDiscount(ctx, id_item, d.IdPromotion, d.Amount); err != nil {
+				return err
+			}
+			used[d.IdPromotion] += d.Amount
+			price -= d.Amount
+		}
+		// Promotions are locked in a fixed order to avoid deadlocks between
+		// orders using the same ones.
+		ids := slices.SortedFunc(maps.Keys(used), func(a, b uuid.UUID) int {
+			return bytes.Compare(a[:], b[:])
+		})
+		for _, id_promotion := range ids {
+			if err := s.checkout.Redeem(ctx, id_promotion, o.IdUser, created.Id, used[id_promotion]); err != nil {
+				return err
+			}
+		}
+
+		goods := math.Round(max(price, 0)*100) / 100
+		created.ShippingCost, err = m.Cost(o.Zone, weight, goods)
+		if err != nil {
+			return err
+		}
+		created.Price = math.Round((goods+created.ShippingCost)*100) / 100
+		if err := s.checkout.SetPrice(ctx, created.Id, created.Price, created.ShippingCost); err != nil {
+			return err
+		}
+		err = s.checkout.AddStatusChange(ctx, structs.OrderStatusChange{
+			IdOrder: created.Id,
+			To:      structs.OrderUnpaid,
+			IdActor: o.IdUser,
+			Role:    structs.OrderRoleCustomer,
+		})
+		if err != nil {
+			return err
+		}
+		return s.checkout.ClearBasket(ctx, o.IdUser)
+	})
+	if err != nil {
+		return structs.Order{}, err
+	}
+	return created, nil
+}
+
+// takeSlot checks that a courier order is placed in a delivery slot of the
+// method that has not started and still has room. The slot stays locked
+// until the order is stored, so concurrent orders cannot overfill it.
+func (s *Service) takeSlot(ctx context.Context, m structs.DeliveryMethod, id_slot uuid.UUID) error {
+	if m.Kind != structs.DeliveryCourier {
+		if id_slot != uuid.Nil {
+			return structs.ErrSlotRequired
+		}
+		return nil
+	}
+	if id_slot == uuid.Nil {
+		return structs.ErrSlotRequired
+	}
+
+	slot, err := s.checkout.LockSlot(ctx, id_slot)
+	if err != nil {
+		return err
+	}
+	if slot.IdDelivery != m.Id {
+		return structs.ErrSlotNotFound
+	}
+	if !slot.StartsAt.After(s.now()) {
+		return structs.ErrSlotClosed
+	}
+	if slot.Taken >= slot.Capacity {
+		return structs.ErrSlotFull
+	}
+	return nil
+}
+
+// take writes off the goods of an order line, first from the valid lots
+// expiring first, then from the stock without a lot.
+func (s *Service) take(ctx context.Context, o structs.Order, id_item uuid.UUID, it structs.BasketItem) error {
+	sale := structs.StockMovement{
+		IdProduct: it.IdProduct,
+		IdVariant: it.IdVariant,
+		Kind:      structs.StockSale,
+		Reason:    "заказ",
+		IdActor:   o.IdUser,
+		Reference: o.Id.String(),
+	}
+
+	lots, err := s.checkout.LockLots(ctx, it.IdProduct, it.IdVariant)
+	if err != nil {
+		return err
+	}
+	remaining := it.Amount
+	for _, l := range lots {
+		if remaining == 0 {
+			break
+		}
+		taken := min(l.Amount, remaining)
+		m := sale
+		m.IdLot = l.Id
+		m.Quantity = -taken
+		if err := s.checkout.AddStockMovement(ctx, m); err != nil {
+			return err
+		}
+		err := s.checkout.AddItemLot(ctx, id_item, structs.OrderItemLot{
+			IdLot:     l.Id,
+			Batch:     l.Batch,
+			ExpiresAt: l.ExpiresAt,
+			Amount:    taken,
+		})
+		if err != nil {
+			return err
+		}
+		remaining -= taken
+	}
+
+	if remaining > 0 {
+		sale.Quantity = -remaining
+		return s.checkout.AddStockMovement(ctx, sale)
+	}
+	return nil
 }
 
 func (s *Service) GetById(ctx context.Context, id uuid.UUID) (structs.Order, error) {
@@ -84,7 +380,7 @@ func (s *Service) GetOrdersByUser(ctx context.Context, id uuid.UUID) ([]structs.
 	return orders, nil
 }
 
-func (s *Service) GetStatus(ctx context.Context, id uuid.UUID) (string, error) {
+func (s *Service) GetStatus(ctx context.Context, id uuid.UUID) (structs.OrderStatus, error) {
 	str, err := s.rep.GetStatus(ctx, id)
 	if err != nil {
 		return "", err
@@ -92,25 +388,63 @@ func (s *Service) GetStatus(ctx context.Context, id uuid.UUID) (string, error) {
 	return str, nil
 }
 
-func (s *Service) ChangeOrderStatus(ctx context.Context, id uuid.UUID, status string) error {
-	stat, err := s.rep.GetStatus(ctx, id)
+// ChangeOrderStatus moves the order to ch.To if the role of the actor may
+// make this move from the current status. Setting the current status again
+// changes nothing. A cancellation needs the reason in ch.Comment.
+func (s *Service) ChangeOrderStatus(ctx context.Context, ch structs.OrderStatusChange) error {
+	if !ch.To.Valid() {
+		return structs.ErrInvalidOrderStatus
+	}
+	ch.Comment = strings.TrimSpace(ch.Comment)
+	if utf8.RuneCountInString(ch.Comment) > maxComment {
+		return structs.ErrInvalidOrderStatus
+	}
+	if ch.To == structs.OrderCancelled && ch.Comment == "" {
+		return structs.ErrCancelReasonRequired
+	}
+
+	stat, err := s.rep.GetStatus(ctx, ch.IdOrder)
 	if err != nil {
 		return err
 	}
-	if stat == status {
+	if stat == ch.To {
 		return nil
-	} else {
-		_, err := s.rep.GetById(ctx, id)
+	}
+	if !stat.CanMove(ch.To, ch.Role) {
+		return fmt.Errorf("%w: %s -> %s", structs.ErrIllegalOrderTransition, stat, ch.To)
+	}
+	ch.From = stat
+	return s.rep.UpdateStatus(ctx, ch)
+}
+
+// Cancel cancels the order on behalf of ch.IdActor, the goods go back to
+// stock. Customers may cancel only their own orders, orders of other users
+// are reported as not found.
+func (s *Service) Cancel(ctx context.Context, ch structs.OrderStatusChange) error {
+	if ch.Role == structs.OrderRoleCustomer {
+		o, err := s.rep.GetById(ctx, ch.IdOrder)
 		if err != nil {
 			return err
-		} else {
-			err := s.rep.UpdateStatus(ctx, id, status)
-			if err != nil {
-				return err
-			}
+		}
+		if o.IdUser != ch.IdActor {
+			return structs.ErrOrderNotFound
 		}
 	}
-	return nil
+	ch.To = structs.OrderCancelled
+	return s.ChangeOrderStatus(ctx, ch)
+}
+
+// GetHistory returns the status changes of an order of the user, oldest
+// first. Orders of other users are reported as not found.
+func (s *Service) GetHistory(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID) ([]structs.OrderStatusChange, error) {
+	o, err := s.rep.GetById(ctx, id_order)
+	if err != nil {
+		return nil, err
+	}
+	if o.IdUser != id_user {
+		return nil, structs.ErrOrderNotFound
+	}
+	return s.rep.GetHistory(ctx, id_order)
 }
 
 func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
 	"github.com/taucuya/ppo/internal/core/structs"
 )
 
@@ -69,11 +70,34 @@ func (f *TestFixture) Cleanup() {
 	f.ctrl.Finish()
 }
 
-// func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockOrderRepository) {
-// mockRepo := mock_structs.NewMockOrderRepository(f.ctrl)
-// service := New(mockRepo)
-// return service, mockRepo
-// }
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockOrderRepository) {
+	mockRepo := mock_structs.NewMockOrderRepository(f.ctrl)
+	service := New(mockRepo, mock_structs.NewMockCheckoutRepository(f.ctrl), mock_structs.NewMockDiscountCalculator(f.ctrl),
+		mock_structs.NewMockBasketFiller(f.ctrl))
+	return service, mockRepo
+}
+
+func (f *TestFixture) CreateCheckoutService(checkout CheckoutRepository) (*Service, *mock_structs.MockDiscountCalculator) {
+	mockPromo := mock_structs.NewMockDiscountCalculator(f.ctrl)
+	service := New(mock_structs.NewMockOrderRepository(f.ctrl), checkout, mockPromo, mock_structs.NewMockBasketFiller(f.ctrl))
+	return service, mockPromo
+}
+
+func (f *TestFixture) CreateRepeatService() (*Service, *mock_structs.MockOrderRepository, *mock_structs.MockBasketFiller) {
+	mockRepo := mock_structs.NewMockOrderRepository(f.ctrl)
+	mockBasket := mock_structs.NewMockBasketFiller(f.ctrl)
+	service := New(mockRepo, mock_structs.NewMockCheckoutRepository(f.ctrl), mock_structs.NewMockDiscountCalculator(f.ctrl), mockBasket)
+	return service, mockRepo, mockBasket
+}
+
+// CreateStoreService builds a service that checks out into checkout and
+// refills baskets through basket, with the other parts mocked.
+func (f *TestFixture) CreateStoreService(checkout CheckoutRepository, basket BasketFiller) (*Service, *mock_structs.MockOrderRepository, *mock_structs.MockDiscountCalculator) {
+	mockRepo := mock_structs.NewMockOrderRepository(f.ctrl)
+	mockPromo := mock_structs.NewMockDiscountCalculator(f.ctrl)
+	service := New(mockRepo, checkout, mockPromo, basket)
+	return service, mockRepo, mockPromo
+}
 
 func (f *TestFixture) AssertError(err error, expectedErr error) {
 	if expectedErr != nil {
@@ -0,0 +1,63 @@
+package order
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+// Repeat puts the lines of a past order of the user into the basket.
+// Lines of archived goods and of goods with no stock left are skipped, the
+// others are cut to the stock not already in the basket. Orders of other
+// users are reported as not found.
+func (s *Service) Repeat(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID) ([]structs.RepeatItem, error) {
+	o, err := s.rep.GetById(ctx, id_order)
+	if err != nil {
+		return nil, err
+	}
+	if o.IdUser != id_user {
+		return nil, structs.ErrOrderNotFound
+	}
+
+	lines, err := s.rep.GetRepeatLines(ctx, id_order, id_user)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]structs.RepeatItem, len(lines))
+	for i, l := range lines {
+		res[i] = structs.RepeatItem{
+			IdProduct: l.IdProduct,
+			IdVariant: l.IdVariant,
+			Name:      l.Name,
+			Requested: l.Amount,
+			Status:    structs.RepeatSkipped,
+		}
+		room := l.Available - l.InBasket
+		switch {
+		case l.Archived:
+			res[i].Reason = structs.RepeatArchived
+			continue
+		case room <= 0:
+			res[i].Reason = structs.RepeatOutOfStock
+			continue
+		}
+
+		amount := min(l.Amount, room)
+		err := s.basket.AddItem(ctx, structs.BasketItem{
+			IdProduct: l.IdProduct,
+			IdVariant: l.IdVariant,
+			Amount:    amount,
+		}, id_user)
+		if err != nil {
+			return nil, err
+		}
+		res[i].Added = amount
+		res[i].Status = structs.RepeatAdded
+		if amount < l.Amount {
+			res[i].Status = structs.RepeatReduced
+		}
+	}
+	return res, nil
+}
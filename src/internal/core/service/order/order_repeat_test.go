@@ -0,0 +1,135 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestRepeat_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	o := fixture.order
+	cream := structs.RepeatLine{IdProduct: structs.GenId(), Name: "Крем", Amount: 2, Available: 10}
+	shade := structs.RepeatLine{IdProduct: structs.GenId(), IdVariant: structs.GenId(), Name: "Помада", Amount: 3, Available: 4, InBasket: 2}
+	gone := structs.RepeatLine{IdVariant: structs.GenId(), Name: "Тушь", Amount: 1, Archived: true}
+	sold := structs.RepeatLine{IdProduct: structs.GenId(), Name: "Сыворотка", Amount: 1, Available: 1, InBasket: 1}
+
+	add := func(l structs.RepeatLine, amount int) structs.BasketItem {
+		return structs.BasketItem{IdProduct: l.IdProduct, IdVariant: l.IdVariant, Amount: amount}
+	}
+
+	tests := []struct {
+		name        string
+		idUser      uuid.UUID
+		setupMocks  func(*mock_structs.MockOrderRepository, *mock_structs.MockBasketFiller)
+		expectedRet []structs.RepeatItem
+		expectedErr error
+	}{
+		{
+			name:   "lines added, reduced and skipped",
+			idUser: o.IdUser,
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository, mockBasket *mock_structs.MockBasketFiller) {
+				mockRepo.EXPECT().GetById(fixture.ctx, o.Id).Return(o, nil)
+				mockRepo.EXPECT().GetRepeatLines(fixture.ctx, o.Id, o.IdUser).
+					Return([]structs.RepeatLine{cream, shade, gone, sold}, nil)
+				mockBasket.EXPECT().AddItem(fixture.ctx, add(cream, 2), o.IdUser).Return(nil)
+				mockBasket.EXPECT().AddItem(fixture.ctx, add(shade, 2), o.IdUser).Return(nil)
+			},
+			expectedRet: []structs.RepeatItem{
+				{IdProduct: cream.IdProduct, Name: "Крем", Requested: 2, Added: 2, Status: structs.RepeatAdded},
+				{IdProduct: shade.IdProduct, IdVariant: shade.IdVariant, Name: "Помада", Requested: 3, Added: 2, Status: structs.RepeatReduced},
+				{IdProduct: gone.IdProduct, IdVariant: gone.IdVariant, Name: "Тушь", Requested: 1, Status: structs.RepeatSkipped, Reason: structs.RepeatArchived},
+				{IdProduct: sold.IdProduct, Name: "Сыворотка", Requested: 1, Status: structs.RepeatSkipped, Reason: structs.RepeatOutOfStock},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:   "order of another user",
+			idUser: structs.GenId(),
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository, mockBasket *mock_structs.MockBasketFiller) {
+				mockRepo.EXPECT().GetById(fixture.ctx, o.Id).Return(o, nil)
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrOrderNotFound,
+		},
+		{
+			name:   "basket error",
+			idUser: o.IdUser,
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository, mockBasket *mock_structs.MockBasketFiller) {
+				mockRepo.EXPECT().GetById(fixture.ctx, o.Id).Return(o, nil)
+				mockRepo.EXPECT().GetRepeatLines(fixture.ctx, o.Id, o.IdUser).Return([]structs.RepeatLine{cream}, nil)
+				mockBasket.EXPECT().AddItem(fixture.ctx, add(cream, 2), o.IdUser).Return(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+		{
+			name:   "repository error",
+			idUser: o.IdUser,
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository, mockBasket *mock_structs.MockBasketFiller) {
+				mockRepo.EXPECT().GetById(fixture.ctx, o.Id).Return(o, nil)
+				mockRepo.EXPECT().GetRepeatLines(fixture.ctx, o.Id, o.IdUser).Return(nil, errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, mockBasket := fixture.CreateRepeatService()
+			tt.setupMocks(mockRepo, mockBasket)
+
+			ret, err := service.Repeat(fixture.ctx, o.Id, tt.idUser)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestRepeat_AfterCheckout(t *testing.T) {
+	fixture := NewTestFixture(t)
+	user := fixture.order.IdUser
+	soap := structs.GenId()
+	pickup := structs.GenId()
+
+	mem := newMemoryCheckout()
+	mem.basketIds[user] = structs.GenId()
+	mem.baskets[user] = []structs.BasketItem{{Id: structs.GenId(), IdProduct: soap, Amount: 2}}
+	mem.stock[stockKey{soap, uuid.Nil}] = 10
+	mem.prices[stockKey{soap, uuid.Nil}] = 100
+	mem.goods[stockKey{soap, uuid.Nil}] = structs.StockLevel{Name: "Мыло", Weight: 300}
+	mem.methods[pickup] = structs.DeliveryMethod{
+		Id: pickup, Kind: structs.DeliveryPickup, Active: true,
+		Rates: []structs.DeliveryRate{{Zone: "moscow"}},
+	}
+	service, mockRepo, mockPromo := fixture.CreateStoreService(mem, memoryBasket{mem})
+	mockPromo.EXPECT().QuoteLines(gomock.Any(), user, "", gomock.Any()).Return(structs.DiscountQuote{}, nil)
+
+	o, err := service.Checkout(fixture.ctx, structs.Order{IdUser: user, IdDelivery: pickup, Zone: "moscow"}, "")
+	require.NoError(t, err)
+	require.Empty(t, mem.baskets[user])
+
+	mockRepo.EXPECT().GetById(fixture.ctx, o.Id).Return(o, nil)
+	mockRepo.EXPECT().GetRepeatLines(fixture.ctx, o.Id, user).Return([]structs.RepeatLine{
+		{IdProduct: soap, Name: "Мыло", Amount: 2, Available: mem.stock[stockKey{soap, uuid.Nil}]},
+	}, nil)
+
+	ret, err := service.Repeat(fixture.ctx, o.Id, user)
+
+	require.NoError(t, err)
+	assert.Equal(t, []structs.RepeatItem{
+		{IdProduct: soap, Name: "Мыло", Requested: 2, Added: 2, Status: structs.RepeatAdded},
+	}, ret)
+	require.Len(t, mem.baskets[user], 1)
+	assert.Equal(t, mem.basketIds[user], mem.baskets[user][0].IdBasket)
+	assert.Equal(t, 2, mem.baskets[user][0].Amount)
+	fixture.Cleanup()
+}
@@ -0,0 +1,172 @@
+package order
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+var exportColumns = []string{
+	"order_id", "date", "status", "customer_name", "customer_mail", "customer_phone", "address",
+	"id_worker", "order_price", "id_product", "articule", "name", "brand", "amount", "unit_price",
+	"discount", "line_total",
+}
+
+// Search returns a page of the orders matching the filter with their lines.
+// The cursor is the NextCursor of the previous page and has to come from a
+// listing with the same sort; a zero limit means the default page size.
+func (s *Service) Search(ctx context.Context, f structs.OrderFilter, cursor string, limit int) (structs.OrderPage, error) {
+	f, err := normalizeFilter(f)
+	if err != nil {
+		return structs.OrderPage{}, err
+	}
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	if limit < 0 || limit > maxPageSize {
+		return structs.OrderPage{}, structs.ErrInvalidOrderFilter
+	}
+	var after *structs.OrderCursor
+	if cursor != "" {
+		after, err = decodeCursor(cursor)
+		if err != nil || after.Sort != f.Sort {
+			return structs.OrderPage{}, structs.ErrInvalidCursor
+		}
+	}
+
+	orders, err := s.rep.Search(ctx, f, after, limit+1)
+	if err != nil {
+		return structs.OrderPage{}, err
+	}
+	page := structs.OrderPage{Orders: orders}
+	if len(orders) > limit {
+		page.Orders = orders[:limit]
+		last := page.Orders[limit-1]
+		page.NextCursor = encodeCursor(structs.OrderCursor{Sort: f.Sort, Date: last.Date, Price: last.Price, Id: last.Id})
+	}
+	if len(page.Orders) == 0 {
+		page.Orders = []structs.OrderSummary{}
+		return page, nil
+	}
+
+	ids := make([]uuid.UUID, len(page.Orders))
+	for i, o := range page.Orders {
+		ids[i] = o.Id
+	}
+	items, err := s.rep.GetItemsByOrders(ctx, ids)
+	if err != nil {
+		return structs.OrderPage{}, err
+	}
+	byOrder := make(map[uuid.UUID][]structs.OrderItem, len(ids))
+	for _, it := range items {
+		byOrder[it.IdOrder] = append(byOrder[it.IdOrder], it)
+	}
+	for i := range page.Orders {
+		page.Orders[i].Items = byOrder[page.Orders[i].Id]
+	}
+	return page, nil
+}
+
+// Export writes the orders matching the filter to w as CSV, one row per
+// order line, in the order of the search. Rows are written while they are
+// read, so the export is not limited to a page.
+func (s *Service) Export(ctx context.Context, f structs.OrderFilter, w io.Writer) error {
+	f, err := normalizeFilter(f)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportColumns); err != nil {
+		return err
+	}
+	err = s.rep.Export(ctx, f, func(r structs.OrderExportRow) error {
+		o, l := r.Order, r.Line
+		row := []string{
+			o.Id.String(), o.Date.Format(time.RFC3339), string(o.Status), o.CustomerName, o.CustomerMail,
+			o.CustomerPhone, o.Address, formatId(o.IdWorker), formatMoney(o.Price),
+			formatId(l.IdProduct), l.Art, l.Name, l.Brand, "", "", "", "",
+		}
+		if l.Id != uuid.Nil {
+			row[13] = strconv.Itoa(l.Amount)
+			row[14] = formatMoney(l.Price)
+			row[15] = formatMoney(l.Discount)
+			row[16] = formatMoney(l.Price*float64(l.Amount) - l.Discount)
+		}
+		return cw.Write(row)
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func normalizeFilter(f structs.OrderFilter) (structs.OrderFilter, error) {
+	if f.Sort == "" {
+		f.Sort = structs.OrderSortNewest
+	}
+	if !slices.Contains(structs.OrderSorts, f.Sort) {
+		return structs.OrderFilter{}, structs.ErrInvalidOrderFilter
+	}
+	for _, st := range f.Statuses {
+		if !st.Valid() {
+			return structs.OrderFilter{}, structs.ErrInvalidOrderStatus
+		}
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return structs.OrderFilter{}, structs.ErrInvalidOrderFilter
+	}
+	if (f.MinPrice != nil && *f.MinPrice < 0) || (f.MaxPrice != nil && *f.MaxPrice < 0) ||
+		(f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice) {
+		return structs.OrderFilter{}, structs.ErrInvalidOrderFilter
+	}
+	f.Customer = strings.ToLower(strings.TrimSpace(f.Customer))
+	return f, nil
+}
+
+func encodeCursor(c structs.OrderCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (*structs.OrderCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c structs.OrderCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	if c.Id == uuid.Nil {
+		return nil, structs.ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+func formatId(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+func formatMoney(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
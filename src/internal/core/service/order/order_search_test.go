@@ -0,0 +1,224 @@
+package order
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestSearch_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	date := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := structs.OrderSummary{Id: structs.GenId(), Date: date, Price: 300, Status: structs.OrderNew}
+	second := structs.OrderSummary{Id: structs.GenId(), Date: date.Add(-time.Hour), Price: 100, Status: structs.OrderNew}
+	third := structs.OrderSummary{Id: structs.GenId(), Date: date.Add(-2 * time.Hour), Price: 200, Status: structs.OrderNew}
+	items := []structs.OrderItem{
+		{Id: structs.GenId(), IdOrder: first.Id, IdProduct: structs.GenId(), Amount: 1},
+		{Id: structs.GenId(), IdOrder: second.Id, IdProduct: structs.GenId(), Amount: 2},
+		{Id: structs.GenId(), IdOrder: first.Id, IdProduct: structs.GenId(), Amount: 3},
+	}
+	newest := structs.OrderFilter{Sort: structs.OrderSortNewest}
+	cursor := encodeCursor(structs.OrderCursor{Sort: structs.OrderSortNewest, Date: second.Date, Price: second.Price, Id: second.Id})
+
+	tests := []struct {
+		name        string
+		filter      structs.OrderFilter
+		cursor      string
+		limit       int
+		setupMocks  func(*mock_structs.MockOrderRepository)
+		expectedRet structs.OrderPage
+		expectedErr error
+	}{
+		{
+			name:   "first page with next cursor",
+			filter: structs.OrderFilter{Customer: "  Ivan@Mail.RU "},
+			limit:  2,
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				f := structs.OrderFilter{Customer: "ivan@mail.ru", Sort: structs.OrderSortNewest}
+				mockRepo.EXPECT().Search(fixture.ctx, f, nil, 3).Return([]structs.OrderSummary{first, second, third}, nil)
+				mockRepo.EXPECT().GetItemsByOrders(fixture.ctx, []uuid.UUID{first.Id, second.Id}).Return(items, nil)
+			},
+			expectedRet: structs.OrderPage{
+				Orders: []structs.OrderSummary{
+					withItems(first, items[0], items[2]),
+					withItems(second, items[1]),
+				},
+				NextCursor: cursor,
+			},
+			expectedErr: nil,
+		},
+		{
+			name:   "last page",
+			cursor: cursor,
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				after := &structs.OrderCursor{Sort: structs.OrderSortNewest, Date: second.Date, Price: second.Price, Id: second.Id}
+				mockRepo.EXPECT().Search(fixture.ctx, newest, after, defaultPageSize+1).Return([]structs.OrderSummary{third}, nil)
+				mockRepo.EXPECT().GetItemsByOrders(fixture.ctx, []uuid.UUID{third.Id}).Return(nil, nil)
+			},
+			expectedRet: structs.OrderPage{Orders: []structs.OrderSummary{third}},
+			expectedErr: nil,
+		},
+		{
+			name: "no orders",
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().Search(fixture.ctx, newest, nil, defaultPageSize+1).Return(nil, nil)
+			},
+			expectedRet: structs.OrderPage{Orders: []structs.OrderSummary{}},
+			expectedErr: nil,
+		},
+		{
+			name:        "cursor of another sort",
+			filter:      structs.OrderFilter{Sort: structs.OrderSortCheapest},
+			cursor:      cursor,
+			setupMocks:  func(mockRepo *mock_structs.MockOrderRepository) {},
+			expectedErr: structs.ErrInvalidCursor,
+		},
+		{
+			name:        "malformed cursor",
+			cursor:      "not a cursor",
+			setupMocks:  func(mockRepo *mock_structs.MockOrderRepository) {},
+			expectedErr: structs.ErrInvalidCursor,
+		},
+		{
+			name:        "unknown sort",
+			filter:      structs.OrderFilter{Sort: "name"},
+			setupMocks:  func(mockRepo *mock_structs.MockOrderRepository) {},
+			expectedErr: structs.ErrInvalidOrderFilter,
+		},
+		{
+			name:        "unknown status",
+			filter:      structs.OrderFilter{Statuses: []structs.OrderStatus{"lost"}},
+			setupMocks:  func(mockRepo *mock_structs.MockOrderRepository) {},
+			expectedErr: structs.ErrInvalidOrderStatus,
+		},
+		{
+			name:        "empty date range",
+			filter:      structs.OrderFilter{From: date, To: date},
+			setupMocks:  func(mockRepo *mock_structs.MockOrderRepository) {},
+			expectedErr: structs.ErrInvalidOrderFilter,
+		},
+		{
+			name:        "page too large",
+			limit:       maxPageSize + 1,
+			setupMocks:  func(mockRepo *mock_structs.MockOrderRepository) {},
+			expectedErr: structs.ErrInvalidOrderFilter,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().Search(fixture.ctx, newest, nil, defaultPageSize+1).Return(nil, errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Search(fixture.ctx, tt.filter, tt.cursor, tt.limit)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestExport_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	order := structs.OrderSummary{
+		Id:            structs.GenId(),
+		Date:          time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Status:        structs.OrderShipped,
+		CustomerName:  "Ivan",
+		CustomerMail:  "ivan@mail.ru",
+		CustomerPhone: "+79990000000",
+		Address:       "Moscow, Lenina 1",
+		Price:         250,
+	}
+	line := structs.OrderItem{
+		Id: structs.GenId(), IdOrder: order.Id, IdProduct: structs.GenId(),
+		Amount: 3, Price: 100, Discount: 50, Name: "Cream", Art: "CR-1", Brand: "Brand",
+	}
+	empty := structs.OrderSummary{Id: structs.GenId(), Date: order.Date, Status: structs.OrderNew}
+
+	tests := []struct {
+		name        string
+		filter      structs.OrderFilter
+		setupMocks  func(*mock_structs.MockOrderRepository)
+		expectedRet [][]string
+		expectedErr error
+	}{
+		{
+			name: "successful export",
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().Export(fixture.ctx, structs.OrderFilter{Sort: structs.OrderSortNewest}, gomock.Any()).
+					DoAndReturn(func(_, _ any, fn func(structs.OrderExportRow) error) error {
+						if err := fn(structs.OrderExportRow{Order: order, Line: line}); err != nil {
+							return err
+						}
+						return fn(structs.OrderExportRow{Order: empty})
+					})
+			},
+			expectedRet: [][]string{
+				exportColumns,
+				{
+					order.Id.String(), "2025-03-01T12:00:00Z", "отданный", "Ivan", "ivan@mail.ru", "+79990000000",
+					"Moscow, Lenina 1", "", "250.00", line.IdProduct.String(), "CR-1", "Cream", "Brand",
+					"3", "100.00", "50.00", "250.00",
+				},
+				{
+					empty.Id.String(), "2025-03-01T12:00:00Z", "непринятый", "", "", "", "", "", "0.00",
+					"", "", "", "", "", "", "", "",
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "invalid filter",
+			filter:      structs.OrderFilter{Sort: "name"},
+			setupMocks:  func(mockRepo *mock_structs.MockOrderRepository) {},
+			expectedErr: structs.ErrInvalidOrderFilter,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().Export(fixture.ctx, gomock.Any(), gomock.Any()).Return(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+			var buf bytes.Buffer
+
+			err := service.Export(fixture.ctx, tt.filter, &buf)
+
+			fixture.AssertError(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				rows, err := csv.NewReader(&buf).ReadAll()
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedRet, rows)
+			}
+		})
+	}
+	fixture.Cleanup()
+}
+
+func withItems(o structs.OrderSummary, items ...structs.OrderItem) structs.OrderSummary {
+	o.Items = items
+	return o
+}
@@ -1,12 +1,19 @@
 package order
 
-// import (
-// 	"testing"
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
 
-// 	"github.com/stretchr/testify/assert"
-// 	"github.com/taucuya/ppo/internal/core/mock_structs"
-// 	"github.com/taucuya/ppo/internal/core/structs"
-// )
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
 
 // func TestCreate_AAA(t *testing.T) {
 // 	fixture := NewTestFixture(t)
@@ -286,74 +293,361 @@ package order
 // 	fixture.Cleanup()
 // }
 
-// func TestChangeOrderStatus_AAA(t *testing.T) {
-// 	fixture := NewTestFixture(t)
+func TestChangeOrderStatus_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	actor := structs.GenId()
 
-// 	tests := []struct {
-// 		name        string
-// 		status      string
-// 		setupMocks  func(*mock_structs.MockOrderRepository)
-// 		expectedErr error
-// 	}{
-// 		{
-// 			name:   "successful status change",
-// 			status: "completed",
-// 			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
-// 				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return("pending", nil)
-// 				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
-// 				mockRepo.EXPECT().UpdateStatus(fixture.ctx, fixture.order.Id, "completed").Return(nil)
-// 			},
-// 			expectedErr: nil,
-// 		},
-// 		{
-// 			name:   "same status - no update needed",
-// 			status: "pending",
-// 			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
-// 				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return("pending", nil)
-// 			},
-// 			expectedErr: nil,
-// 		},
-// 		{
-// 			name:   "error getting status",
-// 			status: "completed",
-// 			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
-// 				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return("", errTest)
-// 			},
-// 			expectedErr: errTest,
-// 		},
-// 		{
-// 			name:   "error getting order",
-// 			status: "completed",
-// 			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
-// 				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return("pending", nil)
-// 				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(structs.Order{}, errTest)
-// 			},
-// 			expectedErr: errTest,
-// 		},
-// 		{
-// 			name:   "error updating status",
-// 			status: "completed",
-// 			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
-// 				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return("pending", nil)
-// 				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
-// 				mockRepo.EXPECT().UpdateStatus(fixture.ctx, fixture.order.Id, "completed").Return(errTest)
-// 			},
-// 			expectedErr: errTest,
-// 		},
-// 	}
+	tests := []struct {
+		name        string
+		change      structs.OrderStatusChange
+		setupMocks  func(*mock_structs.MockOrderRepository)
+		expectedErr error
+	}{
+		{
+			name: "worker accepts new order",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderAccepted,
+				IdActor: actor, Role: structs.OrderRoleWorker, Comment: "  взят в работу ",
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderNew, nil)
+				mockRepo.EXPECT().UpdateStatus(fixture.ctx, structs.OrderStatusChange{
+					IdOrder: fixture.order.Id, From: structs.OrderNew, To: structs.OrderAccepted,
+					IdActor: actor, Role: structs.OrderRoleWorker, Comment: "взят в работу",
+				}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "admin invalidates assembled order",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderInvalid,
+				IdActor: actor, Role: structs.OrderRoleAdmin,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderAssembled, nil)
+				mockRepo.EXPECT().UpdateStatus(fixture.ctx, structs.OrderStatusChange{
+					IdOrder: fixture.order.Id, From: structs.OrderAssembled, To: structs.OrderInvalid,
+					IdActor: actor, Role: structs.OrderRoleAdmin,
+				}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "same status - no update needed",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderAccepted, Role: structs.OrderRoleWorker,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderAccepted, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "worker cannot move shipped order back",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderNew, Role: structs.OrderRoleWorker,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderShipped, nil)
+			},
+			expectedErr: structs.ErrIllegalOrderTransition,
+		},
+		{
+			name: "worker cannot accept unpaid order",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderAccepted, Role: structs.OrderRoleWorker,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderUnpaid, nil)
+			},
+			expectedErr: structs.ErrIllegalOrderTransition,
+		},
+		{
+			name: "admin cannot mark unpaid order paid",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderNew, Role: structs.OrderRoleAdmin,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderUnpaid, nil)
+			},
+			expectedErr: structs.ErrIllegalOrderTransition,
+		},
+		{
+			name: "worker cannot invalidate accepted order",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderInvalid, Role: structs.OrderRoleWorker,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderAccepted, nil)
+			},
+			expectedErr: structs.ErrIllegalOrderTransition,
+		},
+		{
+			name: "steps cannot be skipped",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderShipped, Role: structs.OrderRoleAdmin,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderNew, nil)
+			},
+			expectedErr: structs.ErrIllegalOrderTransition,
+		},
+		{
+			name: "cancellation without reason",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderCancelled, Role: structs.OrderRoleAdmin, Comment: "  ",
+			},
+			setupMocks:  func(mockRepo *mock_structs.MockOrderRepository) {},
+			expectedErr: structs.ErrCancelReasonRequired,
+		},
+		{
+			name: "worker cannot cancel order",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderCancelled, Role: structs.OrderRoleWorker, Comment: "нет товара",
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderNew, nil)
+			},
+			expectedErr: structs.ErrIllegalOrderTransition,
+		},
+		{
+			name: "unknown status",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: "completed", Role: structs.OrderRoleAdmin,
+			},
+			setupMocks:  func(mockRepo *mock_structs.MockOrderRepository) {},
+			expectedErr: structs.ErrInvalidOrderStatus,
+		},
+		{
+			name: "comment too long",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderAccepted, Role: structs.OrderRoleWorker,
+				Comment: strings.Repeat("я", maxComment+1),
+			},
+			setupMocks:  func(mockRepo *mock_structs.MockOrderRepository) {},
+			expectedErr: structs.ErrInvalidOrderStatus,
+		},
+		{
+			name: "error getting status",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderAccepted, Role: structs.OrderRoleWorker,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderStatus(""), structs.ErrOrderNotFound)
+			},
+			expectedErr: structs.ErrOrderNotFound,
+		},
+		{
+			name: "error updating status",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, To: structs.OrderAssembled, Role: structs.OrderRoleWorker,
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderAccepted, nil)
+				mockRepo.EXPECT().UpdateStatus(fixture.ctx, gomock.Any()).Return(structs.ErrOrderStatusChanged)
+			},
+			expectedErr: structs.ErrOrderStatusChanged,
+		},
+	}
 
-// 	for _, tt := range tests {
-// 		t.Run(tt.name, func(t *testing.T) {
-// 			service, mockRepo := fixture.CreateServiceWithMocks()
-// 			tt.setupMocks(mockRepo)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
 
-// 			err := service.ChangeOrderStatus(fixture.ctx, fixture.order.Id, tt.status)
+			err := service.ChangeOrderStatus(fixture.ctx, tt.change)
 
-// 			fixture.AssertError(err, tt.expectedErr)
-// 		})
-// 	}
-// 	fixture.Cleanup()
-// }
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCancel_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	admin := structs.GenId()
+
+	tests := []struct {
+		name        string
+		change      structs.OrderStatusChange
+		setupMocks  func(*mock_structs.MockOrderRepository)
+		expectedErr error
+	}{
+		{
+			name: "customer cancels own new order",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, IdActor: fixture.order.IdUser,
+				Role: structs.OrderRoleCustomer, Comment: "передумал",
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderNew, nil)
+				mockRepo.EXPECT().UpdateStatus(fixture.ctx, structs.OrderStatusChange{
+					IdOrder: fixture.order.Id, From: structs.OrderNew, To: structs.OrderCancelled,
+					IdActor: fixture.order.IdUser, Role: structs.OrderRoleCustomer, Comment: "передумал",
+				}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "customer cancels own unpaid order",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, IdActor: fixture.order.IdUser,
+				Role: structs.OrderRoleCustomer, Comment: "передумал",
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderUnpaid, nil)
+				mockRepo.EXPECT().UpdateStatus(fixture.ctx, structs.OrderStatusChange{
+					IdOrder: fixture.order.Id, From: structs.OrderUnpaid, To: structs.OrderCancelled,
+					IdActor: fixture.order.IdUser, Role: structs.OrderRoleCustomer, Comment: "передумал",
+				}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "customer cannot cancel assembled order",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, IdActor: fixture.order.IdUser,
+				Role: structs.OrderRoleCustomer, Comment: "передумал",
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderAssembled, nil)
+			},
+			expectedErr: structs.ErrIllegalOrderTransition,
+		},
+		{
+			name: "customer cannot cancel order of another user",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, IdActor: structs.GenId(),
+				Role: structs.OrderRoleCustomer, Comment: "передумал",
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+			},
+			expectedErr: structs.ErrOrderNotFound,
+		},
+		{
+			name: "admin cancels assembled order",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, IdActor: admin,
+				Role: structs.OrderRoleAdmin, Comment: "нет в наличии",
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderAssembled, nil)
+				mockRepo.EXPECT().UpdateStatus(fixture.ctx, structs.OrderStatusChange{
+					IdOrder: fixture.order.Id, From: structs.OrderAssembled, To: structs.OrderCancelled,
+					IdActor: admin, Role: structs.OrderRoleAdmin, Comment: "нет в наличии",
+				}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "admin cannot cancel shipped order",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, IdActor: admin,
+				Role: structs.OrderRoleAdmin, Comment: "нет в наличии",
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetStatus(fixture.ctx, fixture.order.Id).Return(structs.OrderShipped, nil)
+			},
+			expectedErr: structs.ErrIllegalOrderTransition,
+		},
+		{
+			name: "order not found",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, IdActor: fixture.order.IdUser,
+				Role: structs.OrderRoleCustomer, Comment: "передумал",
+			},
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(structs.Order{}, structs.ErrOrderNotFound)
+			},
+			expectedErr: structs.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.Cancel(fixture.ctx, tt.change)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetHistory_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	history := []structs.OrderStatusChange{
+		{IdOrder: fixture.order.Id, To: structs.OrderNew, IdActor: fixture.order.IdUser, Role: structs.OrderRoleCustomer},
+		{IdOrder: fixture.order.Id, From: structs.OrderNew, To: structs.OrderAccepted, Role: structs.OrderRoleWorker},
+	}
+
+	tests := []struct {
+		name        string
+		idUser      uuid.UUID
+		setupMocks  func(*mock_structs.MockOrderRepository)
+		expectedRet []structs.OrderStatusChange
+		expectedErr error
+	}{
+		{
+			name:   "successful get",
+			idUser: fixture.order.IdUser,
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+				mockRepo.EXPECT().GetHistory(fixture.ctx, fixture.order.Id).Return(history, nil)
+			},
+			expectedRet: history,
+			expectedErr: nil,
+		},
+		{
+			name:   "order of another user",
+			idUser: structs.GenId(),
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrOrderNotFound,
+		},
+		{
+			name:   "order not found",
+			idUser: fixture.order.IdUser,
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(structs.Order{}, structs.ErrOrderNotFound)
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrOrderNotFound,
+		},
+		{
+			name:   "error getting history",
+			idUser: fixture.order.IdUser,
+			setupMocks: func(mockRepo *mock_structs.MockOrderRepository) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+				mockRepo.EXPECT().GetHistory(fixture.ctx, fixture.order.Id).Return(nil, errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.GetHistory(fixture.ctx, fixture.order.Id, tt.idUser)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
 
 // func TestDelete_AAA(t *testing.T) {
 // 	fixture := NewTestFixture(t)
@@ -391,3 +685,398 @@ package order
 // 	}
 // 	fixture.Cleanup()
 // }
+
+func TestCheckout_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	user := fixture.order.IdUser
+	other := structs.GenId()
+	plain := structs.GenId()
+	product := structs.GenId()
+	variant := structs.GenId()
+	promotion := structs.GenId()
+	soon := structs.GenId()
+	later := structs.GenId()
+	expired := structs.GenId()
+	pickup := structs.GenId()
+	post := structs.GenId()
+	courier := structs.GenId()
+	slot := structs.GenId()
+	started := structs.GenId()
+	brand := structs.GenId()
+	categories := []uuid.UUID{structs.GenId(), structs.GenId()}
+
+	// setup fills a store with a product without variants and a variant
+	// with three lots, one of them past its expiry date, and delivery by
+	// pickup, post and courier to Moscow. Post is cheaper from 500, courier
+	// delivery is free from 1000.
+	setup := func(basket ...structs.BasketItem) *memoryCheckout {
+		mem := newMemoryCheckout()
+		mem.baskets[user] = basket
+		mem.stock[stockKey{plain, uuid.Nil}] = 10
+		mem.prices[stockKey{plain, uuid.Nil}] = 100
+		mem.stock[stockKey{product, variant}] = 9
+		mem.prices[stockKey{product, variant}] = 250.5
+		mem.goods[stockKey{plain, uuid.Nil}] = structs.StockLevel{IdBrand: brand, Categories: categories, Name: "Мыло", Art: "SP-1", Weight: 300}
+		mem.goods[stockKey{product, variant}] = structs.StockLevel{Name: "Крем", Art: "CR-50", Brand: "Natura", Weight: 120}
+		mem.methods[pickup] = structs.DeliveryMethod{
+			Id: pickup, Kind: structs.DeliveryPickup, Active: true,
+			Rates: []structs.DeliveryRate{{Zone: "moscow"}},
+		}
+		mem.methods[post] = structs.DeliveryMethod{
+			Id: post, Kind: structs.DeliveryPost, Active: true,
+			Rates: []structs.DeliveryRate{
+				{Zone: "moscow", MaxWeight: 10000, Cost: 300, PerKg: 50},
+				{Zone: "moscow", MinTotal: 500, Cost: 150},
+			},
+		}
+		mem.methods[courier] = structs.DeliveryMethod{
+			Id: courier, Kind: structs.DeliveryCourier, FreeFrom: 1000, Active: true,
+			Rates: []structs.DeliveryRate{{Zone: "moscow", MaxWeight: 5000, Cost: 400}},
+		}
+		mem.slots[slot] = structs.DeliverySlot{Id: slot, IdDelivery: courier, StartsAt: time.Now().Add(24 * time.Hour), Capacity: 1}
+		mem.slots[started] = structs.DeliverySlot{Id: started, IdDelivery: courier, StartsAt: time.Now().Add(-time.Hour), Capacity: 5}
+		mem.lots = []structs.StockLot{
+			{Id: later, IdProduct: product, IdVariant: variant, Batch: "B2", ExpiresAt: mem.today.AddDate(0, 0, 30), Amount: 3},
+			{Id: expired, IdProduct: product, IdVariant: variant, Batch: "B0", ExpiresAt: mem.today.AddDate(0, 0, -1), Amount: 2},
+			{Id: soon, IdProduct: product, IdVariant: variant, Batch: "B1", ExpiresAt: mem.today.AddDate(0, 0, 5), Amount: 2},
+		}
+		return mem
+	}
+	line := func(id_product uuid.UUID, id_variant uuid.UUID, amount int) structs.BasketItem {
+		return structs.BasketItem{Id: structs.GenId(), IdProduct: id_product, IdVariant: id_variant, Amount: amount}
+	}
+
+	by := func(id_delivery uuid.UUID, zone string, id_slot uuid.UUID) func(*structs.Order) {
+		return func(o *structs.Order) {
+			o.IdDelivery, o.Zone, o.IdSlot = id_delivery, zone, id_slot
+		}
+	}
+	quote := func(mockPromo *mock_structs.MockDiscountCalculator) {
+		mockPromo.EXPECT().QuoteLines(gomock.Any(), user, "", gomock.Any()).Return(structs.DiscountQuote{}, nil)
+	}
+
+	tests := []struct {
+		name        string
+		code        string
+		delivery    func(*structs.Order)
+		store       func() *memoryCheckout
+		setupMocks  func(*mock_structs.MockDiscountCalculator)
+		expectedErr error
+		check       func(*testing.T, structs.Order, *memoryCheckout)
+	}{
+		{
+			name: "order takes first expiring lots and the stock without a lot",
+			store: func() *memoryCheckout {
+				return setup(line(product, variant, 6), line(plain, uuid.Nil, 2))
+			},
+			setupMocks: func(mockPromo *mock_structs.MockDiscountCalculator) {
+				mockPromo.EXPECT().QuoteLines(gomock.Any(), user, "", gomock.Any()).Return(structs.DiscountQuote{}, nil)
+			},
+			check: func(t *testing.T, o structs.Order, mem *memoryCheckout) {
+				require.Len(t, mem.orders, 1)
+				assert.Equal(t, mem.orders[0].Id, o.Id)
+				assert.Equal(t, structs.OrderUnpaid, o.Status)
+				assert.Equal(t, 1703.0, o.Price)
+				assert.Equal(t, 1703.0, mem.orders[0].Price)
+				assert.Equal(t, "moscow", mem.orders[0].Zone)
+				require.Len(t, mem.items, 2)
+
+				var id_item uuid.UUID
+				for _, it := range mem.items {
+					if it.IdVariant == variant {
+						id_item = it.Id
+						assert.Equal(t, structs.OrderItem{
+							Id: it.Id, IdProduct: product, IdVariant: variant, IdOrder: o.Id, Amount: 6,
+							Price: 250.5, Name: "Крем", Art: "CR-50", Brand: "Natura",
+						}, it)
+					}
+				}
+				lots := mem.itemLots[id_item]
+				require.Len(t, lots, 2)
+				assert.Equal(t, structs.OrderItemLot{IdLot: soon, Batch: "B1", ExpiresAt: mem.today.AddDate(0, 0, 5), Amount: 2}, lots[0])
+				assert.Equal(t, structs.OrderItemLot{IdLot: later, Batch: "B2", ExpiresAt: mem.today.AddDate(0, 0, 30), Amount: 3}, lots[1])
+
+				assert.Equal(t, 3, mem.stock[stockKey{product, variant}])
+				assert.Equal(t, 8, mem.stock[stockKey{plain, uuid.Nil}])
+				require.Len(t, mem.movements, 4)
+				for _, m := range mem.movements {
+					assert.Equal(t, structs.StockSale, m.Kind)
+					assert.Equal(t, o.Id.String(), m.Reference)
+				}
+				untracked := slices.IndexFunc(mem.movements, func(m structs.StockMovement) bool {
+					return m.IdVariant == variant && m.IdLot == uuid.Nil
+				})
+				require.NotEqual(t, -1, untracked)
+				assert.Equal(t, -1, mem.movements[untracked].Quantity)
+
+				assert.Empty(t, mem.baskets[user])
+				require.Len(t, mem.history, 1)
+				assert.Equal(t, structs.OrderStatusChange{IdOrder: o.Id, To: structs.OrderUnpaid, IdActor: user, Role: structs.OrderRoleCustomer}, mem.history[0])
+			},
+		},
+		{
+			name: "discounts are stored on the lines and redeemed",
+			code: "SALE",
+			store: func() *memoryCheckout {
+				return setup(line(plain, uuid.Nil, 3))
+			},
+			setupMocks: func(mockPromo *mock_structs.MockDiscountCalculator) {
+				locked := []structs.CheckoutLine{{IdProduct: plain, IdBrand: brand, Categories: categories, UnitPrice: 100, Amount: 3}}
+				mockPromo.EXPECT().QuoteLines(gomock.Any(), user, "SALE", locked).DoAndReturn(
+					func(ctx context.Context, _ uuid.UUID, _ string, _ []structs.CheckoutLine) (structs.DiscountQuote, error) {
+						assert.NotNil(t, ctx.Value(memoryTxKey{}), "quote outside of the checkout transaction")
+						return structs.DiscountQuote{
+							Discounts: []structs.LineDiscount{
+								{IdProduct: plain, IdPromotion: promotion, Amount: 20},
+								{IdProduct: plain, IdPromotion: other, Amount: 10},
+								{IdProduct: structs.GenId(), IdPromotion: structs.GenId(), Amount: 99},
+							},
+						}, nil
+					})
+			},
+			check: func(t *testing.T, o structs.Order, mem *memoryCheckout) {
+				assert.Equal(t, 270.0, o.Price)
+				require.Len(t, mem.items, 1)
+				assert.Equal(t, 100.0, mem.items[0].Price)
+				assert.Equal(t, 30.0, mem.items[0].Discount)
+				assert.Equal(t, "Мыло", mem.items[0].Name)
+				assert.Equal(t, []memoryDiscount{
+					{IdItem: mem.items[0].Id, IdPromotion: promotion, Amount: 20},
+					{IdItem: mem.items[0].Id, IdPromotion: other, Amount: 10},
+				}, mem.discounts)
+				assert.ElementsMatch(t, []memoryRedemption{
+					{IdPromotion: promotion, IdUser: user, IdOrder: o.Id, Amount: 20},
+					{IdPromotion: other, IdUser: user, IdOrder: o.Id, Amount: 10},
+				}, mem.redemptions)
+			},
+		},
+		{
+			name: "all short lines are reported and nothing is written",
+			store: func() *memoryCheckout {
+				mem := setup(line(product, variant, 8), line(plain, uuid.Nil, 4))
+				mem.held[stockKey{plain, uuid.Nil}] = map[uuid.UUID]int{other: 7, user: 4}
+				return mem
+			},
+			setupMocks:  func(*mock_structs.MockDiscountCalculator) {},
+			expectedErr: structs.ErrInsufficientStock,
+			check: func(t *testing.T, o structs.Order, mem *memoryCheckout) {
+				assert.Empty(t, mem.orders)
+				assert.Len(t, mem.baskets[user], 2)
+			},
+		},
+		{
+			name: "failed redemption rolls the order back",
+			code: "SALE",
+			store: func() *memoryCheckout {
+				mem := setup(line(product, variant, 3))
+				mem.exhausted[promotion] = true
+				return mem
+			},
+			setupMocks: func(mockPromo *mock_structs.MockDiscountCalculator) {
+				mockPromo.EXPECT().QuoteLines(gomock.Any(), user, "SALE", gomock.Any()).Return(structs.DiscountQuote{
+					Discounts: []structs.LineDiscount{{IdProduct: product, IdVariant: variant, IdPromotion: promotion, Amount: 10}},
+				}, nil)
+			},
+			expectedErr: structs.ErrPromotionLimitReached,
+			check: func(t *testing.T, o structs.Order, mem *memoryCheckout) {
+				assert.Empty(t, mem.orders)
+				assert.Empty(t, mem.items)
+				assert.Empty(t, mem.itemLots)
+				assert.Empty(t, mem.movements)
+				assert.Equal(t, 9, mem.stock[stockKey{product, variant}])
+				assert.Equal(t, 2, mem.lots[2].Amount)
+				assert.Len(t, mem.baskets[user], 1)
+			},
+		},
+		{
+			name:     "post cost for the weight is added to the price",
+			delivery: by(post, "moscow", uuid.Nil),
+			store: func() *memoryCheckout {
+				return setup(line(plain, uuid.Nil, 3))
+			},
+			setupMocks: quote,
+			check: func(t *testing.T, o structs.Order, mem *memoryCheckout) {
+				assert.Equal(t, 350.0, o.ShippingCost)
+				assert.Equal(t, 650.0, o.Price)
+				require.Len(t, mem.orders, 1)
+				assert.Equal(t, post, mem.orders[0].IdDelivery)
+				assert.Equal(t, "moscow", mem.orders[0].Zone)
+				assert.Equal(t, 350.0, mem.orders[0].ShippingCost)
+				assert.Equal(t, 650.0, mem.orders[0].Price)
+			},
+		},
+		{
+			name:     "cheapest applying rate is taken",
+			delivery: by(post, "moscow", uuid.Nil),
+			store: func() *memoryCheckout {
+				return setup(line(plain, uuid.Nil, 6))
+			},
+			setupMocks: quote,
+			check: func(t *testing.T, o structs.Order, mem *memoryCheckout) {
+				assert.Equal(t, 150.0, o.ShippingCost)
+				assert.Equal(t, 750.0, o.Price)
+			},
+		},
+		{
+			name:     "courier delivery is free from the threshold",
+			delivery: by(courier, "moscow", slot),
+			store: func() *memoryCheckout {
+				return setup(line(product, variant, 6))
+			},
+			setupMocks: quote,
+			check: func(t *testing.T, o structs.Order, mem *memoryCheckout) {
+				assert.Equal(t, 0.0, o.ShippingCost)
+				assert.Equal(t, 1503.0, o.Price)
+				require.Len(t, mem.orders, 1)
+				assert.Equal(t, slot, mem.orders[0].IdSlot)
+			},
+		},
+		{
+			name:     "full slot",
+			delivery: by(courier, "moscow", slot),
+			store: func() *memoryCheckout {
+				mem := setup(line(plain, uuid.Nil, 1))
+				mem.orders = []structs.Order{{Id: structs.GenId(), IdSlot: slot, Status: structs.OrderNew}}
+				return mem
+			},
+			setupMocks:  quote,
+			expectedErr: structs.ErrSlotFull,
+			check: func(t *testing.T, o structs.Order, mem *memoryCheckout) {
+				assert.Len(t, mem.orders, 1)
+				assert.Len(t, mem.baskets[user], 1)
+			},
+		},
+		{
+			name:     "cancelled orders leave the slot",
+			delivery: by(courier, "moscow", slot),
+			store: func() *memoryCheckout {
+				mem := setup(line(plain, uuid.Nil, 1))
+				mem.orders = []structs.Order{{Id: structs.GenId(), IdSlot: slot, Status: structs.OrderCancelled}}
+				return mem
+			},
+			setupMocks: quote,
+			check: func(t *testing.T, o structs.Order, mem *memoryCheckout) {
+				assert.Len(t, mem.orders, 2)
+				assert.Equal(t, 400.0, o.ShippingCost)
+				assert.Equal(t, 500.0, o.Price)
+			},
+		},
+		{
+			name:     "started slot",
+			delivery: by(courier, "moscow", started),
+			store: func() *memoryCheckout {
+				return setup(line(plain, uuid.Nil, 1))
+			},
+			setupMocks:  quote,
+			expectedErr: structs.ErrSlotClosed,
+		},
+		{
+			name:     "courier delivery needs a slot",
+			delivery: by(courier, "moscow", uuid.Nil),
+			store: func() *memoryCheckout {
+				return setup(line(plain, uuid.Nil, 1))
+			},
+			setupMocks:  quote,
+			expectedErr: structs.ErrSlotRequired,
+		},
+		{
+			name:     "zone not served",
+			delivery: by(post, "kazan", uuid.Nil),
+			store: func() *memoryCheckout {
+				return setup(line(plain, uuid.Nil, 2))
+			},
+			setupMocks:  quote,
+			expectedErr: structs.ErrDeliveryUnavailable,
+			check: func(t *testing.T, o structs.Order, mem *memoryCheckout) {
+				assert.Empty(t, mem.orders)
+				assert.Empty(t, mem.movements)
+				assert.Equal(t, 10, mem.stock[stockKey{plain, uuid.Nil}])
+			},
+		},
+		{
+			name:     "unknown delivery method",
+			delivery: by(structs.GenId(), "moscow", uuid.Nil),
+			store: func() *memoryCheckout {
+				return setup(line(plain, uuid.Nil, 1))
+			},
+			setupMocks:  quote,
+			expectedErr: structs.ErrDeliveryNotFound,
+		},
+		{
+			name: "empty basket",
+			store: func() *memoryCheckout {
+				return setup()
+			},
+			setupMocks:  func(*mock_structs.MockDiscountCalculator) {},
+			expectedErr: structs.ErrEmptyBasket,
+		},
+		{
+			name: "product removed from catalog",
+			store: func() *memoryCheckout {
+				return setup(line(structs.GenId(), uuid.Nil, 1))
+			},
+			setupMocks:  func(*mock_structs.MockDiscountCalculator) {},
+			expectedErr: structs.ErrProductNotFound,
+		},
+		{
+			name: "quote error",
+			code: "NOPE",
+			store: func() *memoryCheckout {
+				return setup(line(plain, uuid.Nil, 1))
+			},
+			setupMocks: func(mockPromo *mock_structs.MockDiscountCalculator) {
+				mockPromo.EXPECT().QuoteLines(gomock.Any(), user, "NOPE", gomock.Any()).Return(structs.DiscountQuote{}, structs.ErrPromotionNotFound)
+			},
+			expectedErr: structs.ErrPromotionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := tt.store()
+			service, mockPromo := fixture.CreateCheckoutService(mem)
+			tt.setupMocks(mockPromo)
+			o := structs.Order{IdUser: user, Address: "addr", IdDelivery: pickup, Zone: " Moscow "}
+			if tt.delivery != nil {
+				tt.delivery(&o)
+			}
+
+			o, err := service.Checkout(fixture.ctx, o, tt.code)
+
+			fixture.AssertError(err, tt.expectedErr)
+			if tt.check != nil {
+				tt.check(t, o, mem)
+			}
+		})
+	}
+
+	fixture.Cleanup()
+}
+
+func TestCheckout_ReportsShortages(t *testing.T) {
+	fixture := NewTestFixture(t)
+	user := fixture.order.IdUser
+	other := structs.GenId()
+	first := uuid.UUID{1}
+	second := uuid.UUID{2}
+
+	mem := newMemoryCheckout()
+	mem.baskets[user] = []structs.BasketItem{
+		{IdProduct: second, Amount: 5},
+		{IdProduct: first, Amount: 2},
+	}
+	mem.stock[stockKey{first, uuid.Nil}] = 3
+	mem.stock[stockKey{second, uuid.Nil}] = 4
+	mem.held[stockKey{first, uuid.Nil}] = map[uuid.UUID]int{other: 2}
+	service, _ := fixture.CreateCheckoutService(mem)
+
+	_, err := service.Checkout(fixture.ctx, structs.Order{IdUser: user}, "")
+
+	var se *structs.InsufficientStockError
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, []structs.StockShortage{
+		{IdProduct: first, Requested: 2, Available: 1},
+		{IdProduct: second, Requested: 5, Available: 4},
+	}, se.Shortages)
+	fixture.Cleanup()
+}
@@ -0,0 +1,207 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+const (
+	maxMethod   = 50
+	refundBatch = 100
+)
+
+type PaymentService interface {
+	Start(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID, method string) (structs.Payment, error)
+	GetByOrder(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID) ([]structs.Payment, error)
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+	ProcessRefunds(ctx context.Context) (int, error)
+}
+
+type PaymentRepository interface {
+	Create(ctx context.Context, p structs.Payment) (structs.Payment, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.Payment, error)
+	GetByIntent(ctx context.Context, provider string, intent string) (structs.Payment, error)
+	GetByOrder(ctx context.Context, id_order uuid.UUID) ([]structs.Payment, error)
+	Confirm(ctx context.Context, id_event string, id uuid.UUID) error
+	Fail(ctx context.Context, id_event string, id uuid.UUID, reason string) error
+	GetPendingRefunds(ctx context.Context, limit int) ([]structs.Refund, error)
+	CompleteRefund(ctx context.Context, r structs.Refund, res structs.RefundResult) error
+}
+
+// PaymentProvider is a payment gateway. CreateIntent starts a payment the
+// customer completes with the client secret; the gateway reports the
+// outcome in a webhook, which VerifyWebhook authenticates. An authorized
+// payment is captured to take the money. Refund is safe to repeat with the
+// same key.
+type PaymentProvider interface {
+	Name() string
+	CreateIntent(ctx context.Context, id_payment uuid.UUID, amount float64, method string) (structs.PaymentIntent, error)
+	Capture(ctx context.Context, intent string) error
+	Refund(ctx context.Context, intent string, amount float64, key string) (structs.RefundResult, error)
+	VerifyWebhook(payload []byte, signature string) (structs.PaymentEvent, error)
+}
+
+// PayableOrders gives the payments the orders they pay for.
+type PayableOrders interface {
+	GetById(ctx context.Context, id uuid.UUID) (structs.Order, error)
+}
+
+type Service struct {
+	rep      PaymentRepository
+	orders   PayableOrders
+	provider PaymentProvider
+}
+
+func New(rep PaymentRepository, orders PayableOrders, provider PaymentProvider) *Service {
+	return &Service{rep: rep, orders: orders, provider: provider}
+}
+
+// Start creates a payment of the whole price of an unpaid order of the
+// user. The customer pays it with the returned client secret; method is
+// passed to the provider as is. An order with nothing to pay is confirmed
+// right away without the provider.
+func (s *Service) Start(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID, method string) (structs.Payment, error) {
+	method = strings.TrimSpace(method)
+	if utf8.RuneCountInString(method) > maxMethod {
+		return structs.Payment{}, structs.ErrInvalidPaymentMethod
+	}
+
+	o, err := s.orders.GetById(ctx, id_order)
+	if err != nil {
+		return structs.Payment{}, err
+	}
+	if o.IdUser != id_user {
+		return structs.Payment{}, structs.ErrOrderNotFound
+	}
+	if o.Status != structs.OrderUnpaid {
+		return structs.Payment{}, structs.ErrPaymentNotAllowed
+	}
+
+	p := structs.Payment{
+		Id:       structs.GenId(),
+		IdOrder:  id_order,
+		IdUser:   id_user,
+		Provider: s.provider.Name(),
+		Amount:   o.Price,
+		Status:   structs.PaymentPending,
+	}
+	if p.Amount <= 0 {
+		p.Amount = 0
+		p, err = s.rep.Create(ctx, p)
+		if err != nil {
+			return structs.Payment{}, err
+		}
+		if err := s.rep.Confirm(ctx, "", p.Id); err != nil {
+			return structs.Payment{}, err
+		}
+		return s.rep.GetById(ctx, p.Id)
+	}
+
+	intent, err := s.provider.CreateIntent(ctx, p.Id, p.Amount, method)
+	if err != nil {
+		return structs.Payment{}, err
+	}
+	p.IntentId = intent.Id
+	p, err = s.rep.Create(ctx, p)
+	if err != nil {
+		return structs.Payment{}, err
+	}
+	p.ClientSecret = intent.ClientSecret
+	return p, nil
+}
+
+func (s *Service) GetByOrder(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID) ([]structs.Payment, error) {
+	o, err := s.orders.GetById(ctx, id_order)
+	if err != nil {
+		return nil, err
+	}
+	if o.IdUser != id_user {
+		return nil, structs.ErrOrderNotFound
+	}
+	return s.rep.GetByOrder(ctx, id_order)
+}
+
+// HandleWebhook applies a webhook call of the provider. An authorized
+// payment is captured and passes its order to the workers, a failed one is
+// closed with the reason. Calls are answered the same way however often
+// the provider repeats them: events already processed and events for
+// payments already decided change nothing, and unknown kinds of events are
+// ignored. A capture error is returned, so the provider repeats the call.
+func (s *Service) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	ev, err := s.provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+	if ev.Id == "" || ev.IntentId == "" {
+		return structs.ErrInvalidPaymentEvent
+	}
+	if ev.Type != structs.PaymentEventAuthorized && ev.Type != structs.PaymentEventFailed {
+		return nil
+	}
+
+	p, err := s.rep.GetByIntent(ctx, s.provider.Name(), ev.IntentId)
+	if err != nil {
+		return err
+	}
+	if p.Status != structs.PaymentPending {
+		return nil
+	}
+
+	if ev.Type == structs.PaymentEventAuthorized {
+		if err := s.provider.Capture(ctx, p.IntentId); err != nil {
+			return err
+		}
+		err = s.rep.Confirm(ctx, ev.Id, p.Id)
+	} else {
+		err = s.rep.Fail(ctx, ev.Id, p.Id, ev.Error)
+	}
+	if errors.Is(err, structs.ErrPaymentEventSeen) {
+		return nil
+	}
+	return err
+}
+
+// ProcessRefunds sends the pending refunds to the provider against the
+// payments of their orders. A refund the provider could not be reached for
+// stays pending and is sent again on the next run with the same key, a
+// refund without a payment to return fails.
+func (s *Service) ProcessRefunds(ctx context.Context) (int, error) {
+	refunds, err := s.rep.GetPendingRefunds(ctx, refundBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	n := 0
+	for _, r := range refunds {
+		var res structs.RefundResult
+		if r.IdPayment == uuid.Nil {
+			res = structs.RefundResult{Status: structs.RefundFailed, Error: "order has no payment"}
+		} else {
+			p, err := s.rep.GetById(ctx, r.IdPayment)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			res, err = s.provider.Refund(ctx, p.IntentId, r.Amount, r.Id.String())
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if res.Status == structs.RefundPending {
+				continue
+			}
+		}
+		if err := s.rep.CompleteRefund(ctx, r, res); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		n++
+	}
+	return n, errors.Join(errs...)
+}
@@ -0,0 +1,77 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t       *testing.T
+	ctrl    *gomock.Controller
+	ctx     context.Context
+	order   structs.Order
+	payment structs.Payment
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	order := structs.Order{
+		Id:     structs.GenId(),
+		IdUser: structs.GenId(),
+		Status: structs.OrderUnpaid,
+		Price:  2500,
+	}
+
+	return &TestFixture{
+		t:     t,
+		ctrl:  ctrl,
+		ctx:   context.Background(),
+		order: order,
+		payment: structs.Payment{
+			Id:       structs.GenId(),
+			IdOrder:  order.Id,
+			IdUser:   order.IdUser,
+			Provider: "fake",
+			IntentId: "pi_1",
+			Amount:   order.Price,
+			Status:   structs.PaymentPending,
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockPaymentRepository,
+	*mock_structs.MockPayableOrders, *mock_structs.MockPaymentProvider) {
+	mockRepo := mock_structs.NewMockPaymentRepository(f.ctrl)
+	mockOrders := mock_structs.NewMockPayableOrders(f.ctrl)
+	mockProvider := mock_structs.NewMockPaymentProvider(f.ctrl)
+	mockProvider.EXPECT().Name().Return("fake").AnyTimes()
+
+	service := New(mockRepo, mockOrders, mockProvider)
+	return service, mockRepo, mockOrders, mockProvider
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
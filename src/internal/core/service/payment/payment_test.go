@@ -0,0 +1,324 @@
+package payment
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestStart_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	pending := func(amount float64) structs.Payment {
+		return structs.Payment{
+			IdOrder:  fixture.order.Id,
+			IdUser:   fixture.order.IdUser,
+			Provider: "fake",
+			Amount:   amount,
+			Status:   structs.PaymentPending,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		otherUser   bool
+		method      string
+		setupMocks  func(*mock_structs.MockPaymentRepository, *mock_structs.MockPayableOrders, *mock_structs.MockPaymentProvider)
+		expected    structs.Payment
+		expectedErr error
+	}{
+		{
+			name:   "successful start",
+			method: " fake-delayed ",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockOrders *mock_structs.MockPayableOrders, mockProvider *mock_structs.MockPaymentProvider) {
+				mockOrders.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+				mockProvider.EXPECT().CreateIntent(fixture.ctx, gomock.Any(), 2500.0, "fake-delayed").
+					Return(structs.PaymentIntent{Id: "pi_1", ClientSecret: "secret"}, nil)
+				mockRepo.EXPECT().Create(fixture.ctx, gomock.Any()).DoAndReturn(
+					func(_ any, p structs.Payment) (structs.Payment, error) {
+						want := pending(2500)
+						want.Id = p.Id
+						want.IntentId = "pi_1"
+						assert.Equal(t, want, p)
+						p.Id = fixture.payment.Id
+						return p, nil
+					})
+			},
+			expected: func() structs.Payment {
+				p := fixture.payment
+				p.ClientSecret = "secret"
+				return p
+			}(),
+			expectedErr: nil,
+		},
+		{
+			name: "free order is confirmed without provider",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockOrders *mock_structs.MockPayableOrders, mockProvider *mock_structs.MockPaymentProvider) {
+				o := fixture.order
+				o.Price = 0
+				mockOrders.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(o, nil)
+				paid := pending(0)
+				paid.Id = fixture.payment.Id
+				mockRepo.EXPECT().Create(fixture.ctx, gomock.Any()).Return(paid, nil)
+				mockRepo.EXPECT().Confirm(fixture.ctx, "", fixture.payment.Id).Return(nil)
+				paid.Status = structs.PaymentSucceeded
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.payment.Id).Return(paid, nil)
+			},
+			expected: func() structs.Payment {
+				p := pending(0)
+				p.Id = fixture.payment.Id
+				p.Status = structs.PaymentSucceeded
+				return p
+			}(),
+			expectedErr: nil,
+		},
+		{
+			name:   "method too long",
+			method: strings.Repeat("x", maxMethod+1),
+			setupMocks: func(*mock_structs.MockPaymentRepository, *mock_structs.MockPayableOrders, *mock_structs.MockPaymentProvider) {
+			},
+			expectedErr: structs.ErrInvalidPaymentMethod,
+		},
+		{
+			name:      "order of another user",
+			otherUser: true,
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockOrders *mock_structs.MockPayableOrders, mockProvider *mock_structs.MockPaymentProvider) {
+				mockOrders.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+			},
+			expectedErr: structs.ErrOrderNotFound,
+		},
+		{
+			name: "order already paid",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockOrders *mock_structs.MockPayableOrders, mockProvider *mock_structs.MockPaymentProvider) {
+				o := fixture.order
+				o.Status = structs.OrderNew
+				mockOrders.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(o, nil)
+			},
+			expectedErr: structs.ErrPaymentNotAllowed,
+		},
+		{
+			name: "provider error",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockOrders *mock_structs.MockPayableOrders, mockProvider *mock_structs.MockPaymentProvider) {
+				mockOrders.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+				mockProvider.EXPECT().CreateIntent(fixture.ctx, gomock.Any(), 2500.0, "").Return(structs.PaymentIntent{}, errTest)
+			},
+			expectedErr: errTest,
+		},
+		{
+			name: "order paid meanwhile",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockOrders *mock_structs.MockPayableOrders, mockProvider *mock_structs.MockPaymentProvider) {
+				mockOrders.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+				mockProvider.EXPECT().CreateIntent(fixture.ctx, gomock.Any(), 2500.0, "").Return(structs.PaymentIntent{Id: "pi_1"}, nil)
+				mockRepo.EXPECT().Create(fixture.ctx, gomock.Any()).Return(structs.Payment{}, structs.ErrPaymentNotAllowed)
+			},
+			expectedErr: structs.ErrPaymentNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, mockOrders, mockProvider := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo, mockOrders, mockProvider)
+
+			id_user := fixture.order.IdUser
+			if tt.otherUser {
+				id_user = structs.GenId()
+			}
+			p, err := service.Start(fixture.ctx, fixture.order.Id, id_user, tt.method)
+
+			fixture.AssertError(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.expected, p)
+			}
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestHandleWebhook_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	payload := []byte(`{}`)
+	authorized := structs.PaymentEvent{Id: "ev_1", Type: structs.PaymentEventAuthorized, IntentId: "pi_1"}
+	failed := structs.PaymentEvent{Id: "ev_2", Type: structs.PaymentEventFailed, IntentId: "pi_1", Error: "card declined"}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockPaymentRepository, *mock_structs.MockPaymentProvider)
+		expectedErr error
+	}{
+		{
+			name: "authorized payment is captured and confirmed",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockProvider.EXPECT().VerifyWebhook(payload, "sig").Return(authorized, nil)
+				mockRepo.EXPECT().GetByIntent(fixture.ctx, "fake", "pi_1").Return(fixture.payment, nil)
+				mockProvider.EXPECT().Capture(fixture.ctx, "pi_1").Return(nil)
+				mockRepo.EXPECT().Confirm(fixture.ctx, "ev_1", fixture.payment.Id).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "failed payment is closed with reason",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockProvider.EXPECT().VerifyWebhook(payload, "sig").Return(failed, nil)
+				mockRepo.EXPECT().GetByIntent(fixture.ctx, "fake", "pi_1").Return(fixture.payment, nil)
+				mockRepo.EXPECT().Fail(fixture.ctx, "ev_2", fixture.payment.Id, "card declined").Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "decided payment is not captured again",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				p := fixture.payment
+				p.Status = structs.PaymentSucceeded
+				mockProvider.EXPECT().VerifyWebhook(payload, "sig").Return(authorized, nil)
+				mockRepo.EXPECT().GetByIntent(fixture.ctx, "fake", "pi_1").Return(p, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "repeated event is acknowledged",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockProvider.EXPECT().VerifyWebhook(payload, "sig").Return(failed, nil)
+				mockRepo.EXPECT().GetByIntent(fixture.ctx, "fake", "pi_1").Return(fixture.payment, nil)
+				mockRepo.EXPECT().Fail(fixture.ctx, "ev_2", fixture.payment.Id, "card declined").Return(structs.ErrPaymentEventSeen)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "unknown event is ignored",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockProvider.EXPECT().VerifyWebhook(payload, "sig").
+					Return(structs.PaymentEvent{Id: "ev_3", Type: "payment.disputed", IntentId: "pi_1"}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "bad signature",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockProvider.EXPECT().VerifyWebhook(payload, "sig").Return(structs.PaymentEvent{}, structs.ErrInvalidSignature)
+			},
+			expectedErr: structs.ErrInvalidSignature,
+		},
+		{
+			name: "event without intent",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockProvider.EXPECT().VerifyWebhook(payload, "sig").
+					Return(structs.PaymentEvent{Id: "ev_4", Type: structs.PaymentEventAuthorized}, nil)
+			},
+			expectedErr: structs.ErrInvalidPaymentEvent,
+		},
+		{
+			name: "capture error is returned for a retry",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockProvider.EXPECT().VerifyWebhook(payload, "sig").Return(authorized, nil)
+				mockRepo.EXPECT().GetByIntent(fixture.ctx, "fake", "pi_1").Return(fixture.payment, nil)
+				mockProvider.EXPECT().Capture(fixture.ctx, "pi_1").Return(errTest)
+			},
+			expectedErr: errTest,
+		},
+		{
+			name: "unknown payment",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockProvider.EXPECT().VerifyWebhook(payload, "sig").Return(authorized, nil)
+				mockRepo.EXPECT().GetByIntent(fixture.ctx, "fake", "pi_1").Return(structs.Payment{}, structs.ErrPaymentNotFound)
+			},
+			expectedErr: structs.ErrPaymentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _, mockProvider := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo, mockProvider)
+
+			err := service.HandleWebhook(fixture.ctx, payload, "sig")
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestProcessRefunds_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	refund := structs.Refund{
+		Id:        structs.GenId(),
+		IdOrder:   fixture.order.Id,
+		IdPayment: fixture.payment.Id,
+		Amount:    1800,
+		Status:    structs.RefundPending,
+	}
+	orphan := structs.Refund{Id: structs.GenId(), IdOrder: structs.GenId(), Amount: 100, Status: structs.RefundPending}
+	done := structs.RefundResult{Ref: "re_1", Status: structs.RefundSucceeded}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockPaymentRepository, *mock_structs.MockPaymentProvider)
+		expected    int
+		expectedErr error
+	}{
+		{
+			name: "refunds are sent against payments",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockRepo.EXPECT().GetPendingRefunds(fixture.ctx, refundBatch).Return([]structs.Refund{refund, orphan}, nil)
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.payment.Id).Return(fixture.payment, nil)
+				mockProvider.EXPECT().Refund(fixture.ctx, "pi_1", 1800.0, refund.Id.String()).Return(done, nil)
+				mockRepo.EXPECT().CompleteRefund(fixture.ctx, refund, done).Return(nil)
+				mockRepo.EXPECT().CompleteRefund(fixture.ctx, orphan,
+					structs.RefundResult{Status: structs.RefundFailed, Error: "order has no payment"}).Return(nil)
+			},
+			expected:    2,
+			expectedErr: nil,
+		},
+		{
+			name: "unreachable provider leaves refund pending",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockRepo.EXPECT().GetPendingRefunds(fixture.ctx, refundBatch).Return([]structs.Refund{refund, orphan}, nil)
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.payment.Id).Return(fixture.payment, nil)
+				mockProvider.EXPECT().Refund(fixture.ctx, "pi_1", 1800.0, refund.Id.String()).Return(structs.RefundResult{}, errTest)
+				mockRepo.EXPECT().CompleteRefund(fixture.ctx, orphan, gomock.Any()).Return(nil)
+			},
+			expected:    1,
+			expectedErr: errTest,
+		},
+		{
+			name: "refund still processed by provider",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockRepo.EXPECT().GetPendingRefunds(fixture.ctx, refundBatch).Return([]structs.Refund{refund}, nil)
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.payment.Id).Return(fixture.payment, nil)
+				mockProvider.EXPECT().Refund(fixture.ctx, "pi_1", 1800.0, refund.Id.String()).
+					Return(structs.RefundResult{Ref: "re_1", Status: structs.RefundPending}, nil)
+			},
+			expected:    0,
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockPaymentRepository, mockProvider *mock_structs.MockPaymentProvider) {
+				mockRepo.EXPECT().GetPendingRefunds(fixture.ctx, refundBatch).Return(nil, errTest)
+			},
+			expected:    0,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _, mockProvider := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo, mockProvider)
+
+			n, err := service.ProcessRefunds(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			}
+			assert.Equal(t, tt.expected, n)
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,70 @@
+package price
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type PriceService interface {
+	Schedule(ctx context.Context, p structs.ProductPrice) (uuid.UUID, error)
+	CancelScheduled(ctx context.Context, id_product uuid.UUID, id uuid.UUID) error
+	GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.ProductPrice, error)
+	ActivateDue(ctx context.Context) (int64, error)
+}
+
+type PriceRepository interface {
+	Schedule(ctx context.Context, p structs.ProductPrice) (uuid.UUID, error)
+	DeleteScheduled(ctx context.Context, id_product uuid.UUID, id uuid.UUID) error
+	GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.ProductPrice, error)
+	ActivateDue(ctx context.Context) (int64, error)
+}
+
+type Service struct {
+	rep PriceRepository
+	now func() time.Time
+}
+
+func New(rep PriceRepository) *Service {
+	return &Service{rep: rep, now: time.Now}
+}
+
+// Schedule adds a price that becomes current at p.ValidFrom. The validity
+// intervals of the neighbouring prices are adjusted by the repository.
+func (s *Service) Schedule(ctx context.Context, p structs.ProductPrice) (uuid.UUID, error) {
+	if p.Price < 0 {
+		return uuid.Nil, structs.ErrInvalidPrice
+	}
+	if !p.ValidFrom.After(s.now()) {
+		return uuid.Nil, structs.ErrPriceNotInFuture
+	}
+	return s.rep.Schedule(ctx, p)
+}
+
+// CancelScheduled removes a price that has not started yet.
+func (s *Service) CancelScheduled(ctx context.Context, id_product uuid.UUID, id uuid.UUID) error {
+	return s.rep.DeleteScheduled(ctx, id_product, id)
+}
+
+// GetHistory returns the prices of a product ordered by ValidFrom, future
+// ones are marked as scheduled.
+func (s *Service) GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.ProductPrice, error) {
+	ps, err := s.rep.GetHistory(ctx, id_product)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	for i := range ps {
+		ps[i].Scheduled = ps[i].ValidFrom.After(now)
+	}
+	return ps, nil
+}
+
+// ActivateDue copies the prices that became current into the products and
+// returns the number of changed products.
+func (s *Service) ActivateDue(ctx context.Context) (int64, error) {
+	return s.rep.ActivateDue(ctx)
+}
@@ -0,0 +1,77 @@
+package price
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t         *testing.T
+	ctrl      *gomock.Controller
+	ctx       context.Context
+	now       time.Time
+	current   structs.ProductPrice
+	scheduled structs.ProductPrice
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	id_product := structs.GenId()
+	start := now.Add(24 * time.Hour)
+
+	return &TestFixture{
+		t:    t,
+		ctrl: ctrl,
+		ctx:  context.Background(),
+		now:  now,
+		current: structs.ProductPrice{
+			Id:        structs.GenId(),
+			IdProduct: id_product,
+			Price:     1800,
+			ValidFrom: now.Add(-30 * 24 * time.Hour),
+			ValidTo:   &start,
+		},
+		scheduled: structs.ProductPrice{
+			Id:        structs.GenId(),
+			IdProduct: id_product,
+			Price:     1990,
+			ValidFrom: start,
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockPriceRepository) {
+	mockRepo := mock_structs.NewMockPriceRepository(f.ctrl)
+
+	service := New(mockRepo)
+	service.now = func() time.Time { return f.now }
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
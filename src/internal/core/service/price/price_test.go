@@ -0,0 +1,159 @@
+package price
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestSchedule_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	negative := fixture.scheduled
+	negative.Price = -1
+	past := fixture.scheduled
+	past.ValidFrom = fixture.now
+	past.ValidTo = nil
+
+	tests := []struct {
+		name        string
+		price       structs.ProductPrice
+		setupMocks  func(*mock_structs.MockPriceRepository)
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:  "successful schedule",
+			price: fixture.scheduled,
+			setupMocks: func(mockRepo *mock_structs.MockPriceRepository) {
+				mockRepo.EXPECT().Schedule(fixture.ctx, fixture.scheduled).Return(fixture.scheduled.Id, nil)
+			},
+			expectedRet: fixture.scheduled.Id,
+			expectedErr: nil,
+		},
+		{
+			name:        "negative price",
+			price:       negative,
+			setupMocks:  func(mockRepo *mock_structs.MockPriceRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidPrice,
+		},
+		{
+			name:        "start is not in the future",
+			price:       past,
+			setupMocks:  func(mockRepo *mock_structs.MockPriceRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrPriceNotInFuture,
+		},
+		{
+			name:  "another price at the same time",
+			price: fixture.scheduled,
+			setupMocks: func(mockRepo *mock_structs.MockPriceRepository) {
+				mockRepo.EXPECT().Schedule(fixture.ctx, fixture.scheduled).Return(uuid.Nil, structs.ErrDuplicatePrice)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDuplicatePrice,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Schedule(fixture.ctx, tt.price)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetHistory_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	id_product := fixture.current.IdProduct
+	scheduled := fixture.scheduled
+	scheduled.Scheduled = true
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockPriceRepository)
+		expectedRet []structs.ProductPrice
+		expectedErr error
+	}{
+		{
+			name: "future prices are marked",
+			setupMocks: func(mockRepo *mock_structs.MockPriceRepository) {
+				mockRepo.EXPECT().GetHistory(fixture.ctx, id_product).
+					Return([]structs.ProductPrice{fixture.current, fixture.scheduled}, nil)
+			},
+			expectedRet: []structs.ProductPrice{fixture.current, scheduled},
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupMocks: func(mockRepo *mock_structs.MockPriceRepository) {
+				mockRepo.EXPECT().GetHistory(fixture.ctx, id_product).Return(nil, structs.ErrProductNotFound)
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.GetHistory(fixture.ctx, id_product)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestActivateDue_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockPriceRepository)
+		expectedRet int64
+		expectedErr error
+	}{
+		{
+			name: "prices activated",
+			setupMocks: func(mockRepo *mock_structs.MockPriceRepository) {
+				mockRepo.EXPECT().ActivateDue(fixture.ctx).Return(int64(3), nil)
+			},
+			expectedRet: 3,
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockPriceRepository) {
+				mockRepo.EXPECT().ActivateDue(fixture.ctx).Return(int64(0), errTest)
+			},
+			expectedRet: 0,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.ActivateDue(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
@@ -2,6 +2,8 @@ package product
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/taucuya/ppo/internal/core/structs"
@@ -12,9 +14,13 @@ type ProductService interface {
 	GetById(ctx context.Context, id uuid.UUID) (structs.Product, error)
 	GetByName(ctx context.Context, name string) (structs.Product, error)
 	GetByArticule(ctx context.Context, art string) (structs.Product, error)
-	GetByCategory(ctx context.Context, category string) ([]structs.Product, error)
+	GetByCategory(ctx context.Context, id_category uuid.UUID) ([]structs.Product, error)
 	GetByBrand(ctx context.Context, brand string) ([]structs.Product, error)
+	Search(ctx context.Context, f structs.ProductFilter) ([]structs.Product, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	CreateVariant(ctx context.Context, v structs.ProductVariant) error
+	GetVariants(ctx context.Context, id_product uuid.UUID) (structs.VariantMatrix, error)
+	DeleteVariant(ctx context.Context, id_product uuid.UUID, id uuid.UUID) error
 }
 
 type ProductRepository interface {
@@ -22,9 +28,13 @@ type ProductRepository interface {
 	GetById(ctx context.Context, id uuid.UUID) (structs.Product, error)
 	GetByName(ctx context.Context, name string) (structs.Product, error)
 	GetByArticule(ctx context.Context, art string) (structs.Product, error)
-	GetByCategory(ctx context.Context, category string) ([]structs.Product, error)
+	GetByCategory(ctx context.Context, id_category uuid.UUID) ([]structs.Product, error)
 	GetByBrand(ctx context.Context, brand string) ([]structs.Product, error)
+	Search(ctx context.Context, f structs.ProductFilter) ([]structs.Product, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	CreateVariant(ctx context.Context, v structs.ProductVariant) error
+	GetVariants(ctx context.Context, id_product uuid.UUID) ([]structs.ProductVariant, error)
+	DeleteVariant(ctx context.Context, id_product uuid.UUID, id uuid.UUID) error
 }
 type Service struct {
 	rep ProductRepository
@@ -63,8 +73,8 @@ func (s *Service) GetByArticule(ctx context.Context, art string) (structs.Produc
 	return p, nil
 }
 
-func (s *Service) GetByCategory(ctx context.Context, category string) ([]structs.Product, error) {
-	p, err := s.rep.GetByCategory(ctx, category)
+func (s *Service) GetByCategory(ctx context.Context, id_category uuid.UUID) ([]structs.Product, error) {
+	p, err := s.rep.GetByCategory(ctx, id_category)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +89,79 @@ func (s *Service) GetByBrand(ctx context.Context, brand string) ([]structs.Produ
 	return p, nil
 }
 
+// Search checks the attribute kinds, the sort and the SPF and rating bounds
+// of the filter and normalizes ingredient names to lower case before
+// querying the catalog.
+func (s *Service) Search(ctx context.Context, f structs.ProductFilter) ([]structs.Product, error) {
+	for kind := range f.Attributes {
+		if !slices.Contains(structs.AttributeKinds, kind) {
+			return nil, structs.ErrInvalidAttribute
+		}
+	}
+	if f.MinSpf < 0 || f.MinSpf > 100 {
+		return nil, structs.ErrInvalidProductAttributes
+	}
+	if (f.Sort != "" && !slices.Contains(structs.ProductSorts, f.Sort)) || f.MinRating < 0 || f.MinRating > 5 {
+		return nil, structs.ErrInvalidSort
+	}
+	f.IncludeIngredients = normalizeIngredients(f.IncludeIngredients)
+	f.ExcludeIngredients = normalizeIngredients(f.ExcludeIngredients)
+
+	return s.rep.Search(ctx, f)
+}
+
+func normalizeIngredients(names []string) []string {
+	var res []string
+	for _, n := range names {
+		n = strings.ToLower(strings.TrimSpace(n))
+		if n != "" && !slices.Contains(res, n) {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
 func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
 	err := s.rep.Delete(ctx, id)
 	return err
 }
+
+func (s *Service) CreateVariant(ctx context.Context, v structs.ProductVariant) error {
+	if v.Articule == "" || (v.Shade == "" && v.VolumeMl <= 0) || v.VolumeMl < 0 || v.Price < 0 || v.Amount < 0 || v.Weight < 0 {
+		return structs.ErrInvalidVariant
+	}
+	return s.rep.CreateVariant(ctx, v)
+}
+
+// GetVariants returns the variants of a product along with the shades and
+// volumes they cover. Axes keep the order the variants come in.
+func (s *Service) GetVariants(ctx context.Context, id_product uuid.UUID) (structs.VariantMatrix, error) {
+	vs, err := s.rep.GetVariants(ctx, id_product)
+	if err != nil {
+		return structs.VariantMatrix{}, err
+	}
+
+	m := structs.VariantMatrix{
+		Shades:   []string{},
+		Volumes:  []int{},
+		Variants: []structs.ProductVariant{},
+	}
+	shades := make(map[string]bool)
+	volumes := make(map[int]bool)
+	for _, v := range vs {
+		if v.Shade != "" && !shades[v.Shade] {
+			shades[v.Shade] = true
+			m.Shades = append(m.Shades, v.Shade)
+		}
+		if v.VolumeMl > 0 && !volumes[v.VolumeMl] {
+			volumes[v.VolumeMl] = true
+			m.Volumes = append(m.Volumes, v.VolumeMl)
+		}
+		m.Variants = append(m.Variants, v)
+	}
+	return m, nil
+}
+
+func (s *Service) DeleteVariant(ctx context.Context, id_product uuid.UUID, id uuid.UUID) error {
+	return s.rep.DeleteVariant(ctx, id_product, id)
+}
@@ -24,7 +24,7 @@ func NewProductBuilder() *ProductBuilder {
 			Name:        "Test Product",
 			Description: "Test Description",
 			Articule:    "TEST123",
-			Category:    "electronics",
+			IdCategory:  structs.GenId(),
 			IdBrand:     structs.GenId(),
 			Price:       999.99,
 			Amount:      10,
@@ -52,8 +52,8 @@ func (b *ProductBuilder) WithArticule(articule string) *ProductBuilder {
 	return b
 }
 
-func (b *ProductBuilder) WithCategory(category string) *ProductBuilder {
-	b.product.Category = category
+func (b *ProductBuilder) WithCategory(id_category uuid.UUID) *ProductBuilder {
+	b.product.IdCategory = id_category
 	return b
 }
 
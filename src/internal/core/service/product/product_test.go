@@ -147,7 +147,7 @@ func TestGetByArticule_AAA(t *testing.T) {
 func TestGetByCategory_AAA(t *testing.T) {
 	fixture := NewTestFixture(t)
 
-	category := "electronics"
+	category := structs.GenId()
 	testProducts := []structs.Product{
 		fixture.productBuilder.WithCategory(category).Build(),
 		fixture.productBuilder.WithCategory(category).WithName("Product 2").Build(),
@@ -155,15 +155,15 @@ func TestGetByCategory_AAA(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		category    string
-		setupMocks  func(*mock_structs.MockProductRepository, string, []structs.Product)
+		category    uuid.UUID
+		setupMocks  func(*mock_structs.MockProductRepository, uuid.UUID, []structs.Product)
 		expectedRet []structs.Product
 		expectedErr error
 	}{
 		{
 			name:     "successful get by category",
 			category: category,
-			setupMocks: func(mockRepo *mock_structs.MockProductRepository, category string, products []structs.Product) {
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository, category uuid.UUID, products []structs.Product) {
 				mockRepo.EXPECT().GetByCategory(fixture.ctx, category).Return(products, nil)
 			},
 			expectedRet: testProducts,
@@ -171,8 +171,8 @@ func TestGetByCategory_AAA(t *testing.T) {
 		},
 		{
 			name:     "empty category",
-			category: "nonexistent",
-			setupMocks: func(mockRepo *mock_structs.MockProductRepository, category string, products []structs.Product) {
+			category: structs.GenId(),
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository, category uuid.UUID, products []structs.Product) {
 				mockRepo.EXPECT().GetByCategory(fixture.ctx, category).Return([]structs.Product{}, nil)
 			},
 			expectedRet: []structs.Product{},
@@ -181,7 +181,7 @@ func TestGetByCategory_AAA(t *testing.T) {
 		{
 			name:     "repository error",
 			category: category,
-			setupMocks: func(mockRepo *mock_structs.MockProductRepository, category string, products []structs.Product) {
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository, category uuid.UUID, products []structs.Product) {
 				mockRepo.EXPECT().GetByCategory(fixture.ctx, category).Return(nil, errTest)
 			},
 			expectedRet: nil,
@@ -271,6 +271,99 @@ func TestGetByBrand_AAA(t *testing.T) {
 	fixture.Cleanup()
 }
 
+func TestSearch_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	testProducts := []structs.Product{fixture.productBuilder.Build()}
+	filter := structs.ProductFilter{
+		Attributes:         map[string][]string{structs.AttributeSkinType: {"oily"}},
+		IncludeIngredients: []string{" Niacinamide", "niacinamide", ""},
+		ExcludeIngredients: []string{"PARFUM"},
+		Vegan:              true,
+	}
+	normalized := filter
+	normalized.IncludeIngredients = []string{"niacinamide"}
+	normalized.ExcludeIngredients = []string{"parfum"}
+
+	tests := []struct {
+		name        string
+		filter      structs.ProductFilter
+		setupMocks  func(*mock_structs.MockProductRepository)
+		expectedRet []structs.Product
+		expectedErr error
+	}{
+		{
+			name:   "ingredient names are normalized",
+			filter: filter,
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository) {
+				mockRepo.EXPECT().Search(fixture.ctx, normalized).Return(testProducts, nil)
+			},
+			expectedRet: testProducts,
+			expectedErr: nil,
+		},
+		{
+			name:        "unknown attribute kind",
+			filter:      structs.ProductFilter{Attributes: map[string][]string{"color": {"red"}}},
+			setupMocks:  func(mockRepo *mock_structs.MockProductRepository) {},
+			expectedRet: nil,
+			expectedErr: structs.ErrInvalidAttribute,
+		},
+		{
+			name:        "SPF above 100",
+			filter:      structs.ProductFilter{MinSpf: 150},
+			setupMocks:  func(mockRepo *mock_structs.MockProductRepository) {},
+			expectedRet: nil,
+			expectedErr: structs.ErrInvalidProductAttributes,
+		},
+		{
+			name:   "sorted by rating",
+			filter: structs.ProductFilter{MinRating: 4.5, Sort: structs.ProductSortRating},
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository) {
+				mockRepo.EXPECT().Search(fixture.ctx, structs.ProductFilter{MinRating: 4.5, Sort: structs.ProductSortRating}).
+					Return(testProducts, nil)
+			},
+			expectedRet: testProducts,
+			expectedErr: nil,
+		},
+		{
+			name:        "unknown sort",
+			filter:      structs.ProductFilter{Sort: "popularity"},
+			setupMocks:  func(mockRepo *mock_structs.MockProductRepository) {},
+			expectedRet: nil,
+			expectedErr: structs.ErrInvalidSort,
+		},
+		{
+			name:        "rating above 5",
+			filter:      structs.ProductFilter{MinRating: 6},
+			setupMocks:  func(mockRepo *mock_structs.MockProductRepository) {},
+			expectedRet: nil,
+			expectedErr: structs.ErrInvalidSort,
+		},
+		{
+			name:   "repository error",
+			filter: structs.ProductFilter{MinSpf: 30},
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository) {
+				mockRepo.EXPECT().Search(fixture.ctx, structs.ProductFilter{MinSpf: 30}).Return(nil, errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Search(fixture.ctx, tt.filter)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
 func TestDelete_AAA(t *testing.T) {
 	fixture := NewTestFixture(t)
 
@@ -308,3 +401,132 @@ func TestDelete_AAA(t *testing.T) {
 	}
 	fixture.Cleanup()
 }
+
+func TestCreateVariant_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	testProduct := fixture.productBuilder.Build()
+	variant := structs.ProductVariant{
+		IdProduct: testProduct.Id,
+		Articule:  "TEST123-RED-30",
+		Shade:     "красный",
+		VolumeMl:  30,
+		Price:     1299.99,
+		Amount:    5,
+	}
+	noAttrs := variant
+	noAttrs.Shade = ""
+	noAttrs.VolumeMl = 0
+	negative := variant
+	negative.Amount = -1
+
+	tests := []struct {
+		name        string
+		variant     structs.ProductVariant
+		setupMocks  func(*mock_structs.MockProductRepository)
+		expectedErr error
+	}{
+		{
+			name:    "successful creation",
+			variant: variant,
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository) {
+				mockRepo.EXPECT().CreateVariant(fixture.ctx, variant).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "no shade or volume",
+			variant:     noAttrs,
+			setupMocks:  func(mockRepo *mock_structs.MockProductRepository) {},
+			expectedErr: structs.ErrInvalidVariant,
+		},
+		{
+			name:        "negative amount",
+			variant:     negative,
+			setupMocks:  func(mockRepo *mock_structs.MockProductRepository) {},
+			expectedErr: structs.ErrInvalidVariant,
+		},
+		{
+			name:    "repository error",
+			variant: variant,
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository) {
+				mockRepo.EXPECT().CreateVariant(fixture.ctx, variant).Return(structs.ErrDuplicateArticule)
+			},
+			expectedErr: structs.ErrDuplicateArticule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.CreateVariant(fixture.ctx, tt.variant)
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetVariants_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	testProduct := fixture.productBuilder.Build()
+	variants := []structs.ProductVariant{
+		{Id: structs.GenId(), IdProduct: testProduct.Id, Articule: "A-BEIGE-30", Shade: "бежевый", VolumeMl: 30, Price: 1000, Amount: 3},
+		{Id: structs.GenId(), IdProduct: testProduct.Id, Articule: "A-BEIGE-50", Shade: "бежевый", VolumeMl: 50, Price: 1500, Amount: 0},
+		{Id: structs.GenId(), IdProduct: testProduct.Id, Articule: "A-IVORY-30", Shade: "слоновая кость", VolumeMl: 30, Price: 1000, Amount: 7},
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockProductRepository)
+		expectedRet structs.VariantMatrix
+		expectedErr error
+	}{
+		{
+			name: "matrix of shades and volumes",
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository) {
+				mockRepo.EXPECT().GetVariants(fixture.ctx, testProduct.Id).Return(variants, nil)
+			},
+			expectedRet: structs.VariantMatrix{
+				Shades:   []string{"бежевый", "слоновая кость"},
+				Volumes:  []int{30, 50},
+				Variants: variants,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "product without variants",
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository) {
+				mockRepo.EXPECT().GetVariants(fixture.ctx, testProduct.Id).Return(nil, nil)
+			},
+			expectedRet: structs.VariantMatrix{
+				Shades:   []string{},
+				Volumes:  []int{},
+				Variants: []structs.ProductVariant{},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockProductRepository) {
+				mockRepo.EXPECT().GetVariants(fixture.ctx, testProduct.Id).Return(nil, errTest)
+			},
+			expectedRet: structs.VariantMatrix{},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.GetVariants(fixture.ctx, testProduct.Id)
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,293 @@
+package promotion
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type PromotionService interface {
+	Create(ctx context.Context, p structs.Promotion) (uuid.UUID, error)
+	GetAll(ctx context.Context) ([]structs.Promotion, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.Promotion, error)
+	Deactivate(ctx context.Context, id uuid.UUID) error
+	Quote(ctx context.Context, id_user uuid.UUID, code string) (structs.DiscountQuote, error)
+	QuoteLines(ctx context.Context, id_user uuid.UUID, code string, lines []structs.CheckoutLine) (structs.DiscountQuote, error)
+}
+
+type PromotionRepository interface {
+	Create(ctx context.Context, p structs.Promotion) (uuid.UUID, error)
+	GetAll(ctx context.Context) ([]structs.Promotion, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.Promotion, error)
+	Deactivate(ctx context.Context, id uuid.UUID) error
+	GetAutomatic(ctx context.Context) ([]structs.Promotion, error)
+	GetByCode(ctx context.Context, code string) (structs.Promotion, error)
+	GetRedemptions(ctx context.Context, id uuid.UUID, id_user uuid.UUID) (int, int, error)
+	GetBasketLines(ctx context.Context, id_user uuid.UUID) ([]structs.CheckoutLine, error)
+}
+
+var codeRe = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+type Service struct {
+	rep PromotionRepository
+	now func() time.Time
+}
+
+func New(rep PromotionRepository) *Service {
+	return &Service{rep: rep, now: time.Now}
+}
+
+// Create stores a promotion. Codes are case insensitive and kept in upper
+// case, fixed discounts are only allowed as promo codes.
+func (s *Service) Create(ctx context.Context, p structs.Promotion) (uuid.UUID, error) {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Code = strings.ToUpper(strings.TrimSpace(p.Code))
+	if err := validate(p); err != nil {
+		return uuid.Nil, err
+	}
+	p.Active = true
+	return s.rep.Create(ctx, p)
+}
+
+func validate(p structs.Promotion) error {
+	if p.Name == "" || !slices.Contains(structs.PromotionKinds, p.Kind) {
+		return structs.ErrInvalidPromotion
+	}
+	if p.Code != "" && !codeRe.MatchString(p.Code) {
+		return structs.ErrInvalidPromotion
+	}
+	if p.MinBasket < 0 || p.UsageLimit < 0 || p.PerUserLimit < 0 {
+		return structs.ErrInvalidPromotion
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return structs.ErrInvalidPromotion
+	}
+
+	switch p.Kind {
+	case structs.PromotionPercent:
+		if p.Value <= 0 || p.Value > 100 {
+			return structs.ErrInvalidPromotion
+		}
+	case structs.PromotionFixed:
+		if p.Value <= 0 || p.Code == "" {
+			return structs.ErrInvalidPromotion
+		}
+	case structs.PromotionBundle:
+		if p.BuyQuantity < 1 || p.FreeQuantity < 1 {
+			return structs.ErrInvalidPromotion
+		}
+	}
+	return nil
+}
+
+func (s *Service) GetAll(ctx context.Context) ([]structs.Promotion, error) {
+	return s.rep.GetAll(ctx)
+}
+
+func (s *Service) GetById(ctx context.Context, id uuid.UUID) (structs.Promotion, error) {
+	return s.rep.GetById(ctx, id)
+}
+
+// Deactivate stops a promotion. Promotions are never deleted because order
+// lines keep references to the discounts they gave.
+func (s *Service) Deactivate(ctx context.Context, id uuid.UUID) error {
+	return s.rep.Deactivate(ctx, id)
+}
+
+// Quote prices the basket of the user as it is now, see QuoteLines.
+func (s *Service) Quote(ctx context.Context, id_user uuid.UUID, code string) (structs.DiscountQuote, error) {
+	lines, err := s.rep.GetBasketLines(ctx, id_user)
+	if err != nil {
+		return structs.DiscountQuote{}, err
+	}
+	return s.QuoteLines(ctx, id_user, code, lines)
+}
+
+// QuoteLines prices the given lines of the user, the checkout passes the
+// lines it has locked. Every line gets the best automatic sale covering it,
+// then the promo code, if any, applies to what is left. Limits are checked
+// here for a clear answer and once more by the order repository when the
+// order is placed.
+func (s *Service) QuoteLines(ctx context.Context, id_user uuid.UUID, code string, lines []structs.CheckoutLine) (structs.DiscountQuote, error) {
+	now := s.now()
+	var sales []structs.Promotion
+	auto, err := s.rep.GetAutomatic(ctx)
+	if err != nil {
+		return structs.DiscountQuote{}, err
+	}
+	for _, p := range auto {
+		if !running(p, now) {
+			continue
+		}
+		ok, err := s.withinLimits(ctx, p, id_user)
+		if err != nil {
+			return structs.DiscountQuote{}, err
+		}
+		if ok {
+			sales = append(sales, p)
+		}
+	}
+
+	var promo *structs.Promotion
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code != "" {
+		p, err := s.rep.GetByCode(ctx, code)
+		if err != nil {
+			return structs.DiscountQuote{}, err
+		}
+		if !running(p, now) {
+			return structs.DiscountQuote{}, structs.ErrPromotionExpired
+		}
+		ok, err := s.withinLimits(ctx, p, id_user)
+		if err != nil {
+			return structs.DiscountQuote{}, err
+		}
+		if !ok {
+			return structs.DiscountQuote{}, structs.ErrPromotionLimitReached
+		}
+		promo = &p
+	}
+
+	return apply(lines, sales, promo)
+}
+
+func running(p structs.Promotion, now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return false
+	}
+	return p.EndsAt == nil || now.Before(*p.EndsAt)
+}
+
+func (s *Service) withinLimits(ctx context.Context, p structs.Promotion, id_user uuid.UUID) (bool, error) {
+	if p.UsageLimit == 0 && p.PerUserLimit == 0 {
+		return true, nil
+	}
+	total, mine, err := s.rep.GetRedemptions(ctx, p.Id, id_user)
+	if err != nil {
+		return false, err
+	}
+	if p.UsageLimit > 0 && total >= p.UsageLimit {
+		return false, nil
+	}
+	return p.PerUserLimit == 0 || mine < p.PerUserLimit, nil
+}
+
+// apply computes the discounts in cents so that the sum of the line
+// discounts is exactly the discount of the order.
+func apply(lines []structs.CheckoutLine, sales []structs.Promotion, promo *structs.Promotion) (structs.DiscountQuote, error) {
+	var subtotal int64
+	gross := make([]int64, len(lines))
+	for i, l := range lines {
+		gross[i] = cents(l.UnitPrice) * int64(l.Amount)
+		subtotal += gross[i]
+	}
+
+	var discounts []structs.LineDiscount
+	add := func(i int, id uuid.UUID, amount int64) {
+		if amount > 0 {
+			discounts = append(discounts, structs.LineDiscount{
+				IdProduct:   lines[i].IdProduct,
+				IdVariant:   lines[i].IdVariant,
+				IdPromotion: id,
+				Amount:      float64(amount) / 100,
+			})
+		}
+	}
+
+	rest := slices.Clone(gross)
+	for i, l := range lines {
+		var best int64
+		var id uuid.UUID
+		for _, p := range sales {
+			if !covers(p, l) || subtotal < cents(p.MinBasket) {
+				continue
+			}
+			if d := min(lineDiscount(p, l, gross[i]), gross[i]); d > best {
+				best, id = d, p.Id
+			}
+		}
+		add(i, id, best)
+		rest[i] -= best
+	}
+
+	if promo != nil {
+		if subtotal < cents(promo.MinBasket) {
+			return structs.DiscountQuote{}, structs.ErrPromotionMinBasket
+		}
+
+		var eligible []int
+		var base int64
+		for i, l := range lines {
+			if covers(*promo, l) && rest[i] > 0 {
+				eligible = append(eligible, i)
+				base += rest[i]
+			}
+		}
+		if len(eligible) == 0 {
+			return structs.DiscountQuote{}, structs.ErrPromotionNotApplicable
+		}
+
+		if promo.Kind == structs.PromotionFixed {
+			// The amount is split in proportion to the lines, the last
+			// line takes the rounding remainder.
+			total := min(cents(promo.Value), base)
+			left := total
+			for n, i := range eligible {
+				d := left
+				if n < len(eligible)-1 {
+					d = total * rest[i] / base
+				}
+				add(i, promo.Id, d)
+				left -= d
+			}
+		} else {
+			for _, i := range eligible {
+				add(i, promo.Id, min(lineDiscount(*promo, lines[i], rest[i]), rest[i]))
+			}
+		}
+	}
+
+	var discount int64
+	for _, d := range discounts {
+		discount += cents(d.Amount)
+	}
+	return structs.DiscountQuote{
+		Subtotal:  float64(subtotal) / 100,
+		Discount:  float64(discount) / 100,
+		Total:     float64(subtotal-discount) / 100,
+		Discounts: discounts,
+	}, nil
+}
+
+func covers(p structs.Promotion, l structs.CheckoutLine) bool {
+	if p.IdBrand != uuid.Nil && p.IdBrand != l.IdBrand {
+		return false
+	}
+	return p.IdCategory == uuid.Nil || slices.Contains(l.Categories, p.IdCategory)
+}
+
+// lineDiscount is the discount of a percent or bundle promotion on a line
+// whose undiscounted part costs base cents.
+func lineDiscount(p structs.Promotion, l structs.CheckoutLine, base int64) int64 {
+	switch p.Kind {
+	case structs.PromotionPercent:
+		return int64(math.Round(float64(base) * p.Value / 100))
+	case structs.PromotionBundle:
+		free := l.Amount / (p.BuyQuantity + p.FreeQuantity) * p.FreeQuantity
+		return int64(free) * cents(l.UnitPrice)
+	}
+	return 0
+}
+
+func cents(v float64) int64 {
+	return int64(math.Round(v * 100))
+}
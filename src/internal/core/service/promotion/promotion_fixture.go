@@ -0,0 +1,110 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t        *testing.T
+	ctrl     *gomock.Controller
+	ctx      context.Context
+	now      time.Time
+	idUser   uuid.UUID
+	idBrand  uuid.UUID
+	idParent uuid.UUID
+	lines    []structs.CheckoutLine
+	sale     structs.Promotion
+	code     structs.Promotion
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-24 * time.Hour)
+	end := now.Add(24 * time.Hour)
+	id_brand := structs.GenId()
+	id_parent := structs.GenId()
+
+	return &TestFixture{
+		t:        t,
+		ctrl:     ctrl,
+		ctx:      context.Background(),
+		now:      now,
+		idUser:   structs.GenId(),
+		idBrand:  id_brand,
+		idParent: id_parent,
+		lines: []structs.CheckoutLine{
+			{
+				IdProduct:  structs.GenId(),
+				IdBrand:    id_brand,
+				Categories: []uuid.UUID{structs.GenId(), id_parent},
+				UnitPrice:  1000,
+				Amount:     3,
+			},
+			{
+				IdProduct:  structs.GenId(),
+				IdVariant:  structs.GenId(),
+				IdBrand:    structs.GenId(),
+				Categories: []uuid.UUID{structs.GenId()},
+				UnitPrice:  500.50,
+				Amount:     1,
+			},
+		},
+		sale: structs.Promotion{
+			Id:       structs.GenId(),
+			Name:     "Brand week",
+			Kind:     structs.PromotionPercent,
+			Value:    10,
+			IdBrand:  id_brand,
+			StartsAt: &start,
+			EndsAt:   &end,
+			Active:   true,
+		},
+		code: structs.Promotion{
+			Id:           structs.GenId(),
+			Name:         "Welcome",
+			Kind:         structs.PromotionFixed,
+			Code:         "WELCOME",
+			Value:        300,
+			PerUserLimit: 1,
+			Active:       true,
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockPromotionRepository) {
+	mockRepo := mock_structs.NewMockPromotionRepository(f.ctrl)
+
+	service := New(mockRepo)
+	service.now = func() time.Time { return f.now }
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
@@ -0,0 +1,318 @@
+package promotion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	input := fixture.code
+	input.Id = uuid.Nil
+	input.Code = " welcome "
+	input.Active = false
+	stored := input
+	stored.Code = "WELCOME"
+	stored.Active = true
+
+	withKind := func(kind string) structs.Promotion {
+		p := stored
+		p.Kind = kind
+		return p
+	}
+	automaticFixed := stored
+	automaticFixed.Code = ""
+	badCode := stored
+	badCode.Code = "WELCOME 10"
+	bigPercent := withKind(structs.PromotionPercent)
+	bigPercent.Value = 150
+	bundle := withKind(structs.PromotionBundle)
+	bundle.BuyQuantity = 2
+	bundle.FreeQuantity = 0
+	reversed := stored
+	reversed.StartsAt = fixture.sale.EndsAt
+	reversed.EndsAt = fixture.sale.StartsAt
+
+	tests := []struct {
+		name        string
+		promotion   structs.Promotion
+		setupMocks  func(*mock_structs.MockPromotionRepository)
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:      "successful creation",
+			promotion: input,
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, stored).Return(fixture.code.Id, nil)
+			},
+			expectedRet: fixture.code.Id,
+			expectedErr: nil,
+		},
+		{
+			name:        "unknown kind",
+			promotion:   withKind("gift"),
+			setupMocks:  func(mockRepo *mock_structs.MockPromotionRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidPromotion,
+		},
+		{
+			name:        "fixed discount without a code",
+			promotion:   automaticFixed,
+			setupMocks:  func(mockRepo *mock_structs.MockPromotionRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidPromotion,
+		},
+		{
+			name:        "code with a space",
+			promotion:   badCode,
+			setupMocks:  func(mockRepo *mock_structs.MockPromotionRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidPromotion,
+		},
+		{
+			name:        "percent above 100",
+			promotion:   bigPercent,
+			setupMocks:  func(mockRepo *mock_structs.MockPromotionRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidPromotion,
+		},
+		{
+			name:        "bundle without free units",
+			promotion:   bundle,
+			setupMocks:  func(mockRepo *mock_structs.MockPromotionRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidPromotion,
+		},
+		{
+			name:        "ends before it starts",
+			promotion:   reversed,
+			setupMocks:  func(mockRepo *mock_structs.MockPromotionRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidPromotion,
+		},
+		{
+			name:      "duplicate code",
+			promotion: input,
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, stored).Return(uuid.Nil, structs.ErrDuplicatePromoCode)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDuplicatePromoCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Create(fixture.ctx, tt.promotion)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestQuote_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	lines := fixture.lines
+	sale := fixture.sale
+	code := fixture.code
+
+	later := fixture.now.Add(time.Hour)
+	notStarted := sale
+	notStarted.StartsAt = &later
+	bundle := structs.Promotion{
+		Id:           structs.GenId(),
+		Name:         "2+1",
+		Kind:         structs.PromotionBundle,
+		BuyQuantity:  2,
+		FreeQuantity: 1,
+		Active:       true,
+	}
+	inactive := code
+	inactive.Active = false
+	bigBasket := code
+	bigBasket.MinBasket = 5000
+	category := structs.Promotion{
+		Id:         structs.GenId(),
+		Name:       "Care",
+		Kind:       structs.PromotionPercent,
+		Code:       "CARE20",
+		Value:      20,
+		IdCategory: fixture.idParent,
+		Active:     true,
+	}
+	otherCategory := category
+	otherCategory.IdCategory = structs.GenId()
+
+	tests := []struct {
+		name        string
+		code        string
+		setupMocks  func(*mock_structs.MockPromotionRepository)
+		expectedRet structs.DiscountQuote
+		expectedErr error
+	}{
+		{
+			name: "brand sale without a code",
+			code: "",
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().GetBasketLines(fixture.ctx, fixture.idUser).Return(lines, nil)
+				mockRepo.EXPECT().GetAutomatic(fixture.ctx).Return([]structs.Promotion{sale}, nil)
+			},
+			expectedRet: structs.DiscountQuote{
+				Subtotal: 3500.50,
+				Discount: 300,
+				Total:    3200.50,
+				Discounts: []structs.LineDiscount{
+					{IdProduct: lines[0].IdProduct, IdPromotion: sale.Id, Amount: 300},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "fixed code is split over the rest of the lines",
+			code: "welcome",
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().GetBasketLines(fixture.ctx, fixture.idUser).Return(lines, nil)
+				mockRepo.EXPECT().GetAutomatic(fixture.ctx).Return([]structs.Promotion{sale}, nil)
+				mockRepo.EXPECT().GetByCode(fixture.ctx, "WELCOME").Return(code, nil)
+				mockRepo.EXPECT().GetRedemptions(fixture.ctx, code.Id, fixture.idUser).Return(10, 0, nil)
+			},
+			expectedRet: structs.DiscountQuote{
+				Subtotal: 3500.50,
+				Discount: 600,
+				Total:    2900.50,
+				Discounts: []structs.LineDiscount{
+					{IdProduct: lines[0].IdProduct, IdPromotion: sale.Id, Amount: 300},
+					{IdProduct: lines[0].IdProduct, IdPromotion: code.Id, Amount: 253.08},
+					{IdProduct: lines[1].IdProduct, IdVariant: lines[1].IdVariant, IdPromotion: code.Id, Amount: 46.92},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "best sale wins and future sales are ignored",
+			code: "",
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().GetBasketLines(fixture.ctx, fixture.idUser).Return(lines, nil)
+				mockRepo.EXPECT().GetAutomatic(fixture.ctx).Return([]structs.Promotion{sale, bundle, notStarted}, nil)
+			},
+			expectedRet: structs.DiscountQuote{
+				Subtotal: 3500.50,
+				Discount: 1000,
+				Total:    2500.50,
+				Discounts: []structs.LineDiscount{
+					{IdProduct: lines[0].IdProduct, IdPromotion: bundle.Id, Amount: 1000},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "category code covers subcategories",
+			code: "CARE20",
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().GetBasketLines(fixture.ctx, fixture.idUser).Return(lines, nil)
+				mockRepo.EXPECT().GetAutomatic(fixture.ctx).Return([]structs.Promotion{sale}, nil)
+				mockRepo.EXPECT().GetByCode(fixture.ctx, "CARE20").Return(category, nil)
+			},
+			expectedRet: structs.DiscountQuote{
+				Subtotal: 3500.50,
+				Discount: 840,
+				Total:    2660.50,
+				Discounts: []structs.LineDiscount{
+					{IdProduct: lines[0].IdProduct, IdPromotion: sale.Id, Amount: 300},
+					{IdProduct: lines[0].IdProduct, IdPromotion: category.Id, Amount: 540},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "code for other products",
+			code: "CARE20",
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().GetBasketLines(fixture.ctx, fixture.idUser).Return(lines, nil)
+				mockRepo.EXPECT().GetAutomatic(fixture.ctx).Return(nil, nil)
+				mockRepo.EXPECT().GetByCode(fixture.ctx, "CARE20").Return(otherCategory, nil)
+			},
+			expectedRet: structs.DiscountQuote{},
+			expectedErr: structs.ErrPromotionNotApplicable,
+		},
+		{
+			name: "code used by the user",
+			code: "WELCOME",
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().GetBasketLines(fixture.ctx, fixture.idUser).Return(lines, nil)
+				mockRepo.EXPECT().GetAutomatic(fixture.ctx).Return(nil, nil)
+				mockRepo.EXPECT().GetByCode(fixture.ctx, "WELCOME").Return(code, nil)
+				mockRepo.EXPECT().GetRedemptions(fixture.ctx, code.Id, fixture.idUser).Return(10, 1, nil)
+			},
+			expectedRet: structs.DiscountQuote{},
+			expectedErr: structs.ErrPromotionLimitReached,
+		},
+		{
+			name: "inactive code",
+			code: "WELCOME",
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().GetBasketLines(fixture.ctx, fixture.idUser).Return(lines, nil)
+				mockRepo.EXPECT().GetAutomatic(fixture.ctx).Return(nil, nil)
+				mockRepo.EXPECT().GetByCode(fixture.ctx, "WELCOME").Return(inactive, nil)
+			},
+			expectedRet: structs.DiscountQuote{},
+			expectedErr: structs.ErrPromotionExpired,
+		},
+		{
+			name: "basket below the minimum",
+			code: "WELCOME",
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().GetBasketLines(fixture.ctx, fixture.idUser).Return(lines, nil)
+				mockRepo.EXPECT().GetAutomatic(fixture.ctx).Return(nil, nil)
+				mockRepo.EXPECT().GetByCode(fixture.ctx, "WELCOME").Return(bigBasket, nil)
+				mockRepo.EXPECT().GetRedemptions(fixture.ctx, code.Id, fixture.idUser).Return(0, 0, nil)
+			},
+			expectedRet: structs.DiscountQuote{},
+			expectedErr: structs.ErrPromotionMinBasket,
+		},
+		{
+			name: "unknown code",
+			code: "NOPE",
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().GetBasketLines(fixture.ctx, fixture.idUser).Return(lines, nil)
+				mockRepo.EXPECT().GetAutomatic(fixture.ctx).Return(nil, nil)
+				mockRepo.EXPECT().GetByCode(fixture.ctx, "NOPE").Return(structs.Promotion{}, structs.ErrPromotionNotFound)
+			},
+			expectedRet: structs.DiscountQuote{},
+			expectedErr: structs.ErrPromotionNotFound,
+		},
+		{
+			name: "repository error",
+			code: "",
+			setupMocks: func(mockRepo *mock_structs.MockPromotionRepository) {
+				mockRepo.EXPECT().GetBasketLines(fixture.ctx, fixture.idUser).Return(nil, errTest)
+			},
+			expectedRet: structs.DiscountQuote{},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Quote(fixture.ctx, fixture.idUser, tt.code)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
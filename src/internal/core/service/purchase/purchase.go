@@ -0,0 +1,162 @@
+package purchase
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type PurchaseService interface {
+	CreateSupplier(ctx context.Context, s structs.Supplier) (uuid.UUID, error)
+	GetSuppliers(ctx context.Context) ([]structs.Supplier, error)
+	DeleteSupplier(ctx context.Context, id uuid.UUID) error
+	CreateOrder(ctx context.Context, po structs.PurchaseOrder) (uuid.UUID, error)
+	GetOrders(ctx context.Context, status string) ([]structs.PurchaseOrder, error)
+	GetOrder(ctx context.Context, id uuid.UUID) (structs.PurchaseOrder, error)
+	Send(ctx context.Context, id uuid.UUID) error
+	Receive(ctx context.Context, r structs.PurchaseReceipt) (structs.PurchaseOrder, error)
+}
+
+type PurchaseRepository interface {
+	CreateSupplier(ctx context.Context, s structs.Supplier) (uuid.UUID, error)
+	GetSuppliers(ctx context.Context) ([]structs.Supplier, error)
+	DeleteSupplier(ctx context.Context, id uuid.UUID) error
+	CreateOrder(ctx context.Context, po structs.PurchaseOrder) (uuid.UUID, error)
+	GetOrders(ctx context.Context, status string) ([]structs.PurchaseOrder, error)
+	GetOrder(ctx context.Context, id uuid.UUID) (structs.PurchaseOrder, error)
+	SetStatus(ctx context.Context, id uuid.UUID, from string, to string) error
+	Receive(ctx context.Context, seen structs.PurchaseOrder, r structs.PurchaseReceipt, status string,
+		ds []structs.PurchaseDiscrepancy) error
+}
+
+type Service struct {
+	rep PurchaseRepository
+}
+
+func New(rep PurchaseRepository) *Service {
+	return &Service{rep: rep}
+}
+
+func (s *Service) CreateSupplier(ctx context.Context, sp structs.Supplier) (uuid.UUID, error) {
+	sp.Name = strings.TrimSpace(sp.Name)
+	sp.Email = strings.TrimSpace(sp.Email)
+	sp.Phone = strings.TrimSpace(sp.Phone)
+	if sp.Name == "" || len(sp.Brands) == 0 || slices.Contains(sp.Brands, uuid.Nil) {
+		return uuid.Nil, structs.ErrInvalidSupplier
+	}
+	slices.SortFunc(sp.Brands, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	sp.Brands = slices.Compact(sp.Brands)
+	return s.rep.CreateSupplier(ctx, sp)
+}
+
+func (s *Service) GetSuppliers(ctx context.Context) ([]structs.Supplier, error) {
+	return s.rep.GetSuppliers(ctx)
+}
+
+// DeleteSupplier removes a supplier without purchase orders, the orders are
+// kept as the history of the stock.
+func (s *Service) DeleteSupplier(ctx context.Context, id uuid.UUID) error {
+	return s.rep.DeleteSupplier(ctx, id)
+}
+
+// CreateOrder stores a draft purchase order. Every product or variant may
+// appear on one line only.
+func (s *Service) CreateOrder(ctx context.Context, po structs.PurchaseOrder) (uuid.UUID, error) {
+	if po.IdSupplier == uuid.Nil || len(po.Lines) == 0 {
+		return uuid.Nil, structs.ErrInvalidPurchaseOrder
+	}
+	type key struct{ product, variant uuid.UUID }
+	seen := make(map[key]bool, len(po.Lines))
+	for _, l := range po.Lines {
+		k := key{l.IdProduct, l.IdVariant}
+		if l.IdProduct == uuid.Nil || l.Ordered <= 0 || seen[k] {
+			return uuid.Nil, structs.ErrInvalidPurchaseOrder
+		}
+		seen[k] = true
+	}
+	po.Note = strings.TrimSpace(po.Note)
+	po.Status = structs.PurchaseDraft
+	return s.rep.CreateOrder(ctx, po)
+}
+
+func (s *Service) GetOrders(ctx context.Context, status string) ([]structs.PurchaseOrder, error) {
+	if status != "" && !slices.Contains(structs.PurchaseStatuses, status) {
+		return nil, structs.ErrInvalidPurchaseOrder
+	}
+	return s.rep.GetOrders(ctx, status)
+}
+
+func (s *Service) GetOrder(ctx context.Context, id uuid.UUID) (structs.PurchaseOrder, error) {
+	return s.rep.GetOrder(ctx, id)
+}
+
+// Send marks a draft as sent to the supplier, only sent orders can be
+// received.
+func (s *Service) Send(ctx context.Context, id uuid.UUID) error {
+	return s.rep.SetStatus(ctx, id, structs.PurchaseDraft, structs.PurchaseSent)
+}
+
+// Receive books a delivery. The received quantities are added to the stock
+// as they are, so over-deliveries are stocked too. The order is closed when
+// every line is received in full or the receipt is final, then every line
+// that differs from the order is recorded as a discrepancy. The repository
+// rejects the receipt with ErrPurchaseOrderChanged when another delivery was
+// booked since the order was read.
+func (s *Service) Receive(ctx context.Context, r structs.PurchaseReceipt) (structs.PurchaseOrder, error) {
+	received := make(map[uuid.UUID]int, len(r.Lines))
+	delivered := false
+	for _, l := range r.Lines {
+		if _, ok := received[l.IdLine]; ok || l.Quantity < 0 {
+			return structs.PurchaseOrder{}, structs.ErrInvalidPurchaseOrder
+		}
+		received[l.IdLine] = l.Quantity
+		delivered = delivered || l.Quantity > 0
+	}
+	if !delivered && !r.Final {
+		return structs.PurchaseOrder{}, structs.ErrInvalidPurchaseOrder
+	}
+
+	po, err := s.rep.GetOrder(ctx, r.IdOrder)
+	if err != nil {
+		return structs.PurchaseOrder{}, err
+	}
+	if po.Status != structs.PurchaseSent && po.Status != structs.PurchasePartiallyReceived {
+		return structs.PurchaseOrder{}, structs.ErrPurchaseOrderStatus
+	}
+
+	closed := true
+	totals := make(map[uuid.UUID]int, len(po.Lines))
+	for _, l := range po.Lines {
+		totals[l.Id] = l.Received + received[l.Id]
+		closed = closed && totals[l.Id] >= l.Ordered
+	}
+	for id := range received {
+		if _, ok := totals[id]; !ok {
+			return structs.PurchaseOrder{}, structs.ErrPurchaseLineNotFound
+		}
+	}
+
+	status := structs.PurchasePartiallyReceived
+	var ds []structs.PurchaseDiscrepancy
+	if closed || r.Final {
+		status = structs.PurchaseReceived
+		for _, l := range po.Lines {
+			if totals[l.Id] != l.Ordered {
+				ds = append(ds, structs.PurchaseDiscrepancy{
+					IdLine:     l.Id,
+					Ordered:    l.Ordered,
+					Received:   totals[l.Id],
+					Difference: totals[l.Id] - l.Ordered,
+				})
+			}
+		}
+	}
+
+	if err := s.rep.Receive(ctx, po, r, status, ds); err != nil {
+		return structs.PurchaseOrder{}, err
+	}
+	return s.rep.GetOrder(ctx, r.IdOrder)
+}
@@ -0,0 +1,72 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t        *testing.T
+	ctrl     *gomock.Controller
+	ctx      context.Context
+	supplier structs.Supplier
+	order    structs.PurchaseOrder
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+
+	return &TestFixture{
+		t:    t,
+		ctrl: ctrl,
+		ctx:  context.Background(),
+		supplier: structs.Supplier{
+			Name:   "Aurora Distribution",
+			Email:  "orders@aurora.test",
+			Brands: []uuid.UUID{structs.GenId()},
+		},
+		order: structs.PurchaseOrder{
+			Id:         structs.GenId(),
+			IdSupplier: structs.GenId(),
+			Status:     structs.PurchaseSent,
+			Lines: []structs.PurchaseLine{
+				{Id: structs.GenId(), IdProduct: structs.GenId(), Ordered: 10},
+				{Id: structs.GenId(), IdProduct: structs.GenId(), IdVariant: structs.GenId(), Ordered: 5},
+			},
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockPurchaseRepository) {
+	mockRepo := mock_structs.NewMockPurchaseRepository(f.ctrl)
+
+	service := New(mockRepo)
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
@@ -0,0 +1,277 @@
+package purchase
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreateSupplier_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	id := structs.GenId()
+	padded := fixture.supplier
+	padded.Name = "  " + padded.Name + " "
+	padded.Brands = []uuid.UUID{fixture.supplier.Brands[0], fixture.supplier.Brands[0]}
+	noBrands := fixture.supplier
+	noBrands.Brands = nil
+	noName := fixture.supplier
+	noName.Name = " "
+
+	tests := []struct {
+		name        string
+		supplier    structs.Supplier
+		setupMocks  func(*mock_structs.MockPurchaseRepository)
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:     "name is trimmed and brands deduplicated",
+			supplier: padded,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().CreateSupplier(fixture.ctx, fixture.supplier).Return(id, nil)
+			},
+			expectedRet: id,
+			expectedErr: nil,
+		},
+		{
+			name:        "no brands",
+			supplier:    noBrands,
+			setupMocks:  func(mockRepo *mock_structs.MockPurchaseRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidSupplier,
+		},
+		{
+			name:        "no name",
+			supplier:    noName,
+			setupMocks:  func(mockRepo *mock_structs.MockPurchaseRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidSupplier,
+		},
+		{
+			name:     "repository error",
+			supplier: fixture.supplier,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().CreateSupplier(fixture.ctx, fixture.supplier).Return(uuid.Nil, structs.ErrBrandNotFound)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrBrandNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.CreateSupplier(fixture.ctx, tt.supplier)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCreateOrder_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	id := structs.GenId()
+	draft := fixture.order
+	draft.Status = structs.PurchaseDraft
+	duplicate := fixture.order
+	duplicate.Lines = []structs.PurchaseLine{fixture.order.Lines[0], fixture.order.Lines[0]}
+	empty := fixture.order
+	empty.Lines = nil
+	zero := fixture.order
+	zero.Lines = []structs.PurchaseLine{{IdProduct: structs.GenId()}}
+
+	tests := []struct {
+		name        string
+		order       structs.PurchaseOrder
+		setupMocks  func(*mock_structs.MockPurchaseRepository)
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:  "created as draft",
+			order: fixture.order,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().CreateOrder(fixture.ctx, draft).Return(id, nil)
+			},
+			expectedRet: id,
+			expectedErr: nil,
+		},
+		{
+			name:        "duplicate line",
+			order:       duplicate,
+			setupMocks:  func(mockRepo *mock_structs.MockPurchaseRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidPurchaseOrder,
+		},
+		{
+			name:        "no lines",
+			order:       empty,
+			setupMocks:  func(mockRepo *mock_structs.MockPurchaseRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidPurchaseOrder,
+		},
+		{
+			name:        "zero quantity",
+			order:       zero,
+			setupMocks:  func(mockRepo *mock_structs.MockPurchaseRepository) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidPurchaseOrder,
+		},
+		{
+			name:  "product of another brand",
+			order: fixture.order,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().CreateOrder(fixture.ctx, draft).Return(uuid.Nil, structs.ErrProductNotSupplied)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrProductNotSupplied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.CreateOrder(fixture.ctx, tt.order)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSend_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	service, mockRepo := fixture.CreateServiceWithMocks()
+	mockRepo.EXPECT().SetStatus(fixture.ctx, fixture.order.Id, structs.PurchaseDraft, structs.PurchaseSent).
+		Return(structs.ErrPurchaseOrderStatus)
+
+	err := service.Send(fixture.ctx, fixture.order.Id)
+
+	fixture.AssertError(err, structs.ErrPurchaseOrderStatus)
+	fixture.Cleanup()
+}
+
+func TestReceive_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	po := fixture.order
+	first, second := po.Lines[0], po.Lines[1]
+	partial := po
+	partial.Status = structs.PurchasePartiallyReceived
+	partial.Lines = []structs.PurchaseLine{first, second}
+	partial.Lines[0].Received = 10
+	draft := po
+	draft.Status = structs.PurchaseDraft
+
+	receipt := func(final bool, lines ...structs.ReceivedLine) structs.PurchaseReceipt {
+		return structs.PurchaseReceipt{IdOrder: po.Id, IdWorker: structs.GenId(), Lines: lines, Final: final}
+	}
+	partly := receipt(false, structs.ReceivedLine{IdLine: first.Id, Quantity: 10})
+	full := receipt(false, structs.ReceivedLine{IdLine: first.Id, Quantity: 12},
+		structs.ReceivedLine{IdLine: second.Id, Quantity: 5})
+	short := receipt(true, structs.ReceivedLine{IdLine: second.Id, Quantity: 3})
+	unknown := receipt(false, structs.ReceivedLine{IdLine: structs.GenId(), Quantity: 1})
+
+	tests := []struct {
+		name        string
+		receipt     structs.PurchaseReceipt
+		setupMocks  func(*mock_structs.MockPurchaseRepository)
+		expectedErr error
+	}{
+		{
+			name:    "partial delivery keeps the order open",
+			receipt: partly,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().GetOrder(fixture.ctx, po.Id).Return(po, nil)
+				mockRepo.EXPECT().Receive(fixture.ctx, po, partly, structs.PurchasePartiallyReceived, nil).Return(nil)
+				mockRepo.EXPECT().GetOrder(fixture.ctx, po.Id).Return(partial, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:    "over-delivery closes the order with a discrepancy",
+			receipt: full,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().GetOrder(fixture.ctx, po.Id).Return(po, nil)
+				mockRepo.EXPECT().Receive(fixture.ctx, po, full, structs.PurchaseReceived, []structs.PurchaseDiscrepancy{
+					{IdLine: first.Id, Ordered: 10, Received: 12, Difference: 2},
+				}).Return(nil)
+				mockRepo.EXPECT().GetOrder(fixture.ctx, po.Id).Return(po, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:    "final receipt records the shortage",
+			receipt: short,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().GetOrder(fixture.ctx, po.Id).Return(partial, nil)
+				mockRepo.EXPECT().Receive(fixture.ctx, partial, short, structs.PurchaseReceived, []structs.PurchaseDiscrepancy{
+					{IdLine: second.Id, Ordered: 5, Received: 3, Difference: -2},
+				}).Return(nil)
+				mockRepo.EXPECT().GetOrder(fixture.ctx, po.Id).Return(partial, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "nothing received",
+			receipt:     receipt(false, structs.ReceivedLine{IdLine: first.Id}),
+			setupMocks:  func(mockRepo *mock_structs.MockPurchaseRepository) {},
+			expectedErr: structs.ErrInvalidPurchaseOrder,
+		},
+		{
+			name:    "draft cannot be received",
+			receipt: partly,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().GetOrder(fixture.ctx, po.Id).Return(draft, nil)
+			},
+			expectedErr: structs.ErrPurchaseOrderStatus,
+		},
+		{
+			name:    "unknown line",
+			receipt: unknown,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().GetOrder(fixture.ctx, po.Id).Return(po, nil)
+			},
+			expectedErr: structs.ErrPurchaseLineNotFound,
+		},
+		{
+			name:    "concurrent delivery",
+			receipt: partly,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().GetOrder(fixture.ctx, po.Id).Return(po, nil)
+				mockRepo.EXPECT().Receive(fixture.ctx, po, partly, structs.PurchasePartiallyReceived, nil).
+					Return(structs.ErrPurchaseOrderChanged)
+			},
+			expectedErr: structs.ErrPurchaseOrderChanged,
+		},
+		{
+			name:    "repository error",
+			receipt: partly,
+			setupMocks: func(mockRepo *mock_structs.MockPurchaseRepository) {
+				mockRepo.EXPECT().GetOrder(fixture.ctx, po.Id).Return(structs.PurchaseOrder{}, errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			_, err := service.Receive(fixture.ctx, tt.receipt)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,60 @@
+package recommendation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type RecommendationService interface {
+	Rebuild(ctx context.Context) (int64, error)
+	ForProduct(ctx context.Context, id_product uuid.UUID, id_user uuid.UUID, limit int) ([]structs.Recommendation, error)
+	ForBasket(ctx context.Context, id_user uuid.UUID, limit int) ([]structs.Recommendation, error)
+}
+
+type RecommendationRepository interface {
+	Rebuild(ctx context.Context, t structs.RecommendationThresholds) (int64, error)
+	ForProduct(ctx context.Context, id_product uuid.UUID, id_user uuid.UUID, limit int) ([]structs.Recommendation, error)
+	ForBasket(ctx context.Context, id_user uuid.UUID, limit int) ([]structs.Recommendation, error)
+}
+
+const (
+	defaultLimit = 10
+	maxLimit     = 50
+)
+
+type Service struct {
+	rep        RecommendationRepository
+	thresholds structs.RecommendationThresholds
+}
+
+func New(rep RecommendationRepository, thresholds structs.RecommendationThresholds) *Service {
+	return &Service{rep: rep, thresholds: thresholds}
+}
+
+// Rebuild recomputes the product pairs from the order history and returns
+// how many pairs were kept.
+func (s *Service) Rebuild(ctx context.Context) (int64, error) {
+	return s.rep.Rebuild(ctx, s.thresholds)
+}
+
+// ForProduct returns the products bought together with the product, best
+// first. Products out of stock or already in the basket of the user are
+// left out.
+func (s *Service) ForProduct(ctx context.Context, id_product uuid.UUID, id_user uuid.UUID, limit int) ([]structs.Recommendation, error) {
+	return s.rep.ForProduct(ctx, id_product, id_user, clampLimit(limit))
+}
+
+// ForBasket returns the products bought together with any product of the
+// basket, ranked by their best pair.
+func (s *Service) ForBasket(ctx context.Context, id_user uuid.UUID, limit int) ([]structs.Recommendation, error) {
+	return s.rep.ForBasket(ctx, id_user, clampLimit(limit))
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+	return min(limit, maxLimit)
+}
@@ -0,0 +1,71 @@
+package recommendation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t               *testing.T
+	ctrl            *gomock.Controller
+	ctx             context.Context
+	idProduct       uuid.UUID
+	idUser          uuid.UUID
+	thresholds      structs.RecommendationThresholds
+	recommendations []structs.Recommendation
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+
+	return &TestFixture{
+		t:          t,
+		ctrl:       ctrl,
+		ctx:        context.Background(),
+		idProduct:  structs.GenId(),
+		idUser:     structs.GenId(),
+		thresholds: structs.RecommendationThresholds{MinSupport: 2, MinConfidence: 0.1, MinLift: 1},
+		recommendations: []structs.Recommendation{
+			{
+				Product:    structs.Product{Id: structs.GenId(), Name: "Тушь", Amount: 5},
+				Support:    4,
+				Confidence: 0.4,
+				Lift:       2.5,
+			},
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockRecommendationRepository) {
+	mockRepo := mock_structs.NewMockRecommendationRepository(f.ctrl)
+
+	service := New(mockRepo, f.thresholds)
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
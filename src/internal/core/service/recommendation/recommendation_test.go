@@ -0,0 +1,150 @@
+package recommendation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestRebuild_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockRecommendationRepository)
+		expectedRet int64
+		expectedErr error
+	}{
+		{
+			name: "pairs rebuilt with the thresholds",
+			setupMocks: func(mockRepo *mock_structs.MockRecommendationRepository) {
+				mockRepo.EXPECT().Rebuild(fixture.ctx, fixture.thresholds).Return(int64(12), nil)
+			},
+			expectedRet: 12,
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockRecommendationRepository) {
+				mockRepo.EXPECT().Rebuild(fixture.ctx, fixture.thresholds).Return(int64(0), errTest)
+			},
+			expectedRet: 0,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Rebuild(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestForProduct_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		limit       int
+		setupMocks  func(*mock_structs.MockRecommendationRepository)
+		expectedRet []structs.Recommendation
+		expectedErr error
+	}{
+		{
+			name:  "default limit",
+			limit: 0,
+			setupMocks: func(mockRepo *mock_structs.MockRecommendationRepository) {
+				mockRepo.EXPECT().ForProduct(fixture.ctx, fixture.idProduct, fixture.idUser, defaultLimit).
+					Return(fixture.recommendations, nil)
+			},
+			expectedRet: fixture.recommendations,
+			expectedErr: nil,
+		},
+		{
+			name:  "limit is capped",
+			limit: 1000,
+			setupMocks: func(mockRepo *mock_structs.MockRecommendationRepository) {
+				mockRepo.EXPECT().ForProduct(fixture.ctx, fixture.idProduct, fixture.idUser, maxLimit).
+					Return(fixture.recommendations, nil)
+			},
+			expectedRet: fixture.recommendations,
+			expectedErr: nil,
+		},
+		{
+			name:  "product not found",
+			limit: 5,
+			setupMocks: func(mockRepo *mock_structs.MockRecommendationRepository) {
+				mockRepo.EXPECT().ForProduct(fixture.ctx, fixture.idProduct, fixture.idUser, 5).
+					Return(nil, structs.ErrProductNotFound)
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.ForProduct(fixture.ctx, fixture.idProduct, fixture.idUser, tt.limit)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestForBasket_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		limit       int
+		setupMocks  func(*mock_structs.MockRecommendationRepository)
+		expectedRet []structs.Recommendation
+		expectedErr error
+	}{
+		{
+			name:  "successful get",
+			limit: 3,
+			setupMocks: func(mockRepo *mock_structs.MockRecommendationRepository) {
+				mockRepo.EXPECT().ForBasket(fixture.ctx, fixture.idUser, 3).Return(fixture.recommendations, nil)
+			},
+			expectedRet: fixture.recommendations,
+			expectedErr: nil,
+		},
+		{
+			name:  "repository error",
+			limit: -1,
+			setupMocks: func(mockRepo *mock_structs.MockRecommendationRepository) {
+				mockRepo.EXPECT().ForBasket(fixture.ctx, fixture.idUser, defaultLimit).Return(nil, errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.ForBasket(fixture.ctx, fixture.idUser, tt.limit)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
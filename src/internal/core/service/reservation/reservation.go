@@ -0,0 +1,49 @@
+package reservation
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type ReservationService interface {
+	Reserve(ctx context.Context, id_user uuid.UUID) ([]structs.Reservation, error)
+	Release(ctx context.Context, id_user uuid.UUID) error
+	Sweep(ctx context.Context) (int64, error)
+}
+
+type ReservationRepository interface {
+	Reserve(ctx context.Context, id_user uuid.UUID, ttl time.Duration) ([]structs.Reservation, error)
+	Release(ctx context.Context, id_user uuid.UUID) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type Service struct {
+	rep ReservationRepository
+	ttl time.Duration
+}
+
+func New(rep ReservationRepository, ttl time.Duration) *Service {
+	return &Service{rep: rep, ttl: ttl}
+}
+
+// Reserve starts checkout: the whole basket of the user is held for the
+// configured time, replacing the holds of an earlier attempt. It fails with
+// ErrInsufficientStock when stock not held by other customers does not
+// cover an item.
+func (s *Service) Reserve(ctx context.Context, id_user uuid.UUID) ([]structs.Reservation, error) {
+	return s.rep.Reserve(ctx, id_user, s.ttl)
+}
+
+// Release drops the holds of the user when checkout is abandoned.
+func (s *Service) Release(ctx context.Context, id_user uuid.UUID) error {
+	return s.rep.Release(ctx, id_user)
+}
+
+// Sweep deletes expired holds. Expired holds are already ignored when stock
+// is computed, so sweeping only keeps the table small.
+func (s *Service) Sweep(ctx context.Context) (int64, error) {
+	return s.rep.DeleteExpired(ctx)
+}
@@ -0,0 +1,81 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t      *testing.T
+	ctrl   *gomock.Controller
+	ctx    context.Context
+	ttl    time.Duration
+	idUser uuid.UUID
+	holds  []structs.Reservation
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	id_user := structs.GenId()
+	expires := time.Date(2026, 3, 1, 12, 15, 0, 0, time.UTC)
+
+	return &TestFixture{
+		t:      t,
+		ctrl:   ctrl,
+		ctx:    context.Background(),
+		ttl:    15 * time.Minute,
+		idUser: id_user,
+		holds: []structs.Reservation{
+			{
+				Id:        structs.GenId(),
+				IdUser:    id_user,
+				IdProduct: structs.GenId(),
+				Amount:    2,
+				ExpiresAt: expires,
+			},
+			{
+				Id:        structs.GenId(),
+				IdUser:    id_user,
+				IdProduct: structs.GenId(),
+				IdVariant: structs.GenId(),
+				Amount:    1,
+				ExpiresAt: expires,
+			},
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockReservationRepository) {
+	mockRepo := mock_structs.NewMockReservationRepository(f.ctrl)
+
+	service := New(mockRepo, f.ttl)
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
@@ -0,0 +1,136 @@
+package reservation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestReserve_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockReservationRepository)
+		expectedRet []structs.Reservation
+		expectedErr error
+	}{
+		{
+			name: "successful reserve",
+			setupMocks: func(mockRepo *mock_structs.MockReservationRepository) {
+				mockRepo.EXPECT().Reserve(fixture.ctx, fixture.idUser, fixture.ttl).Return(fixture.holds, nil)
+			},
+			expectedRet: fixture.holds,
+			expectedErr: nil,
+		},
+		{
+			name: "empty basket",
+			setupMocks: func(mockRepo *mock_structs.MockReservationRepository) {
+				mockRepo.EXPECT().Reserve(fixture.ctx, fixture.idUser, fixture.ttl).Return(nil, structs.ErrEmptyBasket)
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrEmptyBasket,
+		},
+		{
+			name: "not enough stock",
+			setupMocks: func(mockRepo *mock_structs.MockReservationRepository) {
+				mockRepo.EXPECT().Reserve(fixture.ctx, fixture.idUser, fixture.ttl).Return(nil, structs.ErrInsufficientStock)
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrInsufficientStock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Reserve(fixture.ctx, fixture.idUser)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestRelease_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockReservationRepository)
+		expectedErr error
+	}{
+		{
+			name: "successful release",
+			setupMocks: func(mockRepo *mock_structs.MockReservationRepository) {
+				mockRepo.EXPECT().Release(fixture.ctx, fixture.idUser).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockReservationRepository) {
+				mockRepo.EXPECT().Release(fixture.ctx, fixture.idUser).Return(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.Release(fixture.ctx, fixture.idUser)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSweep_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockReservationRepository)
+		expectedRet int64
+		expectedErr error
+	}{
+		{
+			name: "successful sweep",
+			setupMocks: func(mockRepo *mock_structs.MockReservationRepository) {
+				mockRepo.EXPECT().DeleteExpired(fixture.ctx).Return(int64(3), nil)
+			},
+			expectedRet: 3,
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockReservationRepository) {
+				mockRepo.EXPECT().DeleteExpired(fixture.ctx).Return(int64(0), errTest)
+			},
+			expectedRet: 0,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Sweep(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
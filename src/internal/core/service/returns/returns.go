@@ -0,0 +1,363 @@
+package returns
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+const (
+	MaxPhotoSize = 10 << 20
+	MaxPhotos    = 5
+	maxText      = 500
+)
+
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+type ReturnService interface {
+	Create(ctx context.Context, r structs.ReturnRequest) (uuid.UUID, error)
+	AddPhotos(ctx context.Context, id uuid.UUID, id_user uuid.UUID, files []structs.ImageFile) ([]structs.ReturnPhoto, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.ReturnRequest, error)
+	GetOwn(ctx context.Context, id uuid.UUID, id_user uuid.UUID) (structs.ReturnRequest, error)
+	GetByUser(ctx context.Context, id_user uuid.UUID) ([]structs.ReturnRequest, error)
+	GetAll(ctx context.Context, status string) ([]structs.ReturnRequest, error)
+	Approve(ctx context.Context, id uuid.UUID, id_admin uuid.UUID) error
+	Reject(ctx context.Context, id uuid.UUID, id_admin uuid.UUID, comment string) error
+	Receive(ctx context.Context, rc structs.ReturnReceipt) (structs.ReturnRequest, error)
+}
+
+type ReturnRepository interface {
+	Create(ctx context.Context, r structs.ReturnRequest) (uuid.UUID, error)
+	AddPhotos(ctx context.Context, id uuid.UUID, photos []structs.ReturnPhoto, max int) error
+	GetById(ctx context.Context, id uuid.UUID) (structs.ReturnRequest, error)
+	GetByUser(ctx context.Context, id_user uuid.UUID) ([]structs.ReturnRequest, error)
+	GetAll(ctx context.Context, status string) ([]structs.ReturnRequest, error)
+	Decide(ctx context.Context, id uuid.UUID, status string, id_admin uuid.UUID, comment string) error
+	GetRestocked(ctx context.Context, id_order uuid.UUID) (map[uuid.UUID]int, error)
+	Receive(ctx context.Context, seen structs.ReturnRequest, rc structs.ReturnReceipt,
+		ms []structs.StockMovement, refund structs.Refund) error
+}
+
+// OrderReader gives the returns the orders they are made against.
+type OrderReader interface {
+	GetById(ctx context.Context, id uuid.UUID) (structs.Order, error)
+	GetItems(ctx context.Context, id uuid.UUID) ([]structs.OrderItem, error)
+	GetHistory(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID) ([]structs.OrderStatusChange, error)
+}
+
+type ReturnStorage interface {
+	Save(ctx context.Context, key string, r io.Reader) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+type Service struct {
+	rep     ReturnRepository
+	orders  OrderReader
+	storage ReturnStorage
+	window  time.Duration
+	now     func() time.Time
+}
+
+// New creates the service; window is the time after an order is handed
+// over in which its goods may be returned.
+func New(rep ReturnRepository, orders OrderReader, storage ReturnStorage, window time.Duration) *Service {
+	return &Service{rep: rep, orders: orders, storage: storage, window: window, now: time.Now}
+}
+
+// Create opens a return of lines of an order of the user. The order must be
+// handed over within the return window, which starts when the order
+// was handed over. Every order line may appear once; the repository
+// rejects the return with ErrReturnAmountExceeded when it would return
+// more of a line than was bought, counting the returns not rejected.
+func (s *Service) Create(ctx context.Context, r structs.ReturnRequest) (uuid.UUID, error) {
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" || utf8.RuneCountInString(r.Reason) > maxText || len(r.Lines) == 0 {
+		return uuid.Nil, structs.ErrInvalidReturn
+	}
+
+	o, err := s.orders.GetById(ctx, r.IdOrder)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if o.IdUser != r.IdUser {
+		return uuid.Nil, structs.ErrOrderNotFound
+	}
+	if o.Status != structs.OrderShipped {
+		return uuid.Nil, structs.ErrReturnNotAllowed
+	}
+	history, err := s.orders.GetHistory(ctx, r.IdOrder, r.IdUser)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	shipped := o.Date
+	for _, ch := range history {
+		if ch.To == structs.OrderShipped {
+			shipped = ch.CreatedAt
+		}
+	}
+	if s.now().After(shipped.Add(s.window)) {
+		return uuid.Nil, structs.ErrReturnWindowClosed
+	}
+
+	items, err := s.orders.GetItems(ctx, r.IdOrder)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	byId := make(map[uuid.UUID]structs.OrderItem, len(items))
+	for _, it := range items {
+		byId[it.Id] = it
+	}
+	seen := make(map[uuid.UUID]bool, len(r.Lines))
+	for i, l := range r.Lines {
+		it, ok := byId[l.IdOrderItem]
+		if !ok || seen[l.IdOrderItem] || l.Amount <= 0 {
+			return uuid.Nil, structs.ErrInvalidReturn
+		}
+		if l.Amount > it.Amount {
+			return uuid.Nil, structs.ErrReturnAmountExceeded
+		}
+		seen[l.IdOrderItem] = true
+		r.Lines[i] = structs.ReturnLine{IdOrderItem: it.Id, IdProduct: it.IdProduct, IdVariant: it.IdVariant, Amount: l.Amount}
+	}
+
+	r.Status = structs.ReturnRequested
+	r.Comment = ""
+	return s.rep.Create(ctx, r)
+}
+
+// AddPhotos attaches photos of the goods to a return of the user that is
+// not decided yet. All files are checked before anything is written, and
+// files already written are removed again if the database insert fails.
+func (s *Service) AddPhotos(ctx context.Context, id uuid.UUID, id_user uuid.UUID, files []structs.ImageFile) ([]structs.ReturnPhoto, error) {
+	if len(files) == 0 {
+		return nil, structs.ErrNoImages
+	}
+	if len(files) > MaxPhotos {
+		return nil, structs.ErrTooManyReturnPhotos
+	}
+	types := make([]string, len(files))
+	for i, f := range files {
+		if len(f.Data) > MaxPhotoSize {
+			return nil, structs.ErrImageTooLarge
+		}
+		types[i] = http.DetectContentType(f.Data)
+		if _, ok := photoExtensions[types[i]]; !ok {
+			return nil, structs.ErrUnsupportedImageType
+		}
+	}
+
+	r, err := s.GetOwn(ctx, id, id_user)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != structs.ReturnRequested {
+		return nil, structs.ErrReturnStatus
+	}
+	if len(r.Photos)+len(files) > MaxPhotos {
+		return nil, structs.ErrTooManyReturnPhotos
+	}
+
+	var saved []string
+	photos := make([]structs.ReturnPhoto, 0, len(files))
+	for i, f := range files {
+		p := structs.ReturnPhoto{
+			Id:          structs.GenId(),
+			IdReturn:    id,
+			ContentType: types[i],
+			Size:        int64(len(f.Data)),
+			CreatedAt:   s.now(),
+		}
+		p.Key = path.Join("returns", id.String(), p.Id.String()+photoExtensions[types[i]])
+		if err := s.storage.Save(ctx, p.Key, bytes.NewReader(f.Data)); err != nil {
+			s.cleanup(ctx, saved)
+			return nil, err
+		}
+		saved = append(saved, p.Key)
+		p.URL = s.storage.URL(p.Key)
+		photos = append(photos, p)
+	}
+
+	if err := s.rep.AddPhotos(ctx, id, photos, MaxPhotos); err != nil {
+		s.cleanup(ctx, saved)
+		return nil, err
+	}
+	return photos, nil
+}
+
+func (s *Service) cleanup(ctx context.Context, keys []string) {
+	for _, k := range keys {
+		_ = s.storage.Delete(ctx, k)
+	}
+}
+
+func (s *Service) GetById(ctx context.Context, id uuid.UUID) (structs.ReturnRequest, error) {
+	r, err := s.rep.GetById(ctx, id)
+	if err != nil {
+		return structs.ReturnRequest{}, err
+	}
+	for i := range r.Photos {
+		r.Photos[i].URL = s.storage.URL(r.Photos[i].Key)
+	}
+	return r, nil
+}
+
+// GetOwn returns a return of the user, returns of other users are reported
+// as not found.
+func (s *Service) GetOwn(ctx context.Context, id uuid.UUID, id_user uuid.UUID) (structs.ReturnRequest, error) {
+	r, err := s.GetById(ctx, id)
+	if err != nil {
+		return structs.ReturnRequest{}, err
+	}
+	if r.IdUser != id_user {
+		return structs.ReturnRequest{}, structs.ErrReturnNotFound
+	}
+	return r, nil
+}
+
+func (s *Service) GetByUser(ctx context.Context, id_user uuid.UUID) ([]structs.ReturnRequest, error) {
+	return s.rep.GetByUser(ctx, id_user)
+}
+
+func (s *Service) GetAll(ctx context.Context, status string) ([]structs.ReturnRequest, error) {
+	if status != "" && !slices.Contains(structs.ReturnStatuses, status) {
+		return nil, structs.ErrInvalidReturn
+	}
+	return s.rep.GetAll(ctx, status)
+}
+
+// Approve lets the customer send the goods of a requested return.
+func (s *Service) Approve(ctx context.Context, id uuid.UUID, id_admin uuid.UUID) error {
+	return s.rep.Decide(ctx, id, structs.ReturnApproved, id_admin, "")
+}
+
+// Reject closes a requested return, the reason is shown to the customer.
+// The goods of a rejected return may be returned again.
+func (s *Service) Reject(ctx context.Context, id uuid.UUID, id_admin uuid.UUID, comment string) error {
+	comment = strings.TrimSpace(comment)
+	if comment == "" || utf8.RuneCountInString(comment) > maxText {
+		return structs.ErrInvalidReturn
+	}
+	return s.rep.Decide(ctx, id, structs.ReturnRejected, id_admin, comment)
+}
+
+// Receive books the goods of an approved return and closes it. Sellable
+// goods go back to stock: to the lots the order line was sold from, latest
+// expiry first and skipping what earlier returns put back, the rest to the
+// stock without a lot; goods of products removed from the catalog are not
+// restocked. The refund is the price paid for every received unit, damaged
+// ones included. The repository rejects the receipt with
+// ErrReturnChanged when the return was received since it was read.
+func (s *Service) Receive(ctx context.Context, rc structs.ReturnReceipt) (structs.ReturnRequest, error) {
+	r, err := s.rep.GetById(ctx, rc.IdReturn)
+	if err != nil {
+		return structs.ReturnRequest{}, err
+	}
+	if r.Status != structs.ReturnApproved {
+		return structs.ReturnRequest{}, structs.ErrReturnStatus
+	}
+
+	received := make(map[uuid.UUID]structs.ReceivedReturnLine, len(rc.Lines))
+	for _, l := range rc.Lines {
+		if _, ok := received[l.IdLine]; ok || l.Received < 0 {
+			return structs.ReturnRequest{}, structs.ErrInvalidReturn
+		}
+		if l.Condition != structs.ReturnSellable && l.Condition != structs.ReturnDamaged {
+			return structs.ReturnRequest{}, structs.ErrInvalidReturn
+		}
+		received[l.IdLine] = l
+	}
+	if len(received) != len(r.Lines) {
+		return structs.ReturnRequest{}, structs.ErrInvalidReturn
+	}
+
+	items, err := s.orders.GetItems(ctx, r.IdOrder)
+	if err != nil {
+		return structs.ReturnRequest{}, err
+	}
+	byId := make(map[uuid.UUID]structs.OrderItem, len(items))
+	for _, it := range items {
+		byId[it.Id] = it
+	}
+	restocked, err := s.rep.GetRestocked(ctx, r.IdOrder)
+	if err != nil {
+		return structs.ReturnRequest{}, err
+	}
+
+	refund := structs.Refund{IdReturn: r.Id, IdOrder: r.IdOrder, Status: structs.RefundPending}
+	var ms []structs.StockMovement
+	for _, l := range r.Lines {
+		got, ok := received[l.Id]
+		if !ok || got.Received > l.Amount {
+			return structs.ReturnRequest{}, structs.ErrInvalidReturn
+		}
+		it := byId[l.IdOrderItem]
+		if it.Amount > 0 {
+			refund.Amount += (it.Price*float64(it.Amount) - it.Discount) / float64(it.Amount) * float64(got.Received)
+		}
+		if got.Condition == structs.ReturnSellable && got.Received > 0 && it.IdProduct != uuid.Nil {
+			ms = append(ms, restock(it, restocked[it.Id], got.Received, rc.IdWorker, r.Id)...)
+		}
+	}
+	refund.Amount = math.Round(refund.Amount*100) / 100
+	// Stock rows are locked in the same order as at checkout.
+	slices.SortStableFunc(ms, func(a, b structs.StockMovement) int {
+		return cmp.Or(strings.Compare(a.IdProduct.String(), b.IdProduct.String()),
+			strings.Compare(a.IdVariant.String(), b.IdVariant.String()),
+			strings.Compare(a.IdLot.String(), b.IdLot.String()))
+	})
+
+	if err := s.rep.Receive(ctx, r, rc, ms, refund); err != nil {
+		return structs.ReturnRequest{}, err
+	}
+	return s.GetById(ctx, r.Id)
+}
+
+// restock splits amount units of the order line into return movements.
+// skip units were already put back by earlier returns.
+func restock(it structs.OrderItem, skip int, amount int, id_worker uuid.UUID, id_return uuid.UUID) []structs.StockMovement {
+	lots := slices.Clone(it.Lots)
+	slices.SortStableFunc(lots, func(a, b structs.OrderItemLot) int { return b.ExpiresAt.Compare(a.ExpiresAt) })
+
+	move := func(id_lot uuid.UUID, n int) structs.StockMovement {
+		return structs.StockMovement{
+			IdProduct: it.IdProduct,
+			IdVariant: it.IdVariant,
+			IdLot:     id_lot,
+			Kind:      structs.StockReturn,
+			Quantity:  n,
+			Reason:    "возврат покупателя",
+			IdActor:   id_worker,
+			Reference: id_return.String(),
+		}
+	}
+	var ms []structs.StockMovement
+	for _, l := range lots {
+		free := l.Amount - skip
+		skip = max(0, skip-l.Amount)
+		if free <= 0 {
+			continue
+		}
+		n := min(free, amount)
+		ms = append(ms, move(l.IdLot, n))
+		amount -= n
+		if amount == 0 {
+			return ms
+		}
+	}
+	return append(ms, move(uuid.Nil, amount))
+}
@@ -0,0 +1,118 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t       *testing.T
+	ctrl    *gomock.Controller
+	ctx     context.Context
+	now     time.Time
+	window  time.Duration
+	order   structs.Order
+	items   []structs.OrderItem
+	history []structs.OrderStatusChange
+	ret     structs.ReturnRequest
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	order := structs.Order{
+		Id:     structs.GenId(),
+		Date:   now.AddDate(0, 0, -7),
+		IdUser: structs.GenId(),
+		Status: structs.OrderShipped,
+		Price:  2500,
+	}
+	items := []structs.OrderItem{
+		{
+			Id:        structs.GenId(),
+			IdProduct: structs.GenId(),
+			IdOrder:   order.Id,
+			Amount:    3,
+			Price:     1000,
+			Discount:  300,
+			Lots: []structs.OrderItemLot{
+				{IdLot: structs.GenId(), ExpiresAt: time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC), Amount: 1},
+				{IdLot: structs.GenId(), ExpiresAt: time.Date(2027, 6, 30, 0, 0, 0, 0, time.UTC), Amount: 2},
+			},
+		},
+		{
+			Id:        structs.GenId(),
+			IdProduct: structs.GenId(),
+			IdVariant: structs.GenId(),
+			IdOrder:   order.Id,
+			Amount:    1,
+			Price:     200,
+		},
+	}
+
+	return &TestFixture{
+		t:      t,
+		ctrl:   ctrl,
+		ctx:    context.Background(),
+		now:    now,
+		window: 14 * 24 * time.Hour,
+		order:  order,
+		items:  items,
+		history: []structs.OrderStatusChange{
+			{IdOrder: order.Id, To: structs.OrderNew, CreatedAt: order.Date},
+			{IdOrder: order.Id, From: structs.OrderAssembled, To: structs.OrderShipped, CreatedAt: now.AddDate(0, 0, -5)},
+		},
+		ret: structs.ReturnRequest{
+			Id:      structs.GenId(),
+			IdOrder: order.Id,
+			IdUser:  order.IdUser,
+			Status:  structs.ReturnApproved,
+			Reason:  "не подошел оттенок",
+			Lines: []structs.ReturnLine{
+				{Id: structs.GenId(), IdOrderItem: items[0].Id, IdProduct: items[0].IdProduct, Amount: 2},
+				{Id: structs.GenId(), IdOrderItem: items[1].Id, IdProduct: items[1].IdProduct, IdVariant: items[1].IdVariant, Amount: 1},
+			},
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockReturnRepository,
+	*mock_structs.MockOrderReader, *mock_structs.MockReturnStorage) {
+	mockRepo := mock_structs.NewMockReturnRepository(f.ctrl)
+	mockOrders := mock_structs.NewMockOrderReader(f.ctrl)
+	mockStorage := mock_structs.NewMockReturnStorage(f.ctrl)
+	mockStorage.EXPECT().URL(gomock.Any()).DoAndReturn(func(key string) string {
+		return "/media/" + key
+	}).AnyTimes()
+
+	service := New(mockRepo, mockOrders, mockStorage, f.window)
+	service.now = func() time.Time { return f.now }
+	return service, mockRepo, mockOrders, mockStorage
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
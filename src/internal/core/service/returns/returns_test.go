@@ -0,0 +1,516 @@
+package returns
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"slices"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	id := structs.GenId()
+	request := func(lines ...structs.ReturnLine) structs.ReturnRequest {
+		return structs.ReturnRequest{IdOrder: fixture.order.Id, IdUser: fixture.order.IdUser, Reason: " не подошел оттенок ", Lines: lines}
+	}
+	line := structs.ReturnLine{IdOrderItem: fixture.items[0].Id, Amount: 2}
+	// The order is read back without its id so the follow-up lookups
+	// have to go by the id of the request.
+	read := fixture.order
+	read.Id = uuid.Nil
+	readOrder := func(mockOrders *mock_structs.MockOrderReader) {
+		mockOrders.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(read, nil)
+		mockOrders.EXPECT().GetHistory(fixture.ctx, fixture.order.Id, fixture.order.IdUser).Return(fixture.history, nil)
+		mockOrders.EXPECT().GetItems(fixture.ctx, fixture.order.Id).Return(fixture.items, nil)
+	}
+
+	tests := []struct {
+		name        string
+		request     structs.ReturnRequest
+		setupMocks  func(*mock_structs.MockReturnRepository, *mock_structs.MockOrderReader)
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:    "successful creation",
+			request: request(line),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				readOrder(mockOrders)
+				mockRepo.EXPECT().Create(fixture.ctx, structs.ReturnRequest{
+					IdOrder: fixture.order.Id,
+					IdUser:  fixture.order.IdUser,
+					Status:  structs.ReturnRequested,
+					Reason:  "не подошел оттенок",
+					Lines: []structs.ReturnLine{
+						{IdOrderItem: fixture.items[0].Id, IdProduct: fixture.items[0].IdProduct, Amount: 2},
+					},
+				}).Return(id, nil)
+			},
+			expectedRet: id,
+			expectedErr: nil,
+		},
+		{
+			name:        "no reason",
+			request:     structs.ReturnRequest{IdOrder: fixture.order.Id, IdUser: fixture.order.IdUser, Reason: "  ", Lines: []structs.ReturnLine{line}},
+			setupMocks:  func(*mock_structs.MockReturnRepository, *mock_structs.MockOrderReader) {},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidReturn,
+		},
+		{
+			name:    "order of another user",
+			request: structs.ReturnRequest{IdOrder: fixture.order.Id, IdUser: structs.GenId(), Reason: "брак", Lines: []structs.ReturnLine{line}},
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				mockOrders.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrOrderNotFound,
+		},
+		{
+			name:    "order not handed over",
+			request: request(line),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				o := fixture.order
+				o.Status = structs.OrderAssembled
+				mockOrders.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(o, nil)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrReturnNotAllowed,
+		},
+		{
+			name:    "return window closed",
+			request: request(line),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				history := []structs.OrderStatusChange{
+					{IdOrder: fixture.order.Id, To: structs.OrderShipped, CreatedAt: fixture.now.Add(-fixture.window).Add(-1)},
+				}
+				mockOrders.EXPECT().GetById(fixture.ctx, fixture.order.Id).Return(fixture.order, nil)
+				mockOrders.EXPECT().GetHistory(fixture.ctx, fixture.order.Id, fixture.order.IdUser).Return(history, nil)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrReturnWindowClosed,
+		},
+		{
+			name:    "line of another order",
+			request: request(structs.ReturnLine{IdOrderItem: structs.GenId(), Amount: 1}),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				readOrder(mockOrders)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidReturn,
+		},
+		{
+			name:    "line listed twice",
+			request: request(line, line),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				readOrder(mockOrders)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrInvalidReturn,
+		},
+		{
+			name:    "more than bought",
+			request: request(structs.ReturnLine{IdOrderItem: fixture.items[1].Id, Amount: 2}),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				readOrder(mockOrders)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrReturnAmountExceeded,
+		},
+		{
+			name:    "repository error",
+			request: request(line),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				readOrder(mockOrders)
+				mockRepo.EXPECT().Create(fixture.ctx, gomock.Any()).Return(uuid.Nil, structs.ErrReturnAmountExceeded)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrReturnAmountExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, mockOrders, _ := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo, mockOrders)
+
+			ret, err := service.Create(fixture.ctx, tt.request)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestAddPhotos_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	photo := structs.ImageFile{Name: "box.png", Data: buf.Bytes()}
+	text := structs.ImageFile{Name: "notes.png", Data: []byte("definitely not an image")}
+	requested := fixture.ret
+	requested.Status = structs.ReturnRequested
+
+	tests := []struct {
+		name        string
+		idUser      uuid.UUID
+		files       []structs.ImageFile
+		setupMocks  func(*mock_structs.MockReturnRepository, *mock_structs.MockReturnStorage)
+		expectedErr error
+	}{
+		{
+			name:   "successful upload",
+			idUser: fixture.ret.IdUser,
+			files:  []structs.ImageFile{photo},
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockStorage *mock_structs.MockReturnStorage) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(requested, nil)
+				mockStorage.EXPECT().Save(fixture.ctx, gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().AddPhotos(fixture.ctx, fixture.ret.Id, gomock.Any(), MaxPhotos).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "not an image",
+			idUser:      fixture.ret.IdUser,
+			files:       []structs.ImageFile{text},
+			setupMocks:  func(*mock_structs.MockReturnRepository, *mock_structs.MockReturnStorage) {},
+			expectedErr: structs.ErrUnsupportedImageType,
+		},
+		{
+			name:   "return of another user",
+			idUser: structs.GenId(),
+			files:  []structs.ImageFile{photo},
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockStorage *mock_structs.MockReturnStorage) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(requested, nil)
+			},
+			expectedErr: structs.ErrReturnNotFound,
+		},
+		{
+			name:   "return already decided",
+			idUser: fixture.ret.IdUser,
+			files:  []structs.ImageFile{photo},
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockStorage *mock_structs.MockReturnStorage) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+			},
+			expectedErr: structs.ErrReturnStatus,
+		},
+		{
+			name:   "too many photos",
+			idUser: fixture.ret.IdUser,
+			files:  []structs.ImageFile{photo, photo},
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockStorage *mock_structs.MockReturnStorage) {
+				r := requested
+				r.Photos = make([]structs.ReturnPhoto, MaxPhotos-1)
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(r, nil)
+			},
+			expectedErr: structs.ErrTooManyReturnPhotos,
+		},
+		{
+			name:   "saved files removed on repository error",
+			idUser: fixture.ret.IdUser,
+			files:  []structs.ImageFile{photo, photo},
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockStorage *mock_structs.MockReturnStorage) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(requested, nil)
+				mockStorage.EXPECT().Save(fixture.ctx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				mockRepo.EXPECT().AddPhotos(fixture.ctx, fixture.ret.Id, gomock.Any(), MaxPhotos).Return(errTest)
+				mockStorage.EXPECT().Delete(fixture.ctx, gomock.Any()).Return(nil).Times(2)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _, mockStorage := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo, mockStorage)
+
+			photos, err := service.AddPhotos(fixture.ctx, fixture.ret.Id, tt.idUser, tt.files)
+
+			fixture.AssertError(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.Len(t, photos, len(tt.files))
+				p := photos[0]
+				assert.Equal(t, "image/png", p.ContentType)
+				assert.Equal(t, "returns/"+fixture.ret.Id.String()+"/"+p.Id.String()+".png", p.Key)
+				assert.Equal(t, "/media/"+p.Key, p.URL)
+			}
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestReject_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	idAdmin := structs.GenId()
+
+	tests := []struct {
+		name        string
+		comment     string
+		setupMocks  func(*mock_structs.MockReturnRepository)
+		expectedErr error
+	}{
+		{
+			name:    "successful reject",
+			comment: " следы использования ",
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository) {
+				mockRepo.EXPECT().Decide(fixture.ctx, fixture.ret.Id, structs.ReturnRejected, idAdmin, "следы использования").Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "no reason",
+			comment:     " ",
+			setupMocks:  func(*mock_structs.MockReturnRepository) {},
+			expectedErr: structs.ErrInvalidReturn,
+		},
+		{
+			name:    "already decided",
+			comment: "поздно",
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository) {
+				mockRepo.EXPECT().Decide(fixture.ctx, fixture.ret.Id, structs.ReturnRejected, idAdmin, "поздно").Return(structs.ErrReturnStatus)
+			},
+			expectedErr: structs.ErrReturnStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _, _ := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			err := service.Reject(fixture.ctx, fixture.ret.Id, idAdmin, tt.comment)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestReceive_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	idWorker := structs.GenId()
+	item := fixture.items[0]
+	early, late := item.Lots[0], item.Lots[1]
+	receipt := func(lines ...structs.ReceivedReturnLine) structs.ReturnReceipt {
+		return structs.ReturnReceipt{IdReturn: fixture.ret.Id, IdWorker: idWorker, Lines: lines}
+	}
+	cream := fixture.ret.Lines[0]
+	serum := fixture.ret.Lines[1]
+	movement := func(id_lot uuid.UUID, n int) structs.StockMovement {
+		return structs.StockMovement{
+			IdProduct: item.IdProduct,
+			IdLot:     id_lot,
+			Kind:      structs.StockReturn,
+			Quantity:  n,
+			Reason:    "возврат покупателя",
+			IdActor:   idWorker,
+			Reference: fixture.ret.Id.String(),
+		}
+	}
+	removed := slices.Clone(fixture.items)
+	removed[0].IdProduct = uuid.Nil
+
+	tests := []struct {
+		name        string
+		receipt     structs.ReturnReceipt
+		setupMocks  func(*mock_structs.MockReturnRepository, *mock_structs.MockOrderReader)
+		expectedErr error
+	}{
+		{
+			name: "sellable goods go to the latest lot",
+			receipt: receipt(
+				structs.ReceivedReturnLine{IdLine: cream.Id, Received: 2, Condition: structs.ReturnSellable},
+				structs.ReceivedReturnLine{IdLine: serum.Id, Received: 1, Condition: structs.ReturnDamaged},
+			),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+				mockOrders.EXPECT().GetItems(fixture.ctx, fixture.order.Id).Return(fixture.items, nil)
+				mockRepo.EXPECT().GetRestocked(fixture.ctx, fixture.order.Id).Return(nil, nil)
+				mockRepo.EXPECT().Receive(fixture.ctx, fixture.ret, gomock.Any(),
+					[]structs.StockMovement{movement(late.IdLot, 2)},
+					structs.Refund{IdReturn: fixture.ret.Id, IdOrder: fixture.order.Id, Amount: 2000, Status: structs.RefundPending},
+				).Return(nil)
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "goods of a removed product are not restocked",
+			receipt: receipt(
+				structs.ReceivedReturnLine{IdLine: cream.Id, Received: 2, Condition: structs.ReturnSellable},
+				structs.ReceivedReturnLine{IdLine: serum.Id, Received: 0, Condition: structs.ReturnSellable},
+			),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+				mockOrders.EXPECT().GetItems(fixture.ctx, fixture.order.Id).Return(removed, nil)
+				mockRepo.EXPECT().GetRestocked(fixture.ctx, fixture.order.Id).Return(nil, nil)
+				mockRepo.EXPECT().Receive(fixture.ctx, fixture.ret, gomock.Any(), gomock.Nil(),
+					structs.Refund{IdReturn: fixture.ret.Id, IdOrder: fixture.order.Id, Amount: 1800, Status: structs.RefundPending},
+				).Return(nil)
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "earlier returns are skipped",
+			receipt: receipt(
+				structs.ReceivedReturnLine{IdLine: cream.Id, Received: 2, Condition: structs.ReturnSellable},
+				structs.ReceivedReturnLine{IdLine: serum.Id, Received: 0, Condition: structs.ReturnSellable},
+			),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+				mockOrders.EXPECT().GetItems(fixture.ctx, fixture.order.Id).Return(fixture.items, nil)
+				mockRepo.EXPECT().GetRestocked(fixture.ctx, fixture.order.Id).Return(map[uuid.UUID]int{item.Id: 1}, nil)
+				mockRepo.EXPECT().Receive(fixture.ctx, fixture.ret, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_, _, _ any, ms []structs.StockMovement, refund structs.Refund) error {
+						assert.ElementsMatch(t, []structs.StockMovement{movement(late.IdLot, 1), movement(early.IdLot, 1)}, ms)
+						assert.Equal(t, 1800.0, refund.Amount)
+						return nil
+					})
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "goods beyond the lots go to stock without a lot",
+			receipt: receipt(
+				structs.ReceivedReturnLine{IdLine: cream.Id, Received: 2, Condition: structs.ReturnSellable},
+				structs.ReceivedReturnLine{IdLine: serum.Id, Received: 0, Condition: structs.ReturnSellable},
+			),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+				mockOrders.EXPECT().GetItems(fixture.ctx, fixture.order.Id).Return(fixture.items, nil)
+				mockRepo.EXPECT().GetRestocked(fixture.ctx, fixture.order.Id).Return(map[uuid.UUID]int{item.Id: 2}, nil)
+				mockRepo.EXPECT().Receive(fixture.ctx, fixture.ret, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_, _, _ any, ms []structs.StockMovement, _ structs.Refund) error {
+						assert.ElementsMatch(t, []structs.StockMovement{movement(early.IdLot, 1), movement(uuid.Nil, 1)}, ms)
+						return nil
+					})
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "return not approved",
+			receipt: receipt(
+				structs.ReceivedReturnLine{IdLine: cream.Id, Received: 2, Condition: structs.ReturnSellable},
+			),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				r := fixture.ret
+				r.Status = structs.ReturnRequested
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(r, nil)
+			},
+			expectedErr: structs.ErrReturnStatus,
+		},
+		{
+			name: "line missing",
+			receipt: receipt(
+				structs.ReceivedReturnLine{IdLine: cream.Id, Received: 2, Condition: structs.ReturnSellable},
+			),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+			},
+			expectedErr: structs.ErrInvalidReturn,
+		},
+		{
+			name: "unknown condition",
+			receipt: receipt(
+				structs.ReceivedReturnLine{IdLine: cream.Id, Received: 2, Condition: "used"},
+				structs.ReceivedReturnLine{IdLine: serum.Id, Received: 1, Condition: structs.ReturnSellable},
+			),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+			},
+			expectedErr: structs.ErrInvalidReturn,
+		},
+		{
+			name: "more received than returned",
+			receipt: receipt(
+				structs.ReceivedReturnLine{IdLine: cream.Id, Received: 3, Condition: structs.ReturnSellable},
+				structs.ReceivedReturnLine{IdLine: serum.Id, Received: 1, Condition: structs.ReturnSellable},
+			),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+				mockOrders.EXPECT().GetItems(fixture.ctx, fixture.order.Id).Return(fixture.items, nil)
+				mockRepo.EXPECT().GetRestocked(fixture.ctx, fixture.order.Id).Return(nil, nil)
+			},
+			expectedErr: structs.ErrInvalidReturn,
+		},
+		{
+			name: "received meanwhile",
+			receipt: receipt(
+				structs.ReceivedReturnLine{IdLine: cream.Id, Received: 2, Condition: structs.ReturnSellable},
+				structs.ReceivedReturnLine{IdLine: serum.Id, Received: 1, Condition: structs.ReturnSellable},
+			),
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository, mockOrders *mock_structs.MockOrderReader) {
+				mockRepo.EXPECT().GetById(fixture.ctx, fixture.ret.Id).Return(fixture.ret, nil)
+				mockOrders.EXPECT().GetItems(fixture.ctx, fixture.order.Id).Return(fixture.items, nil)
+				mockRepo.EXPECT().GetRestocked(fixture.ctx, fixture.order.Id).Return(nil, nil)
+				mockRepo.EXPECT().Receive(fixture.ctx, fixture.ret, gomock.Any(), gomock.Any(), gomock.Any()).Return(structs.ErrReturnChanged)
+			},
+			expectedErr: structs.ErrReturnChanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, mockOrders, _ := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo, mockOrders)
+
+			_, err := service.Receive(fixture.ctx, tt.receipt)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetAll_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		status      string
+		setupMocks  func(*mock_structs.MockReturnRepository)
+		expectedRet []structs.ReturnRequest
+		expectedErr error
+	}{
+		{
+			name:   "filtered by status",
+			status: structs.ReturnApproved,
+			setupMocks: func(mockRepo *mock_structs.MockReturnRepository) {
+				mockRepo.EXPECT().GetAll(fixture.ctx, structs.ReturnApproved).Return([]structs.ReturnRequest{fixture.ret}, nil)
+			},
+			expectedRet: []structs.ReturnRequest{fixture.ret},
+			expectedErr: nil,
+		},
+		{
+			name:        "unknown status",
+			status:      "lost",
+			setupMocks:  func(*mock_structs.MockReturnRepository) {},
+			expectedRet: nil,
+			expectedErr: structs.ErrInvalidReturn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _, _ := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.GetAll(fixture.ctx, tt.status)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
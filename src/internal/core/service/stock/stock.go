@@ -0,0 +1,133 @@
+package stock
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+type StockService interface {
+	Receive(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error)
+	Adjust(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error)
+	GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.StockMovement, error)
+	ReceiveLot(ctx context.Context, m structs.StockMovement, lot structs.StockLot) (structs.StockMovement, error)
+	GetLots(ctx context.Context, id_product uuid.UUID) ([]structs.StockLot, error)
+	GetExpiring(ctx context.Context, days int) ([]structs.StockLot, error)
+}
+
+type StockRepository interface {
+	Create(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error)
+	GetByProduct(ctx context.Context, id_product uuid.UUID) ([]structs.StockMovement, error)
+	CreateLot(ctx context.Context, lot structs.StockLot) (uuid.UUID, error)
+	GetLots(ctx context.Context, id_product uuid.UUID) ([]structs.StockLot, error)
+	GetExpiring(ctx context.Context, days int) ([]structs.StockLot, error)
+}
+
+const (
+	maxReference = 100
+	maxBatch     = 50
+)
+
+type Service struct {
+	rep StockRepository
+}
+
+func New(rep StockRepository) *Service {
+	return &Service{rep: rep}
+}
+
+// Receive posts incoming goods. The quantity must be positive.
+func (s *Service) Receive(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error) {
+	m.Kind = structs.StockReceipt
+	m.Reason = strings.TrimSpace(m.Reason)
+	return s.post(ctx, m)
+}
+
+// Adjust posts a manual correction, a write-off or a return. Corrections may
+// go either way, write-offs take stock away and returns bring it back. A
+// reason is required for all of them.
+func (s *Service) Adjust(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error) {
+	if m.Kind == "" {
+		m.Kind = structs.StockAdjustment
+	}
+	m.Reason = strings.TrimSpace(m.Reason)
+	if m.Reason == "" {
+		return structs.StockMovement{}, structs.ErrInvalidStockMovement
+	}
+	switch m.Kind {
+	case structs.StockAdjustment, structs.StockWriteOff, structs.StockReturn:
+	default:
+		return structs.StockMovement{}, structs.ErrInvalidStockMovement
+	}
+	return s.post(ctx, m)
+}
+
+// post validates the sign of the quantity against the kind and stores the
+// movement. The repository updates the stock in the same transaction and
+// fails with ErrInsufficientStock when it would go negative.
+func (s *Service) post(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error) {
+	m.Reference = strings.TrimSpace(m.Reference)
+	if m.IdProduct == uuid.Nil || m.Quantity == 0 || utf8.RuneCountInString(m.Reference) > maxReference {
+		return structs.StockMovement{}, structs.ErrInvalidStockMovement
+	}
+	switch m.Kind {
+	case structs.StockReceipt, structs.StockReturn:
+		if m.Quantity < 0 {
+			return structs.StockMovement{}, structs.ErrInvalidStockMovement
+		}
+	case structs.StockWriteOff:
+		if m.Quantity > 0 {
+			return structs.StockMovement{}, structs.ErrInvalidStockMovement
+		}
+	}
+	return s.rep.Create(ctx, m)
+}
+
+// GetHistory returns the movements of the product and its variants, newest
+// first.
+func (s *Service) GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.StockMovement, error) {
+	return s.rep.GetByProduct(ctx, id_product)
+}
+
+// ReceiveLot posts incoming goods of one batch. The lot is created on its
+// first delivery, later deliveries of the batch must carry the same expiry
+// date. Only the date of ExpiresAt is kept.
+func (s *Service) ReceiveLot(ctx context.Context, m structs.StockMovement, lot structs.StockLot) (structs.StockMovement, error) {
+	lot.Batch = strings.TrimSpace(lot.Batch)
+	if lot.Batch == "" || utf8.RuneCountInString(lot.Batch) > maxBatch || lot.ExpiresAt.IsZero() {
+		return structs.StockMovement{}, structs.ErrInvalidLot
+	}
+	if m.IdProduct == uuid.Nil || m.Quantity <= 0 {
+		return structs.StockMovement{}, structs.ErrInvalidStockMovement
+	}
+	y, mo, d := lot.ExpiresAt.Date()
+	lot.ExpiresAt = time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+	lot.IdProduct = m.IdProduct
+	lot.IdVariant = m.IdVariant
+
+	id, err := s.rep.CreateLot(ctx, lot)
+	if err != nil {
+		return structs.StockMovement{}, err
+	}
+	m.IdLot = id
+	return s.Receive(ctx, m)
+}
+
+// GetLots returns the lots of the product and its variants that still hold
+// stock, first expiring first.
+func (s *Service) GetLots(ctx context.Context, id_product uuid.UUID) ([]structs.StockLot, error) {
+	return s.rep.GetLots(ctx, id_product)
+}
+
+// GetExpiring returns the lots with stock that expire within days from
+// today, including the lots that have expired already.
+func (s *Service) GetExpiring(ctx context.Context, days int) ([]structs.StockLot, error) {
+	if days < 0 {
+		return nil, structs.ErrInvalidLot
+	}
+	return s.rep.GetExpiring(ctx, days)
+}
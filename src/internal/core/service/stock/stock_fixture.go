@@ -0,0 +1,75 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t        *testing.T
+	ctrl     *gomock.Controller
+	ctx      context.Context
+	receipt  structs.StockMovement
+	writeOff structs.StockMovement
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	ctrl := gomock.NewController(t)
+	id_product := structs.GenId()
+	id_actor := structs.GenId()
+
+	return &TestFixture{
+		t:    t,
+		ctrl: ctrl,
+		ctx:  context.Background(),
+		receipt: structs.StockMovement{
+			IdProduct: id_product,
+			Kind:      structs.StockReceipt,
+			Quantity:  24,
+			Reason:    "поставка",
+			IdActor:   id_actor,
+			Reference: "ТН-1042",
+		},
+		writeOff: structs.StockMovement{
+			IdProduct: id_product,
+			IdVariant: structs.GenId(),
+			Kind:      structs.StockWriteOff,
+			Quantity:  -2,
+			Reason:    "поврежденная упаковка",
+			IdActor:   id_actor,
+		},
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.ctrl.Finish()
+}
+
+func (f *TestFixture) CreateServiceWithMocks() (*Service, *mock_structs.MockStockRepository) {
+	mockRepo := mock_structs.NewMockStockRepository(f.ctrl)
+
+	service := New(mockRepo)
+	return service, mockRepo
+}
+
+func (f *TestFixture) AssertError(err error, expectedErr error) {
+	if expectedErr != nil {
+		if err == nil {
+			f.t.Errorf("Expected error %v, got nil", expectedErr)
+			return
+		} else if !errors.Is(err, expectedErr) && err.Error() != expectedErr.Error() {
+			f.t.Errorf("Expected  error %v, got %v", expectedErr, err)
+		}
+
+	} else if err != nil {
+		f.t.Errorf("Expected error nil, got %v", err)
+		return
+	}
+}
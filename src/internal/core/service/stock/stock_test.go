@@ -0,0 +1,323 @@
+package stock
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/taucuya/ppo/internal/core/mock_structs"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestReceive_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	stored := fixture.receipt
+	stored.Id = structs.GenId()
+	stored.Balance = 30
+	stored.CreatedAt = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	padded := fixture.receipt
+	padded.Reference = "  ТН-1042 "
+	padded.Kind = ""
+	negative := fixture.receipt
+	negative.Quantity = -1
+	long := fixture.receipt
+	long.Reference = strings.Repeat("x", 101)
+
+	tests := []struct {
+		name        string
+		movement    structs.StockMovement
+		setupMocks  func(*mock_structs.MockStockRepository)
+		expectedRet structs.StockMovement
+		expectedErr error
+	}{
+		{
+			name:     "successful receipt",
+			movement: padded,
+			setupMocks: func(mockRepo *mock_structs.MockStockRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, fixture.receipt).Return(stored, nil)
+			},
+			expectedRet: stored,
+			expectedErr: nil,
+		},
+		{
+			name:        "negative quantity",
+			movement:    negative,
+			setupMocks:  func(mockRepo *mock_structs.MockStockRepository) {},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrInvalidStockMovement,
+		},
+		{
+			name:        "reference too long",
+			movement:    long,
+			setupMocks:  func(mockRepo *mock_structs.MockStockRepository) {},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrInvalidStockMovement,
+		},
+		{
+			name:     "product not found",
+			movement: fixture.receipt,
+			setupMocks: func(mockRepo *mock_structs.MockStockRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, fixture.receipt).Return(structs.StockMovement{}, structs.ErrProductNotFound)
+			},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Receive(fixture.ctx, tt.movement)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestAdjust_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	stored := fixture.writeOff
+	stored.Id = structs.GenId()
+	stored.Balance = 5
+	adjustment := fixture.writeOff
+	adjustment.Kind = ""
+	adjustment.Quantity = 3
+	defaulted := adjustment
+	defaulted.Kind = structs.StockAdjustment
+	noReason := fixture.writeOff
+	noReason.Reason = "  "
+	positive := fixture.writeOff
+	positive.Quantity = 2
+	sale := fixture.writeOff
+	sale.Kind = structs.StockSale
+	zero := adjustment
+	zero.Quantity = 0
+
+	tests := []struct {
+		name        string
+		movement    structs.StockMovement
+		setupMocks  func(*mock_structs.MockStockRepository)
+		expectedRet structs.StockMovement
+		expectedErr error
+	}{
+		{
+			name:     "successful write-off",
+			movement: fixture.writeOff,
+			setupMocks: func(mockRepo *mock_structs.MockStockRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, fixture.writeOff).Return(stored, nil)
+			},
+			expectedRet: stored,
+			expectedErr: nil,
+		},
+		{
+			name:     "adjustment by default",
+			movement: adjustment,
+			setupMocks: func(mockRepo *mock_structs.MockStockRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, defaulted).Return(stored, nil)
+			},
+			expectedRet: stored,
+			expectedErr: nil,
+		},
+		{
+			name:        "missing reason",
+			movement:    noReason,
+			setupMocks:  func(mockRepo *mock_structs.MockStockRepository) {},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrInvalidStockMovement,
+		},
+		{
+			name:        "positive write-off",
+			movement:    positive,
+			setupMocks:  func(mockRepo *mock_structs.MockStockRepository) {},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrInvalidStockMovement,
+		},
+		{
+			name:        "sales are posted by orders",
+			movement:    sale,
+			setupMocks:  func(mockRepo *mock_structs.MockStockRepository) {},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrInvalidStockMovement,
+		},
+		{
+			name:        "zero quantity",
+			movement:    zero,
+			setupMocks:  func(mockRepo *mock_structs.MockStockRepository) {},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrInvalidStockMovement,
+		},
+		{
+			name:     "not enough stock",
+			movement: fixture.writeOff,
+			setupMocks: func(mockRepo *mock_structs.MockStockRepository) {
+				mockRepo.EXPECT().Create(fixture.ctx, fixture.writeOff).Return(structs.StockMovement{}, structs.ErrInsufficientStock)
+			},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrInsufficientStock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.Adjust(fixture.ctx, tt.movement)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetHistory_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	id_product := fixture.receipt.IdProduct
+	history := []structs.StockMovement{fixture.writeOff, fixture.receipt}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mock_structs.MockStockRepository)
+		expectedRet []structs.StockMovement
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMocks: func(mockRepo *mock_structs.MockStockRepository) {
+				mockRepo.EXPECT().GetByProduct(fixture.ctx, id_product).Return(history, nil)
+			},
+			expectedRet: history,
+			expectedErr: nil,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mock_structs.MockStockRepository) {
+				mockRepo.EXPECT().GetByProduct(fixture.ctx, id_product).Return(nil, errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.GetHistory(fixture.ctx, id_product)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestReceiveLot_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	id_lot := structs.GenId()
+	expires := time.Date(2027, 9, 30, 0, 0, 0, 0, time.UTC)
+	lot := structs.StockLot{IdProduct: fixture.receipt.IdProduct, Batch: "L2403A", ExpiresAt: expires}
+	input := structs.StockLot{Batch: " L2403A ", ExpiresAt: expires.Add(15 * time.Hour)}
+	withLot := fixture.receipt
+	withLot.IdLot = id_lot
+
+	tests := []struct {
+		name        string
+		lot         structs.StockLot
+		setupMocks  func(*mock_structs.MockStockRepository)
+		expectedErr error
+	}{
+		{
+			name: "batch is trimmed and expiry kept as a date",
+			lot:  input,
+			setupMocks: func(mockRepo *mock_structs.MockStockRepository) {
+				mockRepo.EXPECT().CreateLot(fixture.ctx, lot).Return(id_lot, nil)
+				mockRepo.EXPECT().Create(fixture.ctx, withLot).Return(withLot, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "no batch",
+			lot:         structs.StockLot{ExpiresAt: expires},
+			setupMocks:  func(mockRepo *mock_structs.MockStockRepository) {},
+			expectedErr: structs.ErrInvalidLot,
+		},
+		{
+			name:        "no expiry date",
+			lot:         structs.StockLot{Batch: "L2403A"},
+			setupMocks:  func(mockRepo *mock_structs.MockStockRepository) {},
+			expectedErr: structs.ErrInvalidLot,
+		},
+		{
+			name: "batch known with another expiry",
+			lot:  input,
+			setupMocks: func(mockRepo *mock_structs.MockStockRepository) {
+				mockRepo.EXPECT().CreateLot(fixture.ctx, lot).Return(uuid.Nil, structs.ErrLotExpiryMismatch)
+			},
+			expectedErr: structs.ErrLotExpiryMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			_, err := service.ReceiveLot(fixture.ctx, fixture.receipt, tt.lot)
+
+			fixture.AssertError(err, tt.expectedErr)
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetExpiring_AAA(t *testing.T) {
+	fixture := NewTestFixture(t)
+	lots := []structs.StockLot{{Id: structs.GenId(), IdProduct: fixture.receipt.IdProduct, Batch: "L2403A", Amount: 4}}
+
+	tests := []struct {
+		name        string
+		days        int
+		setupMocks  func(*mock_structs.MockStockRepository)
+		expectedRet []structs.StockLot
+		expectedErr error
+	}{
+		{
+			name: "successful report",
+			days: 30,
+			setupMocks: func(mockRepo *mock_structs.MockStockRepository) {
+				mockRepo.EXPECT().GetExpiring(fixture.ctx, 30).Return(lots, nil)
+			},
+			expectedRet: lots,
+			expectedErr: nil,
+		},
+		{
+			name:        "negative days",
+			days:        -1,
+			setupMocks:  func(mockRepo *mock_structs.MockStockRepository) {},
+			expectedRet: nil,
+			expectedErr: structs.ErrInvalidLot,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := fixture.CreateServiceWithMocks()
+			tt.setupMocks(mockRepo)
+
+			ret, err := service.GetExpiring(fixture.ctx, tt.days)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,52 @@
+package structs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReorderRule asks to replenish a product once its stock, counting all its
+// variants, falls below ReorderPoint. ReorderQuantity is how much to order.
+type ReorderRule struct {
+	IdProduct       uuid.UUID `json:"id_product"`
+	ReorderPoint    int       `json:"reorder_point"`
+	ReorderQuantity int       `json:"reorder_quantity"`
+}
+
+// StockAlert records that a product fell below its reorder point. The alert
+// stays open until the stock is back at the reorder point.
+type StockAlert struct {
+	Id              uuid.UUID  `json:"id"`
+	IdProduct       uuid.UUID  `json:"id_product"`
+	Name            string     `json:"name"`
+	Articule        string     `json:"articule"`
+	Amount          int        `json:"amount"`
+	ReorderPoint    int        `json:"reorder_point"`
+	ReorderQuantity int        `json:"reorder_quantity"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+}
+
+type ReorderItem struct {
+	IdProduct       uuid.UUID `json:"id_product"`
+	Name            string    `json:"name"`
+	Articule        string    `json:"articule"`
+	IdBrand         uuid.UUID `json:"-"`
+	Brand           string    `json:"-"`
+	Amount          int       `json:"amount"`
+	ReorderPoint    int       `json:"reorder_point"`
+	ReorderQuantity int       `json:"reorder_quantity"`
+}
+
+type BrandReorder struct {
+	IdBrand uuid.UUID     `json:"id_brand"`
+	Brand   string        `json:"brand"`
+	Items   []ReorderItem `json:"items"`
+}
+
+var (
+	ErrInvalidReorderRule  = errors.New("reorder point must not be negative and reorder quantity must be positive")
+	ErrReorderRuleNotFound = errors.New("reorder rule not found")
+)
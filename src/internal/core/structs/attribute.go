@@ -0,0 +1,80 @@
+package structs
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AttributeSkinType = "skin_type"
+	AttributeHairType = "hair_type"
+	AttributeFinish   = "finish"
+)
+
+var AttributeKinds = []string{AttributeSkinType, AttributeHairType, AttributeFinish}
+
+// Ingredient is an entry of the INCI dictionary.
+type Ingredient struct {
+	Id       uuid.UUID `json:"id"`
+	InciName string    `json:"inci_name"`
+	Name     string    `json:"name,omitempty"`
+}
+
+// Attribute is a dictionary value of one of AttributeKinds, e.g. the "oily"
+// skin type or the "matte" finish.
+type Attribute struct {
+	Id   uuid.UUID `json:"id"`
+	Kind string    `json:"kind"`
+	Code string    `json:"code"`
+	Name string    `json:"name"`
+}
+
+// ProductAttributes describes the composition of a product. Ingredients keep
+// the INCI order, SPF 0 means the product has no sun protection.
+type ProductAttributes struct {
+	IdProduct   uuid.UUID    `json:"id_product"`
+	Ingredients []Ingredient `json:"ingredients"`
+	Attributes  []Attribute  `json:"attributes"`
+	Spf         int          `json:"spf"`
+	Vegan       bool         `json:"vegan"`
+	CrueltyFree bool         `json:"cruelty_free"`
+}
+
+const (
+	ProductSortName    = "name"
+	ProductSortRating  = "rating"
+	ProductSortReviews = "reviews"
+)
+
+var ProductSorts = []string{ProductSortName, ProductSortRating, ProductSortReviews}
+
+// ProductFilter narrows a catalog query, zero fields do not filter.
+// Attributes maps a kind to codes: a product matches a kind with any of its
+// codes and has to match every kind. Ingredients are INCI names compared
+// case insensitively; all included ones and none of the excluded ones have
+// to be in the product. Sort is one of ProductSorts, empty means by name;
+// rating sorts by average and then by review count, both descending.
+type ProductFilter struct {
+	IdCategory         uuid.UUID
+	Brand              string
+	Attributes         map[string][]string
+	IncludeIngredients []string
+	ExcludeIngredients []string
+	Vegan              bool
+	CrueltyFree        bool
+	MinSpf             int
+	MinRating          float64
+	Sort               string
+}
+
+var (
+	ErrIngredientNotFound       = errors.New("ingredient not found")
+	ErrAttributeNotFound        = errors.New("attribute not found")
+	ErrDuplicateIngredient      = errors.New("ingredient already exists")
+	ErrDuplicateAttribute       = errors.New("attribute already exists")
+	ErrInvalidIngredient        = errors.New("ingredient needs an INCI name")
+	ErrInvalidAttribute         = errors.New("attribute needs a known kind, a code and a name")
+	ErrInvalidProductAttributes = errors.New("ingredients must not repeat and SPF must be between 0 and 100")
+	ErrDictionaryEntryInUse     = errors.New("dictionary entry is used by products")
+)
@@ -9,6 +9,7 @@ import (
 type BasketItem struct {
 	Id        uuid.UUID
 	IdProduct uuid.UUID
+	IdVariant uuid.UUID
 	IdBasket  uuid.UUID
 	Amount    int
 }
@@ -0,0 +1,87 @@
+package structs
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CatalogFormatCSV   = "csv"
+	CatalogFormatJSONL = "jsonl"
+	CatalogFormatYML   = "yml"
+)
+
+const (
+	ImportRowCreated = "created"
+	ImportRowUpdated = "updated"
+	ImportRowInvalid = "invalid"
+)
+
+type CatalogImportRow struct {
+	Line               int
+	Articule           string
+	Name               string
+	Description        string
+	Price              float64
+	Category           string
+	IdCategory         uuid.UUID
+	Amount             int
+	Brand              string
+	BrandDescription   string
+	BrandPriceCategory string
+	PicLink            string
+	Reason             string
+}
+
+type CatalogImportRowResult struct {
+	Line     int    `json:"line"`
+	Articule string `json:"articule"`
+	Status   string `json:"status"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type CatalogImportReport struct {
+	DryRun  bool                     `json:"dry_run"`
+	Applied bool                     `json:"applied"`
+	Created int                      `json:"created"`
+	Updated int                      `json:"updated"`
+	Invalid int                      `json:"invalid"`
+	Rows    []CatalogImportRowResult `json:"rows"`
+}
+
+// CatalogExportItem is an offer of the exported catalog: a product without
+// variants or one variant of a product. A variant offer has its own
+// articule, price and stock and refers to its product by Id and
+// ProductArticule. Available leaves out the stock held by live reservations
+// and expired lots. Images is the gallery of the product, primary first:
+// storage keys as read from the repository, links in the export.
+type CatalogExportItem struct {
+	Id              uuid.UUID `json:"id"`
+	IdVariant       uuid.UUID `json:"id_variant"`
+	Articule        string    `json:"articule"`
+	ProductArticule string    `json:"product_articule"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	Price           float64   `json:"price"`
+	Category        string    `json:"category"`
+	IdCategory      uuid.UUID `json:"id_category"`
+	Shade           string    `json:"shade,omitempty"`
+	VolumeMl        int       `json:"volume_ml,omitempty"`
+	Available       int       `json:"available"`
+	Brand           string    `json:"brand"`
+	PicLink         string    `json:"pic_link"`
+	Images          []string  `json:"images"`
+}
+
+type ShopInfo struct {
+	Name    string
+	Company string
+	URL     string
+}
+
+var (
+	ErrUnsupportedFormat    = errors.New("unsupported catalog format")
+	ErrCatalogImportInvalid = errors.New("catalog import contains invalid rows")
+	ErrEmptyImport          = errors.New("catalog import is empty")
+)
@@ -0,0 +1,25 @@
+package structs
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Category is a node of the catalog tree. A nil IdParent marks a root node.
+type Category struct {
+	Id        uuid.UUID  `json:"id"`
+	IdParent  uuid.UUID  `json:"id_parent"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	SortOrder int        `json:"sort_order"`
+	Children  []Category `json:"children,omitempty"`
+}
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrDuplicateSlug    = errors.New("duplicate category slug")
+	ErrInvalidCategory  = errors.New("category needs a name and a slug of lowercase latin letters, digits and dashes")
+	ErrCategoryCycle    = errors.New("category cannot be moved under itself or its descendant")
+	ErrCategoryInUse    = errors.New("category has subcategories or products")
+)
@@ -0,0 +1,92 @@
+package structs
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryKind is the way an order reaches the customer.
+type DeliveryKind string
+
+const (
+	DeliveryCourier DeliveryKind = "courier"
+	DeliveryPickup  DeliveryKind = "pickup"
+	DeliveryPost    DeliveryKind = "post"
+)
+
+var DeliveryKinds = []DeliveryKind{DeliveryCourier, DeliveryPickup, DeliveryPost}
+
+// DeliveryMethod is a delivery option offered at checkout. Its cost comes
+// from the rates, and delivery is free for orders whose goods cost at
+// least FreeFrom; zero FreeFrom means delivery is never free. Courier
+// delivery is made in delivery slots.
+type DeliveryMethod struct {
+	Id       uuid.UUID      `json:"id"`
+	Kind     DeliveryKind   `json:"kind"`
+	Name     string         `json:"name"`
+	FreeFrom float64        `json:"free_from"`
+	Active   bool           `json:"active"`
+	Rates    []DeliveryRate `json:"rates"`
+}
+
+// DeliveryRate is a cost rule of a delivery method. It applies to orders to
+// Zone weighing at most MaxWeight grams, zero meaning any weight, whose
+// goods cost at least MinTotal. The cost is Cost plus PerKg for every
+// started kilogram.
+type DeliveryRate struct {
+	Zone      string  `json:"zone"`
+	MaxWeight int     `json:"max_weight"`
+	MinTotal  float64 `json:"min_total"`
+	Cost      float64 `json:"cost"`
+	PerKg     float64 `json:"per_kg"`
+}
+
+// Cost returns the delivery cost of an order to zone weighing weight grams
+// whose goods cost total: the cheapest applying rate, or nothing from the
+// free-shipping threshold on. An order no rate applies to cannot be
+// delivered this way.
+func (m DeliveryMethod) Cost(zone string, weight int, total float64) (float64, error) {
+	best := -1.0
+	for _, r := range m.Rates {
+		if r.Zone != zone || (r.MaxWeight > 0 && weight > r.MaxWeight) || total < r.MinTotal {
+			continue
+		}
+		c := r.Cost + r.PerKg*float64((weight+999)/1000)
+		if best < 0 || c < best {
+			best = c
+		}
+	}
+	if best < 0 {
+		return 0, ErrDeliveryUnavailable
+	}
+	if m.FreeFrom > 0 && total >= m.FreeFrom {
+		return 0, nil
+	}
+	return math.Round(best*100) / 100, nil
+}
+
+// DeliverySlot is a time window of courier delivery. Taken is the number of
+// orders to be delivered in it that were not cancelled or invalidated.
+type DeliverySlot struct {
+	Id         uuid.UUID `json:"id"`
+	IdDelivery uuid.UUID `json:"id_delivery"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	Capacity   int       `json:"capacity"`
+	Taken      int       `json:"taken"`
+}
+
+var (
+	ErrDeliveryNotFound    = errors.New("delivery method not found")
+	ErrInvalidDelivery     = errors.New("invalid delivery method")
+	ErrDeliveryUnavailable = errors.New("delivery method does not serve this order")
+	ErrSlotNotFound        = errors.New("delivery slot not found")
+	ErrInvalidSlot         = errors.New("invalid delivery slot")
+	ErrSlotRequired        = errors.New("courier delivery needs a delivery slot, other methods take none")
+	ErrSlotFull            = errors.New("delivery slot is full")
+	ErrSlotClosed          = errors.New("delivery slot has already started")
+	ErrSlotInUse           = errors.New("delivery slot has orders")
+)
@@ -0,0 +1,31 @@
+package structs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyRecord is the first response to an unsafe request sent with an
+// Idempotency-Key. RequestHash identifies the request the key was used for.
+// Status is zero while the first request is still running.
+type IdempotencyRecord struct {
+	IdUser      uuid.UUID
+	Key         string
+	RequestHash string
+	Status      int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time
+}
+
+func (r IdempotencyRecord) Done() bool {
+	return r.Status != 0
+}
+
+var (
+	ErrInvalidIdempotencyKey    = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was used for another request")
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is in progress")
+)
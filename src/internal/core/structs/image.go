@@ -0,0 +1,35 @@
+package structs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductImage struct {
+	Id          uuid.UUID         `json:"id"`
+	IdProduct   uuid.UUID         `json:"id_product"`
+	Position    int               `json:"position"`
+	IsPrimary   bool              `json:"is_primary"`
+	ContentType string            `json:"content_type"`
+	Size        int64             `json:"size"`
+	Key         string            `json:"-"`
+	URL         string            `json:"url"`
+	Thumbnails  map[string]string `json:"thumbnails"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+type ImageFile struct {
+	Name string
+	Data []byte
+}
+
+var (
+	ErrImageNotFound        = errors.New("image not found")
+	ErrImageTooLarge        = errors.New("image is too large")
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	ErrInvalidImage         = errors.New("invalid image")
+	ErrInvalidImageOrder    = errors.New("image order must list every product image exactly once")
+	ErrNoImages             = errors.New("no images")
+)
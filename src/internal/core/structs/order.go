@@ -2,27 +2,140 @@ package structs
 
 import (
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// OrderItem is a line of an order. Price is the unit price, Discount the
+// discount of the whole line, and Name, Art and Brand describe the goods;
+// all of them are taken at purchase time and do not follow the catalog.
+// IdProduct is uuid.Nil once the product is removed from the catalog.
 type OrderItem struct {
 	Id        uuid.UUID
 	IdProduct uuid.UUID
+	IdVariant uuid.UUID
 	IdOrder   uuid.UUID
 	Amount    int
+	Price     float64
+	Discount  float64
+	Name      string
+	Art       string
+	Brand     string
+	Lots      []OrderItemLot
 }
 
+// OrderItemLot is the part of an order item shipped from one lot. Items
+// sold from stock received without a batch have no lots.
+type OrderItemLot struct {
+	IdLot     uuid.UUID `json:"id_lot"`
+	Batch     string    `json:"batch"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Amount    int       `json:"amount"`
+}
+
+// Order is a placed order. It is delivered by the delivery method
+// IdDelivery to Zone, courier orders in the slot IdSlot. Price is the
+// cost of the goods after discounts plus ShippingCost.
 type Order struct {
-	Id      uuid.UUID
-	Date    time.Time
-	IdUser  uuid.UUID
-	Address string
-	Status  string
-	Price   float64
+	Id           uuid.UUID
+	Date         time.Time
+	IdUser       uuid.UUID
+	Address      string
+	Status       OrderStatus
+	Price        float64
+	IdDelivery   uuid.UUID
+	Zone         string
+	IdSlot       uuid.UUID
+	ShippingCost float64
+}
+
+// OrderStatus is the stage of an order. A placed order waits for payment,
+// a paid one waits for a worker, is accepted, assembled and handed over;
+// an order that cannot be fulfilled is marked invalid, and an order may be
+// cancelled before it is handed over. Handed over, invalid and cancelled
+// orders are final.
+type OrderStatus string
+
+const (
+	OrderInvalid   OrderStatus = "некорректный"
+	OrderUnpaid    OrderStatus = "неоплаченный"
+	OrderNew       OrderStatus = "непринятый"
+	OrderAccepted  OrderStatus = "принятый"
+	OrderAssembled OrderStatus = "собранный"
+	OrderShipped   OrderStatus = "отданный"
+	OrderCancelled OrderStatus = "отмененный"
+)
+
+var OrderStatuses = []OrderStatus{OrderInvalid, OrderUnpaid, OrderNew, OrderAccepted, OrderAssembled, OrderShipped, OrderCancelled}
+
+// OrderRole is the side that changes the status of an order.
+type OrderRole string
+
+const (
+	OrderRoleCustomer OrderRole = "customer"
+	OrderRoleWorker   OrderRole = "worker"
+	OrderRoleAdmin    OrderRole = "admin"
+)
+
+// orderTransitions lists for every status the statuses it may move to and
+// the roles allowed to make the move. An unpaid order becomes new only when
+// its payment is confirmed.
+var orderTransitions = map[OrderStatus]map[OrderStatus][]OrderRole{
+	OrderUnpaid: {
+		OrderCancelled: {OrderRoleCustomer, OrderRoleAdmin},
+	},
+	OrderNew: {
+		OrderAccepted:  {OrderRoleWorker, OrderRoleAdmin},
+		OrderInvalid:   {OrderRoleWorker, OrderRoleAdmin},
+		OrderCancelled: {OrderRoleCustomer, OrderRoleAdmin},
+	},
+	OrderAccepted: {
+		OrderAssembled: {OrderRoleWorker, OrderRoleAdmin},
+		OrderInvalid:   {OrderRoleAdmin},
+		OrderCancelled: {OrderRoleCustomer, OrderRoleAdmin},
+	},
+	OrderAssembled: {
+		OrderShipped:   {OrderRoleWorker, OrderRoleAdmin},
+		OrderInvalid:   {OrderRoleAdmin},
+		OrderCancelled: {OrderRoleAdmin},
+	},
+}
+
+// Valid reports whether s is one of the known statuses.
+func (s OrderStatus) Valid() bool {
+	return slices.Contains(OrderStatuses, s)
+}
+
+// CanMove reports whether role may move an order from s to next.
+func (s OrderStatus) CanMove(next OrderStatus, role OrderRole) bool {
+	return slices.Contains(orderTransitions[s][next], role)
+}
+
+// Released reports whether an order in status s no longer holds its goods.
+// Moving an order to such a status puts its goods back to stock.
+func (s OrderStatus) Released() bool {
+	return s == OrderInvalid || s == OrderCancelled
+}
+
+// OrderStatusChange is an entry of the status history of an order. The
+// first entry of an order has an empty From.
+type OrderStatusChange struct {
+	Id        uuid.UUID   `json:"id"`
+	IdOrder   uuid.UUID   `json:"id_order"`
+	From      OrderStatus `json:"from"`
+	To        OrderStatus `json:"to"`
+	IdActor   uuid.UUID   `json:"id_actor"`
+	Role      OrderRole   `json:"role"`
+	Comment   string      `json:"comment"`
+	CreatedAt time.Time   `json:"created_at"`
 }
 
 var (
-	ErrOrderNotFound = errors.New("order not found")
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrInvalidOrderStatus     = errors.New("invalid order status")
+	ErrIllegalOrderTransition = errors.New("order status cannot be changed this way")
+	ErrOrderStatusChanged     = errors.New("order status was changed by someone else")
+	ErrCancelReasonRequired   = errors.New("order cancellation needs a reason")
 )
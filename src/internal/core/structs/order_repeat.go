@@ -0,0 +1,45 @@
+package structs
+
+import "github.com/google/uuid"
+
+// RepeatStatus tells what happened to a line of an order put into the
+// basket again; skipped lines come with one of the Repeat reasons.
+type RepeatStatus string
+
+const (
+	RepeatAdded   RepeatStatus = "added"
+	RepeatReduced RepeatStatus = "reduced"
+	RepeatSkipped RepeatStatus = "skipped"
+)
+
+const (
+	RepeatArchived   = "archived"
+	RepeatOutOfStock = "out_of_stock"
+)
+
+// RepeatLine is a line of a past order with the current state of its
+// goods, used to order it again. Archived is set when the product is no
+// longer in the catalog; ordered variants cannot be deleted on their own.
+// Available leaves out the stock held for other users and InBasket is the
+// amount already in the basket of the user.
+type RepeatLine struct {
+	IdProduct uuid.UUID
+	IdVariant uuid.UUID
+	Name      string
+	Amount    int
+	Archived  bool
+	Available int
+	InBasket  int
+}
+
+// RepeatItem reports what happened to a line of the order: added in
+// full, reduced to the available stock, or skipped with a reason.
+type RepeatItem struct {
+	IdProduct uuid.UUID    `json:"id_product"`
+	IdVariant uuid.UUID    `json:"id_variant,omitempty"`
+	Name      string       `json:"name"`
+	Requested int          `json:"requested"`
+	Added     int          `json:"added"`
+	Status    RepeatStatus `json:"status"`
+	Reason    string       `json:"reason,omitempty"`
+}
@@ -0,0 +1,78 @@
+package structs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OrderSortNewest    = "-date"
+	OrderSortOldest    = "date"
+	OrderSortCheapest  = "price"
+	OrderSortExpensive = "-price"
+)
+
+var OrderSorts = []string{OrderSortNewest, OrderSortOldest, OrderSortCheapest, OrderSortExpensive}
+
+// OrderFilter narrows the admin order search, zero fields do not filter.
+// From is inclusive and To exclusive. Customer matches a part of the e-mail
+// or the phone of the buyer, e-mails case insensitively. MinPrice and
+// MaxPrice bound the order total when set. Sort is one of OrderSorts,
+// empty means newest first.
+type OrderFilter struct {
+	Statuses  []OrderStatus
+	From      time.Time
+	To        time.Time
+	Customer  string
+	IdWorker  uuid.UUID
+	MinPrice  *float64
+	MaxPrice  *float64
+	IdProduct uuid.UUID
+	Sort      string
+}
+
+// OrderCursor is the position of the last order of a page: the sort it was
+// listed by and the key of the order in that sort.
+type OrderCursor struct {
+	Sort  string    `json:"s"`
+	Date  time.Time `json:"d"`
+	Price float64   `json:"p"`
+	Id    uuid.UUID `json:"i"`
+}
+
+// OrderSummary is an order found by the admin search with its buyer, the
+// worker who accepted it and its lines.
+type OrderSummary struct {
+	Id            uuid.UUID   `json:"id"`
+	Date          time.Time   `json:"date"`
+	IdUser        uuid.UUID   `json:"id_user"`
+	Address       string      `json:"address"`
+	Status        OrderStatus `json:"status"`
+	Price         float64     `json:"price"`
+	CustomerName  string      `json:"customer_name"`
+	CustomerMail  string      `json:"customer_mail"`
+	CustomerPhone string      `json:"customer_phone"`
+	IdWorker      uuid.UUID   `json:"id_worker"`
+	Items         []OrderItem `json:"items"`
+}
+
+// OrderPage is one page of the admin search. NextCursor continues the
+// listing and is empty on the last page.
+type OrderPage struct {
+	Orders     []OrderSummary `json:"orders"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// OrderExportRow is one line of an order in the accounting export. Orders
+// without lines give one row with an empty line.
+type OrderExportRow struct {
+	Order OrderSummary
+	Line  OrderItem
+}
+
+var (
+	ErrInvalidOrderFilter = errors.New("invalid order filter")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+)
@@ -0,0 +1,98 @@
+package structs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PaymentPending   = "pending"
+	PaymentSucceeded = "succeeded"
+	PaymentFailed    = "failed"
+)
+
+// Payment is an attempt to pay for an unpaid order through a payment
+// provider. It stays pending until the provider reports the result in a
+// webhook. An order may have several failed payments and at most one
+// succeeded payment that moved it further. ClientSecret is handed to the
+// customer to pay the intent and is not stored.
+type Payment struct {
+	Id           uuid.UUID `json:"id"`
+	IdOrder      uuid.UUID `json:"id_order"`
+	IdUser       uuid.UUID `json:"id_user"`
+	Provider     string    `json:"provider"`
+	IntentId     string    `json:"-"`
+	Amount       float64   `json:"amount"`
+	Status       string    `json:"status"`
+	Error        string    `json:"error,omitempty"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// PaymentIntent is the payment as the provider created it.
+type PaymentIntent struct {
+	Id           string
+	ClientSecret string
+}
+
+// Kinds of webhook events of a payment provider.
+const (
+	PaymentEventAuthorized = "payment.authorized"
+	PaymentEventFailed     = "payment.failed"
+)
+
+// PaymentSignatureHeader carries the signature of a webhook call, which
+// the provider checks against the body.
+const PaymentSignatureHeader = "X-Payment-Signature"
+
+// PaymentEvent is a verified webhook call of a payment provider. Providers
+// may deliver an event several times, Id tells the copies apart from new
+// events.
+type PaymentEvent struct {
+	Id       string `json:"id"`
+	Type     string `json:"type"`
+	IntentId string `json:"intent"`
+	Error    string `json:"error,omitempty"`
+}
+
+const (
+	RefundPending   = "pending"
+	RefundSucceeded = "succeeded"
+	RefundFailed    = "failed"
+)
+
+// Refund is the money owed to the customer: the price paid after discounts
+// for the received goods of a return, or the whole payment of a cancelled
+// order. Pending refunds are sent to the provider against the payment of
+// the order in the background. IdReturn is empty for cancelled orders.
+type Refund struct {
+	Id          uuid.UUID  `json:"id"`
+	IdReturn    uuid.UUID  `json:"id_return"`
+	IdOrder     uuid.UUID  `json:"id_order"`
+	IdPayment   uuid.UUID  `json:"id_payment"`
+	Amount      float64    `json:"amount"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+}
+
+// RefundResult is the answer of the provider to a refund. A refund the
+// provider declined has Status RefundFailed and the reason in Error.
+type RefundResult struct {
+	Ref    string
+	Status string
+	Error  string
+}
+
+var (
+	ErrPaymentNotFound      = errors.New("payment not found")
+	ErrPaymentNotAllowed    = errors.New("only unpaid orders can be paid")
+	ErrInvalidPaymentMethod = errors.New("invalid payment method")
+	ErrInvalidSignature     = errors.New("invalid webhook signature")
+	ErrInvalidPaymentEvent  = errors.New("invalid payment event")
+	ErrPaymentEventSeen     = errors.New("payment event was already processed")
+)
@@ -0,0 +1,26 @@
+package structs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductPrice is a price of a product valid from ValidFrom until ValidTo.
+// The current and the last scheduled prices have no ValidTo.
+type ProductPrice struct {
+	Id        uuid.UUID  `json:"id"`
+	IdProduct uuid.UUID  `json:"id_product"`
+	Price     float64    `json:"price"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+	Scheduled bool       `json:"scheduled"`
+}
+
+var (
+	ErrPriceNotFound    = errors.New("scheduled price not found")
+	ErrInvalidPrice     = errors.New("price must not be negative")
+	ErrPriceNotInFuture = errors.New("scheduled price must start in the future")
+	ErrDuplicatePrice   = errors.New("another price starts at the same time")
+)
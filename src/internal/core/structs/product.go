@@ -6,20 +6,60 @@ import (
 	"github.com/google/uuid"
 )
 
+// Product is an item of the catalog. Weight is the shipping weight of one
+// unit in grams.
 type Product struct {
 	Id          uuid.UUID
 	Name        string
 	Description string
 	Price       float64
-	Category    string
+	IdCategory  uuid.UUID
 	Amount      int
+	Available   int
 	IdBrand     uuid.UUID
 	PicLink     string
 	Articule    string
+	Weight      int
+	Rating      ProductRating
+}
+
+// ProductRating is the review summary kept for every product. Histogram[i]
+// is the number of reviews with i+1 stars.
+type ProductRating struct {
+	Average   float64 `json:"average"`
+	Count     int     `json:"count"`
+	Histogram [5]int  `json:"histogram"`
+}
+
+// ProductVariant is a sellable shade or volume of a product with its own
+// articule, price and stock. A variant without a weight weighs as much as
+// its product.
+type ProductVariant struct {
+	Id        uuid.UUID `json:"id"`
+	IdProduct uuid.UUID `json:"id_product"`
+	Articule  string    `json:"articule"`
+	Shade     string    `json:"shade,omitempty"`
+	VolumeMl  int       `json:"volume_ml,omitempty"`
+	Weight    int       `json:"weight,omitempty"`
+	Price     float64   `json:"price"`
+	Amount    int       `json:"amount"`
+	Available int       `json:"available"`
+}
+
+// VariantMatrix lists the variants of a product together with the distinct
+// shades and volumes they span, so a product page can render a selector.
+type VariantMatrix struct {
+	Shades   []string         `json:"shades"`
+	Volumes  []int            `json:"volumes"`
+	Variants []ProductVariant `json:"variants"`
 }
 
 var (
 	ErrProductNotFound   = errors.New("product not found")
+	ErrVariantNotFound   = errors.New("variant not found")
+	ErrVariantOrdered    = errors.New("variant has been ordered")
+	ErrInvalidVariant    = errors.New("variant needs an articule, a shade or a volume and non-negative price, amount and weight")
 	ErrReviewNotFound    = errors.New("review not found")
 	ErrDuplicateArticule = errors.New("duplicate articule")
+	ErrInvalidSort       = errors.New("sort must be name, rating or reviews and min rating between 0 and 5")
 )
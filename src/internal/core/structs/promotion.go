@@ -0,0 +1,75 @@
+package structs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PromotionPercent = "percent"
+	PromotionFixed   = "fixed"
+	PromotionBundle  = "bundle"
+)
+
+var PromotionKinds = []string{PromotionPercent, PromotionFixed, PromotionBundle}
+
+// Promotion is either a promo code entered by the customer or, when Code is
+// empty, a sale applied automatically. IdBrand and IdCategory restrict the
+// products it covers, the category includes its subcategories. Value is the
+// percent for percent promotions and the amount for fixed ones, bundle
+// promotions give FreeQuantity of every BuyQuantity+FreeQuantity units for
+// free. Zero limits and nil dates mean no restriction.
+type Promotion struct {
+	Id           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Kind         string     `json:"kind"`
+	Code         string     `json:"code"`
+	Value        float64    `json:"value"`
+	BuyQuantity  int        `json:"buy_quantity"`
+	FreeQuantity int        `json:"free_quantity"`
+	IdBrand      uuid.UUID  `json:"id_brand"`
+	IdCategory   uuid.UUID  `json:"id_category"`
+	MinBasket    float64    `json:"min_basket"`
+	UsageLimit   int        `json:"usage_limit"`
+	PerUserLimit int        `json:"per_user_limit"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	Active       bool       `json:"active"`
+}
+
+// CheckoutLine is a basket position priced for checkout. Categories holds the
+// category of the product followed by its ancestors.
+type CheckoutLine struct {
+	IdProduct  uuid.UUID
+	IdVariant  uuid.UUID
+	IdBrand    uuid.UUID
+	Categories []uuid.UUID
+	UnitPrice  float64
+	Amount     int
+}
+
+type LineDiscount struct {
+	IdProduct   uuid.UUID `json:"id_product"`
+	IdVariant   uuid.UUID `json:"id_variant"`
+	IdPromotion uuid.UUID `json:"id_promotion"`
+	Amount      float64   `json:"amount"`
+}
+
+type DiscountQuote struct {
+	Subtotal  float64        `json:"subtotal"`
+	Discount  float64        `json:"discount"`
+	Total     float64        `json:"total"`
+	Discounts []LineDiscount `json:"discounts"`
+}
+
+var (
+	ErrPromotionNotFound      = errors.New("promotion not found")
+	ErrInvalidPromotion       = errors.New("invalid promotion")
+	ErrDuplicatePromoCode     = errors.New("promo code already exists")
+	ErrPromotionExpired       = errors.New("promotion is not active")
+	ErrPromotionMinBasket     = errors.New("basket total is below the promotion minimum")
+	ErrPromotionNotApplicable = errors.New("promotion does not apply to the basket")
+	ErrPromotionLimitReached  = errors.New("promotion usage limit reached")
+)
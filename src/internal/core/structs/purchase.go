@@ -0,0 +1,87 @@
+package structs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PurchaseDraft             = "draft"
+	PurchaseSent              = "sent"
+	PurchasePartiallyReceived = "partially_received"
+	PurchaseReceived          = "received"
+)
+
+var PurchaseStatuses = []string{PurchaseDraft, PurchaseSent, PurchasePartiallyReceived, PurchaseReceived}
+
+// Supplier delivers the goods of its brands. A purchase order may only
+// contain products of the brands of its supplier.
+type Supplier struct {
+	Id     uuid.UUID   `json:"id"`
+	Name   string      `json:"name"`
+	Email  string      `json:"email"`
+	Phone  string      `json:"phone"`
+	Brands []uuid.UUID `json:"brands"`
+}
+
+// PurchaseOrder is an order placed with a supplier. It is prepared as a
+// draft, sent to the supplier and then received in one or more deliveries.
+type PurchaseOrder struct {
+	Id            uuid.UUID             `json:"id"`
+	IdSupplier    uuid.UUID             `json:"id_supplier"`
+	Status        string                `json:"status"`
+	ExpectedAt    *time.Time            `json:"expected_at"`
+	Note          string                `json:"note"`
+	CreatedAt     time.Time             `json:"created_at"`
+	Lines         []PurchaseLine        `json:"lines,omitempty"`
+	Discrepancies []PurchaseDiscrepancy `json:"discrepancies,omitempty"`
+}
+
+type PurchaseLine struct {
+	Id        uuid.UUID `json:"id"`
+	IdProduct uuid.UUID `json:"id_product"`
+	IdVariant uuid.UUID `json:"id_variant"`
+	Ordered   int       `json:"ordered"`
+	Received  int       `json:"received"`
+}
+
+type ReceivedLine struct {
+	IdLine   uuid.UUID
+	Quantity int
+}
+
+// PurchaseReceipt is a delivery counted by a worker. Final closes the order
+// even when some lines were not delivered in full.
+type PurchaseReceipt struct {
+	IdOrder  uuid.UUID
+	IdWorker uuid.UUID
+	Lines    []ReceivedLine
+	Final    bool
+}
+
+// PurchaseDiscrepancy is a line of a closed purchase order that was not
+// delivered as ordered. Difference is positive for over-deliveries and
+// negative for under-deliveries.
+type PurchaseDiscrepancy struct {
+	Id         uuid.UUID `json:"id"`
+	IdLine     uuid.UUID `json:"id_line"`
+	Ordered    int       `json:"ordered"`
+	Received   int       `json:"received"`
+	Difference int       `json:"difference"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+var (
+	ErrSupplierNotFound      = errors.New("supplier not found")
+	ErrInvalidSupplier       = errors.New("supplier needs a name and at least one brand")
+	ErrDuplicateSupplier     = errors.New("supplier already exists")
+	ErrSupplierHasOrders     = errors.New("supplier has purchase orders")
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrInvalidPurchaseOrder  = errors.New("invalid purchase order")
+	ErrPurchaseLineNotFound  = errors.New("purchase order line not found")
+	ErrProductNotSupplied    = errors.New("product is not of a brand of the supplier")
+	ErrPurchaseOrderStatus   = errors.New("purchase order status does not allow this")
+	ErrPurchaseOrderChanged  = errors.New("purchase order was changed, reload it and try again")
+)
@@ -0,0 +1,20 @@
+package structs
+
+// Recommendation is a product frequently bought together with the products
+// it was requested for. Confidence is the share of orders with the source
+// product that also contain this one, Lift compares that share with how
+// often this product is bought at all.
+type Recommendation struct {
+	Product
+	Support    int     `json:"support"`
+	Confidence float64 `json:"confidence"`
+	Lift       float64 `json:"lift"`
+}
+
+// RecommendationThresholds filter the product pairs kept by a rebuild: a
+// pair needs MinSupport common orders and both bounds to be reached.
+type RecommendationThresholds struct {
+	MinSupport    int
+	MinConfidence float64
+	MinLift       float64
+}
@@ -0,0 +1,23 @@
+package structs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reservation holds basket stock for a user who started checkout until
+// ExpiresAt. Held stock is not available to other customers.
+type Reservation struct {
+	Id        uuid.UUID `json:"id"`
+	IdUser    uuid.UUID `json:"id_user"`
+	IdProduct uuid.UUID `json:"id_product"`
+	IdVariant uuid.UUID `json:"id_variant"`
+	Amount    int       `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+var (
+	ErrEmptyBasket = errors.New("basket is empty")
+)
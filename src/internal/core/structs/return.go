@@ -0,0 +1,88 @@
+package structs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReturnRequested = "requested"
+	ReturnApproved  = "approved"
+	ReturnRejected  = "rejected"
+	ReturnReceived  = "received"
+)
+
+var ReturnStatuses = []string{ReturnRequested, ReturnApproved, ReturnRejected, ReturnReceived}
+
+// Condition of returned goods. Only sellable goods go back to stock.
+const (
+	ReturnSellable = "sellable"
+	ReturnDamaged  = "damaged"
+)
+
+// ReturnRequest is a return of lines of a handed over order. The customer
+// opens it, an admin approves or rejects it, and a worker receives the
+// goods of an approved return, which closes it with a refund.
+type ReturnRequest struct {
+	Id         uuid.UUID     `json:"id"`
+	IdOrder    uuid.UUID     `json:"id_order"`
+	IdUser     uuid.UUID     `json:"id_user"`
+	Status     string        `json:"status"`
+	Reason     string        `json:"reason"`
+	Comment    string        `json:"comment"`
+	CreatedAt  time.Time     `json:"created_at"`
+	DecidedAt  *time.Time    `json:"decided_at"`
+	ReceivedAt *time.Time    `json:"received_at"`
+	Lines      []ReturnLine  `json:"lines,omitempty"`
+	Photos     []ReturnPhoto `json:"photos,omitempty"`
+	Refund     *Refund       `json:"refund,omitempty"`
+}
+
+// ReturnLine is the quantity of an order line the customer sends back.
+// Received and Condition are set by the worker who receives the goods.
+type ReturnLine struct {
+	Id          uuid.UUID `json:"id"`
+	IdOrderItem uuid.UUID `json:"id_order_item"`
+	IdProduct   uuid.UUID `json:"id_product"`
+	IdVariant   uuid.UUID `json:"id_variant"`
+	Amount      int       `json:"amount"`
+	Received    int       `json:"received"`
+	Condition   string    `json:"condition"`
+}
+
+type ReturnPhoto struct {
+	Id          uuid.UUID `json:"id"`
+	IdReturn    uuid.UUID `json:"id_return"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Key         string    `json:"-"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ReceivedReturnLine struct {
+	IdLine    uuid.UUID
+	Received  int
+	Condition string
+}
+
+// ReturnReceipt is the goods of a return counted by a worker. Every line of
+// the return has to be listed, lines that did not arrive with zero.
+type ReturnReceipt struct {
+	IdReturn uuid.UUID
+	IdWorker uuid.UUID
+	Lines    []ReceivedReturnLine
+}
+
+var (
+	ErrReturnNotFound       = errors.New("return not found")
+	ErrInvalidReturn        = errors.New("invalid return")
+	ErrReturnNotAllowed     = errors.New("only handed over orders can be returned")
+	ErrReturnWindowClosed   = errors.New("return window is closed")
+	ErrReturnAmountExceeded = errors.New("more goods returned than bought")
+	ErrReturnStatus         = errors.New("return status does not allow this")
+	ErrReturnChanged        = errors.New("return was changed, reload it and try again")
+	ErrTooManyReturnPhotos  = errors.New("too many return photos")
+)
@@ -0,0 +1,108 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StockReceipt    = "receipt"
+	StockSale       = "sale"
+	StockReturn     = "return"
+	StockAdjustment = "adjustment"
+	StockWriteOff   = "write_off"
+)
+
+// StockMovement is an entry of the append-only stock ledger. Quantity is the
+// signed change of the stock of the product, or of its variant when
+// IdVariant is set, and Balance is the stock right after the movement.
+// Reference names the document behind the movement, e.g. an order id or a
+// delivery note number. IdLot is set when the movement concerns one lot.
+type StockMovement struct {
+	Id        uuid.UUID `json:"id"`
+	IdProduct uuid.UUID `json:"id_product"`
+	IdVariant uuid.UUID `json:"id_variant"`
+	IdLot     uuid.UUID `json:"id_lot"`
+	Kind      string    `json:"kind"`
+	Quantity  int       `json:"quantity"`
+	Balance   int       `json:"balance"`
+	Reason    string    `json:"reason"`
+	IdActor   uuid.UUID `json:"id_actor"`
+	Reference string    `json:"reference"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StockLot is a batch of a product or variant with a single expiry date.
+// The stock of a product is the sum of its lots plus the stock received
+// without a batch. A lot is sold up to and including its expiry date and is
+// blocked from sale afterwards.
+type StockLot struct {
+	Id        uuid.UUID `json:"id"`
+	IdProduct uuid.UUID `json:"id_product"`
+	IdVariant uuid.UUID `json:"id_variant"`
+	Batch     string    `json:"batch"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Amount    int       `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StockLevel is the stock of a product or variant locked for a checkout:
+// the amount the buyer may take, the unit price and the goods description
+// stored on the order line, and the unit weight in grams. IdBrand and
+// Categories, the category of the product followed by its ancestors, let
+// promotions be applied to the locked line.
+type StockLevel struct {
+	IdProduct  uuid.UUID
+	IdVariant  uuid.UUID
+	IdBrand    uuid.UUID
+	Categories []uuid.UUID
+	Available  int
+	Price      float64
+	Name       string
+	Art        string
+	Brand      string
+	Weight     int
+}
+
+// StockShortage is a basket line that cannot be served from stock.
+type StockShortage struct {
+	IdProduct uuid.UUID `json:"id_product"`
+	IdVariant uuid.UUID `json:"id_variant"`
+	Requested int       `json:"requested"`
+	Available int       `json:"available"`
+}
+
+// InsufficientStockError lists all basket lines short of stock. It matches
+// ErrInsufficientStock with errors.Is.
+type InsufficientStockError struct {
+	Shortages []StockShortage
+}
+
+func (e *InsufficientStockError) Error() string {
+	lines := make([]string, 0, len(e.Shortages))
+	for _, sh := range e.Shortages {
+		item := "product " + sh.IdProduct.String()
+		if sh.IdVariant != uuid.Nil {
+			item += " variant " + sh.IdVariant.String()
+		}
+		lines = append(lines, fmt.Sprintf("%s: requested %d, available %d", item, sh.Requested, sh.Available))
+	}
+	return ErrInsufficientStock.Error() + ": " + strings.Join(lines, "; ")
+}
+
+func (e *InsufficientStockError) Unwrap() error {
+	return ErrInsufficientStock
+}
+
+var (
+	ErrInvalidStockMovement = errors.New("invalid stock movement")
+	ErrInsufficientStock    = errors.New("not enough stock")
+	ErrInvalidLot           = errors.New("lot needs a batch number and an expiry date")
+	ErrLotNotFound          = errors.New("lot not found")
+	ErrLotExpiryMismatch    = errors.New("batch is already stored with another expiry date")
+	ErrLotRequired          = errors.New("stock is held in lots, choose the lot")
+)
@@ -1,14 +1,51 @@
 create extension if not exists "uuid-ossp";
 
+drop table if exists delivery_slot cascade;
+drop table if exists delivery_rate cascade;
+drop table if exists delivery_method cascade;
+drop table if exists payment_event cascade;
+drop table if exists payment cascade;
+drop table if exists refund cascade;
+drop table if exists return_photo cascade;
+drop table if exists return_line cascade;
+drop table if exists return_request cascade;
+drop table if exists idempotency_key cascade;
+drop table if exists order_status_history cascade;
+drop table if exists order_item_lot cascade;
+drop table if exists purchase_discrepancy cascade;
+drop table if exists purchase_line cascade;
+drop table if exists purchase_order cascade;
+drop table if exists supplier_brand cascade;
+drop table if exists supplier cascade;
+drop table if exists stock_alert cascade;
+drop table if exists product_reorder cascade;
+drop table if exists order_item_discount cascade;
+drop table if exists promotion_redemption cascade;
+drop table if exists promotion cascade;
 drop table if exists order_item cascade;
 drop table if exists "order" cascade;
+drop table if exists stock_reservation cascade;
+drop table if exists stock_movement cascade;
+drop table if exists stock_lot cascade;
+drop table if exists product_pair cascade;
+drop table if exists product_rating cascade;
 drop table if exists review cascade;
 drop table if exists basket_item cascade;
 drop table if exists basket cascade;
 drop table if exists favourites_item cascade;
 drop table if exists favourites cascade;
 drop table if exists worker cascade;
+drop table if exists user_allergen cascade;
+drop table if exists product_properties cascade;
+drop table if exists product_attribute cascade;
+drop table if exists product_ingredient cascade;
+drop table if exists attribute cascade;
+drop table if exists ingredient cascade;
+drop table if exists product_price cascade;
+drop table if exists product_image cascade;
+drop table if exists product_variant cascade;
 drop table if exists product cascade;
+drop table if exists category cascade;
 drop table if exists brand cascade;
 drop table if exists "user" cascade;
 drop table if exists token cascade;
@@ -34,16 +71,94 @@ create table if not exists brand (
     constraint brand_pkey primary key (id)
 );
 
+create table if not exists category (
+    id uuid primary key default uuid_generate_v4(),
+    id_parent uuid,
+    name varchar(255),
+    slug varchar(100),
+    sort_order int
+);
+
 create table if not exists product (
     id uuid primary key default uuid_generate_v4(),
     name varchar(255),
     description text,
     price decimal(10,2),
-    category varchar(100),
+    id_category uuid,
     amount int,
     id_brand uuid,
     pic_link text,
-    art varchar(50)
+    art varchar(50),
+    weight int
+);
+
+create table if not exists product_variant (
+    id uuid primary key default uuid_generate_v4(),
+    id_product uuid,
+    art varchar(50),
+    shade varchar(100),
+    volume_ml int,
+    price decimal(10,2),
+    amount int,
+    weight int
+);
+
+create table if not exists product_image (
+    id uuid primary key default uuid_generate_v4(),
+    id_product uuid,
+    position int,
+    is_primary boolean,
+    content_type varchar(50),
+    size bigint,
+    storage_key text,
+    created_at timestamp without time zone
+);
+
+create table if not exists product_price (
+    id uuid primary key default uuid_generate_v4(),
+    id_product uuid,
+    price decimal(10,2),
+    valid_from timestamp without time zone,
+    valid_to timestamp without time zone
+);
+
+create table if not exists ingredient (
+    id uuid primary key default uuid_generate_v4(),
+    inci_name varchar(255),
+    name varchar(255)
+);
+
+create table if not exists attribute (
+    id uuid primary key default uuid_generate_v4(),
+    kind varchar(50),
+    code varchar(100),
+    name varchar(255)
+);
+
+create table if not exists product_ingredient (
+    id_product uuid,
+    id_ingredient uuid,
+    position int,
+    primary key (id_product, id_ingredient)
+);
+
+create table if not exists product_attribute (
+    id_product uuid,
+    id_attribute uuid,
+    primary key (id_product, id_attribute)
+);
+
+create table if not exists product_properties (
+    id_product uuid primary key,
+    spf int,
+    vegan boolean,
+    cruelty_free boolean
+);
+
+create table if not exists user_allergen (
+    id_user uuid,
+    id_ingredient uuid,
+    primary key (id_user, id_ingredient)
 );
 
 create table if not exists basket (
@@ -56,6 +171,7 @@ create table if not exists basket_item (
     id uuid primary key default uuid_generate_v4(),
     id_basket uuid,
     id_product uuid,
+    id_variant uuid,
     amount int
 );
 
@@ -82,7 +198,11 @@ create table if not exists "order" (
     id_user uuid,
     address text,
     status varchar(50),
-    price decimal(10,2)
+    price decimal(10,2),
+    id_delivery uuid,
+    zone varchar(100),
+    id_slot uuid,
+    shipping_cost decimal(10,2)
 );
 
 create table if not exists order_worker (
@@ -93,11 +213,62 @@ create table if not exists order_worker (
     constraint fk_worker foreign key (id_worker) references worker(id) on delete set null
 );
 
+create table if not exists order_status_history (
+    id uuid primary key default uuid_generate_v4(),
+    id_order uuid,
+    from_status varchar(50),
+    to_status varchar(50),
+    id_actor uuid,
+    role varchar(20),
+    comment text default '',
+    created_at timestamp default current_timestamp
+);
+
 create table if not exists order_item (
     id uuid primary key default uuid_generate_v4(),
     id_order uuid,
     id_product uuid,
-    amount int
+    id_variant uuid,
+    amount int,
+    price decimal(10,2),
+    discount decimal(10,2),
+    name varchar(255),
+    art varchar(50),
+    brand varchar(255)
+);
+
+create table if not exists promotion (
+    id uuid primary key default uuid_generate_v4(),
+    name varchar(255),
+    kind varchar(50),
+    code varchar(32),
+    value decimal(10,2),
+    buy_quantity int,
+    free_quantity int,
+    id_brand uuid,
+    id_category uuid,
+    min_basket decimal(10,2),
+    usage_limit int,
+    per_user_limit int,
+    starts_at timestamp without time zone,
+    ends_at timestamp without time zone,
+    active boolean
+);
+
+create table if not exists promotion_redemption (
+    id uuid primary key default uuid_generate_v4(),
+    id_promotion uuid,
+    id_user uuid,
+    id_order uuid,
+    amount decimal(10,2),
+    date timestamp without time zone
+);
+
+create table if not exists order_item_discount (
+    id_order_item uuid,
+    id_promotion uuid,
+    amount decimal(10,2),
+    primary key (id_order_item, id_promotion)
 );
 
 create table if not exists review (
@@ -109,6 +280,223 @@ create table if not exists review (
     date timestamp without time zone
 );
 
+create table if not exists product_rating (
+    id_product uuid primary key,
+    rating_avg decimal(3,2),
+    rating_count int,
+    stars_1 int,
+    stars_2 int,
+    stars_3 int,
+    stars_4 int,
+    stars_5 int
+);
+
+create table if not exists product_pair (
+    id_product uuid,
+    id_related uuid,
+    support int,
+    confidence decimal(5,4),
+    lift decimal(10,4),
+    primary key (id_product, id_related)
+);
+
+create table if not exists stock_lot (
+    id uuid primary key default uuid_generate_v4(),
+    id_product uuid,
+    id_variant uuid,
+    batch varchar(50),
+    expires_at date,
+    amount int default 0,
+    created_at timestamp default current_timestamp
+);
+
+create table if not exists stock_movement (
+    id uuid primary key default uuid_generate_v4(),
+    id_product uuid,
+    id_variant uuid,
+    id_lot uuid,
+    kind varchar(20),
+    quantity int,
+    balance int,
+    reason text,
+    id_actor uuid,
+    reference varchar(100),
+    created_at timestamp default current_timestamp
+);
+
+create table if not exists idempotency_key (
+    id_user uuid,
+    key varchar(255),
+    request_hash varchar(64),
+    status int,
+    content_type varchar(255),
+    body bytea,
+    expires_at timestamp
+);
+
+create table if not exists stock_reservation (
+    id uuid primary key default uuid_generate_v4(),
+    id_user uuid,
+    id_product uuid,
+    id_variant uuid,
+    amount int,
+    expires_at timestamp
+);
+
+create table if not exists product_reorder (
+    id_product uuid primary key,
+    reorder_point int,
+    reorder_quantity int
+);
+
+create table if not exists stock_alert (
+    id uuid primary key default uuid_generate_v4(),
+    id_product uuid,
+    amount int,
+    reorder_point int,
+    reorder_quantity int,
+    created_at timestamp default current_timestamp,
+    resolved_at timestamp
+);
+
+create table if not exists supplier (
+    id uuid primary key default uuid_generate_v4(),
+    name varchar(100),
+    email varchar(100),
+    phone varchar(20)
+);
+
+create table if not exists supplier_brand (
+    id_supplier uuid,
+    id_brand uuid
+);
+
+create table if not exists purchase_order (
+    id uuid primary key default uuid_generate_v4(),
+    id_supplier uuid,
+    status varchar(20),
+    expected_at timestamp,
+    note text,
+    created_at timestamp default current_timestamp
+);
+
+create table if not exists purchase_line (
+    id uuid primary key default uuid_generate_v4(),
+    id_order uuid,
+    id_product uuid,
+    id_variant uuid,
+    ordered int,
+    received int default 0
+);
+
+create table if not exists purchase_discrepancy (
+    id uuid primary key default uuid_generate_v4(),
+    id_order uuid,
+    id_line uuid,
+    ordered int,
+    received int,
+    difference int,
+    created_at timestamp default current_timestamp
+);
+
+create table if not exists order_item_lot (
+    id_order_item uuid,
+    id_lot uuid,
+    amount int
+);
+
+create table if not exists return_request (
+    id uuid primary key default uuid_generate_v4(),
+    id_order uuid,
+    id_user uuid,
+    status varchar(20),
+    reason text,
+    comment text default '',
+    id_admin uuid,
+    id_worker uuid,
+    created_at timestamp default current_timestamp,
+    decided_at timestamp,
+    received_at timestamp
+);
+
+create table if not exists return_line (
+    id uuid primary key default uuid_generate_v4(),
+    id_return uuid,
+    id_order_item uuid,
+    id_product uuid,
+    id_variant uuid,
+    amount int,
+    received int default 0,
+    condition varchar(20)
+);
+
+create table if not exists return_photo (
+    id uuid primary key default uuid_generate_v4(),
+    id_return uuid,
+    content_type varchar(50),
+    size bigint,
+    key varchar(255),
+    created_at timestamp default current_timestamp
+);
+
+create table if not exists refund (
+    id uuid primary key default uuid_generate_v4(),
+    id_return uuid,
+    id_order uuid,
+    id_payment uuid,
+    amount decimal(10,2),
+    status varchar(20),
+    provider_ref varchar(255),
+    error text default '',
+    created_at timestamp default current_timestamp,
+    processed_at timestamp
+);
+
+create table if not exists payment (
+    id uuid primary key default uuid_generate_v4(),
+    id_order uuid,
+    id_user uuid,
+    provider varchar(50),
+    intent_id varchar(255),
+    amount decimal(10,2),
+    status varchar(20),
+    error text default '',
+    created_at timestamp default current_timestamp,
+    updated_at timestamp default current_timestamp
+);
+
+create table if not exists payment_event (
+    id varchar(255) primary key,
+    id_payment uuid,
+    created_at timestamp default current_timestamp
+);
+
+create table if not exists delivery_method (
+    id uuid primary key default uuid_generate_v4(),
+    kind varchar(20),
+    name varchar(100),
+    free_from decimal(10,2) default 0,
+    active boolean default true
+);
+
+create table if not exists delivery_rate (
+    id uuid primary key default uuid_generate_v4(),
+    id_delivery uuid,
+    zone varchar(100),
+    max_weight int default 0,
+    min_total decimal(10,2) default 0,
+    cost decimal(10,2),
+    per_kg decimal(10,2) default 0
+);
+
+create table if not exists delivery_slot (
+    id uuid primary key default uuid_generate_v4(),
+    id_delivery uuid,
+    starts_at timestamp with time zone,
+    ends_at timestamp with time zone,
+    capacity int
+);
+
 create table if not exists token (
     id uuid primary key default uuid_generate_v4(),
     rtoken text
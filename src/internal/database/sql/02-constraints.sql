@@ -8,14 +8,116 @@ add constraint "user_phone_format" check (phone ~* '^(\+7|8)[\s\-()]?\d{3}[\s\-(
 add constraint "user_mail_unique" unique (mail),
 add constraint "user_phone_unique" unique (phone);
 
+-- CATEGORY
+alter table "category"
+alter column "name" set not null,
+alter column "slug" set not null,
+alter column "sort_order" set not null,
+alter column "sort_order" set default 0,
+add constraint "category_slug_unique" unique (slug),
+add constraint "category_slug_format" check (slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$'),
+add constraint "category_parent_check" check ("id_parent" <> "id"),
+add constraint "fk_category_parent" foreign key ("id_parent") references "category"("id") on delete restrict;
+
+create index if not exists "category_parent_idx" on "category" ("id_parent");
+
 -- PRODUCT
 alter table "product"
 alter column "name" set not null,
 alter column "price" set not null,
 alter column "amount" set not null,
 alter column "art" set not null,
+alter column "weight" set not null,
+alter column "weight" set default 0,
 add constraint "product_art_unique" unique (art),
-add constraint "fk_product_brand" foreign key ("id_brand") references "brand"("id") on delete set null;
+add constraint "product_amount_check" check ("amount" >= 0),
+add constraint "product_weight_check" check ("weight" >= 0),
+add constraint "fk_product_brand" foreign key ("id_brand") references "brand"("id") on delete set null,
+add constraint "fk_product_category" foreign key ("id_category") references "category"("id") on delete restrict;
+
+create index if not exists "product_category_idx" on "product" ("id_category");
+
+-- PRODUCT-VARIANT
+alter table "product_variant"
+alter column "id_product" set not null,
+alter column "art" set not null,
+alter column "price" set not null,
+alter column "amount" set not null,
+add constraint "product_variant_art_unique" unique (art),
+add constraint "product_variant_product_unique" unique ("id", "id_product"),
+add constraint "product_variant_attrs_unique" unique nulls not distinct ("id_product", "shade", "volume_ml"),
+add constraint "product_variant_price_check" check ("price" >= 0),
+add constraint "product_variant_amount_check" check ("amount" >= 0),
+add constraint "product_variant_weight_check" check ("weight" > 0),
+add constraint "fk_product_variant_product" foreign key ("id_product") references "product"("id") on delete cascade;
+
+-- PRODUCT-IMAGE
+alter table "product_image"
+alter column "id_product" set not null,
+alter column "storage_key" set not null,
+alter column "position" set default 0,
+alter column "is_primary" set default false,
+alter column "created_at" set default current_timestamp,
+add constraint "fk_product_image_product" foreign key ("id_product") references "product"("id") on delete cascade;
+
+create unique index if not exists "product_image_primary_unique" on "product_image" ("id_product") where "is_primary";
+
+-- PRODUCT-PRICE
+alter table "product_price"
+alter column "id_product" set not null,
+alter column "price" set not null,
+alter column "valid_from" set not null,
+add constraint "product_price_start_unique" unique ("id_product", "valid_from"),
+add constraint "product_price_price_check" check ("price" >= 0),
+add constraint "product_price_interval_check" check ("valid_to" > "valid_from"),
+add constraint "fk_product_price_product" foreign key ("id_product") references "product"("id") on delete cascade;
+
+-- INGREDIENT
+alter table "ingredient"
+alter column "inci_name" set not null,
+alter column "name" set not null,
+alter column "name" set default '';
+
+create unique index if not exists "ingredient_inci_name_unique" on "ingredient" (lower("inci_name"));
+
+-- ATTRIBUTE
+alter table "attribute"
+alter column "kind" set not null,
+alter column "code" set not null,
+alter column "name" set not null,
+add constraint "attribute_kind_code_unique" unique ("kind", "code"),
+add constraint "attribute_kind_check" check ("kind" in ('skin_type', 'hair_type', 'finish'));
+
+-- PRODUCT-INGREDIENT
+alter table "product_ingredient"
+alter column "position" set not null,
+add constraint "fk_product_ingredient_product" foreign key ("id_product") references "product"("id") on delete cascade,
+add constraint "fk_product_ingredient_ingredient" foreign key ("id_ingredient") references "ingredient"("id") on delete restrict;
+
+create index if not exists "product_ingredient_ingredient_idx" on "product_ingredient" ("id_ingredient");
+
+-- PRODUCT-ATTRIBUTE
+alter table "product_attribute"
+add constraint "fk_product_attribute_product" foreign key ("id_product") references "product"("id") on delete cascade,
+add constraint "fk_product_attribute_attribute" foreign key ("id_attribute") references "attribute"("id") on delete restrict;
+
+create index if not exists "product_attribute_attribute_idx" on "product_attribute" ("id_attribute");
+
+-- PRODUCT-PROPERTIES
+alter table "product_properties"
+alter column "spf" set not null,
+alter column "spf" set default 0,
+alter column "vegan" set not null,
+alter column "vegan" set default false,
+alter column "cruelty_free" set not null,
+alter column "cruelty_free" set default false,
+add constraint "product_properties_spf_check" check ("spf" between 0 and 100),
+add constraint "fk_product_properties_product" foreign key ("id_product") references "product"("id") on delete cascade;
+
+-- USER-ALLERGEN
+alter table "user_allergen"
+add constraint "fk_user_allergen_user" foreign key ("id_user") references "user"("id") on delete cascade,
+add constraint "fk_user_allergen_ingredient" foreign key ("id_ingredient") references "ingredient"("id") on delete cascade;
 
 -- BASKET
 alter table "basket"
@@ -25,7 +127,8 @@ add constraint "fk_basket_user" foreign key ("id_user") references "user"("id")
 -- BASKET-ITEM
 alter table "basket_item"
 add constraint "fk_basket_item_basket" foreign key ("id_basket") references "basket"("id") on delete cascade,
-add constraint "fk_basket_item_product" foreign key ("id_product") references "product"("id") on delete cascade;
+add constraint "fk_basket_item_product" foreign key ("id_product") references "product"("id") on delete cascade,
+add constraint "fk_basket_item_variant" foreign key ("id_variant", "id_product") references "product_variant"("id", "id_product") on delete cascade;
 
 -- FAVOURITES
 alter table "favourites"
@@ -44,16 +147,381 @@ add constraint "worker_user_unique" unique ("id_user");
 -- ORDER
 alter table "order"
 alter column "date" set default current_timestamp,
-add constraint "fk_order_user" foreign key ("id_user") references "user"("id") on delete cascade;
+alter column "zone" set not null,
+alter column "zone" set default '',
+alter column "shipping_cost" set not null,
+alter column "shipping_cost" set default 0,
+add constraint "order_shipping_cost_check" check ("shipping_cost" >= 0),
+add constraint "order_status_check" check ("status" in ('некорректный', 'неоплаченный', 'непринятый', 'принятый', 'собранный', 'отданный', 'отмененный')),
+add constraint "fk_order_user" foreign key ("id_user") references "user"("id") on delete cascade,
+add constraint "fk_order_delivery" foreign key ("id_delivery") references "delivery_method"("id") on delete restrict,
+add constraint "fk_order_slot" foreign key ("id_slot") references "delivery_slot"("id") on delete restrict;
+
+create index if not exists "order_date_idx" on "order" ("date", "id");
+create index if not exists "order_slot_idx" on "order" ("id_slot") where "id_slot" is not null;
+create index if not exists "order_price_idx" on "order" ("price", "id");
 
 --ORDER-ITEM
 alter table "order_item"
+alter column "price" set not null,
+alter column "discount" set not null,
+alter column "discount" set default 0,
+alter column "name" set not null,
+alter column "art" set not null,
+alter column "art" set default '',
+alter column "brand" set not null,
+alter column "brand" set default '',
+add constraint "order_item_price_check" check ("price" >= 0),
+add constraint "order_item_discount_check" check ("discount" >= 0),
 add constraint "fk_order_item_order" foreign key ("id_order") references "order"("id") on delete cascade,
-add constraint "fk_order_item_product" foreign key ("id_product") references "product"("id") on delete cascade;
+add constraint "fk_order_item_product" foreign key ("id_product") references "product"("id") on delete set null,
+add constraint "fk_order_item_variant" foreign key ("id_variant", "id_product") references "product_variant"("id", "id_product");
+
+create index if not exists "order_item_order_idx" on "order_item" ("id_order");
+create index if not exists "order_item_product_idx" on "order_item" ("id_product");
+
+-- PROMOTION
+alter table "promotion"
+alter column "name" set not null,
+alter column "kind" set not null,
+alter column "value" set not null,
+alter column "value" set default 0,
+alter column "buy_quantity" set not null,
+alter column "buy_quantity" set default 0,
+alter column "free_quantity" set not null,
+alter column "free_quantity" set default 0,
+alter column "min_basket" set not null,
+alter column "min_basket" set default 0,
+alter column "usage_limit" set not null,
+alter column "usage_limit" set default 0,
+alter column "per_user_limit" set not null,
+alter column "per_user_limit" set default 0,
+alter column "active" set not null,
+alter column "active" set default true,
+add constraint "promotion_code_unique" unique ("code"),
+add constraint "promotion_kind_check" check ("kind" in ('percent', 'fixed', 'bundle')),
+add constraint "promotion_value_check" check ("value" >= 0 and ("kind" <> 'percent' or "value" <= 100)),
+add constraint "promotion_fixed_code_check" check ("kind" <> 'fixed' or "code" is not null),
+add constraint "promotion_interval_check" check ("ends_at" > "starts_at"),
+add constraint "fk_promotion_brand" foreign key ("id_brand") references "brand"("id") on delete cascade,
+add constraint "fk_promotion_category" foreign key ("id_category") references "category"("id") on delete cascade;
+
+-- PROMOTION-REDEMPTION
+alter table "promotion_redemption"
+alter column "date" set default current_timestamp,
+add constraint "fk_promotion_redemption_promotion" foreign key ("id_promotion") references "promotion"("id") on delete cascade,
+add constraint "fk_promotion_redemption_user" foreign key ("id_user") references "user"("id") on delete cascade,
+add constraint "fk_promotion_redemption_order" foreign key ("id_order") references "order"("id") on delete cascade;
+
+create index if not exists "promotion_redemption_promotion_idx" on "promotion_redemption" ("id_promotion", "id_user");
+
+-- ORDER-ITEM-DISCOUNT
+alter table "order_item_discount"
+alter column "amount" set not null,
+add constraint "order_item_discount_amount_check" check ("amount" > 0),
+add constraint "fk_order_item_discount_item" foreign key ("id_order_item") references "order_item"("id") on delete cascade,
+add constraint "fk_order_item_discount_promotion" foreign key ("id_promotion") references "promotion"("id") on delete restrict;
 
 -- REVIEW
 alter table "review"
 alter column "date" set default current_timestamp,
 add constraint "fk_review_user" foreign key ("id_user") references "user"("id") on delete cascade,
 add constraint "fk_review_product" foreign key ("id_product") references "product"("id") on delete cascade,
-add constraint "review_rating_check" check ("rating" between 1 and 5);
\ No newline at end of file
+add constraint "review_rating_check" check ("rating" between 1 and 5);
+
+create index if not exists "review_product_idx" on "review" ("id_product");
+
+-- PRODUCT-RATING
+alter table "product_rating"
+alter column "rating_avg" set not null,
+alter column "rating_count" set not null,
+alter column "stars_1" set not null,
+alter column "stars_2" set not null,
+alter column "stars_3" set not null,
+alter column "stars_4" set not null,
+alter column "stars_5" set not null,
+add constraint "product_rating_avg_check" check ("rating_avg" between 0 and 5),
+add constraint "product_rating_count_check" check ("rating_count" = "stars_1" + "stars_2" + "stars_3" + "stars_4" + "stars_5"),
+add constraint "fk_product_rating_product" foreign key ("id_product") references "product"("id") on delete cascade;
+
+create index if not exists "product_rating_sort_idx" on "product_rating" ("rating_avg" desc, "rating_count" desc);
+
+-- PRODUCT-PAIR
+alter table "product_pair"
+alter column "support" set not null,
+alter column "confidence" set not null,
+alter column "lift" set not null,
+add constraint "product_pair_support_check" check ("support" > 0),
+add constraint "product_pair_confidence_check" check ("confidence" between 0 and 1),
+add constraint "product_pair_lift_check" check ("lift" > 0),
+add constraint "product_pair_distinct_check" check ("id_product" <> "id_related"),
+add constraint "fk_product_pair_product" foreign key ("id_product") references "product"("id") on delete cascade,
+add constraint "fk_product_pair_related" foreign key ("id_related") references "product"("id") on delete cascade;
+
+create index if not exists "product_pair_rank_idx" on "product_pair" ("id_product", "confidence" desc, "lift" desc);
+
+-- STOCK-LOT
+alter table "stock_lot"
+alter column "id_product" set not null,
+alter column "batch" set not null,
+alter column "expires_at" set not null,
+alter column "amount" set not null,
+alter column "created_at" set not null,
+add constraint "stock_lot_amount_check" check ("amount" >= 0),
+add constraint "stock_lot_batch_unique" unique nulls not distinct ("id_product", "id_variant", "batch"),
+add constraint "fk_stock_lot_product" foreign key ("id_product") references "product"("id") on delete cascade,
+add constraint "fk_stock_lot_variant" foreign key ("id_variant", "id_product") references "product_variant"("id", "id_product") on delete cascade;
+
+create index if not exists "stock_lot_expires_idx" on "stock_lot" ("expires_at") where "amount" > 0;
+
+-- STOCK-MOVEMENT
+alter table "stock_movement"
+alter column "id_product" set not null,
+alter column "kind" set not null,
+alter column "quantity" set not null,
+alter column "balance" set not null,
+alter column "created_at" set not null,
+add constraint "stock_movement_kind_check" check ("kind" in ('receipt', 'sale', 'return', 'adjustment', 'write_off')),
+add constraint "stock_movement_quantity_check" check (
+    "quantity" <> 0
+    and ("kind" not in ('receipt', 'return') or "quantity" > 0)
+    and ("kind" not in ('sale', 'write_off') or "quantity" < 0)),
+add constraint "stock_movement_balance_check" check ("balance" >= 0),
+add constraint "fk_stock_movement_product" foreign key ("id_product") references "product"("id") on delete cascade,
+add constraint "fk_stock_movement_variant" foreign key ("id_variant", "id_product") references "product_variant"("id", "id_product") on delete cascade,
+add constraint "fk_stock_movement_lot" foreign key ("id_lot") references "stock_lot"("id") on delete cascade;
+
+create index if not exists "stock_movement_product_idx" on "stock_movement" ("id_product", "created_at" desc);
+
+-- STOCK-RESERVATION
+alter table "stock_reservation"
+alter column "id_user" set not null,
+alter column "id_product" set not null,
+alter column "amount" set not null,
+alter column "expires_at" set not null,
+add constraint "stock_reservation_amount_check" check ("amount" > 0),
+add constraint "stock_reservation_item_unique" unique nulls not distinct ("id_user", "id_product", "id_variant"),
+add constraint "fk_stock_reservation_user" foreign key ("id_user") references "user"("id") on delete cascade,
+add constraint "fk_stock_reservation_product" foreign key ("id_product") references "product"("id") on delete cascade,
+add constraint "fk_stock_reservation_variant" foreign key ("id_variant", "id_product") references "product_variant"("id", "id_product") on delete cascade;
+
+create index if not exists "stock_reservation_product_idx" on "stock_reservation" ("id_product", "id_variant");
+create index if not exists "stock_reservation_expires_idx" on "stock_reservation" ("expires_at");
+
+-- PRODUCT-REORDER
+alter table "product_reorder"
+alter column "reorder_point" set not null,
+alter column "reorder_quantity" set not null,
+add constraint "product_reorder_point_check" check ("reorder_point" >= 0),
+add constraint "product_reorder_quantity_check" check ("reorder_quantity" > 0),
+add constraint "fk_product_reorder_product" foreign key ("id_product") references "product"("id") on delete cascade;
+
+-- STOCK-ALERT
+alter table "stock_alert"
+alter column "id_product" set not null,
+alter column "amount" set not null,
+alter column "reorder_point" set not null,
+alter column "reorder_quantity" set not null,
+alter column "created_at" set not null,
+add constraint "fk_stock_alert_product" foreign key ("id_product") references "product"("id") on delete cascade;
+
+create unique index if not exists "stock_alert_open_unique" on "stock_alert" ("id_product") where "resolved_at" is null;
+
+-- SUPPLIER
+alter table "supplier"
+alter column "name" set not null,
+add constraint "supplier_name_unique" unique ("name");
+
+-- SUPPLIER-BRAND
+alter table "supplier_brand"
+add constraint "supplier_brand_pkey" primary key ("id_supplier", "id_brand"),
+add constraint "fk_supplier_brand_supplier" foreign key ("id_supplier") references "supplier"("id") on delete cascade,
+add constraint "fk_supplier_brand_brand" foreign key ("id_brand") references "brand"("id") on delete cascade;
+
+-- PURCHASE-ORDER
+alter table "purchase_order"
+alter column "id_supplier" set not null,
+alter column "status" set not null,
+alter column "created_at" set not null,
+add constraint "purchase_order_status_check" check ("status" in ('draft', 'sent', 'partially_received', 'received')),
+add constraint "fk_purchase_order_supplier" foreign key ("id_supplier") references "supplier"("id") on delete restrict;
+
+create index if not exists "purchase_order_status_idx" on "purchase_order" ("status", "created_at" desc);
+
+-- PURCHASE-LINE
+alter table "purchase_line"
+alter column "id_order" set not null,
+alter column "id_product" set not null,
+alter column "ordered" set not null,
+alter column "received" set not null,
+add constraint "purchase_line_ordered_check" check ("ordered" > 0),
+add constraint "purchase_line_received_check" check ("received" >= 0),
+add constraint "purchase_line_item_unique" unique nulls not distinct ("id_order", "id_product", "id_variant"),
+add constraint "fk_purchase_line_order" foreign key ("id_order") references "purchase_order"("id") on delete cascade,
+add constraint "fk_purchase_line_product" foreign key ("id_product") references "product"("id") on delete cascade,
+add constraint "fk_purchase_line_variant" foreign key ("id_variant", "id_product") references "product_variant"("id", "id_product") on delete cascade;
+
+-- PURCHASE-DISCREPANCY
+alter table "purchase_discrepancy"
+alter column "id_order" set not null,
+alter column "id_line" set not null,
+alter column "ordered" set not null,
+alter column "received" set not null,
+alter column "difference" set not null,
+alter column "created_at" set not null,
+add constraint "purchase_discrepancy_difference_check" check ("difference" = "received" - "ordered" and "difference" <> 0),
+add constraint "fk_purchase_discrepancy_order" foreign key ("id_order") references "purchase_order"("id") on delete cascade,
+add constraint "fk_purchase_discrepancy_line" foreign key ("id_line") references "purchase_line"("id") on delete cascade;
+
+-- ORDER-ITEM-LOT
+alter table "order_item_lot"
+alter column "id_order_item" set not null,
+alter column "id_lot" set not null,
+alter column "amount" set not null,
+add constraint "order_item_lot_pkey" primary key ("id_order_item", "id_lot"),
+add constraint "order_item_lot_amount_check" check ("amount" > 0),
+add constraint "fk_order_item_lot_item" foreign key ("id_order_item") references "order_item"("id") on delete cascade,
+add constraint "fk_order_item_lot_lot" foreign key ("id_lot") references "stock_lot"("id") on delete cascade;
+
+create index if not exists "order_item_lot_lot_idx" on "order_item_lot" ("id_lot");
+
+-- ORDER-STATUS-HISTORY
+alter table "order_status_history"
+alter column "id_order" set not null,
+alter column "to_status" set not null,
+alter column "role" set not null,
+alter column "comment" set not null,
+alter column "created_at" set not null,
+add constraint "order_status_history_from_check" check ("from_status" in ('некорректный', 'неоплаченный', 'непринятый', 'принятый', 'собранный', 'отданный', 'отмененный')),
+add constraint "order_status_history_to_check" check ("to_status" in ('некорректный', 'неоплаченный', 'непринятый', 'принятый', 'собранный', 'отданный', 'отмененный')),
+add constraint "order_status_history_role_check" check ("role" in ('customer', 'worker', 'admin')),
+add constraint "fk_order_status_history_order" foreign key ("id_order") references "order"("id") on delete cascade;
+
+create index if not exists "order_status_history_order_idx" on "order_status_history" ("id_order", "created_at");
+
+-- IDEMPOTENCY-KEY
+alter table "idempotency_key"
+alter column "id_user" set not null,
+alter column "key" set not null,
+alter column "request_hash" set not null,
+alter column "expires_at" set not null,
+add constraint "idempotency_key_pk" primary key ("id_user", "key"),
+add constraint "idempotency_key_status_check" check ("status" between 100 and 599),
+add constraint "fk_idempotency_key_user" foreign key ("id_user") references "user"("id") on delete cascade;
+
+create index if not exists "idempotency_key_expires_idx" on "idempotency_key" ("expires_at");
+
+
+-- RETURN-REQUEST
+alter table "return_request"
+alter column "id_order" set not null,
+alter column "id_user" set not null,
+alter column "status" set not null,
+alter column "reason" set not null,
+alter column "comment" set not null,
+alter column "created_at" set not null,
+add constraint "return_request_status_check" check ("status" in ('requested', 'approved', 'rejected', 'received')),
+add constraint "fk_return_request_order" foreign key ("id_order") references "order"("id") on delete cascade,
+add constraint "fk_return_request_user" foreign key ("id_user") references "user"("id") on delete cascade;
+
+create index if not exists "return_request_order_idx" on "return_request" ("id_order");
+create index if not exists "return_request_user_idx" on "return_request" ("id_user", "created_at" desc);
+create index if not exists "return_request_status_idx" on "return_request" ("status", "created_at" desc);
+
+-- RETURN-LINE
+alter table "return_line"
+alter column "id_return" set not null,
+alter column "id_order_item" set not null,
+alter column "amount" set not null,
+alter column "received" set not null,
+add constraint "return_line_amount_check" check ("amount" > 0),
+add constraint "return_line_received_check" check ("received" between 0 and "amount"),
+add constraint "return_line_condition_check" check ("condition" in ('sellable', 'damaged')),
+add constraint "return_line_item_unique" unique ("id_return", "id_order_item"),
+add constraint "fk_return_line_return" foreign key ("id_return") references "return_request"("id") on delete cascade,
+add constraint "fk_return_line_item" foreign key ("id_order_item") references "order_item"("id") on delete cascade;
+
+create index if not exists "return_line_item_idx" on "return_line" ("id_order_item");
+
+-- RETURN-PHOTO
+alter table "return_photo"
+alter column "id_return" set not null,
+alter column "content_type" set not null,
+alter column "size" set not null,
+alter column "key" set not null,
+alter column "created_at" set not null,
+add constraint "fk_return_photo_return" foreign key ("id_return") references "return_request"("id") on delete cascade;
+
+-- REFUND
+alter table "refund"
+alter column "id_order" set not null,
+alter column "amount" set not null,
+alter column "status" set not null,
+alter column "created_at" set not null,
+add constraint "refund_amount_check" check ("amount" > 0),
+alter column "error" set not null,
+add constraint "refund_status_check" check ("status" in ('pending', 'succeeded', 'failed')),
+add constraint "refund_return_unique" unique ("id_return"),
+add constraint "fk_refund_return" foreign key ("id_return") references "return_request"("id") on delete cascade,
+add constraint "fk_refund_order" foreign key ("id_order") references "order"("id") on delete cascade,
+add constraint "fk_refund_payment" foreign key ("id_payment") references "payment"("id") on delete cascade;
+
+create index if not exists "refund_pending_idx" on "refund" ("created_at") where "status" = 'pending';
+
+-- PAYMENT
+alter table "payment"
+alter column "id_order" set not null,
+alter column "id_user" set not null,
+alter column "provider" set not null,
+alter column "amount" set not null,
+alter column "status" set not null,
+alter column "error" set not null,
+alter column "created_at" set not null,
+alter column "updated_at" set not null,
+add constraint "payment_amount_check" check ("amount" >= 0),
+add constraint "payment_status_check" check ("status" in ('pending', 'succeeded', 'failed')),
+add constraint "payment_intent_unique" unique ("provider", "intent_id"),
+add constraint "fk_payment_order" foreign key ("id_order") references "order"("id") on delete cascade,
+add constraint "fk_payment_user" foreign key ("id_user") references "user"("id") on delete cascade;
+
+create index if not exists "payment_order_idx" on "payment" ("id_order", "created_at");
+
+-- PAYMENT-EVENT
+alter table "payment_event"
+alter column "id_payment" set not null,
+alter column "created_at" set not null,
+add constraint "fk_payment_event_payment" foreign key ("id_payment") references "payment"("id") on delete cascade;
+
+-- DELIVERY-METHOD
+alter table "delivery_method"
+alter column "kind" set not null,
+alter column "name" set not null,
+alter column "free_from" set not null,
+alter column "active" set not null,
+add constraint "delivery_method_kind_check" check ("kind" in ('courier', 'pickup', 'post')),
+add constraint "delivery_method_free_from_check" check ("free_from" >= 0);
+
+-- DELIVERY-RATE
+alter table "delivery_rate"
+alter column "id_delivery" set not null,
+alter column "zone" set not null,
+alter column "max_weight" set not null,
+alter column "min_total" set not null,
+alter column "cost" set not null,
+alter column "per_kg" set not null,
+add constraint "delivery_rate_values_check" check ("max_weight" >= 0 and "min_total" >= 0 and "cost" >= 0 and "per_kg" >= 0),
+add constraint "fk_delivery_rate_method" foreign key ("id_delivery") references "delivery_method"("id") on delete cascade;
+
+create index if not exists "delivery_rate_method_idx" on "delivery_rate" ("id_delivery");
+
+-- DELIVERY-SLOT
+alter table "delivery_slot"
+alter column "id_delivery" set not null,
+alter column "starts_at" set not null,
+alter column "ends_at" set not null,
+alter column "capacity" set not null,
+add constraint "delivery_slot_capacity_check" check ("capacity" > 0),
+add constraint "delivery_slot_interval_check" check ("ends_at" > "starts_at"),
+add constraint "fk_delivery_slot_method" foreign key ("id_delivery") references "delivery_method"("id") on delete cascade;
+
+create index if not exists "delivery_slot_method_idx" on "delivery_slot" ("id_delivery", "starts_at");
\ No newline at end of file
@@ -34,18 +34,105 @@ INSERT INTO brand (name, description, price_category) VALUES
 ('La Roche-Posay', 'Французская аптечная косметика', 'люкс'),
 ('NYX', 'Бренд профессиональной декоративной косметики', 'средний');
 
-INSERT INTO product (name, description, price, category, amount, id_brand, pic_link, art) VALUES
-('Тональный крем True Match', 'Тональный крем с SPF 30, 30 мл', 2500.00, 'декоративная', 15, (SELECT id FROM brand WHERE name = 'L''Oreal'), '/images/foundation.jpg', 'LOR-TM-001'),
-('Тушь для ресниц Lash Sensational', 'Объемная тушь для ресниц', 1200.00, 'декоративная', 50, (SELECT id FROM brand WHERE name = 'Maybelline'), '/images/mascara.jpg', 'MAY-LS-002'),
-('Увлажняющий крем Soft', 'Крем для лица с гиалуроновой кислотой, 50 мл', 1800.00, 'уход', 30, (SELECT id FROM brand WHERE name = 'Nivea'), '/images/moisturizer.jpg', 'NIV-SF-003'),
-('Помада Super Lustrous', 'Стойкая матовая помада, 4.5 г', 1500.00, 'декоративная', 25, (SELECT id FROM brand WHERE name = 'Revlon'), '/images/lipstick.jpg', 'REV-SL-004'),
-('Сыворотка Vitamin C', 'Сыворотка с витамином С, 30 мл', 3500.00, 'уход', 20, (SELECT id FROM brand WHERE name = 'The Ordinary'), '/images/serum.jpg', 'ORD-VC-005'),
-('Очищающий гель Pure Active', 'Гель для умывания для проблемной кожи', 900.00, 'уход', 100, (SELECT id FROM brand WHERE name = 'Garnier'), '/images/cleanser.jpg', 'GAR-PA-006'),
-('Тени для век Ultimate', 'Палетка теней, 12 оттенков', 2800.00, 'декоративная', 35, (SELECT id FROM brand WHERE name = 'NYX'), '/images/eyeshadow.jpg', 'NYX-UL-007'),
-('Солнцезащитный крем Anthelios', 'Крем с SPF 50, 50 мл', 2200.00, 'уход', 18, (SELECT id FROM brand WHERE name = 'La Roche-Posay'), '/images/sunscreen.jpg', 'LRP-AN-008'),
-('Духи Beautiful', 'Цветочный аромат, 50 мл', 6500.00, 'парфюмерия', 40, (SELECT id FROM brand WHERE name = 'Estée Lauder'), '/images/perfume.jpg', 'EST-BF-009'),
-('Крем для рук Deep Comfort', 'Интенсивный уход за сухой кожей рук', 800.00, 'уход', 22, (SELECT id FROM brand WHERE name = 'Clinique'), '/images/handcream.jpg', 'CLI-DC-010');
+-- Дерево категорий
+INSERT INTO category (name, slug, sort_order) VALUES
+('уход', 'skincare', 1),
+('декоративная', 'makeup', 2),
+('парфюмерия', 'perfume', 3),
+('для волос', 'hair', 4),
+('мужская', 'men', 5);
 
+INSERT INTO category (id_parent, name, slug, sort_order) VALUES
+((SELECT id FROM category WHERE slug = 'skincare'), 'лицо', 'skincare-face', 1),
+((SELECT id FROM category WHERE slug = 'skincare'), 'руки', 'skincare-hands', 2),
+((SELECT id FROM category WHERE slug = 'skincare'), 'солнцезащита', 'skincare-sun', 3),
+((SELECT id FROM category WHERE slug = 'makeup'), 'лицо', 'makeup-face', 1),
+((SELECT id FROM category WHERE slug = 'makeup'), 'глаза', 'makeup-eyes', 2),
+((SELECT id FROM category WHERE slug = 'makeup'), 'губы', 'makeup-lips', 3);
+
+INSERT INTO product (name, description, price, id_category, amount, id_brand, pic_link, art) VALUES
+('Тональный крем True Match', 'Тональный крем с SPF 30, 30 мл', 2500.00, (SELECT id FROM category WHERE slug = 'makeup-face'), 15, (SELECT id FROM brand WHERE name = 'L''Oreal'), '/images/foundation.jpg', 'LOR-TM-001'),
+('Тушь для ресниц Lash Sensational', 'Объемная тушь для ресниц', 1200.00, (SELECT id FROM category WHERE slug = 'makeup-eyes'), 50, (SELECT id FROM brand WHERE name = 'Maybelline'), '/images/mascara.jpg', 'MAY-LS-002'),
+('Увлажняющий крем Soft', 'Крем для лица с гиалуроновой кислотой, 50 мл', 1800.00, (SELECT id FROM category WHERE slug = 'skincare-face'), 30, (SELECT id FROM brand WHERE name = 'Nivea'), '/images/moisturizer.jpg', 'NIV-SF-003'),
+('Помада Super Lustrous', 'Стойкая матовая помада, 4.5 г', 1500.00, (SELECT id FROM category WHERE slug = 'makeup-lips'), 25, (SELECT id FROM brand WHERE name = 'Revlon'), '/images/lipstick.jpg', 'REV-SL-004'),
+('Сыворотка Vitamin C', 'Сыворотка с витамином С, 30 мл', 3500.00, (SELECT id FROM category WHERE slug = 'skincare-face'), 20, (SELECT id FROM brand WHERE name = 'The Ordinary'), '/images/serum.jpg', 'ORD-VC-005'),
+('Очищающий гель Pure Active', 'Гель для умывания для проблемной кожи', 900.00, (SELECT id FROM category WHERE slug = 'skincare-face'), 100, (SELECT id FROM brand WHERE name = 'Garnier'), '/images/cleanser.jpg', 'GAR-PA-006'),
+('Тени для век Ultimate', 'Палетка теней, 12 оттенков', 2800.00, (SELECT id FROM category WHERE slug = 'makeup-eyes'), 35, (SELECT id FROM brand WHERE name = 'NYX'), '/images/eyeshadow.jpg', 'NYX-UL-007'),
+('Солнцезащитный крем Anthelios', 'Крем с SPF 50, 50 мл', 2200.00, (SELECT id FROM category WHERE slug = 'skincare-sun'), 18, (SELECT id FROM brand WHERE name = 'La Roche-Posay'), '/images/sunscreen.jpg', 'LRP-AN-008'),
+('Духи Beautiful', 'Цветочный аромат, 50 мл', 6500.00, (SELECT id FROM category WHERE slug = 'perfume'), 40, (SELECT id FROM brand WHERE name = 'Estée Lauder'), '/images/perfume.jpg', 'EST-BF-009'),
+('Крем для рук Deep Comfort', 'Интенсивный уход за сухой кожей рук', 800.00, (SELECT id FROM category WHERE slug = 'skincare-hands'), 22, (SELECT id FROM brand WHERE name = 'Clinique'), '/images/handcream.jpg', 'CLI-DC-010');
+
+
+-- Справочники состава и свойств
+INSERT INTO ingredient (inci_name, name) VALUES
+('Aqua', 'вода'),
+('Glycerin', 'глицерин'),
+('Sodium Hyaluronate', 'гиалуронат натрия'),
+('Ascorbic Acid', 'витамин C'),
+('Niacinamide', 'ниацинамид'),
+('Parfum', 'отдушка'),
+('Alcohol Denat.', 'денатурированный спирт'),
+('Linalool', 'линалоол'),
+('Cera Alba', 'пчелиный воск'),
+('Titanium Dioxide', 'диоксид титана');
+
+INSERT INTO attribute (kind, code, name) VALUES
+('skin_type', 'dry', 'сухая'),
+('skin_type', 'oily', 'жирная'),
+('skin_type', 'combination', 'комбинированная'),
+('skin_type', 'sensitive', 'чувствительная'),
+('skin_type', 'normal', 'нормальная'),
+('hair_type', 'dry', 'сухие'),
+('hair_type', 'oily', 'жирные'),
+('hair_type', 'colored', 'окрашенные'),
+('finish', 'matte', 'матовый'),
+('finish', 'satin', 'сатиновый'),
+('finish', 'glossy', 'глянцевый');
+
+INSERT INTO product_ingredient (id_product, id_ingredient, position)
+SELECT p.id, i.id, c.position
+FROM (VALUES
+  ('LOR-TM-001', 'Aqua', 0), ('LOR-TM-001', 'Titanium Dioxide', 1), ('LOR-TM-001', 'Glycerin', 2), ('LOR-TM-001', 'Parfum', 3),
+  ('NIV-SF-003', 'Aqua', 0), ('NIV-SF-003', 'Glycerin', 1), ('NIV-SF-003', 'Sodium Hyaluronate', 2), ('NIV-SF-003', 'Parfum', 3),
+  ('REV-SL-004', 'Cera Alba', 0), ('REV-SL-004', 'Titanium Dioxide', 1), ('REV-SL-004', 'Parfum', 2),
+  ('ORD-VC-005', 'Ascorbic Acid', 0), ('ORD-VC-005', 'Glycerin', 1),
+  ('GAR-PA-006', 'Aqua', 0), ('GAR-PA-006', 'Niacinamide', 1), ('GAR-PA-006', 'Glycerin', 2),
+  ('LRP-AN-008', 'Aqua', 0), ('LRP-AN-008', 'Titanium Dioxide', 1), ('LRP-AN-008', 'Glycerin', 2),
+  ('EST-BF-009', 'Alcohol Denat.', 0), ('EST-BF-009', 'Parfum', 1), ('EST-BF-009', 'Linalool', 2),
+  ('CLI-DC-010', 'Aqua', 0), ('CLI-DC-010', 'Glycerin', 1), ('CLI-DC-010', 'Cera Alba', 2)
+) AS c(art, inci_name, position)
+JOIN product p ON p.art = c.art
+JOIN ingredient i ON i.inci_name = c.inci_name;
+
+INSERT INTO product_attribute (id_product, id_attribute)
+SELECT p.id, a.id
+FROM (VALUES
+  ('LOR-TM-001', 'skin_type', 'normal'), ('LOR-TM-001', 'skin_type', 'combination'), ('LOR-TM-001', 'finish', 'satin'),
+  ('NIV-SF-003', 'skin_type', 'dry'), ('NIV-SF-003', 'skin_type', 'normal'),
+  ('REV-SL-004', 'finish', 'matte'),
+  ('GAR-PA-006', 'skin_type', 'oily'), ('GAR-PA-006', 'skin_type', 'combination'),
+  ('LRP-AN-008', 'skin_type', 'sensitive'), ('LRP-AN-008', 'finish', 'matte')
+) AS c(art, kind, code)
+JOIN product p ON p.art = c.art
+JOIN attribute a ON a.kind = c.kind AND a.code = c.code;
+
+INSERT INTO product_properties (id_product, spf, vegan, cruelty_free)
+SELECT p.id, c.spf, c.vegan, c.cruelty_free
+FROM (VALUES
+  ('LOR-TM-001', 30, false, false),
+  ('NIV-SF-003', 0, true, false),
+  ('ORD-VC-005', 0, true, true),
+  ('GAR-PA-006', 0, true, true),
+  ('LRP-AN-008', 50, false, true)
+) AS c(art, spf, vegan, cruelty_free)
+JOIN product p ON p.art = c.art;
+
+-- Акции и промокоды
+INSERT INTO promotion (name, kind, code, value, buy_quantity, free_quantity, id_brand, id_category, min_basket, usage_limit, per_user_limit) VALUES
+('Неделя Garnier', 'percent', NULL, 15, 0, 0, (SELECT id FROM brand WHERE name = 'Garnier'), NULL, 0, 0, 0),
+('Две туши по цене одной', 'bundle', NULL, 0, 1, 1, NULL, (SELECT id FROM category WHERE slug = 'makeup-eyes'), 0, 0, 0),
+('Скидка новым покупателям', 'fixed', 'WELCOME500', 500, 0, 0, NULL, NULL, 3000, 0, 1),
+('Уход за кожей', 'percent', 'SKIN10', 10, 0, 0, NULL, (SELECT id FROM category WHERE slug = 'skincare'), 0, 100, 2);
 
 -- Вставляем 4 работника (1 админ и 3 работника склада)
 INSERT INTO worker (id_user, job_title)
@@ -120,16 +207,22 @@ WITH order_list AS (
   SELECT id, row_number() OVER () as rn FROM "order" LIMIT 10
 ),
 product_list AS (
-  SELECT id, row_number() OVER () as rn FROM product LIMIT 10
+  SELECT p.id, p.price, p.name, coalesce(p.art, '') as art, coalesce(b.name, '') as brand,
+    row_number() OVER () as rn
+  FROM product p LEFT JOIN brand b ON b.id = p.id_brand LIMIT 10
 ),
 series AS (
   SELECT generate_series(1, 10) as i
 )
-INSERT INTO order_item (id_order, id_product, amount)
+INSERT INTO order_item (id_order, id_product, amount, price, name, art, brand)
 SELECT 
   o.id,
   p.id,
-  (random() * 3 + 1)::int
+  (random() * 3 + 1)::int,
+  p.price,
+  p.name,
+  p.art,
+  p.brand
 FROM series s
 JOIN order_list o ON o.rn = s.i
 JOIN product_list p ON p.rn = s.i;
@@ -185,3 +278,29 @@ SELECT
 FROM series s
 JOIN favourites_list f ON f.rn = ((s.i - 1) / 2) + 1  -- по 2 товара на избранное
 JOIN product_list p ON p.rn = s.i;
+
+-- Способы доставки: самовывоз, почта и курьер по Москве
+INSERT INTO delivery_method (kind, name, free_from) VALUES
+('pickup', 'Самовывоз из пункта выдачи', 0),
+('post', 'Почта России', 0),
+('courier', 'Курьер', 5000);
+
+INSERT INTO delivery_rate (id_delivery, zone, max_weight, min_total, cost, per_kg)
+SELECT m.id, r.zone, r.max_weight, r.min_total, r.cost, r.per_kg
+FROM delivery_method m
+JOIN (VALUES
+  ('pickup', 'moscow', 0, 0, 0, 0),
+  ('pickup', 'saint petersburg', 0, 0, 99, 0),
+  ('post', 'russia', 20000, 0, 250, 40),
+  ('post', 'russia', 20000, 3000, 150, 40),
+  ('courier', 'moscow', 15000, 0, 390, 0),
+  ('courier', 'moscow oblast', 15000, 0, 590, 30)
+) AS r(kind, zone, max_weight, min_total, cost, per_kg) ON r.kind = m.kind;
+
+-- Слоты курьерской доставки на неделю вперед, по два в день
+INSERT INTO delivery_slot (id_delivery, starts_at, ends_at, capacity)
+SELECT m.id, d + h * interval '1 hour', d + (h + 4) * interval '1 hour', 20
+FROM delivery_method m,
+  generate_series(current_date + 1, current_date + 7, interval '1 day') AS d,
+  (VALUES (10), (16)) AS s(h)
+WHERE m.kind = 'courier';
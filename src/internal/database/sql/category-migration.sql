@@ -0,0 +1,58 @@
+-- Перевод product.category (varchar) на дерево категорий для баз,
+-- созданных до появления таблицы category. Новая схема (01-create.sql,
+-- 02-constraints.sql) уже создается с деревом, скрипт для нее не нужен.
+-- Повторный запуск ничего не меняет.
+
+begin;
+
+create table if not exists category (
+    id uuid primary key default uuid_generate_v4(),
+    id_parent uuid constraint fk_category_parent references category(id) on delete restrict,
+    name varchar(255) not null,
+    slug varchar(100) not null constraint category_slug_unique unique,
+    sort_order int not null default 0,
+    constraint category_slug_format check (slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$'),
+    constraint category_parent_check check (id_parent <> id)
+);
+
+create index if not exists category_parent_idx on category (id_parent);
+
+alter table product
+add column if not exists id_category uuid constraint fk_product_category references category(id) on delete restrict;
+
+create index if not exists product_category_idx on product (id_category);
+
+-- Строки из прежнего enum становятся корневыми узлами с постоянными slug
+insert into category (name, slug, sort_order) values
+('уход', 'skincare', 1),
+('декоративная', 'makeup', 2),
+('парфюмерия', 'perfume', 3),
+('для волос', 'hair', 4),
+('мужская', 'men', 5)
+on conflict (slug) do nothing;
+
+do $$
+begin
+    if exists (select 1 from information_schema.columns
+               where table_name = 'product' and column_name = 'category') then
+        -- Прочие строки тоже становятся корневыми узлами, slug строится из md5
+        insert into category (name, slug, sort_order)
+        select distinct on (lower(trim(p.category)))
+            trim(p.category), 'category-' || left(md5(lower(trim(p.category))), 8), 100
+        from product p
+        where coalesce(trim(p.category), '') <> ''
+          and not exists (select 1 from category c
+                          where c.id_parent is null and lower(c.name) = lower(trim(p.category)))
+        on conflict (slug) do nothing;
+
+        update product p set id_category = c.id
+        from category c
+        where p.id_category is null
+          and c.id_parent is null
+          and lower(c.name) = lower(trim(p.category));
+
+        alter table product drop column category;
+    end if;
+end $$;
+
+commit;
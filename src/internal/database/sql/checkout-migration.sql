@@ -0,0 +1,20 @@
+-- Оформление заказа перенесено из триггера на "order" в сервис заказов:
+-- остатки блокируются и списываются, скидки и история статусов пишутся
+-- в одной транзакции приложения. Для баз, созданных с trigger_order.sql,
+-- скрипт удаляет триггер и его функцию, иначе товар списывался бы дважды.
+-- Оформление больше не удаляет корзину, а только очищает ее; пользователям,
+-- чью корзину удалили прежние заказы, она создается заново.
+-- Повторный запуск ничего не меняет.
+
+begin;
+
+drop trigger if exists order_creation_trigger on "order";
+
+drop function if exists process_order_creation();
+
+insert into basket (id_user, date)
+select u.id, current_timestamp
+from "user" u
+where not exists (select 1 from basket b where b.id_user = u.id);
+
+commit;
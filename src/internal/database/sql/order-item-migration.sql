@@ -0,0 +1,68 @@
+-- Снимки позиций заказа (цена за единицу, скидка, название, артикул и
+-- бренд на момент покупки) для баз, созданных до появления этих колонок.
+-- Старые позиции заполняются насколько возможно: цена варианта, если она
+-- задана, иначе цена товара из истории product_price на дату заказа, иначе
+-- текущая цена; скидка - сумма order_item_discount; название, артикул и
+-- бренд - текущие данные каталога. Удаление товара из каталога больше не
+-- удаляет позиции заказов: ссылка на товар обнуляется, снимок остается.
+-- Новая схема (01-create.sql, 02-constraints.sql) уже создается так,
+-- скрипт для нее не нужен.
+-- Повторный запуск ничего не меняет.
+
+begin;
+
+alter table order_item
+add column if not exists price decimal(10,2),
+add column if not exists discount decimal(10,2),
+add column if not exists name varchar(255),
+add column if not exists art varchar(50),
+add column if not exists brand varchar(255);
+
+update order_item oi
+set price = coalesce(
+        (select v.price from product_variant v where v.id = oi.id_variant),
+        (select pp.price from product_price pp join "order" o on o.id = oi.id_order
+         where pp.id_product = oi.id_product
+           and pp.valid_from <= o.date
+           and (pp.valid_to is null or pp.valid_to > o.date)
+         order by pp.valid_from desc
+         limit 1),
+        (select p.price from product p where p.id = oi.id_product),
+        0)
+where oi.price is null;
+
+update order_item oi
+set discount = coalesce((select sum(d.amount) from order_item_discount d where d.id_order_item = oi.id), 0)
+where oi.discount is null;
+
+update order_item oi
+set name = coalesce((select p.name from product p where p.id = oi.id_product), ''),
+    art = coalesce(
+        (select v.art from product_variant v where v.id = oi.id_variant),
+        (select p.art from product p where p.id = oi.id_product),
+        ''),
+    brand = coalesce(
+        (select b.name from product p join brand b on b.id = p.id_brand where p.id = oi.id_product),
+        '')
+where oi.name is null;
+
+alter table order_item
+alter column price set not null,
+alter column discount set not null,
+alter column discount set default 0,
+alter column name set not null,
+alter column art set not null,
+alter column art set default '',
+alter column brand set not null,
+alter column brand set default '',
+drop constraint if exists order_item_price_check,
+drop constraint if exists order_item_discount_check,
+add constraint order_item_price_check check (price >= 0),
+add constraint order_item_discount_check check (discount >= 0),
+drop constraint if exists fk_order_item_product,
+add constraint fk_order_item_product foreign key (id_product) references product(id) on delete set null;
+
+alter table if exists return_line
+alter column id_product drop not null;
+
+commit;
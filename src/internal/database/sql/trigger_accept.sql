@@ -2,13 +2,29 @@ create or replace function accept_order_trigger()
 returns trigger as $$
 declare
     found_worker_id uuid;
+    current_status varchar(50);
+    id_actor uuid := new.id_worker;
 begin
     select id into found_worker_id from worker where id_user = new.id_worker;
 
     new.id_worker := found_worker_id;
-    
+
+    -- Принять можно только непринятый заказ, переход записывается в
+    -- историю статусов заказа от имени работника.
+    select status into current_status from "order" where id = new.id_order for update;
+    if not found then
+        raise exception 'заказ % не найден', new.id_order
+            using errcode = 'foreign_key_violation', constraint = 'fk_order';
+    end if;
+    if current_status <> 'непринятый' then
+        raise exception 'заказ % в статусе % нельзя принять', new.id_order, current_status
+            using errcode = 'check_violation', constraint = 'order_worker_status_check';
+    end if;
+
     update "order" set status = 'принятый' where id = new.id_order;
-    
+    insert into order_status_history (id_order, from_status, to_status, id_actor, role)
+    values (new.id_order, current_status, 'принятый', id_actor, 'worker');
+
     return new;
 end;
 $$ language plpgsql;
@@ -16,4 +32,4 @@ $$ language plpgsql;
 create trigger accept_order_trigger
 before insert on order_worker
 for each row
-execute function accept_order_trigger();
\ No newline at end of file
+execute function accept_order_trigger();
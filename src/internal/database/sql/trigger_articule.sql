@@ -0,0 +1,30 @@
+-- Артикул един для всего каталога: товар и вариант не могут иметь один и
+-- тот же артикул. Внутри таблиц уникальность держат product_art_unique и
+-- product_variant_art_unique, между таблицами - этот триггер. Блокировка по
+-- артикулу не дает двум параллельным вставкам пройти проверку одновременно.
+create or replace function articule_unique_trigger()
+returns trigger as $$
+begin
+    perform pg_advisory_xact_lock(hashtext(new.art));
+
+    if (tg_table_name = 'product' and exists (select 1 from product_variant where art = new.art))
+       or (tg_table_name = 'product_variant' and exists (select 1 from product where art = new.art)) then
+        raise exception 'duplicate articule'
+            using errcode = 'unique_violation',
+                  constraint = 'articule_unique',
+                  detail = format('Key (art)=(%s) already exists.', new.art);
+    end if;
+
+    return new;
+end;
+$$ language plpgsql;
+
+create trigger product_articule_trigger
+before insert or update of art on product
+for each row
+execute function articule_unique_trigger();
+
+create trigger product_variant_articule_trigger
+before insert or update of art on product_variant
+for each row
+execute function articule_unique_trigger();
@@ -0,0 +1,46 @@
+-- История цен: каждое изменение product.price закрывает действующую запись
+-- product_price и открывает новую до начала ближайшей запланированной цены.
+-- Если действующая запись уже содержит эту цену (ее активировал фоновый
+-- процесс), ничего не меняется.
+create or replace function product_price_trigger()
+returns trigger as $$
+declare
+    cur product_price%rowtype;
+begin
+    select * into cur from product_price
+    where id_product = new.id
+      and valid_from <= localtimestamp
+      and (valid_to is null or valid_to > localtimestamp);
+
+    if found and cur.price = new.price then
+        return new;
+    end if;
+
+    if found and cur.valid_from = localtimestamp then
+        update product_price set price = new.price where id = cur.id;
+        return new;
+    end if;
+
+    if found then
+        update product_price set valid_to = localtimestamp where id = cur.id;
+    end if;
+
+    insert into product_price (id_product, price, valid_from, valid_to)
+    values (new.id, new.price, localtimestamp,
+            (select min(valid_from) from product_price
+             where id_product = new.id and valid_from > localtimestamp));
+
+    return new;
+end;
+$$ language plpgsql;
+
+create trigger product_price_trigger
+after insert or update of price on product
+for each row
+execute function product_price_trigger();
+
+-- Товары, созданные до триггера, получают начальную запись истории
+insert into product_price (id_product, price, valid_from)
+select p.id, p.price, localtimestamp
+from product p
+where not exists (select 1 from product_price pp where pp.id_product = p.id);
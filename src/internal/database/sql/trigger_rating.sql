@@ -0,0 +1,56 @@
+-- Сводка отзывов: после каждого добавления, удаления или изменения оценки
+-- отзыва пересчитываются средняя оценка, число отзывов и гистограмма
+-- товара. Для товара, удаляемого вместе с отзывами, сводка не создается.
+create or replace function refresh_product_rating(product_id uuid)
+returns void as $$
+begin
+    -- Блокировка товара упорядочивает пересчеты параллельных отзывов:
+    -- следующий пересчет видит отзыв, закоммиченный предыдущим.
+    perform 1 from product where id = product_id for no key update;
+    if not found then
+        return;
+    end if;
+
+    insert into product_rating (id_product, rating_avg, rating_count, stars_1, stars_2, stars_3, stars_4, stars_5)
+    select product_id, coalesce(round(avg(rating), 2), 0), count(*),
+           count(*) filter (where rating = 1), count(*) filter (where rating = 2),
+           count(*) filter (where rating = 3), count(*) filter (where rating = 4),
+           count(*) filter (where rating = 5)
+    from review where id_product = product_id
+    on conflict (id_product) do update set
+        rating_avg = excluded.rating_avg,
+        rating_count = excluded.rating_count,
+        stars_1 = excluded.stars_1,
+        stars_2 = excluded.stars_2,
+        stars_3 = excluded.stars_3,
+        stars_4 = excluded.stars_4,
+        stars_5 = excluded.stars_5;
+end;
+$$ language plpgsql;
+
+create or replace function product_rating_trigger()
+returns trigger as $$
+begin
+    if tg_op = 'INSERT' then
+        perform refresh_product_rating(new.id_product);
+    elsif tg_op = 'DELETE' then
+        perform refresh_product_rating(old.id_product);
+    else
+        perform refresh_product_rating(old.id_product);
+        if new.id_product <> old.id_product then
+            perform refresh_product_rating(new.id_product);
+        end if;
+    end if;
+    return null;
+end;
+$$ language plpgsql;
+
+create trigger product_rating_trigger
+after insert or delete or update of rating, id_product on review
+for each row
+execute function product_rating_trigger();
+
+-- Отзывы, добавленные до триггера, попадают в сводку
+select refresh_product_rating(p.id)
+from product p
+where exists (select 1 from review r where r.id_product = p.id);
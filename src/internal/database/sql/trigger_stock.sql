@@ -0,0 +1,157 @@
+-- Журнал движения товара. Остаток товара или варианта меняется только
+-- вместе с записью журнала: вставка записи меняет остаток и сохраняет новый
+-- остаток в balance, а изменение остатка в обход журнала (создание товара,
+-- импорт каталога) само записывается в журнал как поступление или
+-- корректировка. Флаг stock.synced отмечает, что вторая сторона уже
+-- обновлена, и не дает триггерам вызывать друг друга. Записи журнала не
+-- изменяются и удаляются только вместе с товаром или вариантом. Автор
+-- записи хранится без внешнего ключа, чтобы журнал пережил пользователя.
+-- Запись с партией меняет и остаток партии. Остаток без партии (разница
+-- между остатком товара и суммой его партий) не может стать отрицательным,
+-- поэтому списать товар, хранящийся партиями, можно только из партии.
+create or replace function check_untracked_stock(p_product uuid, p_variant uuid, p_amount int)
+returns void as $$
+begin
+    if p_amount < (select coalesce(sum(amount), 0) from stock_lot
+                   where id_product = p_product and id_variant is not distinct from p_variant) then
+        raise exception 'остаток товара % хранится партиями, укажите партию', p_product
+            using errcode = 'check_violation', constraint = 'stock_lot_untracked_check';
+    end if;
+end;
+$$ language plpgsql;
+
+create or replace function apply_stock_movement()
+returns trigger as $$
+begin
+    if current_setting('stock.synced', true) = 'on' then
+        return new;
+    end if;
+
+    perform set_config('stock.synced', 'on', true);
+    if new.id_lot is not null then
+        update stock_lot set amount = amount + new.quantity
+        where id = new.id_lot and id_product = new.id_product
+          and id_variant is not distinct from new.id_variant;
+        if not found then
+            raise exception 'партия % товара % не найдена', new.id_lot, new.id_product
+                using errcode = 'foreign_key_violation', constraint = 'fk_stock_movement_lot';
+        end if;
+    end if;
+
+    if new.id_variant is not null then
+        update product_variant set amount = amount + new.quantity
+        where id = new.id_variant and id_product = new.id_product
+        returning amount into new.balance;
+        if not found then
+            raise exception 'вариант % товара % не найден', new.id_variant, new.id_product
+                using errcode = 'foreign_key_violation', constraint = 'fk_stock_movement_variant';
+        end if;
+    else
+        update product set amount = amount + new.quantity
+        where id = new.id_product
+        returning amount into new.balance;
+        if not found then
+            raise exception 'товар % не найден', new.id_product
+                using errcode = 'foreign_key_violation', constraint = 'fk_stock_movement_product';
+        end if;
+    end if;
+
+    if new.id_lot is null and new.quantity < 0 then
+        perform check_untracked_stock(new.id_product, new.id_variant, new.balance);
+    end if;
+    perform set_config('stock.synced', '', true);
+
+    return new;
+end;
+$$ language plpgsql;
+
+create trigger stock_movement_apply_trigger
+before insert on stock_movement
+for each row
+execute function apply_stock_movement();
+
+create or replace function record_stock_change()
+returns trigger as $$
+declare
+    delta int;
+    movement_kind varchar(20) := 'adjustment';
+    movement_reason text := 'изменение остатка в обход журнала';
+begin
+    if tg_op = 'INSERT' then
+        delta := new.amount;
+        movement_kind := 'receipt';
+        movement_reason := 'начальный остаток';
+    else
+        delta := new.amount - old.amount;
+    end if;
+
+    if delta = 0 or current_setting('stock.synced', true) = 'on' then
+        return new;
+    end if;
+
+    perform set_config('stock.synced', 'on', true);
+    if tg_table_name = 'product_variant' then
+        if delta < 0 then
+            perform check_untracked_stock(new.id_product, new.id, new.amount);
+        end if;
+        insert into stock_movement (id_product, id_variant, kind, quantity, balance, reason)
+        values (new.id_product, new.id, movement_kind, delta, new.amount, movement_reason);
+    else
+        if delta < 0 then
+            perform check_untracked_stock(new.id, null, new.amount);
+        end if;
+        insert into stock_movement (id_product, kind, quantity, balance, reason)
+        values (new.id, movement_kind, delta, new.amount, movement_reason);
+    end if;
+    perform set_config('stock.synced', '', true);
+
+    return new;
+end;
+$$ language plpgsql;
+
+create trigger product_stock_trigger
+after insert or update of amount on product
+for each row
+execute function record_stock_change();
+
+create trigger product_variant_stock_trigger
+after insert or update of amount on product_variant
+for each row
+execute function record_stock_change();
+
+create or replace function forbid_stock_movement_change()
+returns trigger as $$
+begin
+    if tg_op = 'DELETE'
+       and (not exists (select 1 from product where id = old.id_product)
+            or (old.id_variant is not null
+                and not exists (select 1 from product_variant where id = old.id_variant))) then
+        return old;
+    end if;
+
+    raise exception 'записи журнала движения товара не изменяются'
+        using errcode = 'restrict_violation';
+end;
+$$ language plpgsql;
+
+create trigger stock_movement_append_only_trigger
+before update or delete on stock_movement
+for each row
+execute function forbid_stock_movement_change();
+
+-- Остатки, внесенные до триггеров, попадают в журнал начальными записями
+select set_config('stock.synced', 'on', false);
+
+insert into stock_movement (id_product, kind, quantity, balance, reason)
+select p.id, 'receipt', p.amount, p.amount, 'начальный остаток'
+from product p
+where p.amount > 0
+  and not exists (select 1 from stock_movement sm where sm.id_product = p.id and sm.id_variant is null);
+
+insert into stock_movement (id_product, id_variant, kind, quantity, balance, reason)
+select v.id_product, v.id, 'receipt', v.amount, v.amount, 'начальный остаток'
+from product_variant v
+where v.amount > 0
+  and not exists (select 1 from stock_movement sm where sm.id_variant = v.id);
+
+select set_config('stock.synced', '', false);
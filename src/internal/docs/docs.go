@@ -15,101 +15,190 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/auth/login": {
-            "post": {
-                "description": "Аутентифицирует пользователя и возвращает токены",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/admin/catalog/export": {
+            "get": {
+                "description": "Выгружает все товары, по предложению на каждый вариант, в CSV, JSON Lines или YML (Яндекс.Маркет) потоком (только для администраторов)",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/xml"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Вход в систему",
+                "summary": "Экспорт каталога",
                 "parameters": [
                     {
-                        "description": "Учетные данные для входа",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.LoginRequest"
-                        }
+                        "enum": [
+                            "csv",
+                            "jsonl",
+                            "yml"
+                        ],
+                        "type": "string",
+                        "description": "Формат выгрузки",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешный вход",
+                        "description": "Файл каталога",
                         "schema": {
-                            "type": "object"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неподдерживаемый формат",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "401": {
-                        "description": "Неверные учетные данные",
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при экспорте",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/v1/auth/logout": {
+        "/api/v1/admin/catalog/import": {
             "post": {
-                "description": "Завершает сессию пользователя",
+                "description": "Загружает товары и бренды из CSV или JSON Lines по артикулу в одной транзакции (только для администраторов). При dry_run=true изменения не применяются, возвращается построчный отчет",
                 "consumes": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Выход из системы",
+                "summary": "Импорт каталога",
                 "parameters": [
                     {
-                        "description": "Refresh token для выхода",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.LogoutRequest"
-                        }
+                        "enum": [
+                            "csv",
+                            "jsonl"
+                        ],
+                        "type": "string",
+                        "description": "Формат файла, по умолчанию определяется по Content-Type или расширению",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить файл без записи в базу",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл каталога (для multipart/form-data)",
+                        "name": "file",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешный выход",
+                        "description": "Отчет об импорте",
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/structs.CatalogImportReport"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверный формат файла",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
                         "schema": {
                             "type": "object"
                         }
                     },
+                    "422": {
+                        "description": "Файл содержит некорректные строки, изменения не применены",
+                        "schema": {
+                            "$ref": "#/definitions/structs.CatalogImportReport"
+                        }
+                    },
                     "500": {
-                        "description": "Ошибка сервера при выходе",
+                        "description": "Ошибка сервера при импорте",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/v1/auth/signup": {
+        "/api/v1/admin/delivery": {
+            "get": {
+                "description": "Возвращает все способы доставки, сначала действующие (только для администраторов)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Получить все способы доставки",
+                "responses": {
+                    "200": {
+                        "description": "Способы доставки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.DeliveryMethod"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении способов доставки",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
             "post": {
-                "description": "Создает нового пользователя в системе",
+                "description": "Создает способ доставки (только для администраторов). kind: courier — курьер по слотам, pickup — пункт выдачи, post — почта. Нужен хотя бы один тариф, зоны не зависят от регистра",
                 "consumes": [
                     "application/json"
                 ],
@@ -117,106 +206,114 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "delivery"
                 ],
-                "summary": "Регистрация пользователя",
+                "summary": "Создать способ доставки",
                 "parameters": [
                     {
-                        "description": "Данные для регистрации",
+                        "description": "Параметры способа доставки",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.SignupRequest"
+                            "$ref": "#/definitions/controller.CreateDeliveryRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Пользователь успешно зарегистрирован",
+                        "description": "ID способа доставки",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверные параметры способа доставки",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при регистрации",
+                        "description": "Ошибка сервера при создании способа доставки",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/v1/brands": {
-            "get": {
-                "description": "Возвращает список брендов в указанной ценовой категории",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/admin/delivery/{id}": {
+            "delete": {
+                "description": "Отключает способ доставки (только для администраторов). Способы доставки не удаляются, так как заказы ссылаются на них",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "delivery"
                 ],
-                "summary": "Получить бренды по категории цен",
+                "summary": "Отключить способ доставки",
                 "parameters": [
                     {
-                        "enum": [
-                            "бюджет",
-                            "средний",
-                            "люкс"
-                        ],
                         "type": "string",
-                        "description": "Ценовая категория",
-                        "name": "category",
-                        "in": "query",
+                        "description": "UUID способа доставки",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список брендов в категории",
+                        "description": "Способ доставки отключен",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object"
-                            }
+                            "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверная категория",
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "404": {
-                        "description": "Бренды не найден",
+                        "description": "Способ доставки не найден",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении брендов",
+                        "description": "Ошибка сервера при отключении способа доставки",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Создает новый бренд в системе (только для администраторов)",
+                ]
+            }
+        },
+        "/api/v1/admin/delivery/{id}/rates": {
+            "put": {
+                "description": "Заменяет все тарифы способа доставки (только для администраторов). Стоимость доставки оформленных заказов не меняется",
                 "consumes": [
                     "application/json"
                 ],
@@ -224,29 +321,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "delivery"
                 ],
-                "summary": "Создать бренд",
+                "summary": "Заменить тарифы доставки",
                 "parameters": [
                     {
-                        "description": "Данные для создания бренда",
+                        "type": "string",
+                        "description": "UUID способа доставки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Тарифы",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.CreateBrandRequest"
+                            "$ref": "#/definitions/controller.SetDeliveryRatesRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Бренд успешно создан",
+                    "200": {
+                        "description": "Тарифы заменены",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверный формат UUID или тарифов",
                         "schema": {
                             "type": "object"
                         }
@@ -257,29 +361,29 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "404": {
+                        "description": "Способ доставки не найден",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при создании бренда",
+                        "description": "Ошибка сервера при замене тарифов",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/brands/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает информацию о бренде по его идентификатору (только для администраторов)",
+                ]
+            }
+        },
+        "/api/v1/admin/delivery/{id}/slots": {
+            "post": {
+                "description": "Добавляет будущий слот курьерской доставки на capacity заказов (только для администраторов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -287,27 +391,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "delivery"
                 ],
-                "summary": "Получить бренд по ID",
+                "summary": "Создать слот доставки",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID бренда",
+                        "description": "UUID способа доставки",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Параметры слота",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateDeliverySlotRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Данные бренда",
+                    "201": {
+                        "description": "ID слота",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат UUID",
+                        "description": "Неверные параметры слота или способ доставки без слотов",
                         "schema": {
                             "type": "object"
                         }
@@ -318,49 +431,55 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "404": {
+                        "description": "Способ доставки не найден",
                         "schema": {
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Бренд не найден",
+                    "500": {
+                        "description": "Ошибка сервера при создании слота",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Удаляет бренд по его идентификатору (только для администраторов)",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/delivery/{id}/slots/{id_slot}": {
+            "delete": {
+                "description": "Удаляет слот, в котором не оформлено ни одного заказа (только для администраторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "delivery"
                 ],
-                "summary": "Удалить бренд",
+                "summary": "Удалить слот доставки",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID бренда",
+                        "description": "UUID способа доставки",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID слота",
+                        "name": "id_slot",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Бренд успешно удален",
+                        "description": "Слот удален",
                         "schema": {
                             "type": "object"
                         }
@@ -377,68 +496,62 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "404": {
+                        "description": "Слот не найден",
                         "schema": {
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Бренд не найден",
+                    "409": {
+                        "description": "В слоте есть заказы",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при удалении бренда",
+                        "description": "Ошибка сервера при удалении слота",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/orders": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает список заказов. Всех, если без параметров только для админа, если status=непринятый - свободные заказы для работников и админов",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/lots/expiring": {
+            "get": {
+                "description": "Возвращает партии с остатком, срок годности которых истекает в ближайшие days дней (по умолчанию 30), включая уже просроченные. Просроченные партии не продаются (для работников и администраторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "admins"
                 ],
-                "summary": "Получить заказы",
+                "summary": "Получить истекающие партии",
                 "parameters": [
                     {
-                        "enum": [
-                            "непринятый"
-                        ],
-                        "type": "string",
-                        "description": "Статус заказа для фильтрации",
-                        "name": "status",
+                        "type": "integer",
+                        "description": "Горизонт отчета в днях",
+                        "name": "days",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список заказов",
+                        "description": "Истекающие партии",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object"
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.StockLot"
                             }
                         }
                     },
                     "400": {
-                        "description": "Неверный параметр статуса",
+                        "description": "Неверное количество дней",
                         "schema": {
                             "type": "object"
                         }
@@ -449,63 +562,117 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
-                    "404": {
-                        "description": "Заказы не найдены",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
                     "500": {
-                        "description": "Ошибка сервера при получении заказов",
+                        "description": "Ошибка сервера при получении отчета",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Создает новый заказ для текущего пользователя",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/orders": {
+            "get": {
+                "description": "Возвращает заказы с позициями по фильтрам (только для администраторов). Страницы листаются курсором: next_cursor ответа передается в cursor следующего запроса с той же сортировкой",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "admins"
                 ],
-                "summary": "Создать заказ",
+                "summary": "Поиск заказов (администратор)",
                 "parameters": [
                     {
-                        "description": "Данные для создания заказа",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.CreateOrderRequest"
-                        }
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Статусы заказа",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата заказа от (YYYY-MM-DD или RFC3339), включительно",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата заказа до (YYYY-MM-DD включительно или RFC3339 не включая)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Часть e-mail или телефона покупателя",
+                        "name": "customer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID работника, принявшего заказ",
+                        "name": "id_worker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная сумма заказа",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная сумма заказа",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID товара в заказе",
+                        "name": "id_product",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "-date",
+                            "date",
+                            "price",
+                            "-price"
+                        ],
+                        "type": "string",
+                        "description": "Сортировка: -date (по умолчанию, сначала новые), date, price, -price",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 50, не больше 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Заказ успешно создан",
+                    "200": {
+                        "description": "Страница заказов",
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/structs.OrderPage"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверные параметры фильтра или курсор",
                         "schema": {
                             "type": "object"
                         }
@@ -517,74 +684,104 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при создании заказа",
+                        "description": "Ошибка сервера при поиске заказов",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/products": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает список продуктов с различными фильтрами: по категории или бренду",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/orders/export": {
+            "get": {
+                "description": "Выгружает заказы по тем же фильтрам и в той же сортировке, что и поиск, потоком в CSV, одна строка на позицию заказа (только для администраторов)",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "products"
+                    "admins"
                 ],
-                "summary": "Получить продукты",
+                "summary": "Экспорт заказов (администратор)",
                 "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Статусы заказа",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "UUID продукта",
-                        "name": "id",
+                        "description": "Дата заказа от (YYYY-MM-DD или RFC3339), включительно",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Артикул продукта",
-                        "name": "art",
+                        "description": "Дата заказа до (YYYY-MM-DD включительно или RFC3339 не включая)",
+                        "name": "to",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "уход",
-                            "декоративная",
-                            "парфюмерия",
-                            "для волос",
-                            "мужская"
-                        ],
                         "type": "string",
-                        "description": "Категория продукта",
-                        "name": "category",
+                        "description": "Часть e-mail или телефона покупателя",
+                        "name": "customer",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Название бренда",
-                        "name": "brand",
+                        "description": "UUID работника, принявшего заказ",
+                        "name": "id_worker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная сумма заказа",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная сумма заказа",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID товара в заказе",
+                        "name": "id_product",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "-date",
+                            "date",
+                            "price",
+                            "-price"
+                        ],
+                        "type": "string",
+                        "description": "Сортировка: -date (по умолчанию), date, price, -price",
+                        "name": "sort",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные продукта или список продуктов",
+                        "description": "CSV с заказами",
                         "schema": {
-                            "type": "object"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Неверные параметры запроса",
+                        "description": "Неверные параметры фильтра",
                         "schema": {
                             "type": "object"
                         }
@@ -595,27 +792,23 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Продукт не найден",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
                     "500": {
-                        "description": "Ошибка сервера при получении продуктов",
+                        "description": "Ошибка сервера при экспорте",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Создает новый продукт в системе (только для администраторов)",
+                ]
+            }
+        },
+        "/api/v1/admin/orders/{id}/cancel": {
+            "post": {
+                "description": "Отменяет любой еще не отданный заказ (только для администраторов). Причина отмены записывается в историю статусов, товар возвращается на склад, в те же партии, оплата возвращается покупателю",
                 "consumes": [
                     "application/json"
                 ],
@@ -623,29 +816,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admins"
                 ],
-                "summary": "Создать продукт",
+                "summary": "Отменить заказ (администратор)",
                 "parameters": [
                     {
-                        "description": "Данные для создания продукта",
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отмены",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.CreateProductRequest"
+                            "$ref": "#/definitions/controller.CancelOrderRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Продукт успешно создан",
+                    "200": {
+                        "description": "Заказ отменен",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверный формат данных или не указана причина",
                         "schema": {
                             "type": "object"
                         }
@@ -656,29 +856,73 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ уже нельзя отменить",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при создании продукта",
+                        "description": "Ошибка сервера при отмене заказа",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/v1/products/{id}": {
-            "delete": {
+        "/api/v1/admin/promotions": {
+            "get": {
+                "description": "Возвращает все акции и промокоды, сначала действующие (только для администраторов)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Получить акции",
+                "responses": {
+                    "200": {
+                        "description": "Акции",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Promotion"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении акций",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Удаляет продукт по его идентификатору (только для администраторов)",
+                ]
+            },
+            "post": {
+                "description": "Создает акцию (только для администраторов). kind: percent — скидка value процентов, fixed — скидка value рублей (только промокод), bundle — из каждых buy_quantity+free_quantity единиц free_quantity бесплатно. Без code акция применяется автоматически. id_brand и id_category ограничивают товары, категория включает подкатегории. Нулевые лимиты и пустые даты — без ограничений",
                 "consumes": [
                     "application/json"
                 ],
@@ -686,27 +930,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "promotions"
                 ],
-                "summary": "Удалить продукт",
+                "summary": "Создать акцию",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "UUID продукта",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Параметры акции",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreatePromotionRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Продукт успешно удален",
+                    "201": {
+                        "description": "ID акции",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат UUID",
+                        "description": "Неверные параметры акции",
                         "schema": {
                             "type": "object"
                         }
@@ -717,44 +963,46 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "404": {
+                        "description": "Бренд или категория не найдены",
                         "schema": {
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Продукт не найден",
+                    "409": {
+                        "description": "Промокод уже существует",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при удалении продукта",
+                        "description": "Ошибка сервера при создании акции",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/v1/products/{id}/reviews": {
+        "/api/v1/admin/promotions/{id}": {
             "get": {
-                "description": "Возвращает список отзывов для указанного продукта",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает акцию по ID (только для администраторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "promotions"
                 ],
-                "summary": "Получить отзывы для продукта",
+                "summary": "Получить акцию",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID продукта",
+                        "description": "UUID акции",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -762,12 +1010,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список отзывов для продукта",
+                        "description": "Акция",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object"
-                            }
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Promotion"
                         }
                     },
                     "400": {
@@ -776,43 +1021,44 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
                     "404": {
-                        "description": "Отзывы не найден",
+                        "description": "Акция не найдена",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении отзывов",
+                        "description": "Ошибка сервера при получении акции",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/products/{id}/reviews/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает информацию об отзыве по его идентификатору",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            },
+            "delete": {
+                "description": "Отключает акцию (только для администраторов). Акции не удаляются, так как скидки в заказах ссылаются на них",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "promotions"
                 ],
-                "summary": "Получить отзыв по ID",
+                "summary": "Остановить акцию",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID отзыва",
+                        "description": "UUID акции",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -820,7 +1066,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные отзыва",
+                        "description": "Акция остановлена",
                         "schema": {
                             "type": "object"
                         }
@@ -838,48 +1084,55 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Отзыв не найден",
+                        "description": "Акция не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при остановке акции",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Удаляет отзыв по его идентификатору (только для администраторов)",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/purchase-orders": {
+            "get": {
+                "description": "Возвращает заказы поставщикам без строк, сначала новые (для администраторов и работников). status: draft, sent, partially_received, received",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "admin"
                 ],
-                "summary": "Удалить отзыв",
+                "summary": "Получить заказы поставщикам",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID отзыва",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Статус заказа",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Отзыв успешно удален",
+                        "description": "Заказы",
                         "schema": {
-                            "type": "object"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.PurchaseOrder"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверный формат UUID",
+                        "description": "Неверный статус",
                         "schema": {
                             "type": "object"
                         }
@@ -890,68 +1143,109 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "500": {
+                        "description": "Ошибка сервера при получении заказов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Создает черновик заказа поставщику (только для администраторов). Каждый товар или вариант указывается в одной строке",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Создать заказ поставщику",
+                "parameters": [
+                    {
+                        "description": "Заказ поставщику",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreatePurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID заказа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные заказа или товар не из брендов поставщика",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "404": {
-                        "description": "Отзыв не найден",
+                        "description": "Поставщик, товар или вариант не найден",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при удалении отзыва",
+                        "description": "Ошибка сервера при создании заказа",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/users": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает информацию о пользователе по email или номеру телефона (только для администраторов) или информацию о всех userах",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/purchase-orders/{id}": {
+            "get": {
+                "description": "Возвращает заказ поставщику со строками и расхождениями (для администраторов и работников)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Получить пользователя по email или телефону",
+                "summary": "Получить заказ поставщику",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Email пользователя",
-                        "name": "email",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Телефон пользователя",
-                        "name": "phone",
-                        "in": "query"
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные пользователя",
+                        "description": "Заказ",
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.PurchaseOrder"
                         }
                     },
                     "400": {
-                        "description": "Не указаны параметры email или phone",
+                        "description": "Неверный формат UUID",
                         "schema": {
                             "type": "object"
                         }
@@ -962,48 +1256,54 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "404": {
+                        "description": "Заказ не найден",
                         "schema": {
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Пользователь не найден",
+                    "500": {
+                        "description": "Ошибка сервера при получении заказа",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/users/me/basket": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает корзину текущего пользователя",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/purchase-orders/{id}/send": {
+            "post": {
+                "description": "Переводит черновик заказа в статус sent, после чего поставку можно принимать (только для администраторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
+                ],
+                "summary": "Отправить заказ поставщику",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получить корзину",
                 "responses": {
                     "200": {
-                        "description": "Данные корзины",
+                        "description": "Заказ отправлен",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат ID",
+                        "description": "Неверный формат UUID",
                         "schema": {
                             "type": "object"
                         }
@@ -1015,48 +1315,51 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Корзина не найдена",
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ уже отправлен",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при отправке заказа",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/users/me/basket/items": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает список всех товаров в корзине текущего пользователя",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/reorder-report": {
+            "get": {
+                "description": "Возвращает товары с остатком ниже точки заказа, сгруппированные по брендам, с количеством для дозаказа (только для администраторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Получить товары корзины",
+                "summary": "Получить отчет для дозаказа",
                 "responses": {
                     "200": {
-                        "description": "Список товаров в корзине",
+                        "description": "Отчет",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object"
+                                "$ref": "#/definitions/structs.BrandReorder"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Неверный формат ID",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
                     "401": {
                         "description": "Неавторизованный доступ",
                         "schema": {
@@ -1064,50 +1367,55 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении товаров",
+                        "description": "Ошибка сервера при построении отчета",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Добавляет товар с указанным количеством в корзину пользователя",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/returns": {
+            "get": {
+                "description": "Возвращает заявки на возврат, сначала новые, с фильтром по статусу (для администраторов и работников)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Добавить товар в корзину",
+                "summary": "Получить заявки на возврат",
                 "parameters": [
                     {
-                        "description": "Данные товара для добавления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.BasketItemRequest"
-                        }
+                        "enum": [
+                            "requested",
+                            "approved",
+                            "rejected",
+                            "received"
+                        ],
+                        "type": "string",
+                        "description": "Статус заявки",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Товар успешно добавлен",
+                    "200": {
+                        "description": "Заявки на возврат",
                         "schema": {
-                            "type": "object"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReturnRequest"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверный статус",
                         "schema": {
                             "type": "object"
                         }
@@ -1118,57 +1426,106 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Корзина не найдена",
+                    "500": {
+                        "description": "Ошибка сервера при получении возвратов",
                         "schema": {
                             "type": "object"
                         }
-                    },
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/admin/returns/{id}": {
+            "get": {
+                "description": "Возвращает заявку на возврат с позициями, фотографиями и суммой возврата денег (для администраторов и работников)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Получить заявку на возврат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заявки на возврат",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заявка на возврат",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReturnRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
                     "500": {
-                        "description": "Ошибка сервера при добавлении товара",
+                        "description": "Ошибка сервера при получении возврата",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Удаляет указанный товар из корзины пользователя",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/returns/{id}/approve": {
+            "post": {
+                "description": "Одобряет заявку на возврат, после чего покупатель может передать товар (только для администраторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Удалить товар из корзины",
+                "summary": "Одобрить возврат",
                 "parameters": [
                     {
-                        "description": "Данные товара для удаления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controller.BasketItemDeleteRequest"
-                        }
+                        "type": "string",
+                        "description": "UUID заявки на возврат",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Товар успешно удален",
+                        "description": "Возврат одобрен",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверный формат UUID",
                         "schema": {
                             "type": "object"
                         }
@@ -1180,26 +1537,34 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Корзина не найдена",
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Заявка уже рассмотрена",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при удалении товара",
+                        "description": "Ошибка сервера при одобрении возврата",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "patch": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Обновляет количество указанного товара в корзине пользователя",
+                ]
+            }
+        },
+        "/api/v1/admin/returns/{id}/reject": {
+            "post": {
+                "description": "Отклоняет заявку на возврат с указанием причины, которую видит покупатель (только для администраторов). Товар отклоненной заявки можно вернуть новой заявкой",
                 "consumes": [
                     "application/json"
                 ],
@@ -1207,29 +1572,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Обновить количество товара",
+                "summary": "Отклонить возврат",
                 "parameters": [
                     {
-                        "description": "Данные для обновления количества",
+                        "type": "string",
+                        "description": "UUID заявки на возврат",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отказа",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.BasketItemRequest"
+                            "$ref": "#/definitions/controller.RejectReturnRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Количество товара успешно обновлено",
+                        "description": "Возврат отклонен",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверный формат данных или не указана причина",
                         "schema": {
                             "type": "object"
                         }
@@ -1241,81 +1613,72 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Элемент корзины не найден",
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Заявка уже рассмотрена",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при обновлении количества",
+                        "description": "Ошибка сервера при отклонении возврата",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/users/me/favourite/items": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает список всех товаров в избранном текущего пользователя",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/suppliers": {
+            "get": {
+                "description": "Возвращает всех поставщиков с их брендами (только для администраторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Получить избранные товары",
+                "summary": "Получить поставщиков",
                 "responses": {
                     "200": {
-                        "description": "Список товаров в избранном",
+                        "description": "Поставщики",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object"
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Supplier"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Неверный формат ID",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
                     "401": {
                         "description": "Неавторизованный доступ",
                         "schema": {
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Избранные не найдены",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
                     "500": {
-                        "description": "Ошибка сервера при получении избранного",
+                        "description": "Ошибка сервера при получении поставщиков",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Добавляет товар в избранное текущего пользователя",
+                ]
+            },
+            "post": {
+                "description": "Создает поставщика брендов (только для администраторов). Заказы поставщику могут содержать только товары его брендов",
                 "consumes": [
                     "application/json"
                 ],
@@ -1323,29 +1686,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Добавить товар в избранное",
+                "summary": "Создать поставщика",
                 "parameters": [
                     {
-                        "description": "Данные товара для добавления в избранное",
+                        "description": "Поставщик",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.AddFavouriteRequest"
+                            "$ref": "#/definitions/controller.CreateSupplierRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Товар успешно добавлен в избранное",
+                        "description": "ID поставщика",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверные данные поставщика",
                         "schema": {
                             "type": "object"
                         }
@@ -1356,45 +1719,54 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
+                    "404": {
+                        "description": "Бренд не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Поставщик уже существует",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
                     "500": {
-                        "description": "Ошибка сервера при добавлении в избранное",
+                        "description": "Ошибка сервера при создании поставщика",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/users/me/favourite/items/{id_product}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Удаляет товар из избранного текущего пользователя",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/admin/suppliers/{id}": {
+            "delete": {
+                "description": "Удаляет поставщика без заказов (только для администраторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Удалить товар из избранного",
+                "summary": "Удалить поставщика",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID товара",
-                        "name": "id_product",
+                        "description": "UUID поставщика",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Товар успешно удален из избранного",
+                        "description": "Поставщик удален",
                         "schema": {
                             "type": "object"
                         }
@@ -1412,50 +1784,61 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Избранные не найдены",
+                        "description": "Поставщик не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "У поставщика есть заказы",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при удалении из избранного",
+                        "description": "Ошибка сервера при удалении поставщика",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/users/me/orders": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает список заказов. Заказы текущего пользователя",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/attributes": {
+            "get": {
+                "description": "Возвращает справочник свойств одного вида или всех видов",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "attributes"
+                ],
+                "summary": "Получить свойства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Вид свойства: skin_type, hair_type или finish",
+                        "name": "kind",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Получить заказы",
                 "responses": {
                     "200": {
-                        "description": "Список заказов",
+                        "description": "Свойства",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object"
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Attribute"
                             }
                         }
                     },
                     "400": {
-                        "description": "Неверный параметр статуса",
+                        "description": "Неизвестный вид свойства",
                         "schema": {
                             "type": "object"
                         }
@@ -1466,35 +1849,21 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
-                    "404": {
-                        "description": "Заказы не найдены",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
                     "500": {
-                        "description": "Ошибка сервера при получении заказов",
+                        "description": "Ошибка сервера при получении свойств",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/users/me/orders/{id}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Удаляет заказ по его идентификатору (только для администраторов)",
+                ]
+            },
+            "post": {
+                "description": "Добавляет значение в справочник типов кожи (skin_type), типов волос (hair_type) или финишей (finish) (только для администраторов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1502,27 +1871,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "attributes"
                 ],
-                "summary": "Удалить заказ",
+                "summary": "Создать свойство",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "UUID заказа",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные свойства",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.AttributeRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Заказ успешно удален",
+                    "201": {
+                        "description": "ID созданного свойства",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат UUID",
+                        "description": "Неверный формат данных",
                         "schema": {
                             "type": "object"
                         }
@@ -1533,69 +1904,54 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "409": {
+                        "description": "Свойство уже существует",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при удалении заказа",
+                        "description": "Ошибка сервера при создании свойства",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "patch": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Обновляет статус заказа (для работников и администраторов)",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/api/v1/attributes/{id}": {
+            "delete": {
+                "description": "Удаляет свойство, не назначенное товарам (только для администраторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "attributes"
                 ],
-                "summary": "Изменить статус заказа",
+                "summary": "Удалить свойство",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID заказа",
+                        "description": "UUID свойства",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "enum": [
-                            "некорректный",
-                            "непринятый",
-                            "принятый",
-                            "собранный",
-                            "отданный"
-                        ],
-                        "type": "string",
-                        "description": "Новый статус заказа",
-                        "name": "status",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Статус заказа успешно обновлен",
+                        "description": "Свойство удалено",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Неверный формат UUID",
                         "schema": {
                             "type": "object"
                         }
@@ -1606,35 +1962,35 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "404": {
+                        "description": "Свойство не найдено",
                         "schema": {
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Заказ не найден",
+                    "409": {
+                        "description": "Свойство назначено товарам",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при обновлении статуса",
+                        "description": "Ошибка сервера при удалении свойства",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/users/me/orders/{id}/items": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает список товаров в указанном заказе",
+                ]
+            }
+        },
+        "/api/v1/auth/login": {
+            "post": {
+                "description": "Аутентифицирует пользователя и возвращает токены",
                 "consumes": [
                     "application/json"
                 ],
@@ -1642,48 +1998,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "auth"
                 ],
-                "summary": "Получить товары заказа",
+                "summary": "Вход в систему",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "UUID заказа",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Учетные данные для входа",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список товаров в заказе",
+                        "description": "Успешный вход",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object"
-                            }
+                            "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат UUID",
+                        "description": "Неверный формат данных",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "401": {
-                        "description": "Неавторизованный доступ",
+                        "description": "Неверные учетные данные",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/logout": {
+            "post": {
+                "description": "Завершает сессию пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Выход из системы",
+                "parameters": [
+                    {
+                        "description": "Refresh token для выхода",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный выход",
                         "schema": {
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Отзывы не найдены",
+                    "400": {
+                        "description": "Неверный формат данных",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении товаров",
+                        "description": "Ошибка сервера при выходе",
                         "schema": {
                             "type": "object"
                         }
@@ -1691,14 +2080,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/me/products/{id_product}/reviews": {
+        "/api/v1/auth/signup": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Создает новый отзыв для указанного продукта от текущего пользователя",
+                "description": "Создает нового пользователя в системе",
                 "consumes": [
                     "application/json"
                 ],
@@ -1706,30 +2090,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "auth"
                 ],
-                "summary": "Создать отзыв",
+                "summary": "Регистрация пользователя",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "UUID продукта",
-                        "name": "id_product",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные для создания отзыва",
+                        "description": "Данные для регистрации",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.CreateReviewRequest"
+                            "$ref": "#/definitions/controller.SignupRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Отзыв успешно создан",
+                        "description": "Пользователь успешно зарегистрирован",
                         "schema": {
                             "type": "object"
                         }
@@ -1740,14 +2117,8 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "401": {
-                        "description": "Неавторизованный доступ",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
                     "500": {
-                        "description": "Ошибка сервера при создании отзыва",
+                        "description": "Ошибка сервера при регистрации",
                         "schema": {
                             "type": "object"
                         }
@@ -1755,14 +2126,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workers": {
+        "/api/v1/brands": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Возвращает список всех работников системы (только для администраторов)",
+                "description": "Возвращает список брендов в указанной ценовой категории",
                 "consumes": [
                     "application/json"
                 ],
@@ -1770,12 +2136,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workers"
+                    "brands"
+                ],
+                "summary": "Получить бренды по категории цен",
+                "parameters": [
+                    {
+                        "enum": [
+                            "бюджет",
+                            "средний",
+                            "люкс"
+                        ],
+                        "type": "string",
+                        "description": "Ценовая категория",
+                        "name": "category",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Получить всех работников",
                 "responses": {
                     "200": {
-                        "description": "Список всех работников",
+                        "description": "Список брендов в категории",
                         "schema": {
                             "type": "array",
                             "items": {
@@ -1783,26 +2163,20 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Неавторизованный доступ",
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "400": {
+                        "description": "Неверная категория",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "404": {
-                        "description": "Работник не найден",
+                        "description": "Бренды не найден",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении работников",
+                        "description": "Ошибка сервера при получении брендов",
                         "schema": {
                             "type": "object"
                         }
@@ -1810,12 +2184,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Создает нового работника в системе (только для администраторов)",
+                "description": "Создает новый бренд в системе (только для администраторов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1823,23 +2192,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workers"
+                    "brands"
                 ],
-                "summary": "Создать работника",
+                "summary": "Создать бренд",
                 "parameters": [
                     {
-                        "description": "Данные для создания работника",
+                        "description": "Данные для создания бренда",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controller.CreateWorkerRequest"
+                            "$ref": "#/definitions/controller.CreateBrandRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Работник успешно создан",
+                        "description": "Бренд успешно создан",
                         "schema": {
                             "type": "object"
                         }
@@ -1863,22 +2232,22 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при создании работника",
+                        "description": "Ошибка сервера при создании бренда",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/workers/me/orders": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Возвращает список заказов текущего работника (только для работников)",
+                ]
+            }
+        },
+        "/api/v1/brands/{id}": {
+            "get": {
+                "description": "Возвращает информацию о бренде по его идентификатору (только для администраторов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1886,17 +2255,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workers"
+                    "brands"
+                ],
+                "summary": "Получить бренд по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID бренда",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получить заказы работника",
                 "responses": {
                     "200": {
-                        "description": "Список заказов работника",
+                        "description": "Данные бренда",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object"
-                            }
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
                         }
                     },
                     "401": {
@@ -1912,48 +2293,48 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Заказы не найдены",
+                        "description": "Бренд не найден",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Принимает заказ для выполнения текущим работником (только для работников)",
-                "consumes": [
+                ]
+            },
+            "delete": {
+                "description": "Удаляет бренд по его идентификатору (только для администраторов)",
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "workers"
+                    "brands"
                 ],
-                "summary": "Принять заказ",
+                "summary": "Удалить бренд",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID заказа",
-                        "name": "order_id",
-                        "in": "query",
+                        "description": "UUID бренда",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Заказ успешно принят",
+                        "description": "Бренд успешно удален",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат UUID заказа",
+                        "description": "Неверный формат UUID",
                         "schema": {
                             "type": "object"
                         }
@@ -1971,28 +2352,185 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Заказ не найдены",
+                        "description": "Бренд не найден",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при принятии заказа",
+                        "description": "Ошибка сервера при удалении бренда",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/api/v1/workers/{id}": {
+        "/api/v1/categories": {
             "get": {
+                "description": "Возвращает корневые категории с вложенными подкатегориями, отсортированные по sort_order и названию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Получить дерево категорий",
+                "responses": {
+                    "200": {
+                        "description": "Дерево категорий",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Category"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении категорий",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            },
+            "post": {
+                "description": "Создает категорию каталога, корневую или вложенную в id_parent (только для администраторов). Slug состоит из строчных латинских букв, цифр и дефисов",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Возвращает информацию о работнике по его идентификатору (только для администраторов)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Создать категорию",
+                "parameters": [
+                    {
+                        "description": "Данные категории",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID созданной категории",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Родительская категория не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Slug уже занят",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании категории",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/categories/{id}": {
+            "get": {
+                "description": "Возвращает категорию по UUID или slug",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Получить категорию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID или slug категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные категории",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Category"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении категории",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Изменяет название, slug, порядок сортировки и родителя категории (только для администраторов). Категорию нельзя перенести в нее саму или в ее потомка",
                 "consumes": [
                     "application/json"
                 ],
@@ -2000,28 +2538,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workers"
+                    "categories"
                 ],
-                "summary": "Получить работника по ID",
+                "summary": "Изменить категорию",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"123e4567-e89b-12d3-a456-426614174000\"",
-                        "description": "UUID работника",
+                        "description": "UUID категории",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Данные категории",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные работника",
+                        "description": "Категория изменена",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат UUID",
+                        "description": "Неверный формат данных или цикл в дереве",
                         "schema": {
                             "type": "object"
                         }
@@ -2032,41 +2578,44 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "404": {
+                        "description": "Категория не найдена",
                         "schema": {
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Работник не найден",
+                    "409": {
+                        "description": "Slug уже занят",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при изменении категории",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Удаляет работника по его идентификатору (только для администраторов)",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            },
+            "delete": {
+                "description": "Удаляет категорию без подкатегорий и товаров (только для администраторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "workers"
+                    "categories"
                 ],
-                "summary": "Удалить работника",
+                "summary": "Удалить категорию",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID работника",
+                        "description": "UUID категории",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2074,7 +2623,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Работник успешно удален",
+                        "description": "Категория удалена",
                         "schema": {
                             "type": "object"
                         }
@@ -2091,220 +2640,6732 @@ const docTemplate = `{
                             "type": "object"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "404": {
+                        "description": "Категория не найдена",
                         "schema": {
                             "type": "object"
                         }
                     },
-                    "404": {
-                        "description": "Работник не найден",
+                    "409": {
+                        "description": "У категории есть подкатегории или товары",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при удалении работника",
+                        "description": "Ошибка сервера при удалении категории",
                         "schema": {
                             "type": "object"
                         }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "controller.AddFavouriteRequest": {
-            "type": "object",
-            "required": [
-                "id_product"
-            ],
-            "properties": {
-                "id_product": {
-                    "type": "string"
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "controller.BasketItemDeleteRequest": {
-            "type": "object",
-            "required": [
-                "product_id"
-            ],
-            "properties": {
-                "product_id": {
-                    "type": "string"
+        "/api/v1/delivery": {
+            "get": {
+                "description": "Возвращает доступные способы доставки с тарифами. Тариф применяется к заказу в зоне zone весом до max_weight граммов (0 — без ограничения) и суммой от min_total; стоимость — cost плюс per_kg за каждый начатый килограмм. Из подходящих тарифов берется самый дешевый, заказы от free_from доставляются бесплатно",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Получить способы доставки",
+                "responses": {
+                    "200": {
+                        "description": "Способы доставки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.DeliveryMethod"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении способов доставки",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
                 }
             }
         },
-        "controller.BasketItemRequest": {
-            "type": "object",
-            "required": [
-                "amount",
-                "product_id"
-            ],
-            "properties": {
-                "amount": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "product_id": {
-                    "type": "string"
+        "/api/v1/delivery/{id}/slots": {
+            "get": {
+                "description": "Возвращает слоты курьерской доставки, начинающиеся в интервале [from, to), с числом занятых мест. По умолчанию — ближайшие две недели, интервал не больше месяца",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "summary": "Получить слоты доставки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID способа доставки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало интервала (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец интервала (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Слоты доставки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.DeliverySlot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID или интервала",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Способ доставки не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении слотов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/ingredients": {
+            "get": {
+                "description": "Возвращает справочник INCI, отсортированный по названию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attributes"
+                ],
+                "summary": "Получить ингредиенты",
+                "responses": {
+                    "200": {
+                        "description": "Ингредиенты",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Ingredient"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении ингредиентов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Добавляет ингредиент в справочник INCI (только для администраторов)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attributes"
+                ],
+                "summary": "Создать ингредиент",
+                "parameters": [
+                    {
+                        "description": "Данные ингредиента",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.IngredientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID созданного ингредиента",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Ингредиент уже существует",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании ингредиента",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/ingredients/{id}": {
+            "delete": {
+                "description": "Удаляет ингредиент, не входящий в состав товаров (только для администраторов)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attributes"
+                ],
+                "summary": "Удалить ингредиент",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ингредиента",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ингредиент удален",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Ингредиент не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Ингредиент входит в состав товаров",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении ингредиента",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/orders": {
+            "get": {
+                "description": "Возвращает список заказов. Всех, если без параметров только для админа, если status=непринятый - свободные оплаченные заказы для работников и админов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить заказы",
+                "parameters": [
+                    {
+                        "enum": [
+                            "непринятый"
+                        ],
+                        "type": "string",
+                        "description": "Статус заказа для фильтрации",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список заказов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный параметр статуса",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказы не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении заказов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Создает новый заказ из корзины текущего пользователя. Применяются действующие акции и промокод promo_code, скидки сохраняются по позициям заказа. Остатки всех позиций проверяются и списываются в одной транзакции, сначала из партий с ближайшим сроком годности. Резерв товара, сделанный при начале оформления, переходит в заказ. Заказ создается неоплаченным и виден работникам после оплаты. Если товара не хватает, в items перечисляются все такие позиции с доступным количеством. Стоимость доставки id_delivery в зону zone считается по весу и сумме заказа и входит в price; курьерская доставка требует слот id_slot со свободными местами",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Создать заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом и телом возвращает первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Данные для создания заказа",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Заказ успешно создан, возвращаются id, итоговая цена и стоимость доставки",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных, корзина пуста, промокод не подходит, доставка в зону недоступна или не указан слот",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Промокод, товар, способ доставки или слот не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Лимит использований промокода исчерпан, товара недостаточно на складе, слот занят или уже начался, или запрос с этим ключом еще выполняется",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности использован для другого запроса",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании заказа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/payments/webhook": {
+            "post": {
+                "description": "Принимает от платежного провайдера результат платежа. Запрос подписывается провайдером в заголовке X-Payment-Signature. Повторные уведомления ничего не меняют, при ответе с ошибкой провайдер повторяет уведомление",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Уведомление о платеже",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Подпись тела запроса",
+                        "name": "X-Payment-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Уведомление принято",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверное уведомление",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверная подпись",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Платеж не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "413": {
+                        "description": "Слишком большое уведомление",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при обработке уведомления",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products": {
+            "get": {
+                "description": "Возвращает список продуктов с различными фильтрами: по категории или бренду. Каждый продукт содержит сводку отзывов Rating: средняя оценка, число отзывов и гистограмма histogram, где i-й элемент — число отзывов с i+1 звездами. При фильтрах по составу, свойствам и оценке или сортировке выполняется поиск, найденные товары с ингредиентами из профиля аллергенов пользователя содержат поле allergens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить продукты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Артикул продукта",
+                        "name": "art",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID или slug категории, товары подкатегорий включаются",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название бренда",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "INCI ингредиенты, которые должны входить в состав",
+                        "name": "ingredient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "INCI ингредиенты, которых не должно быть в составе",
+                        "name": "exclude_ingredient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Коды типов кожи",
+                        "name": "skin_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Коды типов волос",
+                        "name": "hair_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Коды финишей",
+                        "name": "finish",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только веганские",
+                        "name": "vegan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только cruelty free",
+                        "name": "cruelty_free",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальный SPF",
+                        "name": "min_spf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная средняя оценка от 0 до 5",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "rating",
+                            "reviews"
+                        ],
+                        "type": "string",
+                        "description": "Сортировка: name (по умолчанию), rating — по средней оценке и числу отзывов, reviews — по числу отзывов",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные продукта с матрицей вариантов или список продуктов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении продуктов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Создает новый продукт в системе (только для администраторов). weight — вес единицы товара в граммах для расчета доставки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Создать продукт",
+                "parameters": [
+                    {
+                        "description": "Данные для создания продукта",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Продукт успешно создан",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных или категория не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании продукта",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}": {
+            "delete": {
+                "description": "Удаляет продукт по его идентификатору (только для администраторов)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Удалить продукт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Продукт успешно удален",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении продукта",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/attributes": {
+            "get": {
+                "description": "Возвращает ингредиенты в порядке INCI, свойства, SPF и признаки vegan и cruelty free",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить состав продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состав продукта",
+                        "schema": {
+                            "$ref": "#/definitions/structs.ProductAttributes"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении состава",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Заменяет ингредиенты (в порядке INCI), свойства, SPF и признаки vegan и cruelty free продукта (только для администраторов)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Задать состав продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Состав продукта",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.ProductAttributesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состав сохранен",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных, повторы или SPF вне 0..100",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт, ингредиент или свойство не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при сохранении состава",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/images": {
+            "get": {
+                "description": "Возвращает изображения продукта в порядке отображения вместе со ссылками на миниатюры",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить изображения продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изображения продукта",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ProductImage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении изображений",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Задает порядок отображения изображений продукта, в списке должны быть все изображения продукта (только для администраторов)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Изменить порядок изображений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID изображений в новом порядке",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.ReorderImagesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Порядок изменен",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Загружает одно или несколько изображений (JPEG, PNG, GIF до 10 МБ) и создает миниатюры (только для администраторов). Первое изображение становится основным, если primary=true или у продукта еще нет изображений",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Загрузить изображения продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файлы изображений",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Сделать первое изображение основным",
+                        "name": "primary",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Загруженные изображения",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ProductImage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "415": {
+                        "description": "Неподдерживаемый тип файла",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при загрузке",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/images/{id_image}": {
+            "delete": {
+                "description": "Удаляет изображение и его миниатюры (только для администраторов). Если удалено основное изображение, основным становится следующее по порядку",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Удалить изображение продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID изображения",
+                        "name": "id_image",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изображение удалено",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Изображение не найдено",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/images/{id_image}/primary": {
+            "put": {
+                "description": "Делает изображение основным и обновляет pic_link продукта (только для администраторов)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Сделать изображение основным",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID изображения",
+                        "name": "id_image",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Основное изображение изменено",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Изображение не найдено",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/lots": {
+            "get": {
+                "description": "Возвращает партии товара и его вариантов с ненулевым остатком, раньше истекающие первыми (для работников и администраторов)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить партии товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Партии",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.StockLot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении партий",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/price-history": {
+            "get": {
+                "description": "Возвращает все цены продукта с интервалами действия, включая запланированные (только для администраторов)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить историю цен",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История цен",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ProductPrice"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении истории",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/prices": {
+            "post": {
+                "description": "Добавляет цену, которая вступит в силу в момент valid_from (RFC 3339, только для администраторов). Цена становится текущей при следующем запуске фоновой активации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Запланировать цену",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Цена и начало действия",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.SchedulePriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID запланированной цены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных или начало действия в прошлом",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "На это время уже запланирована цена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при планировании цены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/prices/{id_price}": {
+            "delete": {
+                "description": "Удаляет цену, которая еще не вступила в силу (только для администраторов)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Отменить запланированную цену",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID цены",
+                        "name": "id_price",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Цена отменена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт или запланированная цена не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при отмене цены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/recommendations": {
+            "get": {
+                "description": "Возвращает товары, которые часто заказывают вместе с указанным, по убыванию достоверности. Товары без остатка и уже лежащие в корзине пользователя не возвращаются. Пары пересчитываются фоновой задачей",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "С этим товаром покупают",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество товаров (по умолчанию 10, не больше 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Рекомендации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Recommendation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID или лимита",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении рекомендаций",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/reorder": {
+            "put": {
+                "description": "Задает точку заказа и количество для дозаказа товара (только для администраторов). Когда остаток товара вместе с вариантами опускается ниже точки заказа, создается уведомление",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Задать точку заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Точка заказа",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.ReorderRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Точка заказа задана",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при сохранении точки заказа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Отключает уведомления о низком остатке товара (только для администраторов)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Удалить точку заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Точка заказа удалена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Точка заказа не задана",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении точки заказа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/reviews": {
+            "get": {
+                "description": "Возвращает список отзывов для указанного продукта",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить отзывы для продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список отзывов для продукта",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзывы не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении отзывов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/reviews/{id}": {
+            "get": {
+                "description": "Возвращает информацию об отзыве по его идентификатору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить отзыв по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные отзыва",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзыв не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Удаляет отзыв по его идентификатору (только для администраторов)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Удалить отзыв",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отзыв успешно удален",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзыв не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении отзыва",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/stock-movements": {
+            "get": {
+                "description": "Возвращает записи журнала движения товара и его вариантов, новые первыми (только для работников)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить движение товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Журнал движения",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.StockMovement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении журнала",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/variants": {
+            "get": {
+                "description": "Возвращает матрицу вариантов продукта: доступные оттенки, объемы и сами варианты",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Получить варианты продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Матрица вариантов",
+                        "schema": {
+                            "$ref": "#/definitions/structs.VariantMatrix"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении вариантов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Добавляет к продукту вариант (оттенок и/или объем) с собственным артикулом, ценой и остатком (только для администраторов). Вариант без веса weight весит столько же, сколько продукт",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Создать вариант продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные варианта",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Вариант успешно создан",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Артикул или сочетание оттенка и объема уже существует",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании варианта",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/products/{id}/variants/{id_variant}": {
+            "delete": {
+                "description": "Удаляет вариант продукта (только для администраторов)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Удалить вариант продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID варианта",
+                        "name": "id_variant",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Вариант удален",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Вариант не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Вариант уже заказывали",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении варианта",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "description": "Возвращает информацию о пользователе по email или номеру телефона (только для администраторов) или информацию о всех userах",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить пользователя по email или телефону",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email пользователя",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Телефон пользователя",
+                        "name": "phone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные пользователя",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Не указаны параметры email или phone",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/allergens": {
+            "get": {
+                "description": "Возвращает ингредиенты из профиля аллергенов текущего пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить аллергены",
+                "responses": {
+                    "200": {
+                        "description": "Аллергены",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Ingredient"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении аллергенов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Заменяет профиль аллергенов текущего пользователя. Товары с этими ингредиентами помечаются в корзине и поиске",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Задать аллергены",
+                "parameters": [
+                    {
+                        "description": "UUID ингредиентов",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.AllergensRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Профиль сохранен",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Ингредиент не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при сохранении аллергенов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/basket": {
+            "get": {
+                "description": "Возвращает корзину текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить корзину",
+                "responses": {
+                    "200": {
+                        "description": "Данные корзины",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/basket/checkout": {
+            "post": {
+                "description": "Резервирует товары корзины текущего пользователя на время оформления. Повторный вызов заменяет прежний резерв и продлевает его. Зарезервированный товар недоступен другим покупателям, резерв снимается при создании заказа, отмене оформления или по истечении срока",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Начать оформление",
+                "responses": {
+                    "201": {
+                        "description": "Резерв товаров",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Reservation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Корзина пуста",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар или вариант не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Товара недостаточно на складе",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при резервировании",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Снимает резерв товаров корзины текущего пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Отменить оформление",
+                "responses": {
+                    "200": {
+                        "description": "Резерв снят",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при снятии резерва",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/basket/discounts": {
+            "get": {
+                "description": "Возвращает сумму корзины текущего пользователя со скидками действующих акций и промокода. Та же скидка будет применена при оформлении заказа",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Рассчитать скидки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Промокод",
+                        "name": "code",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Расчет скидок",
+                        "schema": {
+                            "$ref": "#/definitions/structs.DiscountQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Промокод неактивен или не подходит к корзине",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Промокод не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Лимит использований промокода исчерпан",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при расчете скидок",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/basket/items": {
+            "get": {
+                "description": "Возвращает список всех товаров в корзине текущего пользователя. Товары с ингредиентами из профиля аллергенов содержат поле allergens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить товары корзины",
+                "responses": {
+                    "200": {
+                        "description": "Список товаров в корзине",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.BasketItemResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении товаров",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Добавляет товар с указанным количеством в корзину пользователя. Для товаров с вариантами (оттенок, объем) передается variant_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Добавить товар в корзину",
+                "parameters": [
+                    {
+                        "description": "Данные товара для добавления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.BasketItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Товар успешно добавлен",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при добавлении товара",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Удаляет указанный товар из корзины пользователя, для товаров с вариантами — конкретный вариант по variant_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Удалить товар из корзины",
+                "parameters": [
+                    {
+                        "description": "Данные товара для удаления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.BasketItemDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Товар успешно удален",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Корзина не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении товара",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Обновляет количество указанного товара в корзине пользователя, для товаров с вариантами — конкретного варианта по variant_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Обновить количество товара",
+                "parameters": [
+                    {
+                        "description": "Данные для обновления количества",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.BasketItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Количество товара успешно обновлено",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Элемент корзины не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при обновлении количества",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/basket/recommendations": {
+            "get": {
+                "description": "Возвращает товары, которые часто заказывают вместе с товарами корзины текущего пользователя. Товары без остатка и уже лежащие в корзине не возвращаются",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Рекомендации к корзине",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество товаров (по умолчанию 10, не больше 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Рекомендации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Recommendation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный лимит",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении рекомендаций",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/favourite/items": {
+            "get": {
+                "description": "Возвращает список всех товаров в избранном текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить избранные товары",
+                "responses": {
+                    "200": {
+                        "description": "Список товаров в избранном",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Избранные не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении избранного",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Добавляет товар в избранное текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Добавить товар в избранное",
+                "parameters": [
+                    {
+                        "description": "Данные товара для добавления в избранное",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.AddFavouriteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Товар успешно добавлен в избранное",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при добавлении в избранное",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/favourite/items/{id_product}": {
+            "delete": {
+                "description": "Удаляет товар из избранного текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Удалить товар из избранного",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID товара",
+                        "name": "id_product",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Товар успешно удален из избранного",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Избранные не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении из избранного",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/orders": {
+            "get": {
+                "description": "Возвращает список заказов. Заказы текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить заказы",
+                "responses": {
+                    "200": {
+                        "description": "Список заказов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный параметр статуса",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказы не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении заказов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/orders/{id}": {
+            "delete": {
+                "description": "Удаляет заказ по его идентификатору (только для администраторов). Товар еще не отданного и не отмененного заказа возвращается на склад",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Удалить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказ успешно удален",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении заказа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Переводит заказ в новый статус (для работников и администраторов). Неоплаченный заказ становится непринятым только после оплаты. Работник ведет заказ по шагам непринятый → принятый → собранный → отданный и может отметить непринятый заказ некорректным, администратор дополнительно может отметить некорректным или отменить любой еще не отданный заказ. Товар некорректного и отмененного заказа возвращается на склад, оплата возвращается покупателю. Отданный, некорректный и отмененный заказы не меняются. Переход записывается в историю статусов с комментарием comment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Изменить статус заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "некорректный",
+                            "неоплаченный",
+                            "непринятый",
+                            "принятый",
+                            "собранный",
+                            "отданный",
+                            "отмененный"
+                        ],
+                        "type": "string",
+                        "description": "Новый статус заказа",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Комментарий к переходу, для отмены - обязательная причина",
+                        "name": "comment",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статус заказа успешно обновлен",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Переход в этот статус недопустим",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при обновлении статуса",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/orders/{id}/cancel": {
+            "post": {
+                "description": "Отменяет заказ текущего пользователя, пока он неоплаченный, непринятый или принятый. Причина отмены записывается в историю статусов, товар возвращается на склад, в те же партии, оплата возвращается покупателю",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Отменить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отмены",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CancelOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказ отменен",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных или не указана причина",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ уже нельзя отменить",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при отмене заказа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/orders/{id}/history": {
+            "get": {
+                "description": "Возвращает переходы статусов заказа текущего пользователя от создания заказа: прежний и новый статус, роль автора перехода, комментарий и время",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить историю статусов заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История статусов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.OrderStatusChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении истории",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/orders/{id}/items": {
+            "get": {
+                "description": "Возвращает список товаров в указанном заказе. Price (цена за единицу), Discount (скидка на позицию), Name, Art и Brand сохранены на момент покупки и не меняются вместе с каталогом. Для товаров, хранящихся партиями, Lots содержит списанные партии со сроками годности",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить товары заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список товаров в заказе",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзывы не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении товаров",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/orders/{id}/payments": {
+            "get": {
+                "description": "Возвращает платежи заказа текущего пользователя, сначала новые, с их статусом и причиной отказа",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить платежи заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Платежи заказа",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Payment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении платежей",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Создает платеж на всю сумму неоплаченного заказа текущего пользователя и возвращает client_secret для оплаты у платежного провайдера. Результат оплаты приходит от провайдера, после успешной оплаты заказ становится непринятым и виден работникам. Заказ без суммы к оплате оплачивается сразу. Поддерживает заголовок Idempotency-Key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Оплатить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Способ оплаты, для тестового провайдера fake-declined или fake-delayed",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controller.StartPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданный платеж",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ уже оплачен или отменен",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании платежа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/orders/{id}/reorder": {
+            "post": {
+                "description": "Добавляет товары заказа текущего пользователя в корзину. Товары, которых больше нет в каталоге (archived) или нет в наличии (out_of_stock), пропускаются; количество уменьшается до остатка с учетом того, что уже лежит в корзине. Для каждой позиции возвращается status: added, reduced или skipped с причиной reason",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Повторить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат по позициям заказа",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/structs.RepeatItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при повторе заказа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/orders/{id}/returns": {
+            "post": {
+                "description": "Создает заявку на возврат части позиций отданного заказа текущего пользователя с указанием количества и причины. Возврат возможен в течение срока возврата с момента выдачи заказа, по каждой позиции нельзя вернуть больше купленного с учетом прежних возвратов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Оформить возврат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Позиции и причина возврата",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID заявки на возврат",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные возврата",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ не отдан, срок возврата истек или товар уже возвращен",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании возврата",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/products/{id_product}/reviews": {
+            "post": {
+                "description": "Создает новый отзыв для указанного продукта от текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Создать отзыв",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID продукта",
+                        "name": "id_product",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом и телом возвращает первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Данные для создания отзыва",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Отзыв успешно создан",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности еще выполняется",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности использован для другого запроса",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании отзыва",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/returns": {
+            "get": {
+                "description": "Возвращает заявки на возврат текущего пользователя, сначала новые",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить свои возвраты",
+                "responses": {
+                    "200": {
+                        "description": "Заявки на возврат",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReturnRequest"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении возвратов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/returns/{id}": {
+            "get": {
+                "description": "Возвращает заявку на возврат текущего пользователя с позициями, фотографиями и суммой возврата денег",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить свой возврат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заявки на возврат",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заявка на возврат",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReturnRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении возврата",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/users/me/returns/{id}/photos": {
+            "post": {
+                "description": "Прикладывает фотографии товара (JPEG или PNG до 10 МБ, не больше 5 на заявку) к заявке на возврат текущего пользователя, пока она не рассмотрена",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Загрузить фотографии к возврату",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заявки на возврат",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Фотографии",
+                        "name": "photos",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Загруженные фотографии",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReturnPhoto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных или слишком много фотографий",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Заявка уже рассмотрена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "415": {
+                        "description": "Неподдерживаемый тип файла",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при загрузке",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers": {
+            "get": {
+                "description": "Возвращает список всех работников системы (только для администраторов)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Получить всех работников",
+                "responses": {
+                    "200": {
+                        "description": "Список всех работников",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Работник не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении работников",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Создает нового работника в системе (только для администраторов)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Создать работника",
+                "parameters": [
+                    {
+                        "description": "Данные для создания работника",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateWorkerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Работник успешно создан",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании работника",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/me/alerts": {
+            "get": {
+                "description": "Возвращает открытые уведомления о товарах с остатком ниже точки заказа, сначала новые (только для работников)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Получить уведомления о низком остатке",
+                "responses": {
+                    "200": {
+                        "description": "Уведомления",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.StockAlert"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении уведомлений",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/me/orders": {
+            "get": {
+                "description": "Возвращает список заказов текущего работника (только для работников)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Получить заказы работника",
+                "responses": {
+                    "200": {
+                        "description": "Список заказов работника",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказы не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Принимает заказ для выполнения текущим работником (только для работников)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Принять заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "order_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказ успешно принят",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID заказа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ уже не в статусе непринятый",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при принятии заказа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/me/purchase-orders": {
+            "get": {
+                "description": "Возвращает заказы поставщикам без строк, сначала новые (для администраторов и работников). status: draft, sent, partially_received, received",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Получить заказы поставщикам",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Статус заказа",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказы",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.PurchaseOrder"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный статус",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении заказов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/me/purchase-orders/{id}": {
+            "get": {
+                "description": "Возвращает заказ поставщику со строками и расхождениями (для администраторов и работников)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Получить заказ поставщику",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказ",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении заказа",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/me/purchase-orders/{id}/receipts": {
+            "post": {
+                "description": "Записывает фактически полученное количество по строкам заказа и увеличивает остатки на это количество (только для работников). Заказ закрывается, когда все строки получены полностью или передан final, тогда строки с недопоставкой или перепоставкой записываются как расхождения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Принять поставку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Поставка",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.PurchaseReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказ после приемки",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные поставки",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ или строка не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ не отправлен, уже получен или изменен другой приемкой",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при приемке",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/me/returns": {
+            "get": {
+                "description": "Возвращает заявки на возврат, сначала новые, с фильтром по статусу (для администраторов и работников)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Получить заявки на возврат",
+                "parameters": [
+                    {
+                        "enum": [
+                            "requested",
+                            "approved",
+                            "rejected",
+                            "received"
+                        ],
+                        "type": "string",
+                        "description": "Статус заявки",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заявки на возврат",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReturnRequest"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный статус",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении возвратов",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/me/returns/{id}": {
+            "get": {
+                "description": "Возвращает заявку на возврат с позициями, фотографиями и суммой возврата денег (для администраторов и работников)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Получить заявку на возврат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заявки на возврат",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заявка на возврат",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReturnRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении возврата",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/me/returns/{id}/receipts": {
+            "post": {
+                "description": "Записывает по каждой позиции одобренного возврата полученное количество и состояние товара: sellable или damaged (только для работников). Годный товар возвращается на склад в партии, из которых был продан, начиная с самой поздней, на сумму, оплаченную за весь полученный товар, создается возврат денег",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Принять возврат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заявки на возврат",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Полученный товар",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.ReturnReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заявка после приемки",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReturnRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные приемки",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Заявка не найдена",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Возврат не одобрен или уже принят",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при приемке",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/me/stock/adjustments": {
+            "post": {
+                "description": "Записывает в журнал движения корректировку (kind=adjustment, по умолчанию, quantity со знаком), списание (write_off, quantity \u003c 0) или возврат на склад (return, quantity \u003e 0) с обязательной причиной (только для работников). Остаток, хранящийся партиями, списывается с указанием партии id_lot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Скорректировать остаток",
+                "parameters": [
+                    {
+                        "description": "Корректировка",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.StockAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Запись журнала",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.StockMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные корректировки",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар, вариант или партия не найдены",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Недостаточно товара на складе или не указана партия",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при корректировке",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/me/stock/receipts": {
+            "post": {
+                "description": "Записывает поступление товара или варианта в журнал движения и увеличивает остаток (только для работников). reference — номер документа поставки. Если указан batch, товар приходуется в партию с этим номером и сроком годности expires_at; партия создается при первом поступлении",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Оприходовать поступление",
+                "parameters": [
+                    {
+                        "description": "Поступление",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.StockReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Запись журнала",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.StockMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные поступления",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар или вариант не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "409": {
+                        "description": "Срок годности не совпадает с уже принятой партией",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при оприходовании",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/workers/{id}": {
+            "get": {
+                "description": "Возвращает информацию о работнике по его идентификатору (только для администраторов)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Получить работника по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"123e4567-e89b-12d3-a456-426614174000\"",
+                        "description": "UUID работника",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные работника",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Работник не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Удаляет работника по его идентификатору (только для администраторов)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workers"
+                ],
+                "summary": "Удалить работника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID работника",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Работник успешно удален",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный доступ",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Работник не найден",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении работника",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "controller.AddFavouriteRequest": {
+            "type": "object",
+            "required": [
+                "id_product"
+            ],
+            "properties": {
+                "id_product": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.AllergensRequest": {
+            "type": "object",
+            "properties": {
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "controller.AttributeRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.BasketItemDeleteRequest": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "variant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.BasketItemRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "product_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "variant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.BasketItemResponse": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Ingredient"
+                    }
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idBasket": {
+                    "type": "string"
+                },
+                "idProduct": {
+                    "type": "string"
+                },
+                "idVariant": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CancelOrderRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CategoryRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "slug"
+            ],
+            "properties": {
+                "id_parent": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.CreateBrandRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name",
+                "price_category"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price_category": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CreateDeliveryRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name",
+                "rates"
+            ],
+            "properties": {
+                "free_from": {
+                    "type": "number"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.DeliveryRateRequest"
+                    }
+                }
+            }
+        },
+        "controller.CreateDeliverySlotRequest": {
+            "type": "object",
+            "required": [
+                "capacity",
+                "ends_at",
+                "starts_at"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CreateOrderRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "id_delivery"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "id_delivery": {
+                    "type": "string"
+                },
+                "id_slot": {
+                    "type": "string"
+                },
+                "promo_code": {
+                    "type": "string"
+                },
+                "zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "id_brand",
+                "id_category",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "articule": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id_brand": {
+                    "type": "string"
+                },
+                "id_category": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pic_link": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "controller.CreatePromotionRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "buy_quantity": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "free_quantity": {
+                    "type": "integer"
+                },
+                "id_brand": {
+                    "type": "string"
+                },
+                "id_category": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "min_basket": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.CreatePurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "id_supplier",
+                "lines"
+            ],
+            "properties": {
+                "expected_at": {
+                    "type": "string"
+                },
+                "id_supplier": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.PurchaseLineRequest"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CreateReturnRequest": {
+            "type": "object",
+            "required": [
+                "lines",
+                "reason"
+            ],
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.ReturnLineRequest"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CreateReviewRequest": {
+            "type": "object",
+            "required": [
+                "r_text",
+                "rating"
+            ],
+            "properties": {
+                "r_text": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "controller.CreateSupplierRequest": {
+            "type": "object",
+            "required": [
+                "brands",
+                "name"
+            ],
+            "properties": {
+                "brands": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CreateVariantRequest": {
+            "type": "object",
+            "required": [
+                "articule"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "articule": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "shade": {
+                    "type": "string"
+                },
+                "volume_ml": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "weight": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "controller.CreateWorkerRequest": {
+            "type": "object",
+            "required": [
+                "id_user",
+                "job_title"
+            ],
+            "properties": {
+                "id_user": {
+                    "type": "string"
+                },
+                "job_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.DeliveryRateRequest": {
+            "type": "object",
+            "required": [
+                "zone"
+            ],
+            "properties": {
+                "cost": {
+                    "type": "number"
+                },
+                "max_weight": {
+                    "type": "integer"
+                },
+                "min_total": {
+                    "type": "number"
+                },
+                "per_kg": {
+                    "type": "number"
+                },
+                "zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.IngredientRequest": {
+            "type": "object",
+            "required": [
+                "inci_name"
+            ],
+            "properties": {
+                "inci_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.LogoutRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.ProductAttributesRequest": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cruelty_free": {
+                    "type": "boolean"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "spf": {
+                    "type": "integer"
+                },
+                "vegan": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "controller.PurchaseLineRequest": {
+            "type": "object",
+            "required": [
+                "id_product",
+                "quantity"
+            ],
+            "properties": {
+                "id_product": {
+                    "type": "string"
+                },
+                "id_variant": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.PurchaseReceiptRequest": {
+            "type": "object",
+            "properties": {
+                "final": {
+                    "type": "boolean"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.ReceivedLineRequest"
+                    }
+                }
+            }
+        },
+        "controller.ReceivedLineRequest": {
+            "type": "object",
+            "required": [
+                "id_line"
+            ],
+            "properties": {
+                "id_line": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.ReceivedReturnLineRequest": {
+            "type": "object",
+            "required": [
+                "condition",
+                "id_line"
+            ],
+            "properties": {
+                "condition": {
+                    "type": "string"
+                },
+                "id_line": {
+                    "type": "string"
+                },
+                "received": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.RejectReturnRequest": {
+            "type": "object",
+            "required": [
+                "comment"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.ReorderImagesRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "controller.ReorderRuleRequest": {
+            "type": "object",
+            "required": [
+                "reorder_quantity"
+            ],
+            "properties": {
+                "reorder_point": {
+                    "type": "integer"
+                },
+                "reorder_quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.ReturnLineRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "id_order_item"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "id_order_item": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.ReturnReceiptRequest": {
+            "type": "object",
+            "required": [
+                "lines"
+            ],
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.ReceivedReturnLineRequest"
+                    }
+                }
+            }
+        },
+        "controller.SchedulePriceRequest": {
+            "type": "object",
+            "required": [
+                "valid_from"
+            ],
+            "properties": {
+                "price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "valid_from": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.SetDeliveryRatesRequest": {
+            "type": "object",
+            "required": [
+                "rates"
+            ],
+            "properties": {
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.DeliveryRateRequest"
+                    }
+                }
+            }
+        },
+        "controller.SignupRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "date_of_birth",
+                "email",
+                "name",
+                "password",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.StartPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "method": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.StockAdjustmentRequest": {
+            "type": "object",
+            "required": [
+                "id_product",
+                "quantity",
+                "reason"
+            ],
+            "properties": {
+                "id_lot": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "id_variant": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.StockReceiptRequest": {
+            "type": "object",
+            "required": [
+                "id_product",
+                "quantity"
+            ],
+            "properties": {
+                "batch": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "id_variant": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.Attribute": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.Category": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Category"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_parent": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.DeliveryMethod": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "free_from": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/structs.DeliveryKind"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.DeliveryRate"
+                    }
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.DeliveryRate": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "number"
+                },
+                "max_weight": {
+                    "type": "integer"
+                },
+                "min_total": {
+                    "type": "number"
+                },
+                "per_kg": {
+                    "type": "number"
+                },
+                "zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.DeliverySlot": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_delivery": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "taken": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.Ingredient": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "inci_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.OrderItem": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "art": {
+                    "type": "string"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idOrder": {
+                    "type": "string"
+                },
+                "idProduct": {
+                    "type": "string"
+                },
+                "idVariant": {
+                    "type": "string"
+                },
+                "lots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.OrderItemLot"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number",
+                    "format": "float64"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.OrderItemLot": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "batch": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id_lot": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.OrderStatusChange": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from": {
+                    "$ref": "#/definitions/structs.OrderStatus"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_actor": {
+                    "type": "string"
+                },
+                "id_order": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/structs.OrderRole"
+                },
+                "to": {
+                    "$ref": "#/definitions/structs.OrderStatus"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.OrderSummary": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "customer_mail": {
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_user": {
+                    "type": "string"
+                },
+                "id_worker": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.OrderItem"
+                    }
+                },
+                "price": {
+                    "type": "number"
+                },
+                "status": {
+                    "$ref": "#/definitions/structs.OrderStatus"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "client_secret": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_order": {
+                    "type": "string"
+                },
+                "id_user": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.ProductImage": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "thumbnails": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.ProductPrice": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "scheduled": {
+                    "type": "boolean"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.ProductVariant": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "articule": {
+                    "type": "string"
+                },
+                "available": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "shade": {
+                    "type": "string"
+                },
+                "volume_ml": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.Promotion": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "buy_quantity": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "free_quantity": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_brand": {
+                    "type": "string"
+                },
+                "id_category": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "min_basket": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.PurchaseDiscrepancy": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "difference": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_line": {
+                    "type": "string"
+                },
+                "ordered": {
+                    "type": "integer"
+                },
+                "received": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.PurchaseLine": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "id_variant": {
+                    "type": "string"
+                },
+                "ordered": {
+                    "type": "integer"
+                },
+                "received": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "discrepancies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.PurchaseDiscrepancy"
+                    }
+                },
+                "expected_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_supplier": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.PurchaseLine"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.Recommendation": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "articule": {
+                    "type": "string"
+                },
+                "available": {
+                    "type": "integer"
+                },
+                "confidence": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idBrand": {
+                    "type": "string"
+                },
+                "idCategory": {
+                    "type": "string"
+                },
+                "lift": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "picLink": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "rating": {
+                    "$ref": "#/definitions/structs.ProductRating"
+                },
+                "support": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.Refund": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_order": {
+                    "type": "string"
+                },
+                "id_payment": {
+                    "type": "string"
+                },
+                "id_return": {
+                    "type": "string"
+                },
+                "processed_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.ReorderItem": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "articule": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reorder_point": {
+                    "type": "integer"
+                },
+                "reorder_quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.Reservation": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "id_user": {
+                    "type": "string"
+                },
+                "id_variant": {
+                    "type": "string"
                 }
             }
         },
-        "controller.CreateBrandRequest": {
+        "github_com_taucuya_ppo_internal_core_structs.ReturnLine": {
             "type": "object",
-            "required": [
-                "description",
-                "name",
-                "price_category"
-            ],
             "properties": {
-                "description": {
+                "amount": {
+                    "type": "integer"
+                },
+                "condition": {
                     "type": "string"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "price_category": {
+                "id_order_item": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "id_variant": {
                     "type": "string"
+                },
+                "received": {
+                    "type": "integer"
                 }
             }
         },
-        "controller.CreateOrderRequest": {
+        "github_com_taucuya_ppo_internal_core_structs.ReturnPhoto": {
             "type": "object",
-            "required": [
-                "address"
-            ],
             "properties": {
-                "address": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_return": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "controller.CreateProductRequest": {
+        "github_com_taucuya_ppo_internal_core_structs.ReturnRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_order": {
+                    "type": "string"
+                },
+                "id_user": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReturnLine"
+                    }
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReturnPhoto"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "refund": {
+                    "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Refund"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_taucuya_ppo_internal_core_structs.StockAlert": {
             "type": "object",
-            "required": [
-                "amount",
-                "category",
-                "id_brand",
-                "name",
-                "price"
-            ],
             "properties": {
                 "amount": {
-                    "type": "integer",
-                    "minimum": 0
+                    "type": "integer"
                 },
                 "articule": {
                     "type": "string"
                 },
-                "category": {
+                "created_at": {
                     "type": "string"
                 },
-                "description": {
+                "id": {
                     "type": "string"
                 },
-                "id_brand": {
+                "id_product": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "pic_link": {
-                    "type": "string"
+                "reorder_point": {
+                    "type": "integer"
                 },
-                "price": {
+                "reorder_quantity": {
+                    "type": "integer"
+                },
+                "resolved_at": {
                     "type": "string"
                 }
             }
         },
-        "controller.CreateReviewRequest": {
+        "github_com_taucuya_ppo_internal_core_structs.StockLot": {
             "type": "object",
-            "required": [
-                "r_text",
-                "rating"
-            ],
             "properties": {
-                "r_text": {
+                "amount": {
+                    "type": "integer"
+                },
+                "batch": {
                     "type": "string"
                 },
-                "rating": {
-                    "type": "integer",
-                    "maximum": 5,
-                    "minimum": 1
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "id_variant": {
+                    "type": "string"
                 }
             }
         },
-        "controller.CreateWorkerRequest": {
+        "github_com_taucuya_ppo_internal_core_structs.StockMovement": {
             "type": "object",
-            "required": [
-                "id_user",
-                "job_title"
-            ],
             "properties": {
-                "id_user": {
+                "balance": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "job_title": {
+                "id": {
+                    "type": "string"
+                },
+                "id_actor": {
+                    "type": "string"
+                },
+                "id_lot": {
+                    "type": "string"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "id_variant": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference": {
                     "type": "string"
                 }
             }
         },
-        "controller.LoginRequest": {
+        "github_com_taucuya_ppo_internal_core_structs.Supplier": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
+                "brands": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "email": {
                     "type": "string"
                 },
-                "password": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
                     "type": "string"
                 }
             }
         },
-        "controller.LogoutRequest": {
+        "structs.BrandReorder": {
             "type": "object",
-            "required": [
-                "refresh_token"
+            "properties": {
+                "brand": {
+                    "type": "string"
+                },
+                "id_brand": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ReorderItem"
+                    }
+                }
+            }
+        },
+        "structs.CatalogImportReport": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/structs.CatalogImportRowResult"
+                    }
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "structs.CatalogImportRowResult": {
+            "type": "object",
+            "properties": {
+                "articule": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "structs.DeliveryKind": {
+            "type": "string",
+            "enum": [
+                "courier",
+                "pickup",
+                "post"
             ],
+            "x-enum-varnames": [
+                "DeliveryCourier",
+                "DeliveryPickup",
+                "DeliveryPost"
+            ]
+        },
+        "structs.DiscountQuote": {
+            "type": "object",
             "properties": {
-                "refresh_token": {
+                "discount": {
+                    "type": "number"
+                },
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/structs.LineDiscount"
+                    }
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "structs.LineDiscount": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "id_product": {
+                    "type": "string"
+                },
+                "id_promotion": {
+                    "type": "string"
+                },
+                "id_variant": {
                     "type": "string"
                 }
             }
         },
-        "controller.SignupRequest": {
+        "structs.OrderPage": {
             "type": "object",
-            "required": [
-                "address",
-                "date_of_birth",
-                "email",
-                "name",
-                "password",
-                "phone"
+            "properties": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.OrderSummary"
+                    }
+                }
+            }
+        },
+        "structs.OrderRole": {
+            "type": "string",
+            "enum": [
+                "customer",
+                "worker",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "OrderRoleCustomer",
+                "OrderRoleWorker",
+                "OrderRoleAdmin"
+            ]
+        },
+        "structs.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "некорректный",
+                "неоплаченный",
+                "непринятый",
+                "принятый",
+                "собранный",
+                "отданный",
+                "отмененный"
             ],
+            "x-enum-varnames": [
+                "OrderInvalid",
+                "OrderUnpaid",
+                "OrderNew",
+                "OrderAccepted",
+                "OrderAssembled",
+                "OrderShipped",
+                "OrderCancelled"
+            ]
+        },
+        "structs.ProductAttributes": {
+            "type": "object",
             "properties": {
-                "address": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Attribute"
+                    }
+                },
+                "cruelty_free": {
+                    "type": "boolean"
+                },
+                "id_product": {
                     "type": "string"
                 },
-                "date_of_birth": {
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.Ingredient"
+                    }
+                },
+                "spf": {
+                    "type": "integer"
+                },
+                "vegan": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "structs.ProductRating": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "histogram": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "structs.RepeatItem": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "id_product": {
                     "type": "string"
                 },
-                "email": {
+                "id_variant": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "password": {
+                "reason": {
                     "type": "string"
                 },
-                "phone": {
-                    "type": "string"
+                "requested": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/structs.RepeatStatus"
+                }
+            }
+        },
+        "structs.RepeatStatus": {
+            "type": "string",
+            "enum": [
+                "added",
+                "reduced",
+                "skipped"
+            ],
+            "x-enum-varnames": [
+                "RepeatAdded",
+                "RepeatReduced",
+                "RepeatSkipped"
+            ]
+        },
+        "structs.VariantMatrix": {
+            "type": "object",
+            "properties": {
+                "shades": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_taucuya_ppo_internal_core_structs.ProductVariant"
+                    }
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         }
@@ -69,7 +69,7 @@ func TestE2E_UserOrderFlow(t *testing.T) {
 	// }
 
 	// 3) Получить каталог товаров
-	req, _ := http.NewRequest("GET", baseURL+"/api/v1/products?category=уход", nil)
+	req, _ := http.NewRequest("GET", baseURL+"/api/v1/products?category=skincare", nil)
 	resp, err = client.Do(req)
 	require.NoError(t, err)
 	defer resp.Body.Close()
@@ -103,9 +103,28 @@ func TestE2E_UserOrderFlow(t *testing.T) {
 	defer resp.Body.Close()
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 
-	// 6) Создать заказ
+	// 6) Выбрать самовывоз
+	req, _ = http.NewRequest("GET", baseURL+"/api/v1/delivery", nil)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var methods []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&methods)
+	deliveryID := ""
+	for _, m := range methods {
+		if m["kind"] == "pickup" {
+			deliveryID, _ = m["id"].(string)
+		}
+	}
+	require.NotEmpty(t, deliveryID)
+
+	// 7) Создать заказ
 	createOrderReq := map[string]string{
-		"address": "123 Delivery Address",
+		"address":     "123 Delivery Address",
+		"id_delivery": deliveryID,
+		"zone":        "moscow",
 	}
 	createOrderBody, _ := json.Marshal(createOrderReq)
 
@@ -116,7 +135,7 @@ func TestE2E_UserOrderFlow(t *testing.T) {
 	defer resp.Body.Close()
 	require.Equal(t, http.StatusCreated, resp.StatusCode)
 
-	// 7) Проверить заказ
+	// 8) Проверить заказ
 	req, _ = http.NewRequest("GET", baseURL+"/api/v1/users/me/orders/", nil)
 	resp, err = client.Do(req)
 	require.NoError(t, err)
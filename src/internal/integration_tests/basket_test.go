@@ -461,7 +461,7 @@ func TestBasket_DeleteItem_AAA(t *testing.T) {
 				defer fixture.cleanupUserData(userID)
 			}
 
-			err := fixture.service.DeleteItem(fixture.ctx, userID, productID)
+			err := fixture.service.DeleteItem(fixture.ctx, userID, productID, uuid.Nil)
 
 			if tt.expectedErr {
 				require.Error(t, err)
@@ -541,7 +541,7 @@ func TestBasket_UpdateItemAmount_AAA(t *testing.T) {
 				defer fixture.cleanupUserData(userID)
 			}
 
-			err := fixture.service.UpdateItemAmount(fixture.ctx, userID, productID, amount)
+			err := fixture.service.UpdateItemAmount(fixture.ctx, userID, productID, uuid.Nil, amount)
 
 			if tt.expectedErr {
 				require.Error(t, err)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log"
 	"net/http"
@@ -21,23 +22,57 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	controller "github.com/taucuya/ppo/internal/controllers"
+	"github.com/taucuya/ppo/internal/core/service/alert"
+	"github.com/taucuya/ppo/internal/core/service/attribute"
 	"github.com/taucuya/ppo/internal/core/service/auth"
 	"github.com/taucuya/ppo/internal/core/service/basket"
 	"github.com/taucuya/ppo/internal/core/service/brand"
+	"github.com/taucuya/ppo/internal/core/service/catalog"
+	"github.com/taucuya/ppo/internal/core/service/category"
+	"github.com/taucuya/ppo/internal/core/service/delivery"
 	"github.com/taucuya/ppo/internal/core/service/favourites"
+	"github.com/taucuya/ppo/internal/core/service/idempotency"
+	"github.com/taucuya/ppo/internal/core/service/media"
 	"github.com/taucuya/ppo/internal/core/service/order"
+	"github.com/taucuya/ppo/internal/core/service/payment"
+	"github.com/taucuya/ppo/internal/core/service/price"
 	"github.com/taucuya/ppo/internal/core/service/product"
+	"github.com/taucuya/ppo/internal/core/service/promotion"
+	"github.com/taucuya/ppo/internal/core/service/purchase"
+	"github.com/taucuya/ppo/internal/core/service/recommendation"
+	"github.com/taucuya/ppo/internal/core/service/reservation"
+	"github.com/taucuya/ppo/internal/core/service/returns"
 	"github.com/taucuya/ppo/internal/core/service/review"
+	"github.com/taucuya/ppo/internal/core/service/stock"
 	"github.com/taucuya/ppo/internal/core/service/user"
 	"github.com/taucuya/ppo/internal/core/service/worker"
+	"github.com/taucuya/ppo/internal/core/structs"
+	payment_prov "github.com/taucuya/ppo/internal/providers/fake/payment"
+	storage_prov "github.com/taucuya/ppo/internal/providers/fs/storage"
 	auth_prov "github.com/taucuya/ppo/internal/providers/jwt/auth"
+	notifier_prov "github.com/taucuya/ppo/internal/providers/log/notifier"
+	alert_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/alert"
+	attribute_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/attribute"
 	auth_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/auth"
 	basket_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/basket"
 	brand_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/brand"
+	catalog_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/catalog"
+	category_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/category"
+	delivery_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/delivery"
 	favourites_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/favourites"
+	idempotency_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/idempotency"
+	media_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/media"
 	order_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/order"
+	payment_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/payment"
+	price_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/price"
 	product_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/product"
+	promotion_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/promotion"
+	purchase_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/purchase"
+	recommendation_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/recommendation"
+	reservation_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/reservation"
+	returns_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/returns"
 	review_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/review"
+	stock_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/stock"
 	user_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/user"
 	worker_rep "github.com/taucuya/ppo/internal/repository/postgres/reps/worker"
 )
@@ -58,6 +93,22 @@ func runSQLScripts(db *sqlx.DB, scripts []string) error {
 	return nil
 }
 
+// runJob calls job right away and then every interval until ctx is done.
+func runJob(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := job(ctx); err != nil {
+			log.Printf("[ERROR] Job %s failed: %v", name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func loadEnv() {
 	if err := godotenv.Load(); err != nil {
 		log.Println("Файл .env не найден, используются переменные окружения")
@@ -111,13 +162,99 @@ func main() {
 		panic(err)
 	}
 
+	shop := structs.ShopInfo{
+		Name:    os.Getenv("SHOP_NAME"),
+		Company: os.Getenv("SHOP_COMPANY"),
+		URL:     os.Getenv("SHOP_URL"),
+	}
+	if shop.Name == "" {
+		shop.Name = "Виртуаль"
+	}
+	if shop.Company == "" {
+		shop.Company = shop.Name
+	}
+	mediaDir := os.Getenv("MEDIA_DIR")
+	if mediaDir == "" {
+		mediaDir = "./media"
+	}
+	priceInterval, err := strconv.Atoi(os.Getenv("PRICE_ACTIVATION_INTERVAL_SECONDS"))
+	if err != nil || priceInterval <= 0 {
+		priceInterval = 60
+	}
+	reservationTTL, err := strconv.Atoi(os.Getenv("RESERVATION_TTL_MINUTES"))
+	if err != nil || reservationTTL <= 0 {
+		reservationTTL = 15
+	}
+	sweepInterval, err := strconv.Atoi(os.Getenv("RESERVATION_SWEEP_INTERVAL_SECONDS"))
+	if err != nil || sweepInterval <= 0 {
+		sweepInterval = 60
+	}
+	idempotencyTTL, err := strconv.Atoi(os.Getenv("IDEMPOTENCY_TTL_HOURS"))
+	if err != nil || idempotencyTTL <= 0 {
+		idempotencyTTL = 24
+	}
+	idempotencySweep, err := strconv.Atoi(os.Getenv("IDEMPOTENCY_SWEEP_INTERVAL_SECONDS"))
+	if err != nil || idempotencySweep <= 0 {
+		idempotencySweep = 3600
+	}
+	recInterval, err := strconv.Atoi(os.Getenv("RECOMMENDATION_INTERVAL_SECONDS"))
+	if err != nil || recInterval <= 0 {
+		recInterval = 3600
+	}
+	thresholds := structs.RecommendationThresholds{MinSupport: 2, MinConfidence: 0.1, MinLift: 1}
+	if v, err := strconv.Atoi(os.Getenv("RECOMMENDATION_MIN_SUPPORT")); err == nil && v > 0 {
+		thresholds.MinSupport = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("RECOMMENDATION_MIN_CONFIDENCE"), 64); err == nil && v >= 0 {
+		thresholds.MinConfidence = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("RECOMMENDATION_MIN_LIFT"), 64); err == nil && v >= 0 {
+		thresholds.MinLift = v
+	}
+	webhookSecret := []byte(os.Getenv("PAYMENT_WEBHOOK_SECRET"))
+	if len(webhookSecret) == 0 {
+		webhookSecret = make([]byte, 32)
+		if _, err := rand.Read(webhookSecret); err != nil {
+			log.Fatalf("Cant generate payment webhook secret: %v", err)
+		}
+		log.Printf("PAYMENT_WEBHOOK_SECRET is not set, using a random secret")
+	}
+	webhookURL := os.Getenv("PAYMENT_WEBHOOK_URL")
+	if webhookURL == "" {
+		webhookURL = "http://localhost:8080/api/v1/payments/webhook"
+	}
+	paymentDelay, err := strconv.Atoi(os.Getenv("FAKE_PAYMENT_DELAY_SECONDS"))
+	if err != nil || paymentDelay < 0 {
+		paymentDelay = 10
+	}
+	refundInterval, err := strconv.Atoi(os.Getenv("REFUND_INTERVAL_SECONDS"))
+	if err != nil || refundInterval <= 0 {
+		refundInterval = 60
+	}
+	returnWindow, err := strconv.Atoi(os.Getenv("RETURN_WINDOW_DAYS"))
+	if err != nil || returnWindow <= 0 {
+		returnWindow = 14
+	}
+	alertInterval, err := strconv.Atoi(os.Getenv("ALERT_INTERVAL_SECONDS"))
+	if err != nil || alertInterval <= 0 {
+		alertInterval = 300
+	}
+	alertLog := log.Default()
+	if name := os.Getenv("ALERT_LOG_FILE"); name != "" {
+		alertFile, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		if err != nil {
+			log.Fatalf("Cant open alert log file: %v", err)
+		}
+		defer alertFile.Close()
+		alertLog = log.New(alertFile, "", log.LstdFlags)
+	}
+
 // 	_ = runSQLScripts(db, []string{
 // 		"/home/taya/Desktop/ppo/src/internal/database/sql/delete.sql",
 // 		"/home/taya/Desktop/ppo/src/internal/database/sql/01-create.sql",
 // 		"/home/taya/Desktop/ppo/src/internal/database/sql/02-constraints.sql",
 // 		"/home/taya/Desktop/ppo/src/internal/database/sql/03-inserts.sql",
 // 		"/home/taya/Desktop/ppo/src/internal/database/sql/trigger_accept.sql",
-// 		"/home/taya/Desktop/ppo/src/internal/database/sql/trigger_order.sql",
 // 	})
 
 	_ = runSQLScripts(db, []string{
@@ -125,39 +262,89 @@ func main() {
 		"./internal/database/sql/02-constraints.sql",
 		"./internal/database/sql/03-inserts.sql",
 		"./internal/database/sql/trigger_accept.sql",
-		"./internal/database/sql/trigger_order.sql",
+		"./internal/database/sql/trigger_price.sql",
+		"./internal/database/sql/trigger_rating.sql",
+		"./internal/database/sql/trigger_stock.sql",
+		"./internal/database/sql/trigger_articule.sql",
 	})
 
 	gin.DefaultWriter = logFile
+	alr := alert_rep.New(db)
+	atr := attribute_rep.New(db)
 	ar := auth_rep.New(db)
 	ap := auth_prov.New(key, time.Duration(time.Duration(acstime)*time.Minute), time.Duration(time.Duration(reftime)*24*time.Hour))
 	bar := basket_rep.New(db)
 	brr := brand_rep.New(db)
+	cr := catalog_rep.New(db)
+	ctr := category_rep.New(db)
+	dr := delivery_rep.New(db)
 	fr := favourites_rep.New(db)
+	ir := idempotency_rep.New(db)
+	mr := media_rep.New(db)
+	msp := storage_prov.New(mediaDir, "/media")
 	or := order_rep.New(db)
+	pyr := payment_rep.New(db)
+	pyp := payment_prov.New(webhookSecret, webhookURL, time.Duration(paymentDelay)*time.Second)
+	prr := price_rep.New(db)
 	pr := product_rep.New(db)
+	pmr := promotion_rep.New(db)
+	pcr := purchase_rep.New(db)
+	rcr := recommendation_rep.New(db)
+	rsr := reservation_rep.New(db)
+	rtr := returns_rep.New(db)
 	rr := review_rep.New(db)
+	sr := stock_rep.New(db)
 	ur := user_rep.New(db)
 	wr := worker_rep.New(db)
 	bas := basket.New(bar)
+	als := alert.New(alr, notifier_prov.New(alertLog))
 	fs := favourites.New(fr)
+	is := idempotency.New(ir, time.Duration(idempotencyTTL)*time.Hour)
+	ms := media.New(mr, msp)
 	us := user.New(ur, bas, fs)
 	as := auth.New(ap, ar, us)
+	ats := attribute.New(atr)
 	brs := brand.New(brr)
-	oss := order.New(or)
+	cs := catalog.New(cr, msp, shop)
+	cts := category.New(ctr)
+	dls := delivery.New(dr)
+	pms := promotion.New(pmr)
+	oss := order.New(or, or, pms, bas)
+	pys := payment.New(pyr, oss, pyp)
+	prs := price.New(prr)
 	ps := product.New(pr)
+	pcs := purchase.New(pcr)
+	rcs := recommendation.New(rcr, thresholds)
+	rss := reservation.New(rsr, time.Duration(reservationTTL)*time.Minute)
+	rts := returns.New(rtr, oss, msp, time.Duration(returnWindow)*24*time.Hour)
 	rs := review.New(rr)
+	ss := stock.New(sr)
 	ws := worker.New(wr)
 	c := controller.Controller{
-		BasketService:     *bas,
-		UserService:       *us,
-		AuthServise:       *as,
-		BrandService:      *brs,
-		FavouritesService: *fs,
-		OrderService:      *oss,
-		ProductService:    *ps,
-		ReviewService:     *rs,
-		WorkerService:     *ws,
+		AlertService:          *als,
+		AttributeService:      *ats,
+		BasketService:         *bas,
+		UserService:           *us,
+		AuthServise:           *as,
+		BrandService:          *brs,
+		CatalogService:        *cs,
+		CategoryService:       *cts,
+		DeliveryService:       *dls,
+		FavouritesService:     *fs,
+		IdempotencyService:    *is,
+		MediaService:          *ms,
+		OrderService:          *oss,
+		PaymentService:        *pys,
+		PriceService:          *prs,
+		ProductService:        *ps,
+		PromotionService:      *pms,
+		PurchaseService:       *pcs,
+		RecommendationService: *rcs,
+		ReservationService:    *rss,
+		ReturnService:         *rts,
+		ReviewService:         *rs,
+		StockService:          *ss,
+		WorkerService:         *ws,
 	}
 
 	router := gin.New()
@@ -168,6 +355,7 @@ func main() {
 		fmt.Println("Health check called")
 		c.JSON(200, gin.H{"status": "ok", "service": "running"})
 	})
+	router.Static("/media", mediaDir)
 
 	api := router.Group("/api/v1")
 	{
@@ -187,6 +375,10 @@ func main() {
 				basket := me.Group("/basket")
 				{
 					basket.GET("", c.GetBasketByIdHandler)
+					basket.GET("/discounts", c.GetBasketDiscountsHandler)
+					basket.GET("/recommendations", c.GetBasketRecommendationsHandler)
+					basket.POST("/checkout", c.StartCheckoutHandler)
+					basket.DELETE("/checkout", c.CancelCheckoutHandler)
 					basketItems := basket.Group("/items")
 					{
 						basketItems.GET("", c.GetBasketItemsHandler)
@@ -213,19 +405,46 @@ func main() {
 					orders.PATCH("/:id", c.ChangeOrderStatusHandler)
 					orders.DELETE("/:id", c.DeleteOrderHandler)
 					orders.GET("/:id/items", c.GetOrderItemsHandler)
+					orders.GET("/:id/history", c.GetOrderHistoryHandler)
+					orders.POST("/:id/cancel", c.CancelOrderHandler)
+					orders.POST("/:id/reorder", c.RepeatOrderHandler)
+					orders.GET("/:id/payments", c.GetOrderPaymentsHandler)
+					orders.POST("/:id/payments", c.Idempotent, c.StartPaymentHandler)
+					orders.POST("/:id/returns", c.Idempotent, c.CreateReturnHandler)
+				}
+
+				returns := me.Group("/returns")
+				{
+					returns.GET("", c.GetMyReturnsHandler)
+					returns.GET("/:id", c.GetMyReturnHandler)
+					returns.POST("/:id/photos", c.UploadReturnPhotosHandler)
 				}
 
 				products := me.Group("/products")
 				{
-					products.POST("/:id_product/reviews", c.CreateReviewHandler)
+					products.POST("/:id_product/reviews", c.Idempotent, c.CreateReviewHandler)
 				}
+
+				me.GET("/allergens", c.GetAllergensHandler)
+				me.PUT("/allergens", c.SetAllergensHandler)
 			}
 		}
 
 		ords := api.Group("/orders")
 		{
 			ords.GET("", c.GetOrdersHandler)
-			ords.POST("", c.CreateOrderHandler)
+			ords.POST("", c.Idempotent, c.CreateOrderHandler)
+		}
+
+		deliveries := api.Group("/delivery")
+		{
+			deliveries.GET("", c.GetDeliveryMethodsHandler)
+			deliveries.GET("/:id/slots", c.GetDeliverySlotsHandler)
+		}
+
+		payments := api.Group("/payments")
+		{
+			payments.POST("/webhook", c.PaymentWebhookHandler)
 		}
 
 		brands := api.Group("/brands")
@@ -236,6 +455,29 @@ func main() {
 			brands.DELETE("/:id", c.DeleteBrandHandler)
 		}
 
+		categories := api.Group("/categories")
+		{
+			categories.GET("", c.GetCategoriesHandler)
+			categories.POST("", c.CreateCategoryHandler)
+			categories.GET("/:id", c.GetCategoryHandler)
+			categories.PUT("/:id", c.UpdateCategoryHandler)
+			categories.DELETE("/:id", c.DeleteCategoryHandler)
+		}
+
+		ingredients := api.Group("/ingredients")
+		{
+			ingredients.GET("", c.GetIngredientsHandler)
+			ingredients.POST("", c.CreateIngredientHandler)
+			ingredients.DELETE("/:id", c.DeleteIngredientHandler)
+		}
+
+		attributes := api.Group("/attributes")
+		{
+			attributes.GET("", c.GetAttributesHandler)
+			attributes.POST("", c.CreateAttributeHandler)
+			attributes.DELETE("/:id", c.DeleteAttributeHandler)
+		}
+
 		products := api.Group("/products")
 		{
 			products.GET("", c.GetProductsHandler)
@@ -244,6 +486,24 @@ func main() {
 			products.GET("/:id/reviews", c.GetReviewsForProductHandler)
 			products.GET("/:id/reviews/:id", c.GetReviewByIdHandler)
 			products.DELETE("/:id/reviews/:id", c.DeleteReviewHandler)
+			products.GET("/:id/attributes", c.GetProductAttributesHandler)
+			products.PUT("/:id/attributes", c.SetProductAttributesHandler)
+			products.GET("/:id/recommendations", c.GetProductRecommendationsHandler)
+			products.GET("/:id/price-history", c.GetPriceHistoryHandler)
+			products.GET("/:id/stock-movements", c.GetStockMovementsHandler)
+			products.GET("/:id/lots", c.GetProductLotsHandler)
+			products.PUT("/:id/reorder", c.SetReorderRuleHandler)
+			products.DELETE("/:id/reorder", c.DeleteReorderRuleHandler)
+			products.POST("/:id/prices", c.SchedulePriceHandler)
+			products.DELETE("/:id/prices/:id_price", c.CancelScheduledPriceHandler)
+			products.GET("/:id/variants", c.GetProductVariantsHandler)
+			products.POST("/:id/variants", c.CreateProductVariantHandler)
+			products.DELETE("/:id/variants/:id_variant", c.DeleteProductVariantHandler)
+			products.GET("/:id/images", c.GetProductImagesHandler)
+			products.POST("/:id/images", c.UploadProductImagesHandler)
+			products.PUT("/:id/images", c.ReorderProductImagesHandler)
+			products.PUT("/:id/images/:id_image/primary", c.SetPrimaryProductImageHandler)
+			products.DELETE("/:id/images/:id_image", c.DeleteProductImageHandler)
 		}
 
 		workers := api.Group("/workers")
@@ -260,6 +520,88 @@ func main() {
 					orders.GET("", c.GetWorkerOrders)
 					orders.POST("", c.AcceptOrderHandler)
 				}
+
+				stock := me.Group("/stock")
+				{
+					stock.POST("/receipts", c.PostStockReceiptHandler)
+					stock.POST("/adjustments", c.PostStockAdjustmentHandler)
+				}
+
+				purchaseOrders := me.Group("/purchase-orders")
+				{
+					purchaseOrders.GET("", c.GetPurchaseOrdersHandler)
+					purchaseOrders.GET("/:id", c.GetPurchaseOrderHandler)
+					purchaseOrders.POST("/:id/receipts", c.ReceivePurchaseOrderHandler)
+				}
+
+				returns := me.Group("/returns")
+				{
+					returns.GET("", c.GetReturnsHandler)
+					returns.GET("/:id", c.GetReturnHandler)
+					returns.POST("/:id/receipts", c.ReceiveReturnHandler)
+				}
+
+				me.GET("/alerts", c.GetStockAlertsHandler)
+			}
+		}
+
+		admin := api.Group("/admin")
+		{
+			catalog := admin.Group("/catalog")
+			{
+				catalog.POST("/import", c.ImportCatalogHandler)
+				catalog.GET("/export", c.ExportCatalogHandler)
+			}
+
+			promotions := admin.Group("/promotions")
+			{
+				promotions.GET("", c.GetPromotionsHandler)
+				promotions.POST("", c.CreatePromotionHandler)
+				promotions.GET("/:id", c.GetPromotionHandler)
+				promotions.DELETE("/:id", c.DeactivatePromotionHandler)
+			}
+
+			deliveries := admin.Group("/delivery")
+			{
+				deliveries.GET("", c.GetAllDeliveryMethodsHandler)
+				deliveries.POST("", c.CreateDeliveryMethodHandler)
+				deliveries.DELETE("/:id", c.DeactivateDeliveryMethodHandler)
+				deliveries.PUT("/:id/rates", c.SetDeliveryRatesHandler)
+				deliveries.POST("/:id/slots", c.CreateDeliverySlotHandler)
+				deliveries.DELETE("/:id/slots/:id_slot", c.DeleteDeliverySlotHandler)
+			}
+
+			suppliers := admin.Group("/suppliers")
+			{
+				suppliers.GET("", c.GetSuppliersHandler)
+				suppliers.POST("", c.CreateSupplierHandler)
+				suppliers.DELETE("/:id", c.DeleteSupplierHandler)
+			}
+
+			purchaseOrders := admin.Group("/purchase-orders")
+			{
+				purchaseOrders.GET("", c.GetPurchaseOrdersHandler)
+				purchaseOrders.POST("", c.CreatePurchaseOrderHandler)
+				purchaseOrders.GET("/:id", c.GetPurchaseOrderHandler)
+				purchaseOrders.POST("/:id/send", c.SendPurchaseOrderHandler)
+			}
+
+			admin.GET("/reorder-report", c.GetReorderReportHandler)
+			admin.GET("/lots/expiring", c.GetExpiringLotsHandler)
+
+			adminOrders := admin.Group("/orders")
+			{
+				adminOrders.GET("", c.SearchOrdersHandler)
+				adminOrders.GET("/export", c.ExportOrdersHandler)
+				adminOrders.POST("/:id/cancel", c.AdminCancelOrderHandler)
+			}
+
+			returns := admin.Group("/returns")
+			{
+				returns.GET("", c.GetReturnsHandler)
+				returns.GET("/:id", c.GetReturnHandler)
+				returns.POST("/:id/approve", c.ApproveReturnHandler)
+				returns.POST("/:id/reject", c.RejectReturnHandler)
 			}
 		}
 	}
@@ -269,6 +611,52 @@ func main() {
 		Handler: router,
 	}
 
+	jobs, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go runJob(jobs, "price activation", time.Duration(priceInterval)*time.Second, func(ctx context.Context) error {
+		n, err := prs.ActivateDue(ctx)
+		if n > 0 {
+			log.Printf("Activated scheduled prices of %d products", n)
+		}
+		return err
+	})
+	go runJob(jobs, "reservation sweep", time.Duration(sweepInterval)*time.Second, func(ctx context.Context) error {
+		n, err := rss.Sweep(ctx)
+		if n > 0 {
+			log.Printf("Released %d expired stock reservations", n)
+		}
+		return err
+	})
+	go runJob(jobs, "idempotency sweep", time.Duration(idempotencySweep)*time.Second, func(ctx context.Context) error {
+		n, err := is.Sweep(ctx)
+		if n > 0 {
+			log.Printf("Deleted %d expired idempotency keys", n)
+		}
+		return err
+	})
+	go runJob(jobs, "recommendations rebuild", time.Duration(recInterval)*time.Second, func(ctx context.Context) error {
+		n, err := rcs.Rebuild(ctx)
+		if err == nil {
+			log.Printf("Rebuilt recommendations from %d product pairs", n)
+		}
+		return err
+	})
+
+	go runJob(jobs, "stock alerts", time.Duration(alertInterval)*time.Second, func(ctx context.Context) error {
+		n, err := als.Check(ctx)
+		if n > 0 {
+			log.Printf("Raised %d low stock alerts", n)
+		}
+		return err
+	})
+	go runJob(jobs, "refunds", time.Duration(refundInterval)*time.Second, func(ctx context.Context) error {
+		n, err := pys.ProcessRefunds(ctx)
+		if n > 0 {
+			log.Printf("Processed %d refunds", n)
+		}
+		return err
+	})
+
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("listen: %s\n", err)
@@ -279,6 +667,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
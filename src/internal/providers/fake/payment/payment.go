@@ -0,0 +1,191 @@
+package payment_prov
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+// Payment methods that choose the outcome of a fake payment. Any other
+// method is paid at once.
+const (
+	MethodDeclined = "fake-declined"
+	MethodDelayed  = "fake-delayed"
+)
+
+const deliveryAttempts = 5
+
+// Provider is a payment gateway for development and tests that keeps its
+// payments in memory. Like a real gateway it reports every payment in a
+// signed webhook call, sent again with growing pauses until it is
+// accepted, so payments are decided even when the call comes before the
+// payment is stored. Payments made with MethodDelayed are reported after
+// the delay, payments made with MethodDeclined fail.
+type Provider struct {
+	secret  []byte
+	webhook string
+	delay   time.Duration
+	client  *http.Client
+
+	mu      sync.Mutex
+	intents map[string]*intent
+}
+
+type intent struct {
+	amount   float64
+	declined bool
+	captured bool
+	refunded float64
+	refunds  map[string]structs.RefundResult
+}
+
+func New(secret []byte, webhook string, delay time.Duration) *Provider {
+	return &Provider{
+		secret:  secret,
+		webhook: webhook,
+		delay:   delay,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		intents: make(map[string]*intent),
+	}
+}
+
+func (p *Provider) Name() string {
+	return "fake"
+}
+
+func (p *Provider) CreateIntent(ctx context.Context, id_payment uuid.UUID, amount float64, method string) (structs.PaymentIntent, error) {
+	if amount <= 0 {
+		return structs.PaymentIntent{}, errors.New("fake payment: amount must be positive")
+	}
+
+	id := "fake_pi_" + uuid.NewString()
+	it := &intent{amount: amount, declined: method == MethodDeclined, refunds: make(map[string]structs.RefundResult)}
+	p.mu.Lock()
+	p.intents[id] = it
+	p.mu.Unlock()
+
+	ev := structs.PaymentEvent{Id: "fake_ev_" + uuid.NewString(), Type: structs.PaymentEventAuthorized, IntentId: id}
+	if it.declined {
+		ev.Type = structs.PaymentEventFailed
+		ev.Error = "card declined"
+	}
+	var wait time.Duration
+	if method == MethodDelayed {
+		wait = p.delay
+	}
+	go p.deliver(ev, wait)
+
+	return structs.PaymentIntent{Id: id, ClientSecret: id + "_secret"}, nil
+}
+
+func (p *Provider) Capture(ctx context.Context, id string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	it, ok := p.intents[id]
+	if !ok {
+		return fmt.Errorf("fake payment: unknown intent %s", id)
+	}
+	if it.declined {
+		return fmt.Errorf("fake payment: intent %s was declined", id)
+	}
+	it.captured = true
+	return nil
+}
+
+// Refund gives back a part of a captured payment. A refund repeated with
+// the same key gets the first answer.
+func (p *Provider) Refund(ctx context.Context, id string, amount float64, key string) (structs.RefundResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	it, ok := p.intents[id]
+	if !ok {
+		return structs.RefundResult{}, fmt.Errorf("fake payment: unknown intent %s", id)
+	}
+	if res, ok := it.refunds[key]; ok {
+		return res, nil
+	}
+
+	res := structs.RefundResult{Ref: "fake_re_" + uuid.NewString(), Status: structs.RefundSucceeded}
+	switch {
+	case !it.captured:
+		res = structs.RefundResult{Status: structs.RefundFailed, Error: "payment was not captured"}
+	case it.refunded+amount > it.amount+0.005:
+		res = structs.RefundResult{Status: structs.RefundFailed, Error: "refund exceeds payment"}
+	default:
+		it.refunded += amount
+	}
+	it.refunds[key] = res
+	return res, nil
+}
+
+func (p *Provider) VerifyWebhook(payload []byte, signature string) (structs.PaymentEvent, error) {
+	sig, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, p.sign(payload)) {
+		return structs.PaymentEvent{}, structs.ErrInvalidSignature
+	}
+
+	var ev structs.PaymentEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return structs.PaymentEvent{}, fmt.Errorf("%w: %v", structs.ErrInvalidPaymentEvent, err)
+	}
+	return ev, nil
+}
+
+func (p *Provider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func (p *Provider) deliver(ev structs.PaymentEvent, wait time.Duration) {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		log.Printf("[ERROR] Cant encode fake payment event: %v", err)
+		return
+	}
+
+	pause := time.Second
+	for attempt := 1; attempt <= deliveryAttempts; attempt++ {
+		time.Sleep(wait)
+		err := p.send(body)
+		if err == nil {
+			return
+		}
+		log.Printf("[ERROR] Cant deliver fake payment event %s, attempt %d: %v", ev.Id, attempt, err)
+		wait = pause
+		pause *= 2
+	}
+}
+
+func (p *Provider) send(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, p.webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(structs.PaymentSignatureHeader, hex.EncodeToString(p.sign(body)))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package storage_prov
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Provider keeps files on the local filesystem under root. The files are
+// expected to be served by the HTTP server under baseURL.
+type Provider struct {
+	root    string
+	baseURL string
+}
+
+func New(root string, baseURL string) *Provider {
+	return &Provider{root: root, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Save writes the file to a temporary name first and renames it afterwards,
+// so a reader never sees a partially written file.
+func (p *Provider) Save(ctx context.Context, key string, r io.Reader) error {
+	name, err := p.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (p *Provider) Delete(ctx context.Context, key string) error {
+	name, err := p.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (p *Provider) URL(key string) string {
+	return p.baseURL + "/" + key
+}
+
+func (p *Provider) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(p.root, filepath.FromSlash(clean)), nil
+}
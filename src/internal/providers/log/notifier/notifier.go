@@ -0,0 +1,24 @@
+package notifier_prov
+
+import (
+	"context"
+	"log"
+
+	"github.com/taucuya/ppo/internal/core/structs"
+)
+
+// Provider writes low stock alerts to a log. The log may go to a file that
+// purchasing watches, other channels only need to implement Notify.
+type Provider struct {
+	logger *log.Logger
+}
+
+func New(logger *log.Logger) *Provider {
+	return &Provider{logger: logger}
+}
+
+func (p *Provider) Notify(ctx context.Context, a structs.StockAlert) error {
+	p.logger.Printf("[ALERT] Low stock: %s (%s) %d left, reorder point %d, reorder %d",
+		a.Name, a.Articule, a.Amount, a.ReorderPoint, a.ReorderQuantity)
+	return nil
+}
@@ -8,4 +8,19 @@ mockgen -source=reps/favourites/favourites_interface.go -destination=mocks/favou
 mockgen -source=reps/order/order_interface.go -destination=mocks/order_mock.go -package=mocks
 mockgen -source=reps/product/product_interface.go -destination=mocks/product_mock.go -package=mocks
 mockgen -source=reps/review/review_interface.go -destination=mocks/review_mock.go -package=mocks
-mockgen -source=reps/worker/worker_interface.go -destination=mocks/worker_mock.go -package=mocks
\ No newline at end of file
+mockgen -source=reps/worker/worker_interface.go -destination=mocks/worker_mock.go -package=mocks
+mockgen -source=reps/catalog/catalog_interface.go -destination=mocks/catalog_mock.go -package=mocks
+mockgen -source=reps/media/media_interface.go -destination=mocks/media_mock.go -package=mocks
+mockgen -source=reps/category/category_interface.go -destination=mocks/category_mock.go -package=mocks
+mockgen -source=reps/attribute/attribute_interface.go -destination=mocks/attribute_mock.go -package=mocks
+mockgen -source=reps/price/price_interface.go -destination=mocks/price_mock.go -package=mocks
+mockgen -source=reps/promotion/promotion_interface.go -destination=mocks/promotion_mock.go -package=mocks
+mockgen -source=reps/recommendation/recommendation_interface.go -destination=mocks/recommendation_mock.go -package=mocks
+mockgen -source=reps/stock/stock_interface.go -destination=mocks/stock_mock.go -package=mocks
+mockgen -source=reps/reservation/reservation_interface.go -destination=mocks/reservation_mock.go -package=mocks
+mockgen -source=reps/alert/alert_interface.go -destination=mocks/alert_mock.go -package=mocks
+mockgen -source=reps/purchase/purchase_interface.go -destination=mocks/purchase_mock.go -package=mocks
+mockgen -source=reps/idempotency/idempotency_interface.go -destination=mocks/idempotency_mock.go -package=mocks
+mockgen -source=reps/returns/returns_interface.go -destination=mocks/returns_mock.go -package=mocks
+mockgen -source=reps/payment/payment_interface.go -destination=mocks/payment_mock.go -package=mocks
+mockgen -source=reps/delivery/delivery_interface.go -destination=mocks/delivery_mock.go -package=mocks
\ No newline at end of file
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/alert/alert_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockAlertRepositoryInterface is a mock of AlertRepositoryInterface interface.
+type MockAlertRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertRepositoryInterfaceMockRecorder
+}
+
+// MockAlertRepositoryInterfaceMockRecorder is the mock recorder for MockAlertRepositoryInterface.
+type MockAlertRepositoryInterfaceMockRecorder struct {
+	mock *MockAlertRepositoryInterface
+}
+
+// NewMockAlertRepositoryInterface creates a new mock instance.
+func NewMockAlertRepositoryInterface(ctrl *gomock.Controller) *MockAlertRepositoryInterface {
+	mock := &MockAlertRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockAlertRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertRepositoryInterface) EXPECT() *MockAlertRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteRule mocks base method.
+func (m *MockAlertRepositoryInterface) DeleteRule(ctx context.Context, id_product uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, id_product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockAlertRepositoryInterfaceMockRecorder) DeleteRule(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockAlertRepositoryInterface)(nil).DeleteRule), ctx, id_product)
+}
+
+// GetOpen mocks base method.
+func (m *MockAlertRepositoryInterface) GetOpen(ctx context.Context) ([]structs.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpen", ctx)
+	ret0, _ := ret[0].([]structs.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpen indicates an expected call of GetOpen.
+func (mr *MockAlertRepositoryInterfaceMockRecorder) GetOpen(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpen", reflect.TypeOf((*MockAlertRepositoryInterface)(nil).GetOpen), ctx)
+}
+
+// GetReorderItems mocks base method.
+func (m *MockAlertRepositoryInterface) GetReorderItems(ctx context.Context) ([]structs.ReorderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReorderItems", ctx)
+	ret0, _ := ret[0].([]structs.ReorderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReorderItems indicates an expected call of GetReorderItems.
+func (mr *MockAlertRepositoryInterfaceMockRecorder) GetReorderItems(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReorderItems", reflect.TypeOf((*MockAlertRepositoryInterface)(nil).GetReorderItems), ctx)
+}
+
+// RaiseAlerts mocks base method.
+func (m *MockAlertRepositoryInterface) RaiseAlerts(ctx context.Context) ([]structs.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RaiseAlerts", ctx)
+	ret0, _ := ret[0].([]structs.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RaiseAlerts indicates an expected call of RaiseAlerts.
+func (mr *MockAlertRepositoryInterfaceMockRecorder) RaiseAlerts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RaiseAlerts", reflect.TypeOf((*MockAlertRepositoryInterface)(nil).RaiseAlerts), ctx)
+}
+
+// SetRule mocks base method.
+func (m *MockAlertRepositoryInterface) SetRule(ctx context.Context, r structs.ReorderRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRule", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRule indicates an expected call of SetRule.
+func (mr *MockAlertRepositoryInterfaceMockRecorder) SetRule(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRule", reflect.TypeOf((*MockAlertRepositoryInterface)(nil).SetRule), ctx, r)
+}
@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/attribute/attribute_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockAttributeRepositoryInterface is a mock of AttributeRepositoryInterface interface.
+type MockAttributeRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttributeRepositoryInterfaceMockRecorder
+}
+
+// MockAttributeRepositoryInterfaceMockRecorder is the mock recorder for MockAttributeRepositoryInterface.
+type MockAttributeRepositoryInterfaceMockRecorder struct {
+	mock *MockAttributeRepositoryInterface
+}
+
+// NewMockAttributeRepositoryInterface creates a new mock instance.
+func NewMockAttributeRepositoryInterface(ctrl *gomock.Controller) *MockAttributeRepositoryInterface {
+	mock := &MockAttributeRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockAttributeRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttributeRepositoryInterface) EXPECT() *MockAttributeRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateAttribute mocks base method.
+func (m *MockAttributeRepositoryInterface) CreateAttribute(ctx context.Context, a structs.Attribute) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttribute", ctx, a)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttribute indicates an expected call of CreateAttribute.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) CreateAttribute(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttribute", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).CreateAttribute), ctx, a)
+}
+
+// CreateIngredient mocks base method.
+func (m *MockAttributeRepositoryInterface) CreateIngredient(ctx context.Context, i structs.Ingredient) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredient", ctx, i)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredient indicates an expected call of CreateIngredient.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) CreateIngredient(ctx, i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredient", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).CreateIngredient), ctx, i)
+}
+
+// DeleteAttribute mocks base method.
+func (m *MockAttributeRepositoryInterface) DeleteAttribute(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttribute", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttribute indicates an expected call of DeleteAttribute.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) DeleteAttribute(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttribute", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).DeleteAttribute), ctx, id)
+}
+
+// DeleteIngredient mocks base method.
+func (m *MockAttributeRepositoryInterface) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIngredient", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIngredient indicates an expected call of DeleteIngredient.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) DeleteIngredient(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredient", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).DeleteIngredient), ctx, id)
+}
+
+// FindAllergens mocks base method.
+func (m *MockAttributeRepositoryInterface) FindAllergens(ctx context.Context, id_user uuid.UUID, id_products []uuid.UUID) (map[uuid.UUID][]structs.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllergens", ctx, id_user, id_products)
+	ret0, _ := ret[0].(map[uuid.UUID][]structs.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllergens indicates an expected call of FindAllergens.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) FindAllergens(ctx, id_user, id_products interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllergens", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).FindAllergens), ctx, id_user, id_products)
+}
+
+// GetAllergens mocks base method.
+func (m *MockAttributeRepositoryInterface) GetAllergens(ctx context.Context, id_user uuid.UUID) ([]structs.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllergens", ctx, id_user)
+	ret0, _ := ret[0].([]structs.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllergens indicates an expected call of GetAllergens.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) GetAllergens(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllergens", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).GetAllergens), ctx, id_user)
+}
+
+// GetAttributes mocks base method.
+func (m *MockAttributeRepositoryInterface) GetAttributes(ctx context.Context, kind string) ([]structs.Attribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttributes", ctx, kind)
+	ret0, _ := ret[0].([]structs.Attribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttributes indicates an expected call of GetAttributes.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) GetAttributes(ctx, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributes", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).GetAttributes), ctx, kind)
+}
+
+// GetIngredients mocks base method.
+func (m *MockAttributeRepositoryInterface) GetIngredients(ctx context.Context) ([]structs.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredients", ctx)
+	ret0, _ := ret[0].([]structs.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredients indicates an expected call of GetIngredients.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) GetIngredients(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredients", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).GetIngredients), ctx)
+}
+
+// GetProductAttributes mocks base method.
+func (m *MockAttributeRepositoryInterface) GetProductAttributes(ctx context.Context, id_product uuid.UUID) (structs.ProductAttributes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductAttributes", ctx, id_product)
+	ret0, _ := ret[0].(structs.ProductAttributes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductAttributes indicates an expected call of GetProductAttributes.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) GetProductAttributes(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductAttributes", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).GetProductAttributes), ctx, id_product)
+}
+
+// SetAllergens mocks base method.
+func (m *MockAttributeRepositoryInterface) SetAllergens(ctx context.Context, id_user uuid.UUID, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAllergens", ctx, id_user, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAllergens indicates an expected call of SetAllergens.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) SetAllergens(ctx, id_user, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllergens", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).SetAllergens), ctx, id_user, ids)
+}
+
+// SetProductAttributes mocks base method.
+func (m *MockAttributeRepositoryInterface) SetProductAttributes(ctx context.Context, a structs.ProductAttributes) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductAttributes", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProductAttributes indicates an expected call of SetProductAttributes.
+func (mr *MockAttributeRepositoryInterfaceMockRecorder) SetProductAttributes(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductAttributes", reflect.TypeOf((*MockAttributeRepositoryInterface)(nil).SetProductAttributes), ctx, a)
+}
@@ -65,17 +65,17 @@ func (mr *MockBasketRepositoryInterfaceMockRecorder) Create(ctx, b interface{})
 }
 
 // DeleteItem mocks base method.
-func (m *MockBasketRepositoryInterface) DeleteItem(ctx context.Context, id, product_id uuid.UUID) error {
+func (m *MockBasketRepositoryInterface) DeleteItem(ctx context.Context, id, product_id, variant_id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteItem", ctx, id, product_id)
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, id, product_id, variant_id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteItem indicates an expected call of DeleteItem.
-func (mr *MockBasketRepositoryInterfaceMockRecorder) DeleteItem(ctx, id, product_id interface{}) *gomock.Call {
+func (mr *MockBasketRepositoryInterfaceMockRecorder) DeleteItem(ctx, id, product_id, variant_id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockBasketRepositoryInterface)(nil).DeleteItem), ctx, id, product_id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockBasketRepositoryInterface)(nil).DeleteItem), ctx, id, product_id, variant_id)
 }
 
 // GetBIdByUId mocks base method.
@@ -124,15 +124,15 @@ func (mr *MockBasketRepositoryInterfaceMockRecorder) GetItems(ctx, id_user inter
 }
 
 // UpdateItemAmount mocks base method.
-func (m *MockBasketRepositoryInterface) UpdateItemAmount(ctx context.Context, basket_id, product_id uuid.UUID, amount int) error {
+func (m *MockBasketRepositoryInterface) UpdateItemAmount(ctx context.Context, basket_id, product_id, variant_id uuid.UUID, amount int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItemAmount", ctx, basket_id, product_id, amount)
+	ret := m.ctrl.Call(m, "UpdateItemAmount", ctx, basket_id, product_id, variant_id, amount)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateItemAmount indicates an expected call of UpdateItemAmount.
-func (mr *MockBasketRepositoryInterfaceMockRecorder) UpdateItemAmount(ctx, basket_id, product_id, amount interface{}) *gomock.Call {
+func (mr *MockBasketRepositoryInterfaceMockRecorder) UpdateItemAmount(ctx, basket_id, product_id, variant_id, amount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemAmount", reflect.TypeOf((*MockBasketRepositoryInterface)(nil).UpdateItemAmount), ctx, basket_id, product_id, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemAmount", reflect.TypeOf((*MockBasketRepositoryInterface)(nil).UpdateItemAmount), ctx, basket_id, product_id, variant_id, amount)
 }
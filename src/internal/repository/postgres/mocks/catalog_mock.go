@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/catalog/catalog_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockCatalogRepositoryInterface is a mock of CatalogRepositoryInterface interface.
+type MockCatalogRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogRepositoryInterfaceMockRecorder
+}
+
+// MockCatalogRepositoryInterfaceMockRecorder is the mock recorder for MockCatalogRepositoryInterface.
+type MockCatalogRepositoryInterfaceMockRecorder struct {
+	mock *MockCatalogRepositoryInterface
+}
+
+// NewMockCatalogRepositoryInterface creates a new mock instance.
+func NewMockCatalogRepositoryInterface(ctrl *gomock.Controller) *MockCatalogRepositoryInterface {
+	mock := &MockCatalogRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockCatalogRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogRepositoryInterface) EXPECT() *MockCatalogRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ExistingArticules mocks base method.
+func (m *MockCatalogRepositoryInterface) ExistingArticules(ctx context.Context, arts []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingArticules", ctx, arts)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingArticules indicates an expected call of ExistingArticules.
+func (mr *MockCatalogRepositoryInterfaceMockRecorder) ExistingArticules(ctx, arts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingArticules", reflect.TypeOf((*MockCatalogRepositoryInterface)(nil).ExistingArticules), ctx, arts)
+}
+
+// ExistingBrands mocks base method.
+func (m *MockCatalogRepositoryInterface) ExistingBrands(ctx context.Context, names []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingBrands", ctx, names)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingBrands indicates an expected call of ExistingBrands.
+func (mr *MockCatalogRepositoryInterfaceMockRecorder) ExistingBrands(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingBrands", reflect.TypeOf((*MockCatalogRepositoryInterface)(nil).ExistingBrands), ctx, names)
+}
+
+// ExportCategories mocks base method.
+func (m *MockCatalogRepositoryInterface) ExportCategories(ctx context.Context) ([]structs.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCategories", ctx)
+	ret0, _ := ret[0].([]structs.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCategories indicates an expected call of ExportCategories.
+func (mr *MockCatalogRepositoryInterfaceMockRecorder) ExportCategories(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCategories", reflect.TypeOf((*MockCatalogRepositoryInterface)(nil).ExportCategories), ctx)
+}
+
+// ExportProducts mocks base method.
+func (m *MockCatalogRepositoryInterface) ExportProducts(ctx context.Context, fn func(structs.CatalogExportItem) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProducts", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockCatalogRepositoryInterfaceMockRecorder) ExportProducts(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockCatalogRepositoryInterface)(nil).ExportProducts), ctx, fn)
+}
+
+// FindCategories mocks base method.
+func (m *MockCatalogRepositoryInterface) FindCategories(ctx context.Context, keys []string) ([]structs.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCategories", ctx, keys)
+	ret0, _ := ret[0].([]structs.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCategories indicates an expected call of FindCategories.
+func (mr *MockCatalogRepositoryInterfaceMockRecorder) FindCategories(ctx, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCategories", reflect.TypeOf((*MockCatalogRepositoryInterface)(nil).FindCategories), ctx, keys)
+}
+
+// Import mocks base method.
+func (m *MockCatalogRepositoryInterface) Import(ctx context.Context, rows []structs.CatalogImportRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockCatalogRepositoryInterfaceMockRecorder) Import(ctx, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockCatalogRepositoryInterface)(nil).Import), ctx, rows)
+}
+
+// VariantArticules mocks base method.
+func (m *MockCatalogRepositoryInterface) VariantArticules(ctx context.Context, arts []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VariantArticules", ctx, arts)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VariantArticules indicates an expected call of VariantArticules.
+func (mr *MockCatalogRepositoryInterfaceMockRecorder) VariantArticules(ctx, arts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariantArticules", reflect.TypeOf((*MockCatalogRepositoryInterface)(nil).VariantArticules), ctx, arts)
+}
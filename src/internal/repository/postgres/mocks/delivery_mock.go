@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/delivery/delivery_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockDeliveryRepositoryInterface is a mock of DeliveryRepositoryInterface interface.
+type MockDeliveryRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryRepositoryInterfaceMockRecorder
+}
+
+// MockDeliveryRepositoryInterfaceMockRecorder is the mock recorder for MockDeliveryRepositoryInterface.
+type MockDeliveryRepositoryInterfaceMockRecorder struct {
+	mock *MockDeliveryRepositoryInterface
+}
+
+// NewMockDeliveryRepositoryInterface creates a new mock instance.
+func NewMockDeliveryRepositoryInterface(ctrl *gomock.Controller) *MockDeliveryRepositoryInterface {
+	mock := &MockDeliveryRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockDeliveryRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryRepositoryInterface) EXPECT() *MockDeliveryRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m_2 *MockDeliveryRepositoryInterface) Create(ctx context.Context, m structs.DeliveryMethod) (uuid.UUID, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDeliveryRepositoryInterfaceMockRecorder) Create(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeliveryRepositoryInterface)(nil).Create), ctx, m)
+}
+
+// CreateSlot mocks base method.
+func (m *MockDeliveryRepositoryInterface) CreateSlot(ctx context.Context, slot structs.DeliverySlot) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSlot", ctx, slot)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSlot indicates an expected call of CreateSlot.
+func (mr *MockDeliveryRepositoryInterfaceMockRecorder) CreateSlot(ctx, slot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSlot", reflect.TypeOf((*MockDeliveryRepositoryInterface)(nil).CreateSlot), ctx, slot)
+}
+
+// Deactivate mocks base method.
+func (m *MockDeliveryRepositoryInterface) Deactivate(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockDeliveryRepositoryInterfaceMockRecorder) Deactivate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockDeliveryRepositoryInterface)(nil).Deactivate), ctx, id)
+}
+
+// DeleteSlot mocks base method.
+func (m *MockDeliveryRepositoryInterface) DeleteSlot(ctx context.Context, id_delivery, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSlot", ctx, id_delivery, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSlot indicates an expected call of DeleteSlot.
+func (mr *MockDeliveryRepositoryInterfaceMockRecorder) DeleteSlot(ctx, id_delivery, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSlot", reflect.TypeOf((*MockDeliveryRepositoryInterface)(nil).DeleteSlot), ctx, id_delivery, id)
+}
+
+// GetActive mocks base method.
+func (m *MockDeliveryRepositoryInterface) GetActive(ctx context.Context) ([]structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx)
+	ret0, _ := ret[0].([]structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockDeliveryRepositoryInterfaceMockRecorder) GetActive(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockDeliveryRepositoryInterface)(nil).GetActive), ctx)
+}
+
+// GetAll mocks base method.
+func (m *MockDeliveryRepositoryInterface) GetAll(ctx context.Context) ([]structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDeliveryRepositoryInterfaceMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDeliveryRepositoryInterface)(nil).GetAll), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockDeliveryRepositoryInterface) GetById(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockDeliveryRepositoryInterfaceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockDeliveryRepositoryInterface)(nil).GetById), ctx, id)
+}
+
+// GetSlots mocks base method.
+func (m *MockDeliveryRepositoryInterface) GetSlots(ctx context.Context, id_delivery uuid.UUID, from, to time.Time) ([]structs.DeliverySlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlots", ctx, id_delivery, from, to)
+	ret0, _ := ret[0].([]structs.DeliverySlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlots indicates an expected call of GetSlots.
+func (mr *MockDeliveryRepositoryInterfaceMockRecorder) GetSlots(ctx, id_delivery, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlots", reflect.TypeOf((*MockDeliveryRepositoryInterface)(nil).GetSlots), ctx, id_delivery, from, to)
+}
+
+// SetRates mocks base method.
+func (m *MockDeliveryRepositoryInterface) SetRates(ctx context.Context, id uuid.UUID, rates []structs.DeliveryRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRates", ctx, id, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRates indicates an expected call of SetRates.
+func (mr *MockDeliveryRepositoryInterfaceMockRecorder) SetRates(ctx, id, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRates", reflect.TypeOf((*MockDeliveryRepositoryInterface)(nil).SetRates), ctx, id, rates)
+}
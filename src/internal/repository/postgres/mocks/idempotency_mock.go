@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/idempotency/idempotency_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockIdempotencyRepositoryInterface is a mock of IdempotencyRepositoryInterface interface.
+type MockIdempotencyRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryInterfaceMockRecorder
+}
+
+// MockIdempotencyRepositoryInterfaceMockRecorder is the mock recorder for MockIdempotencyRepositoryInterface.
+type MockIdempotencyRepositoryInterfaceMockRecorder struct {
+	mock *MockIdempotencyRepositoryInterface
+}
+
+// NewMockIdempotencyRepositoryInterface creates a new mock instance.
+func NewMockIdempotencyRepositoryInterface(ctrl *gomock.Controller) *MockIdempotencyRepositoryInterface {
+	mock := &MockIdempotencyRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepositoryInterface) EXPECT() *MockIdempotencyRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockIdempotencyRepositoryInterface) Claim(ctx context.Context, rec structs.IdempotencyRecord, ttl time.Duration) (structs.IdempotencyRecord, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, rec, ttl)
+	ret0, _ := ret[0].(structs.IdempotencyRecord)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockIdempotencyRepositoryInterfaceMockRecorder) Claim(ctx, rec, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockIdempotencyRepositoryInterface)(nil).Claim), ctx, rec, ttl)
+}
+
+// Complete mocks base method.
+func (m *MockIdempotencyRepositoryInterface) Complete(ctx context.Context, rec structs.IdempotencyRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, rec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyRepositoryInterfaceMockRecorder) Complete(ctx, rec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotencyRepositoryInterface)(nil).Complete), ctx, rec)
+}
+
+// Delete mocks base method.
+func (m *MockIdempotencyRepositoryInterface) Delete(ctx context.Context, id_user uuid.UUID, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id_user, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdempotencyRepositoryInterfaceMockRecorder) Delete(ctx, id_user, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdempotencyRepositoryInterface)(nil).Delete), ctx, id_user, key)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockIdempotencyRepositoryInterface) DeleteExpired(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockIdempotencyRepositoryInterfaceMockRecorder) DeleteExpired(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockIdempotencyRepositoryInterface)(nil).DeleteExpired), ctx)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/media/media_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockMediaRepositoryInterface is a mock of MediaRepositoryInterface interface.
+type MockMediaRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaRepositoryInterfaceMockRecorder
+}
+
+// MockMediaRepositoryInterfaceMockRecorder is the mock recorder for MockMediaRepositoryInterface.
+type MockMediaRepositoryInterfaceMockRecorder struct {
+	mock *MockMediaRepositoryInterface
+}
+
+// NewMockMediaRepositoryInterface creates a new mock instance.
+func NewMockMediaRepositoryInterface(ctrl *gomock.Controller) *MockMediaRepositoryInterface {
+	mock := &MockMediaRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockMediaRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaRepositoryInterface) EXPECT() *MockMediaRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMediaRepositoryInterface) Create(ctx context.Context, imgs []structs.ProductImage) ([]structs.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, imgs)
+	ret0, _ := ret[0].([]structs.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMediaRepositoryInterfaceMockRecorder) Create(ctx, imgs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMediaRepositoryInterface)(nil).Create), ctx, imgs)
+}
+
+// Delete mocks base method.
+func (m *MockMediaRepositoryInterface) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMediaRepositoryInterfaceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMediaRepositoryInterface)(nil).Delete), ctx, id)
+}
+
+// GetById mocks base method.
+func (m *MockMediaRepositoryInterface) GetById(ctx context.Context, id uuid.UUID) (structs.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(structs.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockMediaRepositoryInterfaceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockMediaRepositoryInterface)(nil).GetById), ctx, id)
+}
+
+// GetByProduct mocks base method.
+func (m *MockMediaRepositoryInterface) GetByProduct(ctx context.Context, idProduct uuid.UUID) ([]structs.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProduct", ctx, idProduct)
+	ret0, _ := ret[0].([]structs.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProduct indicates an expected call of GetByProduct.
+func (mr *MockMediaRepositoryInterfaceMockRecorder) GetByProduct(ctx, idProduct interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProduct", reflect.TypeOf((*MockMediaRepositoryInterface)(nil).GetByProduct), ctx, idProduct)
+}
+
+// Reorder mocks base method.
+func (m *MockMediaRepositoryInterface) Reorder(ctx context.Context, idProduct uuid.UUID, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, idProduct, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockMediaRepositoryInterfaceMockRecorder) Reorder(ctx, idProduct, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockMediaRepositoryInterface)(nil).Reorder), ctx, idProduct, ids)
+}
+
+// SetPrimary mocks base method.
+func (m *MockMediaRepositoryInterface) SetPrimary(ctx context.Context, idProduct, id uuid.UUID, picLink string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrimary", ctx, idProduct, id, picLink)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrimary indicates an expected call of SetPrimary.
+func (mr *MockMediaRepositoryInterfaceMockRecorder) SetPrimary(ctx, idProduct, id, picLink interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimary", reflect.TypeOf((*MockMediaRepositoryInterface)(nil).SetPrimary), ctx, idProduct, id, picLink)
+}
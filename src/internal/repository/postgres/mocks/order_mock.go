@@ -36,18 +36,104 @@ func (m *MockOrderRepositoryInterface) EXPECT() *MockOrderRepositoryInterfaceMoc
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockOrderRepositoryInterface) Create(ctx context.Context, o structs.Order) error {
+// AddDiscount mocks base method.
+func (m *MockOrderRepositoryInterface) AddDiscount(ctx context.Context, id_item, id_promotion uuid.UUID, amount float64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, o)
+	ret := m.ctrl.Call(m, "AddDiscount", ctx, id_item, id_promotion, amount)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockOrderRepositoryInterfaceMockRecorder) Create(ctx, o interface{}) *gomock.Call {
+// AddDiscount indicates an expected call of AddDiscount.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) AddDiscount(ctx, id_item, id_promotion, amount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).Create), ctx, o)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDiscount", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).AddDiscount), ctx, id_item, id_promotion, amount)
+}
+
+// AddItem mocks base method.
+func (m *MockOrderRepositoryInterface) AddItem(ctx context.Context, item structs.OrderItem) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", ctx, item)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) AddItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).AddItem), ctx, item)
+}
+
+// AddItemLot mocks base method.
+func (m *MockOrderRepositoryInterface) AddItemLot(ctx context.Context, id_item uuid.UUID, lot structs.OrderItemLot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItemLot", ctx, id_item, lot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItemLot indicates an expected call of AddItemLot.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) AddItemLot(ctx, id_item, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemLot", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).AddItemLot), ctx, id_item, lot)
+}
+
+// AddStatusChange mocks base method.
+func (m *MockOrderRepositoryInterface) AddStatusChange(ctx context.Context, ch structs.OrderStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStatusChange", ctx, ch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStatusChange indicates an expected call of AddStatusChange.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) AddStatusChange(ctx, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStatusChange", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).AddStatusChange), ctx, ch)
+}
+
+// AddStockMovement mocks base method.
+func (m_2 *MockOrderRepositoryInterface) AddStockMovement(ctx context.Context, m structs.StockMovement) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "AddStockMovement", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStockMovement indicates an expected call of AddStockMovement.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) AddStockMovement(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStockMovement", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).AddStockMovement), ctx, m)
+}
+
+// ClearBasket mocks base method.
+func (m *MockOrderRepositoryInterface) ClearBasket(ctx context.Context, id_user uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearBasket", ctx, id_user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearBasket indicates an expected call of ClearBasket.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) ClearBasket(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearBasket", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).ClearBasket), ctx, id_user)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderRepositoryInterface) CreateOrder(ctx context.Context, o structs.Order) (structs.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, o)
+	ret0, _ := ret[0].(structs.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) CreateOrder(ctx, o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).CreateOrder), ctx, o)
 }
 
 // Delete mocks base method.
@@ -64,6 +150,35 @@ func (mr *MockOrderRepositoryInterfaceMockRecorder) Delete(ctx, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).Delete), ctx, id)
 }
 
+// Export mocks base method.
+func (m *MockOrderRepositoryInterface) Export(ctx context.Context, f structs.OrderFilter, fn func(structs.OrderExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, f, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) Export(ctx, f, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).Export), ctx, f, fn)
+}
+
+// GetBasket mocks base method.
+func (m *MockOrderRepositoryInterface) GetBasket(ctx context.Context, id_user uuid.UUID) ([]structs.BasketItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBasket", ctx, id_user)
+	ret0, _ := ret[0].([]structs.BasketItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBasket indicates an expected call of GetBasket.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) GetBasket(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBasket", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).GetBasket), ctx, id_user)
+}
+
 // GetById mocks base method.
 func (m *MockOrderRepositoryInterface) GetById(ctx context.Context, id uuid.UUID) (structs.Order, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +194,21 @@ func (mr *MockOrderRepositoryInterfaceMockRecorder) GetById(ctx, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).GetById), ctx, id)
 }
 
+// GetDelivery mocks base method.
+func (m *MockOrderRepositoryInterface) GetDelivery(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, id)
+	ret0, _ := ret[0].(structs.DeliveryMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) GetDelivery(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).GetDelivery), ctx, id)
+}
+
 // GetFreeOrders mocks base method.
 func (m *MockOrderRepositoryInterface) GetFreeOrders(ctx context.Context) ([]structs.Order, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +224,21 @@ func (mr *MockOrderRepositoryInterfaceMockRecorder) GetFreeOrders(ctx interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFreeOrders", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).GetFreeOrders), ctx)
 }
 
+// GetHistory mocks base method.
+func (m *MockOrderRepositoryInterface) GetHistory(ctx context.Context, id uuid.UUID) ([]structs.OrderStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id)
+	ret0, _ := ret[0].([]structs.OrderStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) GetHistory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).GetHistory), ctx, id)
+}
+
 // GetItems mocks base method.
 func (m *MockOrderRepositoryInterface) GetItems(ctx context.Context, id uuid.UUID) ([]structs.OrderItem, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +254,21 @@ func (mr *MockOrderRepositoryInterfaceMockRecorder) GetItems(ctx, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).GetItems), ctx, id)
 }
 
+// GetItemsByOrders mocks base method.
+func (m *MockOrderRepositoryInterface) GetItemsByOrders(ctx context.Context, ids []uuid.UUID) ([]structs.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsByOrders", ctx, ids)
+	ret0, _ := ret[0].([]structs.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsByOrders indicates an expected call of GetItemsByOrders.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) GetItemsByOrders(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsByOrders", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).GetItemsByOrders), ctx, ids)
+}
+
 // GetOrdersByUser mocks base method.
 func (m *MockOrderRepositoryInterface) GetOrdersByUser(ctx context.Context, id uuid.UUID) ([]structs.Order, error) {
 	m.ctrl.T.Helper()
@@ -124,11 +284,26 @@ func (mr *MockOrderRepositoryInterfaceMockRecorder) GetOrdersByUser(ctx, id inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUser", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).GetOrdersByUser), ctx, id)
 }
 
+// GetRepeatLines mocks base method.
+func (m *MockOrderRepositoryInterface) GetRepeatLines(ctx context.Context, id_order, id_user uuid.UUID) ([]structs.RepeatLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepeatLines", ctx, id_order, id_user)
+	ret0, _ := ret[0].([]structs.RepeatLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepeatLines indicates an expected call of GetRepeatLines.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) GetRepeatLines(ctx, id_order, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepeatLines", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).GetRepeatLines), ctx, id_order, id_user)
+}
+
 // GetStatus mocks base method.
-func (m *MockOrderRepositoryInterface) GetStatus(ctx context.Context, id uuid.UUID) (string, error) {
+func (m *MockOrderRepositoryInterface) GetStatus(ctx context.Context, id uuid.UUID) (structs.OrderStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStatus", ctx, id)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(structs.OrderStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -139,16 +314,118 @@ func (mr *MockOrderRepositoryInterfaceMockRecorder) GetStatus(ctx, id interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).GetStatus), ctx, id)
 }
 
+// InTx mocks base method.
+func (m *MockOrderRepositoryInterface) InTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) InTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).InTx), ctx, fn)
+}
+
+// LockLots mocks base method.
+func (m *MockOrderRepositoryInterface) LockLots(ctx context.Context, id_product, id_variant uuid.UUID) ([]structs.StockLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLots", ctx, id_product, id_variant)
+	ret0, _ := ret[0].([]structs.StockLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockLots indicates an expected call of LockLots.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) LockLots(ctx, id_product, id_variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLots", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).LockLots), ctx, id_product, id_variant)
+}
+
+// LockSlot mocks base method.
+func (m *MockOrderRepositoryInterface) LockSlot(ctx context.Context, id uuid.UUID) (structs.DeliverySlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockSlot", ctx, id)
+	ret0, _ := ret[0].(structs.DeliverySlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockSlot indicates an expected call of LockSlot.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) LockSlot(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSlot", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).LockSlot), ctx, id)
+}
+
+// LockStock mocks base method.
+func (m *MockOrderRepositoryInterface) LockStock(ctx context.Context, id_user, id_product, id_variant uuid.UUID) (structs.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockStock", ctx, id_user, id_product, id_variant)
+	ret0, _ := ret[0].(structs.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockStock indicates an expected call of LockStock.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) LockStock(ctx, id_user, id_product, id_variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockStock", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).LockStock), ctx, id_user, id_product, id_variant)
+}
+
+// Redeem mocks base method.
+func (m *MockOrderRepositoryInterface) Redeem(ctx context.Context, id_promotion, id_user, id_order uuid.UUID, amount float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, id_promotion, id_user, id_order, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) Redeem(ctx, id_promotion, id_user, id_order, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).Redeem), ctx, id_promotion, id_user, id_order, amount)
+}
+
+// Search mocks base method.
+func (m *MockOrderRepositoryInterface) Search(ctx context.Context, f structs.OrderFilter, after *structs.OrderCursor, limit int) ([]structs.OrderSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, f, after, limit)
+	ret0, _ := ret[0].([]structs.OrderSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) Search(ctx, f, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).Search), ctx, f, after, limit)
+}
+
+// SetPrice mocks base method.
+func (m *MockOrderRepositoryInterface) SetPrice(ctx context.Context, id_order uuid.UUID, price, shipping float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrice", ctx, id_order, price, shipping)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrice indicates an expected call of SetPrice.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) SetPrice(ctx, id_order, price, shipping interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrice", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).SetPrice), ctx, id_order, price, shipping)
+}
+
 // UpdateStatus mocks base method.
-func (m *MockOrderRepositoryInterface) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+func (m *MockOrderRepositoryInterface) UpdateStatus(ctx context.Context, ch structs.OrderStatusChange) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, ch)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockOrderRepositoryInterfaceMockRecorder) UpdateStatus(ctx, id, status interface{}) *gomock.Call {
+func (mr *MockOrderRepositoryInterfaceMockRecorder) UpdateStatus(ctx, ch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).UpdateStatus), ctx, id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).UpdateStatus), ctx, ch)
 }
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/payment/payment_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockPaymentRepositoryInterface is a mock of PaymentRepositoryInterface interface.
+type MockPaymentRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepositoryInterfaceMockRecorder
+}
+
+// MockPaymentRepositoryInterfaceMockRecorder is the mock recorder for MockPaymentRepositoryInterface.
+type MockPaymentRepositoryInterfaceMockRecorder struct {
+	mock *MockPaymentRepositoryInterface
+}
+
+// NewMockPaymentRepositoryInterface creates a new mock instance.
+func NewMockPaymentRepositoryInterface(ctrl *gomock.Controller) *MockPaymentRepositoryInterface {
+	mock := &MockPaymentRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepositoryInterface) EXPECT() *MockPaymentRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CompleteRefund mocks base method.
+func (m *MockPaymentRepositoryInterface) CompleteRefund(ctx context.Context, r structs.Refund, res structs.RefundResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRefund", ctx, r, res)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteRefund indicates an expected call of CompleteRefund.
+func (mr *MockPaymentRepositoryInterfaceMockRecorder) CompleteRefund(ctx, r, res interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRefund", reflect.TypeOf((*MockPaymentRepositoryInterface)(nil).CompleteRefund), ctx, r, res)
+}
+
+// Confirm mocks base method.
+func (m *MockPaymentRepositoryInterface) Confirm(ctx context.Context, id_event string, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, id_event, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockPaymentRepositoryInterfaceMockRecorder) Confirm(ctx, id_event, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockPaymentRepositoryInterface)(nil).Confirm), ctx, id_event, id)
+}
+
+// Create mocks base method.
+func (m *MockPaymentRepositoryInterface) Create(ctx context.Context, p structs.Payment) (structs.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, p)
+	ret0, _ := ret[0].(structs.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentRepositoryInterfaceMockRecorder) Create(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentRepositoryInterface)(nil).Create), ctx, p)
+}
+
+// Fail mocks base method.
+func (m *MockPaymentRepositoryInterface) Fail(ctx context.Context, id_event string, id uuid.UUID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, id_event, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockPaymentRepositoryInterfaceMockRecorder) Fail(ctx, id_event, id, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockPaymentRepositoryInterface)(nil).Fail), ctx, id_event, id, reason)
+}
+
+// GetById mocks base method.
+func (m *MockPaymentRepositoryInterface) GetById(ctx context.Context, id uuid.UUID) (structs.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(structs.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockPaymentRepositoryInterfaceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPaymentRepositoryInterface)(nil).GetById), ctx, id)
+}
+
+// GetByIntent mocks base method.
+func (m *MockPaymentRepositoryInterface) GetByIntent(ctx context.Context, provider, intent string) (structs.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIntent", ctx, provider, intent)
+	ret0, _ := ret[0].(structs.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIntent indicates an expected call of GetByIntent.
+func (mr *MockPaymentRepositoryInterfaceMockRecorder) GetByIntent(ctx, provider, intent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIntent", reflect.TypeOf((*MockPaymentRepositoryInterface)(nil).GetByIntent), ctx, provider, intent)
+}
+
+// GetByOrder mocks base method.
+func (m *MockPaymentRepositoryInterface) GetByOrder(ctx context.Context, id_order uuid.UUID) ([]structs.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, id_order)
+	ret0, _ := ret[0].([]structs.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrder indicates an expected call of GetByOrder.
+func (mr *MockPaymentRepositoryInterfaceMockRecorder) GetByOrder(ctx, id_order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockPaymentRepositoryInterface)(nil).GetByOrder), ctx, id_order)
+}
+
+// GetPendingRefunds mocks base method.
+func (m *MockPaymentRepositoryInterface) GetPendingRefunds(ctx context.Context, limit int) ([]structs.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingRefunds", ctx, limit)
+	ret0, _ := ret[0].([]structs.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingRefunds indicates an expected call of GetPendingRefunds.
+func (mr *MockPaymentRepositoryInterfaceMockRecorder) GetPendingRefunds(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingRefunds", reflect.TypeOf((*MockPaymentRepositoryInterface)(nil).GetPendingRefunds), ctx, limit)
+}
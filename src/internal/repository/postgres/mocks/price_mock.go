@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/price/price_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockPriceRepositoryInterface is a mock of PriceRepositoryInterface interface.
+type MockPriceRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceRepositoryInterfaceMockRecorder
+}
+
+// MockPriceRepositoryInterfaceMockRecorder is the mock recorder for MockPriceRepositoryInterface.
+type MockPriceRepositoryInterfaceMockRecorder struct {
+	mock *MockPriceRepositoryInterface
+}
+
+// NewMockPriceRepositoryInterface creates a new mock instance.
+func NewMockPriceRepositoryInterface(ctrl *gomock.Controller) *MockPriceRepositoryInterface {
+	mock := &MockPriceRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockPriceRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceRepositoryInterface) EXPECT() *MockPriceRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ActivateDue mocks base method.
+func (m *MockPriceRepositoryInterface) ActivateDue(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateDue", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateDue indicates an expected call of ActivateDue.
+func (mr *MockPriceRepositoryInterfaceMockRecorder) ActivateDue(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateDue", reflect.TypeOf((*MockPriceRepositoryInterface)(nil).ActivateDue), ctx)
+}
+
+// DeleteScheduled mocks base method.
+func (m *MockPriceRepositoryInterface) DeleteScheduled(ctx context.Context, id_product, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduled", ctx, id_product, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduled indicates an expected call of DeleteScheduled.
+func (mr *MockPriceRepositoryInterfaceMockRecorder) DeleteScheduled(ctx, id_product, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduled", reflect.TypeOf((*MockPriceRepositoryInterface)(nil).DeleteScheduled), ctx, id_product, id)
+}
+
+// GetHistory mocks base method.
+func (m *MockPriceRepositoryInterface) GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id_product)
+	ret0, _ := ret[0].([]structs.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockPriceRepositoryInterfaceMockRecorder) GetHistory(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockPriceRepositoryInterface)(nil).GetHistory), ctx, id_product)
+}
+
+// Schedule mocks base method.
+func (m *MockPriceRepositoryInterface) Schedule(ctx context.Context, p structs.ProductPrice) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, p)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockPriceRepositoryInterfaceMockRecorder) Schedule(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockPriceRepositoryInterface)(nil).Schedule), ctx, p)
+}
@@ -50,6 +50,20 @@ func (mr *MockProductRepositoryInterfaceMockRecorder) Create(ctx, p interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductRepositoryInterface)(nil).Create), ctx, p)
 }
 
+// CreateVariant mocks base method.
+func (m *MockProductRepositoryInterface) CreateVariant(ctx context.Context, v structs.ProductVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", ctx, v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVariant indicates an expected call of CreateVariant.
+func (mr *MockProductRepositoryInterfaceMockRecorder) CreateVariant(ctx, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockProductRepositoryInterface)(nil).CreateVariant), ctx, v)
+}
+
 // Delete mocks base method.
 func (m *MockProductRepositoryInterface) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -64,6 +78,20 @@ func (mr *MockProductRepositoryInterfaceMockRecorder) Delete(ctx, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductRepositoryInterface)(nil).Delete), ctx, id)
 }
 
+// DeleteVariant mocks base method.
+func (m *MockProductRepositoryInterface) DeleteVariant(ctx context.Context, id_product, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVariant", ctx, id_product, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVariant indicates an expected call of DeleteVariant.
+func (mr *MockProductRepositoryInterfaceMockRecorder) DeleteVariant(ctx, id_product, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVariant", reflect.TypeOf((*MockProductRepositoryInterface)(nil).DeleteVariant), ctx, id_product, id)
+}
+
 // GetByArticule mocks base method.
 func (m *MockProductRepositoryInterface) GetByArticule(ctx context.Context, art string) (structs.Product, error) {
 	m.ctrl.T.Helper()
@@ -95,18 +123,18 @@ func (mr *MockProductRepositoryInterfaceMockRecorder) GetByBrand(ctx, brand inte
 }
 
 // GetByCategory mocks base method.
-func (m *MockProductRepositoryInterface) GetByCategory(ctx context.Context, category string) ([]structs.Product, error) {
+func (m *MockProductRepositoryInterface) GetByCategory(ctx context.Context, id_category uuid.UUID) ([]structs.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCategory", ctx, category)
+	ret := m.ctrl.Call(m, "GetByCategory", ctx, id_category)
 	ret0, _ := ret[0].([]structs.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByCategory indicates an expected call of GetByCategory.
-func (mr *MockProductRepositoryInterfaceMockRecorder) GetByCategory(ctx, category interface{}) *gomock.Call {
+func (mr *MockProductRepositoryInterfaceMockRecorder) GetByCategory(ctx, id_category interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategory", reflect.TypeOf((*MockProductRepositoryInterface)(nil).GetByCategory), ctx, category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategory", reflect.TypeOf((*MockProductRepositoryInterface)(nil).GetByCategory), ctx, id_category)
 }
 
 // GetById mocks base method.
@@ -138,3 +166,33 @@ func (mr *MockProductRepositoryInterfaceMockRecorder) GetByName(ctx, name interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockProductRepositoryInterface)(nil).GetByName), ctx, name)
 }
+
+// GetVariants mocks base method.
+func (m *MockProductRepositoryInterface) GetVariants(ctx context.Context, id_product uuid.UUID) ([]structs.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariants", ctx, id_product)
+	ret0, _ := ret[0].([]structs.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariants indicates an expected call of GetVariants.
+func (mr *MockProductRepositoryInterfaceMockRecorder) GetVariants(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariants", reflect.TypeOf((*MockProductRepositoryInterface)(nil).GetVariants), ctx, id_product)
+}
+
+// Search mocks base method.
+func (m *MockProductRepositoryInterface) Search(ctx context.Context, f structs.ProductFilter) ([]structs.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, f)
+	ret0, _ := ret[0].([]structs.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockProductRepositoryInterfaceMockRecorder) Search(ctx, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockProductRepositoryInterface)(nil).Search), ctx, f)
+}
@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/promotion/promotion_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockPromotionRepositoryInterface is a mock of PromotionRepositoryInterface interface.
+type MockPromotionRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryInterfaceMockRecorder
+}
+
+// MockPromotionRepositoryInterfaceMockRecorder is the mock recorder for MockPromotionRepositoryInterface.
+type MockPromotionRepositoryInterfaceMockRecorder struct {
+	mock *MockPromotionRepositoryInterface
+}
+
+// NewMockPromotionRepositoryInterface creates a new mock instance.
+func NewMockPromotionRepositoryInterface(ctrl *gomock.Controller) *MockPromotionRepositoryInterface {
+	mock := &MockPromotionRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepositoryInterface) EXPECT() *MockPromotionRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromotionRepositoryInterface) Create(ctx context.Context, p structs.Promotion) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, p)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionRepositoryInterfaceMockRecorder) Create(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotionRepositoryInterface)(nil).Create), ctx, p)
+}
+
+// Deactivate mocks base method.
+func (m *MockPromotionRepositoryInterface) Deactivate(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockPromotionRepositoryInterfaceMockRecorder) Deactivate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockPromotionRepositoryInterface)(nil).Deactivate), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockPromotionRepositoryInterface) GetAll(ctx context.Context) ([]structs.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]structs.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPromotionRepositoryInterfaceMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPromotionRepositoryInterface)(nil).GetAll), ctx)
+}
+
+// GetAutomatic mocks base method.
+func (m *MockPromotionRepositoryInterface) GetAutomatic(ctx context.Context) ([]structs.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutomatic", ctx)
+	ret0, _ := ret[0].([]structs.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutomatic indicates an expected call of GetAutomatic.
+func (mr *MockPromotionRepositoryInterfaceMockRecorder) GetAutomatic(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutomatic", reflect.TypeOf((*MockPromotionRepositoryInterface)(nil).GetAutomatic), ctx)
+}
+
+// GetBasketLines mocks base method.
+func (m *MockPromotionRepositoryInterface) GetBasketLines(ctx context.Context, id_user uuid.UUID) ([]structs.CheckoutLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBasketLines", ctx, id_user)
+	ret0, _ := ret[0].([]structs.CheckoutLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBasketLines indicates an expected call of GetBasketLines.
+func (mr *MockPromotionRepositoryInterfaceMockRecorder) GetBasketLines(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBasketLines", reflect.TypeOf((*MockPromotionRepositoryInterface)(nil).GetBasketLines), ctx, id_user)
+}
+
+// GetByCode mocks base method.
+func (m *MockPromotionRepositoryInterface) GetByCode(ctx context.Context, code string) (structs.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(structs.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockPromotionRepositoryInterfaceMockRecorder) GetByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockPromotionRepositoryInterface)(nil).GetByCode), ctx, code)
+}
+
+// GetById mocks base method.
+func (m *MockPromotionRepositoryInterface) GetById(ctx context.Context, id uuid.UUID) (structs.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(structs.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockPromotionRepositoryInterfaceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPromotionRepositoryInterface)(nil).GetById), ctx, id)
+}
+
+// GetRedemptions mocks base method.
+func (m *MockPromotionRepositoryInterface) GetRedemptions(ctx context.Context, id, id_user uuid.UUID) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedemptions", ctx, id, id_user)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRedemptions indicates an expected call of GetRedemptions.
+func (mr *MockPromotionRepositoryInterfaceMockRecorder) GetRedemptions(ctx, id, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedemptions", reflect.TypeOf((*MockPromotionRepositoryInterface)(nil).GetRedemptions), ctx, id, id_user)
+}
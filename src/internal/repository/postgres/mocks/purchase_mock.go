@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/purchase/purchase_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockPurchaseRepositoryInterface is a mock of PurchaseRepositoryInterface interface.
+type MockPurchaseRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseRepositoryInterfaceMockRecorder
+}
+
+// MockPurchaseRepositoryInterfaceMockRecorder is the mock recorder for MockPurchaseRepositoryInterface.
+type MockPurchaseRepositoryInterfaceMockRecorder struct {
+	mock *MockPurchaseRepositoryInterface
+}
+
+// NewMockPurchaseRepositoryInterface creates a new mock instance.
+func NewMockPurchaseRepositoryInterface(ctrl *gomock.Controller) *MockPurchaseRepositoryInterface {
+	mock := &MockPurchaseRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockPurchaseRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseRepositoryInterface) EXPECT() *MockPurchaseRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateOrder mocks base method.
+func (m *MockPurchaseRepositoryInterface) CreateOrder(ctx context.Context, po structs.PurchaseOrder) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, po)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockPurchaseRepositoryInterfaceMockRecorder) CreateOrder(ctx, po interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockPurchaseRepositoryInterface)(nil).CreateOrder), ctx, po)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockPurchaseRepositoryInterface) CreateSupplier(ctx context.Context, s structs.Supplier) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", ctx, s)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockPurchaseRepositoryInterfaceMockRecorder) CreateSupplier(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockPurchaseRepositoryInterface)(nil).CreateSupplier), ctx, s)
+}
+
+// DeleteSupplier mocks base method.
+func (m *MockPurchaseRepositoryInterface) DeleteSupplier(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSupplier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSupplier indicates an expected call of DeleteSupplier.
+func (mr *MockPurchaseRepositoryInterfaceMockRecorder) DeleteSupplier(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupplier", reflect.TypeOf((*MockPurchaseRepositoryInterface)(nil).DeleteSupplier), ctx, id)
+}
+
+// GetOrder mocks base method.
+func (m *MockPurchaseRepositoryInterface) GetOrder(ctx context.Context, id uuid.UUID) (structs.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
+	ret0, _ := ret[0].(structs.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockPurchaseRepositoryInterfaceMockRecorder) GetOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockPurchaseRepositoryInterface)(nil).GetOrder), ctx, id)
+}
+
+// GetOrders mocks base method.
+func (m *MockPurchaseRepositoryInterface) GetOrders(ctx context.Context, status string) ([]structs.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx, status)
+	ret0, _ := ret[0].([]structs.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockPurchaseRepositoryInterfaceMockRecorder) GetOrders(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockPurchaseRepositoryInterface)(nil).GetOrders), ctx, status)
+}
+
+// GetSuppliers mocks base method.
+func (m *MockPurchaseRepositoryInterface) GetSuppliers(ctx context.Context) ([]structs.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuppliers", ctx)
+	ret0, _ := ret[0].([]structs.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuppliers indicates an expected call of GetSuppliers.
+func (mr *MockPurchaseRepositoryInterfaceMockRecorder) GetSuppliers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuppliers", reflect.TypeOf((*MockPurchaseRepositoryInterface)(nil).GetSuppliers), ctx)
+}
+
+// Receive mocks base method.
+func (m *MockPurchaseRepositoryInterface) Receive(ctx context.Context, seen structs.PurchaseOrder, r structs.PurchaseReceipt, status string, ds []structs.PurchaseDiscrepancy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, seen, r, status, ds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockPurchaseRepositoryInterfaceMockRecorder) Receive(ctx, seen, r, status, ds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockPurchaseRepositoryInterface)(nil).Receive), ctx, seen, r, status, ds)
+}
+
+// SetStatus mocks base method.
+func (m *MockPurchaseRepositoryInterface) SetStatus(ctx context.Context, id uuid.UUID, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockPurchaseRepositoryInterfaceMockRecorder) SetStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockPurchaseRepositoryInterface)(nil).SetStatus), ctx, id, from, to)
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/recommendation/recommendation_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockRecommendationRepositoryInterface is a mock of RecommendationRepositoryInterface interface.
+type MockRecommendationRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationRepositoryInterfaceMockRecorder
+}
+
+// MockRecommendationRepositoryInterfaceMockRecorder is the mock recorder for MockRecommendationRepositoryInterface.
+type MockRecommendationRepositoryInterfaceMockRecorder struct {
+	mock *MockRecommendationRepositoryInterface
+}
+
+// NewMockRecommendationRepositoryInterface creates a new mock instance.
+func NewMockRecommendationRepositoryInterface(ctrl *gomock.Controller) *MockRecommendationRepositoryInterface {
+	mock := &MockRecommendationRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockRecommendationRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationRepositoryInterface) EXPECT() *MockRecommendationRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ForBasket mocks base method.
+func (m *MockRecommendationRepositoryInterface) ForBasket(ctx context.Context, id_user uuid.UUID, limit int) ([]structs.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForBasket", ctx, id_user, limit)
+	ret0, _ := ret[0].([]structs.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForBasket indicates an expected call of ForBasket.
+func (mr *MockRecommendationRepositoryInterfaceMockRecorder) ForBasket(ctx, id_user, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForBasket", reflect.TypeOf((*MockRecommendationRepositoryInterface)(nil).ForBasket), ctx, id_user, limit)
+}
+
+// ForProduct mocks base method.
+func (m *MockRecommendationRepositoryInterface) ForProduct(ctx context.Context, id_product, id_user uuid.UUID, limit int) ([]structs.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForProduct", ctx, id_product, id_user, limit)
+	ret0, _ := ret[0].([]structs.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForProduct indicates an expected call of ForProduct.
+func (mr *MockRecommendationRepositoryInterfaceMockRecorder) ForProduct(ctx, id_product, id_user, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForProduct", reflect.TypeOf((*MockRecommendationRepositoryInterface)(nil).ForProduct), ctx, id_product, id_user, limit)
+}
+
+// Rebuild mocks base method.
+func (m *MockRecommendationRepositoryInterface) Rebuild(ctx context.Context, t structs.RecommendationThresholds) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebuild", ctx, t)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rebuild indicates an expected call of Rebuild.
+func (mr *MockRecommendationRepositoryInterfaceMockRecorder) Rebuild(ctx, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockRecommendationRepositoryInterface)(nil).Rebuild), ctx, t)
+}
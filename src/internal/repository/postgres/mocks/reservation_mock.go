@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/reservation/reservation_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockReservationRepositoryInterface is a mock of ReservationRepositoryInterface interface.
+type MockReservationRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationRepositoryInterfaceMockRecorder
+}
+
+// MockReservationRepositoryInterfaceMockRecorder is the mock recorder for MockReservationRepositoryInterface.
+type MockReservationRepositoryInterfaceMockRecorder struct {
+	mock *MockReservationRepositoryInterface
+}
+
+// NewMockReservationRepositoryInterface creates a new mock instance.
+func NewMockReservationRepositoryInterface(ctrl *gomock.Controller) *MockReservationRepositoryInterface {
+	mock := &MockReservationRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockReservationRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationRepositoryInterface) EXPECT() *MockReservationRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpired mocks base method.
+func (m *MockReservationRepositoryInterface) DeleteExpired(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockReservationRepositoryInterfaceMockRecorder) DeleteExpired(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockReservationRepositoryInterface)(nil).DeleteExpired), ctx)
+}
+
+// Release mocks base method.
+func (m *MockReservationRepositoryInterface) Release(ctx context.Context, id_user uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id_user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockReservationRepositoryInterfaceMockRecorder) Release(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockReservationRepositoryInterface)(nil).Release), ctx, id_user)
+}
+
+// Reserve mocks base method.
+func (m *MockReservationRepositoryInterface) Reserve(ctx context.Context, id_user uuid.UUID, ttl time.Duration) ([]structs.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, id_user, ttl)
+	ret0, _ := ret[0].([]structs.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockReservationRepositoryInterfaceMockRecorder) Reserve(ctx, id_user, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockReservationRepositoryInterface)(nil).Reserve), ctx, id_user, ttl)
+}
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/returns/returns_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockReturnRepositoryInterface is a mock of ReturnRepositoryInterface interface.
+type MockReturnRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnRepositoryInterfaceMockRecorder
+}
+
+// MockReturnRepositoryInterfaceMockRecorder is the mock recorder for MockReturnRepositoryInterface.
+type MockReturnRepositoryInterfaceMockRecorder struct {
+	mock *MockReturnRepositoryInterface
+}
+
+// NewMockReturnRepositoryInterface creates a new mock instance.
+func NewMockReturnRepositoryInterface(ctrl *gomock.Controller) *MockReturnRepositoryInterface {
+	mock := &MockReturnRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockReturnRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnRepositoryInterface) EXPECT() *MockReturnRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddPhotos mocks base method.
+func (m *MockReturnRepositoryInterface) AddPhotos(ctx context.Context, id uuid.UUID, photos []structs.ReturnPhoto, max int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPhotos", ctx, id, photos, max)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPhotos indicates an expected call of AddPhotos.
+func (mr *MockReturnRepositoryInterfaceMockRecorder) AddPhotos(ctx, id, photos, max interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPhotos", reflect.TypeOf((*MockReturnRepositoryInterface)(nil).AddPhotos), ctx, id, photos, max)
+}
+
+// Create mocks base method.
+func (m *MockReturnRepositoryInterface) Create(ctx context.Context, r structs.ReturnRequest) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, r)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnRepositoryInterfaceMockRecorder) Create(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturnRepositoryInterface)(nil).Create), ctx, r)
+}
+
+// Decide mocks base method.
+func (m *MockReturnRepositoryInterface) Decide(ctx context.Context, id uuid.UUID, status string, id_admin uuid.UUID, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, id, status, id_admin, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockReturnRepositoryInterfaceMockRecorder) Decide(ctx, id, status, id_admin, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockReturnRepositoryInterface)(nil).Decide), ctx, id, status, id_admin, comment)
+}
+
+// GetAll mocks base method.
+func (m *MockReturnRepositoryInterface) GetAll(ctx context.Context, status string) ([]structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, status)
+	ret0, _ := ret[0].([]structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockReturnRepositoryInterfaceMockRecorder) GetAll(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockReturnRepositoryInterface)(nil).GetAll), ctx, status)
+}
+
+// GetById mocks base method.
+func (m *MockReturnRepositoryInterface) GetById(ctx context.Context, id uuid.UUID) (structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockReturnRepositoryInterfaceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockReturnRepositoryInterface)(nil).GetById), ctx, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockReturnRepositoryInterface) GetByUser(ctx context.Context, id_user uuid.UUID) ([]structs.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, id_user)
+	ret0, _ := ret[0].([]structs.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockReturnRepositoryInterfaceMockRecorder) GetByUser(ctx, id_user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockReturnRepositoryInterface)(nil).GetByUser), ctx, id_user)
+}
+
+// GetRestocked mocks base method.
+func (m *MockReturnRepositoryInterface) GetRestocked(ctx context.Context, id_order uuid.UUID) (map[uuid.UUID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRestocked", ctx, id_order)
+	ret0, _ := ret[0].(map[uuid.UUID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestocked indicates an expected call of GetRestocked.
+func (mr *MockReturnRepositoryInterfaceMockRecorder) GetRestocked(ctx, id_order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestocked", reflect.TypeOf((*MockReturnRepositoryInterface)(nil).GetRestocked), ctx, id_order)
+}
+
+// Receive mocks base method.
+func (m *MockReturnRepositoryInterface) Receive(ctx context.Context, seen structs.ReturnRequest, rc structs.ReturnReceipt, ms []structs.StockMovement, refund structs.Refund) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, seen, rc, ms, refund)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockReturnRepositoryInterfaceMockRecorder) Receive(ctx, seen, rc, ms, refund interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockReturnRepositoryInterface)(nil).Receive), ctx, seen, rc, ms, refund)
+}
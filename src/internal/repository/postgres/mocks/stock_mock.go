@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reps/stock/stock_interface.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// MockStockRepositoryInterface is a mock of StockRepositoryInterface interface.
+type MockStockRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockRepositoryInterfaceMockRecorder
+}
+
+// MockStockRepositoryInterfaceMockRecorder is the mock recorder for MockStockRepositoryInterface.
+type MockStockRepositoryInterfaceMockRecorder struct {
+	mock *MockStockRepositoryInterface
+}
+
+// NewMockStockRepositoryInterface creates a new mock instance.
+func NewMockStockRepositoryInterface(ctrl *gomock.Controller) *MockStockRepositoryInterface {
+	mock := &MockStockRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockStockRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockRepositoryInterface) EXPECT() *MockStockRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m_2 *MockStockRepositoryInterface) Create(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(structs.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStockRepositoryInterfaceMockRecorder) Create(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStockRepositoryInterface)(nil).Create), ctx, m)
+}
+
+// CreateLot mocks base method.
+func (m *MockStockRepositoryInterface) CreateLot(ctx context.Context, lot structs.StockLot) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLot", ctx, lot)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLot indicates an expected call of CreateLot.
+func (mr *MockStockRepositoryInterfaceMockRecorder) CreateLot(ctx, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLot", reflect.TypeOf((*MockStockRepositoryInterface)(nil).CreateLot), ctx, lot)
+}
+
+// GetByProduct mocks base method.
+func (m *MockStockRepositoryInterface) GetByProduct(ctx context.Context, id_product uuid.UUID) ([]structs.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProduct", ctx, id_product)
+	ret0, _ := ret[0].([]structs.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProduct indicates an expected call of GetByProduct.
+func (mr *MockStockRepositoryInterfaceMockRecorder) GetByProduct(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProduct", reflect.TypeOf((*MockStockRepositoryInterface)(nil).GetByProduct), ctx, id_product)
+}
+
+// GetExpiring mocks base method.
+func (m *MockStockRepositoryInterface) GetExpiring(ctx context.Context, days int) ([]structs.StockLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiring", ctx, days)
+	ret0, _ := ret[0].([]structs.StockLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiring indicates an expected call of GetExpiring.
+func (mr *MockStockRepositoryInterfaceMockRecorder) GetExpiring(ctx, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiring", reflect.TypeOf((*MockStockRepositoryInterface)(nil).GetExpiring), ctx, days)
+}
+
+// GetLots mocks base method.
+func (m *MockStockRepositoryInterface) GetLots(ctx context.Context, id_product uuid.UUID) ([]structs.StockLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLots", ctx, id_product)
+	ret0, _ := ret[0].([]structs.StockLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLots indicates an expected call of GetLots.
+func (mr *MockStockRepositoryInterfaceMockRecorder) GetLots(ctx, id_product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLots", reflect.TypeOf((*MockStockRepositoryInterface)(nil).GetLots), ctx, id_product)
+}
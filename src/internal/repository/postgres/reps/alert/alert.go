@@ -0,0 +1,168 @@
+package alert_rep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+// stock is the stock of every product with a reorder rule, a product with
+// variants is stocked through them.
+const stock = `stock as (
+		select r.id_product, r.reorder_point, r.reorder_quantity,
+			p.amount + coalesce((select sum(v.amount) from product_variant v where v.id_product = p.id), 0) as amount
+		from product_reorder r join product p on p.id = r.id_product
+	)`
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (rep *Repository) SetRule(ctx context.Context, r structs.ReorderRule) error {
+	_, err := rep.db.ExecContext(ctx, `
+		insert into product_reorder (id_product, reorder_point, reorder_quantity) values ($1, $2, $3)
+		on conflict (id_product) do update set
+			reorder_point = excluded.reorder_point,
+			reorder_quantity = excluded.reorder_quantity`,
+		r.IdProduct, r.ReorderPoint, r.ReorderQuantity)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == "fk_product_reorder_product" {
+		return structs.ErrProductNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set reorder rule: %w", err)
+	}
+	return nil
+}
+
+func (rep *Repository) DeleteRule(ctx context.Context, id_product uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx, `delete from product_reorder where id_product = $1`, id_product)
+	if err != nil {
+		return fmt.Errorf("failed to delete reorder rule: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrReorderRuleNotFound
+	}
+	return nil
+}
+
+// RaiseAlerts closes the open alerts of replenished products and opens one
+// for every product below its reorder point that has none. The partial
+// unique index on open alerts keeps concurrent runs from doubling them.
+func (rep *Repository) RaiseAlerts(ctx context.Context) ([]structs.StockAlert, error) {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		with `+stock+`
+		update stock_alert a set resolved_at = localtimestamp
+		where a.resolved_at is null and not exists (
+			select 1 from stock s where s.id_product = a.id_product and s.amount < s.reorder_point)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve alerts: %w", err)
+	}
+
+	var as []rep_structs.StockAlert
+	err = tx.SelectContext(ctx, &as, `
+		with `+stock+`,
+		created as (
+			insert into stock_alert (id_product, amount, reorder_point, reorder_quantity)
+			select id_product, amount, reorder_point, reorder_quantity from stock
+			where amount < reorder_point
+			on conflict (id_product) where resolved_at is null do nothing
+			returning id, id_product, amount, reorder_point, reorder_quantity, created_at, resolved_at
+		)
+		select c.id, c.id_product, p.name, p.art, c.amount, c.reorder_point, c.reorder_quantity,
+			c.created_at, c.resolved_at
+		from created c join product p on p.id = c.id_product
+		order by p.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to raise alerts: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return toAlerts(as), nil
+}
+
+func (rep *Repository) GetOpen(ctx context.Context) ([]structs.StockAlert, error) {
+	var as []rep_structs.StockAlert
+	err := rep.db.SelectContext(ctx, &as, `
+		select a.id, a.id_product, p.name, p.art, a.amount, a.reorder_point, a.reorder_quantity,
+			a.created_at, a.resolved_at
+		from stock_alert a join product p on p.id = a.id_product
+		where a.resolved_at is null
+		order by a.created_at, p.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alerts: %w", err)
+	}
+	return toAlerts(as), nil
+}
+
+// GetReorderItems lists the products currently below their reorder point,
+// ordered by brand so that the caller can group them.
+func (rep *Repository) GetReorderItems(ctx context.Context) ([]structs.ReorderItem, error) {
+	var rs []rep_structs.ReorderItem
+	err := rep.db.SelectContext(ctx, &rs, `
+		with `+stock+`
+		select s.id_product, p.name, p.art, p.id_brand, coalesce(b.name, '') as brand,
+			s.amount, s.reorder_point, s.reorder_quantity
+		from stock s
+		join product p on p.id = s.id_product
+		left join brand b on b.id = p.id_brand
+		where s.amount < s.reorder_point
+		order by b.name nulls last, p.id_brand, p.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reorder items: %w", err)
+	}
+
+	items := make([]structs.ReorderItem, len(rs))
+	for i, r := range rs {
+		items[i] = structs.ReorderItem{
+			IdProduct:       r.IdProduct,
+			Name:            r.Name,
+			Articule:        r.Articule,
+			IdBrand:         r.IdBrand.UUID,
+			Brand:           r.Brand,
+			Amount:          r.Amount,
+			ReorderPoint:    r.ReorderPoint,
+			ReorderQuantity: r.ReorderQuantity,
+		}
+	}
+	return items, nil
+}
+
+func toAlerts(as []rep_structs.StockAlert) []structs.StockAlert {
+	res := make([]structs.StockAlert, len(as))
+	for i, a := range as {
+		res[i] = structs.StockAlert{
+			Id:              a.Id,
+			IdProduct:       a.IdProduct,
+			Name:            a.Name,
+			Articule:        a.Articule,
+			Amount:          a.Amount,
+			ReorderPoint:    a.ReorderPoint,
+			ReorderQuantity: a.ReorderQuantity,
+			CreatedAt:       a.CreatedAt,
+		}
+		if a.ResolvedAt.Valid {
+			res[i].ResolvedAt = &a.ResolvedAt.Time
+		}
+	}
+	return res
+}
@@ -0,0 +1,96 @@
+package alert_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var (
+	alertColumns = []string{"id", "id_product", "name", "art", "amount", "reorder_point", "reorder_quantity",
+		"created_at", "resolved_at"}
+	itemColumns = []string{"id_product", "name", "art", "id_brand", "brand", "amount", "reorder_point",
+		"reorder_quantity"}
+)
+
+type TestFixture struct {
+	t      *testing.T
+	db     *sql.DB
+	sqlxDB *sqlx.DB
+	mock   sqlmock.Sqlmock
+	repo   *Repository
+	ctx    context.Context
+	rule   structs.ReorderRule
+	alert  structs.StockAlert
+	item   structs.ReorderItem
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	id_product := structs.GenId()
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		rule: structs.ReorderRule{
+			IdProduct:       id_product,
+			ReorderPoint:    10,
+			ReorderQuantity: 50,
+		},
+		alert: structs.StockAlert{
+			Id:              structs.GenId(),
+			IdProduct:       id_product,
+			Name:            "Hydrating Serum",
+			Articule:        "HS-001",
+			Amount:          3,
+			ReorderPoint:    10,
+			ReorderQuantity: 50,
+			CreatedAt:       time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		item: structs.ReorderItem{
+			IdProduct:       id_product,
+			Name:            "Hydrating Serum",
+			Articule:        "HS-001",
+			IdBrand:         structs.GenId(),
+			Brand:           "Aurora",
+			Amount:          3,
+			ReorderPoint:    10,
+			ReorderQuantity: 50,
+		},
+	}
+}
+
+func (f *TestFixture) alertRows() *sqlmock.Rows {
+	a := f.alert
+	return sqlmock.NewRows(alertColumns).AddRow(a.Id, a.IdProduct, a.Name, a.Articule, a.Amount,
+		a.ReorderPoint, a.ReorderQuantity, a.CreatedAt, nil)
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
@@ -0,0 +1,16 @@
+package alert_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type AlertRepositoryInterface interface {
+	SetRule(ctx context.Context, r structs.ReorderRule) error
+	DeleteRule(ctx context.Context, id_product uuid.UUID) error
+	RaiseAlerts(ctx context.Context) ([]structs.StockAlert, error)
+	GetOpen(ctx context.Context) ([]structs.StockAlert, error)
+	GetReorderItems(ctx context.Context) ([]structs.ReorderItem, error)
+}
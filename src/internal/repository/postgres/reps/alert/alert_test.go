@@ -0,0 +1,258 @@
+package alert_rep
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestSetRule_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	r := fixture.rule
+
+	expectUpsert := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`insert into product_reorder \(id_product, reorder_point, reorder_quantity\) values \(\$1, \$2, \$3\) on conflict \(id_product\) do update`).
+			WithArgs(r.IdProduct, r.ReorderPoint, r.ReorderQuantity)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful set",
+			setupMock: func() {
+				expectUpsert().WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "unknown product",
+			setupMock: func() {
+				expectUpsert().WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_product_reorder_product"})
+			},
+			expectedErr: structs.ErrProductNotFound,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectUpsert().WillReturnError(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.SetRule(fixture.ctx, r)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDeleteRule_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id_product := fixture.rule.IdProduct
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from product_reorder where id_product = \$1`).
+					WithArgs(id_product).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "no rule",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from product_reorder where id_product = \$1`).
+					WithArgs(id_product).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrReorderRuleNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.DeleteRule(fixture.ctx, id_product)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestRaiseAlerts_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	expectResolve := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`with stock as .* update stock_alert a set resolved_at = localtimestamp where a.resolved_at is null`)
+	}
+	expectRaise := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into stock_alert \(id_product, amount, reorder_point, reorder_quantity\) .* on conflict \(id_product\) where resolved_at is null do nothing`)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.StockAlert
+		expectedErr error
+	}{
+		{
+			name: "new alert",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectResolve().WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRaise().WillReturnRows(fixture.alertRows())
+				fixture.mock.ExpectCommit()
+			},
+			expectedRet: []structs.StockAlert{fixture.alert},
+			expectedErr: nil,
+		},
+		{
+			name: "resolve error",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectResolve().WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+		{
+			name: "raise error",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectResolve().WillReturnResult(sqlmock.NewResult(0, 0))
+				expectRaise().WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.RaiseAlerts(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetOpen_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.StockAlert
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select a.id, .* from stock_alert a join product p on p.id = a.id_product where a.resolved_at is null`).
+					WillReturnRows(fixture.alertRows())
+			},
+			expectedRet: []structs.StockAlert{fixture.alert},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from stock_alert a`).WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetOpen(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetReorderItems_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	it := fixture.item
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.ReorderItem
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`with stock as .* where s.amount < s.reorder_point order by b.name nulls last, p.id_brand, p.name`).
+					WillReturnRows(sqlmock.NewRows(itemColumns).AddRow(it.IdProduct, it.Name, it.Articule, it.IdBrand,
+						it.Brand, it.Amount, it.ReorderPoint, it.ReorderQuantity))
+			},
+			expectedRet: []structs.ReorderItem{it},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`with stock as`).WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetReorderItems(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
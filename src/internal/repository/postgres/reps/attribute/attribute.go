@@ -0,0 +1,301 @@
+package attribute_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (rep *Repository) CreateIngredient(ctx context.Context, i structs.Ingredient) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := rep.db.GetContext(ctx, &id,
+		`insert into ingredient (inci_name, name) values ($1, $2) returning id`, i.InciName, i.Name)
+	if err != nil {
+		return uuid.Nil, writeError(err, "create ingredient")
+	}
+	return id, nil
+}
+
+func (rep *Repository) GetIngredients(ctx context.Context) ([]structs.Ingredient, error) {
+	var is []rep_structs.Ingredient
+	err := rep.db.SelectContext(ctx, &is, `select id, inci_name, name from ingredient order by inci_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredients: %w", err)
+	}
+	return toIngredients(is), nil
+}
+
+// DeleteIngredient removes an ingredient that is not in the composition of
+// any product, allergen profiles lose it with the ingredient.
+func (rep *Repository) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx, `delete from ingredient where id = $1`, id)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return structs.ErrDictionaryEntryInUse
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete ingredient: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrIngredientNotFound
+	}
+	return nil
+}
+
+func (rep *Repository) CreateAttribute(ctx context.Context, a structs.Attribute) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := rep.db.GetContext(ctx, &id,
+		`insert into attribute (kind, code, name) values ($1, $2, $3) returning id`, a.Kind, a.Code, a.Name)
+	if err != nil {
+		return uuid.Nil, writeError(err, "create attribute")
+	}
+	return id, nil
+}
+
+func (rep *Repository) GetAttributes(ctx context.Context, kind string) ([]structs.Attribute, error) {
+	var as []rep_structs.Attribute
+	err := rep.db.SelectContext(ctx, &as,
+		`select id, kind, code, name from attribute where $1 = '' or kind = $1 order by kind, name`, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attributes: %w", err)
+	}
+	return toAttributes(as), nil
+}
+
+func (rep *Repository) DeleteAttribute(ctx context.Context, id uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx, `delete from attribute where id = $1`, id)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return structs.ErrDictionaryEntryInUse
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete attribute: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrAttributeNotFound
+	}
+	return nil
+}
+
+// GetProductAttributes returns the composition of a product. A product
+// without stored properties has SPF 0 and is neither vegan nor cruelty free.
+func (rep *Repository) GetProductAttributes(ctx context.Context, id_product uuid.UUID) (structs.ProductAttributes, error) {
+	var props rep_structs.ProductProperties
+	err := rep.db.GetContext(ctx, &props, `
+		select p.id as id_product,
+			coalesce(pp.spf, 0) as spf,
+			coalesce(pp.vegan, false) as vegan,
+			coalesce(pp.cruelty_free, false) as cruelty_free
+		from product p
+		left join product_properties pp on pp.id_product = p.id
+		where p.id = $1`, id_product)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.ProductAttributes{}, structs.ErrProductNotFound
+	}
+	if err != nil {
+		return structs.ProductAttributes{}, fmt.Errorf("failed to get product properties: %w", err)
+	}
+
+	var is []rep_structs.Ingredient
+	err = rep.db.SelectContext(ctx, &is, `
+		select i.id, i.inci_name, i.name
+		from product_ingredient pi
+		join ingredient i on i.id = pi.id_ingredient
+		where pi.id_product = $1
+		order by pi.position`, id_product)
+	if err != nil {
+		return structs.ProductAttributes{}, fmt.Errorf("failed to get product ingredients: %w", err)
+	}
+
+	var as []rep_structs.Attribute
+	err = rep.db.SelectContext(ctx, &as, `
+		select a.id, a.kind, a.code, a.name
+		from product_attribute pa
+		join attribute a on a.id = pa.id_attribute
+		where pa.id_product = $1
+		order by a.kind, a.name`, id_product)
+	if err != nil {
+		return structs.ProductAttributes{}, fmt.Errorf("failed to get product attributes: %w", err)
+	}
+
+	return structs.ProductAttributes{
+		IdProduct:   props.IdProduct,
+		Ingredients: toIngredients(is),
+		Attributes:  toAttributes(as),
+		Spf:         props.Spf,
+		Vegan:       props.Vegan,
+		CrueltyFree: props.CrueltyFree,
+	}, nil
+}
+
+// SetProductAttributes replaces the properties, ingredients and attributes of
+// a product in one transaction. Ingredient positions follow the slice order.
+func (rep *Repository) SetProductAttributes(ctx context.Context, a structs.ProductAttributes) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		insert into product_properties (id_product, spf, vegan, cruelty_free)
+		values ($1, $2, $3, $4)
+		on conflict (id_product) do update set
+			spf = excluded.spf,
+			vegan = excluded.vegan,
+			cruelty_free = excluded.cruelty_free`,
+		a.IdProduct, a.Spf, a.Vegan, a.CrueltyFree)
+	if err != nil {
+		return writeError(err, "set product properties")
+	}
+
+	ingredients := make([]uuid.UUID, len(a.Ingredients))
+	for i, v := range a.Ingredients {
+		ingredients[i] = v.Id
+	}
+	if _, err := tx.ExecContext(ctx, `delete from product_ingredient where id_product = $1`, a.IdProduct); err != nil {
+		return fmt.Errorf("failed to clear product ingredients: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		insert into product_ingredient (id_product, id_ingredient, position)
+		select $1, t.id, t.n - 1 from unnest($2::uuid[]) with ordinality as t(id, n)`,
+		a.IdProduct, pq.Array(ingredients))
+	if err != nil {
+		return writeError(err, "set product ingredients")
+	}
+
+	attributes := make([]uuid.UUID, len(a.Attributes))
+	for i, v := range a.Attributes {
+		attributes[i] = v.Id
+	}
+	if _, err := tx.ExecContext(ctx, `delete from product_attribute where id_product = $1`, a.IdProduct); err != nil {
+		return fmt.Errorf("failed to clear product attributes: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		insert into product_attribute (id_product, id_attribute)
+		select $1, unnest($2::uuid[])`,
+		a.IdProduct, pq.Array(attributes))
+	if err != nil {
+		return writeError(err, "set product attributes")
+	}
+
+	return tx.Commit()
+}
+
+func (rep *Repository) GetAllergens(ctx context.Context, id_user uuid.UUID) ([]structs.Ingredient, error) {
+	var is []rep_structs.Ingredient
+	err := rep.db.SelectContext(ctx, &is, `
+		select i.id, i.inci_name, i.name
+		from user_allergen ua
+		join ingredient i on i.id = ua.id_ingredient
+		where ua.id_user = $1
+		order by i.inci_name`, id_user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allergens: %w", err)
+	}
+	return toIngredients(is), nil
+}
+
+func (rep *Repository) SetAllergens(ctx context.Context, id_user uuid.UUID, ids []uuid.UUID) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `delete from user_allergen where id_user = $1`, id_user); err != nil {
+		return fmt.Errorf("failed to clear allergens: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		insert into user_allergen (id_user, id_ingredient)
+		select $1, unnest($2::uuid[])`,
+		id_user, pq.Array(ids))
+	if err != nil {
+		return writeError(err, "set allergens")
+	}
+
+	return tx.Commit()
+}
+
+func (rep *Repository) FindAllergens(ctx context.Context, id_user uuid.UUID, id_products []uuid.UUID) (map[uuid.UUID][]structs.Ingredient, error) {
+	var rows []rep_structs.ProductIngredient
+	err := rep.db.SelectContext(ctx, &rows, `
+		select pi.id_product, i.id, i.inci_name, i.name
+		from product_ingredient pi
+		join user_allergen ua on ua.id_ingredient = pi.id_ingredient
+		join ingredient i on i.id = pi.id_ingredient
+		where ua.id_user = $1 and pi.id_product = any($2)
+		order by pi.id_product, pi.position`,
+		id_user, pq.Array(id_products))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find allergens: %w", err)
+	}
+
+	res := make(map[uuid.UUID][]structs.Ingredient)
+	for _, r := range rows {
+		res[r.IdProduct] = append(res[r.IdProduct], toIngredient(r.Ingredient))
+	}
+	return res, nil
+}
+
+// writeError maps constraint violations to domain errors by constraint name,
+// since one statement may violate references to different tables.
+func writeError(err error, op string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Constraint {
+		case "ingredient_inci_name_unique":
+			return structs.ErrDuplicateIngredient
+		case "attribute_kind_code_unique":
+			return structs.ErrDuplicateAttribute
+		case "fk_product_properties_product", "fk_product_ingredient_product", "fk_product_attribute_product":
+			return structs.ErrProductNotFound
+		case "fk_product_ingredient_ingredient", "fk_user_allergen_ingredient":
+			return structs.ErrIngredientNotFound
+		case "fk_product_attribute_attribute":
+			return structs.ErrAttributeNotFound
+		case "fk_user_allergen_user":
+			return structs.ErrUserNotFound
+		}
+	}
+	return fmt.Errorf("failed to %s: %w", op, err)
+}
+
+func toIngredient(i rep_structs.Ingredient) structs.Ingredient {
+	return structs.Ingredient{Id: i.Id, InciName: i.InciName, Name: i.Name}
+}
+
+func toIngredients(is []rep_structs.Ingredient) []structs.Ingredient {
+	res := make([]structs.Ingredient, len(is))
+	for i, v := range is {
+		res[i] = toIngredient(v)
+	}
+	return res
+}
+
+func toAttributes(as []rep_structs.Attribute) []structs.Attribute {
+	res := make([]structs.Attribute, len(as))
+	for i, a := range as {
+		res[i] = structs.Attribute{Id: a.Id, Kind: a.Kind, Code: a.Code, Name: a.Name}
+	}
+	return res
+}
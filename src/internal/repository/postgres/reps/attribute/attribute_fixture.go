@@ -0,0 +1,81 @@
+package attribute_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var ingredientRowColumns = []string{"id", "inci_name", "name"}
+
+type TestFixture struct {
+	t          *testing.T
+	db         *sql.DB
+	sqlxDB     *sqlx.DB
+	mock       sqlmock.Sqlmock
+	repo       *Repository
+	ctx        context.Context
+	aqua       structs.Ingredient
+	parfum     structs.Ingredient
+	matte      structs.Attribute
+	attributes structs.ProductAttributes
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	aqua := structs.Ingredient{Id: structs.GenId(), InciName: "Aqua", Name: "вода"}
+	parfum := structs.Ingredient{Id: structs.GenId(), InciName: "Parfum", Name: "отдушка"}
+	matte := structs.Attribute{Id: structs.GenId(), Kind: structs.AttributeFinish, Code: "matte", Name: "матовый"}
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		aqua:   aqua,
+		parfum: parfum,
+		matte:  matte,
+		attributes: structs.ProductAttributes{
+			IdProduct:   structs.GenId(),
+			Ingredients: []structs.Ingredient{aqua, parfum},
+			Attributes:  []structs.Attribute{matte},
+			Spf:         15,
+			CrueltyFree: true,
+		},
+	}
+}
+
+func (f *TestFixture) ingredientRows(is ...structs.Ingredient) *sqlmock.Rows {
+	rows := sqlmock.NewRows(ingredientRowColumns)
+	for _, i := range is {
+		rows.AddRow(i.Id, i.InciName, i.Name)
+	}
+	return rows
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
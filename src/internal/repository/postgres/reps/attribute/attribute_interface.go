@@ -0,0 +1,22 @@
+package attribute_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type AttributeRepositoryInterface interface {
+	CreateIngredient(ctx context.Context, i structs.Ingredient) (uuid.UUID, error)
+	GetIngredients(ctx context.Context) ([]structs.Ingredient, error)
+	DeleteIngredient(ctx context.Context, id uuid.UUID) error
+	CreateAttribute(ctx context.Context, a structs.Attribute) (uuid.UUID, error)
+	GetAttributes(ctx context.Context, kind string) ([]structs.Attribute, error)
+	DeleteAttribute(ctx context.Context, id uuid.UUID) error
+	GetProductAttributes(ctx context.Context, id_product uuid.UUID) (structs.ProductAttributes, error)
+	SetProductAttributes(ctx context.Context, a structs.ProductAttributes) error
+	GetAllergens(ctx context.Context, id_user uuid.UUID) ([]structs.Ingredient, error)
+	SetAllergens(ctx context.Context, id_user uuid.UUID, ids []uuid.UUID) error
+	FindAllergens(ctx context.Context, id_user uuid.UUID, id_products []uuid.UUID) (map[uuid.UUID][]structs.Ingredient, error)
+}
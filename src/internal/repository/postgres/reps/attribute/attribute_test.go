@@ -0,0 +1,304 @@
+package attribute_rep
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreateIngredient_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	i := fixture.aqua
+
+	expectInsert := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into ingredient \(inci_name, name\)`).
+			WithArgs(i.InciName, i.Name)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "successful create",
+			setupMock: func() {
+				expectInsert().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(i.Id))
+			},
+			expectedRet: i.Id,
+			expectedErr: nil,
+		},
+		{
+			name: "duplicate INCI name",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23505", Constraint: "ingredient_inci_name_unique"})
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDuplicateIngredient,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectInsert().WillReturnError(errTest)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.CreateIngredient(fixture.ctx, i)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDeleteIngredient_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id := fixture.aqua.Id
+
+	expectDelete := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`delete from ingredient where id = \$1`).WithArgs(id)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			setupMock: func() {
+				expectDelete().WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "ingredient used by products",
+			setupMock: func() {
+				expectDelete().WillReturnError(&pq.Error{Code: "23503"})
+			},
+			expectedErr: structs.ErrDictionaryEntryInUse,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				expectDelete().WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrIngredientNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.DeleteIngredient(fixture.ctx, id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetProductAttributes_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	a := fixture.attributes
+
+	expectProperties := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`select p.id as id_product, .* from product p left join product_properties pp`).
+			WithArgs(a.IdProduct)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet structs.ProductAttributes
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMock: func() {
+				expectProperties().WillReturnRows(sqlmock.NewRows([]string{"id_product", "spf", "vegan", "cruelty_free"}).
+					AddRow(a.IdProduct, a.Spf, a.Vegan, a.CrueltyFree))
+				fixture.mock.ExpectQuery(`from product_ingredient pi join ingredient i .* order by pi.position`).
+					WithArgs(a.IdProduct).
+					WillReturnRows(fixture.ingredientRows(fixture.aqua, fixture.parfum))
+				fixture.mock.ExpectQuery(`from product_attribute pa join attribute a`).
+					WithArgs(a.IdProduct).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "code", "name"}).
+						AddRow(fixture.matte.Id, fixture.matte.Kind, fixture.matte.Code, fixture.matte.Name))
+			},
+			expectedRet: a,
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupMock: func() {
+				expectProperties().WillReturnRows(sqlmock.NewRows([]string{"id_product", "spf", "vegan", "cruelty_free"}))
+			},
+			expectedRet: structs.ProductAttributes{},
+			expectedErr: structs.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetProductAttributes(fixture.ctx, a.IdProduct)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSetProductAttributes_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	a := fixture.attributes
+
+	expectProperties := func() {
+		fixture.mock.ExpectBegin()
+		fixture.mock.ExpectExec(`insert into product_properties .* on conflict \(id_product\) do update`).
+			WithArgs(a.IdProduct, a.Spf, a.Vegan, a.CrueltyFree).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		fixture.mock.ExpectExec(`delete from product_ingredient where id_product = \$1`).
+			WithArgs(a.IdProduct).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+	}
+	expectIngredients := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`insert into product_ingredient .* with ordinality`).
+			WithArgs(a.IdProduct, pq.Array([]uuid.UUID{fixture.aqua.Id, fixture.parfum.Id}))
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful set",
+			setupMock: func() {
+				expectProperties()
+				expectIngredients().WillReturnResult(sqlmock.NewResult(0, 2))
+				fixture.mock.ExpectExec(`delete from product_attribute where id_product = \$1`).
+					WithArgs(a.IdProduct).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`insert into product_attribute`).
+					WithArgs(a.IdProduct, pq.Array([]uuid.UUID{fixture.matte.Id})).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "unknown ingredient",
+			setupMock: func() {
+				expectProperties()
+				expectIngredients().WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_product_ingredient_ingredient"})
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrIngredientNotFound,
+		},
+		{
+			name: "product not found",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`insert into product_properties`).
+					WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_product_properties_product"})
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.SetProductAttributes(fixture.ctx, a)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestFindAllergens_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id_user := structs.GenId()
+	first := structs.GenId()
+	second := structs.GenId()
+	ids := []uuid.UUID{first, second}
+
+	expectFind := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`join user_allergen ua .* where ua.id_user = \$1 and pi.id_product = any\(\$2\)`).
+			WithArgs(id_user, pq.Array(ids))
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet map[uuid.UUID][]structs.Ingredient
+		expectedErr error
+	}{
+		{
+			name: "grouped by product",
+			setupMock: func() {
+				expectFind().WillReturnRows(sqlmock.NewRows([]string{"id_product", "id", "inci_name", "name"}).
+					AddRow(first, fixture.aqua.Id, fixture.aqua.InciName, fixture.aqua.Name).
+					AddRow(first, fixture.parfum.Id, fixture.parfum.InciName, fixture.parfum.Name).
+					AddRow(second, fixture.parfum.Id, fixture.parfum.InciName, fixture.parfum.Name))
+			},
+			expectedRet: map[uuid.UUID][]structs.Ingredient{
+				first:  {fixture.aqua, fixture.parfum},
+				second: {fixture.parfum},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectFind().WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.FindAllergens(fixture.ctx, id_user, ids)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
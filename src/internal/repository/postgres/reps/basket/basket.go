@@ -68,6 +68,7 @@ func (rep *Repository) GetItems(ctx context.Context, id_user uuid.UUID) ([]struc
 		for _, v := range items {
 			itms = append(itms, structs.BasketItem{Id: v.Id,
 				IdProduct: v.IdProduct,
+				IdVariant: v.IdVariant.UUID,
 				IdBasket:  v.IdBasket,
 				Amount:    v.Amount})
 		}
@@ -78,6 +79,7 @@ func (rep *Repository) GetItems(ctx context.Context, id_user uuid.UUID) ([]struc
 func (rep *Repository) AddItem(ctx context.Context, i structs.BasketItem) error {
 	it := rep_structs.BasketItem{
 		IdProduct: i.IdProduct,
+		IdVariant: rep_structs.NullId(i.IdVariant),
 		IdBasket:  i.IdBasket,
 		Amount:    i.Amount,
 	}
@@ -85,18 +87,19 @@ func (rep *Repository) AddItem(ctx context.Context, i structs.BasketItem) error
 	var item rep_structs.BasketItem
 
 	err := rep.db.GetContext(ctx, &item, `select * from basket_item where id_basket = $1
-	 and id_product = $2`, i.IdBasket, i.IdProduct)
+	 and id_product = $2 and id_variant is not distinct from $3`, i.IdBasket, i.IdProduct, it.IdVariant)
 	if err != sql.ErrNoRows {
-		err = rep.UpdateItemAmount(ctx, i.IdBasket, i.IdProduct, item.Amount+it.Amount)
+		err = rep.UpdateItemAmount(ctx, i.IdBasket, i.IdProduct, i.IdVariant, item.Amount+it.Amount)
 	} else {
-		_, err = rep.db.NamedExecContext(ctx, `insert into basket_item (id_product, id_basket, amount) 
-		values (:id_product, :id_basket, :amount)`, it)
+		_, err = rep.db.NamedExecContext(ctx, `insert into basket_item (id_product, id_variant, id_basket, amount) 
+		values (:id_product, :id_variant, :id_basket, :amount)`, it)
 	}
 	return err
 }
 
-func (rep *Repository) DeleteItem(ctx context.Context, id uuid.UUID, product_id uuid.UUID) error {
-	result, err := rep.db.ExecContext(ctx, "delete from basket_item where id_product = $1 and id_basket = $2", product_id, id)
+func (rep *Repository) DeleteItem(ctx context.Context, id uuid.UUID, product_id uuid.UUID, variant_id uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx, `delete from basket_item where id_product = $1 and id_basket = $2
+	 and id_variant is not distinct from $3`, product_id, id, rep_structs.NullId(variant_id))
 	if err != nil {
 		return err
 	}
@@ -113,7 +116,8 @@ func (rep *Repository) DeleteItem(ctx context.Context, id uuid.UUID, product_id
 	return nil
 }
 
-func (rep *Repository) UpdateItemAmount(ctx context.Context, basket_id uuid.UUID, product_id uuid.UUID, amount int) error {
-	_, err := rep.db.ExecContext(ctx, "update basket_item set amount = $1 where id_product = $2 and id_basket = $3", amount, product_id, basket_id)
+func (rep *Repository) UpdateItemAmount(ctx context.Context, basket_id uuid.UUID, product_id uuid.UUID, variant_id uuid.UUID, amount int) error {
+	_, err := rep.db.ExecContext(ctx, `update basket_item set amount = $1 where id_product = $2 and id_basket = $3
+	 and id_variant is not distinct from $4`, amount, product_id, basket_id, rep_structs.NullId(variant_id))
 	return err
 }
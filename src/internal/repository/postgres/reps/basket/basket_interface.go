@@ -13,6 +13,6 @@ type BasketRepositoryInterface interface {
 	GetById(ctx context.Context, id uuid.UUID) (structs.Basket, error)
 	GetItems(ctx context.Context, id_user uuid.UUID) ([]structs.BasketItem, error)
 	AddItem(ctx context.Context, i structs.BasketItem) error
-	DeleteItem(ctx context.Context, id uuid.UUID, product_id uuid.UUID) error
-	UpdateItemAmount(ctx context.Context, basket_id uuid.UUID, product_id uuid.UUID, amount int) error
+	DeleteItem(ctx context.Context, id uuid.UUID, product_id uuid.UUID, variant_id uuid.UUID) error
+	UpdateItemAmount(ctx context.Context, basket_id uuid.UUID, product_id uuid.UUID, variant_id uuid.UUID, amount int) error
 }
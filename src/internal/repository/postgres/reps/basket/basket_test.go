@@ -294,11 +294,11 @@ func TestAddItem(t *testing.T) {
 			name: "successful add new item",
 			setupMock: func() {
 				fixture.mock.ExpectQuery(`select \* from basket_item where id_basket = \$1 and id_product = \$2`).
-					WithArgs(fixture.basketItem.IdBasket, fixture.basketItem.IdProduct).
+					WithArgs(fixture.basketItem.IdBasket, fixture.basketItem.IdProduct, nil).
 					WillReturnError(sql.ErrNoRows)
 
 				fixture.mock.ExpectExec(`insert into basket_item`).
-					WithArgs(fixture.basketItem.IdProduct, fixture.basketItem.IdBasket, fixture.basketItem.Amount).
+					WithArgs(fixture.basketItem.IdProduct, nil, fixture.basketItem.IdBasket, fixture.basketItem.Amount).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			expectedErr: nil,
@@ -316,11 +316,11 @@ func TestAddItem(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id", "id_product", "id_basket", "amount"}).
 					AddRow(existingItem.Id, existingItem.IdProduct, existingItem.IdBasket, existingItem.Amount)
 				fixture.mock.ExpectQuery(`select \* from basket_item where id_basket = \$1 and id_product = \$2`).
-					WithArgs(fixture.basketItem.IdBasket, fixture.basketItem.IdProduct).
+					WithArgs(fixture.basketItem.IdBasket, fixture.basketItem.IdProduct, nil).
 					WillReturnRows(rows)
 
 				fixture.mock.ExpectExec("update basket_item set amount = \\$1 where id_product = \\$2 and id_basket = \\$3").
-					WithArgs(3, fixture.basketItem.IdProduct, fixture.basketItem.IdBasket).
+					WithArgs(3, fixture.basketItem.IdProduct, fixture.basketItem.IdBasket, nil).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			expectedErr: nil,
@@ -329,11 +329,11 @@ func TestAddItem(t *testing.T) {
 			name: "error inserting new item",
 			setupMock: func() {
 				fixture.mock.ExpectQuery(`select \* from basket_item where id_basket = \$1 and id_product = \$2`).
-					WithArgs(fixture.basketItem.IdBasket, fixture.basketItem.IdProduct).
+					WithArgs(fixture.basketItem.IdBasket, fixture.basketItem.IdProduct, nil).
 					WillReturnError(sql.ErrNoRows)
 
 				fixture.mock.ExpectExec(`insert into basket_item`).
-					WithArgs(fixture.basketItem.IdProduct, fixture.basketItem.IdBasket, fixture.basketItem.Amount).
+					WithArgs(fixture.basketItem.IdProduct, nil, fixture.basketItem.IdBasket, fixture.basketItem.Amount).
 					WillReturnError(errTest)
 			},
 			expectedErr: errTest,
@@ -368,7 +368,7 @@ func TestDeleteItem(t *testing.T) {
 			name: "successful delete item",
 			setupMock: func() {
 				fixture.mock.ExpectExec("delete from basket_item where id_product = \\$1 and id_basket = \\$2").
-					WithArgs(fixture.basketItem.IdProduct, fixture.basket.Id).
+					WithArgs(fixture.basketItem.IdProduct, fixture.basket.Id, nil).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			basketID:    fixture.basket.Id,
@@ -379,7 +379,7 @@ func TestDeleteItem(t *testing.T) {
 			name: "item not found",
 			setupMock: func() {
 				fixture.mock.ExpectExec("delete from basket_item where id_product = \\$1 and id_basket = \\$2").
-					WithArgs(fixture.basketItem.IdProduct, fixture.basket.Id).
+					WithArgs(fixture.basketItem.IdProduct, fixture.basket.Id, nil).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			basketID:    fixture.basket.Id,
@@ -390,7 +390,7 @@ func TestDeleteItem(t *testing.T) {
 			name: "database error when deleting item",
 			setupMock: func() {
 				fixture.mock.ExpectExec("delete from basket_item where id_product = \\$1 and id_basket = \\$2").
-					WithArgs(fixture.basketItem.IdProduct, fixture.basket.Id).
+					WithArgs(fixture.basketItem.IdProduct, fixture.basket.Id, nil).
 					WillReturnError(errTest)
 			},
 			basketID:    fixture.basket.Id,
@@ -402,7 +402,7 @@ func TestDeleteItem(t *testing.T) {
 			setupMock: func() {
 				result := sqlmock.NewErrorResult(errTest)
 				fixture.mock.ExpectExec("delete from basket_item where id_product = \\$1 and id_basket = \\$2").
-					WithArgs(fixture.basketItem.IdProduct, fixture.basket.Id).
+					WithArgs(fixture.basketItem.IdProduct, fixture.basket.Id, nil).
 					WillReturnResult(result)
 			},
 			basketID:    fixture.basket.Id,
@@ -415,7 +415,7 @@ func TestDeleteItem(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			err := fixture.repo.DeleteItem(fixture.ctx, tt.basketID, tt.productID)
+			err := fixture.repo.DeleteItem(fixture.ctx, tt.basketID, tt.productID, uuid.Nil)
 
 			fixture.AssertError(err, tt.expectedErr)
 			require.NoError(t, fixture.mock.ExpectationsWereMet())
@@ -440,7 +440,7 @@ func TestUpdateItemAmount(t *testing.T) {
 			name: "successful update item amount",
 			setupMock: func() {
 				fixture.mock.ExpectExec("update basket_item set amount = \\$1 where id_product = \\$2 and id_basket = \\$3").
-					WithArgs(5, fixture.basketItem.IdProduct, fixture.basket.Id).
+					WithArgs(5, fixture.basketItem.IdProduct, fixture.basket.Id, nil).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			basketID:    fixture.basket.Id,
@@ -452,7 +452,7 @@ func TestUpdateItemAmount(t *testing.T) {
 			name: "database error when updating item amount",
 			setupMock: func() {
 				fixture.mock.ExpectExec("update basket_item set amount = \\$1 where id_product = \\$2 and id_basket = \\$3").
-					WithArgs(5, fixture.basketItem.IdProduct, fixture.basket.Id).
+					WithArgs(5, fixture.basketItem.IdProduct, fixture.basket.Id, nil).
 					WillReturnError(errTest)
 			},
 			basketID:    fixture.basket.Id,
@@ -466,7 +466,7 @@ func TestUpdateItemAmount(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			err := fixture.repo.UpdateItemAmount(fixture.ctx, tt.basketID, tt.productID, tt.amount)
+			err := fixture.repo.UpdateItemAmount(fixture.ctx, tt.basketID, tt.productID, uuid.Nil, tt.amount)
 
 			fixture.AssertError(err, tt.expectedErr)
 			require.NoError(t, fixture.mock.ExpectationsWereMet())
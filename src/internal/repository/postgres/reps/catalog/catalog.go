@@ -0,0 +1,233 @@
+package catalog_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (rep *Repository) ExistingArticules(ctx context.Context, arts []string) ([]string, error) {
+	var res []string
+	err := rep.db.SelectContext(ctx, &res, `select art from product where art = any($1)`, pq.Array(arts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articules: %w", err)
+	}
+	return res, nil
+}
+
+// VariantArticules returns the articules among arts taken by product
+// variants. The import can not reuse them for products.
+func (rep *Repository) VariantArticules(ctx context.Context, arts []string) ([]string, error) {
+	var res []string
+	err := rep.db.SelectContext(ctx, &res, `select art from product_variant where art = any($1)`, pq.Array(arts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variant articules: %w", err)
+	}
+	return res, nil
+}
+
+func (rep *Repository) ExistingBrands(ctx context.Context, names []string) ([]string, error) {
+	var res []string
+	err := rep.db.SelectContext(ctx, &res, `select distinct name from brand where name = any($1)`, pq.Array(names))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brands: %w", err)
+	}
+	return res, nil
+}
+
+// FindCategories returns the categories whose slug or name (case
+// insensitive) equals one of the keys.
+func (rep *Repository) FindCategories(ctx context.Context, keys []string) ([]structs.Category, error) {
+	lower := make([]string, len(keys))
+	for i, k := range keys {
+		lower[i] = strings.ToLower(k)
+	}
+
+	var cs []rep_structs.Category
+	err := rep.db.SelectContext(ctx, &cs,
+		`select id, id_parent, name, slug, sort_order from category where slug = any($1) or lower(name) = any($2)`,
+		pq.Array(keys), pq.Array(lower))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return toCategories(cs), nil
+}
+
+func (rep *Repository) Import(ctx context.Context, rows []structs.CatalogImportRow) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	brands := make(map[string]uuid.UUID)
+	for _, r := range rows {
+		id, ok := brands[r.Brand]
+		if !ok {
+			id, err = upsertBrand(ctx, tx, r)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", r.Line, err)
+			}
+			brands[r.Brand] = id
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			insert into product (name, description, price, id_category, amount, id_brand, pic_link, art)
+			values ($1, $2, $3, $4, $5, $6, $7, $8)
+			on conflict (art) do update set
+				name = excluded.name,
+				description = excluded.description,
+				price = excluded.price,
+				id_category = excluded.id_category,
+				amount = excluded.amount,
+				id_brand = excluded.id_brand,
+				pic_link = excluded.pic_link`,
+			r.Name, r.Description, r.Price, r.IdCategory, r.Amount, id, r.PicLink, r.Articule)
+		if err != nil {
+			return fmt.Errorf("line %d: failed to upsert product: %w", r.Line, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func upsertBrand(ctx context.Context, tx *sqlx.Tx, r structs.CatalogImportRow) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := tx.GetContext(ctx, &id, `select id from brand where name = $1 limit 1`, r.Brand)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = tx.GetContext(ctx, &id,
+			`insert into brand (name, description, price_category) values ($1, $2, $3) returning id`,
+			r.Brand, r.BrandDescription, r.BrandPriceCategory)
+		if err != nil {
+			return uuid.UUID{}, fmt.Errorf("failed to create brand: %w", err)
+		}
+		return id, nil
+	}
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("failed to get brand: %w", err)
+	}
+
+	if r.BrandDescription != "" || r.BrandPriceCategory != "" {
+		_, err = tx.ExecContext(ctx, `update brand set
+			description = coalesce(nullif($1, ''), description),
+			price_category = coalesce(nullif($2, ''), price_category)
+			where id = $3`,
+			r.BrandDescription, r.BrandPriceCategory, id)
+		if err != nil {
+			return uuid.UUID{}, fmt.Errorf("failed to update brand: %w", err)
+		}
+	}
+	return id, nil
+}
+
+// ExportCategories returns the whole category tree with parents before
+// their children.
+func (rep *Repository) ExportCategories(ctx context.Context) ([]structs.Category, error) {
+	var cs []rep_structs.Category
+	err := rep.db.SelectContext(ctx, &cs, `
+		with recursive tree as (
+			select id, id_parent, name, slug, sort_order, array[sort_order] as path
+			from category where id_parent is null
+			union all
+			select c.id, c.id_parent, c.name, c.slug, c.sort_order, t.path || c.sort_order
+			from category c join tree t on c.id_parent = t.id
+		)
+		select id, id_parent, name, slug, sort_order from tree order by path, name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return toCategories(cs), nil
+}
+
+// ExportProducts streams the offers of the catalog: one per variant, or one
+// per product without variants. The available stock leaves out live
+// reservations and expired lots like the product card does, images are the
+// storage keys of the gallery with the primary image first.
+func (rep *Repository) ExportProducts(ctx context.Context, fn func(structs.CatalogExportItem) error) error {
+	rows, err := rep.db.QueryxContext(ctx, `
+		select p.id, v.id as id_variant, coalesce(v.art, p.art) as art, p.art as product_art, p.name,
+			coalesce(p.description, '') as description, coalesce(v.price, p.price) as price,
+			coalesce(c.slug, '') as category, p.id_category,
+			coalesce(v.shade, '') as shade, coalesce(v.volume_ml, 0) as volume_ml,
+			greatest(case when v.id is null then
+				p.amount - coalesce((select sum(sr.amount) from stock_reservation sr
+					where sr.id_product = p.id and sr.id_variant is null and sr.expires_at > localtimestamp), 0)
+				- coalesce((select sum(l.amount) from stock_lot l
+					where l.id_product = p.id and l.id_variant is null and l.expires_at < current_date), 0)
+			else
+				v.amount - coalesce((select sum(sr.amount) from stock_reservation sr
+					where sr.id_variant = v.id and sr.expires_at > localtimestamp), 0)
+				- coalesce((select sum(l.amount) from stock_lot l
+					where l.id_variant = v.id and l.expires_at < current_date), 0)
+			end, 0) as available,
+			coalesce(b.name, '') as brand, coalesce(p.pic_link, '') as pic_link,
+			array(select i.storage_key from product_image i where i.id_product = p.id
+				order by i.is_primary desc nulls last, i.position) as images
+		from product p
+		left join product_variant v on v.id_product = p.id
+		left join category c on c.id = p.id_category
+		left join brand b on b.id = p.id_brand
+		order by p.art, v.art`)
+	if err != nil {
+		return fmt.Errorf("failed to export products: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p rep_structs.CatalogExportItem
+		if err := rows.StructScan(&p); err != nil {
+			return fmt.Errorf("failed to scan product: %w", err)
+		}
+		err := fn(structs.CatalogExportItem{
+			Id:              p.Id,
+			IdVariant:       p.IdVariant.UUID,
+			Articule:        p.Articule,
+			ProductArticule: p.ProductArticule,
+			Name:            p.Name,
+			Description:     p.Description,
+			Price:           p.Price,
+			Category:        p.Category,
+			IdCategory:      p.IdCategory.UUID,
+			Shade:           p.Shade,
+			VolumeMl:        p.VolumeMl,
+			Available:       p.Available,
+			Brand:           p.Brand,
+			PicLink:         p.PicLink,
+			Images:          p.Images,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func toCategories(cs []rep_structs.Category) []structs.Category {
+	res := make([]structs.Category, len(cs))
+	for i, c := range cs {
+		res[i] = structs.Category{
+			Id:        c.Id,
+			IdParent:  c.IdParent.UUID,
+			Name:      c.Name,
+			Slug:      c.Slug,
+			SortOrder: c.SortOrder,
+		}
+	}
+	return res
+}
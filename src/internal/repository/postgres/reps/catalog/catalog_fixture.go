@@ -0,0 +1,88 @@
+package catalog_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t        *testing.T
+	db       *sql.DB
+	sqlxDB   *sqlx.DB
+	mock     sqlmock.Sqlmock
+	repo     *Repository
+	ctx      context.Context
+	row      structs.CatalogImportRow
+	category structs.Category
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	category := structs.Category{
+		Id:        uuid.New(),
+		IdParent:  uuid.New(),
+		Name:      "Лицо",
+		Slug:      "makeup-face",
+		SortOrder: 1,
+	}
+
+	row := structs.CatalogImportRow{
+		Line:               2,
+		Articule:           "LOR-TM-001",
+		Name:               "Тональный крем True Match",
+		Description:        "Тональный крем с SPF 30, 30 мл",
+		Price:              2500,
+		Category:           category.Slug,
+		IdCategory:         category.Id,
+		Amount:             15,
+		Brand:              "L'Oreal",
+		BrandPriceCategory: "люкс",
+		PicLink:            "/images/foundation.jpg",
+	}
+
+	return &TestFixture{
+		t:        t,
+		db:       db,
+		sqlxDB:   sqlxDB,
+		mock:     mock,
+		repo:     New(sqlxDB),
+		ctx:      context.Background(),
+		row:      row,
+		category: category,
+	}
+}
+
+func (f *TestFixture) categoryRows(cs ...structs.Category) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "id_parent", "name", "slug", "sort_order"})
+	for _, c := range cs {
+		rows.AddRow(c.Id, c.IdParent, c.Name, c.Slug, c.SortOrder)
+	}
+	return rows
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
@@ -0,0 +1,17 @@
+package catalog_rep
+
+import (
+	"context"
+
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type CatalogRepositoryInterface interface {
+	ExistingArticules(ctx context.Context, arts []string) ([]string, error)
+	VariantArticules(ctx context.Context, arts []string) ([]string, error)
+	ExistingBrands(ctx context.Context, names []string) ([]string, error)
+	FindCategories(ctx context.Context, keys []string) ([]structs.Category, error)
+	Import(ctx context.Context, rows []structs.CatalogImportRow) error
+	ExportCategories(ctx context.Context) ([]structs.Category, error)
+	ExportProducts(ctx context.Context, fn func(structs.CatalogExportItem) error) error
+}
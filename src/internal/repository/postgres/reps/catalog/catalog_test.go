@@ -0,0 +1,430 @@
+package catalog_rep
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestExistingArticules(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name: "successful get articules",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select art from product where art = any\(\$1\)`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"art"}).AddRow(fixture.row.Articule))
+			},
+			expected:    []string{fixture.row.Articule},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select art from product where art = any\(\$1\)`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			arts, err := fixture.repo.ExistingArticules(fixture.ctx, []string{fixture.row.Articule})
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, arts)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestVariantArticules(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name: "successful get variant articules",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select art from product_variant where art = any\(\$1\)`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"art"}).AddRow(fixture.row.Articule))
+			},
+			expected:    []string{fixture.row.Articule},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select art from product_variant where art = any\(\$1\)`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			arts, err := fixture.repo.VariantArticules(fixture.ctx, []string{fixture.row.Articule})
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, arts)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestExistingBrands(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name: "successful get brands",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select distinct name from brand where name = any\(\$1\)`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow(fixture.row.Brand))
+			},
+			expected:    []string{fixture.row.Brand},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select distinct name from brand where name = any\(\$1\)`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			brands, err := fixture.repo.ExistingBrands(fixture.ctx, []string{fixture.row.Brand})
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, brands)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestFindCategories(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	keys := []string{"makeup-face", "Лицо"}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.Category
+		expectedErr error
+	}{
+		{
+			name: "matches slugs and lowercased names",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from category where slug = any\(\$1\) or lower\(name\) = any\(\$2\)`).
+					WithArgs(pq.Array(keys), pq.Array([]string{"makeup-face", "лицо"})).
+					WillReturnRows(fixture.categoryRows(fixture.category))
+			},
+			expectedRet: []structs.Category{fixture.category},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from category where slug = any`).
+					WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.FindCategories(fixture.ctx, keys)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	brandId := uuid.New()
+
+	expectProduct := func() *sqlmock.ExpectedExec {
+		r := fixture.row
+		return fixture.mock.ExpectExec(`insert into product .* on conflict \(art\) do update`).
+			WithArgs(r.Name, r.Description, r.Price, r.IdCategory, r.Amount, brandId, r.PicLink, r.Articule)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "creates missing brand and commits",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select id from brand where name = \$1`).
+					WithArgs(fixture.row.Brand).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				fixture.mock.ExpectQuery(`insert into brand \(name, description, price_category\)`).
+					WithArgs(fixture.row.Brand, fixture.row.BrandDescription, fixture.row.BrandPriceCategory).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(brandId))
+				expectProduct().WillReturnResult(sqlmock.NewResult(1, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "updates existing brand and commits",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select id from brand where name = \$1`).
+					WithArgs(fixture.row.Brand).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(brandId))
+				fixture.mock.ExpectExec(`update brand set`).
+					WithArgs(fixture.row.BrandDescription, fixture.row.BrandPriceCategory, brandId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectProduct().WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "product error rolls back",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select id from brand where name = \$1`).
+					WithArgs(fixture.row.Brand).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(brandId))
+				fixture.mock.ExpectExec(`update brand set`).
+					WithArgs(fixture.row.BrandDescription, fixture.row.BrandPriceCategory, brandId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectProduct().WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: errTest,
+		},
+		{
+			name: "begin error",
+			setupMock: func() {
+				fixture.mock.ExpectBegin().WillReturnError(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Import(fixture.ctx, []structs.CatalogImportRow{fixture.row})
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestExportCategories_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.Category
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`with recursive tree as .* order by path, name`).
+					WillReturnRows(fixture.categoryRows(fixture.category))
+			},
+			expectedRet: []structs.Category{fixture.category},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`with recursive tree as .* order by path, name`).
+					WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.ExportCategories(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestExportProducts_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id := uuid.New()
+	id_variant := uuid.New()
+	r := fixture.row
+	columns := []string{"id", "id_variant", "art", "product_art", "name", "description", "price", "category", "id_category",
+		"shade", "volume_ml", "available", "brand", "pic_link", "images"}
+	selectOffers := `select p.id, v.id as id_variant, coalesce\(v.art, p.art\) as art.* from product p\s+` +
+		`left join product_variant v on v.id_product = p.id\s+left join category c .* left join brand b .* order by p.art, v.art`
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		callbackErr error
+		expectedRet []structs.CatalogExportItem
+		expectedErr error
+	}{
+		{
+			name: "streams products and variants as offers",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(selectOffers).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(id, nil, r.Articule, r.Articule, r.Name, r.Description, r.Price, r.Category, r.IdCategory,
+							"", 0, 12, r.Brand, r.PicLink, "{products/tm/front.jpg,products/tm/back.jpg}").
+						AddRow(id, id_variant, "LOR-TM-001-N2", r.Articule, r.Name, r.Description, 2700.0, r.Category, r.IdCategory,
+							"N2", 30, 0, r.Brand, r.PicLink, "{products/tm/front.jpg,products/tm/back.jpg}"))
+			},
+			expectedRet: []structs.CatalogExportItem{
+				{
+					Id:              id,
+					Articule:        r.Articule,
+					ProductArticule: r.Articule,
+					Name:            r.Name,
+					Description:     r.Description,
+					Price:           r.Price,
+					Category:        r.Category,
+					IdCategory:      r.IdCategory,
+					Available:       12,
+					Brand:           r.Brand,
+					PicLink:         r.PicLink,
+					Images:          []string{"products/tm/front.jpg", "products/tm/back.jpg"},
+				},
+				{
+					Id:              id,
+					IdVariant:       id_variant,
+					Articule:        "LOR-TM-001-N2",
+					ProductArticule: r.Articule,
+					Name:            r.Name,
+					Description:     r.Description,
+					Price:           2700,
+					Category:        r.Category,
+					IdCategory:      r.IdCategory,
+					Shade:           "N2",
+					VolumeMl:        30,
+					Available:       0,
+					Brand:           r.Brand,
+					PicLink:         r.PicLink,
+					Images:          []string{"products/tm/front.jpg", "products/tm/back.jpg"},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "callback error stops the export",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(selectOffers).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(id, nil, r.Articule, r.Articule, r.Name, r.Description, r.Price, r.Category, r.IdCategory,
+							"", 0, r.Amount, r.Brand, r.PicLink, "{}"))
+			},
+			callbackErr: errTest,
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(selectOffers).
+					WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			var ret []structs.CatalogExportItem
+			err := fixture.repo.ExportProducts(fixture.ctx, func(p structs.CatalogExportItem) error {
+				if tt.callbackErr != nil {
+					return tt.callbackErr
+				}
+				ret = append(ret, p)
+				return nil
+			})
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
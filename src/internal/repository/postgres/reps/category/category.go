@@ -0,0 +1,131 @@
+package category_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const categoryColumns = `id, id_parent, name, slug, sort_order`
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (rep *Repository) Create(ctx context.Context, c structs.Category) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := rep.db.GetContext(ctx, &id,
+		`insert into category (id_parent, name, slug, sort_order) values ($1, $2, $3, $4) returning id`,
+		rep_structs.NullId(c.IdParent), c.Name, c.Slug, c.SortOrder)
+	if err != nil {
+		return uuid.Nil, writeError(err)
+	}
+	return id, nil
+}
+
+func (rep *Repository) GetById(ctx context.Context, id uuid.UUID) (structs.Category, error) {
+	var c rep_structs.Category
+	err := rep.db.GetContext(ctx, &c, `select `+categoryColumns+` from category where id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.Category{}, structs.ErrCategoryNotFound
+	}
+	if err != nil {
+		return structs.Category{}, fmt.Errorf("failed to get category: %w", err)
+	}
+	return toCategory(c), nil
+}
+
+func (rep *Repository) GetBySlug(ctx context.Context, slug string) (structs.Category, error) {
+	var c rep_structs.Category
+	err := rep.db.GetContext(ctx, &c, `select `+categoryColumns+` from category where slug = $1`, slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.Category{}, structs.ErrCategoryNotFound
+	}
+	if err != nil {
+		return structs.Category{}, fmt.Errorf("failed to get category: %w", err)
+	}
+	return toCategory(c), nil
+}
+
+func (rep *Repository) GetAll(ctx context.Context) ([]structs.Category, error) {
+	var cs []rep_structs.Category
+	err := rep.db.SelectContext(ctx, &cs, `select `+categoryColumns+` from category order by sort_order, name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	res := make([]structs.Category, len(cs))
+	for i, c := range cs {
+		res[i] = toCategory(c)
+	}
+	return res, nil
+}
+
+func (rep *Repository) Update(ctx context.Context, c structs.Category) error {
+	result, err := rep.db.ExecContext(ctx,
+		`update category set id_parent = $1, name = $2, slug = $3, sort_order = $4 where id = $5`,
+		rep_structs.NullId(c.IdParent), c.Name, c.Slug, c.SortOrder, c.Id)
+	if err != nil {
+		return writeError(err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// Delete removes a leaf category without products. Subcategories and
+// products reference the category with "on delete restrict", so such a
+// deletion is reported as ErrCategoryInUse.
+func (rep *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx, `delete from category where id = $1`, id)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return structs.ErrCategoryInUse
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func writeError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return structs.ErrDuplicateSlug
+		case "23503":
+			return structs.ErrCategoryNotFound
+		}
+	}
+	return fmt.Errorf("failed to save category: %w", err)
+}
+
+func toCategory(c rep_structs.Category) structs.Category {
+	return structs.Category{
+		Id:        c.Id,
+		IdParent:  c.IdParent.UUID,
+		Name:      c.Name,
+		Slug:      c.Slug,
+		SortOrder: c.SortOrder,
+	}
+}
@@ -0,0 +1,77 @@
+package category_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var categoryRowColumns = []string{"id", "id_parent", "name", "slug", "sort_order"}
+
+type TestFixture struct {
+	t        *testing.T
+	db       *sql.DB
+	sqlxDB   *sqlx.DB
+	mock     sqlmock.Sqlmock
+	repo     *Repository
+	ctx      context.Context
+	root     structs.Category
+	category structs.Category
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	root := structs.Category{Id: structs.GenId(), Name: "Уход", Slug: "skincare", SortOrder: 1}
+	category := structs.Category{Id: structs.GenId(), IdParent: root.Id, Name: "Лицо", Slug: "skincare-face", SortOrder: 2}
+
+	return &TestFixture{
+		t:        t,
+		db:       db,
+		sqlxDB:   sqlxDB,
+		mock:     mock,
+		repo:     New(sqlxDB),
+		ctx:      context.Background(),
+		root:     root,
+		category: category,
+	}
+}
+
+// categoryRows returns root categories with a NULL id_parent, as the
+// database does.
+func (f *TestFixture) categoryRows(cs ...structs.Category) *sqlmock.Rows {
+	rows := sqlmock.NewRows(categoryRowColumns)
+	for _, c := range cs {
+		var parent any
+		if c.IdParent != uuid.Nil {
+			parent = c.IdParent
+		}
+		rows.AddRow(c.Id, parent, c.Name, c.Slug, c.SortOrder)
+	}
+	return rows
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
@@ -0,0 +1,17 @@
+package category_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type CategoryRepositoryInterface interface {
+	Create(ctx context.Context, c structs.Category) (uuid.UUID, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.Category, error)
+	GetBySlug(ctx context.Context, slug string) (structs.Category, error)
+	GetAll(ctx context.Context) ([]structs.Category, error)
+	Update(ctx context.Context, c structs.Category) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
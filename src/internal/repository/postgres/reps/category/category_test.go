@@ -0,0 +1,261 @@
+package category_rep
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	c := fixture.category
+
+	expectInsert := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into category \(id_parent, name, slug, sort_order\)`).
+			WithArgs(c.IdParent, c.Name, c.Slug, c.SortOrder)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "successful create",
+			setupMock: func() {
+				expectInsert().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(c.Id))
+			},
+			expectedRet: c.Id,
+			expectedErr: nil,
+		},
+		{
+			name: "duplicate slug",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23505"})
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDuplicateSlug,
+		},
+		{
+			name: "unknown parent",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23503"})
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrCategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.Create(fixture.ctx, c)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetBySlug_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet structs.Category
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from category where slug = \$1`).
+					WithArgs(fixture.category.Slug).
+					WillReturnRows(fixture.categoryRows(fixture.category))
+			},
+			expectedRet: fixture.category,
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from category where slug = \$1`).
+					WithArgs(fixture.category.Slug).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedRet: structs.Category{},
+			expectedErr: structs.ErrCategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetBySlug(fixture.ctx, fixture.category.Slug)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetAll_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.Category
+		expectedErr error
+	}{
+		{
+			name: "root and child",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from category order by sort_order, name`).
+					WillReturnRows(fixture.categoryRows(fixture.root, fixture.category))
+			},
+			expectedRet: []structs.Category{fixture.root, fixture.category},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from category order by sort_order, name`).
+					WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetAll(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestUpdate_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	c := fixture.category
+
+	expectUpdate := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`update category set id_parent = \$1, name = \$2, slug = \$3, sort_order = \$4 where id = \$5`).
+			WithArgs(c.IdParent, c.Name, c.Slug, c.SortOrder, c.Id)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful update",
+			setupMock: func() {
+				expectUpdate().WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				expectUpdate().WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrCategoryNotFound,
+		},
+		{
+			name: "duplicate slug",
+			setupMock: func() {
+				expectUpdate().WillReturnError(&pq.Error{Code: "23505"})
+			},
+			expectedErr: structs.ErrDuplicateSlug,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Update(fixture.ctx, c)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDelete_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from category where id = \$1`).
+					WithArgs(fixture.category.Id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "category in use",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from category where id = \$1`).
+					WithArgs(fixture.category.Id).
+					WillReturnError(&pq.Error{Code: "23503"})
+			},
+			expectedErr: structs.ErrCategoryInUse,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from category where id = \$1`).
+					WithArgs(fixture.category.Id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrCategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Delete(fixture.ctx, fixture.category.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
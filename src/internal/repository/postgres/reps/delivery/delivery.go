@@ -0,0 +1,238 @@
+package delivery_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const columns = `id, kind, name, free_from, active`
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (rep *Repository) Create(ctx context.Context, m structs.DeliveryMethod) (uuid.UUID, error) {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	var id uuid.UUID
+	err = tx.GetContext(ctx, &id, `
+		insert into delivery_method (kind, name, free_from, active) values ($1, $2, $3, $4)
+		returning id`,
+		m.Kind, m.Name, m.FreeFrom, m.Active)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create delivery method: %w", err)
+	}
+	if err := insertRates(ctx, tx, id, m.Rates); err != nil {
+		return uuid.Nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (rep *Repository) GetAll(ctx context.Context) ([]structs.DeliveryMethod, error) {
+	return rep.list(ctx, `select `+columns+` from delivery_method order by active desc, name`)
+}
+
+func (rep *Repository) GetActive(ctx context.Context) ([]structs.DeliveryMethod, error) {
+	return rep.list(ctx, `select `+columns+` from delivery_method where active order by name`)
+}
+
+func (rep *Repository) GetById(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error) {
+	var m rep_structs.DeliveryMethod
+	err := rep.db.GetContext(ctx, &m, `select `+columns+` from delivery_method where id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.DeliveryMethod{}, structs.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return structs.DeliveryMethod{}, fmt.Errorf("failed to get delivery method: %w", err)
+	}
+	res, err := rep.withRates(ctx, []rep_structs.DeliveryMethod{m})
+	if err != nil {
+		return structs.DeliveryMethod{}, err
+	}
+	return res[0], nil
+}
+
+func (rep *Repository) list(ctx context.Context, query string) ([]structs.DeliveryMethod, error) {
+	var ms []rep_structs.DeliveryMethod
+	if err := rep.db.SelectContext(ctx, &ms, query); err != nil {
+		return nil, fmt.Errorf("failed to get delivery methods: %w", err)
+	}
+	return rep.withRates(ctx, ms)
+}
+
+// withRates loads the rates of all the methods at once.
+func (rep *Repository) withRates(ctx context.Context, ms []rep_structs.DeliveryMethod) ([]structs.DeliveryMethod, error) {
+	if len(ms) == 0 {
+		return []structs.DeliveryMethod{}, nil
+	}
+	ids := make([]uuid.UUID, len(ms))
+	for i, m := range ms {
+		ids[i] = m.Id
+	}
+
+	var rates []rep_structs.DeliveryRate
+	err := rep.db.SelectContext(ctx, &rates, `
+		select id_delivery, zone, max_weight, min_total, cost, per_kg
+		from delivery_rate where id_delivery = any($1)
+		order by zone, max_weight, min_total`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivery rates: %w", err)
+	}
+	byMethod := make(map[uuid.UUID][]structs.DeliveryRate)
+	for _, r := range rates {
+		byMethod[r.IdDelivery] = append(byMethod[r.IdDelivery], structs.DeliveryRate{
+			Zone:      r.Zone,
+			MaxWeight: r.MaxWeight,
+			MinTotal:  r.MinTotal,
+			Cost:      r.Cost,
+			PerKg:     r.PerKg,
+		})
+	}
+
+	res := make([]structs.DeliveryMethod, len(ms))
+	for i, m := range ms {
+		res[i] = structs.DeliveryMethod{
+			Id:       m.Id,
+			Kind:     structs.DeliveryKind(m.Kind),
+			Name:     m.Name,
+			FreeFrom: m.FreeFrom,
+			Active:   m.Active,
+			Rates:    byMethod[m.Id],
+		}
+	}
+	return res, nil
+}
+
+// SetRates replaces the rates under a lock of the method row, so that a
+// checkout reads either the old rates or the new ones.
+func (rep *Repository) SetRates(ctx context.Context, id uuid.UUID, rates []structs.DeliveryRate) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var locked uuid.UUID
+	err = tx.GetContext(ctx, &locked, `select id from delivery_method where id = $1 for update`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock delivery method: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `delete from delivery_rate where id_delivery = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete delivery rates: %w", err)
+	}
+	if err := insertRates(ctx, tx, id, rates); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertRates(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, rates []structs.DeliveryRate) error {
+	for _, r := range rates {
+		_, err := tx.ExecContext(ctx, `
+			insert into delivery_rate (id_delivery, zone, max_weight, min_total, cost, per_kg)
+			values ($1, $2, $3, $4, $5, $6)`,
+			id, r.Zone, r.MaxWeight, r.MinTotal, r.Cost, r.PerKg)
+		if err != nil {
+			return fmt.Errorf("failed to add delivery rate: %w", err)
+		}
+	}
+	return nil
+}
+
+func (rep *Repository) Deactivate(ctx context.Context, id uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx, `update delivery_method set active = false where id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate delivery method: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrDeliveryNotFound
+	}
+	return nil
+}
+
+func (rep *Repository) CreateSlot(ctx context.Context, slot structs.DeliverySlot) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := rep.db.GetContext(ctx, &id, `
+		insert into delivery_slot (id_delivery, starts_at, ends_at, capacity) values ($1, $2, $3, $4)
+		returning id`,
+		slot.IdDelivery, slot.StartsAt, slot.EndsAt, slot.Capacity)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == "fk_delivery_slot_method" {
+		return uuid.Nil, structs.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create delivery slot: %w", err)
+	}
+	return id, nil
+}
+
+// GetSlots returns the slots starting in [from, to) with the places taken
+// by orders that were not cancelled or invalidated.
+func (rep *Repository) GetSlots(ctx context.Context, id_delivery uuid.UUID, from time.Time, to time.Time) ([]structs.DeliverySlot, error) {
+	var ss []rep_structs.DeliverySlot
+	err := rep.db.SelectContext(ctx, &ss, `
+		select s.id, s.id_delivery, s.starts_at, s.ends_at, s.capacity,
+			(select count(*) from "order" o where o.id_slot = s.id and o.status not in ($4, $5)) as taken
+		from delivery_slot s
+		where s.id_delivery = $1 and s.starts_at >= $2 and s.starts_at < $3
+		order by s.starts_at`,
+		id_delivery, from, to, structs.OrderInvalid, structs.OrderCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivery slots: %w", err)
+	}
+
+	res := make([]structs.DeliverySlot, len(ss))
+	for i, s := range ss {
+		res[i] = structs.DeliverySlot{
+			Id:         s.Id,
+			IdDelivery: s.IdDelivery,
+			StartsAt:   s.StartsAt,
+			EndsAt:     s.EndsAt,
+			Capacity:   s.Capacity,
+			Taken:      s.Taken,
+		}
+	}
+	return res, nil
+}
+
+func (rep *Repository) DeleteSlot(ctx context.Context, id_delivery uuid.UUID, id uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx,
+		`delete from delivery_slot where id = $1 and id_delivery = $2`, id, id_delivery)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == "fk_order_slot" {
+		return structs.ErrSlotInUse
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete delivery slot: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrSlotNotFound
+	}
+	return nil
+}
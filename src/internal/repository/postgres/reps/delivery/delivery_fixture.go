@@ -0,0 +1,97 @@
+package delivery_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var (
+	methodColumns = []string{"id", "kind", "name", "free_from", "active"}
+	rateColumns   = []string{"id_delivery", "zone", "max_weight", "min_total", "cost", "per_kg"}
+	slotColumns   = []string{"id", "id_delivery", "starts_at", "ends_at", "capacity", "taken"}
+)
+
+type TestFixture struct {
+	t      *testing.T
+	db     *sql.DB
+	sqlxDB *sqlx.DB
+	mock   sqlmock.Sqlmock
+	repo   *Repository
+	ctx    context.Context
+	method structs.DeliveryMethod
+	slot   structs.DeliverySlot
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	id := structs.GenId()
+	start := time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC)
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		method: structs.DeliveryMethod{
+			Id:       id,
+			Kind:     structs.DeliveryCourier,
+			Name:     "Курьер",
+			FreeFrom: 5000,
+			Active:   true,
+			Rates: []structs.DeliveryRate{
+				{Zone: "moscow", MaxWeight: 10000, Cost: 350},
+				{Zone: "moscow oblast", MaxWeight: 10000, Cost: 500, PerKg: 30},
+			},
+		},
+		slot: structs.DeliverySlot{
+			Id:         structs.GenId(),
+			IdDelivery: id,
+			StartsAt:   start,
+			EndsAt:     start.Add(3 * time.Hour),
+			Capacity:   10,
+			Taken:      4,
+		},
+	}
+}
+
+func (f *TestFixture) methodRows() *sqlmock.Rows {
+	m := f.method
+	return sqlmock.NewRows(methodColumns).AddRow(m.Id, m.Kind, m.Name, m.FreeFrom, m.Active)
+}
+
+func (f *TestFixture) rateRows() *sqlmock.Rows {
+	rows := sqlmock.NewRows(rateColumns)
+	for _, r := range f.method.Rates {
+		rows.AddRow(f.method.Id, r.Zone, r.MaxWeight, r.MinTotal, r.Cost, r.PerKg)
+	}
+	return rows
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
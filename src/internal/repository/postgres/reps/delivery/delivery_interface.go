@@ -0,0 +1,21 @@
+package delivery_rep
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type DeliveryRepositoryInterface interface {
+	Create(ctx context.Context, m structs.DeliveryMethod) (uuid.UUID, error)
+	GetAll(ctx context.Context) ([]structs.DeliveryMethod, error)
+	GetActive(ctx context.Context) ([]structs.DeliveryMethod, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error)
+	SetRates(ctx context.Context, id uuid.UUID, rates []structs.DeliveryRate) error
+	Deactivate(ctx context.Context, id uuid.UUID) error
+	CreateSlot(ctx context.Context, slot structs.DeliverySlot) (uuid.UUID, error)
+	GetSlots(ctx context.Context, id_delivery uuid.UUID, from time.Time, to time.Time) ([]structs.DeliverySlot, error)
+	DeleteSlot(ctx context.Context, id_delivery uuid.UUID, id uuid.UUID) error
+}
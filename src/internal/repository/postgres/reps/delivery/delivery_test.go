@@ -0,0 +1,430 @@
+package delivery_rep
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	m := fixture.method
+
+	expectInsert := func() *sqlmock.ExpectedQuery {
+		fixture.mock.ExpectBegin()
+		return fixture.mock.ExpectQuery(`insert into delivery_method \(kind, name, free_from, active\) .* returning id`).
+			WithArgs(m.Kind, m.Name, m.FreeFrom, m.Active)
+	}
+	expectRate := func(r structs.DeliveryRate) *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`insert into delivery_rate`).
+			WithArgs(m.Id, r.Zone, r.MaxWeight, r.MinTotal, r.Cost, r.PerKg)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "successful creation",
+			setupMock: func() {
+				expectInsert().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(m.Id))
+				expectRate(m.Rates[0]).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRate(m.Rates[1]).WillReturnResult(sqlmock.NewResult(1, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedRet: m.Id,
+			expectedErr: nil,
+		},
+		{
+			name: "rate insert fails",
+			setupMock: func() {
+				expectInsert().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(m.Id))
+				expectRate(m.Rates[0]).WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: errTest,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectInsert().WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.Create(fixture.ctx, m)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetById_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	m := fixture.method
+
+	expectMethod := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`select id, kind, name, free_from, active from delivery_method where id = \$1`).
+			WithArgs(m.Id)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    structs.DeliveryMethod
+		expectedErr error
+	}{
+		{
+			name: "method with rates",
+			setupMock: func() {
+				expectMethod().WillReturnRows(fixture.methodRows())
+				fixture.mock.ExpectQuery(`select id_delivery, zone, .* from delivery_rate where id_delivery = any\(\$1\)`).
+					WithArgs(pq.Array([]uuid.UUID{m.Id})).
+					WillReturnRows(fixture.rateRows())
+			},
+			expected:    m,
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				expectMethod().WillReturnError(sql.ErrNoRows)
+			},
+			expected:    structs.DeliveryMethod{},
+			expectedErr: structs.ErrDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetById(fixture.ctx, m.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetActive_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []structs.DeliveryMethod
+		expectedErr error
+	}{
+		{
+			name: "active methods",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from delivery_method where active order by name`).
+					WillReturnRows(fixture.methodRows())
+				fixture.mock.ExpectQuery(`from delivery_rate where id_delivery = any\(\$1\)`).
+					WillReturnRows(fixture.rateRows())
+			},
+			expected:    []structs.DeliveryMethod{fixture.method},
+			expectedErr: nil,
+		},
+		{
+			name: "no methods",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from delivery_method where active order by name`).
+					WillReturnRows(sqlmock.NewRows(methodColumns))
+			},
+			expected:    []structs.DeliveryMethod{},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from delivery_method where active order by name`).
+					WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetActive(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSetRates_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	m := fixture.method
+	rate := structs.DeliveryRate{Zone: "moscow", Cost: 300}
+
+	expectLock := func() *sqlmock.ExpectedQuery {
+		fixture.mock.ExpectBegin()
+		return fixture.mock.ExpectQuery(`select id from delivery_method where id = \$1 for update`).WithArgs(m.Id)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "rates replaced",
+			setupMock: func() {
+				expectLock().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(m.Id))
+				fixture.mock.ExpectExec(`delete from delivery_rate where id_delivery = \$1`).
+					WithArgs(m.Id).WillReturnResult(sqlmock.NewResult(0, 2))
+				fixture.mock.ExpectExec(`insert into delivery_rate`).
+					WithArgs(m.Id, rate.Zone, rate.MaxWeight, rate.MinTotal, rate.Cost, rate.PerKg).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "method not found",
+			setupMock: func() {
+				expectLock().WillReturnError(sql.ErrNoRows)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.SetRates(fixture.ctx, m.Id, []structs.DeliveryRate{rate})
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDeactivate_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id := fixture.method.Id
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "deactivated",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`update delivery_method set active = false where id = \$1`).
+					WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`update delivery_method set active = false where id = \$1`).
+					WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Deactivate(fixture.ctx, id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCreateSlot_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	s := fixture.slot
+
+	expectInsert := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into delivery_slot \(id_delivery, starts_at, ends_at, capacity\)`).
+			WithArgs(s.IdDelivery, s.StartsAt, s.EndsAt, s.Capacity)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "successful creation",
+			setupMock: func() {
+				expectInsert().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(s.Id))
+			},
+			expectedRet: s.Id,
+			expectedErr: nil,
+		},
+		{
+			name: "unknown method",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_delivery_slot_method"})
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.CreateSlot(fixture.ctx, s)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetSlots_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	s := fixture.slot
+	from := s.StartsAt.Truncate(24 * time.Hour)
+	to := from.AddDate(0, 0, 7)
+
+	expectSelect := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`from delivery_slot s\s+where s.id_delivery = \$1 and s.starts_at >= \$2 and s.starts_at < \$3`).
+			WithArgs(s.IdDelivery, from, to, structs.OrderInvalid, structs.OrderCancelled)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []structs.DeliverySlot
+		expectedErr error
+	}{
+		{
+			name: "slots with places taken",
+			setupMock: func() {
+				expectSelect().WillReturnRows(sqlmock.NewRows(slotColumns).
+					AddRow(s.Id, s.IdDelivery, s.StartsAt, s.EndsAt, s.Capacity, s.Taken))
+			},
+			expected:    []structs.DeliverySlot{s},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectSelect().WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetSlots(fixture.ctx, s.IdDelivery, from, to)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDeleteSlot_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	s := fixture.slot
+
+	expectDelete := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`delete from delivery_slot where id = \$1 and id_delivery = \$2`).
+			WithArgs(s.Id, s.IdDelivery)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "deleted",
+			setupMock: func() {
+				expectDelete().WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				expectDelete().WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrSlotNotFound,
+		},
+		{
+			name: "orders placed in the slot",
+			setupMock: func() {
+				expectDelete().WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_order_slot"})
+			},
+			expectedErr: structs.ErrSlotInUse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.DeleteSlot(fixture.ctx, s.IdDelivery, s.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,102 @@
+package idempotency_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const recordColumns = `id_user, key, request_hash, status, content_type, body, expires_at`
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Claim inserts a pending record for the key, or takes over the record of
+// an expired key. When the key is held by a live record, that record is
+// returned and the flag is false.
+func (rep *Repository) Claim(ctx context.Context, rec structs.IdempotencyRecord, ttl time.Duration) (structs.IdempotencyRecord, bool, error) {
+	var r rep_structs.IdempotencyRecord
+	err := rep.db.GetContext(ctx, &r, `
+		insert into idempotency_key (id_user, key, request_hash, expires_at)
+		values ($1, $2, $3, localtimestamp + $4 * interval '1 second')
+		on conflict (id_user, key) do update
+		set request_hash = excluded.request_hash, status = null, content_type = null, body = null,
+			expires_at = excluded.expires_at
+		where idempotency_key.expires_at <= localtimestamp
+		returning `+recordColumns,
+		rec.IdUser, rec.Key, rec.RequestHash, ttl.Seconds())
+	if err == nil {
+		return toRecord(r), true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return structs.IdempotencyRecord{}, false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	err = rep.db.GetContext(ctx, &r, `select `+recordColumns+` from idempotency_key
+		where id_user = $1 and key = $2`, rec.IdUser, rec.Key)
+	if err != nil {
+		return structs.IdempotencyRecord{}, false, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return toRecord(r), false, nil
+}
+
+// Complete stores the response on the pending record of the key.
+func (rep *Repository) Complete(ctx context.Context, rec structs.IdempotencyRecord) error {
+	result, err := rep.db.ExecContext(ctx, `
+		update idempotency_key set status = $1, content_type = $2, body = $3
+		where id_user = $4 and key = $5 and status is null`,
+		rec.Status, rec.ContentType, rec.Body, rec.IdUser, rec.Key)
+	if err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return structs.ErrInvalidIdempotencyKey
+	}
+	return nil
+}
+
+// Delete drops the pending record of the key. Completed records are kept.
+func (rep *Repository) Delete(ctx context.Context, id_user uuid.UUID, key string) error {
+	_, err := rep.db.ExecContext(ctx, `delete from idempotency_key
+		where id_user = $1 and key = $2 and status is null`, id_user, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (rep *Repository) DeleteExpired(ctx context.Context) (int64, error) {
+	result, err := rep.db.ExecContext(ctx, `delete from idempotency_key where expires_at <= localtimestamp`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func toRecord(r rep_structs.IdempotencyRecord) structs.IdempotencyRecord {
+	return structs.IdempotencyRecord{
+		IdUser:      r.IdUser,
+		Key:         r.Key,
+		RequestHash: r.RequestHash,
+		Status:      int(r.Status.Int32),
+		ContentType: r.ContentType.String,
+		Body:        r.Body,
+		ExpiresAt:   r.ExpiresAt,
+	}
+}
@@ -0,0 +1,81 @@
+package idempotency_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var recordRowColumns = []string{"id_user", "key", "request_hash", "status", "content_type", "body", "expires_at"}
+
+type TestFixture struct {
+	t       *testing.T
+	db      *sql.DB
+	sqlxDB  *sqlx.DB
+	mock    sqlmock.Sqlmock
+	repo    *Repository
+	ctx     context.Context
+	ttl     time.Duration
+	pending structs.IdempotencyRecord
+	done    structs.IdempotencyRecord
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	pending := structs.IdempotencyRecord{
+		IdUser:      structs.GenId(),
+		Key:         "3f1c9a52-order",
+		RequestHash: "9b74c9897bac770ffc029102a200c5de",
+		ExpiresAt:   time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC),
+	}
+	done := pending
+	done.Status = 201
+	done.ContentType = "application/json; charset=utf-8"
+	done.Body = []byte(`{"message":"Order created"}`)
+
+	return &TestFixture{
+		t:       t,
+		db:      db,
+		sqlxDB:  sqlxDB,
+		mock:    mock,
+		repo:    New(sqlxDB),
+		ctx:     context.Background(),
+		ttl:     24 * time.Hour,
+		pending: pending,
+		done:    done,
+	}
+}
+
+func (f *TestFixture) recordRow(r structs.IdempotencyRecord) *sqlmock.Rows {
+	var status, contentType any
+	if r.Status != 0 {
+		status, contentType = r.Status, r.ContentType
+	}
+	return sqlmock.NewRows(recordRowColumns).
+		AddRow(r.IdUser, r.Key, r.RequestHash, status, contentType, r.Body, r.ExpiresAt)
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
@@ -0,0 +1,16 @@
+package idempotency_rep
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type IdempotencyRepositoryInterface interface {
+	Claim(ctx context.Context, rec structs.IdempotencyRecord, ttl time.Duration) (structs.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, rec structs.IdempotencyRecord) error
+	Delete(ctx context.Context, id_user uuid.UUID, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
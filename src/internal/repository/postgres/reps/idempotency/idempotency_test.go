@@ -0,0 +1,221 @@
+package idempotency_rep
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestClaim_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	rec := fixture.pending
+
+	expectClaim := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into idempotency_key \(id_user, key, request_hash, expires_at\) .* on conflict \(id_user, key\) do update .* where idempotency_key.expires_at <= localtimestamp returning`).
+			WithArgs(rec.IdUser, rec.Key, rec.RequestHash, fixture.ttl.Seconds())
+	}
+	expectGet := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`select id_user, key, request_hash, status, content_type, body, expires_at from idempotency_key where id_user = \$1 and key = \$2`).
+			WithArgs(rec.IdUser, rec.Key)
+	}
+
+	tests := []struct {
+		name            string
+		setupMock       func()
+		expectedRet     structs.IdempotencyRecord
+		expectedClaimed bool
+		expectedErr     error
+	}{
+		{
+			name: "new key",
+			setupMock: func() {
+				expectClaim().WillReturnRows(fixture.recordRow(rec))
+			},
+			expectedRet:     rec,
+			expectedClaimed: true,
+			expectedErr:     nil,
+		},
+		{
+			name: "live key returns stored record",
+			setupMock: func() {
+				expectClaim().WillReturnError(sql.ErrNoRows)
+				expectGet().WillReturnRows(fixture.recordRow(fixture.done))
+			},
+			expectedRet:     fixture.done,
+			expectedClaimed: false,
+			expectedErr:     nil,
+		},
+		{
+			name: "live key swept meanwhile",
+			setupMock: func() {
+				expectClaim().WillReturnError(sql.ErrNoRows)
+				expectGet().WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectClaim().WillReturnError(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, claimed, err := fixture.repo.Claim(fixture.ctx, rec, fixture.ttl)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			assert.Equal(t, tt.expectedClaimed, claimed)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestComplete_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	rec := fixture.done
+
+	expectUpdate := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`update idempotency_key set status = \$1, content_type = \$2, body = \$3 where id_user = \$4 and key = \$5 and status is null`).
+			WithArgs(rec.Status, rec.ContentType, rec.Body, rec.IdUser, rec.Key)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "response stored",
+			setupMock: func() {
+				expectUpdate().WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "no pending record",
+			setupMock: func() {
+				expectUpdate().WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrInvalidIdempotencyKey,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectUpdate().WillReturnError(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Complete(fixture.ctx, rec)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDelete_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	rec := fixture.pending
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "pending key deleted",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from idempotency_key where id_user = \$1 and key = \$2 and status is null`).
+					WithArgs(rec.IdUser, rec.Key).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from idempotency_key where id_user = \$1 and key = \$2 and status is null`).
+					WithArgs(rec.IdUser, rec.Key).
+					WillReturnError(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Delete(fixture.ctx, rec.IdUser, rec.Key)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDeleteExpired_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet int64
+		expectedErr error
+	}{
+		{
+			name: "successful sweep",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from idempotency_key where expires_at <= localtimestamp`).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+			},
+			expectedRet: 4,
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from idempotency_key where expires_at <= localtimestamp`).
+					WillReturnError(errTest)
+			},
+			expectedRet: 0,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.DeleteExpired(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
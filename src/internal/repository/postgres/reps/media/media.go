@@ -0,0 +1,194 @@
+package media_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const imageColumns = `id, id_product, position, is_primary, content_type, size, storage_key, created_at`
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create appends the images after the existing ones of the product. The
+// product row is locked so that concurrent uploads do not get the same
+// positions. A primary image replaces the previous one and its URL is copied
+// to product.pic_link.
+func (rep *Repository) Create(ctx context.Context, imgs []structs.ProductImage) ([]structs.ProductImage, error) {
+	if len(imgs) == 0 {
+		return imgs, nil
+	}
+	idProduct := imgs[0].IdProduct
+
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id uuid.UUID
+	err = tx.GetContext(ctx, &id, `select id from product where id = $1 for update`, idProduct)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, structs.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	var next int
+	err = tx.GetContext(ctx, &next,
+		`select coalesce(max(position) + 1, 0) from product_image where id_product = $1`, idProduct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image position: %w", err)
+	}
+
+	res := make([]structs.ProductImage, len(imgs))
+	for i, img := range imgs {
+		img.Position = next + i
+		if img.IsPrimary {
+			if err := setPrimary(ctx, tx, idProduct, img.Id, img.URL); err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = tx.NamedExecContext(ctx, `
+			insert into product_image (`+imageColumns+`)
+			values (:id, :id_product, :position, :is_primary, :content_type, :size, :storage_key, :created_at)`,
+			rep_structs.ProductImage{
+				Id:          img.Id,
+				IdProduct:   img.IdProduct,
+				Position:    img.Position,
+				IsPrimary:   img.IsPrimary,
+				ContentType: img.ContentType,
+				Size:        img.Size,
+				Key:         img.Key,
+				CreatedAt:   img.CreatedAt,
+			})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create image: %w", err)
+		}
+		res[i] = img
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (rep *Repository) GetById(ctx context.Context, id uuid.UUID) (structs.ProductImage, error) {
+	var img rep_structs.ProductImage
+	err := rep.db.GetContext(ctx, &img, `select `+imageColumns+` from product_image where id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.ProductImage{}, structs.ErrImageNotFound
+	}
+	if err != nil {
+		return structs.ProductImage{}, fmt.Errorf("failed to get image: %w", err)
+	}
+	return toImage(img), nil
+}
+
+func (rep *Repository) GetByProduct(ctx context.Context, idProduct uuid.UUID) ([]structs.ProductImage, error) {
+	var imgs []rep_structs.ProductImage
+	err := rep.db.SelectContext(ctx, &imgs,
+		`select `+imageColumns+` from product_image where id_product = $1 order by position`, idProduct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get images: %w", err)
+	}
+
+	res := make([]structs.ProductImage, len(imgs))
+	for i, img := range imgs {
+		res[i] = toImage(img)
+	}
+	return res, nil
+}
+
+// SetPrimary marks the image as primary and updates product.pic_link. A nil
+// id leaves the product without a primary image.
+func (rep *Repository) SetPrimary(ctx context.Context, idProduct uuid.UUID, id uuid.UUID, picLink string) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setPrimary(ctx, tx, idProduct, id, picLink); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (rep *Repository) Reorder(ctx context.Context, idProduct uuid.UUID, ids []uuid.UUID) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i, id := range ids {
+		_, err := tx.ExecContext(ctx,
+			`update product_image set position = $1 where id = $2 and id_product = $3`, i, id, idProduct)
+		if err != nil {
+			return fmt.Errorf("failed to reorder images: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (rep *Repository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx, `delete from product_image where id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrImageNotFound
+	}
+	return nil
+}
+
+func setPrimary(ctx context.Context, tx *sqlx.Tx, idProduct uuid.UUID, id uuid.UUID, picLink string) error {
+	// Cleared in a separate statement: the partial unique index on primary
+	// images is checked row by row.
+	_, err := tx.ExecContext(ctx,
+		`update product_image set is_primary = false where id_product = $1 and is_primary`, idProduct)
+	if err != nil {
+		return fmt.Errorf("failed to reset primary image: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`update product_image set is_primary = true where id = $1 and id_product = $2`, id, idProduct)
+	if err != nil {
+		return fmt.Errorf("failed to set primary image: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `update product set pic_link = $1 where id = $2`, picLink, idProduct)
+	if err != nil {
+		return fmt.Errorf("failed to update pic_link: %w", err)
+	}
+	return nil
+}
+
+func toImage(img rep_structs.ProductImage) structs.ProductImage {
+	return structs.ProductImage{
+		Id:          img.Id,
+		IdProduct:   img.IdProduct,
+		Position:    img.Position,
+		IsPrimary:   img.IsPrimary,
+		ContentType: img.ContentType,
+		Size:        img.Size,
+		Key:         img.Key,
+		CreatedAt:   img.CreatedAt,
+	}
+}
@@ -0,0 +1,80 @@
+package media_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var imageRowColumns = []string{"id", "id_product", "position", "is_primary", "content_type", "size", "storage_key", "created_at"}
+
+type TestFixture struct {
+	t      *testing.T
+	db     *sql.DB
+	sqlxDB *sqlx.DB
+	mock   sqlmock.Sqlmock
+	repo   *Repository
+	ctx    context.Context
+	image  structs.ProductImage
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	idProduct := structs.GenId()
+	id := structs.GenId()
+	image := structs.ProductImage{
+		Id:          id,
+		IdProduct:   idProduct,
+		Position:    0,
+		IsPrimary:   true,
+		ContentType: "image/jpeg",
+		Size:        2048,
+		Key:         "products/" + idProduct.String() + "/" + id.String() + ".jpg",
+		URL:         "/media/products/" + idProduct.String() + "/" + id.String() + ".jpg",
+		CreatedAt:   time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		image:  image,
+	}
+}
+
+func (f *TestFixture) imageRows(imgs ...structs.ProductImage) *sqlmock.Rows {
+	rows := sqlmock.NewRows(imageRowColumns)
+	for _, i := range imgs {
+		rows.AddRow(i.Id, i.IdProduct, i.Position, i.IsPrimary, i.ContentType, i.Size, i.Key, i.CreatedAt)
+	}
+	return rows
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
@@ -0,0 +1,17 @@
+package media_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type MediaRepositoryInterface interface {
+	Create(ctx context.Context, imgs []structs.ProductImage) ([]structs.ProductImage, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.ProductImage, error)
+	GetByProduct(ctx context.Context, idProduct uuid.UUID) ([]structs.ProductImage, error)
+	SetPrimary(ctx context.Context, idProduct uuid.UUID, id uuid.UUID, picLink string) error
+	Reorder(ctx context.Context, idProduct uuid.UUID, ids []uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
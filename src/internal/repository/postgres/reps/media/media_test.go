@@ -0,0 +1,349 @@
+package media_rep
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	img := fixture.image
+
+	expectInsert := func(position int) *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`insert into product_image`).
+			WithArgs(img.Id, img.IdProduct, position, img.IsPrimary, img.ContentType, img.Size, img.Key, img.CreatedAt)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedPos int
+		expectedErr error
+	}{
+		{
+			name: "primary image is appended and pic_link updated",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select id from product where id = \$1 for update`).
+					WithArgs(img.IdProduct).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(img.IdProduct))
+				fixture.mock.ExpectQuery(`select coalesce\(max\(position\) \+ 1, 0\) from product_image`).
+					WithArgs(img.IdProduct).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(2))
+				fixture.mock.ExpectExec(`update product_image set is_primary = false`).
+					WithArgs(img.IdProduct).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`update product_image set is_primary = true`).
+					WithArgs(img.Id, img.IdProduct).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				fixture.mock.ExpectExec(`update product set pic_link = \$1`).
+					WithArgs(img.URL, img.IdProduct).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectInsert(2).WillReturnResult(sqlmock.NewResult(1, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedPos: 2,
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select id from product where id = \$1 for update`).
+					WithArgs(img.IdProduct).
+					WillReturnError(sql.ErrNoRows)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrProductNotFound,
+		},
+		{
+			name: "insert error rolls back",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select id from product where id = \$1 for update`).
+					WithArgs(img.IdProduct).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(img.IdProduct))
+				fixture.mock.ExpectQuery(`select coalesce\(max\(position\) \+ 1, 0\) from product_image`).
+					WithArgs(img.IdProduct).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(0))
+				fixture.mock.ExpectExec(`update product_image set is_primary = false`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				fixture.mock.ExpectExec(`update product_image set is_primary = true`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				fixture.mock.ExpectExec(`update product set pic_link = \$1`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectInsert(0).WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.Create(fixture.ctx, []structs.ProductImage{img})
+
+			fixture.AssertError(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.Len(t, ret, 1)
+				assert.Equal(t, tt.expectedPos, ret[0].Position)
+			}
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetById_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	expected := fixture.image
+	expected.URL = ""
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet structs.ProductImage
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from product_image where id = \$1`).
+					WithArgs(fixture.image.Id).
+					WillReturnRows(fixture.imageRows(fixture.image))
+			},
+			expectedRet: expected,
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from product_image where id = \$1`).
+					WithArgs(fixture.image.Id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedRet: structs.ProductImage{},
+			expectedErr: structs.ErrImageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetById(fixture.ctx, fixture.image.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetByProduct_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	first := fixture.image
+	first.URL = ""
+	second := first
+	second.Id = uuid.New()
+	second.Position = 1
+	second.IsPrimary = false
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.ProductImage
+		expectedErr error
+	}{
+		{
+			name: "images in order",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from product_image where id_product = \$1 order by position`).
+					WithArgs(first.IdProduct).
+					WillReturnRows(fixture.imageRows(first, second))
+			},
+			expectedRet: []structs.ProductImage{first, second},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from product_image where id_product = \$1 order by position`).
+					WithArgs(first.IdProduct).
+					WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetByProduct(fixture.ctx, first.IdProduct)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSetPrimary_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	img := fixture.image
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful update",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update product_image set is_primary = false`).
+					WithArgs(img.IdProduct).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`update product_image set is_primary = true`).
+					WithArgs(img.Id, img.IdProduct).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`update product set pic_link = \$1`).
+					WithArgs(img.URL, img.IdProduct).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update product_image set is_primary = false`).
+					WithArgs(img.IdProduct).
+					WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.SetPrimary(fixture.ctx, img.IdProduct, img.Id, img.URL)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestReorder_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	idProduct := fixture.image.IdProduct
+	ids := []uuid.UUID{uuid.New(), fixture.image.Id}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful reorder",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				for i, id := range ids {
+					fixture.mock.ExpectExec(`update product_image set position = \$1`).
+						WithArgs(i, id, idProduct).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update product_image set position = \$1`).
+					WithArgs(0, ids[0], idProduct).
+					WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Reorder(fixture.ctx, idProduct, ids)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDelete_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from product_image where id = \$1`).
+					WithArgs(fixture.image.Id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from product_image where id = \$1`).
+					WithArgs(fixture.image.Id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrImageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Delete(fixture.ctx, fixture.image.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,416 @@
+package order_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+// The checkout steps run in the transaction InTx keeps in the context.
+type txKey struct{}
+
+var errNoTx = errors.New("checkout step called outside of a transaction")
+
+// The available stock is the stock not held by live reservations of other
+// users and not in expired lots, the price and weight of a variant fall
+// back to those of its product. Categories lists the category of the
+// product and its ancestors for the promotions.
+const (
+	productCategories = `array(
+			with recursive up as (
+				select c.id, c.id_parent from category c where c.id = p.id_category
+				union all
+				select c.id, c.id_parent from category c join up on c.id = up.id_parent
+			)
+			select id::text from up) as categories`
+	lockProductStock = `
+		select p.amount - coalesce((select sum(r.amount) from stock_reservation r
+			where r.id_product = p.id and r.id_variant is null and r.id_user <> $2
+			and r.expires_at > localtimestamp), 0)
+			- coalesce((select sum(l.amount) from stock_lot l
+			where l.id_product = p.id and l.id_variant is null and l.expires_at < current_date), 0)
+			as available, p.price, p.name, coalesce(p.art, '') as art, coalesce(b.name, '') as brand,
+			p.weight, p.id_brand, ` + productCategories + `
+		from product p left join brand b on b.id = p.id_brand
+		where p.id = $1 for update of p`
+	lockVariantStock = `
+		select v.amount - coalesce((select sum(r.amount) from stock_reservation r
+			where r.id_variant = v.id and r.id_user <> $3 and r.expires_at > localtimestamp), 0)
+			- coalesce((select sum(l.amount) from stock_lot l
+			where l.id_variant = v.id and l.expires_at < current_date), 0)
+			as available, coalesce(v.price, p.price) as price, p.name,
+			coalesce(v.art, p.art, '') as art, coalesce(b.name, '') as brand,
+			coalesce(v.weight, p.weight) as weight, p.id_brand, ` + productCategories + `
+		from product_variant v join product p on p.id = v.id_product
+		left join brand b on b.id = p.id_brand
+		where v.id = $1 and v.id_product = $2 for update of v`
+)
+
+// InTx runs fn in a transaction and commits it when fn returns nil.
+func (rep *Repository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func txFrom(ctx context.Context) (*sqlx.Tx, error) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	if !ok {
+		return nil, errNoTx
+	}
+	return tx, nil
+}
+
+func (rep *Repository) GetBasket(ctx context.Context, id_user uuid.UUID) ([]structs.BasketItem, error) {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []rep_structs.BasketItem
+	err = tx.SelectContext(ctx, &items, `
+		select bi.id, bi.id_product, bi.id_variant, bi.id_basket, bi.amount
+		from basket_item bi
+		join basket b on b.id = bi.id_basket
+		where b.id_user = $1
+		order by bi.id_product, bi.id_variant nulls first`, id_user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get basket: %w", err)
+	}
+
+	var res []structs.BasketItem
+	for _, v := range items {
+		res = append(res, structs.BasketItem{
+			Id:        v.Id,
+			IdProduct: v.IdProduct,
+			IdVariant: v.IdVariant.UUID,
+			IdBasket:  v.IdBasket,
+			Amount:    v.Amount,
+		})
+	}
+	return res, nil
+}
+
+// LockStock locks the stock row of the product, or of the variant when
+// id_variant is set, and returns the amount available to the user.
+func (rep *Repository) LockStock(ctx context.Context, id_user uuid.UUID, id_product uuid.UUID, id_variant uuid.UUID) (structs.StockLevel, error) {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return structs.StockLevel{}, err
+	}
+
+	var level struct {
+		Available  int            `db:"available"`
+		Price      float64        `db:"price"`
+		Name       string         `db:"name"`
+		Art        string         `db:"art"`
+		Brand      string         `db:"brand"`
+		Weight     int            `db:"weight"`
+		IdBrand    uuid.NullUUID  `db:"id_brand"`
+		Categories pq.StringArray `db:"categories"`
+	}
+	if id_variant != uuid.Nil {
+		err = tx.GetContext(ctx, &level, lockVariantStock, id_variant, id_product, id_user)
+	} else {
+		err = tx.GetContext(ctx, &level, lockProductStock, id_product, id_user)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		if id_variant != uuid.Nil {
+			return structs.StockLevel{}, structs.ErrVariantNotFound
+		}
+		return structs.StockLevel{}, structs.ErrProductNotFound
+	}
+	if err != nil {
+		return structs.StockLevel{}, fmt.Errorf("failed to lock stock: %w", err)
+	}
+	categories := make([]uuid.UUID, len(level.Categories))
+	for i, c := range level.Categories {
+		if categories[i], err = uuid.Parse(c); err != nil {
+			return structs.StockLevel{}, fmt.Errorf("failed to parse category id: %w", err)
+		}
+	}
+
+	return structs.StockLevel{
+		IdProduct:  id_product,
+		IdVariant:  id_variant,
+		IdBrand:    level.IdBrand.UUID,
+		Categories: categories,
+		Available:  level.Available,
+		Price:      level.Price,
+		Name:       level.Name,
+		Art:        level.Art,
+		Brand:      level.Brand,
+		Weight:     level.Weight,
+	}, nil
+}
+
+// LockLots locks the lots of the product or variant that hold stock and
+// have not expired, first expiring first.
+func (rep *Repository) LockLots(ctx context.Context, id_product uuid.UUID, id_variant uuid.UUID) ([]structs.StockLot, error) {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var lots []rep_structs.StockLot
+	err = tx.SelectContext(ctx, &lots, `
+		select id, id_product, id_variant, batch, expires_at, amount, created_at
+		from stock_lot
+		where id_product = $1 and id_variant is not distinct from $2
+		  and amount > 0 and expires_at >= current_date
+		order by expires_at, id
+		for update`, id_product, rep_structs.NullId(id_variant))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock lots: %w", err)
+	}
+
+	var res []structs.StockLot
+	for _, v := range lots {
+		res = append(res, structs.StockLot{
+			Id:        v.Id,
+			IdProduct: v.IdProduct,
+			IdVariant: v.IdVariant.UUID,
+			Batch:     v.Batch,
+			ExpiresAt: v.ExpiresAt,
+			Amount:    v.Amount,
+			CreatedAt: v.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+// GetDelivery returns the delivery method with its rates.
+func (rep *Repository) GetDelivery(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error) {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return structs.DeliveryMethod{}, err
+	}
+
+	var m rep_structs.DeliveryMethod
+	err = tx.GetContext(ctx, &m, `
+		select id, kind, name, free_from, active from delivery_method where id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.DeliveryMethod{}, structs.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return structs.DeliveryMethod{}, fmt.Errorf("failed to get delivery method: %w", err)
+	}
+
+	var rates []rep_structs.DeliveryRate
+	err = tx.SelectContext(ctx, &rates, `
+		select id_delivery, zone, max_weight, min_total, cost, per_kg
+		from delivery_rate where id_delivery = $1`, id)
+	if err != nil {
+		return structs.DeliveryMethod{}, fmt.Errorf("failed to get delivery rates: %w", err)
+	}
+
+	res := structs.DeliveryMethod{
+		Id:       m.Id,
+		Kind:     structs.DeliveryKind(m.Kind),
+		Name:     m.Name,
+		FreeFrom: m.FreeFrom,
+		Active:   m.Active,
+	}
+	for _, r := range rates {
+		res.Rates = append(res.Rates, structs.DeliveryRate{
+			Zone:      r.Zone,
+			MaxWeight: r.MaxWeight,
+			MinTotal:  r.MinTotal,
+			Cost:      r.Cost,
+			PerKg:     r.PerKg,
+		})
+	}
+	return res, nil
+}
+
+// LockSlot locks the delivery slot and counts the orders placed in it that
+// were not cancelled or invalidated. Orders taking the slot wait for the
+// lock, so they see each other.
+func (rep *Repository) LockSlot(ctx context.Context, id uuid.UUID) (structs.DeliverySlot, error) {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return structs.DeliverySlot{}, err
+	}
+
+	var s rep_structs.DeliverySlot
+	err = tx.GetContext(ctx, &s, `
+		select s.id, s.id_delivery, s.starts_at, s.ends_at, s.capacity,
+			(select count(*) from "order" o where o.id_slot = s.id and o.status not in ($2, $3)) as taken
+		from delivery_slot s
+		where s.id = $1 for update of s`,
+		id, structs.OrderInvalid, structs.OrderCancelled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.DeliverySlot{}, structs.ErrSlotNotFound
+	}
+	if err != nil {
+		return structs.DeliverySlot{}, fmt.Errorf("failed to lock delivery slot: %w", err)
+	}
+
+	return structs.DeliverySlot{
+		Id:         s.Id,
+		IdDelivery: s.IdDelivery,
+		StartsAt:   s.StartsAt,
+		EndsAt:     s.EndsAt,
+		Capacity:   s.Capacity,
+		Taken:      s.Taken,
+	}, nil
+}
+
+func (rep *Repository) CreateOrder(ctx context.Context, o structs.Order) (structs.Order, error) {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return structs.Order{}, err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		insert into "order" (id_user, address, status, id_delivery, zone, id_slot)
+		values ($1, $2, $3, $4, $5, $6)
+		returning id, date`,
+		o.IdUser, o.Address, o.Status, o.IdDelivery, o.Zone, rep_structs.NullId(o.IdSlot)).Scan(&o.Id, &o.Date)
+	if err != nil {
+		return structs.Order{}, fmt.Errorf("failed to create order: %w", err)
+	}
+	return o, nil
+}
+
+func (rep *Repository) AddItem(ctx context.Context, item structs.OrderItem) (uuid.UUID, error) {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+		insert into order_item (id_product, id_variant, id_order, amount, price, discount, name, art, brand)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		returning id`,
+		item.IdProduct, rep_structs.NullId(item.IdVariant), item.IdOrder, item.Amount,
+		item.Price, item.Discount, item.Name, item.Art, item.Brand).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to add order item: %w", err)
+	}
+	return id, nil
+}
+
+func (rep *Repository) AddItemLot(ctx context.Context, id_item uuid.UUID, lot structs.OrderItemLot) error {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		insert into order_item_lot (id_order_item, id_lot, amount) values ($1, $2, $3)`,
+		id_item, lot.IdLot, lot.Amount)
+	if err != nil {
+		return fmt.Errorf("failed to add order item lot: %w", err)
+	}
+	return nil
+}
+
+// AddStockMovement records the movement, the ledger triggers change the
+// stock of the product, variant and lot.
+func (rep *Repository) AddStockMovement(ctx context.Context, m structs.StockMovement) error {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		insert into stock_movement (id_product, id_variant, id_lot, kind, quantity, reason, id_actor, reference)
+		values ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		m.IdProduct, rep_structs.NullId(m.IdVariant), rep_structs.NullId(m.IdLot), m.Kind, m.Quantity,
+		m.Reason, rep_structs.NullId(m.IdActor), m.Reference)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Constraint {
+		case "product_amount_check", "product_variant_amount_check", "stock_lot_amount_check", "stock_lot_untracked_check":
+			return fmt.Errorf("%w: %s", structs.ErrInsufficientStock, pqErr.Message)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record stock movement: %w", err)
+	}
+	return nil
+}
+
+func (rep *Repository) AddDiscount(ctx context.Context, id_item uuid.UUID, id_promotion uuid.UUID, amount float64) error {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		insert into order_item_discount (id_order_item, id_promotion, amount) values ($1, $2, $3)`,
+		id_item, id_promotion, amount)
+	if err != nil {
+		return fmt.Errorf("failed to store discount: %w", err)
+	}
+	return nil
+}
+
+// Redeem records the use of the promotion under a row lock, so its limits
+// hold for concurrent orders.
+func (rep *Repository) Redeem(ctx context.Context, id_promotion uuid.UUID, id_user uuid.UUID, id_order uuid.UUID, amount float64) error {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return err
+	}
+	return redeem(ctx, tx, id_promotion, id_user, id_order, amount)
+}
+
+func (rep *Repository) SetPrice(ctx context.Context, id_order uuid.UUID, price float64, shipping float64) error {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `update "order" set price = $2, shipping_cost = $3 where id = $1`,
+		id_order, price, shipping)
+	if err != nil {
+		return fmt.Errorf("failed to set order price: %w", err)
+	}
+	return nil
+}
+
+func (rep *Repository) AddStatusChange(ctx context.Context, ch structs.OrderStatusChange) error {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return err
+	}
+	return addStatusChange(ctx, tx, ch)
+}
+
+// ClearBasket empties the basket of the user and releases the stock held
+// for it. The basket itself is made once at signup and stays for the next
+// orders.
+func (rep *Repository) ClearBasket(ctx context.Context, id_user uuid.UUID) error {
+	tx, err := txFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `delete from stock_reservation where id_user = $1`, id_user)
+	if err != nil {
+		return fmt.Errorf("failed to release reservations: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		delete from basket_item where id_basket in (select id from basket where id_user = $1)`, id_user)
+	if err != nil {
+		return fmt.Errorf("failed to clear basket: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,702 @@
+package order_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestInTx(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		fn          func(ctx context.Context) error
+		expectedErr error
+	}{
+		{
+			name: "commit when steps succeed",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update "order" set price = \$2, shipping_cost = \$3 where id = \$1`).
+					WithArgs(fixture.order.Id, 2500.0, 300.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			fn: func(ctx context.Context) error {
+				return fixture.repo.SetPrice(ctx, fixture.order.Id, 2500, 300)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "rollback when a step fails",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update "order" set price = \$2, shipping_cost = \$3 where id = \$1`).
+					WithArgs(fixture.order.Id, 2500.0, 300.0).
+					WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			fn: func(ctx context.Context) error {
+				return fixture.repo.SetPrice(ctx, fixture.order.Id, 2500, 300)
+			},
+			expectedErr: errors.New("failed to set order price: " + errTest.Error()),
+		},
+		{
+			name: "begin error",
+			setupMock: func() {
+				fixture.mock.ExpectBegin().WillReturnError(errTest)
+			},
+			fn:          func(ctx context.Context) error { return nil },
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.InTx(fixture.ctx, tt.fn)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCheckoutStepOutsideTx(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	_, err := fixture.repo.GetBasket(fixture.ctx, fixture.order.IdUser)
+
+	fixture.AssertError(err, errNoTx)
+	require.NoError(t, fixture.mock.ExpectationsWereMet())
+	fixture.Cleanup()
+}
+
+func TestGetBasket(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	id_basket := uuid.New()
+	expected := []structs.BasketItem{
+		{Id: uuid.New(), IdProduct: uuid.New(), IdBasket: id_basket, Amount: 2},
+		{Id: uuid.New(), IdProduct: uuid.New(), IdVariant: uuid.New(), IdBasket: id_basket, Amount: 1},
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []structs.BasketItem
+		expectedErr error
+	}{
+		{
+			name: "successful get basket",
+			setupMock: func() {
+				rows := sqlmock.NewRows([]string{"id", "id_product", "id_variant", "id_basket", "amount"}).
+					AddRow(expected[0].Id, expected[0].IdProduct, nil, id_basket, 2).
+					AddRow(expected[1].Id, expected[1].IdProduct, expected[1].IdVariant, id_basket, 1)
+				fixture.mock.ExpectQuery(`select bi.id, bi.id_product, bi.id_variant, bi.id_basket, bi.amount from basket_item bi join basket b on b.id = bi.id_basket where b.id_user = \$1 order by bi.id_product, bi.id_variant nulls first`).
+					WithArgs(fixture.order.IdUser).
+					WillReturnRows(rows)
+			},
+			expected:    expected,
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select bi.id, .* from basket_item bi`).
+					WithArgs(fixture.order.IdUser).
+					WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: errors.New("failed to get basket: " + errTest.Error()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture.mock.ExpectBegin()
+			tt.setupMock()
+			if tt.expectedErr != nil {
+				fixture.mock.ExpectRollback()
+			} else {
+				fixture.mock.ExpectCommit()
+			}
+
+			var items []structs.BasketItem
+			err := fixture.repo.InTx(fixture.ctx, func(ctx context.Context) error {
+				var err error
+				items, err = fixture.repo.GetBasket(ctx, fixture.order.IdUser)
+				return err
+			})
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, items)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+var levelColumns = []string{"available", "price", "name", "art", "brand", "weight", "id_brand", "categories"}
+
+func TestLockStock(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	id_product := uuid.New()
+	id_variant := uuid.New()
+	id_brand := uuid.New()
+	face, skin := uuid.New(), uuid.New()
+	categories := "{" + face.String() + "," + skin.String() + "}"
+
+	tests := []struct {
+		name        string
+		idVariant   uuid.UUID
+		setupMock   func()
+		expected    structs.StockLevel
+		expectedErr error
+	}{
+		{
+			name: "product stock",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select p.amount - coalesce\(\(select sum\(r.amount\) from stock_reservation r where r.id_product = p.id and r.id_variant is null and r.id_user <> \$2 .* as available, p.price, p.name, coalesce\(p.art, ''\) as art, coalesce\(b.name, ''\) as brand, p.weight, p.id_brand, array\( with recursive up as .* as categories from product p left join brand b on b.id = p.id_brand where p.id = \$1 for update of p`).
+					WithArgs(id_product, fixture.order.IdUser).
+					WillReturnRows(sqlmock.NewRows(levelColumns).AddRow(7, 1500.0, "Сыворотка", "SR-030", "Natura", 120, id_brand, categories))
+			},
+			expected: structs.StockLevel{
+				IdProduct: id_product, IdBrand: id_brand, Categories: []uuid.UUID{face, skin},
+				Available: 7, Price: 1500, Name: "Сыворотка", Art: "SR-030", Brand: "Natura", Weight: 120,
+			},
+			expectedErr: nil,
+		},
+		{
+			name:      "variant stock",
+			idVariant: id_variant,
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select v.amount - coalesce\(\(select sum\(r.amount\) from stock_reservation r where r.id_variant = v.id and r.id_user <> \$3 .* coalesce\(v.price, p.price\) as price, p.name, coalesce\(v.art, p.art, ''\) as art, coalesce\(b.name, ''\) as brand, coalesce\(v.weight, p.weight\) as weight, p.id_brand, array\(.* as categories from product_variant v join product p on p.id = v.id_product left join brand b on b.id = p.id_brand where v.id = \$1 and v.id_product = \$2 for update of v`).
+					WithArgs(id_variant, id_product, fixture.order.IdUser).
+					WillReturnRows(sqlmock.NewRows(levelColumns).AddRow(0, 1700.0, "Сыворотка", "SR-030-50", "", 80, nil, "{}"))
+			},
+			expected: structs.StockLevel{
+				IdProduct: id_product, IdVariant: id_variant, Categories: []uuid.UUID{},
+				Available: 0, Price: 1700, Name: "Сыворотка", Art: "SR-030-50", Weight: 80,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from product p left join brand b on b.id = p.id_brand where p.id = \$1 for update of p`).
+					WithArgs(id_product, fixture.order.IdUser).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expected:    structs.StockLevel{},
+			expectedErr: structs.ErrProductNotFound,
+		},
+		{
+			name:      "variant not found",
+			idVariant: id_variant,
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from product_variant v join product p`).
+					WithArgs(id_variant, id_product, fixture.order.IdUser).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expected:    structs.StockLevel{},
+			expectedErr: structs.ErrVariantNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture.mock.ExpectBegin()
+			tt.setupMock()
+			if tt.expectedErr != nil {
+				fixture.mock.ExpectRollback()
+			} else {
+				fixture.mock.ExpectCommit()
+			}
+
+			var level structs.StockLevel
+			err := fixture.repo.InTx(fixture.ctx, func(ctx context.Context) error {
+				var err error
+				level, err = fixture.repo.LockStock(ctx, fixture.order.IdUser, id_product, tt.idVariant)
+				return err
+			})
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, level)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestLockLots(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	id_product := uuid.New()
+	created := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	expected := []structs.StockLot{
+		{Id: uuid.New(), IdProduct: id_product, Batch: "A1", ExpiresAt: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), Amount: 3, CreatedAt: created},
+		{Id: uuid.New(), IdProduct: id_product, Batch: "B2", ExpiresAt: time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC), Amount: 5, CreatedAt: created},
+	}
+
+	fixture.mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "id_product", "id_variant", "batch", "expires_at", "amount", "created_at"})
+	for _, l := range expected {
+		rows.AddRow(l.Id, l.IdProduct, nil, l.Batch, l.ExpiresAt, l.Amount, l.CreatedAt)
+	}
+	fixture.mock.ExpectQuery(`select id, id_product, id_variant, batch, expires_at, amount, created_at from stock_lot where id_product = \$1 and id_variant is not distinct from \$2 and amount > 0 and expires_at >= current_date order by expires_at, id for update`).
+		WithArgs(id_product, uuid.NullUUID{}).
+		WillReturnRows(rows)
+	fixture.mock.ExpectCommit()
+
+	var lots []structs.StockLot
+	err := fixture.repo.InTx(fixture.ctx, func(ctx context.Context) error {
+		var err error
+		lots, err = fixture.repo.LockLots(ctx, id_product, uuid.Nil)
+		return err
+	})
+
+	fixture.AssertError(err, nil)
+	assert.Equal(t, expected, lots)
+	require.NoError(t, fixture.mock.ExpectationsWereMet())
+	fixture.Cleanup()
+}
+
+func TestGetDelivery(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	id := fixture.order.IdDelivery
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    structs.DeliveryMethod
+		expectedErr error
+	}{
+		{
+			name: "method with rates",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select id, kind, name, free_from, active from delivery_method where id = \$1`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "name", "free_from", "active"}).
+						AddRow(id, "post", "Почта", 5000.0, true))
+				fixture.mock.ExpectQuery(`select id_delivery, zone, max_weight, min_total, cost, per_kg from delivery_rate where id_delivery = \$1`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"id_delivery", "zone", "max_weight", "min_total", "cost", "per_kg"}).
+						AddRow(id, "moscow", 20000, 0.0, 250.0, 40.0))
+			},
+			expected: structs.DeliveryMethod{
+				Id: id, Kind: structs.DeliveryPost, Name: "Почта", FreeFrom: 5000, Active: true,
+				Rates: []structs.DeliveryRate{{Zone: "moscow", MaxWeight: 20000, Cost: 250, PerKg: 40}},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "method not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from delivery_method where id = \$1`).
+					WithArgs(id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expected:    structs.DeliveryMethod{},
+			expectedErr: structs.ErrDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture.mock.ExpectBegin()
+			tt.setupMock()
+			if tt.expectedErr != nil {
+				fixture.mock.ExpectRollback()
+			} else {
+				fixture.mock.ExpectCommit()
+			}
+
+			var m structs.DeliveryMethod
+			err := fixture.repo.InTx(fixture.ctx, func(ctx context.Context) error {
+				var err error
+				m, err = fixture.repo.GetDelivery(ctx, id)
+				return err
+			})
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, m)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestLockSlot(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	id := uuid.New()
+	starts := time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC)
+	ends := starts.Add(2 * time.Hour)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    structs.DeliverySlot
+		expectedErr error
+	}{
+		{
+			name: "slot with its orders counted",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select s.id, s.id_delivery, s.starts_at, s.ends_at, s.capacity, \(select count\(\*\) from "order" o where o.id_slot = s.id and o.status not in \(\$2, \$3\)\) as taken from delivery_slot s where s.id = \$1 for update of s`).
+					WithArgs(id, structs.OrderInvalid, structs.OrderCancelled).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_delivery", "starts_at", "ends_at", "capacity", "taken"}).
+						AddRow(id, fixture.order.IdDelivery, starts, ends, 10, 4))
+			},
+			expected: structs.DeliverySlot{
+				Id: id, IdDelivery: fixture.order.IdDelivery, StartsAt: starts, EndsAt: ends, Capacity: 10, Taken: 4,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "slot not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from delivery_slot s where s.id = \$1 for update of s`).
+					WithArgs(id, structs.OrderInvalid, structs.OrderCancelled).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expected:    structs.DeliverySlot{},
+			expectedErr: structs.ErrSlotNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture.mock.ExpectBegin()
+			tt.setupMock()
+			if tt.expectedErr != nil {
+				fixture.mock.ExpectRollback()
+			} else {
+				fixture.mock.ExpectCommit()
+			}
+
+			var slot structs.DeliverySlot
+			err := fixture.repo.InTx(fixture.ctx, func(ctx context.Context) error {
+				var err error
+				slot, err = fixture.repo.LockSlot(ctx, id)
+				return err
+			})
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, slot)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCreateOrder(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	id := uuid.New()
+	date := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	fixture.mock.ExpectBegin()
+	fixture.mock.ExpectQuery(`insert into "order" \(id_user, address, status, id_delivery, zone, id_slot\) values \(\$1, \$2, \$3, \$4, \$5, \$6\) returning id, date`).
+		WithArgs(fixture.order.IdUser, fixture.order.Address, fixture.order.Status, fixture.order.IdDelivery, fixture.order.Zone, uuid.NullUUID{}).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "date"}).AddRow(id, date))
+	fixture.mock.ExpectCommit()
+
+	var created structs.Order
+	err := fixture.repo.InTx(fixture.ctx, func(ctx context.Context) error {
+		var err error
+		created, err = fixture.repo.CreateOrder(ctx, fixture.order)
+		return err
+	})
+
+	expected := fixture.order
+	expected.Id = id
+	expected.Date = date
+	fixture.AssertError(err, nil)
+	assert.Equal(t, expected, created)
+	require.NoError(t, fixture.mock.ExpectationsWereMet())
+	fixture.Cleanup()
+}
+
+func TestAddStockMovement(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	m := structs.StockMovement{
+		IdProduct: uuid.New(),
+		IdLot:     uuid.New(),
+		Kind:      structs.StockSale,
+		Quantity:  -2,
+		Reason:    "заказ",
+		IdActor:   fixture.order.IdUser,
+		Reference: uuid.NewString(),
+	}
+	expectInsert := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`insert into stock_movement \(id_product, id_variant, id_lot, kind, quantity, reason, id_actor, reference\) values \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8\)`).
+			WithArgs(m.IdProduct, uuid.NullUUID{}, uuid.NullUUID{UUID: m.IdLot, Valid: true}, m.Kind, m.Quantity,
+				m.Reason, uuid.NullUUID{UUID: m.IdActor, Valid: true}, m.Reference)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful sale",
+			setupMock: func() {
+				expectInsert().WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "lot ran out",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23514", Constraint: "stock_lot_amount_check",
+					Message: "new row violates check constraint"})
+			},
+			expectedErr: errors.New(structs.ErrInsufficientStock.Error() + ": new row violates check constraint"),
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectInsert().WillReturnError(errTest)
+			},
+			expectedErr: errors.New("failed to record stock movement: " + errTest.Error()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture.mock.ExpectBegin()
+			tt.setupMock()
+			if tt.expectedErr != nil {
+				fixture.mock.ExpectRollback()
+			} else {
+				fixture.mock.ExpectCommit()
+			}
+
+			err := fixture.repo.InTx(fixture.ctx, func(ctx context.Context) error {
+				return fixture.repo.AddStockMovement(ctx, m)
+			})
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestRedeem(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	id_promotion := uuid.New()
+	id_order := uuid.New()
+	expectLock := func(usageLimit, perUserLimit int) {
+		fixture.mock.ExpectQuery(`select usage_limit, per_user_limit from promotion where id = \$1 for update`).
+			WithArgs(id_promotion).
+			WillReturnRows(sqlmock.NewRows([]string{"usage_limit", "per_user_limit"}).AddRow(usageLimit, perUserLimit))
+	}
+	expectCount := func(total, mine int) {
+		fixture.mock.ExpectQuery(`select count\(\*\), count\(\*\) filter \(where id_user = \$2\) from promotion_redemption`).
+			WithArgs(id_promotion, fixture.order.IdUser).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "count"}).AddRow(total, mine))
+	}
+	expectRedeem := func() {
+		fixture.mock.ExpectExec(`insert into promotion_redemption \(id_promotion, id_user, id_order, amount\)`).
+			WithArgs(id_promotion, fixture.order.IdUser, id_order, 300.0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "promotion without limits",
+			setupMock: func() {
+				expectLock(0, 0)
+				expectRedeem()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "promotion under its limits",
+			setupMock: func() {
+				expectLock(100, 1)
+				expectCount(10, 0)
+				expectRedeem()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "promotion limit reached",
+			setupMock: func() {
+				expectLock(0, 1)
+				expectCount(5, 1)
+			},
+			expectedErr: structs.ErrPromotionLimitReached,
+		},
+		{
+			name: "promotion not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select usage_limit, per_user_limit from promotion where id = \$1 for update`).
+					WithArgs(id_promotion).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: structs.ErrPromotionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture.mock.ExpectBegin()
+			tt.setupMock()
+			if tt.expectedErr != nil {
+				fixture.mock.ExpectRollback()
+			} else {
+				fixture.mock.ExpectCommit()
+			}
+
+			err := fixture.repo.InTx(fixture.ctx, func(ctx context.Context) error {
+				return fixture.repo.Redeem(ctx, id_promotion, fixture.order.IdUser, id_order, 300)
+			})
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCheckoutLines(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	id_order := uuid.New()
+	id_item := uuid.New()
+	id_variant := uuid.New()
+	id_promotion := uuid.New()
+	lot := structs.OrderItemLot{IdLot: uuid.New(), Amount: 2}
+	item := structs.OrderItem{
+		IdProduct: uuid.New(), IdVariant: id_variant, IdOrder: id_order, Amount: 3,
+		Price: 1200, Discount: 150, Name: "Тушь", Art: "MS-01-BK", Brand: "Natura",
+	}
+
+	fixture.mock.ExpectBegin()
+	fixture.mock.ExpectQuery(`insert into order_item \(id_product, id_variant, id_order, amount, price, discount, name, art, brand\) values \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9\) returning id`).
+		WithArgs(item.IdProduct, uuid.NullUUID{UUID: id_variant, Valid: true}, id_order, 3, 1200.0, 150.0, "Тушь", "MS-01-BK", "Natura").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id_item))
+	fixture.mock.ExpectExec(`insert into order_item_lot \(id_order_item, id_lot, amount\) values \(\$1, \$2, \$3\)`).
+		WithArgs(id_item, lot.IdLot, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	fixture.mock.ExpectExec(`insert into order_item_discount \(id_order_item, id_promotion, amount\) values \(\$1, \$2, \$3\)`).
+		WithArgs(id_item, id_promotion, 150.0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	fixture.mock.ExpectExec(`insert into order_status_history \(id_order, from_status, to_status, id_actor, role, comment\)`).
+		WithArgs(id_order, nil, structs.OrderNew, uuid.NullUUID{UUID: fixture.order.IdUser, Valid: true}, structs.OrderRoleCustomer, "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	fixture.mock.ExpectCommit()
+
+	err := fixture.repo.InTx(fixture.ctx, func(ctx context.Context) error {
+		got, err := fixture.repo.AddItem(ctx, item)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, id_item, got)
+		if err := fixture.repo.AddItemLot(ctx, got, lot); err != nil {
+			return err
+		}
+		if err := fixture.repo.AddDiscount(ctx, got, id_promotion, 150); err != nil {
+			return err
+		}
+		return fixture.repo.AddStatusChange(ctx, structs.OrderStatusChange{
+			IdOrder: id_order,
+			To:      structs.OrderNew,
+			IdActor: fixture.order.IdUser,
+			Role:    structs.OrderRoleCustomer,
+		})
+	})
+
+	fixture.AssertError(err, nil)
+	require.NoError(t, fixture.mock.ExpectationsWereMet())
+	fixture.Cleanup()
+}
+
+func TestClearBasket(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful clear",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from stock_reservation where id_user = \$1`).
+					WithArgs(fixture.order.IdUser).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				fixture.mock.ExpectExec(`delete from basket_item where id_basket in \(select id from basket where id_user = \$1\)`).
+					WithArgs(fixture.order.IdUser).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from stock_reservation where id_user = \$1`).
+					WithArgs(fixture.order.IdUser).
+					WillReturnError(errTest)
+			},
+			expectedErr: errors.New("failed to release reservations: " + errTest.Error()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture.mock.ExpectBegin()
+			tt.setupMock()
+			if tt.expectedErr != nil {
+				fixture.mock.ExpectRollback()
+			} else {
+				fixture.mock.ExpectCommit()
+			}
+
+			err := fixture.repo.InTx(fixture.ctx, func(ctx context.Context) error {
+				return fixture.repo.ClearBasket(ctx, fixture.order.IdUser)
+			})
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
@@ -2,6 +2,8 @@ package order_rep
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -18,101 +20,110 @@ func New(db *sqlx.DB) *Repository {
 	return &Repository{db: db}
 }
 
-func (rep *Repository) Create(ctx context.Context, o structs.Order) error {
-	var id uuid.UUID
-	ord := rep_structs.Order{
-		// Date:    o.Date,
-		IdUser:  o.IdUser,
-		Address: o.Address,
-		Status:  o.Status,
-		// Price:   o.Price,
-	}
-	err := rep.db.QueryRowContext(ctx, `
-		insert into "order" (id_user, address, status) 
-		values ($1, $2, $3) 
-		returning id`,
-		ord.IdUser, ord.Address, ord.Status).Scan(&id)
-	// if err != nil {
-	// 	return err
-	// }
-	// var basket_id uuid.UUID
-	// var items []rep_structs.BasketItem
-	// err = rep.db.GetContext(ctx, &basket_id, "select id from basket where id_user = $1", o.IdUser)
-	// if err != nil {
-	// 	return err
-	// }
-	// err = rep.db.SelectContext(ctx, &items, "select * from basket_item where id_basket = $1", basket_id)
-	// if err != nil {
-	// 	return err
-	// }
-	// for _, v := range items {
-	// 	var am int
-	// 	if err := rep.db.GetContext(ctx, &am, `select amount from product where id = $1`, v.IdProduct); err != nil {
-	// 		return err
-	// 	}
-	// 	if am < v.Amount {
-	// 		return fmt.Errorf(`not enouth products on warehouse`)
-	// 	}
-
-	// 	_, err := rep.db.ExecContext(ctx, `update product set amount = $1 where id = $2`, am - v.Amount,v.IdProduct)
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// }
-
-	// var sm float64
-	// err = rep.db.GetContext(ctx, &sm, `select sum(p.price * b.amount) as total_price from basket_item b
-	// 		join product p on b.id_product = p.id where b.id_basket = $1;`, basket_id)
-
-	// if err != nil {
-	// 	return err
-	// }
-
-	// _, err = rep.db.ExecContext(ctx, `update "order" set price = $1 where id = $2`, sm, id)
-	// if err != nil {
-	// 	return err
-	// }
-
-	// for _, item := range items {
-	// 	_, err := rep.db.ExecContext(ctx,
-	// 		"insert into order_item (id_product, id_order, amount) values ($1, $2, $3)",
-	// 		item.IdProduct, id, item.Amount)
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// }
-	return err
+func redeem(ctx context.Context, tx *sqlx.Tx, id_promotion, id_user, id_order uuid.UUID, amount float64) error {
+	var usageLimit, perUserLimit int
+	err := tx.QueryRowContext(ctx, `select usage_limit, per_user_limit from promotion where id = $1 for update`,
+		id_promotion).Scan(&usageLimit, &perUserLimit)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.ErrPromotionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock promotion: %w", err)
+	}
+
+	if usageLimit > 0 || perUserLimit > 0 {
+		var total, mine int
+		err = tx.QueryRowContext(ctx, `
+			select count(*), count(*) filter (where id_user = $2)
+			from promotion_redemption where id_promotion = $1`, id_promotion, id_user).Scan(&total, &mine)
+		if err != nil {
+			return fmt.Errorf("failed to count promotion redemptions: %w", err)
+		}
+		if (usageLimit > 0 && total >= usageLimit) || (perUserLimit > 0 && mine >= perUserLimit) {
+			return structs.ErrPromotionLimitReached
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`insert into promotion_redemption (id_promotion, id_user, id_order, amount) values ($1, $2, $3, $4)`,
+		id_promotion, id_user, id_order, amount)
+	if err != nil {
+		return fmt.Errorf("failed to redeem promotion: %w", err)
+	}
+	return nil
 }
 
 func (rep *Repository) GetById(ctx context.Context, id uuid.UUID) (structs.Order, error) {
 	var o rep_structs.Order
 	err := rep.db.GetContext(ctx, &o, "select * from \"order\" where id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.Order{}, structs.ErrOrderNotFound
+	}
 	if err != nil {
 		return structs.Order{}, fmt.Errorf("failed to get order: %w", err)
 	}
 	ord := structs.Order{
-		Date:    o.Date,
-		IdUser:  o.IdUser,
-		Address: o.Address,
-		Status:  o.Status,
-		Price:   o.Price,
+		Id:           o.Id,
+		Date:         o.Date,
+		IdUser:       o.IdUser,
+		Address:      o.Address,
+		Status:       structs.OrderStatus(o.Status),
+		Price:        o.Price,
+		IdDelivery:   o.IdDelivery.UUID,
+		Zone:         o.Zone,
+		IdSlot:       o.IdSlot.UUID,
+		ShippingCost: o.ShippingCost,
 	}
 	return ord, nil
 }
 
 func (rep *Repository) GetItems(ctx context.Context, id uuid.UUID) ([]structs.OrderItem, error) {
 	var items []rep_structs.OrderItem
-	err := rep.db.SelectContext(ctx, &items, "select * from order_item where id_order = $1", id)
+	err := rep.db.SelectContext(ctx, &items, `
+		select id, id_product, id_variant, id_order, amount, price, discount, name, art, brand
+		from order_item where id_order = $1`, id)
 	if err != nil {
 		return nil, err
 	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	var lots []rep_structs.OrderItemLot
+	err = rep.db.SelectContext(ctx, &lots, `
+		select il.id_order_item, il.id_lot, l.batch, l.expires_at, il.amount
+		from order_item_lot il
+		join order_item oi on oi.id = il.id_order_item
+		join stock_lot l on l.id = il.id_lot
+		where oi.id_order = $1
+		order by l.expires_at, l.batch`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order item lots: %w", err)
+	}
+	byItem := make(map[uuid.UUID][]structs.OrderItemLot)
+	for _, l := range lots {
+		byItem[l.IdOrderItem] = append(byItem[l.IdOrderItem], structs.OrderItemLot{
+			IdLot:     l.IdLot,
+			Batch:     l.Batch,
+			ExpiresAt: l.ExpiresAt,
+			Amount:    l.Amount,
+		})
+	}
+
 	var itms []structs.OrderItem
 	for _, v := range items {
 		itms = append(itms, structs.OrderItem{
 			Id:        v.Id,
-			IdProduct: v.IdProduct,
+			IdProduct: v.IdProduct.UUID,
+			IdVariant: v.IdVariant.UUID,
 			IdOrder:   v.IdOrder,
 			Amount:    v.Amount,
+			Price:     v.Price,
+			Discount:  v.Discount,
+			Name:      v.Name,
+			Art:       v.Art,
+			Brand:     v.Brand,
+			Lots:      byItem[v.Id],
 		})
 	}
 	return itms, nil
@@ -120,19 +131,23 @@ func (rep *Repository) GetItems(ctx context.Context, id uuid.UUID) ([]structs.Or
 
 func (rep *Repository) GetFreeOrders(ctx context.Context) ([]structs.Order, error) {
 	var orders []rep_structs.Order
-	err := rep.db.SelectContext(ctx, &orders, `select * from "order" where status = $1`, "непринятый")
+	err := rep.db.SelectContext(ctx, &orders, `select * from "order" where status = $1`, structs.OrderNew)
 	if err != nil {
 		return nil, err
 	}
 	var ords []structs.Order
 	for _, v := range orders {
 		ords = append(ords, structs.Order{
-			Id:      v.Id,
-			Date:    v.Date,
-			IdUser:  v.IdUser,
-			Address: v.Address,
-			Status:  v.Status,
-			Price:   v.Price,
+			Id:           v.Id,
+			Date:         v.Date,
+			IdUser:       v.IdUser,
+			Address:      v.Address,
+			Status:       structs.OrderStatus(v.Status),
+			Price:        v.Price,
+			IdDelivery:   v.IdDelivery.UUID,
+			Zone:         v.Zone,
+			IdSlot:       v.IdSlot.UUID,
+			ShippingCost: v.ShippingCost,
 		})
 	}
 	return ords, nil
@@ -147,12 +162,16 @@ func (rep *Repository) GetAllOrders(ctx context.Context) ([]structs.Order, error
 	var ords []structs.Order
 	for _, v := range orders {
 		ords = append(ords, structs.Order{
-			Id:      v.Id,
-			Date:    v.Date,
-			IdUser:  v.IdUser,
-			Address: v.Address,
-			Status:  v.Status,
-			Price:   v.Price,
+			Id:           v.Id,
+			Date:         v.Date,
+			IdUser:       v.IdUser,
+			Address:      v.Address,
+			Status:       structs.OrderStatus(v.Status),
+			Price:        v.Price,
+			IdDelivery:   v.IdDelivery.UUID,
+			Zone:         v.Zone,
+			IdSlot:       v.IdSlot.UUID,
+			ShippingCost: v.ShippingCost,
 		})
 	}
 	return ords, nil
@@ -167,50 +186,212 @@ func (rep *Repository) GetOrdersByUser(ctx context.Context, id uuid.UUID) ([]str
 	var res []structs.Order
 	for _, v := range orders {
 		res = append(res, structs.Order{
-			Id:      v.Id,
-			Date:    v.Date,
-			IdUser:  v.IdUser,
-			Address: v.Address,
-			Status:  v.Status,
-			Price:   v.Price,
+			Id:           v.Id,
+			Date:         v.Date,
+			IdUser:       v.IdUser,
+			Address:      v.Address,
+			Status:       structs.OrderStatus(v.Status),
+			Price:        v.Price,
+			IdDelivery:   v.IdDelivery.UUID,
+			Zone:         v.Zone,
+			IdSlot:       v.IdSlot.UUID,
+			ShippingCost: v.ShippingCost,
 		})
 	}
 	return res, nil
 }
 
-func (rep *Repository) GetStatus(ctx context.Context, id uuid.UUID) (string, error) {
+func (rep *Repository) GetStatus(ctx context.Context, id uuid.UUID) (structs.OrderStatus, error) {
 	var status string
 	err := rep.db.GetContext(ctx, &status, "select status from \"order\" where id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", structs.ErrOrderNotFound
+	}
 	if err != nil {
 		return "", err
 	}
-	return status, nil
+	return structs.OrderStatus(status), nil
 }
 
+// Delete removes the order. The goods of an order that still holds them are
+// put back to stock first.
 func (rep *Repository) Delete(ctx context.Context, id uuid.UUID) error {
-	result, err := rep.db.ExecContext(ctx, "delete from \"order\" where id = $1", id)
+	tx, err := rep.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
+	var status string
+	err = tx.GetContext(ctx, &status, `select status from "order" where id = $1 for update`, id)
+	if errors.Is(err, sql.ErrNoRows) {
 		return fmt.Errorf("order with id %v not found", id)
 	}
+	if err != nil {
+		return err
+	}
+	if st := structs.OrderStatus(status); !st.Released() && st != structs.OrderShipped {
+		if err := restoreStock(ctx, tx, id, uuid.Nil, "удаление заказа"); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "delete from \"order\" where id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
 
+// restoreStock puts the goods of the order back to stock with return
+// movements: the lots the goods were taken from get them back, the rest
+// goes to the stock without a lot. Lines of products removed from the
+// catalog have no stock to go back to. Rows are locked in the same order as
+// at checkout.
+func restoreStock(ctx context.Context, tx *sqlx.Tx, id_order, id_actor uuid.UUID, reason string) error {
+	_, err := tx.ExecContext(ctx, `
+		insert into stock_movement (id_product, id_variant, id_lot, kind, quantity, reason, id_actor, reference)
+		select id_product, id_variant, id_lot, 'return', amount, $2, $3, $1::text
+		from (
+			select oi.id_product, oi.id_variant, il.id_lot, il.amount
+			from order_item oi
+			join order_item_lot il on il.id_order_item = oi.id
+			where oi.id_order = $1 and oi.id_product is not null
+			union all
+			select oi.id_product, oi.id_variant, null, oi.amount - coalesce(sum(il.amount), 0)
+			from order_item oi
+			left join order_item_lot il on il.id_order_item = oi.id
+			where oi.id_order = $1 and oi.id_product is not null
+			group by oi.id
+			having oi.amount > coalesce(sum(il.amount), 0)
+		) r
+		order by id_product, id_variant nulls first, id_lot nulls first`,
+		id_order, reason, rep_structs.NullId(id_actor))
+	if err != nil {
+		return fmt.Errorf("failed to restore stock: %w", err)
+	}
 	return nil
 }
 
-func (rep *Repository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
-	result, err := rep.db.ExecContext(ctx, "update \"order\" set status = $1 where id = $2", status, id)
+// UpdateStatus moves the order from ch.From to ch.To and records the change
+// in the status history. The order must still be in ch.From, so two
+// concurrent changes cannot both pass the transition check. An order that
+// is cancelled or marked invalid puts its goods back to stock in the same
+// transaction, with the comment as the reason, and its payment is owed back
+// to the customer.
+func (rep *Repository) UpdateStatus(ctx context.Context, ch structs.OrderStatusChange) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
+	result, err := tx.ExecContext(ctx, `
+		update "order" set status = $1 where id = $2 and status = $3`,
+		ch.To, ch.IdOrder, ch.From)
+	if err != nil {
+		return err
+	}
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return fmt.Errorf("order with id %v not found", id)
+		var exists bool
+		err = tx.GetContext(ctx, &exists, `select exists (select 1 from "order" where id = $1)`, ch.IdOrder)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return structs.ErrOrderNotFound
+		}
+		return structs.ErrOrderStatusChanged
+	}
+
+	if err := addStatusChange(ctx, tx, ch); err != nil {
+		return err
+	}
+
+	if ch.To.Released() {
+		reason := "отмена заказа"
+		if ch.To == structs.OrderInvalid {
+			reason = "заказ некорректный"
+		}
+		if ch.Comment != "" {
+			reason += ": " + ch.Comment
+		}
+		if err := restoreStock(ctx, tx, ch.IdOrder, ch.IdActor, reason); err != nil {
+			return err
+		}
+		if err := refundPayments(ctx, tx, ch.IdOrder); err != nil {
+			return err
+		}
 	}
 
+	return tx.Commit()
+}
+
+// refundPayments records pending refunds of the succeeded payments of the
+// order.
+func refundPayments(ctx context.Context, tx *sqlx.Tx, id_order uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		insert into refund (id_order, id_payment, amount, status)
+		select id_order, id, amount, $2 from payment
+		where id_order = $1 and status = $3 and amount > 0`,
+		id_order, structs.RefundPending, structs.PaymentSucceeded)
+	if err != nil {
+		return fmt.Errorf("failed to record refund: %w", err)
+	}
 	return nil
 }
+
+// addStatusChange records the change in the status history, the first
+// entry of an order has no previous status.
+func addStatusChange(ctx context.Context, tx *sqlx.Tx, ch structs.OrderStatusChange) error {
+	_, err := tx.ExecContext(ctx, `
+		insert into order_status_history (id_order, from_status, to_status, id_actor, role, comment)
+		values ($1, $2, $3, $4, $5, $6)`,
+		ch.IdOrder, sql.NullString{String: string(ch.From), Valid: ch.From != ""}, ch.To,
+		rep_structs.NullId(ch.IdActor), ch.Role, ch.Comment)
+	if err != nil {
+		return fmt.Errorf("failed to record order status change: %w", err)
+	}
+	return nil
+}
+
+// GetHistory returns the status changes of the order, oldest first.
+func (rep *Repository) GetHistory(ctx context.Context, id uuid.UUID) ([]structs.OrderStatusChange, error) {
+	var history []rep_structs.OrderStatusChange
+	err := rep.db.SelectContext(ctx, &history, `
+		select id, id_order, from_status, to_status, id_actor, role, comment, created_at
+		from order_status_history
+		where id_order = $1
+		order by created_at, id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order status history: %w", err)
+	}
+	if len(history) == 0 {
+		var exists bool
+		err = rep.db.GetContext(ctx, &exists, `select exists (select 1 from "order" where id = $1)`, id)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, structs.ErrOrderNotFound
+		}
+		return nil, nil
+	}
+
+	var res []structs.OrderStatusChange
+	for _, v := range history {
+		res = append(res, structs.OrderStatusChange{
+			Id:        v.Id,
+			IdOrder:   v.IdOrder,
+			From:      structs.OrderStatus(v.From.String),
+			To:        structs.OrderStatus(v.To),
+			IdActor:   v.IdActor.UUID,
+			Role:      structs.OrderRole(v.Role),
+			Comment:   v.Comment,
+			CreatedAt: v.CreatedAt,
+		})
+	}
+	return res, nil
+}
@@ -34,11 +34,13 @@ func NewTestFixture(t *testing.T) *TestFixture {
 	sqlxDB := sqlx.NewDb(db, "sqlmock")
 
 	order := structs.Order{
-		Date:    time.Now(),
-		IdUser:  uuid.New(),
-		Address: "Test Address",
-		Status:  "непринятый",
-		Price:   100.0,
+		Date:       time.Now(),
+		IdUser:     uuid.New(),
+		Address:    "Test Address",
+		Status:     "непринятый",
+		Price:      100.0,
+		IdDelivery: uuid.New(),
+		Zone:       "moscow",
 	}
 
 	return &TestFixture{
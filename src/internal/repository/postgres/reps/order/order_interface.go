@@ -8,12 +8,31 @@ import (
 )
 
 type OrderRepositoryInterface interface {
-	Create(ctx context.Context, o structs.Order) error
 	GetById(ctx context.Context, id uuid.UUID) (structs.Order, error)
 	GetItems(ctx context.Context, id uuid.UUID) ([]structs.OrderItem, error)
 	GetFreeOrders(ctx context.Context) ([]structs.Order, error)
 	GetOrdersByUser(ctx context.Context, id uuid.UUID) ([]structs.Order, error)
-	GetStatus(ctx context.Context, id uuid.UUID) (string, error)
+	GetStatus(ctx context.Context, id uuid.UUID) (structs.OrderStatus, error)
 	Delete(ctx context.Context, id uuid.UUID) error
-	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+	UpdateStatus(ctx context.Context, ch structs.OrderStatusChange) error
+	GetHistory(ctx context.Context, id uuid.UUID) ([]structs.OrderStatusChange, error)
+	Search(ctx context.Context, f structs.OrderFilter, after *structs.OrderCursor, limit int) ([]structs.OrderSummary, error)
+	GetItemsByOrders(ctx context.Context, ids []uuid.UUID) ([]structs.OrderItem, error)
+	Export(ctx context.Context, f structs.OrderFilter, fn func(structs.OrderExportRow) error) error
+	GetRepeatLines(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID) ([]structs.RepeatLine, error)
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	GetBasket(ctx context.Context, id_user uuid.UUID) ([]structs.BasketItem, error)
+	LockStock(ctx context.Context, id_user uuid.UUID, id_product uuid.UUID, id_variant uuid.UUID) (structs.StockLevel, error)
+	LockLots(ctx context.Context, id_product uuid.UUID, id_variant uuid.UUID) ([]structs.StockLot, error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (structs.DeliveryMethod, error)
+	LockSlot(ctx context.Context, id uuid.UUID) (structs.DeliverySlot, error)
+	CreateOrder(ctx context.Context, o structs.Order) (structs.Order, error)
+	AddItem(ctx context.Context, item structs.OrderItem) (uuid.UUID, error)
+	AddItemLot(ctx context.Context, id_item uuid.UUID, lot structs.OrderItemLot) error
+	AddStockMovement(ctx context.Context, m structs.StockMovement) error
+	AddDiscount(ctx context.Context, id_item uuid.UUID, id_promotion uuid.UUID, amount float64) error
+	Redeem(ctx context.Context, id_promotion uuid.UUID, id_user uuid.UUID, id_order uuid.UUID, amount float64) error
+	SetPrice(ctx context.Context, id_order uuid.UUID, price float64, shipping float64) error
+	AddStatusChange(ctx context.Context, ch structs.OrderStatusChange) error
+	ClearBasket(ctx context.Context, id_user uuid.UUID) error
 }
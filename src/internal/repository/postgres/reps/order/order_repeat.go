@@ -0,0 +1,64 @@
+package order_rep
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+// GetRepeatLines returns the lines of the order with the stock of their
+// goods now. The stock leaves out what other users hold and expired lots,
+// like a checkout of the user would; the basket amount is the amount of the
+// same product and variant in the basket of the user. A line whose product
+// was removed from the catalog has lost its id_product and is archived.
+func (rep *Repository) GetRepeatLines(ctx context.Context, id_order uuid.UUID, id_user uuid.UUID) ([]structs.RepeatLine, error) {
+	var ls []struct {
+		IdProduct uuid.NullUUID `db:"id_product"`
+		IdVariant uuid.NullUUID `db:"id_variant"`
+		Name      string        `db:"name"`
+		Amount    int           `db:"amount"`
+		Available int           `db:"available"`
+		InBasket  int           `db:"in_basket"`
+	}
+	err := rep.db.SelectContext(ctx, &ls, `
+		select oi.id_product, oi.id_variant, oi.name, oi.amount,
+			coalesce(case when oi.id_variant is null then
+				p.amount - coalesce((select sum(r.amount) from stock_reservation r
+					where r.id_product = p.id and r.id_variant is null and r.id_user <> $2
+					and r.expires_at > localtimestamp), 0)
+				- coalesce((select sum(l.amount) from stock_lot l
+					where l.id_product = p.id and l.id_variant is null and l.expires_at < current_date), 0)
+			else
+				v.amount - coalesce((select sum(r.amount) from stock_reservation r
+					where r.id_variant = v.id and r.id_user <> $2 and r.expires_at > localtimestamp), 0)
+				- coalesce((select sum(l.amount) from stock_lot l
+					where l.id_variant = v.id and l.expires_at < current_date), 0)
+			end, 0) as available,
+			coalesce((select bi.amount from basket_item bi join basket b on b.id = bi.id_basket
+				where b.id_user = $2 and bi.id_product = oi.id_product
+				and bi.id_variant is not distinct from oi.id_variant), 0) as in_basket
+		from order_item oi
+		left join product p on p.id = oi.id_product
+		left join product_variant v on v.id = oi.id_variant
+		where oi.id_order = $1
+		order by oi.name, oi.id`, id_order, id_user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order lines: %w", err)
+	}
+
+	res := make([]structs.RepeatLine, len(ls))
+	for i, l := range ls {
+		res[i] = structs.RepeatLine{
+			IdProduct: l.IdProduct.UUID,
+			IdVariant: l.IdVariant.UUID,
+			Name:      l.Name,
+			Amount:    l.Amount,
+			Archived:  !l.IdProduct.Valid,
+			Available: l.Available,
+			InBasket:  l.InBasket,
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,70 @@
+package order_rep
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var repeatColumns = []string{"id_product", "id_variant", "name", "amount", "available", "in_basket"}
+
+func TestGetRepeatLines(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id_order := uuid.New()
+	id_user := fixture.order.IdUser
+	lines := []structs.RepeatLine{
+		{IdProduct: uuid.New(), Name: "Крем", Amount: 2, Available: 5, InBasket: 1},
+		{IdVariant: uuid.New(), Name: "Тушь", Amount: 1, Archived: true},
+	}
+
+	expectSelect := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`from order_item oi\s+left join product p on p.id = oi.id_product\s+`+
+			`left join product_variant v on v.id = oi.id_variant\s+where oi.id_order = \$1`).
+			WithArgs(id_order, id_user)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []structs.RepeatLine
+		expectedErr error
+	}{
+		{
+			name: "lines with stock and basket amounts, removed product archived",
+			setupMock: func() {
+				expectSelect().WillReturnRows(sqlmock.NewRows(repeatColumns).
+					AddRow(lines[0].IdProduct, nil, lines[0].Name, lines[0].Amount, 5, 1).
+					AddRow(nil, lines[1].IdVariant, lines[1].Name, lines[1].Amount, 0, 0))
+			},
+			expected:    lines,
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectSelect().WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: errors.New("failed to get order lines: " + errTest.Error()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetRepeatLines(fixture.ctx, id_order, id_user)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
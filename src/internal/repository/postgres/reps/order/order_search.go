@@ -0,0 +1,205 @@
+package order_rep
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const selectOrderSummary = `
+	select o.id, o.date, o.id_user, coalesce(o.address, '') as address, o.status, o.price,
+		coalesce(u.name, '') as customer_name, coalesce(u.mail, '') as customer_mail,
+		coalesce(u.phone, '') as customer_phone,
+		(select w.id_worker from order_worker w where w.id_order = o.id limit 1) as id_worker`
+
+// orderSorts maps a sort to the key column and the direction. Ties are
+// broken by the order id, so the key of the last order is a cursor.
+var orderSorts = map[string]struct{ key, dir string }{
+	structs.OrderSortNewest:    {"o.date", "desc"},
+	structs.OrderSortOldest:    {"o.date", "asc"},
+	structs.OrderSortCheapest:  {"o.price", "asc"},
+	structs.OrderSortExpensive: {"o.price", "desc"},
+}
+
+// orderConditions turns every set field of the filter into a condition on
+// "order" o and "user" u. arg adds a query argument and returns its
+// placeholder.
+func orderConditions(f structs.OrderFilter, arg func(any) string) []string {
+	var conds []string
+	if len(f.Statuses) > 0 {
+		statuses := make([]string, len(f.Statuses))
+		for i, st := range f.Statuses {
+			statuses[i] = string(st)
+		}
+		conds = append(conds, `o.status = any(`+arg(pq.Array(statuses))+`)`)
+	}
+	if !f.From.IsZero() {
+		conds = append(conds, `o.date >= `+arg(f.From))
+	}
+	if !f.To.IsZero() {
+		conds = append(conds, `o.date < `+arg(f.To))
+	}
+	if f.Customer != "" {
+		c := arg(f.Customer)
+		conds = append(conds, `(position(`+c+` in lower(u.mail)) > 0 or position(`+c+` in u.phone) > 0)`)
+	}
+	if f.IdWorker != uuid.Nil {
+		conds = append(conds, `exists (select 1 from order_worker w
+			where w.id_order = o.id and w.id_worker = `+arg(f.IdWorker)+`)`)
+	}
+	if f.MinPrice != nil {
+		conds = append(conds, `o.price >= `+arg(*f.MinPrice))
+	}
+	if f.MaxPrice != nil {
+		conds = append(conds, `o.price <= `+arg(*f.MaxPrice))
+	}
+	if f.IdProduct != uuid.Nil {
+		conds = append(conds, `exists (select 1 from order_item oi
+			where oi.id_order = o.id and oi.id_product = `+arg(f.IdProduct)+`)`)
+	}
+	return conds
+}
+
+// Search returns at most limit orders matching the filter in the order of
+// f.Sort, starting after the cursor when it is set.
+func (rep *Repository) Search(ctx context.Context, f structs.OrderFilter, after *structs.OrderCursor, limit int) ([]structs.OrderSummary, error) {
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	sort := orderSorts[f.Sort]
+	conds := orderConditions(f, arg)
+	if after != nil {
+		var key any = after.Date
+		if sort.key == "o.price" {
+			key = after.Price
+		}
+		op := ">"
+		if sort.dir == "desc" {
+			op = "<"
+		}
+		conds = append(conds, `(`+sort.key+`, o.id) `+op+` (`+arg(key)+`, `+arg(after.Id)+`)`)
+	}
+
+	query := selectOrderSummary + `
+		from "order" o left join "user" u on u.id = o.id_user`
+	if len(conds) > 0 {
+		query += ` where ` + strings.Join(conds, ` and `)
+	}
+	query += ` order by ` + sort.key + ` ` + sort.dir + `, o.id ` + sort.dir + ` limit ` + arg(limit)
+
+	var found []rep_structs.OrderSummary
+	if err := rep.db.SelectContext(ctx, &found, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to search orders: %w", err)
+	}
+	res := make([]structs.OrderSummary, len(found))
+	for i, o := range found {
+		res[i] = toOrderSummary(o)
+	}
+	return res, nil
+}
+
+// GetItemsByOrders returns the lines of the orders, without lots.
+func (rep *Repository) GetItemsByOrders(ctx context.Context, ids []uuid.UUID) ([]structs.OrderItem, error) {
+	var items []rep_structs.OrderItem
+	err := rep.db.SelectContext(ctx, &items, `
+		select id, id_product, id_variant, id_order, amount, price, discount, name, art, brand
+		from order_item where id_order = any($1)
+		order by id_order, name, id`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order items: %w", err)
+	}
+	res := make([]structs.OrderItem, len(items))
+	for i, v := range items {
+		res[i] = structs.OrderItem{
+			Id:        v.Id,
+			IdProduct: v.IdProduct.UUID,
+			IdVariant: v.IdVariant.UUID,
+			IdOrder:   v.IdOrder,
+			Amount:    v.Amount,
+			Price:     v.Price,
+			Discount:  v.Discount,
+			Name:      v.Name,
+			Art:       v.Art,
+			Brand:     v.Brand,
+		}
+	}
+	return res, nil
+}
+
+// Export streams the lines of the orders matching the filter to fn in the
+// order of f.Sort. fn is called while the rows are read, so it must not
+// query the database through the same connection.
+func (rep *Repository) Export(ctx context.Context, f structs.OrderFilter, fn func(structs.OrderExportRow) error) error {
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	sort := orderSorts[f.Sort]
+	query := selectOrderSummary + `,
+			oi.id as id_item, oi.id_product, oi.id_variant, coalesce(oi.amount, 0) as amount,
+			coalesce(oi.price, 0) as item_price, coalesce(oi.discount, 0) as discount,
+			coalesce(oi.name, '') as name, coalesce(oi.art, '') as art, coalesce(oi.brand, '') as brand
+		from "order" o left join "user" u on u.id = o.id_user
+		left join order_item oi on oi.id_order = o.id`
+	if conds := orderConditions(f, arg); len(conds) > 0 {
+		query += ` where ` + strings.Join(conds, ` and `)
+	}
+	query += ` order by ` + sort.key + ` ` + sort.dir + `, o.id ` + sort.dir + `, oi.name, oi.id`
+
+	rows, err := rep.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to export orders: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r rep_structs.OrderExportRow
+		if err := rows.StructScan(&r); err != nil {
+			return fmt.Errorf("failed to scan order: %w", err)
+		}
+		err := fn(structs.OrderExportRow{
+			Order: toOrderSummary(r.OrderSummary),
+			Line: structs.OrderItem{
+				Id:        r.IdItem.UUID,
+				IdProduct: r.IdProduct.UUID,
+				IdVariant: r.IdVariant.UUID,
+				IdOrder:   r.Id,
+				Amount:    r.Amount,
+				Price:     r.ItemPrice,
+				Discount:  r.Discount,
+				Name:      r.Name,
+				Art:       r.Art,
+				Brand:     r.Brand,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func toOrderSummary(o rep_structs.OrderSummary) structs.OrderSummary {
+	return structs.OrderSummary{
+		Id:            o.Id,
+		Date:          o.Date,
+		IdUser:        o.IdUser,
+		Address:       o.Address,
+		Status:        structs.OrderStatus(o.Status),
+		Price:         o.Price,
+		CustomerName:  o.CustomerName,
+		CustomerMail:  o.CustomerMail,
+		CustomerPhone: o.CustomerPhone,
+		IdWorker:      o.IdWorker.UUID,
+	}
+}
@@ -0,0 +1,273 @@
+package order_rep
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var summaryColumns = []string{
+	"id", "date", "id_user", "address", "status", "price",
+	"customer_name", "customer_mail", "customer_phone", "id_worker",
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	date := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	idWorker := uuid.New()
+	idProduct := uuid.New()
+	minPrice, maxPrice := 100.0, 500.0
+	order := structs.OrderSummary{
+		Id:            uuid.New(),
+		Date:          date,
+		IdUser:        uuid.New(),
+		Address:       "Test Address",
+		Status:        structs.OrderAccepted,
+		Price:         250,
+		CustomerName:  "Иван",
+		CustomerMail:  "ivan@mail.ru",
+		CustomerPhone: "+79990000000",
+		IdWorker:      idWorker,
+	}
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(summaryColumns).AddRow(order.Id, order.Date, order.IdUser, order.Address,
+			string(order.Status), order.Price, order.CustomerName, order.CustomerMail, order.CustomerPhone, idWorker)
+	}
+
+	tests := []struct {
+		name        string
+		filter      structs.OrderFilter
+		after       *structs.OrderCursor
+		setupMock   func()
+		expected    []structs.OrderSummary
+		expectedErr error
+	}{
+		{
+			name:   "no conditions",
+			filter: structs.OrderFilter{Sort: structs.OrderSortNewest},
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from "order" o left join "user" u on u.id = o.id_user order by o.date desc, o.id desc limit \$1`).
+					WithArgs(20).
+					WillReturnRows(row())
+			},
+			expected:    []structs.OrderSummary{order},
+			expectedErr: nil,
+		},
+		{
+			name: "every condition",
+			filter: structs.OrderFilter{
+				Statuses:  []structs.OrderStatus{structs.OrderNew, structs.OrderAccepted},
+				From:      date,
+				To:        date.AddDate(0, 0, 1),
+				Customer:  "ivan",
+				IdWorker:  idWorker,
+				MinPrice:  &minPrice,
+				MaxPrice:  &maxPrice,
+				IdProduct: idProduct,
+				Sort:      structs.OrderSortOldest,
+			},
+			setupMock: func() {
+				fixture.mock.ExpectQuery(strings.Join([]string{
+					`where o.status = any\(\$1\) and o.date >= \$2 and o.date < \$3`,
+					`and \(position\(\$4 in lower\(u.mail\)\) > 0 or position\(\$4 in u.phone\) > 0\)`,
+					`and exists \(select 1 from order_worker w where w.id_order = o.id and w.id_worker = \$5\)`,
+					`and o.price >= \$6 and o.price <= \$7`,
+					`and exists \(select 1 from order_item oi where oi.id_order = o.id and oi.id_product = \$8\)`,
+					`order by o.date asc, o.id asc limit \$9`,
+				}, " ")).
+					WithArgs(pq.Array([]string{string(structs.OrderNew), string(structs.OrderAccepted)}),
+						date, date.AddDate(0, 0, 1), "ivan", idWorker, minPrice, maxPrice, idProduct, 20).
+					WillReturnRows(row())
+			},
+			expected:    []structs.OrderSummary{order},
+			expectedErr: nil,
+		},
+		{
+			name:   "after cursor by price",
+			filter: structs.OrderFilter{Sort: structs.OrderSortExpensive},
+			after:  &structs.OrderCursor{Sort: structs.OrderSortExpensive, Date: date, Price: 300, Id: order.Id},
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`where \(o.price, o.id\) < \(\$1, \$2\) order by o.price desc, o.id desc limit \$3`).
+					WithArgs(300.0, order.Id, 20).
+					WillReturnRows(sqlmock.NewRows(summaryColumns))
+			},
+			expected:    []structs.OrderSummary{},
+			expectedErr: nil,
+		},
+		{
+			name:   "database error",
+			filter: structs.OrderFilter{Sort: structs.OrderSortCheapest},
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`order by o.price asc, o.id asc limit \$1`).
+					WithArgs(20).
+					WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: fmt.Errorf("failed to search orders: %w", errTest),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			orders, err := fixture.repo.Search(fixture.ctx, tt.filter, tt.after, 20)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, orders)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetItemsByOrders(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	item := structs.OrderItem{
+		Id:        uuid.New(),
+		IdProduct: uuid.New(),
+		IdOrder:   ids[1],
+		Amount:    2,
+		Price:     899.9,
+		Discount:  150,
+		Name:      "Крем для лица",
+		Art:       "CR-050",
+		Brand:     "Natura",
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []structs.OrderItem
+		expectedErr error
+	}{
+		{
+			name: "successful get items",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from order_item where id_order = any\(\$1\) order by id_order, name, id`).
+					WithArgs(pq.Array(ids)).
+					WillReturnRows(sqlmock.NewRows(itemColumns).AddRow(item.Id, item.IdProduct, item.IdOrder,
+						item.Amount, item.Price, item.Discount, item.Name, item.Art, item.Brand))
+			},
+			expected:    []structs.OrderItem{item},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from order_item where id_order = any\(\$1\)`).
+					WithArgs(pq.Array(ids)).
+					WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: fmt.Errorf("failed to get order items: %w", errTest),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			items, err := fixture.repo.GetItemsByOrders(fixture.ctx, ids)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, items)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	date := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	columns := append(append([]string{}, summaryColumns...),
+		"id_item", "id_product", "id_variant", "amount", "item_price", "discount", "name", "art", "brand")
+	order := structs.OrderSummary{
+		Id:      uuid.New(),
+		Date:    date,
+		IdUser:  uuid.New(),
+		Address: "Test Address",
+		Status:  structs.OrderNew,
+		Price:   250,
+	}
+	line := structs.OrderItem{
+		Id:        uuid.New(),
+		IdProduct: uuid.New(),
+		IdOrder:   order.Id,
+		Amount:    3,
+		Price:     100,
+		Discount:  50,
+		Name:      "Крем для лица",
+	}
+	empty := structs.OrderSummary{Id: uuid.New(), Date: date, IdUser: uuid.New(), Status: structs.OrderNew}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []structs.OrderExportRow
+		expectedErr error
+	}{
+		{
+			name: "orders with and without lines",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`left join order_item oi on oi.id_order = o.id where o.price >= \$1 order by o.date desc, o.id desc, oi.name, oi.id`).
+					WithArgs(100.0).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(order.Id, order.Date, order.IdUser, order.Address, string(order.Status), order.Price, "", "", "", nil,
+							line.Id, line.IdProduct, nil, line.Amount, line.Price, line.Discount, line.Name, "", "").
+						AddRow(empty.Id, empty.Date, empty.IdUser, "", string(empty.Status), 0.0, "", "", "", nil,
+							nil, nil, nil, 0, 0.0, 0.0, "", "", ""))
+			},
+			expected: []structs.OrderExportRow{
+				{Order: order, Line: line},
+				{Order: empty, Line: structs.OrderItem{IdOrder: empty.Id}},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`left join order_item oi on oi.id_order = o.id`).
+					WithArgs(100.0).
+					WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: fmt.Errorf("failed to export orders: %w", errTest),
+		},
+	}
+
+	minPrice := 100.0
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			var rows []structs.OrderExportRow
+
+			err := fixture.repo.Export(fixture.ctx, structs.OrderFilter{MinPrice: &minPrice, Sort: structs.OrderSortNewest},
+				func(r structs.OrderExportRow) error {
+					rows = append(rows, r)
+					return nil
+				})
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, rows)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
@@ -14,51 +14,11 @@ import (
 	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
 )
 
-func TestCreate(t *testing.T) {
-	t.Parallel()
-	fixture := NewTestFixture(t)
-
-	tests := []struct {
-		name        string
-		setupMock   func()
-		expectedErr error
-	}{
-		{
-			name: "successful order creation",
-			setupMock: func() {
-				fixture.mock.ExpectQuery(`insert into "order" \(id_user, address, status\) values \(\$1, \$2, \$3\) returning id`).
-					WithArgs(fixture.order.IdUser, fixture.order.Address, fixture.order.Status).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fixture.order.Id))
-			},
-			expectedErr: nil,
-		},
-		{
-			name: "order creation error",
-			setupMock: func() {
-				fixture.mock.ExpectQuery(`insert into "order" \(id_user, address, status\) values \(\$1, \$2, \$3\) returning id`).
-					WithArgs(fixture.order.IdUser, fixture.order.Address, fixture.order.Status).
-					WillReturnError(errTest)
-			},
-			expectedErr: errTest,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.setupMock()
-
-			err := fixture.repo.Create(fixture.ctx, fixture.order)
-
-			fixture.AssertError(err, tt.expectedErr)
-			require.NoError(t, fixture.mock.ExpectationsWereMet())
-		})
-	}
-	fixture.Cleanup()
-}
-
 func TestGetById(t *testing.T) {
 	t.Parallel()
 	fixture := NewTestFixture(t)
+	order := fixture.order
+	order.Id = uuid.New()
 
 	tests := []struct {
 		name        string
@@ -69,30 +29,31 @@ func TestGetById(t *testing.T) {
 		{
 			name: "successful get order by id",
 			setupMock: func() {
-				rows := sqlmock.NewRows([]string{"date", "id_user", "address", "status", "price"}).
-					AddRow(fixture.order.Date, fixture.order.IdUser, fixture.order.Address, fixture.order.Status, fixture.order.Price)
+				rows := sqlmock.NewRows([]string{"id", "date", "id_user", "address", "status", "price", "id_delivery", "zone", "id_slot", "shipping_cost"}).
+					AddRow(order.Id, order.Date, order.IdUser, order.Address, order.Status, order.Price,
+						order.IdDelivery, order.Zone, nil, order.ShippingCost)
 				fixture.mock.ExpectQuery(`select \* from "order" where id = \$1`).
-					WithArgs(fixture.order.Id).
+					WithArgs(order.Id).
 					WillReturnRows(rows)
 			},
-			expected:    fixture.order,
+			expected:    order,
 			expectedErr: nil,
 		},
 		{
 			name: "order not found",
 			setupMock: func() {
 				fixture.mock.ExpectQuery(`select \* from "order" where id = \$1`).
-					WithArgs(fixture.order.Id).
+					WithArgs(order.Id).
 					WillReturnError(sql.ErrNoRows)
 			},
 			expected:    structs.Order{},
-			expectedErr: errors.New("failed to get order: " + sql.ErrNoRows.Error()),
+			expectedErr: structs.ErrOrderNotFound,
 		},
 		{
 			name: "database error when getting order",
 			setupMock: func() {
 				fixture.mock.ExpectQuery(`select \* from "order" where id = \$1`).
-					WithArgs(fixture.order.Id).
+					WithArgs(order.Id).
 					WillReturnError(errTest)
 			},
 			expected:    structs.Order{},
@@ -104,7 +65,7 @@ func TestGetById(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			order, err := fixture.repo.GetById(fixture.ctx, fixture.order.Id)
+			order, err := fixture.repo.GetById(fixture.ctx, order.Id)
 
 			fixture.AssertError(err, tt.expectedErr)
 			assert.Equal(t, tt.expected, order)
@@ -114,6 +75,8 @@ func TestGetById(t *testing.T) {
 	fixture.Cleanup()
 }
 
+var itemColumns = []string{"id", "id_product", "id_order", "amount", "price", "discount", "name", "art", "brand"}
+
 func TestGetItems(t *testing.T) {
 	t.Parallel()
 	fixture := NewTestFixture(t)
@@ -121,30 +84,51 @@ func TestGetItems(t *testing.T) {
 	items := []rep_structs.OrderItem{
 		{
 			Id:        uuid.New(),
-			IdProduct: uuid.New(),
+			IdProduct: uuid.NullUUID{UUID: uuid.New(), Valid: true},
 			IdOrder:   fixture.order.Id,
 			Amount:    2,
+			Price:     899.9,
+			Discount:  150,
+			Name:      "Крем для лица",
+			Art:       "CR-050",
+			Brand:     "Natura",
 		},
+		// The product of this line was removed from the catalog.
 		{
-			Id:        uuid.New(),
-			IdProduct: uuid.New(),
-			IdOrder:   fixture.order.Id,
-			Amount:    1,
+			Id:      uuid.New(),
+			IdOrder: fixture.order.Id,
+			Amount:  1,
+			Price:   350,
+			Name:    "Бальзам для губ",
 		},
 	}
 
+	lot := structs.OrderItemLot{
+		IdLot:     uuid.New(),
+		Batch:     "L2403A",
+		ExpiresAt: time.Date(2027, 9, 30, 0, 0, 0, 0, time.UTC),
+		Amount:    2,
+	}
+
 	expectedItems := []structs.OrderItem{
 		{
 			Id:        items[0].Id,
-			IdProduct: items[0].IdProduct,
+			IdProduct: items[0].IdProduct.UUID,
 			IdOrder:   items[0].IdOrder,
 			Amount:    items[0].Amount,
+			Price:     items[0].Price,
+			Discount:  items[0].Discount,
+			Name:      items[0].Name,
+			Art:       items[0].Art,
+			Brand:     items[0].Brand,
+			Lots:      []structs.OrderItemLot{lot},
 		},
 		{
-			Id:        items[1].Id,
-			IdProduct: items[1].IdProduct,
-			IdOrder:   items[1].IdOrder,
-			Amount:    items[1].Amount,
+			Id:      items[1].Id,
+			IdOrder: items[1].IdOrder,
+			Amount:  items[1].Amount,
+			Price:   items[1].Price,
+			Name:    items[1].Name,
 		},
 	}
 
@@ -157,12 +141,17 @@ func TestGetItems(t *testing.T) {
 		{
 			name: "successful get items",
 			setupMock: func() {
-				rows := sqlmock.NewRows([]string{"id", "id_product", "id_order", "amount"}).
-					AddRow(items[0].Id, items[0].IdProduct, items[0].IdOrder, items[0].Amount).
-					AddRow(items[1].Id, items[1].IdProduct, items[1].IdOrder, items[1].Amount)
-				fixture.mock.ExpectQuery("select \\* from order_item where id_order = \\$1").
+				rows := sqlmock.NewRows(itemColumns)
+				for _, it := range items {
+					rows.AddRow(it.Id, it.IdProduct, it.IdOrder, it.Amount, it.Price, it.Discount, it.Name, it.Art, it.Brand)
+				}
+				fixture.mock.ExpectQuery(`select id, id_product, id_variant, id_order, amount, price, discount, name, art, brand from order_item where id_order = \$1`).
 					WithArgs(fixture.order.Id).
 					WillReturnRows(rows)
+				fixture.mock.ExpectQuery(`select il.id_order_item, il.id_lot, l.batch, l.expires_at, il.amount from order_item_lot il`).
+					WithArgs(fixture.order.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"id_order_item", "id_lot", "batch", "expires_at", "amount"}).
+						AddRow(items[0].Id, lot.IdLot, lot.Batch, lot.ExpiresAt, lot.Amount))
 			},
 			expected:    expectedItems,
 			expectedErr: nil,
@@ -170,8 +159,8 @@ func TestGetItems(t *testing.T) {
 		{
 			name: "no items found",
 			setupMock: func() {
-				rows := sqlmock.NewRows([]string{"id", "id_product", "id_order", "amount"})
-				fixture.mock.ExpectQuery("select \\* from order_item where id_order = \\$1").
+				rows := sqlmock.NewRows(itemColumns)
+				fixture.mock.ExpectQuery(`select id, id_product, id_variant, id_order, amount, price, discount, name, art, brand from order_item where id_order = \$1`).
 					WithArgs(fixture.order.Id).
 					WillReturnRows(rows)
 			},
@@ -181,7 +170,7 @@ func TestGetItems(t *testing.T) {
 		{
 			name: "database error when getting items",
 			setupMock: func() {
-				fixture.mock.ExpectQuery("select \\* from order_item where id_order = \\$1").
+				fixture.mock.ExpectQuery(`select id, id_product, id_variant, id_order, amount, price, discount, name, art, brand from order_item where id_order = \$1`).
 					WithArgs(fixture.order.Id).
 					WillReturnError(errTest)
 			},
@@ -233,7 +222,7 @@ func TestGetFreeOrders(t *testing.T) {
 			Date:    orders[0].Date,
 			IdUser:  orders[0].IdUser,
 			Address: orders[0].Address,
-			Status:  orders[0].Status,
+			Status:  structs.OrderStatus(orders[0].Status),
 			Price:   orders[0].Price,
 		},
 		{
@@ -241,7 +230,7 @@ func TestGetFreeOrders(t *testing.T) {
 			Date:    orders[1].Date,
 			IdUser:  orders[1].IdUser,
 			Address: orders[1].Address,
-			Status:  orders[1].Status,
+			Status:  structs.OrderStatus(orders[1].Status),
 			Price:   orders[1].Price,
 		},
 	}
@@ -323,7 +312,7 @@ func TestGetOrdersByUser(t *testing.T) {
 			Date:    orders[0].Date,
 			IdUser:  orders[0].IdUser,
 			Address: orders[0].Address,
-			Status:  orders[0].Status,
+			Status:  structs.OrderStatus(orders[0].Status),
 			Price:   orders[0].Price,
 		},
 	}
@@ -390,7 +379,7 @@ func TestGetStatus(t *testing.T) {
 	tests := []struct {
 		name        string
 		setupMock   func()
-		expected    string
+		expected    structs.OrderStatus
 		expectedErr error
 	}{
 		{
@@ -412,7 +401,7 @@ func TestGetStatus(t *testing.T) {
 					WillReturnError(sql.ErrNoRows)
 			},
 			expected:    "",
-			expectedErr: sql.ErrNoRows,
+			expectedErr: structs.ErrOrderNotFound,
 		},
 		{
 			name: "database error when getting status",
@@ -444,35 +433,75 @@ func TestDelete(t *testing.T) {
 	t.Parallel()
 	fixture := NewTestFixture(t)
 
+	expectStatus := func(status structs.OrderStatus) {
+		fixture.mock.ExpectQuery(`select status from "order" where id = \$1 for update`).
+			WithArgs(fixture.order.Id).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+	}
+
 	tests := []struct {
 		name        string
 		setupMock   func()
 		expectedErr error
 	}{
 		{
-			name: "successful delete order",
+			name: "successful delete of active order restores stock",
 			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectStatus(structs.OrderAccepted)
+				fixture.mock.ExpectExec(`insert into stock_movement \(id_product, id_variant, id_lot, kind, quantity, reason, id_actor, reference\) select id_product, id_variant, id_lot, 'return', amount, \$2, \$3, \$1::text`).
+					WithArgs(fixture.order.Id, "удаление заказа", uuid.NullUUID{}).
+					WillReturnResult(sqlmock.NewResult(0, 2))
 				fixture.mock.ExpectExec(`delete from "order" where id = \$1`).
 					WithArgs(fixture.order.Id).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "order not found",
+			name: "successful delete of shipped order",
 			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectStatus(structs.OrderShipped)
 				fixture.mock.ExpectExec(`delete from "order" where id = \$1`).
 					WithArgs(fixture.order.Id).
-					WillReturnResult(sqlmock.NewResult(0, 0))
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "order not found",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select status from "order" where id = \$1 for update`).
+					WithArgs(fixture.order.Id).
+					WillReturnError(sql.ErrNoRows)
+				fixture.mock.ExpectRollback()
 			},
 			expectedErr: errors.New("order with id " + fixture.order.Id.String() + " not found"),
 		},
+		{
+			name: "database error when restoring stock",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectStatus(structs.OrderNew)
+				fixture.mock.ExpectExec(`insert into stock_movement`).
+					WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: errors.New("failed to restore stock: " + errTest.Error()),
+		},
 		{
 			name: "database error when deleting order",
 			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectStatus(structs.OrderCancelled)
 				fixture.mock.ExpectExec(`delete from "order" where id = \$1`).
 					WithArgs(fixture.order.Id).
 					WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
 			},
 			expectedErr: errTest,
 		},
@@ -495,52 +524,243 @@ func TestUpdateStatus(t *testing.T) {
 	t.Parallel()
 	fixture := NewTestFixture(t)
 
+	change := structs.OrderStatusChange{
+		IdOrder: fixture.order.Id,
+		From:    structs.OrderNew,
+		To:      structs.OrderAccepted,
+		IdActor: uuid.New(),
+		Role:    structs.OrderRoleWorker,
+		Comment: "взят в работу",
+	}
+
 	tests := []struct {
 		name        string
+		change      structs.OrderStatusChange
 		setupMock   func()
-		orderID     uuid.UUID
-		status      string
 		expectedErr error
 	}{
 		{
-			name: "successful update status",
+			name:   "successful update status",
+			change: change,
 			setupMock: func() {
-				fixture.mock.ExpectExec(`update "order" set status = \$1 where id = \$2`).
-					WithArgs("принятый", fixture.order.Id).
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update "order" set status = \$1 where id = \$2 and status = \$3`).
+					WithArgs(change.To, change.IdOrder, change.From).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`insert into order_status_history \(id_order, from_status, to_status, id_actor, role, comment\)`).
+					WithArgs(change.IdOrder, change.From, change.To, uuid.NullUUID{UUID: change.IdActor, Valid: true}, change.Role, change.Comment).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
 			},
-			status:      "принятый",
 			expectedErr: nil,
 		},
 		{
-			name: "order not found",
+			name: "cancellation restores stock",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, From: structs.OrderAccepted, To: structs.OrderCancelled,
+				IdActor: fixture.order.IdUser, Role: structs.OrderRoleCustomer, Comment: "передумал",
+			},
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update "order" set status = \$1 where id = \$2 and status = \$3`).
+					WithArgs(structs.OrderCancelled, fixture.order.Id, structs.OrderAccepted).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`insert into order_status_history`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`insert into stock_movement \(id_product, id_variant, id_lot, kind, quantity, reason, id_actor, reference\) select .* from order_item oi join order_item_lot il .* group by oi.id having oi.amount > coalesce\(sum\(il.amount\), 0\) \) r order by id_product, id_variant nulls first, id_lot nulls first`).
+					WithArgs(fixture.order.Id, "отмена заказа: передумал", uuid.NullUUID{UUID: fixture.order.IdUser, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				fixture.mock.ExpectExec(`insert into refund \(id_order, id_payment, amount, status\) select id_order, id, amount, \$2 from payment where id_order = \$1 and status = \$3`).
+					WithArgs(fixture.order.Id, structs.RefundPending, structs.PaymentSucceeded).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid order restores stock",
+			change: structs.OrderStatusChange{
+				IdOrder: fixture.order.Id, From: structs.OrderNew, To: structs.OrderInvalid,
+				IdActor: change.IdActor, Role: structs.OrderRoleAdmin,
+			},
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update "order" set status = \$1 where id = \$2 and status = \$3`).
+					WithArgs(structs.OrderInvalid, fixture.order.Id, structs.OrderNew).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`insert into order_status_history`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`insert into stock_movement`).
+					WithArgs(fixture.order.Id, "заказ некорректный", uuid.NullUUID{UUID: change.IdActor, Valid: true}).
+					WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: errors.New("failed to restore stock: " + errTest.Error()),
+		},
+		{
+			name:   "order not found",
+			change: change,
 			setupMock: func() {
-				fixture.mock.ExpectExec(`update "order" set status = \$1 where id = \$2`).
-					WithArgs("в обработке", fixture.order.Id).
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update "order" set status = \$1 where id = \$2 and status = \$3`).
+					WithArgs(change.To, change.IdOrder, change.From).
 					WillReturnResult(sqlmock.NewResult(0, 0))
+				fixture.mock.ExpectQuery(`select exists \(select 1 from "order" where id = \$1\)`).
+					WithArgs(change.IdOrder).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				fixture.mock.ExpectRollback()
 			},
-			status:      "в обработке",
-			expectedErr: errors.New("order with id " + fixture.order.Id.String() + " not found"),
+			expectedErr: structs.ErrOrderNotFound,
+		},
+		{
+			name:   "status changed concurrently",
+			change: change,
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update "order" set status = \$1 where id = \$2 and status = \$3`).
+					WithArgs(change.To, change.IdOrder, change.From).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				fixture.mock.ExpectQuery(`select exists \(select 1 from "order" where id = \$1\)`).
+					WithArgs(change.IdOrder).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrOrderStatusChanged,
 		},
 		{
-			name: "database error when updating status",
+			name:   "database error when updating status",
+			change: change,
 			setupMock: func() {
-				fixture.mock.ExpectExec(`update "order" set status = \$1 where id = \$2`).
-					WithArgs("на сборке", fixture.order.Id).
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update "order" set status = \$1 where id = \$2 and status = \$3`).
+					WithArgs(change.To, change.IdOrder, change.From).
 					WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
 			},
-			status:      "на сборке",
 			expectedErr: errTest,
 		},
+		{
+			name:   "database error when recording history",
+			change: change,
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`update "order" set status = \$1 where id = \$2 and status = \$3`).
+					WithArgs(change.To, change.IdOrder, change.From).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`insert into order_status_history`).
+					WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: errors.New("failed to record order status change: " + errTest.Error()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.UpdateStatus(fixture.ctx, tt.change)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetHistory(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	actor := uuid.New()
+	expected := []structs.OrderStatusChange{
+		{
+			Id:        uuid.New(),
+			IdOrder:   fixture.order.Id,
+			To:        structs.OrderNew,
+			IdActor:   fixture.order.IdUser,
+			Role:      structs.OrderRoleCustomer,
+			CreatedAt: created,
+		},
+		{
+			Id:        uuid.New(),
+			IdOrder:   fixture.order.Id,
+			From:      structs.OrderNew,
+			To:        structs.OrderAccepted,
+			IdActor:   actor,
+			Role:      structs.OrderRoleWorker,
+			Comment:   "взят в работу",
+			CreatedAt: created.Add(time.Hour),
+		},
+	}
+	columns := []string{"id", "id_order", "from_status", "to_status", "id_actor", "role", "comment", "created_at"}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []structs.OrderStatusChange
+		expectedErr error
+	}{
+		{
+			name: "successful get history",
+			setupMock: func() {
+				rows := sqlmock.NewRows(columns).
+					AddRow(expected[0].Id, expected[0].IdOrder, nil, expected[0].To, expected[0].IdActor, expected[0].Role, "", created).
+					AddRow(expected[1].Id, expected[1].IdOrder, expected[1].From, expected[1].To, expected[1].IdActor, expected[1].Role, expected[1].Comment, expected[1].CreatedAt)
+				fixture.mock.ExpectQuery(`select id, id_order, from_status, to_status, id_actor, role, comment, created_at from order_status_history where id_order = \$1 order by created_at, id`).
+					WithArgs(fixture.order.Id).
+					WillReturnRows(rows)
+			},
+			expected:    expected,
+			expectedErr: nil,
+		},
+		{
+			name: "order without history",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select id, id_order, from_status, to_status, id_actor, role, comment, created_at from order_status_history`).
+					WithArgs(fixture.order.Id).
+					WillReturnRows(sqlmock.NewRows(columns))
+				fixture.mock.ExpectQuery(`select exists \(select 1 from "order" where id = \$1\)`).
+					WithArgs(fixture.order.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			expected:    nil,
+			expectedErr: nil,
+		},
+		{
+			name: "order not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select id, id_order, from_status, to_status, id_actor, role, comment, created_at from order_status_history`).
+					WithArgs(fixture.order.Id).
+					WillReturnRows(sqlmock.NewRows(columns))
+				fixture.mock.ExpectQuery(`select exists \(select 1 from "order" where id = \$1\)`).
+					WithArgs(fixture.order.Id).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			expected:    nil,
+			expectedErr: structs.ErrOrderNotFound,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select id, id_order, from_status, to_status, id_actor, role, comment, created_at from order_status_history`).
+					WithArgs(fixture.order.Id).
+					WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: errors.New("failed to get order status history: " + errTest.Error()),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			err := fixture.repo.UpdateStatus(fixture.ctx, fixture.order.Id, tt.status)
+			history, err := fixture.repo.GetHistory(fixture.ctx, fixture.order.Id)
 
 			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, history)
 			require.NoError(t, fixture.mock.ExpectationsWereMet())
 		})
 	}
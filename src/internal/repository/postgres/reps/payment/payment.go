@@ -0,0 +1,289 @@
+package payment_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const paymentFields = `id, id_order, id_user, provider, intent_id, amount, status, error, created_at, updated_at`
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create stores the payment of an order that is still unpaid. The order
+// row is locked, so a payment cannot be added while the order is being
+// paid or cancelled.
+func (rep *Repository) Create(ctx context.Context, p structs.Payment) (structs.Payment, error) {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return structs.Payment{}, err
+	}
+	defer tx.Rollback()
+
+	var status structs.OrderStatus
+	err = tx.GetContext(ctx, &status, `select status from "order" where id = $1 for update`, p.IdOrder)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.Payment{}, structs.ErrOrderNotFound
+	}
+	if err != nil {
+		return structs.Payment{}, fmt.Errorf("failed to lock order: %w", err)
+	}
+	if status != structs.OrderUnpaid {
+		return structs.Payment{}, structs.ErrPaymentNotAllowed
+	}
+
+	var row rep_structs.Payment
+	err = tx.GetContext(ctx, &row, `
+		insert into payment (id, id_order, id_user, provider, intent_id, amount, status)
+		values ($1, $2, $3, $4, $5, $6, $7)
+		returning `+paymentFields,
+		p.Id, p.IdOrder, p.IdUser, p.Provider,
+		sql.NullString{String: p.IntentId, Valid: p.IntentId != ""}, p.Amount, p.Status)
+	if err != nil {
+		return structs.Payment{}, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return structs.Payment{}, err
+	}
+	return toPayment(row), nil
+}
+
+func (rep *Repository) GetById(ctx context.Context, id uuid.UUID) (structs.Payment, error) {
+	var row rep_structs.Payment
+	err := rep.db.GetContext(ctx, &row, `select `+paymentFields+` from payment where id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.Payment{}, structs.ErrPaymentNotFound
+	}
+	if err != nil {
+		return structs.Payment{}, fmt.Errorf("failed to get payment: %w", err)
+	}
+	return toPayment(row), nil
+}
+
+func (rep *Repository) GetByIntent(ctx context.Context, provider string, intent string) (structs.Payment, error) {
+	var row rep_structs.Payment
+	err := rep.db.GetContext(ctx, &row, `
+		select `+paymentFields+` from payment where provider = $1 and intent_id = $2`, provider, intent)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.Payment{}, structs.ErrPaymentNotFound
+	}
+	if err != nil {
+		return structs.Payment{}, fmt.Errorf("failed to get payment: %w", err)
+	}
+	return toPayment(row), nil
+}
+
+// GetByOrder returns the payments of the order, newest first.
+func (rep *Repository) GetByOrder(ctx context.Context, id_order uuid.UUID) ([]structs.Payment, error) {
+	var rows []rep_structs.Payment
+	err := rep.db.SelectContext(ctx, &rows, `
+		select `+paymentFields+` from payment where id_order = $1 order by created_at desc`, id_order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payments: %w", err)
+	}
+
+	res := make([]structs.Payment, len(rows))
+	for i, row := range rows {
+		res[i] = toPayment(row)
+	}
+	return res, nil
+}
+
+// recordEvent marks the webhook event as processed. It returns
+// ErrPaymentEventSeen for an event processed before. Payments confirmed
+// without the provider have no event.
+func recordEvent(ctx context.Context, tx *sqlx.Tx, id_event string, id uuid.UUID) error {
+	if id_event == "" {
+		return nil
+	}
+	result, err := tx.ExecContext(ctx, `
+		insert into payment_event (id, id_payment) values ($1, $2) on conflict (id) do nothing`,
+		id_event, id)
+	if err != nil {
+		return fmt.Errorf("failed to record payment event: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrPaymentEventSeen
+	}
+	return nil
+}
+
+// Confirm marks the pending payment succeeded and passes its unpaid order
+// to the workers, recording the change in the status history. When the
+// order was cancelled while being paid, the payment is owed back in a
+// pending refund instead. A payment already decided is left as it is.
+func (rep *Repository) Confirm(ctx context.Context, id_event string, id uuid.UUID) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := recordEvent(ctx, tx, id_event, id); err != nil {
+		return err
+	}
+
+	var row rep_structs.Payment
+	err = tx.GetContext(ctx, &row, `select `+paymentFields+` from payment where id = $1 for update`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.ErrPaymentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock payment: %w", err)
+	}
+	if row.Status != structs.PaymentPending {
+		return tx.Commit()
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		update payment set status = $2, error = '', updated_at = current_timestamp where id = $1`,
+		id, structs.PaymentSucceeded)
+	if err != nil {
+		return fmt.Errorf("failed to confirm payment: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		update "order" set status = $2 where id = $1 and status = $3`,
+		row.IdOrder, structs.OrderNew, structs.OrderUnpaid)
+	if err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 1 {
+		_, err = tx.ExecContext(ctx, `
+			insert into order_status_history (id_order, from_status, to_status, id_actor, role, comment)
+			values ($1, $2, $3, $4, $5, $6)`,
+			row.IdOrder, structs.OrderUnpaid, structs.OrderNew, row.IdUser, structs.OrderRoleCustomer, "оплата")
+		if err != nil {
+			return fmt.Errorf("failed to record order status change: %w", err)
+		}
+	} else if row.Amount > 0 {
+		_, err = tx.ExecContext(ctx, `
+			insert into refund (id_order, id_payment, amount, status) values ($1, $2, $3, $4)`,
+			row.IdOrder, id, row.Amount, structs.RefundPending)
+		if err != nil {
+			return fmt.Errorf("failed to record refund: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Fail closes the pending payment with the reason given by the provider.
+func (rep *Repository) Fail(ctx context.Context, id_event string, id uuid.UUID, reason string) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := recordEvent(ctx, tx, id_event, id); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		update payment set status = $2, error = $3, updated_at = current_timestamp
+		where id = $1 and status = $4`,
+		id, structs.PaymentFailed, reason, structs.PaymentPending)
+	if err != nil {
+		return fmt.Errorf("failed to fail payment: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetPendingRefunds returns the oldest pending refunds. A refund of a
+// return is made against the succeeded payment of its order that was not
+// given back whole.
+func (rep *Repository) GetPendingRefunds(ctx context.Context, limit int) ([]structs.Refund, error) {
+	var rows []rep_structs.Refund
+	err := rep.db.SelectContext(ctx, &rows, `
+		select r.id, r.id_return, r.id_order,
+			coalesce(r.id_payment, (
+				select p.id from payment p
+				where p.id_order = r.id_order and p.status = $1
+					and not exists (
+						select 1 from refund c where c.id_payment = p.id and c.id_return is null)
+				order by p.created_at
+				limit 1)) as id_payment,
+			r.amount, r.status, r.error, r.created_at, r.processed_at
+		from refund r
+		where r.status = $2
+		order by r.created_at
+		limit $3`,
+		structs.PaymentSucceeded, structs.RefundPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refunds: %w", err)
+	}
+
+	res := make([]structs.Refund, len(rows))
+	for i, row := range rows {
+		res[i] = structs.Refund{
+			Id:          row.Id,
+			IdReturn:    row.IdReturn.UUID,
+			IdOrder:     row.IdOrder,
+			IdPayment:   row.IdPayment.UUID,
+			Amount:      row.Amount,
+			Status:      row.Status,
+			Error:       row.Error,
+			CreatedAt:   row.CreatedAt,
+			ProcessedAt: nullTime(row.ProcessedAt),
+		}
+	}
+	return res, nil
+}
+
+// CompleteRefund stores the answer of the provider to a pending refund
+// together with the payment it was made against.
+func (rep *Repository) CompleteRefund(ctx context.Context, r structs.Refund, res structs.RefundResult) error {
+	result, err := rep.db.ExecContext(ctx, `
+		update refund set id_payment = $2, status = $3, provider_ref = $4, error = $5,
+			processed_at = current_timestamp
+		where id = $1 and status = $6`,
+		r.Id, rep_structs.NullId(r.IdPayment), res.Status, res.Ref, res.Error, structs.RefundPending)
+	if err != nil {
+		return fmt.Errorf("failed to complete refund: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("refund %s is not pending", r.Id)
+	}
+	return nil
+}
+
+func toPayment(row rep_structs.Payment) structs.Payment {
+	return structs.Payment{
+		Id:        row.Id,
+		IdOrder:   row.IdOrder,
+		IdUser:    row.IdUser,
+		Provider:  row.Provider,
+		IntentId:  row.IntentId.String,
+		Amount:    row.Amount,
+		Status:    row.Status,
+		Error:     row.Error,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
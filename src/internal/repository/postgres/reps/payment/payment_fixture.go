@@ -0,0 +1,81 @@
+package payment_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var (
+	paymentColumns = []string{"id", "id_order", "id_user", "provider", "intent_id", "amount", "status", "error", "created_at", "updated_at"}
+	refundColumns  = []string{"id", "id_return", "id_order", "id_payment", "amount", "status", "error", "created_at", "processed_at"}
+)
+
+type TestFixture struct {
+	t       *testing.T
+	db      *sql.DB
+	sqlxDB  *sqlx.DB
+	mock    sqlmock.Sqlmock
+	repo    *Repository
+	ctx     context.Context
+	payment structs.Payment
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	created := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		payment: structs.Payment{
+			Id:        structs.GenId(),
+			IdOrder:   structs.GenId(),
+			IdUser:    structs.GenId(),
+			Provider:  "fake",
+			IntentId:  "pi_1",
+			Amount:    2500,
+			Status:    structs.PaymentPending,
+			CreatedAt: created,
+			UpdatedAt: created,
+		},
+	}
+}
+
+func (f *TestFixture) paymentRows(p structs.Payment) *sqlmock.Rows {
+	var intent any
+	if p.IntentId != "" {
+		intent = p.IntentId
+	}
+	return sqlmock.NewRows(paymentColumns).
+		AddRow(p.Id, p.IdOrder, p.IdUser, p.Provider, intent, p.Amount, p.Status, p.Error, p.CreatedAt, p.UpdatedAt)
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
@@ -0,0 +1,19 @@
+package payment_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type PaymentRepositoryInterface interface {
+	Create(ctx context.Context, p structs.Payment) (structs.Payment, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.Payment, error)
+	GetByIntent(ctx context.Context, provider string, intent string) (structs.Payment, error)
+	GetByOrder(ctx context.Context, id_order uuid.UUID) ([]structs.Payment, error)
+	Confirm(ctx context.Context, id_event string, id uuid.UUID) error
+	Fail(ctx context.Context, id_event string, id uuid.UUID, reason string) error
+	GetPendingRefunds(ctx context.Context, limit int) ([]structs.Refund, error)
+	CompleteRefund(ctx context.Context, r structs.Refund, res structs.RefundResult) error
+}
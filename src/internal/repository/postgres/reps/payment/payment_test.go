@@ -0,0 +1,436 @@
+package payment_rep
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	p := fixture.payment
+
+	expectLock := func(status structs.OrderStatus) {
+		fixture.mock.ExpectQuery(`select status from "order" where id = \$1 for update`).WithArgs(p.IdOrder).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+	}
+
+	tests := []struct {
+		name        string
+		payment     structs.Payment
+		setupMock   func()
+		expected    structs.Payment
+		expectedErr error
+	}{
+		{
+			name:    "successful creation",
+			payment: p,
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.OrderUnpaid)
+				fixture.mock.ExpectQuery(`insert into payment \(id, id_order, id_user, provider, intent_id, amount, status\) values \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\) returning`).
+					WithArgs(p.Id, p.IdOrder, p.IdUser, "fake", sql.NullString{String: "pi_1", Valid: true}, 2500.0, structs.PaymentPending).
+					WillReturnRows(fixture.paymentRows(p))
+				fixture.mock.ExpectCommit()
+			},
+			expected:    p,
+			expectedErr: nil,
+		},
+		{
+			name: "payment without intent",
+			payment: func() structs.Payment {
+				free := p
+				free.IntentId = ""
+				free.Amount = 0
+				return free
+			}(),
+			setupMock: func() {
+				free := p
+				free.IntentId = ""
+				free.Amount = 0
+				fixture.mock.ExpectBegin()
+				expectLock(structs.OrderUnpaid)
+				fixture.mock.ExpectQuery(`insert into payment`).
+					WithArgs(p.Id, p.IdOrder, p.IdUser, "fake", sql.NullString{}, 0.0, structs.PaymentPending).
+					WillReturnRows(fixture.paymentRows(free))
+				fixture.mock.ExpectCommit()
+			},
+			expected: func() structs.Payment {
+				free := p
+				free.IntentId = ""
+				free.Amount = 0
+				return free
+			}(),
+			expectedErr: nil,
+		},
+		{
+			name:    "order already paid",
+			payment: p,
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.OrderNew)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrPaymentNotAllowed,
+		},
+		{
+			name:    "order not found",
+			payment: p,
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select status from "order" where id = \$1 for update`).WithArgs(p.IdOrder).
+					WillReturnRows(sqlmock.NewRows([]string{"status"}))
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			res, err := fixture.repo.Create(fixture.ctx, tt.payment)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, res)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetByIntent_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	p := fixture.payment
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    structs.Payment
+		expectedErr error
+	}{
+		{
+			name: "payment found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from payment where provider = \$1 and intent_id = \$2`).
+					WithArgs("fake", "pi_1").
+					WillReturnRows(fixture.paymentRows(p))
+			},
+			expected:    p,
+			expectedErr: nil,
+		},
+		{
+			name: "payment not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from payment where provider = \$1 and intent_id = \$2`).
+					WithArgs("fake", "pi_1").
+					WillReturnRows(sqlmock.NewRows(paymentColumns))
+			},
+			expectedErr: structs.ErrPaymentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			res, err := fixture.repo.GetByIntent(fixture.ctx, "fake", "pi_1")
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, res)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestConfirm_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	p := fixture.payment
+
+	expectEvent := func(rows int64) {
+		fixture.mock.ExpectExec(`insert into payment_event \(id, id_payment\) values \(\$1, \$2\) on conflict \(id\) do nothing`).
+			WithArgs("ev_1", p.Id).
+			WillReturnResult(sqlmock.NewResult(0, rows))
+	}
+	expectLock := func(status string) {
+		locked := p
+		locked.Status = status
+		fixture.mock.ExpectQuery(`from payment where id = \$1 for update`).WithArgs(p.Id).
+			WillReturnRows(fixture.paymentRows(locked))
+	}
+	expectSucceeded := func() {
+		fixture.mock.ExpectExec(`update payment set status = \$2, error = '', updated_at = current_timestamp where id = \$1`).
+			WithArgs(p.Id, structs.PaymentSucceeded).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	expectOrder := func(rows int64) {
+		fixture.mock.ExpectExec(`update "order" set status = \$2 where id = \$1 and status = \$3`).
+			WithArgs(p.IdOrder, structs.OrderNew, structs.OrderUnpaid).
+			WillReturnResult(sqlmock.NewResult(0, rows))
+	}
+
+	tests := []struct {
+		name        string
+		id_event    string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name:     "payment passes order to workers",
+			id_event: "ev_1",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectEvent(1)
+				expectLock(structs.PaymentPending)
+				expectSucceeded()
+				expectOrder(1)
+				fixture.mock.ExpectExec(`insert into order_status_history \(id_order, from_status, to_status, id_actor, role, comment\)`).
+					WithArgs(p.IdOrder, structs.OrderUnpaid, structs.OrderNew, p.IdUser, structs.OrderRoleCustomer, "оплата").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:     "payment of cancelled order is refunded",
+			id_event: "ev_1",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectEvent(1)
+				expectLock(structs.PaymentPending)
+				expectSucceeded()
+				expectOrder(0)
+				fixture.mock.ExpectExec(`insert into refund \(id_order, id_payment, amount, status\) values \(\$1, \$2, \$3, \$4\)`).
+					WithArgs(p.IdOrder, p.Id, 2500.0, structs.RefundPending).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:     "free order without event",
+			id_event: "",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.PaymentPending)
+				expectSucceeded()
+				expectOrder(1)
+				fixture.mock.ExpectExec(`insert into order_status_history`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:     "repeated event",
+			id_event: "ev_1",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectEvent(0)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrPaymentEventSeen,
+		},
+		{
+			name:     "payment already decided",
+			id_event: "ev_1",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectEvent(1)
+				expectLock(structs.PaymentFailed)
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:     "status history error",
+			id_event: "ev_1",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectEvent(1)
+				expectLock(structs.PaymentPending)
+				expectSucceeded()
+				expectOrder(1)
+				fixture.mock.ExpectExec(`insert into order_status_history`).WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Confirm(fixture.ctx, tt.id_event, p.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestFail_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	p := fixture.payment
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "payment fails with reason",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`insert into payment_event`).WithArgs("ev_2", p.Id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`update payment set status = \$2, error = \$3, updated_at = current_timestamp where id = \$1 and status = \$4`).
+					WithArgs(p.Id, structs.PaymentFailed, "card declined", structs.PaymentPending).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "repeated event",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`insert into payment_event`).WithArgs("ev_2", p.Id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrPaymentEventSeen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Fail(fixture.ctx, "ev_2", p.Id, "card declined")
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetPendingRefunds_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	created := time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC)
+	refund := structs.Refund{
+		Id:        structs.GenId(),
+		IdReturn:  structs.GenId(),
+		IdOrder:   fixture.payment.IdOrder,
+		IdPayment: fixture.payment.Id,
+		Amount:    1800,
+		Status:    structs.RefundPending,
+		CreatedAt: created,
+	}
+	orphan := structs.Refund{Id: structs.GenId(), IdOrder: structs.GenId(), Amount: 100, Status: structs.RefundPending, CreatedAt: created}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []structs.Refund
+		expectedErr error
+	}{
+		{
+			name: "refunds with resolved payments",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`coalesce\(r.id_payment, .* from refund r where r.status = \$2 order by r.created_at limit \$3`).
+					WithArgs(structs.PaymentSucceeded, structs.RefundPending, 10).
+					WillReturnRows(sqlmock.NewRows(refundColumns).
+						AddRow(refund.Id, refund.IdReturn, refund.IdOrder, refund.IdPayment, refund.Amount, refund.Status, "", created, nil).
+						AddRow(orphan.Id, nil, orphan.IdOrder, nil, orphan.Amount, orphan.Status, "", created, nil))
+			},
+			expected:    []structs.Refund{refund, orphan},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from refund r`).WillReturnError(errTest)
+			},
+			expected:    nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			res, err := fixture.repo.GetPendingRefunds(fixture.ctx, 10)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, res)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCompleteRefund_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	refund := structs.Refund{Id: structs.GenId(), IdPayment: fixture.payment.Id, Amount: 1800, Status: structs.RefundPending}
+	res := structs.RefundResult{Ref: "re_1", Status: structs.RefundSucceeded}
+	expectUpdate := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`update refund set id_payment = \$2, status = \$3, provider_ref = \$4, error = \$5, processed_at = current_timestamp where id = \$1 and status = \$6`).
+			WithArgs(refund.Id, uuid.NullUUID{UUID: fixture.payment.Id, Valid: true}, structs.RefundSucceeded, "re_1", "", structs.RefundPending)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr string
+	}{
+		{
+			name: "refund completed",
+			setupMock: func() {
+				expectUpdate().WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: "",
+		},
+		{
+			name: "refund no longer pending",
+			setupMock: func() {
+				expectUpdate().WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: "is not pending",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.CompleteRefund(fixture.ctx, refund, res)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,159 @@
+package price_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Schedule inserts a future price under a lock of the product row, so that
+// concurrent changes of the same product do not interleave their intervals.
+func (rep *Repository) Schedule(ctx context.Context, p structs.ProductPrice) (uuid.UUID, error) {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(ctx, tx, p.IdProduct); err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.GetContext(ctx, &id,
+		`insert into product_price (id_product, price, valid_from) values ($1, $2, $3) returning id`,
+		p.IdProduct, p.Price, p.ValidFrom)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return uuid.Nil, structs.ErrDuplicatePrice
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to schedule price: %w", err)
+	}
+
+	if err := closeIntervals(ctx, tx, p.IdProduct); err != nil {
+		return uuid.Nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (rep *Repository) DeleteScheduled(ctx context.Context, id_product uuid.UUID, id uuid.UUID) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(ctx, tx, id_product); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`delete from product_price where id = $1 and id_product = $2 and valid_from > localtimestamp`,
+		id, id_product)
+	if err != nil {
+		return fmt.Errorf("failed to delete price: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrPriceNotFound
+	}
+
+	if err := closeIntervals(ctx, tx, id_product); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (rep *Repository) GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.ProductPrice, error) {
+	var ps []rep_structs.ProductPrice
+	err := rep.db.SelectContext(ctx, &ps, `
+		select id, id_product, price, valid_from, valid_to
+		from product_price where id_product = $1 order by valid_from`, id_product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history: %w", err)
+	}
+
+	// Every product gets its first price on insert, so an empty history
+	// means there is no such product.
+	if len(ps) == 0 {
+		return nil, structs.ErrProductNotFound
+	}
+
+	res := make([]structs.ProductPrice, len(ps))
+	for i, p := range ps {
+		res[i] = structs.ProductPrice{
+			Id:        p.Id,
+			IdProduct: p.IdProduct,
+			Price:     p.Price,
+			ValidFrom: p.ValidFrom,
+		}
+		if p.ValidTo.Valid {
+			validTo := p.ValidTo.Time
+			res[i].ValidTo = &validTo
+		}
+	}
+	return res, nil
+}
+
+// ActivateDue sets product.price to the price valid now wherever they differ.
+// The price trigger sees the covering history row and records nothing.
+func (rep *Repository) ActivateDue(ctx context.Context) (int64, error) {
+	result, err := rep.db.ExecContext(ctx, `
+		update product p set price = pp.price
+		from product_price pp
+		where pp.id_product = p.id
+			and pp.valid_from <= localtimestamp
+			and (pp.valid_to is null or pp.valid_to > localtimestamp)
+			and p.price <> pp.price`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to activate prices: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func lockProduct(ctx context.Context, tx *sqlx.Tx, id_product uuid.UUID) error {
+	var id uuid.UUID
+	err := tx.GetContext(ctx, &id, `select id from product where id = $1 for update`, id_product)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.ErrProductNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock product: %w", err)
+	}
+	return nil
+}
+
+// closeIntervals makes each price of the product valid until the next one
+// starts and leaves the last one open.
+func closeIntervals(ctx context.Context, tx *sqlx.Tx, id_product uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		update product_price pp set valid_to = n.next_from
+		from (
+			select id, lead(valid_from) over (order by valid_from) as next_from
+			from product_price where id_product = $1
+		) n
+		where pp.id = n.id and pp.valid_to is distinct from n.next_from`, id_product)
+	if err != nil {
+		return fmt.Errorf("failed to update price intervals: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package price_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+type TestFixture struct {
+	t         *testing.T
+	db        *sql.DB
+	sqlxDB    *sqlx.DB
+	mock      sqlmock.Sqlmock
+	repo      *Repository
+	ctx       context.Context
+	current   structs.ProductPrice
+	scheduled structs.ProductPrice
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	id_product := structs.GenId()
+	start := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		current: structs.ProductPrice{
+			Id:        structs.GenId(),
+			IdProduct: id_product,
+			Price:     1800,
+			ValidFrom: time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC),
+			ValidTo:   &start,
+		},
+		scheduled: structs.ProductPrice{
+			Id:        structs.GenId(),
+			IdProduct: id_product,
+			Price:     1990,
+			ValidFrom: start,
+		},
+	}
+}
+
+func (f *TestFixture) expectLock(id_product any) *sqlmock.ExpectedQuery {
+	f.mock.ExpectBegin()
+	return f.mock.ExpectQuery(`select id from product where id = \$1 for update`).WithArgs(id_product)
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
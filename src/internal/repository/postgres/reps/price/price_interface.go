@@ -0,0 +1,15 @@
+package price_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type PriceRepositoryInterface interface {
+	Schedule(ctx context.Context, p structs.ProductPrice) (uuid.UUID, error)
+	DeleteScheduled(ctx context.Context, id_product uuid.UUID, id uuid.UUID) error
+	GetHistory(ctx context.Context, id_product uuid.UUID) ([]structs.ProductPrice, error)
+	ActivateDue(ctx context.Context) (int64, error)
+}
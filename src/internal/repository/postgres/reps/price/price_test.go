@@ -0,0 +1,231 @@
+package price_rep
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestSchedule_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	p := fixture.scheduled
+
+	expectInsert := func() *sqlmock.ExpectedQuery {
+		fixture.expectLock(p.IdProduct).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(p.IdProduct))
+		return fixture.mock.ExpectQuery(`insert into product_price \(id_product, price, valid_from\)`).
+			WithArgs(p.IdProduct, p.Price, p.ValidFrom)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "successful schedule",
+			setupMock: func() {
+				expectInsert().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(p.Id))
+				fixture.mock.ExpectExec(`update product_price pp set valid_to = n.next_from .* lead\(valid_from\)`).
+					WithArgs(p.IdProduct).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				fixture.mock.ExpectCommit()
+			},
+			expectedRet: p.Id,
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupMock: func() {
+				fixture.expectLock(p.IdProduct).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrProductNotFound,
+		},
+		{
+			name: "another price at the same time",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23505"})
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDuplicatePrice,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.Schedule(fixture.ctx, p)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDeleteScheduled_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	p := fixture.scheduled
+
+	expectDelete := func() *sqlmock.ExpectedExec {
+		fixture.expectLock(p.IdProduct).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(p.IdProduct))
+		return fixture.mock.ExpectExec(`delete from product_price where id = \$1 and id_product = \$2 and valid_from > localtimestamp`).
+			WithArgs(p.Id, p.IdProduct)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			setupMock: func() {
+				expectDelete().WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`update product_price pp set valid_to`).
+					WithArgs(p.IdProduct).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "price already started or missing",
+			setupMock: func() {
+				expectDelete().WillReturnResult(sqlmock.NewResult(0, 0))
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrPriceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.DeleteScheduled(fixture.ctx, p.IdProduct, p.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetHistory_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id_product := fixture.current.IdProduct
+
+	expectSelect := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`from product_price where id_product = \$1 order by valid_from`).
+			WithArgs(id_product)
+	}
+	columns := []string{"id", "id_product", "price", "valid_from", "valid_to"}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.ProductPrice
+		expectedErr error
+	}{
+		{
+			name: "open last interval",
+			setupMock: func() {
+				c, s := fixture.current, fixture.scheduled
+				expectSelect().WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(c.Id, c.IdProduct, c.Price, c.ValidFrom, *c.ValidTo).
+					AddRow(s.Id, s.IdProduct, s.Price, s.ValidFrom, nil))
+			},
+			expectedRet: []structs.ProductPrice{fixture.current, fixture.scheduled},
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupMock: func() {
+				expectSelect().WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrProductNotFound,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectSelect().WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetHistory(fixture.ctx, id_product)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestActivateDue_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	expectUpdate := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`update product p set price = pp.price from product_price pp .* p.price <> pp.price`)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet int64
+		expectedErr error
+	}{
+		{
+			name: "prices activated",
+			setupMock: func() {
+				expectUpdate().WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			expectedRet: 2,
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectUpdate().WillReturnError(errTest)
+			},
+			expectedRet: 0,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.ActivateDue(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
@@ -2,10 +2,13 @@ package product_rep
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	structs "github.com/taucuya/ppo/internal/core/structs"
 	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
 )
@@ -23,129 +26,184 @@ func (rep *Repository) Create(ctx context.Context, p structs.Product) error {
 		Name:        p.Name,
 		Description: p.Description,
 		Price:       p.Price,
-		Category:    p.Category,
+		IdCategory:  rep_structs.NullId(p.IdCategory),
 		Amount:      p.Amount,
 		IdBrand:     p.IdBrand,
 		PicLink:     p.PicLink,
 		Articule:    p.Articule,
+		Weight:      p.Weight,
 	}
 	_, err := rep.db.NamedExecContext(ctx,
 		`insert into product 
-		(name, description, price, category, amount, id_brand, pic_link, art) 
+		(name, description, price, id_category, amount, id_brand, pic_link, art, weight) 
 		values 
-		(:name, :description, :price, :category, :amount, :id_brand, :pic_link, :art)`,
+		(:name, :description, :price, :id_category, :amount, :id_brand, :pic_link, :art, :weight)`,
 		pr)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Constraint == "fk_product_category":
+			return structs.ErrCategoryNotFound
+		case pqErr.Code == "23505":
+			return fmt.Errorf("%w: %s", structs.ErrDuplicateArticule, pqErr.Detail)
+		}
+	}
 	return err
 }
 
+// selectProduct reads products with their rating summary, products
+// without reviews get zeros. The available stock leaves out the stock held
+// by live reservations and the stock of expired lots.
+const selectProduct = `select p.*, coalesce(r.rating_avg, 0) as rating_avg, coalesce(r.rating_count, 0) as rating_count,
+	coalesce(r.stars_1, 0) as stars_1, coalesce(r.stars_2, 0) as stars_2, coalesce(r.stars_3, 0) as stars_3,
+	coalesce(r.stars_4, 0) as stars_4, coalesce(r.stars_5, 0) as stars_5,
+	p.amount - coalesce((select sum(sr.amount) from stock_reservation sr
+		where sr.id_product = p.id and sr.id_variant is null and sr.expires_at > localtimestamp), 0)
+		- coalesce((select sum(l.amount) from stock_lot l
+		where l.id_product = p.id and l.id_variant is null and l.expires_at < current_date), 0) as available
+	from product p left join product_rating r on r.id_product = p.id`
+
 func (rep *Repository) GetById(ctx context.Context, id uuid.UUID) (structs.Product, error) {
 	var p rep_structs.Product
-	err := rep.db.GetContext(ctx, &p,
-		`select * from product where id = $1`, id)
+	err := rep.db.GetContext(ctx, &p, selectProduct+` where p.id = $1`, id)
 	if err != nil {
 		return structs.Product{}, fmt.Errorf("failed to get product: %w", err)
 	}
-	pr := structs.Product{
-		Id:          p.Id,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Category:    p.Category,
-		Amount:      p.Amount,
-		IdBrand:     p.IdBrand,
-		PicLink:     p.PicLink,
-		Articule:    p.Articule,
-	}
-	return pr, nil
+	return toProduct(p), nil
 }
 
 func (rep *Repository) GetByName(ctx context.Context, name string) (structs.Product, error) {
 	var p rep_structs.Product
-	err := rep.db.GetContext(ctx, &p,
-		`select * from product where name = $1`, name)
+	err := rep.db.GetContext(ctx, &p, selectProduct+` where p.name = $1`, name)
 	if err != nil {
 		return structs.Product{}, fmt.Errorf("failed to get product: %w", err)
 	}
-	pr := structs.Product{
-		Id:          p.Id,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Category:    p.Category,
-		Amount:      p.Amount,
-		IdBrand:     p.IdBrand,
-		PicLink:     p.PicLink,
-		Articule:    p.Articule,
-	}
-	return pr, nil
+	return toProduct(p), nil
 }
 
 func (rep *Repository) GetByArticule(ctx context.Context, art string) (structs.Product, error) {
 	var p rep_structs.Product
-	err := rep.db.GetContext(ctx, &p,
-		`select * from product where art = $1`, art)
+	err := rep.db.GetContext(ctx, &p, selectProduct+` where p.art = $1`, art)
 	if err != nil {
 		return structs.Product{}, fmt.Errorf("failed to get product: %w", err)
 	}
-	pr := structs.Product{
-		Id:          p.Id,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Category:    p.Category,
-		Amount:      p.Amount,
-		IdBrand:     p.IdBrand,
-		PicLink:     p.PicLink,
-		Articule:    p.Articule,
-	}
-	return pr, nil
+	return toProduct(p), nil
 }
 
-func (rep *Repository) GetByCategory(ctx context.Context, category string) ([]structs.Product, error) {
+// GetByCategory returns the products of the category and of all its
+// descendants.
+func (rep *Repository) GetByCategory(ctx context.Context, id_category uuid.UUID) ([]structs.Product, error) {
 	var ps []rep_structs.Product
-	if err := rep.db.SelectContext(ctx, &ps, `select * from product where category = $1`, category); err != nil {
+	if err := rep.db.SelectContext(ctx, &ps, `
+		with recursive tree as (
+			select id from category where id = $1
+			union all
+			select c.id from category c join tree t on c.id_parent = t.id
+		)
+		`+selectProduct+` where p.id_category in (select id from tree)`, id_category); err != nil {
 		return nil, err
 	}
 
 	var products []structs.Product
-
 	for _, v := range ps {
-		products = append(products, structs.Product{
-			Id:          v.Id,
-			Name:        v.Name,
-			Description: v.Description,
-			Price:       v.Price,
-			Category:    v.Category,
-			Amount:      v.Amount,
-			IdBrand:     v.IdBrand,
-			PicLink:     v.PicLink,
-			Articule:    v.Articule,
-		})
+		products = append(products, toProduct(v))
 	}
 	return products, nil
 }
 
 func (rep *Repository) GetByBrand(ctx context.Context, brand string) ([]structs.Product, error) {
 	var ps []rep_structs.Product
-	if err := rep.db.SelectContext(ctx, &ps, `select * from product where id_brand in
+	if err := rep.db.SelectContext(ctx, &ps, selectProduct+` where p.id_brand in
 	 (select id from brand where name = $1)`, brand); err != nil {
 		return nil, err
 	}
 
 	var products []structs.Product
+	for _, v := range ps {
+		products = append(products, toProduct(v))
+	}
+	return products, nil
+}
 
+// Search returns the products matching every set field of the filter in
+// the requested order. The query is assembled from one condition per field.
+func (rep *Repository) Search(ctx context.Context, f structs.ProductFilter) ([]structs.Product, error) {
+	var (
+		with  string
+		conds []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if f.IdCategory != uuid.Nil {
+		with = `with recursive tree as (
+			select id from category where id = ` + arg(f.IdCategory) + `
+			union all
+			select c.id from category c join tree t on c.id_parent = t.id
+		) `
+		conds = append(conds, `p.id_category in (select id from tree)`)
+	}
+	if f.Brand != "" {
+		conds = append(conds, `p.id_brand in (select id from brand where name = `+arg(f.Brand)+`)`)
+	}
+	if len(f.IncludeIngredients) > 0 {
+		conds = append(conds, `(select count(distinct pi.id_ingredient)
+			from product_ingredient pi join ingredient i on i.id = pi.id_ingredient
+			where pi.id_product = p.id and lower(i.inci_name) = any(`+arg(pq.Array(f.IncludeIngredients))+`)) = `+
+			arg(len(f.IncludeIngredients)))
+	}
+	if len(f.ExcludeIngredients) > 0 {
+		conds = append(conds, `not exists (select 1
+			from product_ingredient pi join ingredient i on i.id = pi.id_ingredient
+			where pi.id_product = p.id and lower(i.inci_name) = any(`+arg(pq.Array(f.ExcludeIngredients))+`))`)
+	}
+	for _, kind := range structs.AttributeKinds {
+		codes := f.Attributes[kind]
+		if len(codes) == 0 {
+			continue
+		}
+		conds = append(conds, `exists (select 1
+			from product_attribute pa join attribute a on a.id = pa.id_attribute
+			where pa.id_product = p.id and a.kind = `+arg(kind)+` and a.code = any(`+arg(pq.Array(codes))+`))`)
+	}
+	if f.Vegan {
+		conds = append(conds, `coalesce(pp.vegan, false)`)
+	}
+	if f.CrueltyFree {
+		conds = append(conds, `coalesce(pp.cruelty_free, false)`)
+	}
+	if f.MinSpf > 0 {
+		conds = append(conds, `coalesce(pp.spf, 0) >= `+arg(f.MinSpf))
+	}
+	if f.MinRating > 0 {
+		conds = append(conds, `coalesce(r.rating_avg, 0) >= `+arg(f.MinRating))
+	}
+
+	query := with + selectProduct + `
+		left join product_properties pp on pp.id_product = p.id`
+	if len(conds) > 0 {
+		query += ` where ` + strings.Join(conds, ` and `)
+	}
+	switch f.Sort {
+	case structs.ProductSortRating:
+		query += ` order by coalesce(r.rating_avg, 0) desc, coalesce(r.rating_count, 0) desc, p.name`
+	case structs.ProductSortReviews:
+		query += ` order by coalesce(r.rating_count, 0) desc, p.name`
+	default:
+		query += ` order by p.name`
+	}
+
+	var ps []rep_structs.Product
+	if err := rep.db.SelectContext(ctx, &ps, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+
+	products := make([]structs.Product, 0, len(ps))
 	for _, v := range ps {
-		products = append(products, structs.Product{
-			Id:          v.Id,
-			Name:        v.Name,
-			Description: v.Description,
-			Price:       v.Price,
-			Category:    v.Category,
-			Amount:      v.Amount,
-			IdBrand:     v.IdBrand,
-			PicLink:     v.PicLink,
-			Articule:    v.Articule,
-		})
+		products = append(products, toProduct(v))
 	}
 	return products, nil
 }
@@ -164,3 +222,101 @@ func (rep *Repository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+func (rep *Repository) CreateVariant(ctx context.Context, v structs.ProductVariant) error {
+	pv := rep_structs.ProductVariant{
+		IdProduct: v.IdProduct,
+		Articule:  v.Articule,
+		Shade:     v.Shade,
+		VolumeMl:  v.VolumeMl,
+		Price:     v.Price,
+		Amount:    v.Amount,
+		Weight:    v.Weight,
+	}
+	_, err := rep.db.NamedExecContext(ctx,
+		`insert into product_variant
+		(id_product, art, shade, volume_ml, price, amount, weight)
+		values
+		(:id_product, :art, nullif(:shade, ''), nullif(:volume_ml, 0), :price, :amount, nullif(:weight, 0))`,
+		pv)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return fmt.Errorf("%w: %s", structs.ErrDuplicateArticule, pqErr.Detail)
+		case "23503":
+			return structs.ErrProductNotFound
+		}
+	}
+	return err
+}
+
+func (rep *Repository) GetVariants(ctx context.Context, id_product uuid.UUID) ([]structs.ProductVariant, error) {
+	var vs []rep_structs.ProductVariant
+	err := rep.db.SelectContext(ctx, &vs,
+		`select v.id, v.id_product, v.art, coalesce(v.shade, '') as shade, coalesce(v.volume_ml, 0) as volume_ml,
+			coalesce(v.weight, 0) as weight, v.price, v.amount, v.amount - coalesce((select sum(sr.amount) from stock_reservation sr
+				where sr.id_variant = v.id and sr.expires_at > localtimestamp), 0)
+				- coalesce((select sum(l.amount) from stock_lot l
+				where l.id_variant = v.id and l.expires_at < current_date), 0) as available
+		from product_variant v where v.id_product = $1 order by v.shade, v.volume_ml`, id_product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variants: %w", err)
+	}
+
+	var variants []structs.ProductVariant
+	for _, v := range vs {
+		variants = append(variants, structs.ProductVariant{
+			Id:        v.Id,
+			IdProduct: v.IdProduct,
+			Articule:  v.Articule,
+			Shade:     v.Shade,
+			VolumeMl:  v.VolumeMl,
+			Weight:    v.Weight,
+			Price:     v.Price,
+			Amount:    v.Amount,
+			Available: v.Available,
+		})
+	}
+	return variants, nil
+}
+
+func (rep *Repository) DeleteVariant(ctx context.Context, id_product uuid.UUID, id uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx,
+		`delete from product_variant where id = $1 and id_product = $2`, id, id_product)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == "fk_order_item_variant" {
+		return structs.ErrVariantOrdered
+	}
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func toProduct(p rep_structs.Product) structs.Product {
+	return structs.Product{
+		Id:          p.Id,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		IdCategory:  p.IdCategory.UUID,
+		Amount:      p.Amount,
+		Available:   p.Available,
+		IdBrand:     p.IdBrand,
+		PicLink:     p.PicLink,
+		Articule:    p.Articule,
+		Weight:      p.Weight,
+		Rating: structs.ProductRating{
+			Average:   p.RatingAvg,
+			Count:     p.RatingCount,
+			Histogram: [5]int{p.Stars1, p.Stars2, p.Stars3, p.Stars4, p.Stars5},
+		},
+	}
+}
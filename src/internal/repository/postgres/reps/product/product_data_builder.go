@@ -27,7 +27,7 @@ func NewProductBuilder() *ProductBuilder {
 			Name:        "Test Product",
 			Description: "Test Description",
 			Price:       99.99,
-			Category:    "electronics",
+			IdCategory:  structs.GenId(),
 			Amount:      10,
 			IdBrand:     uuid.New(),
 			PicLink:     "http://example.com/pic.jpg",
@@ -56,8 +56,8 @@ func (b *ProductBuilder) WithPrice(price float64) *ProductBuilder {
 	return b
 }
 
-func (b *ProductBuilder) WithCategory(category string) *ProductBuilder {
-	b.product.Category = category
+func (b *ProductBuilder) WithCategory(id_category uuid.UUID) *ProductBuilder {
+	b.product.IdCategory = id_category
 	return b
 }
 
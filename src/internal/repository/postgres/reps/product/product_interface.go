@@ -12,7 +12,11 @@ type ProductRepositoryInterface interface {
 	GetById(ctx context.Context, id uuid.UUID) (structs.Product, error)
 	GetByName(ctx context.Context, name string) (structs.Product, error)
 	GetByArticule(ctx context.Context, art string) (structs.Product, error)
-	GetByCategory(ctx context.Context, category string) ([]structs.Product, error)
+	GetByCategory(ctx context.Context, id_category uuid.UUID) ([]structs.Product, error)
 	GetByBrand(ctx context.Context, brand string) ([]structs.Product, error)
+	Search(ctx context.Context, f structs.ProductFilter) ([]structs.Product, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	CreateVariant(ctx context.Context, v structs.ProductVariant) error
+	GetVariants(ctx context.Context, id_product uuid.UUID) ([]structs.ProductVariant, error)
+	DeleteVariant(ctx context.Context, id_product uuid.UUID, id uuid.UUID) error
 }
@@ -3,10 +3,12 @@ package product_rep
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	structs "github.com/taucuya/ppo/internal/core/structs"
@@ -31,11 +33,12 @@ func TestCreate(t *testing.T) {
 						product.Name,
 						product.Description,
 						product.Price,
-						product.Category,
+						product.IdCategory,
 						product.Amount,
 						product.IdBrand,
 						product.PicLink,
 						product.Articule,
+						product.Weight,
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
@@ -49,16 +52,36 @@ func TestCreate(t *testing.T) {
 						product.Name,
 						product.Description,
 						product.Price,
-						product.Category,
+						product.IdCategory,
 						product.Amount,
 						product.IdBrand,
 						product.PicLink,
 						product.Articule,
+						product.Weight,
 					).
 					WillReturnError(errTest)
 			},
 			expectedErr: errTest,
 		},
+		{
+			name: "articule taken by a variant",
+			setupMocks: func(product structs.Product) {
+				fixture.mock.ExpectExec(`insert into product`).
+					WithArgs(
+						product.Name,
+						product.Description,
+						product.Price,
+						product.IdCategory,
+						product.Amount,
+						product.IdBrand,
+						product.PicLink,
+						product.Articule,
+						product.Weight,
+					).
+					WillReturnError(&pq.Error{Code: "23505", Constraint: "articule_unique", Detail: "art exists"})
+			},
+			expectedErr: fmt.Errorf("%w: art exists", structs.ErrDuplicateArticule),
+		},
 	}
 
 	for _, tt := range tests {
@@ -78,6 +101,7 @@ func TestGetById(t *testing.T) {
 	fixture := NewTestFixture(t)
 
 	testProduct := fixture.productBuilder.Build()
+	testProduct.Rating = structs.ProductRating{Average: 4.5, Count: 4, Histogram: [5]int{0, 0, 0, 2, 2}}
 
 	tests := []struct {
 		name        string
@@ -88,9 +112,11 @@ func TestGetById(t *testing.T) {
 		{
 			name: "successful get by id",
 			setupMocks: func(product structs.Product) {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "category", "amount", "id_brand", "pic_link", "art"}).
-					AddRow(product.Id, product.Name, product.Description, product.Price, product.Category, product.Amount, product.IdBrand, product.PicLink, product.Articule)
-				fixture.mock.ExpectQuery(`select \* from product where id = \$1`).
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "id_category", "amount", "id_brand", "pic_link", "art",
+					"rating_avg", "rating_count", "stars_1", "stars_2", "stars_3", "stars_4", "stars_5"}).
+					AddRow(product.Id, product.Name, product.Description, product.Price, product.IdCategory, product.Amount, product.IdBrand, product.PicLink, product.Articule,
+						4.5, 4, 0, 0, 0, 2, 2)
+				fixture.mock.ExpectQuery(`select p\.\*, .* from product p left join product_rating r on r.id_product = p.id where p.id = \$1`).
 					WithArgs(product.Id).
 					WillReturnRows(rows)
 			},
@@ -100,7 +126,7 @@ func TestGetById(t *testing.T) {
 		{
 			name: "product not found",
 			setupMocks: func(product structs.Product) {
-				fixture.mock.ExpectQuery(`select \* from product where id = \$1`).
+				fixture.mock.ExpectQuery(`select p\.\*, .* from product p left join product_rating r on r.id_product = p.id where p.id = \$1`).
 					WithArgs(product.Id).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -110,7 +136,7 @@ func TestGetById(t *testing.T) {
 		{
 			name: "database error",
 			setupMocks: func(product structs.Product) {
-				fixture.mock.ExpectQuery(`select \* from product where id = \$1`).
+				fixture.mock.ExpectQuery(`select p\.\*, .* from product p left join product_rating r on r.id_product = p.id where p.id = \$1`).
 					WithArgs(product.Id).
 					WillReturnError(errTest)
 			},
@@ -152,9 +178,9 @@ func TestGetByName(t *testing.T) {
 		{
 			name: "successful get by name",
 			setupMocks: func(product structs.Product) {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "category", "amount", "id_brand", "pic_link", "art"}).
-					AddRow(product.Id, product.Name, product.Description, product.Price, product.Category, product.Amount, product.IdBrand, product.PicLink, product.Articule)
-				fixture.mock.ExpectQuery(`select \* from product where name = \$1`).
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "id_category", "amount", "id_brand", "pic_link", "art"}).
+					AddRow(product.Id, product.Name, product.Description, product.Price, product.IdCategory, product.Amount, product.IdBrand, product.PicLink, product.Articule)
+				fixture.mock.ExpectQuery(`select p\.\*, .* from product p left join product_rating r on r.id_product = p.id where p.name = \$1`).
 					WithArgs(product.Name).
 					WillReturnRows(rows)
 			},
@@ -164,7 +190,7 @@ func TestGetByName(t *testing.T) {
 		{
 			name: "product not found by name",
 			setupMocks: func(product structs.Product) {
-				fixture.mock.ExpectQuery(`select \* from product where name = \$1`).
+				fixture.mock.ExpectQuery(`select p\.\*, .* from product p left join product_rating r on r.id_product = p.id where p.name = \$1`).
 					WithArgs(product.Name).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -206,9 +232,9 @@ func TestGetByArticule(t *testing.T) {
 		{
 			name: "successful get by articule",
 			setupMocks: func(product structs.Product) {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "category", "amount", "id_brand", "pic_link", "art"}).
-					AddRow(product.Id, product.Name, product.Description, product.Price, product.Category, product.Amount, product.IdBrand, product.PicLink, product.Articule)
-				fixture.mock.ExpectQuery(`select \* from product where art = \$1`).
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "id_category", "amount", "id_brand", "pic_link", "art"}).
+					AddRow(product.Id, product.Name, product.Description, product.Price, product.IdCategory, product.Amount, product.IdBrand, product.PicLink, product.Articule)
+				fixture.mock.ExpectQuery(`select p\.\*, .* from product p left join product_rating r on r.id_product = p.id where p.art = \$1`).
 					WithArgs(product.Articule).
 					WillReturnRows(rows)
 			},
@@ -218,7 +244,7 @@ func TestGetByArticule(t *testing.T) {
 		{
 			name: "product not found by articule",
 			setupMocks: func(product structs.Product) {
-				fixture.mock.ExpectQuery(`select \* from product where art = \$1`).
+				fixture.mock.ExpectQuery(`select p\.\*, .* from product p left join product_rating r on r.id_product = p.id where p.art = \$1`).
 					WithArgs(product.Articule).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -249,7 +275,7 @@ func TestGetByCategory(t *testing.T) {
 	t.Parallel()
 	fixture := NewTestFixture(t)
 
-	category := "electronics"
+	category := structs.GenId()
 	testProducts := []structs.Product{
 		fixture.productBuilder.WithCategory(category).Build(),
 		fixture.productBuilder.WithCategory(category).WithName("Product 2").Build(),
@@ -257,20 +283,20 @@ func TestGetByCategory(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		category    string
-		setupMocks  func(string, []structs.Product)
+		category    uuid.UUID
+		setupMocks  func(uuid.UUID, []structs.Product)
 		expectedRet []structs.Product
 		expectedErr error
 	}{
 		{
 			name:     "successful get by category",
 			category: category,
-			setupMocks: func(category string, products []structs.Product) {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "category", "amount", "id_brand", "pic_link", "art"})
+			setupMocks: func(category uuid.UUID, products []structs.Product) {
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "id_category", "amount", "id_brand", "pic_link", "art"})
 				for _, p := range products {
-					rows.AddRow(p.Id, p.Name, p.Description, p.Price, p.Category, p.Amount, p.IdBrand, p.PicLink, p.Articule)
+					rows.AddRow(p.Id, p.Name, p.Description, p.Price, p.IdCategory, p.Amount, p.IdBrand, p.PicLink, p.Articule)
 				}
-				fixture.mock.ExpectQuery(`select \* from product where category = \$1`).
+				fixture.mock.ExpectQuery(`with recursive tree as .* from product p left join product_rating r on r.id_product = p.id where p.id_category in`).
 					WithArgs(category).
 					WillReturnRows(rows)
 			},
@@ -279,10 +305,10 @@ func TestGetByCategory(t *testing.T) {
 		},
 		{
 			name:     "empty category",
-			category: "nonexistent",
-			setupMocks: func(category string, products []structs.Product) {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "category", "amount", "id_brand", "pic_link", "art"})
-				fixture.mock.ExpectQuery(`select \* from product where category = \$1`).
+			category: structs.GenId(),
+			setupMocks: func(category uuid.UUID, products []structs.Product) {
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "id_category", "amount", "id_brand", "pic_link", "art"})
+				fixture.mock.ExpectQuery(`with recursive tree as .* from product p left join product_rating r on r.id_product = p.id where p.id_category in`).
 					WithArgs(category).
 					WillReturnRows(rows)
 			},
@@ -292,8 +318,8 @@ func TestGetByCategory(t *testing.T) {
 		{
 			name:     "database error",
 			category: category,
-			setupMocks: func(category string, products []structs.Product) {
-				fixture.mock.ExpectQuery(`select \* from product where category = \$1`).
+			setupMocks: func(category uuid.UUID, products []structs.Product) {
+				fixture.mock.ExpectQuery(`with recursive tree as .* from product p left join product_rating r on r.id_product = p.id where p.id_category in`).
 					WithArgs(category).
 					WillReturnError(errTest)
 			},
@@ -341,11 +367,11 @@ func TestGetByBrand(t *testing.T) {
 			name:  "successful get by brand",
 			brand: brand,
 			setupMocks: func(brand string, products []structs.Product) {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "category", "amount", "id_brand", "pic_link", "art"})
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "id_category", "amount", "id_brand", "pic_link", "art"})
 				for _, p := range products {
-					rows.AddRow(p.Id, p.Name, p.Description, p.Price, p.Category, p.Amount, p.IdBrand, p.PicLink, p.Articule)
+					rows.AddRow(p.Id, p.Name, p.Description, p.Price, p.IdCategory, p.Amount, p.IdBrand, p.PicLink, p.Articule)
 				}
-				fixture.mock.ExpectQuery(`select \* from product where id_brand in`).
+				fixture.mock.ExpectQuery(`select p\.\*, .* from product p left join product_rating r on r.id_product = p.id where p.id_brand in`).
 					WithArgs(brand).
 					WillReturnRows(rows)
 			},
@@ -356,8 +382,8 @@ func TestGetByBrand(t *testing.T) {
 			name:  "empty brand",
 			brand: "SomeBrand",
 			setupMocks: func(brand string, products []structs.Product) {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "category", "amount", "id_brand", "pic_link", "art"})
-				fixture.mock.ExpectQuery(`select \* from product where id_brand in`).
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "id_category", "amount", "id_brand", "pic_link", "art"})
+				fixture.mock.ExpectQuery(`select p\.\*, .* from product p left join product_rating r on r.id_product = p.id where p.id_brand in`).
 					WithArgs(brand).
 					WillReturnRows(rows)
 			},
@@ -368,7 +394,7 @@ func TestGetByBrand(t *testing.T) {
 			name:  "database error",
 			brand: brand,
 			setupMocks: func(brand string, products []structs.Product) {
-				fixture.mock.ExpectQuery(`select \* from product where id_brand in`).
+				fixture.mock.ExpectQuery(`select p\.\*, .* from product p left join product_rating r on r.id_product = p.id where p.id_brand in`).
 					WithArgs(brand).
 					WillReturnError(errTest)
 			},
@@ -395,6 +421,104 @@ func TestGetByBrand(t *testing.T) {
 	fixture.Cleanup()
 }
 
+func TestSearch(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	category := structs.GenId()
+	testProducts := []structs.Product{fixture.productBuilder.WithCategory(category).Build()}
+	productRows := func(products []structs.Product) *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "id_category", "amount", "id_brand", "pic_link", "art"})
+		for _, p := range products {
+			rows.AddRow(p.Id, p.Name, p.Description, p.Price, p.IdCategory, p.Amount, p.IdBrand, p.PicLink, p.Articule)
+		}
+		return rows
+	}
+
+	tests := []struct {
+		name        string
+		filter      structs.ProductFilter
+		setupMocks  func()
+		expectedRet []structs.Product
+		expectedErr error
+	}{
+		{
+			name:   "no filter",
+			filter: structs.ProductFilter{},
+			setupMocks: func() {
+				fixture.mock.ExpectQuery(`^select p\.\*, .* from product p left join product_rating r on r.id_product = p.id left join product_properties pp on pp.id_product = p.id order by p.name$`).
+					WithArgs().
+					WillReturnRows(productRows(testProducts))
+			},
+			expectedRet: testProducts,
+			expectedErr: nil,
+		},
+		{
+			name: "all conditions in argument order",
+			filter: structs.ProductFilter{
+				IdCategory:         category,
+				Brand:              "Nivea",
+				Attributes:         map[string][]string{structs.AttributeFinish: {"matte"}, structs.AttributeSkinType: {"oily", "combination"}},
+				IncludeIngredients: []string{"glycerin", "aqua"},
+				ExcludeIngredients: []string{"parfum"},
+				Vegan:              true,
+				CrueltyFree:        true,
+				MinSpf:             30,
+				MinRating:          4,
+				Sort:               structs.ProductSortRating,
+			},
+			setupMocks: func() {
+				fixture.mock.ExpectQuery(`^with recursive tree as \( select id from category where id = \$1 .* `+
+					`where p.id_category in \(select id from tree\) `+
+					`and p.id_brand in \(select id from brand where name = \$2\) `+
+					`and \(select count\(distinct pi.id_ingredient\) .* any\(\$3\)\) = \$4 `+
+					`and not exists \(.* any\(\$5\)\) `+
+					`and exists \(.* a.kind = \$6 and a.code = any\(\$7\)\) `+
+					`and exists \(.* a.kind = \$8 and a.code = any\(\$9\)\) `+
+					`and coalesce\(pp.vegan, false\) and coalesce\(pp.cruelty_free, false\) `+
+					`and coalesce\(pp.spf, 0\) >= \$10 and coalesce\(r.rating_avg, 0\) >= \$11 `+
+					`order by coalesce\(r.rating_avg, 0\) desc, coalesce\(r.rating_count, 0\) desc, p.name$`).
+					WithArgs(category, "Nivea",
+						pq.Array([]string{"glycerin", "aqua"}), 2,
+						pq.Array([]string{"parfum"}),
+						structs.AttributeSkinType, pq.Array([]string{"oily", "combination"}),
+						structs.AttributeFinish, pq.Array([]string{"matte"}),
+						30, 4.0).
+					WillReturnRows(productRows(testProducts))
+			},
+			expectedRet: testProducts,
+			expectedErr: nil,
+		},
+		{
+			name:   "database error",
+			filter: structs.ProductFilter{Vegan: true},
+			setupMocks: func() {
+				fixture.mock.ExpectQuery(`where coalesce\(pp.vegan, false\)`).
+					WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: fmt.Errorf("failed to search products: %w", errTest),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			ret, err := fixture.repo.Search(fixture.ctx, tt.filter)
+
+			if tt.expectedErr != nil {
+				fixture.AssertError(err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRet, ret)
+			}
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
 func TestDelete(t *testing.T) {
 	t.Parallel()
 	fixture := NewTestFixture(t)
@@ -446,3 +570,172 @@ func TestDelete(t *testing.T) {
 	}
 	fixture.Cleanup()
 }
+
+func TestCreateVariant(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	testProduct := fixture.productBuilder.Build()
+	variant := structs.ProductVariant{
+		IdProduct: testProduct.Id,
+		Articule:  "TEST-RED",
+		Shade:     "красный",
+		Price:     799,
+		Amount:    4,
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "successful creation",
+			setupMocks: func() {
+				fixture.mock.ExpectExec(`insert into product_variant`).
+					WithArgs(variant.IdProduct, variant.Articule, variant.Shade, variant.VolumeMl, variant.Price, variant.Amount, variant.Weight).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "duplicate articule",
+			setupMocks: func() {
+				fixture.mock.ExpectExec(`insert into product_variant`).
+					WithArgs(variant.IdProduct, variant.Articule, variant.Shade, variant.VolumeMl, variant.Price, variant.Amount, variant.Weight).
+					WillReturnError(&pq.Error{Code: "23505", Detail: "art exists"})
+			},
+			expectedErr: fmt.Errorf("%w: art exists", structs.ErrDuplicateArticule),
+		},
+		{
+			name: "unknown product",
+			setupMocks: func() {
+				fixture.mock.ExpectExec(`insert into product_variant`).
+					WithArgs(variant.IdProduct, variant.Articule, variant.Shade, variant.VolumeMl, variant.Price, variant.Amount, variant.Weight).
+					WillReturnError(&pq.Error{Code: "23503"})
+			},
+			expectedErr: structs.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := fixture.repo.CreateVariant(fixture.ctx, variant)
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetVariants(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	testProduct := fixture.productBuilder.Build()
+	variant := structs.ProductVariant{
+		Id:        uuid.New(),
+		IdProduct: testProduct.Id,
+		Articule:  "TEST-50",
+		VolumeMl:  50,
+		Price:     1200,
+		Amount:    2,
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedRet []structs.ProductVariant
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMocks: func() {
+				rows := sqlmock.NewRows([]string{"id", "id_product", "art", "shade", "volume_ml", "price", "amount"}).
+					AddRow(variant.Id, variant.IdProduct, variant.Articule, "", variant.VolumeMl, variant.Price, variant.Amount)
+				fixture.mock.ExpectQuery(`select .* from product_variant v where v.id_product = \$1`).
+					WithArgs(testProduct.Id).
+					WillReturnRows(rows)
+			},
+			expectedRet: []structs.ProductVariant{variant},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMocks: func() {
+				fixture.mock.ExpectQuery(`select .* from product_variant v where v.id_product = \$1`).
+					WithArgs(testProduct.Id).
+					WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: fmt.Errorf("failed to get variants: %w", errTest),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			ret, err := fixture.repo.GetVariants(fixture.ctx, testProduct.Id)
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDeleteVariant(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	testProduct := fixture.productBuilder.Build()
+	variantID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			setupMocks: func() {
+				fixture.mock.ExpectExec(`delete from product_variant where id = \$1 and id_product = \$2`).
+					WithArgs(variantID, testProduct.Id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "variant not found",
+			setupMocks: func() {
+				fixture.mock.ExpectExec(`delete from product_variant where id = \$1 and id_product = \$2`).
+					WithArgs(variantID, testProduct.Id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrVariantNotFound,
+		},
+		{
+			name: "variant has been ordered",
+			setupMocks: func() {
+				fixture.mock.ExpectExec(`delete from product_variant where id = \$1 and id_product = \$2`).
+					WithArgs(variantID, testProduct.Id).
+					WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_order_item_variant"})
+			},
+			expectedErr: structs.ErrVariantOrdered,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := fixture.repo.DeleteVariant(fixture.ctx, testProduct.Id, variantID)
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
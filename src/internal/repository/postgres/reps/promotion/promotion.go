@@ -0,0 +1,204 @@
+package promotion_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const columns = `id, name, kind, code, value, buy_quantity, free_quantity, id_brand, id_category,
+	min_basket, usage_limit, per_user_limit, starts_at, ends_at, active`
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (rep *Repository) Create(ctx context.Context, p structs.Promotion) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := rep.db.GetContext(ctx, &id, `
+		insert into promotion (name, kind, code, value, buy_quantity, free_quantity, id_brand, id_category,
+			min_basket, usage_limit, per_user_limit, starts_at, ends_at, active)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		returning id`,
+		p.Name, p.Kind, sql.NullString{String: p.Code, Valid: p.Code != ""}, p.Value, p.BuyQuantity, p.FreeQuantity,
+		rep_structs.NullId(p.IdBrand), rep_structs.NullId(p.IdCategory), p.MinBasket, p.UsageLimit,
+		p.PerUserLimit, nullTime(p.StartsAt), nullTime(p.EndsAt), p.Active)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Constraint {
+		case "promotion_code_unique":
+			return uuid.Nil, structs.ErrDuplicatePromoCode
+		case "fk_promotion_brand":
+			return uuid.Nil, structs.ErrBrandNotFound
+		case "fk_promotion_category":
+			return uuid.Nil, structs.ErrCategoryNotFound
+		}
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+	return id, nil
+}
+
+func (rep *Repository) GetAll(ctx context.Context) ([]structs.Promotion, error) {
+	var ps []rep_structs.Promotion
+	err := rep.db.SelectContext(ctx, &ps, `select `+columns+` from promotion order by active desc, name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotions: %w", err)
+	}
+	return toPromotions(ps), nil
+}
+
+func (rep *Repository) GetById(ctx context.Context, id uuid.UUID) (structs.Promotion, error) {
+	return rep.get(ctx, `select `+columns+` from promotion where id = $1`, id)
+}
+
+func (rep *Repository) GetByCode(ctx context.Context, code string) (structs.Promotion, error) {
+	return rep.get(ctx, `select `+columns+` from promotion where code = $1`, code)
+}
+
+func (rep *Repository) get(ctx context.Context, query string, arg any) (structs.Promotion, error) {
+	var p rep_structs.Promotion
+	err := rep.db.GetContext(ctx, &p, query, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.Promotion{}, structs.ErrPromotionNotFound
+	}
+	if err != nil {
+		return structs.Promotion{}, fmt.Errorf("failed to get promotion: %w", err)
+	}
+	return toPromotion(p), nil
+}
+
+func (rep *Repository) Deactivate(ctx context.Context, id uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx, `update promotion set active = false where id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate promotion: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrPromotionNotFound
+	}
+	return nil
+}
+
+// GetAutomatic returns the active promotions that need no code. The
+// validity window is checked by the service.
+func (rep *Repository) GetAutomatic(ctx context.Context) ([]structs.Promotion, error) {
+	var ps []rep_structs.Promotion
+	err := rep.db.SelectContext(ctx, &ps, `select `+columns+` from promotion where code is null and active`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotions: %w", err)
+	}
+	return toPromotions(ps), nil
+}
+
+// GetRedemptions returns how many orders used the promotion in total and how
+// many of them belong to the user.
+func (rep *Repository) GetRedemptions(ctx context.Context, id uuid.UUID, id_user uuid.UUID) (int, int, error) {
+	var total, mine int
+	err := rep.db.QueryRowContext(ctx, `
+		select count(*), count(*) filter (where id_user = $2)
+		from promotion_redemption where id_promotion = $1`, id, id_user).Scan(&total, &mine)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count promotion redemptions: %w", err)
+	}
+	return total, mine, nil
+}
+
+// GetBasketLines returns the basket of the user with current prices, brands
+// and the category of every product together with its ancestors.
+func (rep *Repository) GetBasketLines(ctx context.Context, id_user uuid.UUID) ([]structs.CheckoutLine, error) {
+	var ls []rep_structs.CheckoutLine
+	err := rep.db.SelectContext(ctx, &ls, `
+		with recursive ancestor as (
+			select id as id_category, id, id_parent from category
+			union all
+			select a.id_category, c.id, c.id_parent
+			from ancestor a join category c on c.id = a.id_parent
+		)
+		select bi.id_product, bi.id_variant, p.id_brand,
+			array(select a.id::text from ancestor a where a.id_category = p.id_category) as categories,
+			coalesce(v.price, p.price) as unit_price, bi.amount
+		from basket b
+		join basket_item bi on bi.id_basket = b.id
+		join product p on p.id = bi.id_product
+		left join product_variant v on v.id = bi.id_variant
+		where b.id_user = $1
+		order by bi.id`, id_user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get basket lines: %w", err)
+	}
+
+	res := make([]structs.CheckoutLine, len(ls))
+	for i, l := range ls {
+		categories := make([]uuid.UUID, len(l.Categories))
+		for j, c := range l.Categories {
+			if categories[j], err = uuid.Parse(c); err != nil {
+				return nil, fmt.Errorf("failed to parse category id: %w", err)
+			}
+		}
+		res[i] = structs.CheckoutLine{
+			IdProduct:  l.IdProduct,
+			IdVariant:  l.IdVariant.UUID,
+			IdBrand:    l.IdBrand.UUID,
+			Categories: categories,
+			UnitPrice:  l.UnitPrice,
+			Amount:     l.Amount,
+		}
+	}
+	return res, nil
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func toPromotions(ps []rep_structs.Promotion) []structs.Promotion {
+	res := make([]structs.Promotion, len(ps))
+	for i, p := range ps {
+		res[i] = toPromotion(p)
+	}
+	return res
+}
+
+func toPromotion(p rep_structs.Promotion) structs.Promotion {
+	res := structs.Promotion{
+		Id:           p.Id,
+		Name:         p.Name,
+		Kind:         p.Kind,
+		Code:         p.Code.String,
+		Value:        p.Value,
+		BuyQuantity:  p.BuyQuantity,
+		FreeQuantity: p.FreeQuantity,
+		IdBrand:      p.IdBrand.UUID,
+		IdCategory:   p.IdCategory.UUID,
+		MinBasket:    p.MinBasket,
+		UsageLimit:   p.UsageLimit,
+		PerUserLimit: p.PerUserLimit,
+		Active:       p.Active,
+	}
+	if p.StartsAt.Valid {
+		startsAt := p.StartsAt.Time
+		res.StartsAt = &startsAt
+	}
+	if p.EndsAt.Valid {
+		endsAt := p.EndsAt.Time
+		res.EndsAt = &endsAt
+	}
+	return res
+}
@@ -0,0 +1,78 @@
+package promotion_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var promotionColumns = []string{"id", "name", "kind", "code", "value", "buy_quantity", "free_quantity",
+	"id_brand", "id_category", "min_basket", "usage_limit", "per_user_limit", "starts_at", "ends_at", "active"}
+
+type TestFixture struct {
+	t         *testing.T
+	db        *sql.DB
+	sqlxDB    *sqlx.DB
+	mock      sqlmock.Sqlmock
+	repo      *Repository
+	ctx       context.Context
+	promotion structs.Promotion
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	end := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		promotion: structs.Promotion{
+			Id:           structs.GenId(),
+			Name:         "Welcome",
+			Kind:         structs.PromotionFixed,
+			Code:         "WELCOME500",
+			Value:        500,
+			IdBrand:      structs.GenId(),
+			MinBasket:    3000,
+			PerUserLimit: 1,
+			EndsAt:       &end,
+			Active:       true,
+		},
+	}
+}
+
+func (f *TestFixture) promotionRow(rows *sqlmock.Rows) *sqlmock.Rows {
+	p := f.promotion
+	return rows.AddRow(p.Id, p.Name, p.Kind, p.Code, p.Value, p.BuyQuantity, p.FreeQuantity, p.IdBrand, nil,
+		p.MinBasket, p.UsageLimit, p.PerUserLimit, nil, *p.EndsAt, p.Active)
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
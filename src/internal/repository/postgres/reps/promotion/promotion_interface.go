@@ -0,0 +1,19 @@
+package promotion_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type PromotionRepositoryInterface interface {
+	Create(ctx context.Context, p structs.Promotion) (uuid.UUID, error)
+	GetAll(ctx context.Context) ([]structs.Promotion, error)
+	GetById(ctx context.Context, id uuid.UUID) (structs.Promotion, error)
+	Deactivate(ctx context.Context, id uuid.UUID) error
+	GetAutomatic(ctx context.Context) ([]structs.Promotion, error)
+	GetByCode(ctx context.Context, code string) (structs.Promotion, error)
+	GetRedemptions(ctx context.Context, id uuid.UUID, id_user uuid.UUID) (int, int, error)
+	GetBasketLines(ctx context.Context, id_user uuid.UUID) ([]structs.CheckoutLine, error)
+}
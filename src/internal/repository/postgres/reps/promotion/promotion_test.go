@@ -0,0 +1,244 @@
+package promotion_rep
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	p := fixture.promotion
+
+	expectInsert := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into promotion \(name, kind, code, value, .*\) values .* returning id`).
+			WithArgs(p.Name, p.Kind, sql.NullString{String: p.Code, Valid: true}, p.Value, p.BuyQuantity, p.FreeQuantity,
+				uuid.NullUUID{UUID: p.IdBrand, Valid: true}, uuid.NullUUID{}, p.MinBasket, p.UsageLimit,
+				p.PerUserLimit, sql.NullTime{}, sql.NullTime{Time: *p.EndsAt, Valid: true}, p.Active)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "successful creation",
+			setupMock: func() {
+				expectInsert().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(p.Id))
+			},
+			expectedRet: p.Id,
+			expectedErr: nil,
+		},
+		{
+			name: "duplicate code",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23505", Constraint: "promotion_code_unique"})
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDuplicatePromoCode,
+		},
+		{
+			name: "unknown brand",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_promotion_brand"})
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrBrandNotFound,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectInsert().WillReturnError(errTest)
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.Create(fixture.ctx, p)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetByCode_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet structs.Promotion
+		expectedErr error
+	}{
+		{
+			name: "promotion found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select id, name, kind, code, .* from promotion where code = \$1`).
+					WithArgs(fixture.promotion.Code).
+					WillReturnRows(fixture.promotionRow(sqlmock.NewRows(promotionColumns)))
+			},
+			expectedRet: fixture.promotion,
+			expectedErr: nil,
+		},
+		{
+			name: "promotion not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select id, name, kind, code, .* from promotion where code = \$1`).
+					WithArgs(fixture.promotion.Code).
+					WillReturnRows(sqlmock.NewRows(promotionColumns))
+			},
+			expectedRet: structs.Promotion{},
+			expectedErr: structs.ErrPromotionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetByCode(fixture.ctx, fixture.promotion.Code)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDeactivate_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id := fixture.promotion.Id
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful deactivation",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`update promotion set active = false where id = \$1`).
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "promotion not found",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`update promotion set active = false where id = \$1`).
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrPromotionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Deactivate(fixture.ctx, id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetRedemptions_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id := fixture.promotion.Id
+	id_user := structs.GenId()
+
+	fixture.mock.ExpectQuery(`select count\(\*\), count\(\*\) filter \(where id_user = \$2\) from promotion_redemption where id_promotion = \$1`).
+		WithArgs(id, id_user).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "count"}).AddRow(12, 1))
+
+	total, mine, err := fixture.repo.GetRedemptions(fixture.ctx, id, id_user)
+
+	require.NoError(t, err)
+	assert.Equal(t, 12, total)
+	assert.Equal(t, 1, mine)
+	require.NoError(t, fixture.mock.ExpectationsWereMet())
+	fixture.Cleanup()
+}
+
+func TestGetBasketLines_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id_user := structs.GenId()
+	line := structs.CheckoutLine{
+		IdProduct:  structs.GenId(),
+		IdVariant:  structs.GenId(),
+		IdBrand:    structs.GenId(),
+		Categories: []uuid.UUID{structs.GenId(), structs.GenId()},
+		UnitPrice:  1200,
+		Amount:     2,
+	}
+	columns := []string{"id_product", "id_variant", "id_brand", "categories", "unit_price", "amount"}
+	query := `with recursive ancestor as .* from basket b join basket_item bi .* where b.id_user = \$1`
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.CheckoutLine
+		expectedErr error
+	}{
+		{
+			name: "lines with category ancestors",
+			setupMock: func() {
+				categories := "{" + line.Categories[0].String() + "," + line.Categories[1].String() + "}"
+				fixture.mock.ExpectQuery(query).
+					WithArgs(id_user).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(line.IdProduct, line.IdVariant, line.IdBrand, categories, line.UnitPrice, line.Amount))
+			},
+			expectedRet: []structs.CheckoutLine{line},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(query).WithArgs(id_user).WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetBasketLines(fixture.ctx, id_user)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,359 @@
+package purchase_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const (
+	orderFields = `id, id_supplier, status, expected_at, coalesce(note, '') as note, created_at`
+
+	// receiveLine adds the delivered quantity to the line and posts it to
+	// the stock ledger, whose trigger raises the stock.
+	receiveLine = `
+		with line as (
+			update purchase_line set received = received + $2
+			where id = $1 and id_order = $3
+			returning id_product, id_variant)
+		insert into stock_movement (id_product, id_variant, kind, quantity, reason, id_actor, reference)
+		select id_product, id_variant, 'receipt', $2, 'приемка заказа поставщику', $4, $5 from line`
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (rep *Repository) CreateSupplier(ctx context.Context, s structs.Supplier) (uuid.UUID, error) {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	var id uuid.UUID
+	err = tx.GetContext(ctx, &id, `
+		insert into supplier (name, email, phone) values ($1, nullif($2, ''), nullif($3, ''))
+		returning id`, s.Name, s.Email, s.Phone)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == "supplier_name_unique" {
+		return uuid.Nil, structs.ErrDuplicateSupplier
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create supplier: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		insert into supplier_brand (id_supplier, id_brand) select $1, unnest($2::uuid[])`,
+		id, pq.Array(s.Brands))
+	if errors.As(err, &pqErr) && pqErr.Constraint == "fk_supplier_brand_brand" {
+		return uuid.Nil, structs.ErrBrandNotFound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to link supplier brands: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (rep *Repository) GetSuppliers(ctx context.Context) ([]structs.Supplier, error) {
+	var rs []rep_structs.Supplier
+	err := rep.db.SelectContext(ctx, &rs, `
+		select s.id, s.name, coalesce(s.email, '') as email, coalesce(s.phone, '') as phone,
+			array_remove(array_agg(sb.id_brand::text order by sb.id_brand), null) as brands
+		from supplier s left join supplier_brand sb on sb.id_supplier = s.id
+		group by s.id
+		order by s.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suppliers: %w", err)
+	}
+
+	res := make([]structs.Supplier, len(rs))
+	for i, r := range rs {
+		brands := make([]uuid.UUID, len(r.Brands))
+		for j, b := range r.Brands {
+			if brands[j], err = uuid.Parse(b); err != nil {
+				return nil, fmt.Errorf("failed to parse brand id: %w", err)
+			}
+		}
+		res[i] = structs.Supplier{
+			Id:     r.Id,
+			Name:   r.Name,
+			Email:  r.Email,
+			Phone:  r.Phone,
+			Brands: brands,
+		}
+	}
+	return res, nil
+}
+
+func (rep *Repository) DeleteSupplier(ctx context.Context, id uuid.UUID) error {
+	result, err := rep.db.ExecContext(ctx, `delete from supplier where id = $1`, id)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == "fk_purchase_order_supplier" {
+		return structs.ErrSupplierHasOrders
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete supplier: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return structs.ErrSupplierNotFound
+	}
+	return nil
+}
+
+// CreateOrder stores the order with its lines and then checks that every
+// product is of a brand of the supplier.
+func (rep *Repository) CreateOrder(ctx context.Context, po structs.PurchaseOrder) (uuid.UUID, error) {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	var id uuid.UUID
+	err = tx.GetContext(ctx, &id, `
+		insert into purchase_order (id_supplier, status, expected_at, note)
+		values ($1, $2, $3, nullif($4, ''))
+		returning id`, po.IdSupplier, po.Status, nullTime(po.ExpectedAt), po.Note)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == "fk_purchase_order_supplier" {
+		return uuid.Nil, structs.ErrSupplierNotFound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create purchase order: %w", err)
+	}
+
+	for _, l := range po.Lines {
+		_, err = tx.ExecContext(ctx, `
+			insert into purchase_line (id_order, id_product, id_variant, ordered) values ($1, $2, $3, $4)`,
+			id, l.IdProduct, rep_structs.NullId(l.IdVariant), l.Ordered)
+		if errors.As(err, &pqErr) {
+			switch pqErr.Constraint {
+			case "fk_purchase_line_product":
+				return uuid.Nil, structs.ErrProductNotFound
+			case "fk_purchase_line_variant":
+				return uuid.Nil, structs.ErrVariantNotFound
+			case "purchase_line_item_unique", "purchase_line_ordered_check":
+				return uuid.Nil, structs.ErrInvalidPurchaseOrder
+			}
+		}
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to create purchase order line: %w", err)
+		}
+	}
+
+	var foreign bool
+	err = tx.GetContext(ctx, &foreign, `
+		select exists (
+			select 1 from purchase_line l join product p on p.id = l.id_product
+			where l.id_order = $1 and not exists (
+				select 1 from supplier_brand sb where sb.id_supplier = $2 and sb.id_brand = p.id_brand))`,
+		id, po.IdSupplier)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to check supplier brands: %w", err)
+	}
+	if foreign {
+		return uuid.Nil, structs.ErrProductNotSupplied
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+// GetOrders returns the orders with the status, all of them when the status
+// is empty, newest first. Lines are only loaded by GetOrder.
+func (rep *Repository) GetOrders(ctx context.Context, status string) ([]structs.PurchaseOrder, error) {
+	var rs []rep_structs.PurchaseOrder
+	err := rep.db.SelectContext(ctx, &rs, `select `+orderFields+` from purchase_order
+		where $1 = '' or status = $1
+		order by created_at desc, id`, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase orders: %w", err)
+	}
+
+	res := make([]structs.PurchaseOrder, len(rs))
+	for i, r := range rs {
+		res[i] = toOrder(r)
+	}
+	return res, nil
+}
+
+func (rep *Repository) GetOrder(ctx context.Context, id uuid.UUID) (structs.PurchaseOrder, error) {
+	var r rep_structs.PurchaseOrder
+	err := rep.db.GetContext(ctx, &r, `select `+orderFields+` from purchase_order where id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.PurchaseOrder{}, structs.ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return structs.PurchaseOrder{}, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+	po := toOrder(r)
+
+	var ls []rep_structs.PurchaseLine
+	err = rep.db.SelectContext(ctx, &ls, `
+		select id, id_product, id_variant, ordered, received from purchase_line
+		where id_order = $1
+		order by id_product, id_variant nulls first`, id)
+	if err != nil {
+		return structs.PurchaseOrder{}, fmt.Errorf("failed to get purchase order lines: %w", err)
+	}
+	for _, l := range ls {
+		po.Lines = append(po.Lines, structs.PurchaseLine{
+			Id:        l.Id,
+			IdProduct: l.IdProduct,
+			IdVariant: l.IdVariant.UUID,
+			Ordered:   l.Ordered,
+			Received:  l.Received,
+		})
+	}
+
+	var ds []rep_structs.PurchaseDiscrepancy
+	err = rep.db.SelectContext(ctx, &ds, `
+		select id, id_line, ordered, received, difference, created_at from purchase_discrepancy
+		where id_order = $1
+		order by created_at, id`, id)
+	if err != nil {
+		return structs.PurchaseOrder{}, fmt.Errorf("failed to get purchase order discrepancies: %w", err)
+	}
+	for _, d := range ds {
+		po.Discrepancies = append(po.Discrepancies, structs.PurchaseDiscrepancy{
+			Id:         d.Id,
+			IdLine:     d.IdLine,
+			Ordered:    d.Ordered,
+			Received:   d.Received,
+			Difference: d.Difference,
+			CreatedAt:  d.CreatedAt,
+		})
+	}
+	return po, nil
+}
+
+func (rep *Repository) SetStatus(ctx context.Context, id uuid.UUID, from string, to string) error {
+	result, err := rep.db.ExecContext(ctx, `update purchase_order set status = $3 where id = $1 and status = $2`,
+		id, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to update purchase order: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		var exists bool
+		err := rep.db.GetContext(ctx, &exists, `select exists (select 1 from purchase_order where id = $1)`, id)
+		if err != nil {
+			return fmt.Errorf("failed to get purchase order: %w", err)
+		}
+		if !exists {
+			return structs.ErrPurchaseOrderNotFound
+		}
+		return structs.ErrPurchaseOrderStatus
+	}
+	return nil
+}
+
+// Receive books the delivery in one transaction. The order row is locked
+// first, then the order is compared with the state the receipt was planned
+// on, so two workers booking deliveries at once cannot both close it or
+// both miss that it is complete.
+func (rep *Repository) Receive(ctx context.Context, seen structs.PurchaseOrder, r structs.PurchaseReceipt, status string,
+	ds []structs.PurchaseDiscrepancy) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.GetContext(ctx, &current, `select status from purchase_order where id = $1 for update`, seen.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock purchase order: %w", err)
+	}
+
+	var received, expected int
+	err = tx.GetContext(ctx, &received, `select coalesce(sum(received), 0) from purchase_line where id_order = $1`,
+		seen.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get purchase order lines: %w", err)
+	}
+	for _, l := range seen.Lines {
+		expected += l.Received
+	}
+	if current != seen.Status || received != expected {
+		return structs.ErrPurchaseOrderChanged
+	}
+
+	for _, l := range r.Lines {
+		if l.Quantity == 0 {
+			continue
+		}
+		result, err := tx.ExecContext(ctx, receiveLine, l.IdLine, l.Quantity, seen.Id,
+			rep_structs.NullId(r.IdWorker), seen.Id.String())
+		if err != nil {
+			return fmt.Errorf("failed to receive purchase order line: %w", err)
+		}
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return structs.ErrPurchaseLineNotFound
+		}
+	}
+
+	for _, d := range ds {
+		_, err := tx.ExecContext(ctx, `
+			insert into purchase_discrepancy (id_order, id_line, ordered, received, difference)
+			values ($1, $2, $3, $4, $5)`,
+			seen.Id, d.IdLine, d.Ordered, d.Received, d.Difference)
+		if err != nil {
+			return fmt.Errorf("failed to record discrepancy: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `update purchase_order set status = $2 where id = $1`, seen.Id, status)
+	if err != nil {
+		return fmt.Errorf("failed to update purchase order: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func toOrder(r rep_structs.PurchaseOrder) structs.PurchaseOrder {
+	po := structs.PurchaseOrder{
+		Id:         r.Id,
+		IdSupplier: r.IdSupplier,
+		Status:     r.Status,
+		Note:       r.Note,
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.ExpectedAt.Valid {
+		po.ExpectedAt = &r.ExpectedAt.Time
+	}
+	return po
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
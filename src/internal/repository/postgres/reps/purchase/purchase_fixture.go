@@ -0,0 +1,99 @@
+package purchase_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var (
+	orderColumns       = []string{"id", "id_supplier", "status", "expected_at", "note", "created_at"}
+	lineColumns        = []string{"id", "id_product", "id_variant", "ordered", "received"}
+	discrepancyColumns = []string{"id", "id_line", "ordered", "received", "difference", "created_at"}
+)
+
+type TestFixture struct {
+	t        *testing.T
+	db       *sql.DB
+	sqlxDB   *sqlx.DB
+	mock     sqlmock.Sqlmock
+	repo     *Repository
+	ctx      context.Context
+	supplier structs.Supplier
+	order    structs.PurchaseOrder
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	expected := created.Add(7 * 24 * time.Hour)
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		supplier: structs.Supplier{
+			Id:     structs.GenId(),
+			Name:   "Aurora Distribution",
+			Email:  "orders@aurora.test",
+			Brands: []uuid.UUID{structs.GenId()},
+		},
+		order: structs.PurchaseOrder{
+			Id:         structs.GenId(),
+			IdSupplier: structs.GenId(),
+			Status:     structs.PurchaseSent,
+			ExpectedAt: &expected,
+			CreatedAt:  created,
+			Lines: []structs.PurchaseLine{
+				{Id: structs.GenId(), IdProduct: structs.GenId(), Ordered: 10, Received: 4},
+				{Id: structs.GenId(), IdProduct: structs.GenId(), IdVariant: structs.GenId(), Ordered: 5},
+			},
+		},
+	}
+}
+
+func (f *TestFixture) orderRows() *sqlmock.Rows {
+	po := f.order
+	return sqlmock.NewRows(orderColumns).AddRow(po.Id, po.IdSupplier, po.Status, *po.ExpectedAt, po.Note, po.CreatedAt)
+}
+
+func (f *TestFixture) lineRows() *sqlmock.Rows {
+	rows := sqlmock.NewRows(lineColumns)
+	for _, l := range f.order.Lines {
+		var id_variant any
+		if l.IdVariant != uuid.Nil {
+			id_variant = l.IdVariant
+		}
+		rows.AddRow(l.Id, l.IdProduct, id_variant, l.Ordered, l.Received)
+	}
+	return rows
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
@@ -0,0 +1,20 @@
+package purchase_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type PurchaseRepositoryInterface interface {
+	CreateSupplier(ctx context.Context, s structs.Supplier) (uuid.UUID, error)
+	GetSuppliers(ctx context.Context) ([]structs.Supplier, error)
+	DeleteSupplier(ctx context.Context, id uuid.UUID) error
+	CreateOrder(ctx context.Context, po structs.PurchaseOrder) (uuid.UUID, error)
+	GetOrders(ctx context.Context, status string) ([]structs.PurchaseOrder, error)
+	GetOrder(ctx context.Context, id uuid.UUID) (structs.PurchaseOrder, error)
+	SetStatus(ctx context.Context, id uuid.UUID, from string, to string) error
+	Receive(ctx context.Context, seen structs.PurchaseOrder, r structs.PurchaseReceipt, status string,
+		ds []structs.PurchaseDiscrepancy) error
+}
@@ -0,0 +1,448 @@
+package purchase_rep
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreateSupplier_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	s := fixture.supplier
+
+	expectInsert := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into supplier \(name, email, phone\)`).
+			WithArgs(s.Name, s.Email, s.Phone)
+	}
+	expectBrands := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`insert into supplier_brand \(id_supplier, id_brand\) select \$1, unnest\(\$2::uuid\[\]\)`).
+			WithArgs(s.Id, pq.Array(s.Brands))
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "successful create",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectInsert().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(s.Id))
+				expectBrands().WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedRet: s.Id,
+			expectedErr: nil,
+		},
+		{
+			name: "duplicate name",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectInsert().WillReturnError(&pq.Error{Code: "23505", Constraint: "supplier_name_unique"})
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrDuplicateSupplier,
+		},
+		{
+			name: "unknown brand",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectInsert().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(s.Id))
+				expectBrands().WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_supplier_brand_brand"})
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrBrandNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.CreateSupplier(fixture.ctx, s)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetSuppliers_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	s := fixture.supplier
+
+	fixture.mock.ExpectQuery(`from supplier s left join supplier_brand sb on sb.id_supplier = s.id group by s.id order by s.name`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone", "brands"}).
+			AddRow(s.Id, s.Name, s.Email, s.Phone, "{"+s.Brands[0].String()+"}"))
+
+	ret, err := fixture.repo.GetSuppliers(fixture.ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []structs.Supplier{s}, ret)
+	require.NoError(t, fixture.mock.ExpectationsWereMet())
+	fixture.Cleanup()
+}
+
+func TestDeleteSupplier_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id := fixture.supplier.Id
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from supplier where id = \$1`).WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "supplier has orders",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from supplier where id = \$1`).WithArgs(id).
+					WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_purchase_order_supplier"})
+			},
+			expectedErr: structs.ErrSupplierHasOrders,
+		},
+		{
+			name: "supplier not found",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from supplier where id = \$1`).WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: structs.ErrSupplierNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.DeleteSupplier(fixture.ctx, id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCreateOrder_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	po := fixture.order
+	po.Status = structs.PurchaseDraft
+	first, second := po.Lines[0], po.Lines[1]
+
+	expectOrder := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into purchase_order \(id_supplier, status, expected_at, note\)`).
+			WithArgs(po.IdSupplier, po.Status, *po.ExpectedAt, po.Note)
+	}
+	expectLine := func(l structs.PurchaseLine, id_variant any) *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`insert into purchase_line \(id_order, id_product, id_variant, ordered\)`).
+			WithArgs(po.Id, l.IdProduct, id_variant, l.Ordered)
+	}
+	expectBrands := func(foreign bool) {
+		fixture.mock.ExpectQuery(`select exists \( select 1 from purchase_line l join product p on p.id = l.id_product`).
+			WithArgs(po.Id, po.IdSupplier).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(foreign))
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "successful create",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectOrder().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(po.Id))
+				expectLine(first, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectLine(second, second.IdVariant).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectBrands(false)
+				fixture.mock.ExpectCommit()
+			},
+			expectedRet: po.Id,
+			expectedErr: nil,
+		},
+		{
+			name: "unknown supplier",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectOrder().WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_purchase_order_supplier"})
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrSupplierNotFound,
+		},
+		{
+			name: "unknown variant",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectOrder().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(po.Id))
+				expectLine(first, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectLine(second, second.IdVariant).
+					WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_purchase_line_variant"})
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrVariantNotFound,
+		},
+		{
+			name: "product of another brand",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectOrder().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(po.Id))
+				expectLine(first, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectLine(second, second.IdVariant).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectBrands(true)
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrProductNotSupplied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.CreateOrder(fixture.ctx, po)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetOrder_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	po := fixture.order
+	closed := po
+	d := structs.PurchaseDiscrepancy{
+		Id:         structs.GenId(),
+		IdLine:     po.Lines[1].Id,
+		Ordered:    5,
+		Received:   3,
+		Difference: -2,
+		CreatedAt:  po.CreatedAt,
+	}
+	closed.Discrepancies = []structs.PurchaseDiscrepancy{d}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet structs.PurchaseOrder
+		expectedErr error
+	}{
+		{
+			name: "order with lines and discrepancies",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from purchase_order where id = \$1`).WithArgs(po.Id).
+					WillReturnRows(fixture.orderRows())
+				fixture.mock.ExpectQuery(`from purchase_line where id_order = \$1`).WithArgs(po.Id).
+					WillReturnRows(fixture.lineRows())
+				fixture.mock.ExpectQuery(`from purchase_discrepancy where id_order = \$1`).WithArgs(po.Id).
+					WillReturnRows(sqlmock.NewRows(discrepancyColumns).
+						AddRow(d.Id, d.IdLine, d.Ordered, d.Received, d.Difference, d.CreatedAt))
+			},
+			expectedRet: closed,
+			expectedErr: nil,
+		},
+		{
+			name: "order not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`select .* from purchase_order where id = \$1`).WithArgs(po.Id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedRet: structs.PurchaseOrder{},
+			expectedErr: structs.ErrPurchaseOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetOrder(fixture.ctx, po.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestSetStatus_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id := fixture.order.Id
+
+	expectUpdate := func(rows int64) {
+		fixture.mock.ExpectExec(`update purchase_order set status = \$3 where id = \$1 and status = \$2`).
+			WithArgs(id, structs.PurchaseDraft, structs.PurchaseSent).
+			WillReturnResult(sqlmock.NewResult(0, rows))
+	}
+	expectExists := func(exists bool) {
+		fixture.mock.ExpectQuery(`select exists \(select 1 from purchase_order where id = \$1\)`).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(exists))
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name:        "successful send",
+			setupMock:   func() { expectUpdate(1) },
+			expectedErr: nil,
+		},
+		{
+			name: "already sent",
+			setupMock: func() {
+				expectUpdate(0)
+				expectExists(true)
+			},
+			expectedErr: structs.ErrPurchaseOrderStatus,
+		},
+		{
+			name: "order not found",
+			setupMock: func() {
+				expectUpdate(0)
+				expectExists(false)
+			},
+			expectedErr: structs.ErrPurchaseOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.SetStatus(fixture.ctx, id, structs.PurchaseDraft, structs.PurchaseSent)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestReceive_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	po := fixture.order
+	id_worker := structs.GenId()
+	r := structs.PurchaseReceipt{
+		IdOrder:  po.Id,
+		IdWorker: id_worker,
+		Lines: []structs.ReceivedLine{
+			{IdLine: po.Lines[0].Id, Quantity: 6},
+			{IdLine: po.Lines[1].Id, Quantity: 0},
+		},
+		Final: true,
+	}
+	ds := []structs.PurchaseDiscrepancy{{IdLine: po.Lines[1].Id, Ordered: 5, Received: 0, Difference: -5}}
+
+	expectLock := func(status string, received int) {
+		fixture.mock.ExpectQuery(`select status from purchase_order where id = \$1 for update`).WithArgs(po.Id).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+		fixture.mock.ExpectQuery(`select coalesce\(sum\(received\), 0\) from purchase_line where id_order = \$1`).
+			WithArgs(po.Id).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(received))
+	}
+	expectLine := func() *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`with line as \( update purchase_line set received = received \+ \$2 .* insert into stock_movement`).
+			WithArgs(po.Lines[0].Id, 6, po.Id, id_worker, po.Id.String())
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "final receipt with shortage",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.PurchaseSent, 4)
+				expectLine().WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`insert into purchase_discrepancy \(id_order, id_line, ordered, received, difference\)`).
+					WithArgs(po.Id, ds[0].IdLine, 5, 0, -5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`update purchase_order set status = \$2 where id = \$1`).
+					WithArgs(po.Id, structs.PurchaseReceived).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "another delivery was booked",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.PurchaseSent, 7)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrPurchaseOrderChanged,
+		},
+		{
+			name: "line of another order",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.PurchaseSent, 4)
+				expectLine().WillReturnResult(sqlmock.NewResult(0, 0))
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrPurchaseLineNotFound,
+		},
+		{
+			name: "order not found",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select status from purchase_order where id = \$1 for update`).
+					WithArgs(po.Id).WillReturnError(sql.ErrNoRows)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrPurchaseOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Receive(fixture.ctx, po, r, structs.PurchaseReceived, ds)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,180 @@
+package recommendation_rep
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const productColumns = `p.*, coalesce(r.rating_avg, 0) as rating_avg, coalesce(r.rating_count, 0) as rating_count,
+	coalesce(r.stars_1, 0) as stars_1, coalesce(r.stars_2, 0) as stars_2, coalesce(r.stars_3, 0) as stars_3,
+	coalesce(r.stars_4, 0) as stars_4, coalesce(r.stars_5, 0) as stars_5`
+
+// inStock keeps products that have stock themselves or in any variant.
+const inStock = `(p.amount > 0 or exists (select 1 from product_variant v where v.id_product = p.id and v.amount > 0))`
+
+// skippedStatuses are the statuses of orders whose goods were never bought,
+// so they do not count for the pairs.
+var skippedStatuses = []string{
+	string(structs.OrderInvalid),
+	string(structs.OrderCancelled),
+	string(structs.OrderUnpaid),
+}
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Rebuild replaces the product pairs in one transaction, so readers see
+// either the old or the new pairs. Every order counts once per product and
+// incorrect, cancelled and unpaid orders are ignored.
+func (rep *Repository) Rebuild(ctx context.Context, t structs.RecommendationThresholds) (int64, error) {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `delete from product_pair`); err != nil {
+		return 0, fmt.Errorf("failed to clear product pairs: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		with line as (
+			select distinct oi.id_order, oi.id_product
+			from order_item oi join "order" o on o.id = oi.id_order
+			where o.status <> all($4)
+		),
+		total as (
+			select count(distinct id_order) as n from line
+		),
+		item as (
+			select id_product, count(*) as cnt from line group by id_product
+		),
+		pair as (
+			select a.id_product, b.id_product as id_related, count(*) as support
+			from line a join line b on b.id_order = a.id_order and b.id_product <> a.id_product
+			group by a.id_product, b.id_product
+			having count(*) >= $1
+		),
+		scored as (
+			select p.id_product, p.id_related, p.support,
+				p.support::numeric / ia.cnt as confidence,
+				p.support::numeric * t.n / (ia.cnt * ib.cnt) as lift
+			from pair p
+			join item ia on ia.id_product = p.id_product
+			join item ib on ib.id_product = p.id_related
+			cross join total t
+		)
+		insert into product_pair (id_product, id_related, support, confidence, lift)
+		select id_product, id_related, support, round(confidence, 4), round(lift, 4)
+		from scored
+		where confidence >= $2 and lift >= $3`,
+		t.MinSupport, t.MinConfidence, t.MinLift, pq.Array(skippedStatuses))
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute product pairs: %w", err)
+	}
+	n, _ := result.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (rep *Repository) ForProduct(ctx context.Context, id_product uuid.UUID, id_user uuid.UUID, limit int) ([]structs.Recommendation, error) {
+	var rs []rep_structs.Recommendation
+	err := rep.db.SelectContext(ctx, &rs, `
+		select `+productColumns+`, pp.support, pp.confidence, pp.lift
+		from product_pair pp
+		join product p on p.id = pp.id_related
+		left join product_rating r on r.id_product = p.id
+		where pp.id_product = $1 and `+inStock+`
+			and not exists (select 1 from basket b join basket_item bi on bi.id_basket = b.id
+				where b.id_user = $2 and bi.id_product = p.id)
+		order by pp.confidence desc, pp.lift desc, p.name
+		limit $3`, id_product, id_user, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommendations: %w", err)
+	}
+
+	if len(rs) == 0 {
+		var exists bool
+		err := rep.db.GetContext(ctx, &exists, `select exists (select 1 from product where id = $1)`, id_product)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if !exists {
+			return nil, structs.ErrProductNotFound
+		}
+	}
+	return toRecommendations(rs), nil
+}
+
+// ForBasket ranks the products paired with any basket product by the best
+// support, confidence and lift among those pairs.
+func (rep *Repository) ForBasket(ctx context.Context, id_user uuid.UUID, limit int) ([]structs.Recommendation, error) {
+	var rs []rep_structs.Recommendation
+	err := rep.db.SelectContext(ctx, &rs, `
+		with basket_product as (
+			select distinct bi.id_product
+			from basket b join basket_item bi on bi.id_basket = b.id
+			where b.id_user = $1
+		),
+		best as (
+			select id_related, max(support) as support, max(confidence) as confidence, max(lift) as lift
+			from product_pair
+			where id_product in (select id_product from basket_product)
+				and id_related not in (select id_product from basket_product)
+			group by id_related
+		)
+		select `+productColumns+`, bp.support, bp.confidence, bp.lift
+		from best bp
+		join product p on p.id = bp.id_related
+		left join product_rating r on r.id_product = p.id
+		where `+inStock+`
+		order by bp.confidence desc, bp.lift desc, p.name
+		limit $2`, id_user, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommendations: %w", err)
+	}
+	return toRecommendations(rs), nil
+}
+
+func toRecommendations(rs []rep_structs.Recommendation) []structs.Recommendation {
+	res := make([]structs.Recommendation, len(rs))
+	for i, r := range rs {
+		res[i] = structs.Recommendation{
+			Product: structs.Product{
+				Id:          r.Id,
+				Name:        r.Name,
+				Description: r.Description,
+				Price:       r.Price,
+				IdCategory:  r.IdCategory.UUID,
+				Amount:      r.Amount,
+				IdBrand:     r.IdBrand,
+				PicLink:     r.PicLink,
+				Articule:    r.Articule,
+				Weight:      r.Weight,
+				Rating: structs.ProductRating{
+					Average:   r.RatingAvg,
+					Count:     r.RatingCount,
+					Histogram: [5]int{r.Stars1, r.Stars2, r.Stars3, r.Stars4, r.Stars5},
+				},
+			},
+			Support:    r.Support,
+			Confidence: r.Confidence,
+			Lift:       r.Lift,
+		}
+	}
+	return res
+}
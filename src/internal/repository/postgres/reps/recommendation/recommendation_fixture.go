@@ -0,0 +1,88 @@
+package recommendation_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var recommendationColumns = []string{"id", "name", "description", "price", "id_category", "amount", "id_brand",
+	"pic_link", "art", "rating_avg", "rating_count", "stars_1", "stars_2", "stars_3", "stars_4", "stars_5",
+	"support", "confidence", "lift"}
+
+type TestFixture struct {
+	t              *testing.T
+	db             *sql.DB
+	sqlxDB         *sqlx.DB
+	mock           sqlmock.Sqlmock
+	repo           *Repository
+	ctx            context.Context
+	thresholds     structs.RecommendationThresholds
+	recommendation structs.Recommendation
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	return &TestFixture{
+		t:          t,
+		db:         db,
+		sqlxDB:     sqlxDB,
+		mock:       mock,
+		repo:       New(sqlxDB),
+		ctx:        context.Background(),
+		thresholds: structs.RecommendationThresholds{MinSupport: 2, MinConfidence: 0.1, MinLift: 1},
+		recommendation: structs.Recommendation{
+			Product: structs.Product{
+				Id:          structs.GenId(),
+				Name:        "Lip Balm",
+				Description: "Moisturizing balm",
+				Price:       350,
+				IdCategory:  structs.GenId(),
+				Amount:      12,
+				IdBrand:     structs.GenId(),
+				PicLink:     "balm.jpg",
+				Articule:    "LB-001",
+				Rating: structs.ProductRating{
+					Average:   4.5,
+					Count:     2,
+					Histogram: [5]int{0, 0, 0, 1, 1},
+				},
+			},
+			Support:    3,
+			Confidence: 0.75,
+			Lift:       2.5,
+		},
+	}
+}
+
+func (f *TestFixture) recommendationRow(rows *sqlmock.Rows) *sqlmock.Rows {
+	r := f.recommendation
+	h := r.Rating.Histogram
+	return rows.AddRow(r.Id, r.Name, r.Description, r.Price, r.IdCategory, r.Amount, r.IdBrand, r.PicLink,
+		r.Articule, r.Rating.Average, r.Rating.Count, h[0], h[1], h[2], h[3], h[4], r.Support, r.Confidence, r.Lift)
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
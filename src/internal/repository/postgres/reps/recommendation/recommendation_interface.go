@@ -0,0 +1,14 @@
+package recommendation_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type RecommendationRepositoryInterface interface {
+	Rebuild(ctx context.Context, t structs.RecommendationThresholds) (int64, error)
+	ForProduct(ctx context.Context, id_product uuid.UUID, id_user uuid.UUID, limit int) ([]structs.Recommendation, error)
+	ForBasket(ctx context.Context, id_user uuid.UUID, limit int) ([]structs.Recommendation, error)
+}
@@ -0,0 +1,242 @@
+package recommendation_rep
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestRebuild_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	th := fixture.thresholds
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet int64
+		expectedErr error
+	}{
+		{
+			name: "successful rebuild",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`delete from product_pair`).WillReturnResult(sqlmock.NewResult(0, 10))
+				fixture.mock.ExpectExec(`with line as .* insert into product_pair \(id_product, id_related, support, confidence, lift\) .* where confidence >= \$2 and lift >= \$3`).
+					WithArgs(th.MinSupport, th.MinConfidence, th.MinLift, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 14))
+				fixture.mock.ExpectCommit()
+			},
+			expectedRet: 14,
+			expectedErr: nil,
+		},
+		{
+			name: "clear error",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`delete from product_pair`).WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: 0,
+			expectedErr: errTest,
+		},
+		{
+			name: "compute error",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectExec(`delete from product_pair`).WillReturnResult(sqlmock.NewResult(0, 0))
+				fixture.mock.ExpectExec(`insert into product_pair`).
+					WithArgs(th.MinSupport, th.MinConfidence, th.MinLift, sqlmock.AnyArg()).
+					WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: 0,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.Rebuild(fixture.ctx, th)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+// statusesArg matches a text array argument holding exactly the statuses.
+type statusesArg []structs.OrderStatus
+
+func (a statusesArg) Match(v driver.Value) bool {
+	var got pq.StringArray
+	if err := got.Scan([]byte(fmt.Sprint(v))); err != nil {
+		return false
+	}
+	want := make([]string, len(a))
+	for i, st := range a {
+		want[i] = string(st)
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	return slices.Equal(got, want)
+}
+
+func TestRebuild_SkipsCancelledOrders(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	th := fixture.thresholds
+
+	fixture.mock.ExpectBegin()
+	fixture.mock.ExpectExec(`delete from product_pair`).WillReturnResult(sqlmock.NewResult(0, 0))
+	fixture.mock.ExpectExec(`where o.status <> all\(\$4\)`).
+		WithArgs(th.MinSupport, th.MinConfidence, th.MinLift,
+			statusesArg{structs.OrderInvalid, structs.OrderCancelled, structs.OrderUnpaid}).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	fixture.mock.ExpectCommit()
+
+	ret, err := fixture.repo.Rebuild(fixture.ctx, th)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), ret)
+	require.NoError(t, fixture.mock.ExpectationsWereMet())
+	fixture.Cleanup()
+}
+
+func TestForProduct_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id_product := structs.GenId()
+	id_user := structs.GenId()
+
+	expectSelect := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`select p.\*, .* pp.support, pp.confidence, pp.lift from product_pair pp join product p on p.id = pp.id_related .* where pp.id_product = \$1 .* order by pp.confidence desc, pp.lift desc, p.name limit \$3`).
+			WithArgs(id_product, id_user, 5)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.Recommendation
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMock: func() {
+				expectSelect().WillReturnRows(fixture.recommendationRow(sqlmock.NewRows(recommendationColumns)))
+			},
+			expectedRet: []structs.Recommendation{fixture.recommendation},
+			expectedErr: nil,
+		},
+		{
+			name: "no pairs",
+			setupMock: func() {
+				expectSelect().WillReturnRows(sqlmock.NewRows(recommendationColumns))
+				fixture.mock.ExpectQuery(`select exists \(select 1 from product where id = \$1\)`).
+					WithArgs(id_product).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			expectedRet: []structs.Recommendation{},
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupMock: func() {
+				expectSelect().WillReturnRows(sqlmock.NewRows(recommendationColumns))
+				fixture.mock.ExpectQuery(`select exists \(select 1 from product where id = \$1\)`).
+					WithArgs(id_product).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrProductNotFound,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectSelect().WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.ForProduct(fixture.ctx, id_product, id_user, 5)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestForBasket_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id_user := structs.GenId()
+
+	expectSelect := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`with basket_product as .* where b.id_user = \$1 .* from best bp join product p on p.id = bp.id_related .* limit \$2`).
+			WithArgs(id_user, 10)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.Recommendation
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMock: func() {
+				expectSelect().WillReturnRows(fixture.recommendationRow(sqlmock.NewRows(recommendationColumns)))
+			},
+			expectedRet: []structs.Recommendation{fixture.recommendation},
+			expectedErr: nil,
+		},
+		{
+			name: "empty basket",
+			setupMock: func() {
+				expectSelect().WillReturnRows(sqlmock.NewRows(recommendationColumns))
+			},
+			expectedRet: []structs.Recommendation{},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectSelect().WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.ForBasket(fixture.ctx, id_user, 10)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
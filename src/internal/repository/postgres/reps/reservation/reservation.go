@@ -0,0 +1,129 @@
+package reservation_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+// The available stock is the stock not held by live reservations and not in
+// expired lots. The row is locked, so holds and orders of the same goods run
+// one after another.
+const (
+	lockProduct = `
+		select p.amount - coalesce((select sum(r.amount) from stock_reservation r
+			where r.id_product = p.id and r.id_variant is null and r.expires_at > localtimestamp), 0)
+			- coalesce((select sum(l.amount) from stock_lot l
+			where l.id_product = p.id and l.id_variant is null and l.expires_at < current_date), 0)
+		from product p where p.id = $1 for update`
+	lockVariant = `
+		select v.amount - coalesce((select sum(r.amount) from stock_reservation r
+			where r.id_variant = v.id and r.expires_at > localtimestamp), 0)
+			- coalesce((select sum(l.amount) from stock_lot l
+			where l.id_variant = v.id and l.expires_at < current_date), 0)
+		from product_variant v where v.id = $1 for update`
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Reserve replaces the holds of the user with holds of the whole basket.
+// Rows are locked in the order of product and variant ids, the same order
+// the checkout uses, so concurrent checkouts cannot deadlock.
+func (rep *Repository) Reserve(ctx context.Context, id_user uuid.UUID, ttl time.Duration) ([]structs.Reservation, error) {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var items []rep_structs.BasketItem
+	err = tx.SelectContext(ctx, &items, `
+		select bi.id, bi.id_product, bi.id_variant, bi.id_basket, bi.amount
+		from basket b join basket_item bi on bi.id_basket = b.id
+		where b.id_user = $1
+		order by bi.id_product, bi.id_variant nulls first`, id_user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get basket: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, structs.ErrEmptyBasket
+	}
+
+	if _, err := tx.ExecContext(ctx, `delete from stock_reservation where id_user = $1`, id_user); err != nil {
+		return nil, fmt.Errorf("failed to release reservations: %w", err)
+	}
+
+	rs := make([]structs.Reservation, 0, len(items))
+	for _, it := range items {
+		var available int
+		if it.IdVariant.Valid {
+			err = tx.GetContext(ctx, &available, lockVariant, it.IdVariant.UUID)
+		} else {
+			err = tx.GetContext(ctx, &available, lockProduct, it.IdProduct)
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			if it.IdVariant.Valid {
+				return nil, structs.ErrVariantNotFound
+			}
+			return nil, structs.ErrProductNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock stock: %w", err)
+		}
+		if available < it.Amount {
+			return nil, fmt.Errorf("%w: product %s", structs.ErrInsufficientStock, it.IdProduct)
+		}
+
+		var r rep_structs.Reservation
+		err = tx.GetContext(ctx, &r, `
+			insert into stock_reservation (id_user, id_product, id_variant, amount, expires_at)
+			values ($1, $2, $3, $4, localtimestamp + $5 * interval '1 second')
+			returning id, id_user, id_product, id_variant, amount, expires_at`,
+			id_user, it.IdProduct, it.IdVariant, it.Amount, ttl.Seconds())
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve stock: %w", err)
+		}
+		rs = append(rs, structs.Reservation{
+			Id:        r.Id,
+			IdUser:    r.IdUser,
+			IdProduct: r.IdProduct,
+			IdVariant: r.IdVariant.UUID,
+			Amount:    r.Amount,
+			ExpiresAt: r.ExpiresAt,
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (rep *Repository) Release(ctx context.Context, id_user uuid.UUID) error {
+	_, err := rep.db.ExecContext(ctx, `delete from stock_reservation where id_user = $1`, id_user)
+	if err != nil {
+		return fmt.Errorf("failed to release reservations: %w", err)
+	}
+	return nil
+}
+
+func (rep *Repository) DeleteExpired(ctx context.Context) (int64, error) {
+	result, err := rep.db.ExecContext(ctx, `delete from stock_reservation where expires_at <= localtimestamp`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired reservations: %w", err)
+	}
+	return result.RowsAffected()
+}
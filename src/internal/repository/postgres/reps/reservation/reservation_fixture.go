@@ -0,0 +1,97 @@
+package reservation_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+var errTest = errors.New("test error")
+
+var (
+	itemColumns        = []string{"id", "id_product", "id_variant", "id_basket", "amount"}
+	reservationColumns = []string{"id", "id_user", "id_product", "id_variant", "amount", "expires_at"}
+)
+
+type TestFixture struct {
+	t       *testing.T
+	db      *sql.DB
+	sqlxDB  *sqlx.DB
+	mock    sqlmock.Sqlmock
+	repo    *Repository
+	ctx     context.Context
+	ttl     time.Duration
+	idUser  uuid.UUID
+	product structs.Reservation
+	variant structs.Reservation
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	id_user := structs.GenId()
+	expires := time.Date(2026, 3, 1, 12, 15, 0, 0, time.UTC)
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		ttl:    15 * time.Minute,
+		idUser: id_user,
+		product: structs.Reservation{
+			Id:        structs.GenId(),
+			IdUser:    id_user,
+			IdProduct: structs.GenId(),
+			Amount:    2,
+			ExpiresAt: expires,
+		},
+		variant: structs.Reservation{
+			Id:        structs.GenId(),
+			IdUser:    id_user,
+			IdProduct: structs.GenId(),
+			IdVariant: structs.GenId(),
+			Amount:    1,
+			ExpiresAt: expires,
+		},
+	}
+}
+
+func (f *TestFixture) itemRows() *sqlmock.Rows {
+	rows := sqlmock.NewRows(itemColumns)
+	for _, r := range []structs.Reservation{f.product, f.variant} {
+		rows.AddRow(structs.GenId(), r.IdProduct, rep_structs.NullId(r.IdVariant), structs.GenId(), r.Amount)
+	}
+	return rows
+}
+
+func (f *TestFixture) reservationRow(r structs.Reservation) *sqlmock.Rows {
+	return sqlmock.NewRows(reservationColumns).
+		AddRow(r.Id, r.IdUser, r.IdProduct, rep_structs.NullId(r.IdVariant), r.Amount, r.ExpiresAt)
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
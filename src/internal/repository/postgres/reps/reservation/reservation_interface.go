@@ -0,0 +1,15 @@
+package reservation_rep
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type ReservationRepositoryInterface interface {
+	Reserve(ctx context.Context, id_user uuid.UUID, ttl time.Duration) ([]structs.Reservation, error)
+	Release(ctx context.Context, id_user uuid.UUID) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
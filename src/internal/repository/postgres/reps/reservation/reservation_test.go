@@ -0,0 +1,204 @@
+package reservation_rep
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+func TestReserve_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	p, v := fixture.product, fixture.variant
+
+	expectItems := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`select bi.id, bi.id_product, bi.id_variant, bi.id_basket, bi.amount from basket b join basket_item bi .* order by bi.id_product, bi.id_variant nulls first`).
+			WithArgs(fixture.idUser)
+	}
+	expectRelease := func() {
+		fixture.mock.ExpectExec(`delete from stock_reservation where id_user = \$1`).
+			WithArgs(fixture.idUser).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	expectLockProduct := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`select p.amount - coalesce\(.*\) from product p where p.id = \$1 for update`).
+			WithArgs(p.IdProduct)
+	}
+	expectInsert := func(r structs.Reservation) *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into stock_reservation \(id_user, id_product, id_variant, amount, expires_at\)`).
+			WithArgs(fixture.idUser, r.IdProduct, rep_structs.NullId(r.IdVariant), r.Amount, fixture.ttl.Seconds())
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.Reservation
+		expectedErr error
+	}{
+		{
+			name: "successful reserve",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectItems().WillReturnRows(fixture.itemRows())
+				expectRelease()
+				expectLockProduct().WillReturnRows(sqlmock.NewRows([]string{"available"}).AddRow(5))
+				expectInsert(p).WillReturnRows(fixture.reservationRow(p))
+				fixture.mock.ExpectQuery(`select v.amount - coalesce\(.*\) from product_variant v where v.id = \$1 for update`).
+					WithArgs(v.IdVariant).
+					WillReturnRows(sqlmock.NewRows([]string{"available"}).AddRow(1))
+				expectInsert(v).WillReturnRows(fixture.reservationRow(v))
+				fixture.mock.ExpectCommit()
+			},
+			expectedRet: []structs.Reservation{p, v},
+			expectedErr: nil,
+		},
+		{
+			name: "empty basket",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectItems().WillReturnRows(sqlmock.NewRows(itemColumns))
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrEmptyBasket,
+		},
+		{
+			name: "held by other customers",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectItems().WillReturnRows(fixture.itemRows())
+				expectRelease()
+				expectLockProduct().WillReturnRows(sqlmock.NewRows([]string{"available"}).AddRow(1))
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrInsufficientStock,
+		},
+		{
+			name: "product deleted",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectItems().WillReturnRows(fixture.itemRows())
+				expectRelease()
+				expectLockProduct().WillReturnError(sql.ErrNoRows)
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrProductNotFound,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectItems().WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.Reserve(fixture.ctx, fixture.idUser, fixture.ttl)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestRelease_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful release",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from stock_reservation where id_user = \$1`).
+					WithArgs(fixture.idUser).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from stock_reservation where id_user = \$1`).
+					WithArgs(fixture.idUser).
+					WillReturnError(errTest)
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Release(fixture.ctx, fixture.idUser)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDeleteExpired_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet int64
+		expectedErr error
+	}{
+		{
+			name: "successful sweep",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from stock_reservation where expires_at <= localtimestamp`).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+			},
+			expectedRet: 4,
+			expectedErr: nil,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`delete from stock_reservation where expires_at <= localtimestamp`).
+					WillReturnError(errTest)
+			},
+			expectedRet: 0,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.DeleteExpired(fixture.ctx)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
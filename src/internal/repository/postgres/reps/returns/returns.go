@@ -0,0 +1,369 @@
+package returns_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const (
+	returnFields = `id, id_order, id_user, status, reason, comment, created_at, decided_at, received_at`
+
+	// addLine adds the line only while the goods of the order line returned
+	// so far, counting requested and approved returns in full and received
+	// ones by what arrived, stay within the amount bought.
+	addLine = `
+		insert into return_line (id_return, id_order_item, id_product, id_variant, amount)
+		select $1, oi.id, oi.id_product, oi.id_variant, $3
+		from order_item oi
+		where oi.id = $2 and oi.id_order = $4
+			and oi.amount >= $3 + (
+				select coalesce(sum(case when rr.status = 'received' then rl.received else rl.amount end), 0)
+				from return_line rl
+				join return_request rr on rr.id = rl.id_return
+				where rl.id_order_item = oi.id and rr.status <> 'rejected')`
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create stores the return with its lines. The order row is locked first,
+// so two returns of the same order are checked one after the other.
+func (rep *Repository) Create(ctx context.Context, r structs.ReturnRequest) (uuid.UUID, error) {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	err = tx.GetContext(ctx, &locked, `select true from "order" where id = $1 for update`, r.IdOrder)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, structs.ErrOrderNotFound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to lock order: %w", err)
+	}
+
+	var id uuid.UUID
+	err = tx.GetContext(ctx, &id, `
+		insert into return_request (id_order, id_user, status, reason)
+		values ($1, $2, $3, $4)
+		returning id`,
+		r.IdOrder, r.IdUser, r.Status, r.Reason)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create return: %w", err)
+	}
+
+	for _, l := range r.Lines {
+		result, err := tx.ExecContext(ctx, addLine, id, l.IdOrderItem, l.Amount, r.IdOrder)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to add return line: %w", err)
+		}
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return uuid.Nil, structs.ErrReturnAmountExceeded
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+// AddPhotos stores the photos of a requested return while the return has
+// fewer than max photos with them. The return row is locked for the count.
+func (rep *Repository) AddPhotos(ctx context.Context, id uuid.UUID, photos []structs.ReturnPhoto, max int) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.GetContext(ctx, &status, `select status from return_request where id = $1 for update`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.ErrReturnNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock return: %w", err)
+	}
+	if status != structs.ReturnRequested {
+		return structs.ErrReturnStatus
+	}
+
+	var count int
+	err = tx.GetContext(ctx, &count, `select count(*) from return_photo where id_return = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to count return photos: %w", err)
+	}
+	if count+len(photos) > max {
+		return structs.ErrTooManyReturnPhotos
+	}
+
+	for _, p := range photos {
+		_, err := tx.ExecContext(ctx, `
+			insert into return_photo (id, id_return, content_type, size, key, created_at)
+			values ($1, $2, $3, $4, $5, $6)`,
+			p.Id, id, p.ContentType, p.Size, p.Key, p.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add return photo: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (rep *Repository) GetById(ctx context.Context, id uuid.UUID) (structs.ReturnRequest, error) {
+	var r rep_structs.ReturnRequest
+	err := rep.db.GetContext(ctx, &r, `select `+returnFields+` from return_request where id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.ReturnRequest{}, structs.ErrReturnNotFound
+	}
+	if err != nil {
+		return structs.ReturnRequest{}, fmt.Errorf("failed to get return: %w", err)
+	}
+	res := toReturn(r)
+
+	var ls []rep_structs.ReturnLine
+	err = rep.db.SelectContext(ctx, &ls, `
+		select id, id_order_item, id_product, id_variant, amount, received, coalesce(condition, '') as condition
+		from return_line
+		where id_return = $1
+		order by id_product, id_variant nulls first`, id)
+	if err != nil {
+		return structs.ReturnRequest{}, fmt.Errorf("failed to get return lines: %w", err)
+	}
+	for _, l := range ls {
+		res.Lines = append(res.Lines, structs.ReturnLine{
+			Id:          l.Id,
+			IdOrderItem: l.IdOrderItem,
+			IdProduct:   l.IdProduct.UUID,
+			IdVariant:   l.IdVariant.UUID,
+			Amount:      l.Amount,
+			Received:    l.Received,
+			Condition:   l.Condition,
+		})
+	}
+
+	var ps []rep_structs.ReturnPhoto
+	err = rep.db.SelectContext(ctx, &ps, `
+		select id, id_return, content_type, size, key, created_at
+		from return_photo
+		where id_return = $1
+		order by created_at, id`, id)
+	if err != nil {
+		return structs.ReturnRequest{}, fmt.Errorf("failed to get return photos: %w", err)
+	}
+	for _, p := range ps {
+		res.Photos = append(res.Photos, structs.ReturnPhoto{
+			Id:          p.Id,
+			IdReturn:    p.IdReturn,
+			ContentType: p.ContentType,
+			Size:        p.Size,
+			Key:         p.Key,
+			CreatedAt:   p.CreatedAt,
+		})
+	}
+
+	var rf rep_structs.Refund
+	err = rep.db.GetContext(ctx, &rf, `
+		select id, id_return, id_order, id_payment, amount, status, error, created_at, processed_at
+		from refund where id_return = $1`, id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return structs.ReturnRequest{}, fmt.Errorf("failed to get refund: %w", err)
+	}
+	if err == nil {
+		res.Refund = &structs.Refund{
+			Id:          rf.Id,
+			IdReturn:    rf.IdReturn.UUID,
+			IdOrder:     rf.IdOrder,
+			IdPayment:   rf.IdPayment.UUID,
+			Amount:      rf.Amount,
+			Status:      rf.Status,
+			Error:       rf.Error,
+			CreatedAt:   rf.CreatedAt,
+			ProcessedAt: nullTime(rf.ProcessedAt),
+		}
+	}
+	return res, nil
+}
+
+func (rep *Repository) GetByUser(ctx context.Context, id_user uuid.UUID) ([]structs.ReturnRequest, error) {
+	var rs []rep_structs.ReturnRequest
+	err := rep.db.SelectContext(ctx, &rs, `select `+returnFields+` from return_request
+		where id_user = $1
+		order by created_at desc, id`, id_user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returns: %w", err)
+	}
+
+	res := make([]structs.ReturnRequest, len(rs))
+	for i, r := range rs {
+		res[i] = toReturn(r)
+	}
+	return res, nil
+}
+
+func (rep *Repository) GetAll(ctx context.Context, status string) ([]structs.ReturnRequest, error) {
+	var rs []rep_structs.ReturnRequest
+	err := rep.db.SelectContext(ctx, &rs, `select `+returnFields+` from return_request
+		where $1 = '' or status = $1
+		order by created_at desc, id`, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returns: %w", err)
+	}
+
+	res := make([]structs.ReturnRequest, len(rs))
+	for i, r := range rs {
+		res[i] = toReturn(r)
+	}
+	return res, nil
+}
+
+// Decide approves or rejects a requested return.
+func (rep *Repository) Decide(ctx context.Context, id uuid.UUID, status string, id_admin uuid.UUID, comment string) error {
+	result, err := rep.db.ExecContext(ctx, `
+		update return_request set status = $2, id_admin = $3, comment = $4, decided_at = current_timestamp
+		where id = $1 and status = 'requested'`,
+		id, status, rep_structs.NullId(id_admin), comment)
+	if err != nil {
+		return fmt.Errorf("failed to update return: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		var exists bool
+		err := rep.db.GetContext(ctx, &exists, `select exists (select 1 from return_request where id = $1)`, id)
+		if err != nil {
+			return fmt.Errorf("failed to get return: %w", err)
+		}
+		if !exists {
+			return structs.ErrReturnNotFound
+		}
+		return structs.ErrReturnStatus
+	}
+	return nil
+}
+
+// GetRestocked returns for every line of the order the sellable goods its
+// received returns put back to stock.
+func (rep *Repository) GetRestocked(ctx context.Context, id_order uuid.UUID) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		IdOrderItem uuid.UUID `db:"id_order_item"`
+		Amount      int       `db:"amount"`
+	}
+	err := rep.db.SelectContext(ctx, &rows, `
+		select rl.id_order_item, sum(rl.received) as amount
+		from return_line rl
+		join return_request rr on rr.id = rl.id_return
+		where rr.id_order = $1 and rr.status = 'received' and rl.condition = 'sellable'
+		group by rl.id_order_item`, id_order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restocked goods: %w", err)
+	}
+
+	res := make(map[uuid.UUID]int, len(rows))
+	for _, r := range rows {
+		res[r.IdOrderItem] = r.Amount
+	}
+	return res, nil
+}
+
+// Receive books the receipt in one transaction: the lines get what arrived,
+// the movements return the goods to stock and the refund is recorded. The
+// return row is locked first and must still be in the status it was read
+// in, so a return cannot be received twice.
+func (rep *Repository) Receive(ctx context.Context, seen structs.ReturnRequest, rc structs.ReturnReceipt,
+	ms []structs.StockMovement, refund structs.Refund) error {
+	tx, err := rep.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.GetContext(ctx, &current, `select status from return_request where id = $1 for update`, seen.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return structs.ErrReturnNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock return: %w", err)
+	}
+	if current != seen.Status {
+		return structs.ErrReturnChanged
+	}
+
+	for _, l := range rc.Lines {
+		_, err := tx.ExecContext(ctx, `
+			update return_line set received = $3, condition = $4 where id = $1 and id_return = $2`,
+			l.IdLine, seen.Id, l.Received, l.Condition)
+		if err != nil {
+			return fmt.Errorf("failed to receive return line: %w", err)
+		}
+	}
+
+	for _, m := range ms {
+		_, err := tx.ExecContext(ctx, `
+			insert into stock_movement (id_product, id_variant, id_lot, kind, quantity, reason, id_actor, reference)
+			values ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			m.IdProduct, rep_structs.NullId(m.IdVariant), rep_structs.NullId(m.IdLot), m.Kind, m.Quantity,
+			m.Reason, rep_structs.NullId(m.IdActor), m.Reference)
+		if err != nil {
+			return fmt.Errorf("failed to record stock movement: %w", err)
+		}
+	}
+
+	if refund.Amount > 0 {
+		_, err := tx.ExecContext(ctx, `
+			insert into refund (id_return, id_order, amount, status) values ($1, $2, $3, $4)`,
+			refund.IdReturn, refund.IdOrder, refund.Amount, refund.Status)
+		if err != nil {
+			return fmt.Errorf("failed to record refund: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		update return_request set status = 'received', id_worker = $2, received_at = current_timestamp
+		where id = $1`, seen.Id, rep_structs.NullId(rc.IdWorker))
+	if err != nil {
+		return fmt.Errorf("failed to update return: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func toReturn(r rep_structs.ReturnRequest) structs.ReturnRequest {
+	res := structs.ReturnRequest{
+		Id:        r.Id,
+		IdOrder:   r.IdOrder,
+		IdUser:    r.IdUser,
+		Status:    r.Status,
+		Reason:    r.Reason,
+		Comment:   r.Comment,
+		CreatedAt: r.CreatedAt,
+	}
+	res.DecidedAt = nullTime(r.DecidedAt)
+	res.ReceivedAt = nullTime(r.ReceivedAt)
+	return res
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,96 @@
+package returns_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var (
+	returnColumns = []string{"id", "id_order", "id_user", "status", "reason", "comment", "created_at", "decided_at", "received_at"}
+	lineColumns   = []string{"id", "id_order_item", "id_product", "id_variant", "amount", "received", "condition"}
+	photoColumns  = []string{"id", "id_return", "content_type", "size", "key", "created_at"}
+	refundColumns = []string{"id", "id_return", "id_order", "id_payment", "amount", "status", "error", "created_at", "processed_at"}
+)
+
+type TestFixture struct {
+	t      *testing.T
+	db     *sql.DB
+	sqlxDB *sqlx.DB
+	mock   sqlmock.Sqlmock
+	repo   *Repository
+	ctx    context.Context
+	ret    structs.ReturnRequest
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	created := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	decided := created.Add(2 * time.Hour)
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		ret: structs.ReturnRequest{
+			Id:        structs.GenId(),
+			IdOrder:   structs.GenId(),
+			IdUser:    structs.GenId(),
+			Status:    structs.ReturnApproved,
+			Reason:    "не подошел оттенок",
+			CreatedAt: created,
+			DecidedAt: &decided,
+			Lines: []structs.ReturnLine{
+				{Id: structs.GenId(), IdOrderItem: structs.GenId(), IdProduct: structs.GenId(), Amount: 2},
+				{Id: structs.GenId(), IdOrderItem: structs.GenId(), IdProduct: structs.GenId(), IdVariant: structs.GenId(), Amount: 1},
+			},
+		},
+	}
+}
+
+func (f *TestFixture) returnRows() *sqlmock.Rows {
+	r := f.ret
+	return sqlmock.NewRows(returnColumns).
+		AddRow(r.Id, r.IdOrder, r.IdUser, r.Status, r.Reason, r.Comment, r.CreatedAt, *r.DecidedAt, nil)
+}
+
+func (f *TestFixture) lineRows() *sqlmock.Rows {
+	rows := sqlmock.NewRows(lineColumns)
+	for _, l := range f.ret.Lines {
+		var id_variant any
+		if l.IdVariant != uuid.Nil {
+			id_variant = l.IdVariant
+		}
+		rows.AddRow(l.Id, l.IdOrderItem, l.IdProduct, id_variant, l.Amount, l.Received, l.Condition)
+	}
+	return rows
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
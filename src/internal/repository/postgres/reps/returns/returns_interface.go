@@ -0,0 +1,20 @@
+package returns_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type ReturnRepositoryInterface interface {
+	Create(ctx context.Context, r structs.ReturnRequest) (uuid.UUID, error)
+	AddPhotos(ctx context.Context, id uuid.UUID, photos []structs.ReturnPhoto, max int) error
+	GetById(ctx context.Context, id uuid.UUID) (structs.ReturnRequest, error)
+	GetByUser(ctx context.Context, id_user uuid.UUID) ([]structs.ReturnRequest, error)
+	GetAll(ctx context.Context, status string) ([]structs.ReturnRequest, error)
+	Decide(ctx context.Context, id uuid.UUID, status string, id_admin uuid.UUID, comment string) error
+	GetRestocked(ctx context.Context, id_order uuid.UUID) (map[uuid.UUID]int, error)
+	Receive(ctx context.Context, seen structs.ReturnRequest, rc structs.ReturnReceipt,
+		ms []structs.StockMovement, refund structs.Refund) error
+}
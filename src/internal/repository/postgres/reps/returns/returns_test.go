@@ -0,0 +1,413 @@
+package returns_rep
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	r := fixture.ret
+	r.Status = structs.ReturnRequested
+	id := structs.GenId()
+
+	expectLock := func() {
+		fixture.mock.ExpectQuery(`select true from "order" where id = \$1 for update`).WithArgs(r.IdOrder).
+			WillReturnRows(sqlmock.NewRows([]string{"bool"}).AddRow(true))
+	}
+	expectInsert := func() {
+		fixture.mock.ExpectQuery(`insert into return_request \(id_order, id_user, status, reason\)`).
+			WithArgs(r.IdOrder, r.IdUser, structs.ReturnRequested, r.Reason).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+	}
+	expectLine := func(l structs.ReturnLine) *sqlmock.ExpectedExec {
+		return fixture.mock.ExpectExec(`insert into return_line .* where oi.id = \$2 and oi.id_order = \$4 and oi.amount >= \$3 \+`).
+			WithArgs(id, l.IdOrderItem, l.Amount, r.IdOrder)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "successful creation",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock()
+				expectInsert()
+				expectLine(r.Lines[0]).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectLine(r.Lines[1]).WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedRet: id,
+			expectedErr: nil,
+		},
+		{
+			name: "goods already returned",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock()
+				expectInsert()
+				expectLine(r.Lines[0]).WillReturnResult(sqlmock.NewResult(0, 0))
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrReturnAmountExceeded,
+		},
+		{
+			name: "order not found",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select true from "order" where id = \$1 for update`).WithArgs(r.IdOrder).
+					WillReturnRows(sqlmock.NewRows([]string{"bool"}))
+				fixture.mock.ExpectRollback()
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.Create(fixture.ctx, r)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestAddPhotos_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id := fixture.ret.Id
+	photo := structs.ReturnPhoto{
+		Id:          structs.GenId(),
+		IdReturn:    id,
+		ContentType: "image/png",
+		Size:        2048,
+		Key:         "returns/" + id.String() + "/photo.png",
+		CreatedAt:   time.Date(2026, 3, 10, 13, 0, 0, 0, time.UTC),
+	}
+
+	expectLock := func(status string) {
+		fixture.mock.ExpectQuery(`select status from return_request where id = \$1 for update`).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+	}
+	expectCount := func(n int) {
+		fixture.mock.ExpectQuery(`select count\(\*\) from return_photo where id_return = \$1`).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(n))
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful add",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.ReturnRequested)
+				expectCount(1)
+				fixture.mock.ExpectExec(`insert into return_photo \(id, id_return, content_type, size, key, created_at\)`).
+					WithArgs(photo.Id, id, photo.ContentType, photo.Size, photo.Key, photo.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "return decided",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.ReturnApproved)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrReturnStatus,
+		},
+		{
+			name: "limit reached",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.ReturnRequested)
+				expectCount(5)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrTooManyReturnPhotos,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.AddPhotos(fixture.ctx, id, []structs.ReturnPhoto{photo}, 5)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetById_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	r := fixture.ret
+	refund := structs.Refund{
+		Id:        structs.GenId(),
+		IdReturn:  r.Id,
+		IdOrder:   r.IdOrder,
+		Amount:    1800,
+		Status:    structs.RefundPending,
+		CreatedAt: r.CreatedAt.Add(24 * time.Hour),
+	}
+
+	expectReturn := func() {
+		fixture.mock.ExpectQuery(`select id, id_order, id_user, status, reason, comment, created_at, decided_at, received_at from return_request where id = \$1`).
+			WithArgs(r.Id).
+			WillReturnRows(fixture.returnRows())
+	}
+	expectLines := func() {
+		fixture.mock.ExpectQuery(`from return_line where id_return = \$1`).WithArgs(r.Id).
+			WillReturnRows(fixture.lineRows())
+		fixture.mock.ExpectQuery(`from return_photo where id_return = \$1`).WithArgs(r.Id).
+			WillReturnRows(sqlmock.NewRows(photoColumns))
+	}
+
+	withRefund := r
+	withRefund.Refund = &refund
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    structs.ReturnRequest
+		expectedErr error
+	}{
+		{
+			name: "with refund",
+			setupMock: func() {
+				expectReturn()
+				expectLines()
+				fixture.mock.ExpectQuery(`from refund where id_return = \$1`).WithArgs(r.Id).
+					WillReturnRows(sqlmock.NewRows(refundColumns).
+						AddRow(refund.Id, refund.IdReturn, refund.IdOrder, nil, refund.Amount, refund.Status, "", refund.CreatedAt, nil))
+			},
+			expected:    withRefund,
+			expectedErr: nil,
+		},
+		{
+			name: "without refund",
+			setupMock: func() {
+				expectReturn()
+				expectLines()
+				fixture.mock.ExpectQuery(`from refund where id_return = \$1`).WithArgs(r.Id).
+					WillReturnRows(sqlmock.NewRows(refundColumns))
+			},
+			expected:    r,
+			expectedErr: nil,
+		},
+		{
+			name: "return not found",
+			setupMock: func() {
+				fixture.mock.ExpectQuery(`from return_request where id = \$1`).WithArgs(r.Id).
+					WillReturnRows(sqlmock.NewRows(returnColumns))
+			},
+			expected:    structs.ReturnRequest{},
+			expectedErr: structs.ErrReturnNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetById(fixture.ctx, r.Id)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expected, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestDecide_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id := fixture.ret.Id
+	id_admin := structs.GenId()
+
+	expectUpdate := func(rows int64) {
+		fixture.mock.ExpectExec(`update return_request set status = \$2, id_admin = \$3, comment = \$4, decided_at = current_timestamp where id = \$1 and status = 'requested'`).
+			WithArgs(id, structs.ReturnApproved, id_admin, "").
+			WillReturnResult(sqlmock.NewResult(0, rows))
+	}
+	expectExists := func(exists bool) {
+		fixture.mock.ExpectQuery(`select exists \(select 1 from return_request where id = \$1\)`).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(exists))
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name:        "successful approve",
+			setupMock:   func() { expectUpdate(1) },
+			expectedErr: nil,
+		},
+		{
+			name: "already decided",
+			setupMock: func() {
+				expectUpdate(0)
+				expectExists(true)
+			},
+			expectedErr: structs.ErrReturnStatus,
+		},
+		{
+			name: "return not found",
+			setupMock: func() {
+				expectUpdate(0)
+				expectExists(false)
+			},
+			expectedErr: structs.ErrReturnNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Decide(fixture.ctx, id, structs.ReturnApproved, id_admin, "")
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetRestocked_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id_item := fixture.ret.Lines[0].IdOrderItem
+
+	fixture.mock.ExpectQuery(`where rr.id_order = \$1 and rr.status = 'received' and rl.condition = 'sellable' group by rl.id_order_item`).
+		WithArgs(fixture.ret.IdOrder).
+		WillReturnRows(sqlmock.NewRows([]string{"id_order_item", "amount"}).AddRow(id_item, 2))
+
+	ret, err := fixture.repo.GetRestocked(fixture.ctx, fixture.ret.IdOrder)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{id_item: 2}, ret)
+	require.NoError(t, fixture.mock.ExpectationsWereMet())
+	fixture.Cleanup()
+}
+
+func TestReceive_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	r := fixture.ret
+	id_worker := structs.GenId()
+	id_lot := structs.GenId()
+	rc := structs.ReturnReceipt{
+		IdReturn: r.Id,
+		IdWorker: id_worker,
+		Lines: []structs.ReceivedReturnLine{
+			{IdLine: r.Lines[0].Id, Received: 2, Condition: structs.ReturnSellable},
+			{IdLine: r.Lines[1].Id, Received: 0, Condition: structs.ReturnDamaged},
+		},
+	}
+	ms := []structs.StockMovement{{
+		IdProduct: r.Lines[0].IdProduct,
+		IdLot:     id_lot,
+		Kind:      structs.StockReturn,
+		Quantity:  2,
+		Reason:    "возврат покупателя",
+		IdActor:   id_worker,
+		Reference: r.Id.String(),
+	}}
+	refund := structs.Refund{IdReturn: r.Id, IdOrder: r.IdOrder, Amount: 1800, Status: structs.RefundPending}
+
+	expectLock := func(status string) {
+		fixture.mock.ExpectQuery(`select status from return_request where id = \$1 for update`).WithArgs(r.Id).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "successful receipt",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.ReturnApproved)
+				for _, l := range rc.Lines {
+					fixture.mock.ExpectExec(`update return_line set received = \$3, condition = \$4 where id = \$1 and id_return = \$2`).
+						WithArgs(l.IdLine, r.Id, l.Received, l.Condition).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				fixture.mock.ExpectExec(`insert into stock_movement`).
+					WithArgs(ms[0].IdProduct, nil, id_lot, structs.StockReturn, 2, ms[0].Reason, id_worker, r.Id.String()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`insert into refund \(id_return, id_order, amount, status\)`).
+					WithArgs(r.Id, r.IdOrder, 1800.0, structs.RefundPending).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectExec(`update return_request set status = 'received', id_worker = \$2, received_at = current_timestamp where id = \$1`).
+					WithArgs(r.Id, id_worker).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				fixture.mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "received meanwhile",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				expectLock(structs.ReturnReceived)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: structs.ErrReturnChanged,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				fixture.mock.ExpectBegin()
+				fixture.mock.ExpectQuery(`select status from return_request where id = \$1 for update`).WithArgs(r.Id).
+					WillReturnError(errTest)
+				fixture.mock.ExpectRollback()
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := fixture.repo.Receive(fixture.ctx, r, rc, ms, refund)
+
+			fixture.AssertError(err, tt.expectedErr)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
@@ -0,0 +1,182 @@
+package stock_rep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+const (
+	movementFields = `id, id_product, id_variant, id_lot, kind, quantity, balance, coalesce(reason, '') as reason,
+	id_actor, coalesce(reference, '') as reference, created_at`
+	lotFields = `id, id_product, id_variant, batch, expires_at, amount, created_at`
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create appends a movement to the ledger. The stock_movement trigger
+// updates the stock of the product or variant in the same statement and
+// stores the new stock as the balance, the amount checks of product,
+// product_variant and stock_lot keep it from going negative.
+func (rep *Repository) Create(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error) {
+	var r rep_structs.StockMovement
+	err := rep.db.GetContext(ctx, &r, `
+		insert into stock_movement (id_product, id_variant, id_lot, kind, quantity, reason, id_actor, reference)
+		values ($1, $2, $3, $4, $5, nullif($6, ''), $7, nullif($8, ''))
+		returning `+movementFields,
+		m.IdProduct, rep_structs.NullId(m.IdVariant), rep_structs.NullId(m.IdLot), m.Kind, m.Quantity, m.Reason,
+		rep_structs.NullId(m.IdActor), m.Reference)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Constraint {
+		case "fk_stock_movement_product":
+			return structs.StockMovement{}, structs.ErrProductNotFound
+		case "fk_stock_movement_variant":
+			return structs.StockMovement{}, structs.ErrVariantNotFound
+		case "fk_stock_movement_lot":
+			return structs.StockMovement{}, structs.ErrLotNotFound
+		case "product_amount_check", "product_variant_amount_check", "stock_lot_amount_check":
+			return structs.StockMovement{}, structs.ErrInsufficientStock
+		case "stock_lot_untracked_check":
+			return structs.StockMovement{}, structs.ErrLotRequired
+		case "stock_movement_kind_check", "stock_movement_quantity_check":
+			return structs.StockMovement{}, structs.ErrInvalidStockMovement
+		}
+	}
+	if err != nil {
+		return structs.StockMovement{}, fmt.Errorf("failed to create stock movement: %w", err)
+	}
+	return toMovement(r), nil
+}
+
+func (rep *Repository) GetByProduct(ctx context.Context, id_product uuid.UUID) ([]structs.StockMovement, error) {
+	var rs []rep_structs.StockMovement
+	err := rep.db.SelectContext(ctx, &rs, `select `+movementFields+`
+		from stock_movement where id_product = $1 order by created_at desc, id`, id_product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock movements: %w", err)
+	}
+
+	// Products without stock have no movements yet.
+	if len(rs) == 0 {
+		var exists bool
+		err := rep.db.GetContext(ctx, &exists, `select exists (select 1 from product where id = $1)`, id_product)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if !exists {
+			return nil, structs.ErrProductNotFound
+		}
+	}
+
+	ms := make([]structs.StockMovement, len(rs))
+	for i, r := range rs {
+		ms[i] = toMovement(r)
+	}
+	return ms, nil
+}
+
+// CreateLot returns the lot of the batch, creating it on the first delivery.
+// A batch delivered again must keep its expiry date.
+func (rep *Repository) CreateLot(ctx context.Context, lot structs.StockLot) (uuid.UUID, error) {
+	var r rep_structs.StockLot
+	err := rep.db.GetContext(ctx, &r, `
+		insert into stock_lot (id_product, id_variant, batch, expires_at) values ($1, $2, $3, $4)
+		on conflict on constraint stock_lot_batch_unique do update set batch = excluded.batch
+		returning `+lotFields,
+		lot.IdProduct, rep_structs.NullId(lot.IdVariant), lot.Batch, lot.ExpiresAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Constraint {
+		case "fk_stock_lot_product":
+			return uuid.Nil, structs.ErrProductNotFound
+		case "fk_stock_lot_variant":
+			return uuid.Nil, structs.ErrVariantNotFound
+		}
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create lot: %w", err)
+	}
+	if r.ExpiresAt.Format(time.DateOnly) != lot.ExpiresAt.Format(time.DateOnly) {
+		return uuid.Nil, structs.ErrLotExpiryMismatch
+	}
+	return r.Id, nil
+}
+
+func (rep *Repository) GetLots(ctx context.Context, id_product uuid.UUID) ([]structs.StockLot, error) {
+	var rs []rep_structs.StockLot
+	err := rep.db.SelectContext(ctx, &rs, `select `+lotFields+` from stock_lot
+		where id_product = $1 and amount > 0
+		order by expires_at, batch`, id_product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lots: %w", err)
+	}
+
+	if len(rs) == 0 {
+		var exists bool
+		err := rep.db.GetContext(ctx, &exists, `select exists (select 1 from product where id = $1)`, id_product)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if !exists {
+			return nil, structs.ErrProductNotFound
+		}
+	}
+	return toLots(rs), nil
+}
+
+func (rep *Repository) GetExpiring(ctx context.Context, days int) ([]structs.StockLot, error) {
+	var rs []rep_structs.StockLot
+	err := rep.db.SelectContext(ctx, &rs, `select `+lotFields+` from stock_lot
+		where amount > 0 and expires_at <= current_date + $1::int
+		order by expires_at, id_product, batch`, days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring lots: %w", err)
+	}
+	return toLots(rs), nil
+}
+
+func toLots(rs []rep_structs.StockLot) []structs.StockLot {
+	lots := make([]structs.StockLot, len(rs))
+	for i, r := range rs {
+		lots[i] = structs.StockLot{
+			Id:        r.Id,
+			IdProduct: r.IdProduct,
+			IdVariant: r.IdVariant.UUID,
+			Batch:     r.Batch,
+			ExpiresAt: r.ExpiresAt,
+			Amount:    r.Amount,
+			CreatedAt: r.CreatedAt,
+		}
+	}
+	return lots
+}
+
+func toMovement(r rep_structs.StockMovement) structs.StockMovement {
+	return structs.StockMovement{
+		Id:        r.Id,
+		IdProduct: r.IdProduct,
+		IdVariant: r.IdVariant.UUID,
+		IdLot:     r.IdLot.UUID,
+		Kind:      r.Kind,
+		Quantity:  r.Quantity,
+		Balance:   r.Balance,
+		Reason:    r.Reason,
+		IdActor:   r.IdActor.UUID,
+		Reference: r.Reference,
+		CreatedAt: r.CreatedAt,
+	}
+}
@@ -0,0 +1,99 @@
+package stock_rep
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+var errTest = errors.New("test error")
+
+var (
+	movementColumns = []string{"id", "id_product", "id_variant", "id_lot", "kind", "quantity", "balance", "reason",
+		"id_actor", "reference", "created_at"}
+	lotColumns = []string{"id", "id_product", "id_variant", "batch", "expires_at", "amount", "created_at"}
+)
+
+type TestFixture struct {
+	t        *testing.T
+	db       *sql.DB
+	sqlxDB   *sqlx.DB
+	mock     sqlmock.Sqlmock
+	repo     *Repository
+	ctx      context.Context
+	movement structs.StockMovement
+	lot      structs.StockLot
+}
+
+func NewTestFixture(t *testing.T) *TestFixture {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	id_product := structs.GenId()
+	id_variant := structs.GenId()
+	id_lot := structs.GenId()
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	return &TestFixture{
+		t:      t,
+		db:     db,
+		sqlxDB: sqlxDB,
+		mock:   mock,
+		repo:   New(sqlxDB),
+		ctx:    context.Background(),
+		movement: structs.StockMovement{
+			Id:        structs.GenId(),
+			IdProduct: id_product,
+			IdVariant: id_variant,
+			IdLot:     id_lot,
+			Kind:      structs.StockReceipt,
+			Quantity:  12,
+			Balance:   20,
+			Reason:    "поставка",
+			IdActor:   structs.GenId(),
+			Reference: "ТН-1042",
+			CreatedAt: created,
+		},
+		lot: structs.StockLot{
+			Id:        id_lot,
+			IdProduct: id_product,
+			IdVariant: id_variant,
+			Batch:     "L2403A",
+			ExpiresAt: time.Date(2027, 9, 30, 0, 0, 0, 0, time.UTC),
+			Amount:    12,
+			CreatedAt: created,
+		},
+	}
+}
+
+func (f *TestFixture) movementRow(rows *sqlmock.Rows) *sqlmock.Rows {
+	m := f.movement
+	return rows.AddRow(m.Id, m.IdProduct, m.IdVariant, m.IdLot, m.Kind, m.Quantity, m.Balance, m.Reason,
+		m.IdActor, m.Reference, m.CreatedAt)
+}
+
+func (f *TestFixture) lotRow(rows *sqlmock.Rows) *sqlmock.Rows {
+	l := f.lot
+	return rows.AddRow(l.Id, l.IdProduct, l.IdVariant, l.Batch, l.ExpiresAt, l.Amount, l.CreatedAt)
+}
+
+func (f *TestFixture) AssertError(actual, expected error) {
+	if expected == nil {
+		assert.NoError(f.t, actual)
+	} else {
+		assert.ErrorContains(f.t, actual, expected.Error())
+	}
+}
+
+func (f *TestFixture) Cleanup() {
+	f.db.Close()
+}
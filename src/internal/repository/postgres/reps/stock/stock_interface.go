@@ -0,0 +1,16 @@
+package stock_rep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+)
+
+type StockRepositoryInterface interface {
+	Create(ctx context.Context, m structs.StockMovement) (structs.StockMovement, error)
+	GetByProduct(ctx context.Context, id_product uuid.UUID) ([]structs.StockMovement, error)
+	CreateLot(ctx context.Context, lot structs.StockLot) (uuid.UUID, error)
+	GetLots(ctx context.Context, id_product uuid.UUID) ([]structs.StockLot, error)
+	GetExpiring(ctx context.Context, days int) ([]structs.StockLot, error)
+}
@@ -0,0 +1,290 @@
+package stock_rep
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	structs "github.com/taucuya/ppo/internal/core/structs"
+	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
+)
+
+func TestCreate_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	m := fixture.movement
+
+	expectInsert := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into stock_movement \(id_product, id_variant, id_lot, kind, quantity, reason, id_actor, reference\) .* returning id, id_product, id_variant, id_lot, kind, quantity, balance`).
+			WithArgs(m.IdProduct, rep_structs.NullId(m.IdVariant), rep_structs.NullId(m.IdLot), m.Kind, m.Quantity,
+				m.Reason, rep_structs.NullId(m.IdActor), m.Reference)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet structs.StockMovement
+		expectedErr error
+	}{
+		{
+			name: "successful creation",
+			setupMock: func() {
+				expectInsert().WillReturnRows(fixture.movementRow(sqlmock.NewRows(movementColumns)))
+			},
+			expectedRet: m,
+			expectedErr: nil,
+		},
+		{
+			name: "unknown variant",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_stock_movement_variant"})
+			},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrVariantNotFound,
+		},
+		{
+			name: "stock would go negative",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23514", Constraint: "product_variant_amount_check"})
+			},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrInsufficientStock,
+		},
+		{
+			name: "lot of another product",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_stock_movement_lot"})
+			},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrLotNotFound,
+		},
+		{
+			name: "stock held in lots",
+			setupMock: func() {
+				expectInsert().WillReturnError(&pq.Error{Code: "23514", Constraint: "stock_lot_untracked_check"})
+			},
+			expectedRet: structs.StockMovement{},
+			expectedErr: structs.ErrLotRequired,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectInsert().WillReturnError(errTest)
+			},
+			expectedRet: structs.StockMovement{},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.Create(fixture.ctx, m)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetByProduct_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id_product := fixture.movement.IdProduct
+
+	expectSelect := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`select id, id_product, .* from stock_movement where id_product = \$1 order by created_at desc, id`).
+			WithArgs(id_product)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.StockMovement
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMock: func() {
+				expectSelect().WillReturnRows(fixture.movementRow(sqlmock.NewRows(movementColumns)))
+			},
+			expectedRet: []structs.StockMovement{fixture.movement},
+			expectedErr: nil,
+		},
+		{
+			name: "no movements yet",
+			setupMock: func() {
+				expectSelect().WillReturnRows(sqlmock.NewRows(movementColumns))
+				fixture.mock.ExpectQuery(`select exists \(select 1 from product where id = \$1\)`).
+					WithArgs(id_product).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			expectedRet: []structs.StockMovement{},
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupMock: func() {
+				expectSelect().WillReturnRows(sqlmock.NewRows(movementColumns))
+				fixture.mock.ExpectQuery(`select exists \(select 1 from product where id = \$1\)`).
+					WithArgs(id_product).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrProductNotFound,
+		},
+		{
+			name: "database error",
+			setupMock: func() {
+				expectSelect().WillReturnError(errTest)
+			},
+			expectedRet: nil,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetByProduct(fixture.ctx, id_product)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestCreateLot_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	l := fixture.lot
+	other := l
+	other.ExpiresAt = l.ExpiresAt.AddDate(0, 1, 0)
+
+	expectUpsert := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`insert into stock_lot \(id_product, id_variant, batch, expires_at\) .* on conflict on constraint stock_lot_batch_unique do update`).
+			WithArgs(l.IdProduct, rep_structs.NullId(l.IdVariant), l.Batch, l.ExpiresAt)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet uuid.UUID
+		expectedErr error
+	}{
+		{
+			name: "new or known batch",
+			setupMock: func() {
+				expectUpsert().WillReturnRows(fixture.lotRow(sqlmock.NewRows(lotColumns)))
+			},
+			expectedRet: l.Id,
+			expectedErr: nil,
+		},
+		{
+			name: "batch with another expiry date",
+			setupMock: func() {
+				expectUpsert().WillReturnRows(sqlmock.NewRows(lotColumns).
+					AddRow(l.Id, l.IdProduct, l.IdVariant, l.Batch, other.ExpiresAt, l.Amount, l.CreatedAt))
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrLotExpiryMismatch,
+		},
+		{
+			name: "unknown variant",
+			setupMock: func() {
+				expectUpsert().WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_stock_lot_variant"})
+			},
+			expectedRet: uuid.Nil,
+			expectedErr: structs.ErrVariantNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.CreateLot(fixture.ctx, l)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetLots_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+	id_product := fixture.lot.IdProduct
+
+	expectSelect := func() *sqlmock.ExpectedQuery {
+		return fixture.mock.ExpectQuery(`select .* from stock_lot where id_product = \$1 and amount > 0 order by expires_at, batch`).
+			WithArgs(id_product)
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedRet []structs.StockLot
+		expectedErr error
+	}{
+		{
+			name: "successful get",
+			setupMock: func() {
+				expectSelect().WillReturnRows(fixture.lotRow(sqlmock.NewRows(lotColumns)))
+			},
+			expectedRet: []structs.StockLot{fixture.lot},
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupMock: func() {
+				expectSelect().WillReturnRows(sqlmock.NewRows(lotColumns))
+				fixture.mock.ExpectQuery(`select exists \(select 1 from product where id = \$1\)`).
+					WithArgs(id_product).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			expectedRet: nil,
+			expectedErr: structs.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			ret, err := fixture.repo.GetLots(fixture.ctx, id_product)
+
+			fixture.AssertError(err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRet, ret)
+			require.NoError(t, fixture.mock.ExpectationsWereMet())
+		})
+	}
+	fixture.Cleanup()
+}
+
+func TestGetExpiring_AAA(t *testing.T) {
+	t.Parallel()
+	fixture := NewTestFixture(t)
+
+	fixture.mock.ExpectQuery(`select .* from stock_lot where amount > 0 and expires_at <= current_date \+ \$1::int`).
+		WithArgs(30).
+		WillReturnRows(fixture.lotRow(sqlmock.NewRows(lotColumns)))
+
+	ret, err := fixture.repo.GetExpiring(fixture.ctx, 30)
+
+	require.NoError(t, err)
+	assert.Equal(t, []structs.StockLot{fixture.lot}, ret)
+	require.NoError(t, fixture.mock.ExpectationsWereMet())
+	fixture.Cleanup()
+}
@@ -2,10 +2,12 @@ package worker_rep
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	structs "github.com/taucuya/ppo/internal/core/structs"
 	rep_structs "github.com/taucuya/ppo/internal/repository/postgres/structs"
 )
@@ -61,12 +63,16 @@ func (rep *Repository) GetOrders(ctx context.Context, id uuid.UUID) ([]structs.O
 	var ords []structs.Order
 	for _, v := range o {
 		ords = append(ords, structs.Order{
-			Id:      v.Id,
-			Date:    v.Date,
-			IdUser:  v.IdUser,
-			Address: v.Address,
-			Status:  v.Status,
-			Price:   v.Price,
+			Id:           v.Id,
+			Date:         v.Date,
+			IdUser:       v.IdUser,
+			Address:      v.Address,
+			Status:       structs.OrderStatus(v.Status),
+			Price:        v.Price,
+			IdDelivery:   v.IdDelivery.UUID,
+			Zone:         v.Zone,
+			IdSlot:       v.IdSlot.UUID,
+			ShippingCost: v.ShippingCost,
 		})
 	}
 
@@ -112,9 +118,16 @@ func (rep *Repository) AcceptOrder(ctx context.Context, id_order uuid.UUID, id_u
 	// }
 
 	_, err := rep.db.ExecContext(ctx, `insert into order_worker (id_order, id_worker) values ($1, $2)`, id_order, id_user)
-	// if err != nil {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Constraint {
+		case "fk_order":
+			return structs.ErrOrderNotFound
+		case "order_worker_status_check":
+			return structs.ErrIllegalOrderTransition
+		}
+	}
 	return err
-	// }
 
 	// _, err = rep.db.ExecContext(ctx, `update "order" set status = $1 where id = $2`, "принятый", id_order)
 	// return err
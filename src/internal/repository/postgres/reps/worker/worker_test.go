@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	structs "github.com/taucuya/ppo/internal/core/structs"
@@ -348,6 +349,24 @@ func TestAcceptOrder(t *testing.T) {
 			},
 			expectedErr: errTest,
 		},
+		{
+			name: "order not found",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`insert into order_worker \(id_order, id_worker\) values \(\$1, \$2\)`).
+					WithArgs(testOrder.Id, testWorker.IdUser).
+					WillReturnError(&pq.Error{Code: "23503", Constraint: "fk_order"})
+			},
+			expectedErr: structs.ErrOrderNotFound,
+		},
+		{
+			name: "order is not new",
+			setupMock: func() {
+				fixture.mock.ExpectExec(`insert into order_worker \(id_order, id_worker\) values \(\$1, \$2\)`).
+					WithArgs(testOrder.Id, testWorker.IdUser).
+					WillReturnError(&pq.Error{Code: "23514", Constraint: "order_worker_status_check"})
+			},
+			expectedErr: structs.ErrIllegalOrderTransition,
+		},
 	}
 
 	for _, tt := range tests {
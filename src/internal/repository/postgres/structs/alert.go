@@ -0,0 +1,31 @@
+package structs
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockAlert struct {
+	Id              uuid.UUID    `db:"id"`
+	IdProduct       uuid.UUID    `db:"id_product"`
+	Name            string       `db:"name"`
+	Articule        string       `db:"art"`
+	Amount          int          `db:"amount"`
+	ReorderPoint    int          `db:"reorder_point"`
+	ReorderQuantity int          `db:"reorder_quantity"`
+	CreatedAt       time.Time    `db:"created_at"`
+	ResolvedAt      sql.NullTime `db:"resolved_at"`
+}
+
+type ReorderItem struct {
+	IdProduct       uuid.UUID     `db:"id_product"`
+	Name            string        `db:"name"`
+	Articule        string        `db:"art"`
+	IdBrand         uuid.NullUUID `db:"id_brand"`
+	Brand           string        `db:"brand"`
+	Amount          int           `db:"amount"`
+	ReorderPoint    int           `db:"reorder_point"`
+	ReorderQuantity int           `db:"reorder_quantity"`
+}
@@ -0,0 +1,28 @@
+package structs
+
+import "github.com/google/uuid"
+
+type Ingredient struct {
+	Id       uuid.UUID `db:"id"`
+	InciName string    `db:"inci_name"`
+	Name     string    `db:"name"`
+}
+
+type Attribute struct {
+	Id   uuid.UUID `db:"id"`
+	Kind string    `db:"kind"`
+	Code string    `db:"code"`
+	Name string    `db:"name"`
+}
+
+type ProductProperties struct {
+	IdProduct   uuid.UUID `db:"id_product"`
+	Spf         int       `db:"spf"`
+	Vegan       bool      `db:"vegan"`
+	CrueltyFree bool      `db:"cruelty_free"`
+}
+
+type ProductIngredient struct {
+	IdProduct uuid.UUID `db:"id_product"`
+	Ingredient
+}
@@ -7,10 +7,11 @@ import (
 )
 
 type BasketItem struct {
-	Id        uuid.UUID `db:"id"`
-	IdProduct uuid.UUID `db:"id_product"`
-	IdBasket  uuid.UUID `db:"id_basket"`
-	Amount    int       `db:"amount"`
+	Id        uuid.UUID     `db:"id"`
+	IdProduct uuid.UUID     `db:"id_product"`
+	IdVariant uuid.NullUUID `db:"id_variant"`
+	IdBasket  uuid.UUID     `db:"id_basket"`
+	Amount    int           `db:"amount"`
 }
 
 type Basket struct {
@@ -0,0 +1,24 @@
+package structs
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type CatalogExportItem struct {
+	Id              uuid.UUID      `db:"id"`
+	IdVariant       uuid.NullUUID  `db:"id_variant"`
+	Articule        string         `db:"art"`
+	ProductArticule string         `db:"product_art"`
+	Name            string         `db:"name"`
+	Description     string         `db:"description"`
+	Price           float64        `db:"price"`
+	Category        string         `db:"category"`
+	IdCategory      uuid.NullUUID  `db:"id_category"`
+	Shade           string         `db:"shade"`
+	VolumeMl        int            `db:"volume_ml"`
+	Available       int            `db:"available"`
+	Brand           string         `db:"brand"`
+	PicLink         string         `db:"pic_link"`
+	Images          pq.StringArray `db:"images"`
+}
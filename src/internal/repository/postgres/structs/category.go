@@ -0,0 +1,11 @@
+package structs
+
+import "github.com/google/uuid"
+
+type Category struct {
+	Id        uuid.UUID     `db:"id"`
+	IdParent  uuid.NullUUID `db:"id_parent"`
+	Name      string        `db:"name"`
+	Slug      string        `db:"slug"`
+	SortOrder int           `db:"sort_order"`
+}
@@ -0,0 +1,33 @@
+package structs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryMethod struct {
+	Id       uuid.UUID `db:"id"`
+	Kind     string    `db:"kind"`
+	Name     string    `db:"name"`
+	FreeFrom float64   `db:"free_from"`
+	Active   bool      `db:"active"`
+}
+
+type DeliveryRate struct {
+	IdDelivery uuid.UUID `db:"id_delivery"`
+	Zone       string    `db:"zone"`
+	MaxWeight  int       `db:"max_weight"`
+	MinTotal   float64   `db:"min_total"`
+	Cost       float64   `db:"cost"`
+	PerKg      float64   `db:"per_kg"`
+}
+
+type DeliverySlot struct {
+	Id         uuid.UUID `db:"id"`
+	IdDelivery uuid.UUID `db:"id_delivery"`
+	StartsAt   time.Time `db:"starts_at"`
+	EndsAt     time.Time `db:"ends_at"`
+	Capacity   int       `db:"capacity"`
+	Taken      int       `db:"taken"`
+}
@@ -0,0 +1,8 @@
+package structs
+
+import "github.com/google/uuid"
+
+// NullId maps uuid.Nil, used by the core structs for "not set", to SQL null.
+func NullId(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
@@ -0,0 +1,18 @@
+package structs
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IdempotencyRecord struct {
+	IdUser      uuid.UUID      `db:"id_user"`
+	Key         string         `db:"key"`
+	RequestHash string         `db:"request_hash"`
+	Status      sql.NullInt32  `db:"status"`
+	ContentType sql.NullString `db:"content_type"`
+	Body        []byte         `db:"body"`
+	ExpiresAt   time.Time      `db:"expires_at"`
+}
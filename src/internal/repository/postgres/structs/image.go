@@ -0,0 +1,18 @@
+package structs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductImage struct {
+	Id          uuid.UUID `db:"id"`
+	IdProduct   uuid.UUID `db:"id_product"`
+	Position    int       `db:"position"`
+	IsPrimary   bool      `db:"is_primary"`
+	ContentType string    `db:"content_type"`
+	Size        int64     `db:"size"`
+	Key         string    `db:"storage_key"`
+	CreatedAt   time.Time `db:"created_at"`
+}
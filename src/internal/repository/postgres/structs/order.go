@@ -1,23 +1,79 @@
 package structs
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type OrderItem struct {
-	Id        uuid.UUID `db:"id"`
-	IdProduct uuid.UUID `db:"id_product"`
-	IdOrder   uuid.UUID `db:"id_order"`
-	Amount    int       `db:"amount"`
+	Id        uuid.UUID     `db:"id"`
+	IdProduct uuid.NullUUID `db:"id_product"`
+	IdVariant uuid.NullUUID `db:"id_variant"`
+	IdOrder   uuid.UUID     `db:"id_order"`
+	Amount    int           `db:"amount"`
+	Price     float64       `db:"price"`
+	Discount  float64       `db:"discount"`
+	Name      string        `db:"name"`
+	Art       string        `db:"art"`
+	Brand     string        `db:"brand"`
+}
+
+type OrderItemLot struct {
+	IdOrderItem uuid.UUID `db:"id_order_item"`
+	IdLot       uuid.UUID `db:"id_lot"`
+	Batch       string    `db:"batch"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	Amount      int       `db:"amount"`
 }
 
 type Order struct {
-	Id      uuid.UUID `db:"id"`
-	Date    time.Time `db:"date"`
-	IdUser  uuid.UUID `db:"id_user"`
-	Address string    `db:"address"`
-	Status  string    `db:"status"`
-	Price   float64   `db:"price"`
+	Id           uuid.UUID     `db:"id"`
+	Date         time.Time     `db:"date"`
+	IdUser       uuid.UUID     `db:"id_user"`
+	Address      string        `db:"address"`
+	Status       string        `db:"status"`
+	Price        float64       `db:"price"`
+	IdDelivery   uuid.NullUUID `db:"id_delivery"`
+	Zone         string        `db:"zone"`
+	IdSlot       uuid.NullUUID `db:"id_slot"`
+	ShippingCost float64       `db:"shipping_cost"`
+}
+
+type OrderStatusChange struct {
+	Id        uuid.UUID      `db:"id"`
+	IdOrder   uuid.UUID      `db:"id_order"`
+	From      sql.NullString `db:"from_status"`
+	To        string         `db:"to_status"`
+	IdActor   uuid.NullUUID  `db:"id_actor"`
+	Role      string         `db:"role"`
+	Comment   string         `db:"comment"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+type OrderSummary struct {
+	Id            uuid.UUID     `db:"id"`
+	Date          time.Time     `db:"date"`
+	IdUser        uuid.UUID     `db:"id_user"`
+	Address       string        `db:"address"`
+	Status        string        `db:"status"`
+	Price         float64       `db:"price"`
+	CustomerName  string        `db:"customer_name"`
+	CustomerMail  string        `db:"customer_mail"`
+	CustomerPhone string        `db:"customer_phone"`
+	IdWorker      uuid.NullUUID `db:"id_worker"`
+}
+
+type OrderExportRow struct {
+	OrderSummary
+	IdItem    uuid.NullUUID `db:"id_item"`
+	IdProduct uuid.NullUUID `db:"id_product"`
+	IdVariant uuid.NullUUID `db:"id_variant"`
+	Amount    int           `db:"amount"`
+	ItemPrice float64       `db:"item_price"`
+	Discount  float64       `db:"discount"`
+	Name      string        `db:"name"`
+	Art       string        `db:"art"`
+	Brand     string        `db:"brand"`
 }
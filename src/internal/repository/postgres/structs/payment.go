@@ -0,0 +1,33 @@
+package structs
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Payment struct {
+	Id        uuid.UUID      `db:"id"`
+	IdOrder   uuid.UUID      `db:"id_order"`
+	IdUser    uuid.UUID      `db:"id_user"`
+	Provider  string         `db:"provider"`
+	IntentId  sql.NullString `db:"intent_id"`
+	Amount    float64        `db:"amount"`
+	Status    string         `db:"status"`
+	Error     string         `db:"error"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+type Refund struct {
+	Id          uuid.UUID     `db:"id"`
+	IdReturn    uuid.NullUUID `db:"id_return"`
+	IdOrder     uuid.UUID     `db:"id_order"`
+	IdPayment   uuid.NullUUID `db:"id_payment"`
+	Amount      float64       `db:"amount"`
+	Status      string        `db:"status"`
+	Error       string        `db:"error"`
+	CreatedAt   time.Time     `db:"created_at"`
+	ProcessedAt sql.NullTime  `db:"processed_at"`
+}
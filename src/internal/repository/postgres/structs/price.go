@@ -0,0 +1,16 @@
+package structs
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductPrice struct {
+	Id        uuid.UUID    `db:"id"`
+	IdProduct uuid.UUID    `db:"id_product"`
+	Price     float64      `db:"price"`
+	ValidFrom time.Time    `db:"valid_from"`
+	ValidTo   sql.NullTime `db:"valid_to"`
+}
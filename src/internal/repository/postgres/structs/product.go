@@ -3,13 +3,34 @@ package structs
 import "github.com/google/uuid"
 
 type Product struct {
-	Id          uuid.UUID `db:"id"`
-	Name        string    `db:"name"`
-	Description string    `db:"description"`
-	Price       float64   `db:"price"`
-	Category    string    `db:"category"`
-	Amount      int       `db:"amount"`
-	IdBrand     uuid.UUID `db:"id_brand"`
-	PicLink     string    `db:"pic_link"`
-	Articule    string    `db:"art"`
+	Id          uuid.UUID     `db:"id"`
+	Name        string        `db:"name"`
+	Description string        `db:"description"`
+	Price       float64       `db:"price"`
+	IdCategory  uuid.NullUUID `db:"id_category"`
+	Amount      int           `db:"amount"`
+	IdBrand     uuid.UUID     `db:"id_brand"`
+	PicLink     string        `db:"pic_link"`
+	Articule    string        `db:"art"`
+	Weight      int           `db:"weight"`
+	RatingAvg   float64       `db:"rating_avg"`
+	RatingCount int           `db:"rating_count"`
+	Stars1      int           `db:"stars_1"`
+	Stars2      int           `db:"stars_2"`
+	Stars3      int           `db:"stars_3"`
+	Stars4      int           `db:"stars_4"`
+	Stars5      int           `db:"stars_5"`
+	Available   int           `db:"available"`
+}
+
+type ProductVariant struct {
+	Id        uuid.UUID `db:"id"`
+	IdProduct uuid.UUID `db:"id_product"`
+	Articule  string    `db:"art"`
+	Shade     string    `db:"shade"`
+	VolumeMl  int       `db:"volume_ml"`
+	Weight    int       `db:"weight"`
+	Price     float64   `db:"price"`
+	Amount    int       `db:"amount"`
+	Available int       `db:"available"`
 }
@@ -0,0 +1,35 @@
+package structs
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Promotion struct {
+	Id           uuid.UUID      `db:"id"`
+	Name         string         `db:"name"`
+	Kind         string         `db:"kind"`
+	Code         sql.NullString `db:"code"`
+	Value        float64        `db:"value"`
+	BuyQuantity  int            `db:"buy_quantity"`
+	FreeQuantity int            `db:"free_quantity"`
+	IdBrand      uuid.NullUUID  `db:"id_brand"`
+	IdCategory   uuid.NullUUID  `db:"id_category"`
+	MinBasket    float64        `db:"min_basket"`
+	UsageLimit   int            `db:"usage_limit"`
+	PerUserLimit int            `db:"per_user_limit"`
+	StartsAt     sql.NullTime   `db:"starts_at"`
+	EndsAt       sql.NullTime   `db:"ends_at"`
+	Active       bool           `db:"active"`
+}
+
+type CheckoutLine struct {
+	IdProduct  uuid.UUID      `db:"id_product"`
+	IdVariant  uuid.NullUUID  `db:"id_variant"`
+	IdBrand    uuid.NullUUID  `db:"id_brand"`
+	Categories pq.StringArray `db:"categories"`
+	UnitPrice  float64        `db:"unit_price"`
+	Amount     int            `db:"amount"`
+}
@@ -0,0 +1,43 @@
+package structs
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Supplier struct {
+	Id     uuid.UUID      `db:"id"`
+	Name   string         `db:"name"`
+	Email  string         `db:"email"`
+	Phone  string         `db:"phone"`
+	Brands pq.StringArray `db:"brands"`
+}
+
+type PurchaseOrder struct {
+	Id         uuid.UUID    `db:"id"`
+	IdSupplier uuid.UUID    `db:"id_supplier"`
+	Status     string       `db:"status"`
+	ExpectedAt sql.NullTime `db:"expected_at"`
+	Note       string       `db:"note"`
+	CreatedAt  time.Time    `db:"created_at"`
+}
+
+type PurchaseLine struct {
+	Id        uuid.UUID     `db:"id"`
+	IdProduct uuid.UUID     `db:"id_product"`
+	IdVariant uuid.NullUUID `db:"id_variant"`
+	Ordered   int           `db:"ordered"`
+	Received  int           `db:"received"`
+}
+
+type PurchaseDiscrepancy struct {
+	Id         uuid.UUID `db:"id"`
+	IdLine     uuid.UUID `db:"id_line"`
+	Ordered    int       `db:"ordered"`
+	Received   int       `db:"received"`
+	Difference int       `db:"difference"`
+	CreatedAt  time.Time `db:"created_at"`
+}
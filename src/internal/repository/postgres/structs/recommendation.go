@@ -0,0 +1,8 @@
+package structs
+
+type Recommendation struct {
+	Product
+	Support    int     `db:"support"`
+	Confidence float64 `db:"confidence"`
+	Lift       float64 `db:"lift"`
+}
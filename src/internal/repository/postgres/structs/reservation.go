@@ -0,0 +1,16 @@
+package structs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Reservation struct {
+	Id        uuid.UUID     `db:"id"`
+	IdUser    uuid.UUID     `db:"id_user"`
+	IdProduct uuid.UUID     `db:"id_product"`
+	IdVariant uuid.NullUUID `db:"id_variant"`
+	Amount    int           `db:"amount"`
+	ExpiresAt time.Time     `db:"expires_at"`
+}
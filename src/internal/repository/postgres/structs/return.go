@@ -0,0 +1,39 @@
+package structs
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReturnRequest struct {
+	Id         uuid.UUID    `db:"id"`
+	IdOrder    uuid.UUID    `db:"id_order"`
+	IdUser     uuid.UUID    `db:"id_user"`
+	Status     string       `db:"status"`
+	Reason     string       `db:"reason"`
+	Comment    string       `db:"comment"`
+	CreatedAt  time.Time    `db:"created_at"`
+	DecidedAt  sql.NullTime `db:"decided_at"`
+	ReceivedAt sql.NullTime `db:"received_at"`
+}
+
+type ReturnLine struct {
+	Id          uuid.UUID     `db:"id"`
+	IdOrderItem uuid.UUID     `db:"id_order_item"`
+	IdProduct   uuid.NullUUID `db:"id_product"`
+	IdVariant   uuid.NullUUID `db:"id_variant"`
+	Amount      int           `db:"amount"`
+	Received    int           `db:"received"`
+	Condition   string        `db:"condition"`
+}
+
+type ReturnPhoto struct {
+	Id          uuid.UUID `db:"id"`
+	IdReturn    uuid.UUID `db:"id_return"`
+	ContentType string    `db:"content_type"`
+	Size        int64     `db:"size"`
+	Key         string    `db:"key"`
+	CreatedAt   time.Time `db:"created_at"`
+}
@@ -0,0 +1,31 @@
+package structs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockMovement struct {
+	Id        uuid.UUID     `db:"id"`
+	IdProduct uuid.UUID     `db:"id_product"`
+	IdVariant uuid.NullUUID `db:"id_variant"`
+	IdLot     uuid.NullUUID `db:"id_lot"`
+	Kind      string        `db:"kind"`
+	Quantity  int           `db:"quantity"`
+	Balance   int           `db:"balance"`
+	Reason    string        `db:"reason"`
+	IdActor   uuid.NullUUID `db:"id_actor"`
+	Reference string        `db:"reference"`
+	CreatedAt time.Time     `db:"created_at"`
+}
+
+type StockLot struct {
+	Id        uuid.UUID     `db:"id"`
+	IdProduct uuid.UUID     `db:"id_product"`
+	IdVariant uuid.NullUUID `db:"id_variant"`
+	Batch     string        `db:"batch"`
+	ExpiresAt time.Time     `db:"expires_at"`
+	Amount    int           `db:"amount"`
+	CreatedAt time.Time     `db:"created_at"`
+}
@@ -17,7 +17,22 @@ func CreateOrder(client *http.Client, reader *bufio.Reader) {
 	address, _ := reader.ReadString('\n')
 	address = strings.TrimSpace(address)
 
-	payload := map[string]string{"address": address}
+	fmt.Print("Enter delivery method ID: ")
+	idDelivery, _ := reader.ReadString('\n')
+	idDelivery = strings.TrimSpace(idDelivery)
+
+	fmt.Print("Enter delivery zone: ")
+	zone, _ := reader.ReadString('\n')
+	zone = strings.TrimSpace(zone)
+
+	fmt.Print("Enter delivery slot ID (empty for none): ")
+	idSlot, _ := reader.ReadString('\n')
+	idSlot = strings.TrimSpace(idSlot)
+
+	payload := map[string]string{"address": address, "id_delivery": idDelivery, "zone": zone}
+	if idSlot != "" {
+		payload["id_slot"] = idSlot
+	}
 	body, _ := json.Marshal(payload)
 
 	req, _ := http.NewRequest("POST", "http://localhost:8080/api/v1/orders", bytes.NewBuffer(body))
@@ -20,7 +20,7 @@ func CreateProduct(client *http.Client, reader *bufio.Reader) {
 	fmt.Print("Articule: ")
 	articule, _ := reader.ReadString('\n')
 
-	fmt.Print("Category: ")
+	fmt.Print("Category ID: ")
 	category, _ := reader.ReadString('\n')
 
 	fmt.Print("Brand ID: ")
@@ -45,7 +45,7 @@ func CreateProduct(client *http.Client, reader *bufio.Reader) {
 		"name":        strings.TrimSpace(name),
 		"description": strings.TrimSpace(description),
 		"articule":    strings.TrimSpace(articule),
-		"category":    strings.TrimSpace(category),
+		"id_category": strings.TrimSpace(category),
 		"id_brand":    strings.TrimSpace(brand),
 		"price":       strings.TrimSpace(price),
 		"amount":      amountInt,
@@ -135,7 +135,7 @@ func GetProductById(client *http.Client, reader *bufio.Reader) {
 		fmt.Printf("Name:        %v\n", product["Name"])
 		fmt.Printf("Description: %v\n", product["Description"])
 		fmt.Printf("Price:       %.2f\n", product["Price"])
-		fmt.Printf("Category:    %v\n", product["Category"])
+		fmt.Printf("Category ID: %v\n", product["IdCategory"])
 		fmt.Printf("Amount:      %v\n", product["Amount"])
 		fmt.Printf("Brand ID:    %v\n", product["IdBrand"])
 		fmt.Printf("Articule:    %v\n", product["Articule"])
@@ -174,7 +174,7 @@ func GetProductByArticule(client *http.Client, reader *bufio.Reader) {
 		fmt.Printf("Name:        %v\n", product["Name"])
 		fmt.Printf("Description: %v\n", product["Description"])
 		fmt.Printf("Price:       %.2f\n", product["Price"])
-		fmt.Printf("Category:    %v\n", product["Category"])
+		fmt.Printf("Category ID: %v\n", product["IdCategory"])
 		fmt.Printf("Amount:      %v\n", product["Amount"])
 		fmt.Printf("Brand ID:    %v\n", product["IdBrand"])
 		fmt.Printf("Articule:    %v\n", product["Articule"])
@@ -187,7 +187,7 @@ func GetProductByArticule(client *http.Client, reader *bufio.Reader) {
 }
 
 func GetProductsByCategory(client *http.Client, reader *bufio.Reader) {
-	fmt.Print("Enter Category: ")
+	fmt.Print("Enter Category (slug or ID): ")
 	category, _ := reader.ReadString('\n')
 	category = strings.TrimSpace(category)
 